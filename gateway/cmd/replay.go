@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"golang.org/x/exp/slog"
+)
+
+var (
+	replayMqttAddr        string
+	replayClientId        string
+	replaySpeed           float64
+	replayResponseTimeout time.Duration
+	replayOutput          string
+)
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay <capture-file>...",
+	Short: "Replay a charge station capture against the manager",
+	Long: `Replays the calls that a charge station made in one or more capture
+files (as written by serve --capture-dir) to the manager via the MQTT
+broker and compares the manager's responses with the captured ones.
+The capture files are replayed in the order given, so rotated files
+should be listed oldest first.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		brokerUrl, err := url.Parse(replayMqttAddr)
+		if err != nil {
+			return fmt.Errorf("parsing mqtt broker url: %v", err)
+		}
+
+		var frames []server.CapturedFrame
+		for _, path := range args {
+			fileFrames, err := readCaptureFile(path)
+			if err != nil {
+				return err
+			}
+			frames = append(frames, fileFrames...)
+		}
+
+		var output io.Writer = os.Stdout
+		if replayOutput != "" && replayOutput != "-" {
+			//#nosec G304 - only files specified by the person running the application will be written
+			f, err := os.Create(replayOutput)
+			if err != nil {
+				return fmt.Errorf("creating replay output %s: %v", replayOutput, err)
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			output = f
+		}
+
+		replayer := server.NewReplayer(brokerUrl,
+			server.WithReplayTopicPrefix("cs"),
+			server.WithReplayClientId(replayClientId),
+			server.WithReplaySpeed(replaySpeed),
+			server.WithReplayResponseTimeout(replayResponseTimeout),
+			server.WithReplayOutput(output))
+
+		summary, err := replayer.Replay(cmd.Context(), frames)
+		if err != nil {
+			return err
+		}
+		slog.Info("replay complete",
+			"calls", summary.Calls,
+			"matched", summary.Matched,
+			"timedOut", summary.TimedOut,
+			"unsolicited", summary.Unsolicited)
+		return nil
+	},
+}
+
+func readCaptureFile(path string) ([]server.CapturedFrame, error) {
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening capture file %s: %v", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	frames, err := server.ReadCapture(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading capture file %s: %v", path, err)
+	}
+	return frames, nil
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().StringVarP(&replayMqttAddr, "mqtt-addr", "m", "mqtt://127.0.0.1:1883",
+		"The address of the MQTT broker, e.g. mqtt://127.0.0.1:1883")
+	replayCmd.Flags().StringVar(&replayClientId, "cs-id", "",
+		"Replay the capture as if it came from the charge station with this id (default the captured charge station)")
+	replayCmd.Flags().Float64Var(&replaySpeed, "speed", 0,
+		"Replay the calls at this multiple of the captured speed (0 replays each call as soon as the previous call is answered)")
+	replayCmd.Flags().DurationVar(&replayResponseTimeout, "response-timeout", 10*time.Second,
+		"How long to wait for the manager to answer each call")
+	replayCmd.Flags().StringVar(&replayOutput, "output", "",
+		"A file where the result of each replayed call is written as a line of JSON (default stdout)")
+}
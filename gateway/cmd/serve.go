@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/subnova/slog-exporter/slogtrace"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
 	"github.com/thoughtworks/maeve-csms/gateway/registry"
 	"github.com/thoughtworks/maeve-csms/gateway/server"
 	"go.opentelemetry.io/contrib/detectors/gcp"
@@ -23,14 +24,52 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"net/url"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 )
 
+// drainGracePeriod is how long to wait, after the drain window, for the last charge
+// stations to complete the websocket closing handshake
+const drainGracePeriod = 10 * time.Second
+
 var (
 	mqttAddr          string
+	mqttPoolSize      int
+	mqttClientId      string
+	natsAddrs         []string
+	natsStream        string
+	outboundQueueLen  int
+	outboundSpillDir  string
+	outboundSpillLen  int
+	duplicatePolicy   string
+	wsPingInterval    time.Duration
+	wsPongTimeout     time.Duration
+	wsIdleTimeout     time.Duration
+	maxMessageSize    int64
+	rateLimits        []string
+	registryCacheTTL  time.Duration
+	registryNegTTL    time.Duration
+	registryFile      string
+	registryReload    time.Duration
+	crlFiles          []string
+	crlRefresh        time.Duration
+	ocspEnabled       bool
+	ocspTimeout       time.Duration
+	ocspCacheTTL      time.Duration
+	revocationMode    string
+	captureDir        string
+	captureStations   []string
+	captureMaxSize    int64
+	captureMaxFiles   int
+	drainWindow       time.Duration
+	csmsCallQueueLen  int
+	csmsCallMaxAge    time.Duration
 	wsAddr            string
 	wssAddr           string
 	statusAddr        string
+	adminTokenFile    string
 	tlsServerCert     string
 	tlsServerKey      string
 	tlsTrustCert      []string
@@ -136,17 +175,126 @@ var serveCmd = &cobra.Command{
 			return fmt.Errorf("parsing mqtt broker url: %v", err)
 		}
 
-		remoteRegistry := registry.RemoteRegistry{
+		if outboundSpillDir != "" {
+			err = os.MkdirAll(outboundSpillDir, 0700)
+			if err != nil {
+				return fmt.Errorf("creating outbound spill directory %s: %v", outboundSpillDir, err)
+			}
+		}
+
+		duplicateConnectionPolicy, err := server.ParseDuplicateConnectionPolicy(duplicatePolicy)
+		if err != nil {
+			return err
+		}
+
+		var deviceRegistry registry.DeviceRegistry = registry.RemoteRegistry{
 			ManagerApiAddr: managerApiAddr,
 		}
-		statusServer := server.New("status", statusAddr, nil, server.NewStatusHandler())
-		websocketHandler := server.NewWebsocketHandler(
+		if registryFile != "" {
+			fileRegistry, err := registry.NewFileRegistry(registryFile, registry.WithReloadInterval(registryReload))
+			if err != nil {
+				return err
+			}
+			go fileRegistry.Watch(cmd.Context())
+			deviceRegistry = fileRegistry
+		} else if registryCacheTTL > 0 {
+			deviceRegistry = registry.NewCachingRegistry(deviceRegistry,
+				registry.WithCacheTTL(registryCacheTTL),
+				registry.WithNegativeCacheTTL(registryNegTTL))
+		}
+		connections := server.NewConnectionTracker()
+		var capture *server.FrameCapture
+		if captureDir != "" {
+			capture, err = server.NewFrameCapture(captureDir,
+				server.WithCaptureStations(captureStations...),
+				server.WithCaptureMaxFileSize(captureMaxSize),
+				server.WithCaptureMaxFiles(captureMaxFiles))
+			if err != nil {
+				return err
+			}
+			defer capture.Close()
+		}
+		drainer := server.NewDrainer(connections, server.WithDrainWindow(drainWindow))
+		statusOpts := []server.StatusOpt{
+			server.WithDrainControl(drainer),
+		}
+		if adminTokenFile != "" {
+			//#nosec G304 - only files specified by the person running the application will be loaded
+			tb, err := os.ReadFile(adminTokenFile)
+			if err != nil {
+				return fmt.Errorf("reading admin token from %s: %v", adminTokenFile, err)
+			}
+			adminToken := strings.TrimSpace(string(tb))
+			if adminToken == "" {
+				return fmt.Errorf("admin token file %s is empty", adminTokenFile)
+			}
+			statusOpts = append(statusOpts, server.WithAdminApi(connections, adminToken))
+			if capture != nil {
+				statusOpts = append(statusOpts, server.WithCaptureControl(capture))
+			}
+		}
+		statusServer := server.New("status", statusAddr, nil, server.NewStatusHandler(statusOpts...))
+		websocketOpts := []server.WebsocketOpt{
+			server.WithConnectionTracker(connections),
+			server.WithDuplicateConnectionPolicy(duplicateConnectionPolicy),
+			server.WithPingInterval(wsPingInterval),
+			server.WithPongTimeout(wsPongTimeout),
+			server.WithIdleTimeout(wsIdleTimeout),
+			server.WithMaxMessageSize(maxMessageSize),
 			server.WithMqttBrokerUrl(brokerUrl),
 			server.WithMqttTopicPrefix("cs"),
-			server.WithDeviceRegistry(remoteRegistry),
+			server.WithMqttPoolSize(mqttPoolSize),
+			server.WithMqttClientIdPrefix(mqttClientId),
+			server.WithOutboundQueueLen(outboundQueueLen),
+			server.WithOutboundSpill(outboundSpillDir, outboundSpillLen),
+			server.WithDeviceRegistry(deviceRegistry),
 			server.WithOrgNames(orgNames),
+			server.WithDrainer(drainer),
+			server.WithPipeOptions([]pipe.Opt{
+				pipe.WithCSMSCallQueueLen(csmsCallQueueLen),
+				pipe.WithCSMSCallMaxAge(csmsCallMaxAge),
+			}),
 			server.WithTrustProxyHeaders(trustProxyHeaders),
-			server.WithOtelTracer(tracer))
+			server.WithOtelTracer(tracer),
+		}
+		if capture != nil {
+			websocketOpts = append(websocketOpts, server.WithFrameCapture(capture))
+		}
+		for _, rateLimit := range rateLimits {
+			actions, limit, err := server.ParseRateLimit(rateLimit)
+			if err != nil {
+				return err
+			}
+			websocketOpts = append(websocketOpts, server.WithRateLimit(limit, actions...))
+		}
+		if len(crlFiles) > 0 || ocspEnabled {
+			mode, err := server.ParseRevocationMode(revocationMode)
+			if err != nil {
+				return err
+			}
+			revocationOpts := []server.RevocationOpt{
+				server.WithCRLFiles(crlFiles...),
+				server.WithCRLRefreshInterval(crlRefresh),
+				server.WithIssuerCertificateFiles(tlsTrustCert...),
+				server.WithRevocationMode(mode),
+			}
+			if ocspEnabled {
+				revocationOpts = append(revocationOpts, server.WithOCSP(ocspTimeout, ocspCacheTTL))
+			}
+			revocationChecker, err := server.NewRevocationChecker(revocationOpts...)
+			if err != nil {
+				return err
+			}
+			go revocationChecker.Watch(cmd.Context())
+			websocketOpts = append(websocketOpts, server.WithRevocationChecker(revocationChecker))
+		}
+		for _, natsAddr := range natsAddrs {
+			websocketOpts = append(websocketOpts, server.WithNatsUrl(natsAddr))
+		}
+		if len(natsAddrs) > 0 {
+			websocketOpts = append(websocketOpts, server.WithNatsStream(natsStream))
+		}
+		websocketHandler := server.NewWebsocketHandler(websocketOpts...)
 		wsServer := server.New("ws", wsAddr, nil, websocketHandler)
 		var wssServer *server.Server
 
@@ -193,15 +341,48 @@ var serveCmd = &cobra.Command{
 			wssServer = server.New("wss", wssAddr, tlsConfig, websocketHandler)
 		}
 
-		errCh := make(chan error, 1)
+		// each of the ws, wss and status servers reports at most one error
+		errCh := make(chan error, 3)
 		wsServer.Start(errCh)
 		if wssServer != nil {
 			wssServer.Start(errCh)
 		}
 		statusServer.Start(errCh)
 
-		err = <-errCh
-		return err
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, os.Interrupt)
+		defer stop()
+		select {
+		case err = <-errCh:
+			websocketHandler.Close()
+			return err
+		case <-ctx.Done():
+		}
+
+		// close the charge station connections gradually so that they can be rebalanced
+		// across the other gateway instances, then stop the servers
+		slog.Info("shutting down", "drainWindow", drainWindow)
+		drainCtx, cancel := context.WithTimeout(context.Background(), drainWindow+drainGracePeriod)
+		defer cancel()
+		err = drainer.Drain(drainCtx)
+		if err != nil {
+			slog.Warn("connections still open after drain", "err", err)
+		}
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer stopCancel()
+		servers := []*server.Server{wsServer, statusServer}
+		if wssServer != nil {
+			servers = append(servers, wssServer)
+		}
+		for _, srv := range servers {
+			err := srv.Stop(stopCtx)
+			if err != nil {
+				slog.Warn("stopping server", "err", err)
+			}
+		}
+		// the connections have been drained, so nothing else is published to the broker
+		websocketHandler.Close()
+		return nil
 	},
 }
 
@@ -210,12 +391,74 @@ func init() {
 
 	serveCmd.Flags().StringVarP(&mqttAddr, "mqtt-addr", "m", "mqtt://127.0.0.1:1883",
 		"The address of the MQTT broker, e.g. mqtt://127.0.0.1:1883")
+	serveCmd.Flags().IntVar(&mqttPoolSize, "mqtt-pool-size", 1,
+		"The number of MQTT connections shared by all the connected charge stations")
+	serveCmd.Flags().StringVar(&mqttClientId, "mqtt-client-id-prefix", "",
+		"The prefix of the MQTT client ids used by the gateway, must be unique per gateway instance (default random)")
+	serveCmd.Flags().StringSliceVar(&natsAddrs, "nats-addr", nil,
+		"The addresses of the NATS servers, e.g. nats://127.0.0.1:4222: when set NATS JetStream is used instead of MQTT")
+	serveCmd.Flags().StringVar(&natsStream, "nats-stream", "csms",
+		"The name of the JetStream stream that holds the messages to the CSMS")
+	serveCmd.Flags().IntVar(&outboundQueueLen, "outbound-queue-len", 100,
+		"The number of messages from each charge station held in memory whilst the MQTT broker is unavailable: they are lost if the charge station disconnects unless --outbound-spill-dir is set")
+	serveCmd.Flags().StringVar(&outboundSpillDir, "outbound-spill-dir", "",
+		"A directory where messages from charge stations are written when the in-memory buffer is full")
+	serveCmd.Flags().IntVar(&outboundSpillLen, "outbound-spill-len", 10000,
+		"The number of messages from each charge station that can be written to the outbound spill directory")
+	serveCmd.Flags().StringVar(&duplicatePolicy, "duplicate-connection-policy", string(server.DuplicateConnectionCloseOld),
+		"What to do when a charge station connects whilst already connected, one of [close-old, reject-new]")
+	serveCmd.Flags().IntVar(&csmsCallQueueLen, "csms-call-queue-len", 5,
+		"The number of CSMS calls queued for each charge station whilst it is busy: further calls are rejected")
+	serveCmd.Flags().DurationVar(&csmsCallMaxAge, "csms-call-max-age", 2*time.Minute,
+		"The longest time a CSMS call is queued before it is rejected instead of being sent (0 disables expiry)")
+	serveCmd.Flags().DurationVar(&wsPingInterval, "ws-ping-interval", 30*time.Second,
+		"The interval at which websocket pings are sent to each charge station (0 disables pings)")
+	serveCmd.Flags().DurationVar(&wsPongTimeout, "ws-pong-timeout", 10*time.Second,
+		"How long to wait for a charge station to answer a websocket ping before closing the connection")
+	serveCmd.Flags().DurationVar(&wsIdleTimeout, "ws-idle-timeout", 0,
+		"How long a charge station can go without sending a message before the connection is closed (0 disables the timeout)")
+	serveCmd.Flags().Int64Var(&maxMessageSize, "max-message-size", 32768,
+		"The size in bytes of the largest message accepted from a charge station")
+	serveCmd.Flags().StringArrayVar(&rateLimits, "rate-limit", nil,
+		"A limit on the calls each charge station can make of the form [<action>,...=]<rate>:<burst>, where rate is in calls per second, e.g. MeterValues=1:10 (can be repeated)")
+	serveCmd.Flags().DurationVar(&registryCacheTTL, "registry-cache-ttl", time.Minute,
+		"How long charge station and certificate details from the manager are cached (0 disables the cache)")
+	serveCmd.Flags().DurationVar(&registryNegTTL, "registry-negative-cache-ttl", 10*time.Second,
+		"How long the absence of a charge station or certificate in the manager is cached")
+	serveCmd.Flags().StringVar(&registryFile, "registry-file", "",
+		"A YAML or TOML file that contains the charge stations that can connect, used instead of the manager API")
+	serveCmd.Flags().DurationVar(&registryReload, "registry-file-reload-interval", 5*time.Second,
+		"How often the registry file is checked for changes")
+	serveCmd.Flags().StringArrayVar(&crlFiles, "crl-file", nil,
+		"A file that contains a PEM or DER encoded CRL used to reject revoked client certificates (can be repeated)")
+	serveCmd.Flags().DurationVar(&crlRefresh, "crl-refresh-interval", time.Hour,
+		"How often the CRL files are read")
+	serveCmd.Flags().BoolVar(&ocspEnabled, "ocsp", false,
+		"Check client certificates using the OCSP responder named in the certificate")
+	serveCmd.Flags().DurationVar(&ocspTimeout, "ocsp-timeout", 5*time.Second,
+		"How long to wait for a response from an OCSP responder")
+	serveCmd.Flags().DurationVar(&ocspCacheTTL, "ocsp-cache-ttl", time.Hour,
+		"The longest time an OCSP response is cached (responses are never cached beyond their next update)")
+	serveCmd.Flags().StringVar(&revocationMode, "revocation-mode", string(server.RevocationSoftFail),
+		"What to do when the revocation status of a client certificate cannot be determined, one of [soft-fail, hard-fail]")
+	serveCmd.Flags().StringVar(&captureDir, "capture-dir", "",
+		"A directory where the frames exchanged with selected charge stations are captured (capture disabled if not set)")
+	serveCmd.Flags().StringArrayVar(&captureStations, "capture-station", nil,
+		"The id of a charge station whose frames are captured from startup (can be repeated)")
+	serveCmd.Flags().Int64Var(&captureMaxSize, "capture-max-file-size", 10*1024*1024,
+		"The size in bytes at which a charge station's capture file is rotated")
+	serveCmd.Flags().IntVar(&captureMaxFiles, "capture-max-files", 5,
+		"The number of rotated capture files kept for each charge station")
+	serveCmd.Flags().DurationVar(&drainWindow, "drain-window", 30*time.Second,
+		"The period over which the charge station connections are closed when the gateway is draining")
 	serveCmd.Flags().StringVarP(&wsAddr, "ws-addr", "a", "127.0.0.1:9310",
 		"The address that the insecure websocket server will listen on for connections, e.g. 127.0.0.1:9310")
 	serveCmd.Flags().StringVarP(&wssAddr, "wss-addr", "w", "",
 		"The address that the secure websocket server will listen on for connections, e.g. 127.0.0.1:9311")
 	serveCmd.Flags().StringVarP(&statusAddr, "status-addr", "s", "127.0.0.1:9312",
 		"The address that the status server will listen on for connections, e.g. 127.0.0.1:9312")
+	serveCmd.Flags().StringVar(&adminTokenFile, "admin-token-file", "",
+		"A file that contains the bearer token required by the admin API on the status server (admin API disabled if not set)")
 	serveCmd.Flags().StringVarP(&tlsServerCert, "tls-server-cert", "c", "",
 		"A file that contains a PEM encoded certificate to use as the TLS server cert")
 	serveCmd.Flags().StringVarP(&tlsServerKey, "tls-server-key", "k", "",
@@ -6,10 +6,17 @@ import (
 	"container/ring"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
 	"golang.org/x/exp/slog"
 )
 
+var responseTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_pipe_response_timeouts_total",
+	Help: "The number of calls that were not answered before the pipe's response timeout",
+}, []string{"awaiting"})
+
 // Pipe provides a bidirectional RPC pipe between a ChargeStation and the CSMS
 type Pipe struct {
 	// ChargeStationRx is an incoming channel from the Charge Station
@@ -22,16 +29,18 @@ type Pipe struct {
 	CSMSTx chan *GatewayMessage
 	// halt in a signal channel used to stop the pipe
 	halt chan struct{}
-	// csmsRxCallBuf is a channel that buffers CSMS calls whilst waiting for a CallResult
-	csmsRxCallBuf chan *GatewayMessage
+	// queue holds the CSMS calls that arrive whilst the charge station is busy
+	queue *callQueue
 	// responseTimeout is the duration that the pipe will wait for a response to a call
 	responseTimeout time.Duration
 	// messageIdBufferLen is the number of previously used message ids that will be stored
 	messageIdBufferLen int
 	// csmsMessageQueueLen is the maximum number of CSMS messages that will be queued for delivery
 	csmsMessageQueueLen int
-	// csmsCallQueueLen is the maximum number of CSMS call messages that will be queued looking for a CallResult
+	// csmsCallQueueLen is the maximum number of CSMS call messages that will be queued whilst the charge station is busy
 	csmsCallQueueLen int
+	// csmsCallMaxAge is the longest time a CSMS call will be queued before it is rejected
+	csmsCallMaxAge time.Duration
 	// csmsCallResponseBufferLen is the maximum number of CSMS messages that will be cached waiting for a response
 	csmsCallResponseBufferLen int
 }
@@ -51,7 +60,11 @@ func NewPipe(opts ...Opt) *Pipe {
 	pipe.CSMSRx = make(chan *GatewayMessage, pipe.csmsMessageQueueLen)
 	pipe.CSMSTx = make(chan *GatewayMessage, 1)
 	pipe.halt = make(chan struct{}, 1)
-	pipe.csmsRxCallBuf = make(chan *GatewayMessage, pipe.csmsCallQueueLen)
+	pipe.queue = &callQueue{
+		maxLen: pipe.csmsCallQueueLen,
+		maxAge: pipe.csmsCallMaxAge,
+		now:    time.Now,
+	}
 
 	return pipe
 }
@@ -87,14 +100,23 @@ func WithCSMSMessageQueueLen(queueLen int) Opt {
 	}
 }
 
-// WithCSMSCallQueueLen is a pipe option that sets the maximum number of CSMS Call messages to queue when looking for
-// a CallResult to send to the charge station
+// WithCSMSCallQueueLen is a pipe option that sets the maximum number of CSMS Call messages to queue whilst the
+// charge station is busy: further calls are answered with a CallError
 func WithCSMSCallQueueLen(queueLen int) Opt {
 	return func(p *Pipe) {
 		p.csmsCallQueueLen = queueLen
 	}
 }
 
+// WithCSMSCallMaxAge is a pipe option that sets the longest time a CSMS Call message can be queued: calls that
+// are queued for longer are answered with a CallError instead of being sent to the charge station. Zero means
+// that calls never expire.
+func WithCSMSCallMaxAge(maxAge time.Duration) Opt {
+	return func(p *Pipe) {
+		p.csmsCallMaxAge = maxAge
+	}
+}
+
 // WithCSMSCallResponseBufferLen is a pipe option that sets the maximum number of CSMS Call messages to buffer waiting
 // for CallResult responses from the charge station
 func WithCSMSCallResponseBufferLen(queueLen int) Opt {
@@ -114,10 +136,28 @@ const (
 // Start will begin transferring RPC messages between the ChargeStation and CSMS
 func (p Pipe) Start() {
 	go func() {
+		defer p.queue.clear()
+
 		processedMessageIds := ring.New(p.messageIdBufferLen)
 		processedCSMSCalls := ring.New(p.csmsCallResponseBufferLen)
 
 		status := StatusWaiting
+		// deadline is when the pipe gives up waiting for a response to the current call
+		var deadline time.Time
+		enter := func(s Status) {
+			status = s
+			deadline = time.Now().Add(p.responseTimeout)
+		}
+
+		sendCSMSCall := func(msg *GatewayMessage) {
+			if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
+				slog.Warn("CSMS call with duplicate message", slog.String("messageId", msg.MessageId))
+			}
+			processedCSMSCalls = processedCSMSCalls.Next()
+			processedCSMSCalls.Value = msg
+			enter(StatusCSMSCall)
+			p.ChargeStationTx <- msg
+		}
 
 		for {
 			var currentMsg *GatewayMessage
@@ -125,6 +165,14 @@ func (p Pipe) Start() {
 				currentMsg = processedCSMSCalls.Value.(*GatewayMessage)
 			}
 
+			for _, msg := range p.queue.expire() {
+				p.reject(msg, rejectReasonExpired, "call expired whilst queued for the charge station")
+			}
+			var expiry <-chan time.Time
+			if d, ok := p.queue.nextExpiry(); ok {
+				expiry = time.After(d)
+			}
+
 			switch status {
 			case StatusWaiting:
 				// prioritise pending calls from the ChargeStation
@@ -138,7 +186,7 @@ func (p Pipe) Start() {
 						}
 						processedMessageIds = processedMessageIds.Next()
 						processedMessageIds.Value = msg.MessageId
-						status = StatusChargeStationCall
+						enter(StatusChargeStationCall)
 						p.CSMSTx <- msg
 					} else if currentMsg != nil && msg.MessageId == currentMsg.MessageId {
 						// call result / call error for current CSMS call from CS
@@ -159,7 +207,13 @@ func (p Pipe) Start() {
 				case <-p.halt:
 					return
 				default:
-					// if no pending calls from the ChargeStation wait for next call from anywhere
+					// then calls from the CSMS that were queued whilst the ChargeStation was busy
+					if msg := p.queue.pop(); msg != nil {
+						sendCSMSCall(msg)
+						continue
+					}
+
+					// if no pending calls wait for next call from anywhere
 					select {
 					case msg := <-p.ChargeStationRx:
 						if msg.MessageType == ocpp.MessageTypeCall {
@@ -170,7 +224,7 @@ func (p Pipe) Start() {
 							}
 							processedMessageIds = processedMessageIds.Next()
 							processedMessageIds.Value = msg.MessageId
-							status = StatusChargeStationCall
+							enter(StatusChargeStationCall)
 							p.CSMSTx <- msg
 						} else if currentMsg != nil && msg.MessageId == currentMsg.MessageId {
 							// call result / call error for current CSMS call from CS
@@ -191,31 +245,11 @@ func (p Pipe) Start() {
 					case msg := <-p.CSMSRx:
 						if msg.MessageType != ocpp.MessageTypeCall {
 							// call result / call error from CSMS
-							if processedMessageIds.Value == msg.MessageId {
-								slog.Warn("CS call response is late", slog.String("messageId", msg.MessageId))
-								p.ChargeStationTx <- msg
-							} else {
-								slog.Error("CSMS message is not a call", slog.String("messageId", msg.MessageId))
-							}
+							p.lateCSMSResponse(processedMessageIds, msg)
 						} else {
 							// call from CSMS
-							if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
-								slog.Warn("CSMS call with duplicate message", slog.String("messageId", msg.MessageId))
-							}
-							processedCSMSCalls = processedCSMSCalls.Next()
-							processedCSMSCalls.Value = msg
-							status = StatusCSMSCall
-							p.ChargeStationTx <- msg
-						}
-					case msg := <-p.csmsRxCallBuf:
-						// buffered call from CSMS
-						if csmsCall := findCSMSCall(processedCSMSCalls, msg.MessageId); csmsCall != nil {
-							slog.Warn("CSMS call with duplicate message", slog.String("messageId", msg.MessageId))
+							sendCSMSCall(msg)
 						}
-						processedCSMSCalls = processedCSMSCalls.Next()
-						processedCSMSCalls.Value = msg
-						status = StatusCSMSCall
-						p.ChargeStationTx <- msg
 					case <-p.halt:
 						return
 					}
@@ -225,14 +259,8 @@ func (p Pipe) Start() {
 				case msg := <-p.CSMSRx:
 					if msg.MessageType == ocpp.MessageTypeCall {
 						// call from CSMS
-						select {
-						case p.csmsRxCallBuf <- msg:
-							slog.Warn("buffering CSMS call message", slog.String("messageId", msg.MessageId))
-							continue
-						default:
-							slog.Warn("CSMS call buffer full - dropping message", slog.String("messageId", msg.MessageId))
-							continue
-						}
+						p.enqueue(msg)
+						continue
 					} else {
 						// call result / call error from CSMS
 						if processedMessageIds.Value == msg.MessageId {
@@ -243,9 +271,12 @@ func (p Pipe) Start() {
 							continue
 						}
 					}
-				case <-time.After(p.responseTimeout):
+				case <-time.After(time.Until(deadline)):
 					slog.Warn("CSMS did not respond before timeout", slog.String("messageId", processedMessageIds.Value.(string)))
+					responseTimeouts.WithLabelValues("csms").Inc()
 					status = StatusWaiting
+				case <-expiry:
+					continue
 				case <-p.halt:
 					return
 				}
@@ -261,7 +292,7 @@ func (p Pipe) Start() {
 						}
 						processedMessageIds = processedMessageIds.Next()
 						processedMessageIds.Value = msg.MessageId
-						status = StatusChargeStationCall
+						enter(StatusChargeStationCall)
 						p.CSMSTx <- msg
 					} else if msg.MessageId == currentMsg.MessageId {
 						// call result / call error for current CSMS call from CS
@@ -279,9 +310,20 @@ func (p Pipe) Start() {
 						// call result / call error for unknown CSMS call from CS
 						slog.Error("CS call response has no corresponding CSMS call", slog.String("messageId", msg.MessageId))
 					}
-				case <-time.After(p.responseTimeout):
+				case msg := <-p.CSMSRx:
+					if msg.MessageType == ocpp.MessageTypeCall {
+						// call from CSMS
+						p.enqueue(msg)
+					} else {
+						// call result / call error from CSMS
+						p.lateCSMSResponse(processedMessageIds, msg)
+					}
+				case <-time.After(time.Until(deadline)):
 					slog.Warn("CS did not respond before timeout", slog.String("messageId", currentMsg.MessageId))
+					responseTimeouts.WithLabelValues("charge_station").Inc()
 					status = StatusWaiting
+				case <-expiry:
+					continue
 				case <-p.halt:
 					return
 				}
@@ -290,6 +332,48 @@ func (p Pipe) Start() {
 	}()
 }
 
+// QueueStats describes the CSMS calls that are waiting to be sent to the charge station
+func (p Pipe) QueueStats() QueueStats {
+	return p.queue.stats()
+}
+
+// enqueue holds a CSMS call until the charge station is free, rejecting the call if
+// the queue is full
+func (p Pipe) enqueue(msg *GatewayMessage) {
+	if !p.queue.push(msg) {
+		p.reject(msg, rejectReasonQueueFull, "too many calls queued for the charge station")
+		return
+	}
+	slog.Warn("buffering CSMS call message", slog.String("messageId", msg.MessageId))
+}
+
+// reject answers a CSMS call with a CALLERROR as if the charge station had rejected it
+func (p Pipe) reject(msg *GatewayMessage, reason, description string) {
+	slog.Warn("rejecting CSMS call", slog.String("messageId", msg.MessageId), slog.String("action", msg.Action),
+		slog.String("reason", reason))
+	rejectedCalls.WithLabelValues(reason).Inc()
+	p.CSMSTx <- &GatewayMessage{
+		Context:          msg.Context,
+		MessageType:      ocpp.MessageTypeCallError,
+		Action:           msg.Action,
+		MessageId:        msg.MessageId,
+		RequestPayload:   msg.RequestPayload,
+		ErrorCode:        ocpp.ErrorGenericError,
+		ErrorDescription: description,
+	}
+}
+
+// lateCSMSResponse passes on a response from the CSMS that arrives after the call from the
+// charge station has timed out
+func (p Pipe) lateCSMSResponse(processedMessageIds *ring.Ring, msg *GatewayMessage) {
+	if processedMessageIds.Value == msg.MessageId {
+		slog.Warn("CS call response is late", slog.String("messageId", msg.MessageId))
+		p.ChargeStationTx <- msg
+	} else {
+		slog.Error("CSMS message is not a call", slog.String("messageId", msg.MessageId))
+	}
+}
+
 func (p Pipe) Close() {
 	p.halt <- struct{}{}
 }
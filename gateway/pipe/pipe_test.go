@@ -511,15 +511,27 @@ func TestCSMSCallsWithInsufficientBufferWhilstHandlingCSCalls(t *testing.T) {
 		RequestPayload: json.RawMessage(`{"call":true}`),
 	}
 
+	// the call that does not fit in the queue is rejected
+	rejectedCh := make(chan struct{})
 	go func() {
 		// incoming CSMS messages
+		var second bool
 		for {
 			select {
 			case msg := <-p.CSMSTx:
-				assert.Equal(t, callMessage, msg)
-				p.CSMSRx <- csmsCallMessage
-				p.CSMSRx <- csmsCallMessage2
-				p.CSMSRx <- callResponseMessage
+				if !second {
+					assert.Equal(t, callMessage, msg)
+					p.CSMSRx <- csmsCallMessage
+					p.CSMSRx <- csmsCallMessage2
+					p.CSMSRx <- callResponseMessage
+					second = true
+				} else {
+					assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageType)
+					assert.Equal(t, "7777", msg.MessageId)
+					assert.Equal(t, "CSMSCall", msg.Action)
+					assert.Equal(t, ocpp.ErrorGenericError, msg.ErrorCode)
+					close(rejectedCh)
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -549,11 +561,13 @@ func TestCSMSCallsWithInsufficientBufferWhilstHandlingCSCalls(t *testing.T) {
 	// make call from CS
 	p.ChargeStationRx <- callMessage
 
-	select {
-	case <-doneCh:
-		// do nothing
-	case <-ctx.Done():
-		t.Fatal("timeout waiting for test to complete")
+	for _, ch := range []chan struct{}{doneCh, rejectedCh} {
+		select {
+		case <-ch:
+			// do nothing
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for test to complete")
+		}
 	}
 }
 
@@ -1039,3 +1053,108 @@ func TestChargeStationResponseForAPreviousCSMSCall(t *testing.T) {
 		t.Fatal("timeout waiting for test to complete")
 	}
 }
+
+func TestQueuedCSMSCallExpires(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	p := pipe.NewPipe(pipe.WithResponseTimeout(time.Second), pipe.WithCSMSCallMaxAge(50*time.Millisecond))
+	p.Start()
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	callMessage := &pipe.GatewayMessage{
+		MessageType:    ocpp.MessageTypeCall,
+		Action:         "CSCall",
+		MessageId:      "1234",
+		RequestPayload: json.RawMessage(`{"call":true}`),
+	}
+	csmsCallMessage := &pipe.GatewayMessage{
+		MessageType:    ocpp.MessageTypeCall,
+		Action:         "RequestStartTransaction",
+		MessageId:      "5678",
+		RequestPayload: json.RawMessage(`{"call":true}`),
+	}
+
+	// the CSMS call is queued behind the call from the CS, which the CSMS does not answer
+	p.ChargeStationRx <- callMessage
+	select {
+	case msg := <-p.CSMSTx:
+		assert.Equal(t, callMessage, msg)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for CS call")
+	}
+	p.CSMSRx <- csmsCallMessage
+
+	assert.Eventually(t, func() bool {
+		return p.QueueStats().Depth == 1
+	}, 40*time.Millisecond, time.Millisecond)
+	assert.False(t, p.QueueStats().OldestQueuedAt.IsZero())
+
+	select {
+	case msg := <-p.CSMSTx:
+		assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageType)
+		assert.Equal(t, "5678", msg.MessageId)
+		assert.Equal(t, "RequestStartTransaction", msg.Action)
+	case msg := <-p.ChargeStationTx:
+		t.Fatalf("expired call sent to charge station: %v", msg)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for call to expire")
+	}
+	assert.Equal(t, pipe.QueueStats{}, p.QueueStats())
+}
+
+func TestCSMSCallsQueuedWhilstWaitingForCSResponse(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	p := pipe.NewPipe()
+	p.Start()
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	firstCall := &pipe.GatewayMessage{
+		MessageType: ocpp.MessageTypeCall,
+		Action:      "CSMSCall",
+		MessageId:   "1",
+	}
+	secondCall := &pipe.GatewayMessage{
+		MessageType: ocpp.MessageTypeCall,
+		Action:      "CSMSCall",
+		MessageId:   "2",
+	}
+
+	p.CSMSRx <- firstCall
+	p.CSMSRx <- secondCall
+
+	select {
+	case msg := <-p.ChargeStationTx:
+		assert.Equal(t, firstCall, msg)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for first call")
+	}
+	assert.Eventually(t, func() bool {
+		return p.QueueStats().Depth == 1
+	}, 50*time.Millisecond, time.Millisecond)
+
+	p.ChargeStationRx <- &pipe.GatewayMessage{
+		MessageType: ocpp.MessageTypeCallResult,
+		MessageId:   "1",
+	}
+	select {
+	case msg := <-p.CSMSTx:
+		assert.Equal(t, "1", msg.MessageId)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for response")
+	}
+
+	select {
+	case msg := <-p.ChargeStationTx:
+		assert.Equal(t, secondCall, msg)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for second call")
+	}
+	assert.Equal(t, 0, p.QueueStats().Depth)
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pipe
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	rejectReasonQueueFull = "queue_full"
+	rejectReasonExpired   = "expired"
+)
+
+var queuedCalls = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "gateway_queued_csms_calls",
+	Help: "The number of CSMS calls waiting to be sent to charge stations",
+})
+
+var rejectedCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_rejected_csms_calls_total",
+	Help: "The number of CSMS calls that were answered with a CALLERROR by the gateway instead of being sent to the charge station",
+}, []string{"reason"})
+
+// QueueStats describes the CSMS calls waiting to be sent to the charge station
+type QueueStats struct {
+	// Depth is the number of calls waiting
+	Depth int
+	// OldestQueuedAt is the time that the call that has been waiting longest was queued,
+	// zero if there are no calls waiting
+	OldestQueuedAt time.Time
+}
+
+type queuedCall struct {
+	msg      *GatewayMessage
+	queuedAt time.Time
+}
+
+// callQueue holds the CSMS calls that arrive whilst the charge station is busy. It is only
+// modified by the pipe's goroutine but can be inspected from anywhere.
+type callQueue struct {
+	maxLen int
+	maxAge time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	calls []queuedCall
+}
+
+// push adds a call to the back of the queue, returning false if the queue is full
+func (q *callQueue) push(msg *GatewayMessage) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.calls) >= q.maxLen {
+		return false
+	}
+	q.calls = append(q.calls, queuedCall{msg: msg, queuedAt: q.now()})
+	queuedCalls.Inc()
+	return true
+}
+
+// pop removes the call at the front of the queue, returning nil if the queue is empty
+func (q *callQueue) pop() *GatewayMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.calls) == 0 {
+		return nil
+	}
+	msg := q.calls[0].msg
+	q.calls[0] = queuedCall{}
+	q.calls = q.calls[1:]
+	queuedCalls.Dec()
+	return msg
+}
+
+// expire removes and returns the calls that have been queued for longer than the maximum age
+func (q *callQueue) expire() []*GatewayMessage {
+	if q.maxAge <= 0 {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	cutoff := q.now().Add(-q.maxAge)
+	var expired []*GatewayMessage
+	for len(q.calls) > 0 && q.calls[0].queuedAt.Before(cutoff) {
+		expired = append(expired, q.calls[0].msg)
+		q.calls[0] = queuedCall{}
+		q.calls = q.calls[1:]
+		queuedCalls.Dec()
+	}
+	return expired
+}
+
+// nextExpiry returns how long until the call at the front of the queue expires, or
+// false if no call will expire
+func (q *callQueue) nextExpiry() (time.Duration, bool) {
+	if q.maxAge <= 0 {
+		return 0, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.calls) == 0 {
+		return 0, false
+	}
+	return q.calls[0].queuedAt.Add(q.maxAge).Sub(q.now()), true
+}
+
+// clear discards the queued calls
+func (q *callQueue) clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	queuedCalls.Sub(float64(len(q.calls)))
+	q.calls = nil
+}
+
+func (q *callQueue) stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stats := QueueStats{Depth: len(q.calls)}
+	if len(q.calls) > 0 {
+		stats.OldestQueuedAt = q.calls[0].queuedAt
+	}
+	return stats
+}
@@ -2,7 +2,11 @@
 
 package registry
 
-import "crypto/x509"
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+)
 
 type SecurityProfile int
 
@@ -17,6 +21,24 @@ type ChargeStation struct {
 	SecurityProfile        SecurityProfile
 	Base64SHA256Password   string
 	InvalidUsernameAllowed bool
+	// CertificateHashes are the base64 encoded SHA-256 hashes of the client certificates
+	// that the charge station may use. If empty, any trusted certificate is allowed.
+	CertificateHashes []string
+}
+
+// AllowsCertificate reports whether the charge station may use the client certificate
+func (cs *ChargeStation) AllowsCertificate(cert *x509.Certificate) bool {
+	if len(cs.CertificateHashes) == 0 {
+		return true
+	}
+	hash := sha256.Sum256(cert.Raw)
+	certHash := normalizeCertHash(base64.StdEncoding.EncodeToString(hash[:]))
+	for _, allowed := range cs.CertificateHashes {
+		if normalizeCertHash(allowed) == certHash {
+			return true
+		}
+	}
+	return false
 }
 
 type DeviceRegistry interface {
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"crypto/x509"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Invalidator is implemented by registries that hold copies of the details in the
+// registry and can be told to discard them when they change
+type Invalidator interface {
+	InvalidateChargeStation(clientId string)
+	InvalidateCertificate(certHash string)
+	InvalidateAll()
+}
+
+// CachingRegistry is a DeviceRegistry that caches the results of looking up charge
+// stations and certificates in another DeviceRegistry. Lookups that find nothing are
+// cached for a shorter time than those that succeed and lookups that fail are not
+// cached. Concurrent lookups for the same charge station or certificate are combined
+// into a single lookup.
+type CachingRegistry struct {
+	delegate       DeviceRegistry
+	ttl            time.Duration
+	negativeTTL    time.Duration
+	now            func() time.Time
+	chargeStations *cache[*ChargeStation]
+	certificates   *cache[*x509.Certificate]
+}
+
+type CachingOpt func(*CachingRegistry)
+
+// WithCacheTTL sets how long the details of a charge station or certificate are cached
+func WithCacheTTL(ttl time.Duration) CachingOpt {
+	return func(r *CachingRegistry) {
+		r.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets how long a lookup that finds no charge station or certificate
+// is cached
+func WithNegativeCacheTTL(ttl time.Duration) CachingOpt {
+	return func(r *CachingRegistry) {
+		r.negativeTTL = ttl
+	}
+}
+
+// WithCacheClock sets the function used to get the current time
+func WithCacheClock(now func() time.Time) CachingOpt {
+	return func(r *CachingRegistry) {
+		r.now = now
+	}
+}
+
+func NewCachingRegistry(delegate DeviceRegistry, opts ...CachingOpt) *CachingRegistry {
+	r := &CachingRegistry{
+		delegate:       delegate,
+		ttl:            time.Minute,
+		negativeTTL:    10 * time.Second,
+		now:            time.Now,
+		chargeStations: newCache[*ChargeStation](),
+		certificates:   newCache[*x509.Certificate](),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *CachingRegistry) LookupChargeStation(clientId string) (*ChargeStation, error) {
+	return lookup(r, r.chargeStations, clientId, r.delegate.LookupChargeStation)
+}
+
+func (r *CachingRegistry) LookupCertificate(certHash string) (*x509.Certificate, error) {
+	return lookup(r, r.certificates, normalizeCertHash(certHash), func(string) (*x509.Certificate, error) {
+		return r.delegate.LookupCertificate(certHash)
+	})
+}
+
+func (r *CachingRegistry) InvalidateChargeStation(clientId string) {
+	r.chargeStations.invalidate(clientId)
+}
+
+func (r *CachingRegistry) InvalidateCertificate(certHash string) {
+	r.certificates.invalidate(normalizeCertHash(certHash))
+}
+
+func (r *CachingRegistry) InvalidateAll() {
+	r.chargeStations.invalidateAll()
+	r.certificates.invalidateAll()
+}
+
+func lookup[T comparable](r *CachingRegistry, c *cache[T], key string, fetch func(string) (T, error)) (T, error) {
+	if value, ok := c.get(key, r.now()); ok {
+		return value, nil
+	}
+
+	result, err, _ := c.group.Do(key, func() (any, error) {
+		generation := c.currentGeneration()
+		value, err := fetch(key)
+		if err != nil {
+			return value, err
+		}
+		ttl := r.ttl
+		var zero T
+		if value == zero {
+			ttl = r.negativeTTL
+		}
+		c.put(key, value, r.now().Add(ttl), generation)
+		return value, nil
+	})
+
+	value, _ := result.(T)
+	return value, err
+}
+
+// normalizeCertHash converts a base64 encoded certificate hash to the unpadded URL
+// encoding used by the manager so that the same certificate always has the same key
+func normalizeCertHash(certHash string) string {
+	certHash = strings.ReplaceAll(certHash, "/", "_")
+	certHash = strings.ReplaceAll(certHash, "+", "-")
+	return strings.TrimRight(certHash, "=")
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// cache holds the results of lookups. The generation is incremented whenever entries
+// are invalidated so that a lookup that was in progress when its entry was invalidated
+// does not put the old details back into the cache.
+type cache[T any] struct {
+	mu         sync.Mutex
+	entries    map[string]cacheEntry[T]
+	generation uint64
+	group      singleflight.Group
+}
+
+func newCache[T any]() *cache[T] {
+	return &cache[T]{
+		entries: make(map[string]cacheEntry[T]),
+	}
+}
+
+func (c *cache[T]) get(key string, now time.Time) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		delete(c.entries, key)
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *cache[T]) put(key string, value T, expires time.Time, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	c.entries[key] = cacheEntry[T]{value: value, expires: expires}
+}
+
+func (c *cache[T]) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *cache[T]) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	c.generation++
+	// later lookups must not wait for the result of a lookup made before the change
+	c.group.Forget(key)
+}
+
+func (c *cache[T]) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry[T])
+	c.generation++
+}
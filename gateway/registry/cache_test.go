@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+)
+
+type countingRegistry struct {
+	registry.MockRegistry
+	lookups atomic.Int32
+	err     error
+	block   chan struct{}
+}
+
+func (c *countingRegistry) LookupChargeStation(clientId string) (*registry.ChargeStation, error) {
+	c.lookups.Add(1)
+	if c.block != nil {
+		<-c.block
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.MockRegistry.LookupChargeStation(clientId)
+}
+
+func (c *countingRegistry) LookupCertificate(certHash string) (*x509.Certificate, error) {
+	c.lookups.Add(1)
+	return c.MockRegistry.LookupCertificate(certHash)
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func newCountingRegistry() *countingRegistry {
+	delegate := &countingRegistry{MockRegistry: *registry.NewMockRegistry()}
+	delegate.ChargeStations["cs001"] = &registry.ChargeStation{ClientId: "cs001", SecurityProfile: 1}
+	return delegate
+}
+
+func TestCachingRegistryCachesChargeStations(t *testing.T) {
+	delegate := newCountingRegistry()
+	clock := &fakeClock{now: time.Now()}
+	reg := registry.NewCachingRegistry(delegate, registry.WithCacheTTL(time.Minute), registry.WithCacheClock(clock.Now))
+
+	for i := 0; i < 3; i++ {
+		cs, err := reg.LookupChargeStation("cs001")
+		require.NoError(t, err)
+		assert.Equal(t, "cs001", cs.ClientId)
+	}
+	assert.Equal(t, int32(1), delegate.lookups.Load())
+
+	clock.now = clock.now.Add(time.Minute)
+	_, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), delegate.lookups.Load())
+}
+
+func TestCachingRegistryCachesMissingChargeStations(t *testing.T) {
+	delegate := newCountingRegistry()
+	clock := &fakeClock{now: time.Now()}
+	reg := registry.NewCachingRegistry(delegate, registry.WithNegativeCacheTTL(5*time.Second), registry.WithCacheClock(clock.Now))
+
+	cs, err := reg.LookupChargeStation("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+	_, _ = reg.LookupChargeStation("unknown")
+	assert.Equal(t, int32(1), delegate.lookups.Load())
+
+	clock.now = clock.now.Add(5 * time.Second)
+	_, _ = reg.LookupChargeStation("unknown")
+	assert.Equal(t, int32(2), delegate.lookups.Load())
+}
+
+func TestCachingRegistryDoesNotCacheErrors(t *testing.T) {
+	delegate := newCountingRegistry()
+	delegate.err = errors.New("manager unavailable")
+	reg := registry.NewCachingRegistry(delegate)
+
+	_, err := reg.LookupChargeStation("cs001")
+	assert.Error(t, err)
+
+	delegate.err = nil
+	cs, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	assert.NotNil(t, cs)
+	assert.Equal(t, int32(2), delegate.lookups.Load())
+}
+
+func TestCachingRegistryCoalescesConcurrentLookups(t *testing.T) {
+	delegate := newCountingRegistry()
+	delegate.block = make(chan struct{})
+	reg := registry.NewCachingRegistry(delegate)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cs, err := reg.LookupChargeStation("cs001")
+			assert.NoError(t, err)
+			assert.NotNil(t, cs)
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		return delegate.lookups.Load() == 1
+	}, time.Second, time.Millisecond)
+	// give the other lookups time to join the one in progress
+	time.Sleep(50 * time.Millisecond)
+	close(delegate.block)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), delegate.lookups.Load())
+}
+
+func TestCachingRegistryInvalidation(t *testing.T) {
+	delegate := newCountingRegistry()
+	cert := &x509.Certificate{Raw: []byte("cert")}
+	delegate.Certificates["ab+c/d="] = cert
+	reg := registry.NewCachingRegistry(delegate)
+
+	_, _ = reg.LookupChargeStation("cs001")
+	got, err := reg.LookupCertificate("ab+c/d=")
+	require.NoError(t, err)
+	assert.Equal(t, cert, got)
+	assert.Equal(t, int32(2), delegate.lookups.Load())
+
+	delegate.ChargeStations["cs001"].SecurityProfile = 2
+	reg.InvalidateChargeStation("cs001")
+	cs, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	assert.Equal(t, registry.SecurityProfile(2), cs.SecurityProfile)
+	assert.Equal(t, int32(3), delegate.lookups.Load())
+
+	// the manager identifies certificates using unpadded URL encoding
+	reg.InvalidateCertificate("ab-c_d")
+	_, _ = reg.LookupCertificate("ab+c/d=")
+	assert.Equal(t, int32(4), delegate.lookups.Load())
+
+	reg.InvalidateAll()
+	_, _ = reg.LookupChargeStation("cs001")
+	_, _ = reg.LookupCertificate("ab+c/d=")
+	assert.Equal(t, int32(6), delegate.lookups.Load())
+}
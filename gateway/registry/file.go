@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/exp/slog"
+	"gopkg.in/yaml.v3"
+)
+
+// FileRegistry is a DeviceRegistry that reads the charge stations and certificates from a
+// YAML or TOML file (determined by the file extension). It is intended for deployments
+// where the gateway runs without the manager API, e.g.
+//
+//	chargeStations:
+//	  - id: cs001
+//	    securityProfile: 1
+//	    base64SHA256Password: XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=
+//	  - id: cs002
+//	    securityProfile: 2
+//	    certificateHashes:
+//	      - 0B8Mdw4SBLJNK4pR1USwnfYJW+6bCx8yG7p1IvNBpP0=
+//	certificates:
+//	  - certs/cs002.pem
+//
+// Certificate files are PEM encoded and are read relative to the directory containing
+// the registry file. The certificates are served by LookupCertificate for use when TLS
+// is terminated by a proxy.
+type FileRegistry struct {
+	path           string
+	reloadInterval time.Duration
+
+	mu             sync.RWMutex
+	modTime        time.Time
+	chargeStations map[string]*ChargeStation
+	certificates   map[string]*x509.Certificate
+}
+
+type FileRegistryOpt func(*FileRegistry)
+
+// WithReloadInterval sets how often Watch checks whether the file has changed
+func WithReloadInterval(interval time.Duration) FileRegistryOpt {
+	return func(r *FileRegistry) {
+		r.reloadInterval = interval
+	}
+}
+
+// NewFileRegistry creates a FileRegistry and loads the file at path
+func NewFileRegistry(path string, opts ...FileRegistryOpt) (*FileRegistry, error) {
+	r := &FileRegistry{
+		path:           path,
+		reloadInterval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading registry file: %w", err)
+	}
+	err = r.load(info.ModTime())
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FileRegistry) LookupChargeStation(clientId string) (*ChargeStation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.chargeStations[clientId], nil
+}
+
+func (r *FileRegistry) LookupCertificate(certHash string) (*x509.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificates[normalizeCertHash(certHash)], nil
+}
+
+// Watch reloads the file whenever it changes until ctx is done. If the changed file is
+// not valid then the error is logged and the previous contents are kept.
+func (r *FileRegistry) Watch(ctx context.Context) {
+	ticker := time.NewTicker(r.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			info, err := os.Stat(r.path)
+			if err != nil {
+				slog.Warn("checking registry file", "path", r.path, "err", err)
+				continue
+			}
+			r.mu.RLock()
+			changed := !info.ModTime().Equal(r.modTime)
+			r.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			err = r.load(info.ModTime())
+			if err != nil {
+				slog.Error("reloading registry file - keeping previous registry", "path", r.path, "err", err)
+				// don't report the same error until the file changes again
+				r.mu.Lock()
+				r.modTime = info.ModTime()
+				r.mu.Unlock()
+				continue
+			}
+			slog.Info("reloaded registry file", "path", r.path)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type registryFile struct {
+	ChargeStations []registryFileChargeStation `yaml:"chargeStations" toml:"chargeStations"`
+	Certificates   []string                    `yaml:"certificates" toml:"certificates"`
+}
+
+type registryFileChargeStation struct {
+	Id                     string   `yaml:"id" toml:"id"`
+	SecurityProfile        int      `yaml:"securityProfile" toml:"securityProfile"`
+	Base64SHA256Password   string   `yaml:"base64SHA256Password" toml:"base64SHA256Password"`
+	InvalidUsernameAllowed bool     `yaml:"invalidUsernameAllowed" toml:"invalidUsernameAllowed"`
+	CertificateHashes      []string `yaml:"certificateHashes" toml:"certificateHashes"`
+}
+
+func (r *FileRegistry) load(modTime time.Time) error {
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("reading registry file: %w", err)
+	}
+
+	var file registryFile
+	switch ext := strings.ToLower(filepath.Ext(r.path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(b)).DisallowUnknownFields().Decode(&file)
+	default:
+		return fmt.Errorf("registry file %s must have a .yaml, .yml or .toml extension", r.path)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing registry file %s: %w", r.path, err)
+	}
+
+	chargeStations := make(map[string]*ChargeStation)
+	for i, cs := range file.ChargeStations {
+		if cs.Id == "" {
+			return fmt.Errorf("charge station %d in %s has no id", i, r.path)
+		}
+		if _, ok := chargeStations[cs.Id]; ok {
+			return fmt.Errorf("charge station %s appears more than once in %s", cs.Id, r.path)
+		}
+		securityProfile := SecurityProfile(cs.SecurityProfile)
+		switch securityProfile {
+		case UnsecuredTransportWithBasicAuth, TLSWithBasicAuth:
+			if cs.Base64SHA256Password == "" {
+				return fmt.Errorf("charge station %s in %s must have a password for security profile %d", cs.Id, r.path, cs.SecurityProfile)
+			}
+		case TLSWithClientSideCertificates:
+		default:
+			return fmt.Errorf("charge station %s in %s has invalid security profile %d", cs.Id, r.path, cs.SecurityProfile)
+		}
+		chargeStations[cs.Id] = &ChargeStation{
+			ClientId:               cs.Id,
+			SecurityProfile:        securityProfile,
+			Base64SHA256Password:   cs.Base64SHA256Password,
+			InvalidUsernameAllowed: cs.InvalidUsernameAllowed,
+			CertificateHashes:      cs.CertificateHashes,
+		}
+	}
+
+	certificates := make(map[string]*x509.Certificate)
+	for _, certFile := range file.Certificates {
+		if !filepath.IsAbs(certFile) {
+			certFile = filepath.Join(filepath.Dir(r.path), certFile)
+		}
+		certs, err := readCertificates(certFile)
+		if err != nil {
+			return err
+		}
+		for _, cert := range certs {
+			hash := sha256.Sum256(cert.Raw)
+			certificates[normalizeCertHash(base64.StdEncoding.EncodeToString(hash[:]))] = cert
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.modTime = modTime
+	r.chargeStations = chargeStations
+	r.certificates = certificates
+	return nil
+}
+
+func readCertificates(path string) ([]*x509.Certificate, error) {
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate file: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate in %s: %w", path, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return certs, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package registry_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	err := os.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+}
+
+func TestFileRegistryLoadsYaml(t *testing.T) {
+	dir := t.TempDir()
+	cert := generateCertificate(t)
+	writeFile(t, filepath.Join(dir, "cs002.pem"), string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+	certHash := sha256.Sum256(cert.Raw)
+	b64CertHash := base64.StdEncoding.EncodeToString(certHash[:])
+
+	path := filepath.Join(dir, "registry.yaml")
+	writeFile(t, path, `
+chargeStations:
+  - id: cs001
+    securityProfile: 1
+    base64SHA256Password: DEADBEEF
+    invalidUsernameAllowed: true
+  - id: cs002
+    securityProfile: 2
+    certificateHashes:
+      - `+b64CertHash+`
+certificates:
+  - cs002.pem
+`)
+
+	reg, err := registry.NewFileRegistry(path)
+	require.NoError(t, err)
+
+	cs, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &registry.ChargeStation{
+		ClientId:               "cs001",
+		SecurityProfile:        registry.TLSWithBasicAuth,
+		Base64SHA256Password:   "DEADBEEF",
+		InvalidUsernameAllowed: true,
+	}, cs)
+
+	cs, err = reg.LookupChargeStation("cs002")
+	require.NoError(t, err)
+	assert.Equal(t, registry.TLSWithClientSideCertificates, cs.SecurityProfile)
+	assert.True(t, cs.AllowsCertificate(cert))
+	assert.False(t, cs.AllowsCertificate(generateCertificate(t)))
+
+	cs, err = reg.LookupChargeStation("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+
+	got, err := reg.LookupCertificate(b64CertHash)
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, got.Raw)
+}
+
+func TestFileRegistryLoadsToml(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.toml")
+	writeFile(t, path, `
+[[chargeStations]]
+id = "cs001"
+securityProfile = 0
+base64SHA256Password = "DEADBEEF"
+`)
+
+	reg, err := registry.NewFileRegistry(path)
+	require.NoError(t, err)
+
+	cs, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	require.NotNil(t, cs)
+	assert.Equal(t, registry.UnsecuredTransportWithBasicAuth, cs.SecurityProfile)
+	assert.Equal(t, "DEADBEEF", cs.Base64SHA256Password)
+}
+
+func TestFileRegistryRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]string{
+		"registry.json":          `{}`,
+		"unknown-field.yaml":     "chargeStations:\n  - id: cs001\n    profile: 1\n",
+		"no-id.yaml":             "chargeStations:\n  - securityProfile: 2\n",
+		"duplicate.yaml":         "chargeStations:\n  - id: cs001\n    securityProfile: 2\n  - id: cs001\n    securityProfile: 2\n",
+		"no-password.yaml":       "chargeStations:\n  - id: cs001\n    securityProfile: 1\n",
+		"bad-profile.yaml":       "chargeStations:\n  - id: cs001\n    securityProfile: 3\n",
+		"missing-cert-file.yaml": "certificates:\n  - missing.pem\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			writeFile(t, path, content)
+			_, err := registry.NewFileRegistry(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFileRegistryReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	writeFile(t, path, "chargeStations:\n  - id: cs001\n    securityProfile: 2\n")
+
+	reg, err := registry.NewFileRegistry(path, registry.WithReloadInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reg.Watch(ctx)
+
+	// an invalid file leaves the previous registry in place
+	writeFile(t, path, "chargeStations: [")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	time.Sleep(50 * time.Millisecond)
+	cs, _ := reg.LookupChargeStation("cs001")
+	assert.NotNil(t, cs)
+
+	writeFile(t, path, "chargeStations:\n  - id: cs002\n    securityProfile: 2\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+	require.Eventually(t, func() bool {
+		cs, _ := reg.LookupChargeStation("cs002")
+		return cs != nil
+	}, time.Second, 10*time.Millisecond)
+
+	cs, _ = reg.LookupChargeStation("cs001")
+	assert.Nil(t, cs)
+}
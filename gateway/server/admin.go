@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+// DisconnectRequest is the body of a request to close a charge station's websocket
+type DisconnectRequest struct {
+	// Code is the websocket close code, defaults to 1008 (policy violation)
+	Code int `json:"code,omitempty"`
+	// Reason is the websocket close reason
+	Reason string `json:"reason,omitempty"`
+}
+
+func listConnections(connections *ConnectionTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, connections.List())
+	}
+}
+
+func getConnection(connections *ConnectionTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		info, ok := connections.Get(chi.URLParam(r, "id"))
+		if !ok {
+			writeJSONError(w, http.StatusNotFound, "charge station not connected")
+			return
+		}
+		writeJSON(w, http.StatusOK, info)
+	}
+}
+
+func disconnectConnection(connections *ConnectionTracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		var req DisconnectRequest
+		err := json.NewDecoder(io.LimitReader(r.Body, 4096)).Decode(&req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			writeJSONError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		if req.Code == 0 {
+			req.Code = int(websocket.StatusPolicyViolation)
+		}
+		if !isSendableCloseCode(req.Code) {
+			writeJSONError(w, http.StatusBadRequest, "invalid close code")
+			return
+		}
+		// the close frame payload is limited to 125 bytes, two of which are the code
+		if len(req.Reason) > 123 {
+			writeJSONError(w, http.StatusBadRequest, "close reason too long")
+			return
+		}
+
+		if !connections.Disconnect(id, websocket.StatusCode(req.Code), req.Reason) {
+			writeJSONError(w, http.StatusNotFound, "charge station not connected")
+			return
+		}
+
+		slog.Info("disconnecting charge station", "clientId", id, "code", req.Code, "reason", req.Reason)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// CaptureList is the body of the response to a request for the charge stations that
+// are being captured
+type CaptureList struct {
+	Stations []string `json:"stations"`
+}
+
+func listCapturedStations(capture *FrameCapture) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, CaptureList{Stations: capture.Stations()})
+	}
+}
+
+func enableCapture(capture *FrameCapture) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		capture.Enable(id)
+		slog.Info("capturing charge station frames", "clientId", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func disableCapture(capture *FrameCapture) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		capture.Disable(id)
+		slog.Info("stopped capturing charge station frames", "clientId", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func startDrain(drainer *Drainer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		slog.Info("drain requested via admin api")
+		drainer.Start()
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// isSendableCloseCode reports whether code may be sent in a websocket close frame (RFC 6455, section 7.4)
+func isSendableCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003:
+		return true
+	case code >= 1007 && code <= 1014:
+		return true
+	case code >= 3000 && code <= 4999:
+		return true
+	default:
+		return false
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		slog.Warn("writing response", "err", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,301 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+// CaptureDirection is the direction of a captured frame relative to the charge station
+type CaptureDirection string
+
+const (
+	// CaptureFromChargeStation is a frame that the charge station sent to the gateway
+	CaptureFromChargeStation CaptureDirection = "from-cs"
+	// CaptureToChargeStation is a frame that the gateway sent to the charge station
+	CaptureToChargeStation CaptureDirection = "to-cs"
+)
+
+// CapturedFrame is a single websocket frame exchanged with a charge station, as written
+// (one per line) to a capture file
+type CapturedFrame struct {
+	Timestamp time.Time        `json:"timestamp"`
+	ClientId  string           `json:"clientId"`
+	Protocol  string           `json:"protocol"`
+	Direction CaptureDirection `json:"direction"`
+	// Frame is the OCPP-J message exactly as it was sent: binary frames are base64 encoded
+	Frame  string `json:"frame"`
+	Binary bool   `json:"binary,omitempty"`
+}
+
+// FrameCapture records the raw frames exchanged with selected charge stations. The frames
+// for each charge station are appended to <dir>/<clientId>.jsonl, which is rotated to
+// <clientId>.jsonl.1, <clientId>.jsonl.2 and so on when it reaches the maximum size.
+type FrameCapture struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	stations map[string]bool
+	files    map[string]*captureFile
+}
+
+type captureFile struct {
+	path string
+	f    *os.File
+	size int64
+}
+
+type CaptureOpt func(*FrameCapture)
+
+// WithCaptureStations enables capture for the charge stations with the given ids
+func WithCaptureStations(clientIds ...string) CaptureOpt {
+	return func(c *FrameCapture) {
+		for _, clientId := range clientIds {
+			c.stations[clientId] = true
+		}
+	}
+}
+
+// WithCaptureMaxFileSize sets the size in bytes at which a capture file is rotated
+func WithCaptureMaxFileSize(maxFileSize int64) CaptureOpt {
+	return func(c *FrameCapture) {
+		c.maxFileSize = maxFileSize
+	}
+}
+
+// WithCaptureMaxFiles sets the number of rotated capture files kept for each charge station
+func WithCaptureMaxFiles(maxFiles int) CaptureOpt {
+	return func(c *FrameCapture) {
+		c.maxFiles = maxFiles
+	}
+}
+
+// WithCaptureClock sets the function used to timestamp the captured frames
+func WithCaptureClock(now func() time.Time) CaptureOpt {
+	return func(c *FrameCapture) {
+		c.now = now
+	}
+}
+
+// NewFrameCapture creates a FrameCapture that writes capture files to dir, creating it
+// if necessary
+func NewFrameCapture(dir string, opts ...CaptureOpt) (*FrameCapture, error) {
+	c := &FrameCapture{
+		dir:         dir,
+		maxFileSize: 10 * 1024 * 1024,
+		maxFiles:    5,
+		now:         time.Now,
+		stations:    make(map[string]bool),
+		files:       make(map[string]*captureFile),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("creating capture directory %s: %w", dir, err)
+	}
+	return c, nil
+}
+
+// Enable starts capturing the frames exchanged with a charge station
+func (c *FrameCapture) Enable(clientId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stations[clientId] = true
+}
+
+// Disable stops capturing the frames exchanged with a charge station
+func (c *FrameCapture) Disable(clientId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.stations, clientId)
+	c.closeFile(clientId)
+}
+
+// Enabled reports whether the frames exchanged with a charge station are being captured
+func (c *FrameCapture) Enabled(clientId string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stations[clientId]
+}
+
+// Stations returns the ids of the charge stations that are being captured
+func (c *FrameCapture) Stations() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stations := make([]string, 0, len(c.stations))
+	for clientId := range c.stations {
+		stations = append(stations, clientId)
+	}
+	sort.Strings(stations)
+	return stations
+}
+
+// Close closes all the open capture files
+func (c *FrameCapture) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for clientId := range c.files {
+		c.closeFile(clientId)
+	}
+}
+
+// record writes a frame to the charge station's capture file if capture is enabled for
+// the charge station. Failures are logged rather than affecting the connection.
+func (c *FrameCapture) record(clientId, protocol string, direction CaptureDirection, typ websocket.MessageType, data []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stations[clientId] {
+		return
+	}
+
+	frame := CapturedFrame{
+		Timestamp: c.now().UTC(),
+		ClientId:  clientId,
+		Protocol:  protocol,
+		Direction: direction,
+		Frame:     string(data),
+	}
+	if typ != websocket.MessageText {
+		frame.Frame = base64.StdEncoding.EncodeToString(data)
+		frame.Binary = true
+	}
+	line, err := json.Marshal(frame)
+	if err != nil {
+		slog.Warn("encoding captured frame", "clientId", clientId, "err", err)
+		return
+	}
+	line = append(line, '\n')
+
+	err = c.write(clientId, line)
+	if err != nil {
+		slog.Warn("writing captured frame", "clientId", clientId, "err", err)
+	}
+}
+
+func (c *FrameCapture) write(clientId string, line []byte) error {
+	cf, err := c.openFile(clientId)
+	if err != nil {
+		return err
+	}
+
+	if cf.size > 0 && cf.size+int64(len(line)) > c.maxFileSize {
+		c.closeFile(clientId)
+		err = c.rotate(cf.path)
+		if err != nil {
+			return err
+		}
+		cf, err = c.openFile(clientId)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := cf.f.Write(line)
+	cf.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("writing capture file %s: %w", cf.path, err)
+	}
+	return nil
+}
+
+func (c *FrameCapture) openFile(clientId string) (*captureFile, error) {
+	if cf, ok := c.files[clientId]; ok {
+		return cf, nil
+	}
+
+	path := filepath.Join(c.dir, url.PathEscape(clientId)+".jsonl")
+	//#nosec G304 - the capture directory is specified by the person running the application
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening capture file %s: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("reading capture file %s: %w", path, err)
+	}
+
+	cf := &captureFile{path: path, f: f, size: info.Size()}
+	c.files[clientId] = cf
+	return cf, nil
+}
+
+func (c *FrameCapture) closeFile(clientId string) {
+	cf, ok := c.files[clientId]
+	if !ok {
+		return
+	}
+	delete(c.files, clientId)
+	err := cf.f.Close()
+	if err != nil {
+		slog.Warn("closing capture file", "path", cf.path, "err", err)
+	}
+}
+
+// rotate moves path to path.1, path.1 to path.2 and so on, discarding the oldest file
+func (c *FrameCapture) rotate(path string) error {
+	if c.maxFiles < 1 {
+		return os.Remove(path)
+	}
+	for i := c.maxFiles - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rotating capture file %s: %w", path, err)
+		}
+	}
+	err := os.Rename(path, path+".1")
+	if err != nil {
+		return fmt.Errorf("rotating capture file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadCapture reads the frames from a capture file
+func ReadCapture(r io.Reader) ([]CapturedFrame, error) {
+	var frames []CapturedFrame
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxSpillLineLen)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var frame CapturedFrame
+		err := json.Unmarshal(scanner.Bytes(), &frame)
+		if err != nil {
+			return nil, fmt.Errorf("parsing captured frame on line %d: %w", line, err)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading capture: %w", err)
+	}
+	return frames, nil
+}
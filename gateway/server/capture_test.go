@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+)
+
+func readCaptureFile(t *testing.T, path string) []CapturedFrame {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	frames, err := ReadCapture(f)
+	require.NoError(t, err)
+	return frames
+}
+
+func TestFrameCaptureRecordsEnabledStations(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2023, 6, 15, 10, 30, 0, 0, time.UTC)
+	capture, err := NewFrameCapture(dir, WithCaptureStations("cs001"), WithCaptureClock(func() time.Time { return now }))
+	require.NoError(t, err)
+	defer capture.Close()
+
+	capture.record("cs001", "ocpp2.0.1", CaptureFromChargeStation, websocket.MessageText, []byte(`[2,"1","Heartbeat",{}]`))
+	capture.record("cs002", "ocpp2.0.1", CaptureFromChargeStation, websocket.MessageText, []byte(`[2,"1","Heartbeat",{}]`))
+	capture.record("cs001", "ocpp2.0.1", CaptureToChargeStation, websocket.MessageBinary, []byte{0x01, 0x02})
+
+	assert.True(t, capture.Enabled("cs001"))
+	assert.False(t, capture.Enabled("cs002"))
+	assert.NoFileExists(t, filepath.Join(dir, "cs002.jsonl"))
+
+	frames := readCaptureFile(t, filepath.Join(dir, "cs001.jsonl"))
+	want := []CapturedFrame{
+		{
+			Timestamp: now,
+			ClientId:  "cs001",
+			Protocol:  "ocpp2.0.1",
+			Direction: CaptureFromChargeStation,
+			Frame:     `[2,"1","Heartbeat",{}]`,
+		},
+		{
+			Timestamp: now,
+			ClientId:  "cs001",
+			Protocol:  "ocpp2.0.1",
+			Direction: CaptureToChargeStation,
+			Frame:     "AQI=",
+			Binary:    true,
+		},
+	}
+	assert.Equal(t, want, frames)
+
+	capture.Disable("cs001")
+	capture.Enable("cs002")
+	capture.record("cs001", "ocpp2.0.1", CaptureFromChargeStation, websocket.MessageText, []byte(`[2,"2","Heartbeat",{}]`))
+	assert.Len(t, readCaptureFile(t, filepath.Join(dir, "cs001.jsonl")), 2)
+	assert.Equal(t, []string{"cs002"}, capture.Stations())
+}
+
+func TestFrameCaptureRotatesFiles(t *testing.T) {
+	dir := t.TempDir()
+	capture, err := NewFrameCapture(dir,
+		WithCaptureStations("cs001"),
+		WithCaptureMaxFileSize(300),
+		WithCaptureMaxFiles(2))
+	require.NoError(t, err)
+	defer capture.Close()
+
+	for i := 0; i < 10; i++ {
+		capture.record("cs001", "ocpp1.6", CaptureFromChargeStation, websocket.MessageText,
+			[]byte(fmt.Sprintf(`[2,"%d","Heartbeat",{}]`, i)))
+	}
+
+	path := filepath.Join(dir, "cs001.jsonl")
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(300), name)
+	}
+	assert.NoFileExists(t, path+".3")
+
+	// the current file holds the most recent frames
+	frames := readCaptureFile(t, path)
+	require.NotEmpty(t, frames)
+	assert.Equal(t, `[2,"9","Heartbeat",{}]`, frames[len(frames)-1].Frame)
+}
@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"nhooyr.io/websocket"
+)
+
+// ConnectionTracker keeps track of the charge stations that are currently connected
+// to the gateway so that they can be inspected and disconnected by an operator
+type ConnectionTracker struct {
+	mu          sync.RWMutex
+	connections map[string]*trackedConnection
+}
+
+func NewConnectionTracker() *ConnectionTracker {
+	return &ConnectionTracker{
+		connections: make(map[string]*trackedConnection),
+	}
+}
+
+// ConnectionInfo describes a live charge station connection
+type ConnectionInfo struct {
+	Id              string       `json:"id"`
+	Protocol        string       `json:"protocol"`
+	RemoteAddr      string       `json:"remoteAddr,omitempty"`
+	ConnectedSince  time.Time    `json:"connectedSince"`
+	LastMessageAt   *time.Time   `json:"lastMessageAt,omitempty"`
+	BytesIn         int64        `json:"bytesIn"`
+	BytesOut        int64        `json:"bytesOut"`
+	PendingCsmsCall *PendingCall `json:"pendingCsmsCall,omitempty"`
+	// QueuedCsmsCalls is the number of CSMS calls waiting for the pending call to be answered
+	QueuedCsmsCalls int `json:"queuedCsmsCalls"`
+	// OldestQueuedCsmsCallAt is when the CSMS call that has been queued longest was queued
+	OldestQueuedCsmsCallAt *time.Time `json:"oldestQueuedCsmsCallAt,omitempty"`
+	RateLimited            int64      `json:"rateLimited"`
+	Oversized              int64      `json:"oversized"`
+}
+
+// PendingCall describes a call sent by the CSMS that the charge station has not yet answered
+type PendingCall struct {
+	MessageId string    `json:"messageId"`
+	Action    string    `json:"action"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+// trackedConnection holds the statistics for a single websocket connection
+type trackedConnection struct {
+	id             string
+	protocol       string
+	remoteAddr     string
+	connectedSince time.Time
+	wsConn         *websocket.Conn
+	// queue reports the CSMS calls queued for the charge station
+	queue func() pipe.QueueStats
+	// event is the event published when the connection was established
+	event *connectionEvent
+	// done is closed when the connection is no longer tracked
+	done chan struct{}
+	// superseded is set when the connection is closed because the charge station has
+	// another connection
+	superseded atomic.Bool
+
+	lastMessageAt  atomic.Int64
+	lastReceivedAt atomic.Int64
+	bytesIn        atomic.Int64
+	bytesOut       atomic.Int64
+	rateLimited    atomic.Int64
+	oversized      atomic.Int64
+
+	mu          sync.Mutex
+	pendingCall *PendingCall
+	// closeCode and closeReason are set when the gateway closes the connection
+	closeCode   websocket.StatusCode
+	closeReason string
+}
+
+func newTrackedConnection(wsConn *websocket.Conn, event *connectionEvent, queue func() pipe.QueueStats) *trackedConnection {
+	return &trackedConnection{
+		id:             event.ClientId,
+		protocol:       event.Protocol,
+		remoteAddr:     event.RemoteAddr,
+		connectedSince: event.Timestamp,
+		wsConn:         wsConn,
+		queue:          queue,
+		event:          event,
+		done:           make(chan struct{}),
+	}
+}
+
+// add starts tracking a connection, returning the connection that was previously tracked
+// for the charge station (if any). The returned function must be called when the
+// connection is closed.
+func (t *ConnectionTracker) add(conn *trackedConnection) (func(), *trackedConnection) {
+	t.mu.Lock()
+	existing := t.connections[conn.id]
+	t.connections[conn.id] = conn
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.connections[conn.id] == conn {
+			delete(t.connections, conn.id)
+		}
+		close(conn.done)
+	}, existing
+}
+
+func (t *ConnectionTracker) get(id string) *trackedConnection {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.connections[id]
+}
+
+// all returns the live connections
+func (t *ConnectionTracker) all() []*trackedConnection {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	conns := make([]*trackedConnection, 0, len(t.connections))
+	for _, conn := range t.connections {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// List returns the details of all the live connections ordered by charge station id
+func (t *ConnectionTracker) List() []ConnectionInfo {
+	t.mu.RLock()
+	infos := make([]ConnectionInfo, 0, len(t.connections))
+	for _, conn := range t.connections {
+		infos = append(infos, conn.info())
+	}
+	t.mu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Id < infos[j].Id
+	})
+	return infos
+}
+
+// Get returns the details of the connection for the charge station or false if
+// the charge station is not connected
+func (t *ConnectionTracker) Get(id string) (ConnectionInfo, bool) {
+	conn := t.get(id)
+	if conn == nil {
+		return ConnectionInfo{}, false
+	}
+	return conn.info(), true
+}
+
+// Disconnect closes the charge station's websocket with the given close code and reason.
+// It returns false if the charge station is not connected.
+func (t *ConnectionTracker) Disconnect(id string, code websocket.StatusCode, reason string) bool {
+	conn := t.get(id)
+	if conn == nil {
+		return false
+	}
+	conn.close(code, reason)
+	return true
+}
+
+func (c *trackedConnection) info() ConnectionInfo {
+	info := ConnectionInfo{
+		Id:             c.id,
+		Protocol:       c.protocol,
+		RemoteAddr:     c.remoteAddr,
+		ConnectedSince: c.connectedSince,
+		BytesIn:        c.bytesIn.Load(),
+		BytesOut:       c.bytesOut.Load(),
+		RateLimited:    c.rateLimited.Load(),
+		Oversized:      c.oversized.Load(),
+	}
+	if lastMessageAt := c.lastMessageAt.Load(); lastMessageAt != 0 {
+		t := time.Unix(0, lastMessageAt).UTC()
+		info.LastMessageAt = &t
+	}
+
+	c.mu.Lock()
+	if c.pendingCall != nil {
+		pendingCall := *c.pendingCall
+		info.PendingCsmsCall = &pendingCall
+	}
+	c.mu.Unlock()
+
+	if c.queue != nil {
+		stats := c.queue()
+		info.QueuedCsmsCalls = stats.Depth
+		if !stats.OldestQueuedAt.IsZero() {
+			t := stats.OldestQueuedAt.UTC()
+			info.OldestQueuedCsmsCallAt = &t
+		}
+	}
+
+	return info
+}
+
+// close closes the websocket in the background: close performs the closing handshake,
+// which can take a while if the charge station does not respond
+func (c *trackedConnection) close(code websocket.StatusCode, reason string) {
+	c.recordClose(code, reason)
+	go func() {
+		_ = c.wsConn.Close(code, reason)
+	}()
+}
+
+// supersede closes a connection that has been replaced by another connection from the
+// same charge station
+func (c *trackedConnection) supersede(code websocket.StatusCode, reason string) {
+	c.superseded.Store(true)
+	c.close(code, reason)
+}
+
+// recordClose records why the gateway closed the connection. Only the first reason is kept.
+func (c *trackedConnection) recordClose(code websocket.StatusCode, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closeCode == 0 {
+		c.closeCode = code
+		c.closeReason = reason
+	}
+}
+
+// closedByGateway returns the close code and reason if the gateway closed the connection
+func (c *trackedConnection) closedByGateway() (websocket.StatusCode, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeCode, c.closeReason, c.closeCode != 0
+}
+
+// received records a message read from the charge station
+func (c *trackedConnection) received(size int) {
+	now := time.Now().UnixNano()
+	c.bytesIn.Add(int64(size))
+	c.lastMessageAt.Store(now)
+	c.lastReceivedAt.Store(now)
+}
+
+// lastReceived returns the time that a message was last read from the charge station, or
+// the time that the charge station connected if no message has been read
+func (c *trackedConnection) lastReceived() time.Time {
+	if lastReceivedAt := c.lastReceivedAt.Load(); lastReceivedAt != 0 {
+		return time.Unix(0, lastReceivedAt)
+	}
+	return c.connectedSince
+}
+
+// limited records a message from the charge station that was rejected because of a limit
+func (c *trackedConnection) limited(reason string) {
+	switch reason {
+	case limitReasonRate:
+		c.rateLimited.Add(1)
+	case limitReasonSize:
+		c.oversized.Add(1)
+	}
+	limitedMessages.WithLabelValues(reason).Inc()
+}
+
+// sent records a message written to the charge station
+func (c *trackedConnection) sent(size int) {
+	c.bytesOut.Add(int64(size))
+	c.lastMessageAt.Store(time.Now().UnixNano())
+}
+
+// called records a call from the CSMS that has been sent to the charge station
+func (c *trackedConnection) called(messageId, action string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pendingCall = &PendingCall{
+		MessageId: messageId,
+		Action:    action,
+		SentAt:    time.Now().UTC(),
+	}
+}
+
+// responded records a response from the charge station to a call from the CSMS,
+// returning the action of the call if it is the pending call
+func (c *trackedConnection) responded(messageId string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pendingCall != nil && c.pendingCall.MessageId == messageId {
+		action := c.pendingCall.Action
+		c.pendingCall = nil
+		return action
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+// drainCloseReason is the websocket close reason sent to charge stations whose connection
+// is closed by a drain
+const drainCloseReason = "gateway draining"
+
+// Drainer takes a gateway instance out of service without disconnecting every charge
+// station at once. Once draining, the gateway refuses new connections, reports itself as
+// unhealthy and closes the existing connections one by one, spread over the drain window,
+// so that the charge stations reconnect to other instances gradually.
+type Drainer struct {
+	connections *ConnectionTracker
+	window      time.Duration
+
+	draining  atomic.Bool
+	startOnce sync.Once
+	done      chan struct{}
+}
+
+type DrainOpt func(*Drainer)
+
+// WithDrainWindow sets the period over which the existing connections are closed
+func WithDrainWindow(window time.Duration) DrainOpt {
+	return func(d *Drainer) {
+		d.window = window
+	}
+}
+
+// NewDrainer creates a Drainer that closes the connections recorded by connections
+func NewDrainer(connections *ConnectionTracker, opts ...DrainOpt) *Drainer {
+	d := &Drainer{
+		connections: connections,
+		window:      30 * time.Second,
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Draining reports whether the gateway is draining
+func (d *Drainer) Draining() bool {
+	return d != nil && d.draining.Load()
+}
+
+// Start starts draining the gateway, if it is not already draining, and returns
+// immediately
+func (d *Drainer) Start() {
+	d.startOnce.Do(func() {
+		d.draining.Store(true)
+		go d.drain()
+	})
+}
+
+// Drain starts draining the gateway, if it is not already draining, and waits until all
+// the connections that existed when the drain started have closed or ctx is done
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.Start()
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Drainer) drain() {
+	defer close(d.done)
+
+	conns := d.connections.all()
+	slog.Info("draining gateway", "connections", len(conns), "window", d.window)
+	if len(conns) == 0 {
+		return
+	}
+
+	// each connection is closed at a random time within its own slot of the window, so
+	// that the closures are evenly spread but do not follow a predictable pattern
+	slot := d.window / time.Duration(len(conns))
+	start := time.Now()
+	for i, conn := range conns {
+		at := time.Duration(i) * slot
+		if slot > 0 {
+			at += rand.N(slot)
+		}
+		if wait := time.Until(start.Add(at)); wait > 0 {
+			time.Sleep(wait)
+		}
+		slog.Info("closing connection to drain gateway", "clientId", conn.id)
+		conn.close(websocket.StatusGoingAway, drainCloseReason)
+	}
+
+	for _, conn := range conns {
+		<-conn.done
+	}
+	slog.Info("gateway drained")
+}
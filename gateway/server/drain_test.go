@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func TestDrainerClosesConnectionsAndRefusesNewOnes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	require.NoError(t, broker.Serve())
+	defer func() {
+		_ = broker.Close()
+	}()
+	client := startEchoManager(ctx, t, broker, addr)
+	defer func() {
+		_ = client.Disconnect(ctx)
+	}()
+
+	mockRegistry := registry.NewMockRegistry()
+	for _, id := range []string{"cs001", "cs002", "cs003"} {
+		mockRegistry.ChargeStations[id] = &registry.ChargeStation{
+			ClientId:             id,
+			SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+			Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+		}
+	}
+
+	connections := server.NewConnectionTracker()
+	drainer := server.NewDrainer(connections, server.WithDrainWindow(200*time.Millisecond))
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections),
+		server.WithDrainer(drainer)))
+	defer srv.Close()
+
+	dial := func(id string) (*websocket.Conn, *http.Response, error) {
+		authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:password", id)))
+		return websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, id), &websocket.DialOptions{
+			Subprotocols: []string{"ocpp2.0.1"},
+			HTTPHeader: http.Header{
+				"authorization": []string{authHeader},
+			},
+		})
+	}
+
+	var conns []*websocket.Conn
+	for _, id := range []string{"cs001", "cs002"} {
+		conn, _, err := dial(id)
+		require.NoError(t, err)
+		conns = append(conns, conn)
+	}
+	require.Eventually(t, func() bool {
+		return len(connections.List()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	status := server.NewStatusHandler(server.WithDrainControl(drainer))
+	health := func() int {
+		w := httptest.NewRecorder()
+		status.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, health())
+
+	// the websocket is only closed once the charge station reads the close frame
+	closed := make(chan websocket.CloseError, len(conns))
+	for _, conn := range conns {
+		go func(conn *websocket.Conn) {
+			_, _, err := conn.Read(ctx)
+			var closeErr websocket.CloseError
+			if assert.ErrorAs(t, err, &closeErr) {
+				closed <- closeErr
+			}
+		}(conn)
+	}
+
+	require.NoError(t, drainer.Drain(ctx))
+	assert.True(t, drainer.Draining())
+	assert.Equal(t, http.StatusServiceUnavailable, health())
+	assert.Empty(t, connections.List())
+
+	for range conns {
+		closeErr := <-closed
+		assert.Equal(t, websocket.StatusGoingAway, closeErr.Code)
+		assert.Equal(t, "gateway draining", closeErr.Reason)
+	}
+
+	_, resp, err := dial("cs003")
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestDrainerWithNoConnections(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	drainer := server.NewDrainer(server.NewConnectionTracker())
+	assert.False(t, drainer.Draining())
+	require.NoError(t, drainer.Drain(ctx))
+	assert.True(t, drainer.Draining())
+}
+
+func TestWebsocketServerCloseDisconnectsFromBroker(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	require.NoError(t, broker.Serve())
+	defer func() {
+		_ = broker.Close()
+	}()
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["dupCS"] = &registry.ChargeStation{
+		ClientId:             "dupCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	handler := server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttPoolSize(2),
+		server.WithMqttClientIdPrefix("gw1"),
+		server.WithDeviceRegistry(mockRegistry))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	conn, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close(websocket.StatusNormalClosure, "OK"))
+
+	connected := func() int {
+		count := 0
+		for id, cl := range broker.Clients.GetAll() {
+			if (id == "gw1-0" || id == "gw1-1") && !cl.Closed() {
+				count++
+			}
+		}
+		return count
+	}
+	require.Eventually(t, func() bool { return connected() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	handler.Close()
+
+	assert.Eventually(t, func() bool { return connected() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
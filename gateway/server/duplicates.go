@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+// DuplicateConnectionPolicy determines what happens when a charge station connects
+// whilst it already has a connection to a gateway
+type DuplicateConnectionPolicy string
+
+const (
+	// DuplicateConnectionCloseOld closes the existing connection in favour of the new one
+	DuplicateConnectionCloseOld DuplicateConnectionPolicy = "close-old"
+	// DuplicateConnectionRejectNew keeps the existing connection and rejects the new one
+	DuplicateConnectionRejectNew DuplicateConnectionPolicy = "reject-new"
+)
+
+// ParseDuplicateConnectionPolicy converts a string to a DuplicateConnectionPolicy
+func ParseDuplicateConnectionPolicy(policy string) (DuplicateConnectionPolicy, error) {
+	switch p := DuplicateConnectionPolicy(policy); p {
+	case DuplicateConnectionCloseOld, DuplicateConnectionRejectNew:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown duplicate connection policy: %s", policy)
+	}
+}
+
+const (
+	closeReasonReplaced         = "replaced by a newer connection"
+	closeReasonAlreadyConnected = "already connected"
+)
+
+// duplicateCloseTimeout is the time that a new connection will wait for the connection
+// that it replaces to be closed
+const duplicateCloseTimeout = 5 * time.Second
+
+// replace closes the existing connection for a charge station that has connected again to
+// this gateway and waits for the existing connection to finish
+func (s *WebsocketHandler) replace(ctx context.Context, existing *trackedConnection) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("duplicate connection", trace.WithAttributes(
+		attribute.String("duplicate.policy", string(DuplicateConnectionCloseOld)),
+		attribute.String("duplicate.gateway_id", s.mqttClientIdPrefix),
+		attribute.String("duplicate.connected_since", existing.connectedSince.Format(time.RFC3339Nano))))
+	slog.Warn("duplicate connection - closing existing connection", "clientId", existing.id,
+		"connectedSince", existing.connectedSince)
+
+	existing.supersede(websocket.StatusPolicyViolation, closeReasonReplaced)
+
+	select {
+	case <-existing.done:
+	case <-time.After(duplicateCloseTimeout):
+		slog.Warn("timeout waiting for existing connection to close", "clientId", existing.id)
+	case <-ctx.Done():
+	}
+}
+
+// handleGatewayEvent applies the duplicate connection policy when another gateway instance
+// reports that a charge station connected to this gateway has connected to it
+func (s *WebsocketHandler) handleGatewayEvent(msg *paho.Publish) {
+	var event connectionEvent
+	err := json.Unmarshal(msg.Payload, &event)
+	if err != nil {
+		slog.Warn("unmarshalling connection event", "topic", msg.Topic, "err", err)
+		return
+	}
+	if event.Type != connectionEventConnected || event.GatewayId == s.mqttClientIdPrefix {
+		return
+	}
+
+	local := s.connections.get(event.ClientId)
+	if local == nil {
+		return
+	}
+
+	// the connection on this gateway loses if the policy prefers the other connection
+	otherIsNewer := local.event.olderThan(&event)
+	var closeLocal bool
+	switch s.duplicateConnectionPolicy {
+	case DuplicateConnectionCloseOld:
+		closeLocal = otherIsNewer
+	case DuplicateConnectionRejectNew:
+		closeLocal = !otherIsNewer
+	}
+
+	_, span := s.tracer.Start(context.Background(), "duplicate connection",
+		trace.WithAttributes(
+			attribute.String("csId", event.ClientId),
+			attribute.String("duplicate.policy", string(s.duplicateConnectionPolicy)),
+			attribute.String("duplicate.gateway_id", event.GatewayId),
+			attribute.Bool("duplicate.close_local", closeLocal),
+		))
+	defer span.End()
+
+	if closeLocal {
+		slog.Warn("duplicate connection on another gateway - closing connection", "clientId", event.ClientId,
+			"policy", s.duplicateConnectionPolicy, "otherGatewayId", event.GatewayId)
+		reason := closeReasonReplaced
+		if s.duplicateConnectionPolicy == DuplicateConnectionRejectNew {
+			reason = closeReasonAlreadyConnected
+		}
+		local.supersede(websocket.StatusPolicyViolation, reason)
+		return
+	}
+
+	if s.duplicateConnectionPolicy == DuplicateConnectionRejectNew {
+		// announce the existing connection again so the other gateway closes the new one
+		slog.Warn("duplicate connection on another gateway - announcing existing connection", "clientId", event.ClientId,
+			"otherGatewayId", event.GatewayId)
+		go publishConnectionEvent(context.Background(), s.tracer, s.transport, s.mqttConnectTimeout, s.mqttTopicPrefix, local.event)
+	}
+}
+
+// olderThan reports whether the connection described by e was established before the
+// connection described by other. Ties are broken using the gateway id so that both
+// gateways reach the same conclusion.
+func (e *connectionEvent) olderThan(other *connectionEvent) bool {
+	if e.Timestamp.Equal(other.Timestamp) {
+		return e.GatewayId < other.GatewayId
+	}
+	return e.Timestamp.Before(other.Timestamp)
+}
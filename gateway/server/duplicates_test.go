@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func newDuplicateTestGateway(t *testing.T, brokerUrl string, gatewayId string, policy server.DuplicateConnectionPolicy) (*httptest.Server, *server.ConnectionTracker) {
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["dupCS"] = &registry.ChargeStation{
+		ClientId:             "dupCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	connections := server.NewConnectionTracker()
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrlString(brokerUrl),
+		server.WithMqttClientIdPrefix(gatewayId),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections),
+		server.WithDuplicateConnectionPolicy(policy)))
+	return srv, connections
+}
+
+func dialDuplicateTestStation(ctx context.Context, srv *httptest.Server) (*websocket.Conn, *http.Response, error) {
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("dupCS:password"))
+	return websocket.Dial(ctx, fmt.Sprintf("%s/ws/dupCS", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp1.6"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+}
+
+func startDuplicateTestBroker(t *testing.T) string {
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = broker.Close()
+	})
+	return addr.String()
+}
+
+func requireClosedWith(ctx context.Context, t *testing.T, conn *websocket.Conn, code websocket.StatusCode, reason string) {
+	_, _, err := conn.Read(ctx)
+	var closeErr websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, code, closeErr.Code)
+	assert.Equal(t, reason, closeErr.Reason)
+}
+
+func TestDuplicateConnectionCloseOld(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, connections := newDuplicateTestGateway(t, startDuplicateTestBroker(t), "gateway-a", server.DuplicateConnectionCloseOld)
+	defer srv.Close()
+
+	first, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = first.Close(websocket.StatusNormalClosure, "OK")
+	}()
+	require.Eventually(t, func() bool {
+		_, ok := connections.Get("dupCS")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	firstInfo, _ := connections.Get("dupCS")
+
+	second, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = second.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	requireClosedWith(ctx, t, first, websocket.StatusPolicyViolation, "replaced by a newer connection")
+
+	info, ok := connections.Get("dupCS")
+	require.True(t, ok)
+	assert.True(t, info.ConnectedSince.After(firstInfo.ConnectedSince))
+}
+
+func TestDuplicateConnectionRejectNew(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, connections := newDuplicateTestGateway(t, startDuplicateTestBroker(t), "gateway-a", server.DuplicateConnectionRejectNew)
+	defer srv.Close()
+
+	first, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = first.Close(websocket.StatusNormalClosure, "OK")
+	}()
+	require.Eventually(t, func() bool {
+		_, ok := connections.Get("dupCS")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, resp, err := dialDuplicateTestStation(ctx, srv)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	_, ok := connections.Get("dupCS")
+	assert.True(t, ok)
+}
+
+func TestDuplicateConnectionAcrossGateways(t *testing.T) {
+	tests := map[server.DuplicateConnectionPolicy]struct {
+		closedOld bool
+		reason    string
+	}{
+		server.DuplicateConnectionCloseOld:  {closedOld: true, reason: "replaced by a newer connection"},
+		server.DuplicateConnectionRejectNew: {closedOld: false, reason: "already connected"},
+	}
+
+	for policy, tc := range tests {
+		t.Run(string(policy), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+
+			brokerUrl := startDuplicateTestBroker(t)
+
+			srvA, connectionsA := newDuplicateTestGateway(t, brokerUrl, "gateway-a", policy)
+			defer srvA.Close()
+			srvB, connectionsB := newDuplicateTestGateway(t, brokerUrl, "gateway-b", policy)
+			defer srvB.Close()
+
+			// connect a station to gateway b first so that its pool is subscribed to events
+			other, _, err := dialDuplicateTestStation(ctx, srvB)
+			require.NoError(t, err)
+			require.NoError(t, other.Close(websocket.StatusNormalClosure, "OK"))
+			require.Eventually(t, func() bool {
+				return len(connectionsB.List()) == 0
+			}, 5*time.Second, 10*time.Millisecond)
+
+			old, _, err := dialDuplicateTestStation(ctx, srvA)
+			require.NoError(t, err)
+			defer func() {
+				_ = old.Close(websocket.StatusNormalClosure, "OK")
+			}()
+			require.Eventually(t, func() bool {
+				_, ok := connectionsA.Get("dupCS")
+				return ok
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// ensure the new connection is later than the old one
+			time.Sleep(10 * time.Millisecond)
+
+			newer, _, err := dialDuplicateTestStation(ctx, srvB)
+			require.NoError(t, err)
+			defer func() {
+				_ = newer.Close(websocket.StatusNormalClosure, "OK")
+			}()
+
+			if tc.closedOld {
+				requireClosedWith(ctx, t, old, websocket.StatusPolicyViolation, tc.reason)
+			} else {
+				requireClosedWith(ctx, t, newer, websocket.StatusPolicyViolation, tc.reason)
+			}
+		})
+	}
+}
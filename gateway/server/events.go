@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+type connectionEventType string
+
+const (
+	connectionEventConnected    connectionEventType = "connected"
+	connectionEventDisconnected connectionEventType = "disconnected"
+)
+
+// connectionEvent is published to the CSMS when a charge station's websocket connection
+// is established or closed
+type connectionEvent struct {
+	Type            connectionEventType `json:"type"`
+	ClientId        string              `json:"clientId"`
+	Protocol        string              `json:"protocol"`
+	SecurityProfile int                 `json:"securityProfile"`
+	RemoteAddr      string              `json:"remoteAddr,omitempty"`
+	TLSCertHash     string              `json:"tlsCertHash,omitempty"`
+	CloseCode       int                 `json:"closeCode,omitempty"`
+	CloseReason     string              `json:"closeReason,omitempty"`
+	GatewayId       string              `json:"gatewayId,omitempty"`
+	Timestamp       time.Time           `json:"timestamp"`
+}
+
+func newConnectionEvent(r *http.Request, clientId string, cs *registry.ChargeStation, protocol, gatewayId string, trustProxyHeaders bool) *connectionEvent {
+	event := &connectionEvent{
+		Type:            connectionEventConnected,
+		ClientId:        clientId,
+		Protocol:        protocol,
+		SecurityProfile: int(cs.SecurityProfile),
+		RemoteAddr:      r.RemoteAddr,
+		GatewayId:       gatewayId,
+		Timestamp:       time.Now().UTC(),
+	}
+
+	if trustProxyHeaders {
+		if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+			clientAddr, _, _ := strings.Cut(forwardedFor, ",")
+			event.RemoteAddr = strings.TrimSpace(clientAddr)
+		}
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		certHash := sha256.Sum256(r.TLS.PeerCertificates[0].Raw)
+		event.TLSCertHash = base64.StdEncoding.EncodeToString(certHash[:])
+	}
+
+	return event
+}
+
+// disconnected returns the event to publish when the connection described by the
+// connected event is closed with err
+func (e *connectionEvent) disconnected(err error) *connectionEvent {
+	event := *e
+	event.Type = connectionEventDisconnected
+	event.Timestamp = time.Now().UTC()
+
+	var closeErr websocket.CloseError
+	switch {
+	case errors.As(err, &closeErr):
+		event.CloseCode = int(closeErr.Code)
+		event.CloseReason = closeErr.Reason
+	case err != nil:
+		event.CloseCode = int(websocket.StatusAbnormalClosure)
+		event.CloseReason = err.Error()
+	default:
+		event.CloseCode = int(websocket.StatusNormalClosure)
+	}
+
+	return &event
+}
+
+// publishConnectionEvent publishes the event to the CSMS. Publication is best effort: if the
+// MQTT broker is not available within timeout then the event is discarded.
+func publishConnectionEvent(ctx context.Context, tracer trace.Tracer, transport csmsTransport, timeout time.Duration, topicPrefix string, event *connectionEvent) {
+	topic := fmt.Sprintf("%s/events/%s/%s", topicPrefix, event.Protocol, event.ClientId)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("marshalling connection event", "clientId", event.ClientId, "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	newCtx, span := tracer.Start(ctx,
+		fmt.Sprintf("%s/events/%s/# publish", topicPrefix, event.Protocol),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("mqtt"),
+			semconv.MessagingMessagePayloadSizeBytes(len(data)),
+			semconv.MessagingOperationKey.String("publish"),
+			attribute.String("csId", event.ClientId),
+			attribute.String("connection.event", string(event.Type)),
+		))
+	defer span.End()
+
+	err = transport.awaitConnection(newCtx, event.ClientId)
+	if err == nil {
+		err = transport.publish(newCtx, event.ClientId, &paho.Publish{
+			Topic:   topic,
+			QoS:     1,
+			Payload: data,
+			Properties: &paho.PublishProperties{
+				ContentType: "application/json",
+			},
+		})
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "publish failed")
+		span.RecordError(err)
+		slog.Warn("discarding connection event", "clientId", event.ClientId, "type", event.Type, "err", err)
+	}
+}
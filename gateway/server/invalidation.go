@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"golang.org/x/exp/slog"
+)
+
+// registryInvalidation is published by the CSMS when the details used to authenticate
+// a charge station change. If neither field is set then all details are invalidated.
+type registryInvalidation struct {
+	ClientId        string `json:"clientId,omitempty"`
+	CertificateHash string `json:"certificateHash,omitempty"`
+}
+
+// registryInvalidationHandler returns a handler that tells invalidator to discard the
+// details described by the registry invalidation messages that it receives
+func registryInvalidationHandler(invalidator registry.Invalidator) func(*paho.Publish) {
+	return func(msg *paho.Publish) {
+		var invalidation registryInvalidation
+		err := json.Unmarshal(msg.Payload, &invalidation)
+		if err != nil {
+			slog.Warn("unmarshalling registry invalidation", "topic", msg.Topic, "err", err)
+			return
+		}
+
+		if invalidation.ClientId != "" {
+			invalidator.InvalidateChargeStation(invalidation.ClientId)
+		}
+		if invalidation.CertificateHash != "" {
+			invalidator.InvalidateCertificate(invalidation.CertificateHash)
+		}
+		if invalidation.ClientId == "" && invalidation.CertificateHash == "" {
+			invalidator.InvalidateAll()
+		}
+		slog.Debug("invalidated registry cache", "clientId", invalidation.ClientId,
+			"certificateHash", invalidation.CertificateHash)
+	}
+}
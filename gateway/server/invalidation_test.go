@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+)
+
+type invalidatingRegistry struct {
+	*registry.MockRegistry
+	mu          sync.Mutex
+	invalidated []string
+}
+
+func (r *invalidatingRegistry) InvalidateChargeStation(clientId string) {
+	r.record("cs:" + clientId)
+}
+
+func (r *invalidatingRegistry) InvalidateCertificate(certHash string) {
+	r.record("cert:" + certHash)
+}
+
+func (r *invalidatingRegistry) InvalidateAll() {
+	r.record("all")
+}
+
+func (r *invalidatingRegistry) record(s string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invalidated = append(r.invalidated, s)
+}
+
+func (r *invalidatingRegistry) invalidations() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.invalidated...)
+}
+
+func TestWebsocketHandlerInvalidatesRegistry(t *testing.T) {
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	defer func() {
+		_ = broker.Close()
+	}()
+
+	reg := &invalidatingRegistry{MockRegistry: registry.NewMockRegistry()}
+
+	// the gateway subscribes to the notifications before any charge station has connected
+	handler := server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(reg))
+	defer handler.Close()
+
+	want := []string{"cs:cs001", "cert:abc", "all"}
+	require.Eventually(t, func() bool {
+		// keep publishing until the gateway has subscribed
+		if len(reg.invalidations()) == 0 {
+			_ = broker.Publish("cs/registry", []byte(`{"clientId":"cs001"}`), false, 1)
+		}
+		return len(reg.invalidations()) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, broker.Publish("cs/registry", []byte(`{"certificateHash":"abc"}`), false, 1))
+	require.NoError(t, broker.Publish("cs/registry", []byte(`{}`), false, 1))
+
+	require.Eventually(t, func() bool {
+		return len(reg.invalidations()) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, want, reg.invalidations()[len(reg.invalidations())-3:])
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxMessageSize is the largest message accepted from a charge station if no
+// other limit is configured
+const defaultMaxMessageSize = 32768
+
+// RateLimit is a token bucket limit on the rate at which a charge station can make calls
+type RateLimit struct {
+	// Rate is the number of calls per second that are added to the bucket
+	Rate float64
+	// Burst is the size of the bucket: the number of calls that can be made at once
+	Burst int
+}
+
+// ParseRateLimit parses a rate limit of the form [<action>,...=]<rate>:<burst>, e.g.
+// "MeterValues,DataTransfer=0.5:10". It returns the actions that the limit applies to,
+// which is empty if the limit applies to all other calls.
+func ParseRateLimit(s string) ([]string, RateLimit, error) {
+	var actions []string
+	limit := s
+	if actionList, rest, ok := strings.Cut(s, "="); ok {
+		for _, action := range strings.Split(actionList, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				actions = append(actions, action)
+			}
+		}
+		if len(actions) == 0 {
+			return nil, RateLimit{}, fmt.Errorf("rate limit %q has no actions", s)
+		}
+		limit = rest
+	}
+
+	rateStr, burstStr, ok := strings.Cut(limit, ":")
+	if !ok {
+		return nil, RateLimit{}, fmt.Errorf("rate limit %q must have the form [<action>,...=]<rate>:<burst>", s)
+	}
+	r, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || r <= 0 {
+		return nil, RateLimit{}, fmt.Errorf("rate limit %q has invalid rate: %s", s, rateStr)
+	}
+	burst, err := strconv.Atoi(burstStr)
+	if err != nil || burst <= 0 {
+		return nil, RateLimit{}, fmt.Errorf("rate limit %q has invalid burst: %s", s, burstStr)
+	}
+
+	return actions, RateLimit{Rate: r, Burst: burst}, nil
+}
+
+// actionRateLimit is a rate limit shared by a class of actions
+type actionRateLimit struct {
+	actions []string
+	limit   RateLimit
+}
+
+// rateLimiter holds the token buckets for a single charge station connection
+type rateLimiter struct {
+	defaultLimiter *rate.Limiter
+	actionLimiters map[string]*rate.Limiter
+}
+
+func newRateLimiter(defaultLimit *RateLimit, actionLimits []actionRateLimit) *rateLimiter {
+	l := &rateLimiter{
+		actionLimiters: make(map[string]*rate.Limiter),
+	}
+	if defaultLimit != nil {
+		l.defaultLimiter = rate.NewLimiter(rate.Limit(defaultLimit.Rate), defaultLimit.Burst)
+	}
+	for _, actionLimit := range actionLimits {
+		limiter := rate.NewLimiter(rate.Limit(actionLimit.limit.Rate), actionLimit.limit.Burst)
+		for _, action := range actionLimit.actions {
+			l.actionLimiters[action] = limiter
+		}
+	}
+	return l
+}
+
+// allow reports whether a call for action can be made now
+func (l *rateLimiter) allow(action string) bool {
+	if limiter, ok := l.actionLimiters[action]; ok {
+		return limiter.Allow()
+	}
+	if l.defaultLimiter != nil {
+		return l.defaultLimiter.Allow()
+	}
+	return true
+}
+
+const (
+	limitReasonRate = "rate"
+	limitReasonSize = "size"
+)
+
+var limitedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_limited_messages_total",
+	Help: "The number of messages from charge stations that were rejected because of a rate or size limit",
+}, []string{"reason"})
+
+// errMessageTooLarge is returned when a message from the charge station is larger than the
+// maximum message size. The rest of the message is not read, so the connection cannot be used
+// for any more messages.
+var errMessageTooLarge = errors.New("message too large")
+
+// callMessageId returns the message id from the start of a call without decoding the rest
+// of the message, which may be incomplete
+func callMessageId(b []byte) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	var tokens [3]json.Token
+	for i := range tokens {
+		token, err := dec.Token()
+		if err != nil {
+			return "", false
+		}
+		tokens[i] = token
+	}
+	if delim, ok := tokens[0].(json.Delim); !ok || delim != '[' {
+		return "", false
+	}
+	if messageType, ok := tokens[1].(float64); !ok || messageType != float64(ocpp.MessageTypeCall) {
+		return "", false
+	}
+	messageId, ok := tokens[2].(string)
+	return messageId, ok
+}
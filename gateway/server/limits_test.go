@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := map[string]struct {
+		actions []string
+		limit   server.RateLimit
+		err     bool
+	}{
+		"2:10":                          {limit: server.RateLimit{Rate: 2, Burst: 10}},
+		"MeterValues=0.5:5":             {actions: []string{"MeterValues"}, limit: server.RateLimit{Rate: 0.5, Burst: 5}},
+		"MeterValues, DataTransfer=1:3": {actions: []string{"MeterValues", "DataTransfer"}, limit: server.RateLimit{Rate: 1, Burst: 3}},
+		"=1:3":                          {err: true},
+		"1":                             {err: true},
+		"x:1":                           {err: true},
+		"1:0":                           {err: true},
+		"-1:1":                          {err: true},
+	}
+
+	for s, tc := range tests {
+		t.Run(s, func(t *testing.T) {
+			actions, limit, err := server.ParseRateLimit(s)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.actions, actions)
+			assert.Equal(t, tc.limit, limit)
+		})
+	}
+}
+
+func newLimitsTestGateway(ctx context.Context, t *testing.T, opts ...server.WebsocketOpt) (*websocket.Conn, *server.ConnectionTracker, func()) {
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	client := startEchoManager(ctx, t, broker, addr)
+
+	cs := &registry.ChargeStation{
+		ClientId:             "limitedCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	connections := server.NewConnectionTracker()
+	opts = append([]server.WebsocketOpt{
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections),
+	}, opts...)
+	srv := httptest.NewServer(server.NewWebsocketHandler(opts...))
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+
+	return conn, connections, func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+		srv.Close()
+		_ = client.Disconnect(ctx)
+		_ = broker.Close()
+	}
+}
+
+func call(ctx context.Context, t *testing.T, conn *websocket.Conn, messageId, action, payload string) *ocpp.Message {
+	data, err := json.Marshal(ocpp.Message{
+		MessageTypeId: ocpp.MessageTypeCall,
+		MessageId:     messageId,
+		Data: []json.RawMessage{
+			json.RawMessage(fmt.Sprintf("%q", action)),
+			json.RawMessage(payload),
+		},
+	})
+	require.NoError(t, err)
+	err = conn.Write(ctx, websocket.MessageText, data)
+	require.NoError(t, err)
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	var msg ocpp.Message
+	err = json.Unmarshal(b, &msg)
+	require.NoError(t, err)
+	require.Equal(t, messageId, msg.MessageId)
+	return &msg
+}
+
+func TestWebsocketHandlerRateLimitsCalls(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	conn, connections, cleanup := newLimitsTestGateway(ctx, t,
+		server.WithRateLimit(server.RateLimit{Rate: 0.001, Burst: 1}, "MeterValues", "DataTransfer"))
+	defer cleanup()
+
+	msg := call(ctx, t, conn, "1", "MeterValues", `{}`)
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	// MeterValues and DataTransfer share a bucket
+	msg = call(ctx, t, conn, "2", "DataTransfer", `{}`)
+	assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageTypeId)
+	assert.Equal(t, `"GenericError"`, string(msg.Data[0]))
+
+	// other actions are not limited
+	msg = call(ctx, t, conn, "3", "Heartbeat", `{}`)
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	info, ok := connections.Get("limitedCS")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), info.RateLimited)
+	assert.Equal(t, int64(0), info.Oversized)
+}
+
+func TestWebsocketHandlerLimitsMessageSize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	conn, connections, cleanup := newLimitsTestGateway(ctx, t,
+		server.WithMaxMessageSize(100))
+	defer cleanup()
+
+	// a message of exactly the maximum size is accepted
+	msg := call(ctx, t, conn, "1", "DataTransfer", fmt.Sprintf("%q", strings.Repeat("x", 75)))
+	assert.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	msg = call(ctx, t, conn, "2", "DataTransfer", fmt.Sprintf("%q", strings.Repeat("x", 120)))
+	assert.Equal(t, ocpp.MessageTypeCallError, msg.MessageTypeId)
+	assert.Equal(t, `"FormatViolation"`, string(msg.Data[0]))
+
+	info, ok := connections.Get("limitedCS")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), info.Oversized)
+
+	// the rest of the oversized message is not read, so the connection is closed
+	_, _, err := conn.Read(ctx)
+	assert.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+}
+
+func TestWebsocketHandlerClosesConnectionForOversizedResult(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	conn, connections, cleanup := newLimitsTestGateway(ctx, t,
+		server.WithMaxMessageSize(100))
+	defer cleanup()
+
+	err := conn.Write(ctx, websocket.MessageText, []byte(fmt.Sprintf(`[3,"1",{"data":"%s"}]`, strings.Repeat("x", 500))))
+	require.NoError(t, err)
+	_, _, err = conn.Read(ctx)
+	assert.Equal(t, websocket.StatusMessageTooBig, websocket.CloseStatus(err))
+
+	info, ok := connections.Get("limitedCS")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), info.Oversized)
+}
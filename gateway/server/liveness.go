@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"nhooyr.io/websocket"
+)
+
+const (
+	closeReasonPongTimeout = "pong timeout"
+	closeReasonIdleTimeout = "idle timeout"
+)
+
+// goMonitorLiveness closes the connection if the charge station does not answer a ping within
+// pongTimeout or does not send a message within idleTimeout. A zero pingInterval disables
+// pings and a zero idleTimeout disables the idle check.
+func goMonitorLiveness(ctx context.Context, tracer trace.Tracer, conn *trackedConnection, pingInterval, pongTimeout, idleTimeout time.Duration) {
+	if pingInterval <= 0 && idleTimeout <= 0 {
+		return
+	}
+
+	go func() {
+		var pingCh, idleCh <-chan time.Time
+		if pingInterval > 0 {
+			pingTicker := time.NewTicker(pingInterval)
+			defer pingTicker.Stop()
+			pingCh = pingTicker.C
+		}
+		if idleTimeout > 0 {
+			// check often enough that the connection is closed soon after it becomes idle
+			idleTicker := time.NewTicker(idleTimeout / 10)
+			defer idleTicker.Stop()
+			idleCh = idleTicker.C
+		}
+
+		for {
+			select {
+			case <-pingCh:
+				pingCtx, cancel := context.WithTimeout(ctx, pongTimeout)
+				err := conn.wsConn.Ping(pingCtx)
+				cancel()
+				if err != nil && ctx.Err() == nil {
+					if errors.Is(err, context.DeadlineExceeded) {
+						// the websocket library has already closed the connection
+						conn.recordClose(websocket.StatusAbnormalClosure, closeReasonPongTimeout)
+						traceLivenessFailure(tracer, conn, websocket.StatusAbnormalClosure, closeReasonPongTimeout, err)
+					}
+					return
+				}
+			case now := <-idleCh:
+				if now.Sub(conn.lastReceived()) >= idleTimeout {
+					conn.close(websocket.StatusPolicyViolation, closeReasonIdleTimeout)
+					traceLivenessFailure(tracer, conn, websocket.StatusPolicyViolation, closeReasonIdleTimeout, nil)
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func traceLivenessFailure(tracer trace.Tracer, conn *trackedConnection, code websocket.StatusCode, reason string, err error) {
+	_, span := tracer.Start(context.Background(), "websocket close",
+		trace.WithAttributes(
+			attribute.String("csId", conn.id),
+			attribute.Int("websocket.close_code", int(code)),
+			attribute.String("websocket.close_reason", reason),
+		))
+	defer span.End()
+	span.SetStatus(codes.Error, reason)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	slog.Warn("closing unresponsive connection", "clientId", conn.id, "reason", reason,
+		"lastReceived", conn.lastReceived())
+}
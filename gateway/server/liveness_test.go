@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func newLivenessTestGateway(t *testing.T, opts ...server.WebsocketOpt) (*httptest.Server, *server.ConnectionTracker) {
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["dupCS"] = &registry.ChargeStation{
+		ClientId:             "dupCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	connections := server.NewConnectionTracker()
+	opts = append([]server.WebsocketOpt{
+		server.WithMqttBrokerUrlString(startDuplicateTestBroker(t)),
+		server.WithMqttClientIdPrefix("gateway-test"),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections),
+	}, opts...)
+	srv := httptest.NewServer(server.NewWebsocketHandler(opts...))
+	return srv, connections
+}
+
+func TestWebsocketHandlerClosesConnectionWithoutPong(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, connections := newLivenessTestGateway(t,
+		server.WithPingInterval(100*time.Millisecond),
+		server.WithPongTimeout(200*time.Millisecond))
+	defer srv.Close()
+
+	// the client never reads from the connection so it never answers a ping
+	conn, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	require.Eventually(t, func() bool {
+		_, ok := connections.Get("dupCS")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, ok := connections.Get("dupCS")
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebsocketHandlerKeepsConnectionThatAnswersPings(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, connections := newLivenessTestGateway(t,
+		server.WithPingInterval(50*time.Millisecond),
+		server.WithPongTimeout(200*time.Millisecond))
+	defer srv.Close()
+
+	conn, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	// reading from the connection answers the pings
+	readCtx := conn.CloseRead(ctx)
+
+	time.Sleep(500 * time.Millisecond)
+	require.NoError(t, readCtx.Err())
+	_, ok := connections.Get("dupCS")
+	assert.True(t, ok)
+}
+
+func TestWebsocketHandlerClosesIdleConnection(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv, connections := newLivenessTestGateway(t,
+		server.WithIdleTimeout(200*time.Millisecond))
+	defer srv.Close()
+
+	conn, _, err := dialDuplicateTestStation(ctx, srv)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	requireClosedWith(ctx, t, conn, websocket.StatusPolicyViolation, "idle timeout")
+
+	require.Eventually(t, func() bool {
+		_, ok := connections.Get("dupCS")
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
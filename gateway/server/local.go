@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/eclipse/paho.golang/paho"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/exp/slog"
+)
+
+// LocalMessage is a message from the gateway to a CSMS running in the same process
+type LocalMessage struct {
+	// Context carries the trace of the message
+	Context context.Context
+	// Topic is the MQTT topic that the message would have been published on, e.g.
+	// cs/in/ocpp2.0.1/cs001 or cs/events/ocpp2.0.1/cs001
+	Topic   string
+	Payload []byte
+}
+
+// LocalTransport connects the gateway to a CSMS running in the same process instead of
+// an MQTT broker. The messages that the gateway would publish are sent on the Messages
+// channel and the CSMS passes the messages for the charge stations to Deliver.
+type LocalTransport struct {
+	*csmsRouter
+	messages chan *LocalMessage
+}
+
+// NewLocalTransport creates a LocalTransport that holds up to bufLen messages for the CSMS
+// before the charge stations' messages are held in their outbound buffers
+func NewLocalTransport(bufLen int) *LocalTransport {
+	return &LocalTransport{
+		messages: make(chan *LocalMessage, bufLen),
+	}
+}
+
+// WithLocalTransport exchanges messages with a CSMS running in the same process instead
+// of using MQTT
+func WithLocalTransport(local *LocalTransport) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.localTransport = local
+	}
+}
+
+// Messages returns the channel that the CSMS receives the gateway's messages from
+func (l *LocalTransport) Messages() <-chan *LocalMessage {
+	return l.messages
+}
+
+// Deliver passes a message from the CSMS to the charge station that it is addressed to by
+// topic, e.g. cs/out/ocpp2.0.1/cs001. Messages for charge stations that are not connected
+// are discarded.
+func (l *LocalTransport) Deliver(ctx context.Context, topic string, payload []byte) {
+	if l.csmsRouter == nil {
+		slog.Warn("local transport not attached to a websocket handler - discarding message", "topic", topic)
+		return
+	}
+
+	correlationMap := make(map[string]string)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(correlationMap))
+	correlationData, err := json.Marshal(correlationMap)
+	if err != nil {
+		slog.Warn("marshalling correlation map", "err", err)
+	}
+
+	l.dispatch(&paho.Publish{
+		Topic:   topic,
+		Payload: payload,
+		Properties: &paho.PublishProperties{
+			ContentType:     "application/json",
+			CorrelationData: correlationData,
+		},
+	})
+}
+
+func (l *LocalTransport) start() error {
+	return nil
+}
+
+func (l *LocalTransport) awaitConnection(context.Context, string) error {
+	return nil
+}
+
+func (l *LocalTransport) publish(ctx context.Context, _ string, msg *paho.Publish) error {
+	select {
+	case l.messages <- &LocalMessage{Context: ctx, Topic: msg.Topic, Payload: msg.Payload}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *LocalTransport) close() {}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func TestLocalTransportExchangesMessagesWithTheCsms(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:             "localCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	local := server.NewLocalTransport(10)
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithLocalTransport(local),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	// answer the charge station's calls as the CSMS would
+	go func() {
+		for msg := range local.Messages() {
+			var call pipe.GatewayMessage
+			if msg.Topic != "cs/in/ocpp2.0.1/localCS" || json.Unmarshal(msg.Payload, &call) != nil {
+				continue
+			}
+			result, _ := json.Marshal(&pipe.GatewayMessage{
+				MessageType:     ocpp.MessageTypeCallResult,
+				Action:          call.Action,
+				MessageId:       call.MessageId,
+				ResponsePayload: json.RawMessage(`{"currentTime":"2023-06-15T15:05:00Z"}`),
+			})
+			local.Deliver(msg.Context, "cs/out/ocpp2.0.1/localCS", result)
+		}
+	}()
+
+	resp := call(ctx, t, conn, "1234", "Heartbeat", "{}")
+	assert.Equal(t, ocpp.MessageTypeCallResult, resp.MessageTypeId)
+	require.Len(t, resp.Data, 1)
+	assert.JSONEq(t, `{"currentTime":"2023-06-15T15:05:00Z"}`, string(resp.Data[0]))
+}
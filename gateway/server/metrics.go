@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+)
+
+const (
+	upgradeFailureUnknownStation = "unknown_station"
+	upgradeFailureUnauthorized   = "unauthorized"
+	upgradeFailureTLS            = "tls"
+	upgradeFailureRegistry       = "registry_error"
+	upgradeFailureDuplicate      = "duplicate"
+	upgradeFailureWebsocket      = "websocket"
+	upgradeFailureDraining       = "draining"
+)
+
+const (
+	messageDirectionIn  = "in"
+	messageDirectionOut = "out"
+)
+
+var activeConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "gateway_connections",
+	Help: "The number of charge stations connected to the gateway",
+}, []string{"protocol", "security_profile"})
+
+var upgradeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_upgrade_failures_total",
+	Help: "The number of charge station connections that were refused before the websocket was established",
+}, []string{"reason"})
+
+var messages = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_messages_total",
+	Help: "The number of OCPP messages received from (in) and sent to (out) charge stations",
+}, []string{"direction", "message_type", "action"})
+
+var mqttPublishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "gateway_mqtt_publish_duration_seconds",
+	Help:    "The time taken to publish a message to the MQTT broker",
+	Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+}, []string{"result"})
+
+var mqttReconnects = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "gateway_mqtt_reconnects_total",
+	Help: "The number of times a connection to the MQTT broker was re-established after being lost",
+})
+
+// maxActionLabels is the number of distinct actions recorded in the message metrics: the
+// action is chosen by the charge station, so anything beyond this is recorded as "other"
+const maxActionLabels = 256
+
+var actionLabels = struct {
+	sync.Mutex
+	seen map[string]bool
+}{seen: make(map[string]bool)}
+
+func actionLabel(action string) string {
+	if action == "" {
+		return "unknown"
+	}
+	actionLabels.Lock()
+	defer actionLabels.Unlock()
+	if actionLabels.seen[action] {
+		return action
+	}
+	if len(actionLabels.seen) >= maxActionLabels {
+		return "other"
+	}
+	actionLabels.seen[action] = true
+	return action
+}
+
+func messageTypeLabel(messageType ocpp.MessageType) string {
+	switch messageType {
+	case ocpp.MessageTypeCall:
+		return "call"
+	case ocpp.MessageTypeCallResult:
+		return "call_result"
+	case ocpp.MessageTypeCallError:
+		return "call_error"
+	default:
+		return "unknown"
+	}
+}
+
+// countMessage records an OCPP message exchanged with a charge station
+func countMessage(direction string, messageType ocpp.MessageType, action string) {
+	messages.WithLabelValues(direction, messageTypeLabel(messageType), actionLabel(action)).Inc()
+}
+
+// trackConnection records a charge station connection as active until the returned
+// function is called
+func trackConnection(protocol string, securityProfile registry.SecurityProfile) func() {
+	gauge := activeConnections.WithLabelValues(protocol, strconv.Itoa(int(securityProfile)))
+	gauge.Inc()
+	return gauge.Dec
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func scrapeMetrics(t *testing.T) string {
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.NewStatusHandler().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	b, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestMetricsRecordConnectionsAndMessages(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, _, closeGateway := newLimitsTestGateway(ctx, t)
+	defer closeGateway()
+
+	msg := call(ctx, t, conn, "1", "Heartbeat", "{}")
+	require.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+
+	metrics := scrapeMetrics(t)
+	assert.Contains(t, metrics, `gateway_connections{protocol="ocpp2.0.1",security_profile="0"} 1`)
+	assert.Contains(t, metrics, `gateway_messages_total{action="Heartbeat",direction="in",message_type="call"}`)
+	// the action of a response is the one the manager provides: the test manager provides none
+	assert.Contains(t, metrics, `gateway_messages_total{action="unknown",direction="out",message_type="call_result"}`)
+	assert.Contains(t, metrics, `gateway_mqtt_publish_duration_seconds_count{result="ok"}`)
+}
+
+func TestMetricsRecordUpgradeFailures(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["cs001"] = &registry.ChargeStation{
+		ClientId:        "cs001",
+		SecurityProfile: registry.TLSWithBasicAuth,
+	}
+	srv := httptest.NewServer(server.NewWebsocketHandler(server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	for _, id := range []string{"unknownCS", "cs001"} {
+		_, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, id), &websocket.DialOptions{
+			Subprotocols: []string{"ocpp2.0.1"},
+		})
+		require.Error(t, err)
+	}
+
+	metrics := scrapeMetrics(t)
+	assert.Contains(t, metrics, `gateway_upgrade_failures_total{reason="unknown_station"}`)
+	assert.Contains(t, metrics, `gateway_upgrade_failures_total{reason="tls"}`)
+}
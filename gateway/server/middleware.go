@@ -1,6 +1,7 @@
 package server
 
 import (
+	"crypto/subtle"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"net/http"
 	"strconv"
+	"strings"
 
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 
@@ -86,3 +88,19 @@ func TLSOffload(registry registry.DeviceRegistry) func(http.Handler) http.Handle
 		})
 	}
 }
+
+// RequireBearerToken is middleware that rejects requests that do not present
+// the token in a bearer authorization header
+func RequireBearerToken(token string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gateway admin"`)
+				writeJSONError(w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"golang.org/x/exp/slog"
+)
+
+// ocppSubprotocols are the websocket subprotocols supported by the gateway, in order of preference
+var ocppSubprotocols = []string{"ocpp2.1", "ocpp2.0.1", "ocpp1.6"}
+
+// defaultOcppSubprotocol is the subprotocol assumed when a charge station does not request one
+const defaultOcppSubprotocol = "ocpp2.0.1"
+
+var errMqttPoolClosed = errors.New("mqtt connection pool closed")
+
+// mqttPool is a set of MQTT connections that is shared by all the websocket connections
+// handled by the gateway. Messages from the CSMS are received through a single wildcard
+// subscription per protocol and are demultiplexed to the charge station that registered
+// for them. The subscriptions are held by one connection at a time: if that connection
+// goes down they are moved to another connection that is up. Messages to the CSMS are
+// spread across the connections in the pool based on the charge station id.
+type mqttPool struct {
+	*csmsRouter
+	brokerURLs        []*url.URL
+	clientIdPrefix    string
+	size              int
+	connectTimeout    time.Duration
+	connectRetryDelay time.Duration
+	keepAliveInterval uint16
+
+	startOnce   sync.Once
+	connections []*autopaho.ConnectionManager
+	startErr    error
+
+	mu sync.Mutex
+	// up holds the connections that are currently connected, indexed by their position in
+	// the pool, with nil for those that are not
+	up []*autopaho.ConnectionManager
+	// subscriber is the index of the connection that holds the subscriptions, or -1
+	subscriber int
+	// subscribed is closed once a connection holds the subscriptions
+	subscribed chan struct{}
+}
+
+func newMqttPool(handler *WebsocketHandler, router *csmsRouter) *mqttPool {
+	return &mqttPool{
+		csmsRouter:        router,
+		brokerURLs:        handler.mqttBrokerURLs,
+		clientIdPrefix:    handler.mqttClientIdPrefix,
+		size:              handler.mqttPoolSize,
+		connectTimeout:    handler.mqttConnectTimeout,
+		connectRetryDelay: handler.mqttConnectRetryDelay,
+		keepAliveInterval: handler.mqttKeepAliveInterval,
+		subscriber:        -1,
+		subscribed:        make(chan struct{}),
+	}
+}
+
+// start connects the pool to the MQTT broker. The connections are only established
+// once: subsequent calls return the result of the first call. The connections retry
+// in the background until the broker is available.
+func (m *mqttPool) start() error {
+	m.startOnce.Do(func() {
+		m.connections = make([]*autopaho.ConnectionManager, m.size)
+		m.up = make([]*autopaho.ConnectionManager, m.size)
+		for i := 0; i < m.size; i++ {
+			i := i
+			cfg := autopaho.ClientConfig{
+				BrokerUrls:        m.brokerURLs,
+				KeepAlive:         m.keepAliveInterval,
+				ConnectTimeout:    m.connectTimeout,
+				ConnectRetryDelay: m.connectRetryDelay,
+				ClientConfig: paho.ClientConfig{
+					ClientID: fmt.Sprintf("%s-%d", m.clientIdPrefix, i),
+					Router:   paho.NewSingleHandlerRouter(m.dispatch),
+					OnServerDisconnect: func(disconnect *paho.Disconnect) {
+						slog.Warn("mqtt server disconnect", "reason", disconnect.Properties.ReasonString)
+						m.connectionDown(i)
+					},
+					OnClientError: func(err error) {
+						slog.Warn("mqtt client error", "err", err)
+						m.connectionDown(i)
+					},
+				},
+			}
+			var connected atomic.Bool
+			cfg.OnConnectionUp = func(manager *autopaho.ConnectionManager, _ *paho.Connack) {
+				if connected.Swap(true) {
+					mqttReconnects.Inc()
+				}
+				m.connectionUp(i, manager)
+			}
+			conn, err := autopaho.NewConnection(context.Background(), cfg)
+			if err != nil {
+				m.startErr = fmt.Errorf("connecting to mqtt: %w", err)
+				m.closeConnections()
+				return
+			}
+			m.connections[i] = conn
+		}
+	})
+	return m.startErr
+}
+
+// connectionUp records that a connection is up. The connection takes the subscriptions
+// if no other connection holds them. The connections start clean, so a connection that
+// held the subscriptions before it went down must subscribe again.
+func (m *mqttPool) connectionUp(i int, manager *autopaho.ConnectionManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.up[i] = manager
+	if m.subscriber == i {
+		// the connection went down without it being reported
+		m.subscriber = -1
+		m.subscribed = make(chan struct{})
+	}
+	if m.subscriber == -1 {
+		m.subscribeUsing(i)
+	}
+}
+
+// connectionDown records that a connection is down. If the connection held the
+// subscriptions then they are moved to another connection that is up.
+func (m *mqttPool) connectionDown(i int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	manager := m.up[i]
+	if manager == nil {
+		return
+	}
+	m.up[i] = nil
+	if m.subscriber != i {
+		return
+	}
+
+	m.subscriber = -1
+	m.subscribed = make(chan struct{})
+	// in case the connection is still up, so that messages are not received twice
+	ctx, cancel := context.WithTimeout(context.Background(), m.connectTimeout)
+	_, _ = manager.Unsubscribe(ctx, &paho.Unsubscribe{Topics: m.topics()})
+	cancel()
+
+	for j := range m.up {
+		if m.up[j] != nil && m.subscribeUsing(j) {
+			slog.Info("moved mqtt subscriptions", "from", i, "to", j)
+			return
+		}
+	}
+	slog.Warn("no mqtt connection available for subscriptions")
+}
+
+// subscribeUsing subscribes to the messages from the CSMS using the connection at index i,
+// which must be up, and returns true if the subscriptions were made. It must be called
+// with m.mu held.
+func (m *mqttPool) subscribeUsing(i int) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), m.connectTimeout)
+	defer cancel()
+	_, err := m.up[i].Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: m.subscriptions(),
+	})
+	if err != nil {
+		slog.Error("subscribing to mqtt topics", "connection", i, "err", err)
+		return false
+	}
+
+	m.subscriber = i
+	close(m.subscribed)
+	return true
+}
+
+// topics returns the topics that the pool subscribes to
+func (m *mqttPool) topics() []string {
+	var topics []string
+	for topic := range m.subscriptions() {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+func (m *mqttPool) subscriptions() map[string]paho.SubscribeOptions {
+	subscriptions := make(map[string]paho.SubscribeOptions)
+	for _, protocol := range ocppSubprotocols {
+		subscriptions[fmt.Sprintf("%s/out/%s/#", m.topicPrefix, protocol)] = paho.SubscribeOptions{}
+	}
+	if m.eventHandler != nil {
+		subscriptions[fmt.Sprintf("%s/events/#", m.topicPrefix)] = paho.SubscribeOptions{}
+	}
+	if m.registryHandler != nil {
+		subscriptions[fmt.Sprintf("%s/registry", m.topicPrefix)] = paho.SubscribeOptions{QoS: 1}
+	}
+	return subscriptions
+}
+
+// close disconnects the pool from the MQTT broker
+func (m *mqttPool) close() {
+	m.startOnce.Do(func() {
+		m.startErr = errMqttPoolClosed
+	})
+	m.closeConnections()
+}
+
+func (m *mqttPool) closeConnections() {
+	for _, conn := range m.connections {
+		if conn == nil {
+			continue
+		}
+		err := conn.Disconnect(context.Background())
+		if err != nil {
+			slog.Error("disconnecting from mqtt", "err", err)
+		}
+	}
+}
+
+// connectionFor returns the connection that is used to publish messages for the charge station
+func (m *mqttPool) connectionFor(clientId string) *autopaho.ConnectionManager {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(clientId))
+	return m.connections[h.Sum32()%uint32(len(m.connections))]
+}
+
+// awaitConnection waits until a connection holds the subscriptions and the connection used
+// to publish messages for the charge station is connected
+func (m *mqttPool) awaitConnection(ctx context.Context, clientId string) error {
+	if err := m.start(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	subscribed := m.subscribed
+	m.mu.Unlock()
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return m.connectionFor(clientId).AwaitConnection(ctx)
+}
+
+// publish sends a message to the MQTT broker using the connection assigned to the charge station.
+// The call blocks until the message has been handed to the broker.
+func (m *mqttPool) publish(ctx context.Context, clientId string, msg *paho.Publish) error {
+	if err := m.start(); err != nil {
+		return err
+	}
+	start := time.Now()
+	_, err := m.connectionFor(clientId).Publish(ctx, msg)
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	mqttPublishDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func randomClientIdPrefix() string {
+	b := make([]byte, 4)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return "gateway-" + hex.EncodeToString(b)
+}
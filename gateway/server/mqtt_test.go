@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/mochi-co/mqtt/v2"
+	"github.com/mochi-co/mqtt/v2/listeners"
+	"github.com/mochi-co/mqtt/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// denyHook allows all clients to connect except those that have been denied
+type denyHook struct {
+	mqtt.HookBase
+	denied sync.Map
+}
+
+func (h *denyHook) ID() string {
+	return "deny"
+}
+
+func (h *denyHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{mqtt.OnConnectAuthenticate, mqtt.OnACLCheck}, []byte{b})
+}
+
+func (h *denyHook) OnConnectAuthenticate(cl *mqtt.Client, _ packets.Packet) bool {
+	_, denied := h.denied.Load(cl.ID)
+	return !denied
+}
+
+func (h *denyHook) OnACLCheck(*mqtt.Client, string, bool) bool {
+	return true
+}
+
+func TestMqttPoolMovesSubscriptionsWhenSubscriberGoesDown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	hook := &denyHook{}
+	broker := mqtt.New(nil)
+	require.NoError(t, broker.AddHook(hook, nil))
+	port, err := getFreePort()
+	require.NoError(t, err)
+	l := listeners.NewTCP("broker1", fmt.Sprintf("127.0.0.1:%d", port), nil)
+	require.NoError(t, broker.AddListener(l))
+	require.NoError(t, broker.Serve())
+	defer func() {
+		_ = broker.Close()
+	}()
+	addr, err := url.Parse(fmt.Sprintf("mqtt://%s", l.Address()))
+	require.NoError(t, err)
+
+	handler := &WebsocketHandler{
+		mqttBrokerURLs:        []*url.URL{addr},
+		mqttClientIdPrefix:    "gw",
+		mqttPoolSize:          2,
+		mqttConnectTimeout:    time.Second,
+		mqttConnectRetryDelay: 100 * time.Millisecond,
+		mqttKeepAliveInterval: 10,
+		mqttTopicPrefix:       "cs",
+		mqttSubscriberBufLen:  10,
+	}
+	pool := newMqttPool(handler, newCsmsRouter(handler))
+	defer pool.close()
+
+	received := make(chan *paho.Publish, 10)
+	unregister := pool.register("ocpp2.0.1", "cs001", func(msg *paho.Publish) {
+		received <- msg
+	}, func(*paho.Publish) {})
+	defer unregister()
+
+	require.NoError(t, pool.awaitConnection(ctx, "cs001"))
+	expectDelivery := func(payload string) {
+		t.Helper()
+		require.NoError(t, broker.Publish("cs/out/ocpp2.0.1/cs001", []byte(payload), false, 0))
+		select {
+		case msg := <-received:
+			assert.Equal(t, payload, string(msg.Payload))
+		case <-ctx.Done():
+			t.Fatalf("message %s not delivered", payload)
+		}
+	}
+	expectDelivery("before")
+
+	// kill the subscribing connection and stop it from reconnecting
+	pool.mu.Lock()
+	subscriber := pool.subscriber
+	pool.mu.Unlock()
+	clientId := fmt.Sprintf("gw-%d", subscriber)
+	hook.denied.Store(clientId, true)
+	cl, ok := broker.Clients.Get(clientId)
+	require.True(t, ok)
+	cl.Stop(errors.New("killed by test"))
+
+	require.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		return pool.subscriber != -1 && pool.subscriber != subscriber
+	}, 5*time.Second, 10*time.Millisecond)
+
+	expectDelivery("after")
+	select {
+	case msg := <-received:
+		t.Errorf("unexpected message %s", msg.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
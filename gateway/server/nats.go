@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/nats-io/nats.go"
+	"golang.org/x/exp/slog"
+)
+
+var errNatsPoolClosed = errors.New("nats connection closed")
+
+// natsPollInterval is how often the connection state is checked whilst waiting for the
+// connection to NATS
+const natsPollInterval = 50 * time.Millisecond
+
+// natsPool is a csmsTransport that uses NATS. The MQTT topics used by the gateway are
+// mapped to subjects by replacing the separators with dots and percent encoding the
+// characters that have a special meaning in a subject, so cs/in/ocpp2.0.1/cs001 becomes
+// cs.in.ocpp2%2E0%2E1.cs001.
+//
+// The messages to the CSMS are published to a JetStream stream, so they are held until
+// the CSMS has processed them. The messages from the CSMS (and the connection events from
+// the other gateway instances) are received through plain NATS subscriptions as they are
+// only of interest to the gateway instances that are connected at the time.
+type natsPool struct {
+	*csmsRouter
+	urls          []string
+	stream        string
+	name          string
+	reconnectWait time.Duration
+
+	startOnce sync.Once
+	conn      *nats.Conn
+	js        nats.JetStreamContext
+	startErr  error
+
+	streamMu    sync.Mutex
+	streamReady bool
+}
+
+func newNatsPool(handler *WebsocketHandler, router *csmsRouter) *natsPool {
+	return &natsPool{
+		csmsRouter:    router,
+		urls:          handler.natsURLs,
+		stream:        handler.natsStream,
+		name:          handler.mqttClientIdPrefix,
+		reconnectWait: handler.mqttConnectRetryDelay,
+	}
+}
+
+// start connects to NATS. The connection is only established once: subsequent calls
+// return the result of the first call.
+func (n *natsPool) start() error {
+	n.startOnce.Do(func() {
+		conn, err := nats.Connect(strings.Join(n.urls, ","),
+			nats.Name(n.name),
+			nats.ReconnectWait(n.reconnectWait),
+			nats.MaxReconnects(-1),
+			nats.RetryOnFailedConnect(true),
+			nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+				if err != nil {
+					slog.Warn("nats disconnect", "err", err)
+				}
+			}))
+		if err != nil {
+			n.startErr = fmt.Errorf("connecting to nats: %w", err)
+			return
+		}
+		n.conn = conn
+
+		n.js, err = conn.JetStream()
+		if err != nil {
+			n.startErr = fmt.Errorf("creating jetstream context: %w", err)
+			conn.Close()
+			return
+		}
+
+		subjects := []string{n.subjectFor(n.topicPrefix+"/out") + ".>"}
+		if n.eventHandler != nil {
+			subjects = append(subjects, n.subjectFor(n.topicPrefix+"/events")+".>")
+		}
+		if n.registryHandler != nil {
+			subjects = append(subjects, n.subjectFor(n.topicPrefix+"/registry"))
+		}
+		for _, subj := range subjects {
+			_, err = conn.Subscribe(subj, n.receive)
+			if err != nil {
+				n.startErr = fmt.Errorf("subscribing to %s: %w", subj, err)
+				conn.Close()
+				return
+			}
+		}
+	})
+	return n.startErr
+}
+
+// close disconnects from NATS
+func (n *natsPool) close() {
+	n.startOnce.Do(func() {
+		n.startErr = errNatsPoolClosed
+	})
+	if n.conn != nil {
+		n.conn.Close()
+	}
+}
+
+// awaitConnection waits until the gateway is connected to NATS and the stream that holds
+// the messages to the CSMS exists
+func (n *natsPool) awaitConnection(ctx context.Context, _ string) error {
+	if err := n.start(); err != nil {
+		return err
+	}
+	for !n.conn.IsConnected() {
+		if n.conn.IsClosed() {
+			return errNatsPoolClosed
+		}
+		select {
+		case <-time.After(natsPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return n.ensureStream(ctx)
+}
+
+// ensureStream creates the stream that holds the messages to the CSMS if it does not
+// already exist. An existing stream is left unchanged so that its limits can be managed
+// outside the gateway.
+func (n *natsPool) ensureStream(ctx context.Context) error {
+	n.streamMu.Lock()
+	defer n.streamMu.Unlock()
+	if n.streamReady {
+		return nil
+	}
+
+	_, err := n.js.StreamInfo(n.stream, nats.Context(ctx))
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = n.js.AddStream(&nats.StreamConfig{
+			Name: n.stream,
+			Subjects: []string{
+				n.subjectFor(n.topicPrefix+"/in") + ".>",
+				n.subjectFor(n.topicPrefix+"/events") + ".>",
+			},
+			Retention: nats.InterestPolicy,
+			Storage:   nats.FileStorage,
+		}, nats.Context(ctx))
+		if errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("creating stream %s: %w", n.stream, err)
+	}
+	n.streamReady = true
+	return nil
+}
+
+// publish sends a message to NATS: messages to the CSMS are published to the stream and
+// the call blocks until the stream has stored the message
+func (n *natsPool) publish(ctx context.Context, _ string, msg *paho.Publish) error {
+	if err := n.start(); err != nil {
+		return err
+	}
+
+	natsMsg := nats.NewMsg(n.subjectFor(msg.Topic))
+	natsMsg.Data = msg.Payload
+	if msg.Properties != nil {
+		if msg.Properties.ContentType != "" {
+			natsMsg.Header.Set("Content-Type", msg.Properties.ContentType)
+		}
+		if msg.Properties.CorrelationData != nil {
+			correlationMap := make(map[string]string)
+			err := json.Unmarshal(msg.Properties.CorrelationData, &correlationMap)
+			if err != nil {
+				slog.Warn("unmarshalling correlation map", "err", err)
+			}
+			for k, v := range correlationMap {
+				http.Header(natsMsg.Header).Set(k, v)
+			}
+		}
+	}
+
+	if strings.HasPrefix(msg.Topic, n.topicPrefix+"/in/") || strings.HasPrefix(msg.Topic, n.topicPrefix+"/events/") {
+		_, err := n.js.PublishMsg(natsMsg, nats.Context(ctx))
+		return err
+	}
+	return n.conn.PublishMsg(natsMsg)
+}
+
+// receive passes a message received from NATS to the router as if it had been received
+// from MQTT: the headers become the correlation data
+func (n *natsPool) receive(natsMsg *nats.Msg) {
+	msg := &paho.Publish{
+		Topic:      n.topicFor(natsMsg.Subject),
+		Payload:    natsMsg.Data,
+		Properties: &paho.PublishProperties{},
+	}
+	correlationMap := make(map[string]string)
+	for k, v := range natsMsg.Header {
+		if len(v) == 0 {
+			continue
+		}
+		if k == "Content-Type" {
+			msg.Properties.ContentType = v[0]
+			continue
+		}
+		correlationMap[strings.ToLower(k)] = v[0]
+	}
+	correlationData, err := json.Marshal(correlationMap)
+	if err != nil {
+		slog.Warn("marshalling correlation map", "err", err)
+	}
+	msg.Properties.CorrelationData = correlationData
+	n.dispatch(msg)
+}
+
+// subjectFor returns the subject for an MQTT topic
+func (n *natsPool) subjectFor(topic string) string {
+	rest, _ := strings.CutPrefix(topic, n.topicPrefix+"/")
+	var b strings.Builder
+	b.WriteString(n.topicPrefix)
+	for _, token := range strings.Split(rest, "/") {
+		b.WriteByte('.')
+		for i := 0; i < len(token); i++ {
+			c := token[i]
+			if c == '.' || c == '*' || c == '>' || c == '%' || c <= ' ' || c == 0x7f {
+				_, _ = fmt.Fprintf(&b, "%%%02X", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// topicFor returns the MQTT topic for a subject
+func (n *natsPool) topicFor(subject string) string {
+	rest, _ := strings.CutPrefix(subject, n.topicPrefix+".")
+	tokens := strings.Split(rest, ".")
+	for i, token := range tokens {
+		if decoded, err := url.PathUnescape(token); err == nil {
+			tokens[i] = decoded
+		}
+	}
+	return n.topicPrefix + "/" + strings.Join(tokens, "/")
+}
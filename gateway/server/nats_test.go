@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNatsPoolMapsTopicsToSubjects(t *testing.T) {
+	pool := &natsPool{csmsRouter: &csmsRouter{topicPrefix: "cs"}}
+
+	tests := map[string]string{
+		"cs/in/ocpp2.0.1/cs001":    "cs.in.ocpp2%2E0%2E1.cs001",
+		"cs/out/ocpp1.6/cs.001":    "cs.out.ocpp1%2E6.cs%2E001",
+		"cs/events/ocpp2.1/cs*>%1": "cs.events.ocpp2%2E1.cs%2A%3E%251",
+		"cs/out/ocpp1.6/cs 001":    "cs.out.ocpp1%2E6.cs%20001",
+		"cs/registry":              "cs.registry",
+	}
+
+	for topic, subject := range tests {
+		t.Run(topic, func(t *testing.T) {
+			assert.Equal(t, subject, pool.subjectFor(topic))
+			assert.Equal(t, topic, pool.topicFor(subject))
+		})
+	}
+}
+
+// natsTestPool returns a pool connected to an in-process NATS server, with JetStream enabled,
+// that is started for the test
+func natsTestPool(t *testing.T) (*natsPool, *nats.Conn) {
+	opts := natstest.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natstest.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+
+	handler := &WebsocketHandler{
+		natsURLs:              []string{srv.ClientURL()},
+		natsStream:            "CSMS",
+		mqttTopicPrefix:       "cs",
+		mqttClientIdPrefix:    "cs",
+		mqttConnectRetryDelay: time.Second,
+		mqttSubscriberBufLen:  10,
+	}
+	pool := newNatsPool(handler, newCsmsRouter(handler))
+	t.Cleanup(pool.close)
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	return pool, conn
+}
+
+func TestNatsPoolPublishesMessagesToTheStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pool, conn := natsTestPool(t)
+
+	err := pool.awaitConnection(ctx, "cs001")
+	require.NoError(t, err)
+
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+	sub, err := js.SubscribeSync(pool.subjectFor(pool.topicPrefix+"/in/ocpp2.0.1/cs001"), nats.BindStream(pool.stream))
+	require.NoError(t, err)
+
+	correlationData, err := json.Marshal(map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"})
+	require.NoError(t, err)
+	err = pool.publish(ctx, "cs001", &paho.Publish{
+		Topic:   pool.topicPrefix + "/in/ocpp2.0.1/cs001",
+		Payload: []byte(`{"type":2,"id":"1234","action":"Heartbeat","payload":{}}`),
+		Properties: &paho.PublishProperties{
+			ContentType:     "application/json",
+			CorrelationData: correlationData,
+		},
+	})
+	require.NoError(t, err)
+
+	msg, err := sub.NextMsgWithContext(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":2,"id":"1234","action":"Heartbeat","payload":{}}`, string(msg.Data))
+	assert.Equal(t, "application/json", msg.Header.Get("Content-Type"))
+	// the NATS server lower-cases the trace context header
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", msg.Header.Get("traceparent"))
+}
+
+func TestNatsPoolDeliversMessagesFromTheCsms(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pool, conn := natsTestPool(t)
+
+	received := make(chan *paho.Publish, 1)
+	unregister := pool.register("ocpp1.6", "cs.001", func(msg *paho.Publish) {
+		received <- msg
+	}, nil)
+	defer unregister()
+
+	err := pool.awaitConnection(ctx, "cs.001")
+	require.NoError(t, err)
+	require.NoError(t, pool.conn.Flush())
+
+	msg := nats.NewMsg(pool.topicPrefix + ".out.ocpp1%2E6.cs%2E001")
+	msg.Data = []byte(`{"type":3,"id":"1234","payload":{}}`)
+	msg.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	err = conn.PublishMsg(msg)
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message")
+	case got := <-received:
+		assert.Equal(t, pool.topicPrefix+"/out/ocpp1.6/cs.001", got.Topic)
+		var correlationMap map[string]string
+		err := json.Unmarshal(got.Properties.CorrelationData, &correlationMap)
+		require.NoError(t, err)
+		assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", correlationMap["traceparent"])
+	}
+}
@@ -0,0 +1,336 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// outboundQueue holds the messages from a charge station that are waiting to be
+// published to the CSMS. Messages are held in memory up to memLimit, after which
+// they are spilled to a file (if a spill directory has been configured) up to
+// spillLimit. Messages are always delivered in the order in which they were pushed.
+// Without a spill directory, the messages still held when the queue is closed are lost.
+type outboundQueue struct {
+	mu       sync.Mutex
+	mem      []*pipe.GatewayMessage
+	memLimit int
+	spill    *spillFile
+	notify   chan struct{}
+}
+
+func newOutboundQueue(clientId string, memLimit int, spillDir string, spillLimit int) (*outboundQueue, error) {
+	q := &outboundQueue{
+		memLimit: memLimit,
+		notify:   make(chan struct{}, 1),
+	}
+	if spillDir != "" {
+		var err error
+		q.spill, err = openSpillFile(filepath.Join(spillDir, url.PathEscape(clientId)+".jsonl"), spillLimit)
+		if err != nil {
+			return nil, err
+		}
+		if q.spill.count > 0 {
+			// messages left over from a previous connection
+			q.signal()
+		}
+	}
+	return q, nil
+}
+
+// push adds a message to the end of the queue. It returns false if the queue is full.
+func (q *outboundQueue) push(msg *pipe.GatewayMessage) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case (q.spill == nil || q.spill.count == 0) && len(q.mem) < q.memLimit:
+		q.mem = append(q.mem, msg)
+	case q.spill != nil && q.spill.count < q.spill.limit:
+		err := q.spill.append(msg)
+		if err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+
+	q.signal()
+	return true, nil
+}
+
+// peek returns the message at the head of the queue or nil if the queue is empty
+func (q *outboundQueue) peek() (*pipe.GatewayMessage, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.mem) == 0 && q.spill != nil && q.spill.count > 0 {
+		msgs, err := q.spill.read(q.memLimit)
+		if err != nil {
+			return nil, err
+		}
+		q.mem = append(q.mem, msgs...)
+	}
+
+	if len(q.mem) == 0 {
+		return nil, nil
+	}
+	return q.mem[0], nil
+}
+
+// pop removes the message at the head of the queue
+func (q *outboundQueue) pop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.mem) > 0 {
+		q.mem[0] = nil
+		q.mem = q.mem[1:]
+	}
+}
+
+// len returns the number of messages in the queue
+func (q *outboundQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	n := len(q.mem)
+	if q.spill != nil {
+		n += q.spill.count
+	}
+	return n
+}
+
+func (q *outboundQueue) signal() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// close releases the queue. Any messages still held in memory are written to the spill
+// file, if there is one, so that they can be delivered when the charge station reconnects.
+// It returns the number of messages that have been discarded.
+func (q *outboundQueue) close() (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.spill == nil {
+		dropped := len(q.mem)
+		q.mem = nil
+		return dropped, nil
+	}
+
+	err := q.spill.prepend(q.mem)
+	q.mem = nil
+	if err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// spillFile is an append-only file of JSON encoded messages with a read offset
+type spillFile struct {
+	path   string
+	limit  int
+	count  int
+	offset int64
+}
+
+type spilledMessage struct {
+	Message *pipe.GatewayMessage `json:"message"`
+	Trace   map[string]string    `json:"trace,omitempty"`
+}
+
+func openSpillFile(path string, limit int) (*spillFile, error) {
+	s := &spillFile{path: path, limit: limit}
+
+	//#nosec G304 - the spill directory is specified by the person running the application
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("opening spill file %s: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxSpillLineLen)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			s.count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading spill file %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+const maxSpillLineLen = 16 * 1024 * 1024
+
+func (s *spillFile) append(msg *pipe.GatewayMessage) error {
+	line, err := encodeSpilledMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	//#nosec G304 - the spill directory is specified by the person running the application
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("opening spill file %s: %w", s.path, err)
+	}
+	_, err = f.Write(line)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing spill file %s: %w", s.path, err)
+	}
+
+	s.count++
+	return nil
+}
+
+// read removes up to n messages from the head of the file
+func (s *spillFile) read(n int) ([]*pipe.GatewayMessage, error) {
+	//#nosec G304 - the spill directory is specified by the person running the application
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("opening spill file %s: %w", s.path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	_, err = f.Seek(s.offset, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("seeking spill file %s: %w", s.path, err)
+	}
+
+	var msgs []*pipe.GatewayMessage
+	reader := bufio.NewReader(f)
+	for len(msgs) < n {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			break
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading spill file %s: %w", s.path, err)
+		}
+		s.offset += int64(len(line))
+		if len(line) <= 1 {
+			continue
+		}
+		msg, err := decodeSpilledMessage(line)
+		if err != nil {
+			return nil, fmt.Errorf("decoding spill file %s: %w", s.path, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	s.count -= len(msgs)
+	if s.count <= 0 {
+		s.count = 0
+		s.offset = 0
+		err = os.Remove(s.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("removing spill file %s: %w", s.path, err)
+		}
+	}
+
+	return msgs, nil
+}
+
+// prepend rewrites the file with msgs ahead of any unread messages
+func (s *spillFile) prepend(msgs []*pipe.GatewayMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	var remaining []*pipe.GatewayMessage
+	if s.count > 0 {
+		var err error
+		remaining, err = s.read(s.count)
+		if err != nil {
+			return err
+		}
+	}
+
+	tmpPath := s.path + ".tmp"
+	//#nosec G304 - the spill directory is specified by the person running the application
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("creating spill file %s: %w", tmpPath, err)
+	}
+	w := bufio.NewWriter(f)
+	for _, msg := range append(msgs, remaining...) {
+		line, err := encodeSpilledMessage(msg)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		_, err = w.Write(line)
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("writing spill file %s: %w", tmpPath, err)
+		}
+	}
+	err = w.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing spill file %s: %w", tmpPath, err)
+	}
+
+	err = os.Rename(tmpPath, s.path)
+	if err != nil {
+		return fmt.Errorf("renaming spill file %s: %w", tmpPath, err)
+	}
+
+	s.count = len(msgs) + len(remaining)
+	s.offset = 0
+	return nil
+}
+
+func encodeSpilledMessage(msg *pipe.GatewayMessage) ([]byte, error) {
+	spilled := spilledMessage{Message: msg}
+	if msg.Context != nil {
+		spilled.Trace = make(map[string]string)
+		otel.GetTextMapPropagator().Inject(msg.Context, propagation.MapCarrier(spilled.Trace))
+	}
+	b, err := json.Marshal(spilled)
+	if err != nil {
+		return nil, fmt.Errorf("encoding spilled message: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+func decodeSpilledMessage(line []byte) (*pipe.GatewayMessage, error) {
+	var spilled spilledMessage
+	err := json.Unmarshal(line, &spilled)
+	if err != nil {
+		return nil, err
+	}
+	if spilled.Message == nil {
+		return nil, errors.New("no message")
+	}
+	spilled.Message.Context = otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(spilled.Trace))
+	return spilled.Message, nil
+}
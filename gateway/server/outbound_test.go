@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newCall(id int) *pipe.GatewayMessage {
+	return &pipe.GatewayMessage{
+		Context:     context.Background(),
+		MessageType: ocpp.MessageTypeCall,
+		MessageId:   fmt.Sprintf("%d", id),
+		Action:      "TransactionEvent",
+	}
+}
+
+func drain(t *testing.T, q *outboundQueue) []string {
+	var ids []string
+	for {
+		msg, err := q.peek()
+		require.NoError(t, err)
+		if msg == nil {
+			return ids
+		}
+		require.NotNil(t, msg.Context)
+		ids = append(ids, msg.MessageId)
+		q.pop()
+	}
+}
+
+func TestOutboundQueueInMemory(t *testing.T) {
+	q, err := newOutboundQueue("cs001", 2, "", 0)
+	require.NoError(t, err)
+
+	for i := 1; i <= 2; i++ {
+		ok, err := q.push(newCall(i))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, err := q.push(newCall(3))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"1", "2"}, drain(t, q))
+
+	ok, err = q.push(newCall(4))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	dropped, err := q.close()
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+}
+
+func TestOutboundQueueSpillsToDiskInOrder(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := newOutboundQueue("cs/001", 2, dir, 3)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		ok, err := q.push(newCall(i))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	ok, err := q.push(newCall(6))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 5, q.len())
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, drain(t, q))
+	assert.Equal(t, 0, q.len())
+}
+
+func TestOutboundQueuePersistsMessagesOnClose(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := newOutboundQueue("cs001", 2, dir, 10)
+	require.NoError(t, err)
+
+	for i := 1; i <= 4; i++ {
+		ok, err := q.push(newCall(i))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	// the first message has been delivered
+	_, err = q.peek()
+	require.NoError(t, err)
+	q.pop()
+
+	dropped, err := q.close()
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	q, err = newOutboundQueue("cs001", 2, dir, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, q.len())
+	select {
+	case <-q.notify:
+	default:
+		t.Error("expected queue to be signalled")
+	}
+
+	assert.Equal(t, []string{"2", "3", "4"}, drain(t, q))
+}
+
+// unavailableTransport is a csmsTransport whose broker is never available
+type unavailableTransport struct{}
+
+func (unavailableTransport) start() error { return errors.New("broker unavailable") }
+
+func (unavailableTransport) register(string, string, func(*paho.Publish), func(*paho.Publish)) func() {
+	return func() {}
+}
+
+func (unavailableTransport) awaitConnection(ctx context.Context, _ string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (unavailableTransport) publish(context.Context, string, *paho.Publish) error {
+	return errors.New("broker unavailable")
+}
+
+func (unavailableTransport) close() {}
+
+// publishUntilDisconnected passes the messages to goPublishToCSMS whilst the broker is
+// unavailable and then disconnects the charge station
+func publishUntilDisconnected(t *testing.T, queue *outboundQueue, msgs ...*pipe.GatewayMessage) {
+	ctx, cancel := context.WithCancel(context.Background())
+	csmsTx := make(chan *pipe.GatewayMessage)
+	published := goPublishToCSMS(ctx, trace.NewNoopTracerProvider().Tracer(""), queue, csmsTx, make(chan *pipe.GatewayMessage, 1),
+		unavailableTransport{}, time.Millisecond, "cs", "ocpp2.0.1", "cs001")
+
+	for _, msg := range msgs {
+		csmsTx <- msg
+	}
+	require.Eventually(t, func() bool { return queue.len() == len(msgs) }, time.Second, time.Millisecond)
+
+	cancel()
+	<-published
+}
+
+func TestPublishToCSMSDiscardsBufferedMessagesOnDisconnect(t *testing.T) {
+	q, err := newOutboundQueue("cs001", 10, "", 0)
+	require.NoError(t, err)
+
+	publishUntilDisconnected(t, q, newCall(1), newCall(2))
+
+	// without a spill directory the messages are not available to the next connection
+	q, err = newOutboundQueue("cs001", 10, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, q.len())
+}
+
+func TestPublishToCSMSKeepsBufferedMessagesOnDisconnectWithSpill(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := newOutboundQueue("cs001", 10, dir, 10)
+	require.NoError(t, err)
+
+	publishUntilDisconnected(t, q, newCall(1), newCall(2))
+
+	q, err = newOutboundQueue("cs001", 10, dir, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, drain(t, q))
+}
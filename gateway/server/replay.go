@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/google/uuid"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"golang.org/x/exp/slog"
+)
+
+// ReplayResult is the outcome of replaying a single call from a capture
+type ReplayResult struct {
+	MessageId string `json:"messageId"`
+	Action    string `json:"action"`
+	// Captured is the response to the call that was sent to the charge station in the capture
+	Captured string `json:"captured,omitempty"`
+	// Replayed is the response to the call that the manager sent during the replay
+	Replayed string `json:"replayed,omitempty"`
+	// Matched is true when the replayed response is the same JSON as the captured response
+	Matched  bool `json:"matched"`
+	TimedOut bool `json:"timedOut,omitempty"`
+}
+
+// ReplaySummary counts the outcomes of a replay
+type ReplaySummary struct {
+	Calls    int
+	Matched  int
+	TimedOut int
+	// Unsolicited is the number of calls that the manager made to the charge station
+	Unsolicited int
+}
+
+// Replayer publishes the calls that a charge station made in a capture to the manager via
+// MQTT, as if they came from the gateway, and reports the manager's responses. Only the
+// calls made by the charge station are replayed: the calls made by the manager are
+// reported but are not answered.
+type Replayer struct {
+	brokerURL       *url.URL
+	topicPrefix     string
+	clientId        string
+	speed           float64
+	responseTimeout time.Duration
+	output          io.Writer
+}
+
+type ReplayOpt func(*Replayer)
+
+// WithReplayTopicPrefix sets the prefix of the MQTT topics used to reach the manager
+func WithReplayTopicPrefix(topicPrefix string) ReplayOpt {
+	return func(r *Replayer) {
+		r.topicPrefix = topicPrefix
+	}
+}
+
+// WithReplayClientId replays the capture as if it came from a different charge station
+func WithReplayClientId(clientId string) ReplayOpt {
+	return func(r *Replayer) {
+		r.clientId = clientId
+	}
+}
+
+// WithReplaySpeed replays the calls with the gaps between them in the capture divided by
+// speed: 0 replays each call as soon as the previous one has been answered
+func WithReplaySpeed(speed float64) ReplayOpt {
+	return func(r *Replayer) {
+		r.speed = speed
+	}
+}
+
+// WithReplayResponseTimeout sets how long to wait for the manager to answer each call
+func WithReplayResponseTimeout(timeout time.Duration) ReplayOpt {
+	return func(r *Replayer) {
+		r.responseTimeout = timeout
+	}
+}
+
+// WithReplayOutput sets where the ReplayResult for each call is written as a line of JSON
+func WithReplayOutput(output io.Writer) ReplayOpt {
+	return func(r *Replayer) {
+		r.output = output
+	}
+}
+
+func NewReplayer(brokerURL *url.URL, opts ...ReplayOpt) *Replayer {
+	r := &Replayer{
+		brokerURL:       brokerURL,
+		topicPrefix:     "cs",
+		responseTimeout: 10 * time.Second,
+		output:          io.Discard,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Replay replays the calls in frames, which must all be for the same charge station
+func (r *Replayer) Replay(ctx context.Context, frames []CapturedFrame) (*ReplaySummary, error) {
+	if len(frames) == 0 {
+		return &ReplaySummary{}, nil
+	}
+	protocol := frames[0].Protocol
+	clientId := frames[0].ClientId
+	for _, frame := range frames {
+		if frame.ClientId != clientId || frame.Protocol != protocol {
+			return nil, fmt.Errorf("capture contains frames for more than one charge station or protocol: %s/%s and %s/%s",
+				clientId, protocol, frame.ClientId, frame.Protocol)
+		}
+	}
+	if r.clientId != "" {
+		clientId = r.clientId
+	}
+
+	// index the responses sent to the charge station so they can be compared with the replay
+	captured := make(map[string]string)
+	for _, frame := range frames {
+		if frame.Direction != CaptureToChargeStation || frame.Binary {
+			continue
+		}
+		msg, err := unmarshalOcppAsGatewayMessage([]byte(frame.Frame))
+		if err == nil && msg.MessageType != ocpp.MessageTypeCall {
+			captured[msg.MessageId] = frame.Frame
+		}
+	}
+
+	summary := &ReplaySummary{}
+	var unsolicited atomic.Int64
+	defer func() {
+		summary.Unsolicited = int(unsolicited.Load())
+	}()
+	responses := make(chan *pipe.GatewayMessage, 1)
+	outTopic := fmt.Sprintf("%s/out/%s/%s", r.topicPrefix, protocol, clientId)
+
+	conn, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{r.brokerURL},
+		KeepAlive:         10,
+		ConnectRetryDelay: time.Second,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					outTopic: {},
+				},
+			})
+			if err != nil {
+				slog.Error("subscribing to mqtt topic", "topic", outTopic, "err", err)
+			}
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: fmt.Sprintf("replay-%s", uuid.NewString()),
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				var msg pipe.GatewayMessage
+				err := json.Unmarshal(publish.Payload, &msg)
+				if err != nil {
+					slog.Warn("unmarshalling manager message", "err", err)
+					return
+				}
+				if msg.MessageType == ocpp.MessageTypeCall {
+					unsolicited.Add(1)
+					slog.Info("manager made call", "clientId", clientId, "messageId", msg.MessageId, "action", msg.Action)
+					return
+				}
+				select {
+				case responses <- &msg:
+				default:
+					slog.Warn("discarding unexpected response", "clientId", clientId, "messageId", msg.MessageId)
+				}
+			}),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to mqtt: %w", err)
+	}
+	defer func() {
+		_ = conn.Disconnect(context.Background())
+	}()
+	err = conn.AwaitConnection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to mqtt: %w", err)
+	}
+
+	var previous time.Time
+	for _, frame := range frames {
+		if frame.Direction != CaptureFromChargeStation || frame.Binary {
+			continue
+		}
+		msg, err := unmarshalOcppAsGatewayMessage([]byte(frame.Frame))
+		if err != nil {
+			slog.Warn("skipping invalid frame", "timestamp", frame.Timestamp, "err", err)
+			continue
+		}
+		if msg.MessageType != ocpp.MessageTypeCall {
+			continue
+		}
+
+		if r.speed > 0 && !previous.IsZero() {
+			select {
+			case <-time.After(time.Duration(float64(frame.Timestamp.Sub(previous)) / r.speed)):
+			case <-ctx.Done():
+				return summary, ctx.Err()
+			}
+		}
+		previous = frame.Timestamp
+
+		result, err := r.replayCall(ctx, conn, protocol, clientId, msg, responses)
+		if err != nil {
+			return summary, err
+		}
+		result.Captured = captured[msg.MessageId]
+		result.Matched = result.Replayed != "" && jsonEqual(result.Captured, result.Replayed)
+
+		summary.Calls++
+		if result.Matched {
+			summary.Matched++
+		}
+		if result.TimedOut {
+			summary.TimedOut++
+		}
+
+		line, err := json.Marshal(result)
+		if err != nil {
+			return summary, err
+		}
+		_, err = r.output.Write(append(line, '\n'))
+		if err != nil {
+			return summary, fmt.Errorf("writing replay result: %w", err)
+		}
+	}
+
+	return summary, nil
+}
+
+func (r *Replayer) replayCall(ctx context.Context, conn *autopaho.ConnectionManager, protocol, clientId string, msg *pipe.GatewayMessage, responses chan *pipe.GatewayMessage) (*ReplayResult, error) {
+	result := &ReplayResult{
+		MessageId: msg.MessageId,
+		Action:    msg.Action,
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.Publish(ctx, &paho.Publish{
+		Topic:   fmt.Sprintf("%s/in/%s/%s", r.topicPrefix, protocol, clientId),
+		Payload: data,
+		Properties: &paho.PublishProperties{
+			ContentType:   "application/json",
+			ResponseTopic: fmt.Sprintf("%s/out/%s/%s", r.topicPrefix, protocol, clientId),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("publishing %s: %w", msg.MessageId, err)
+	}
+
+	timeout := time.NewTimer(r.responseTimeout)
+	defer timeout.Stop()
+	for {
+		select {
+		case resp := <-responses:
+			if resp.MessageId != msg.MessageId {
+				slog.Warn("discarding unexpected response", "clientId", clientId, "messageId", resp.MessageId)
+				continue
+			}
+			b, err := marshalGatewayMessageAsOcpp(resp)
+			if err != nil {
+				return nil, err
+			}
+			result.Replayed = string(b)
+			return result, nil
+		case <-timeout.C:
+			result.TimedOut = true
+			return result, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// jsonEqual reports whether a and b are JSON documents with the same content
+func jsonEqual(a, b string) bool {
+	var av, bv any
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
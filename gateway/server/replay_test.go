@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
+)
+
+func TestCaptureAndReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	require.NoError(t, broker.Serve())
+	defer func() {
+		_ = broker.Close()
+	}()
+	client := startEchoManager(ctx, t, broker, addr)
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	dir := t.TempDir()
+	capture, err := server.NewFrameCapture(dir, server.WithCaptureStations("cs1"))
+	require.NoError(t, err)
+	defer capture.Close()
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["cs1"] = &registry.ChargeStation{
+		ClientId:             "cs1",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithFrameCapture(capture)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("cs1:password"))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/cs1", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	call := `[2,"1","Heartbeat",{}]`
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte(call)))
+	_, resp, err := conn.Read(ctx)
+	require.NoError(t, err)
+
+	var frames []server.CapturedFrame
+	require.Eventually(t, func() bool {
+		f, err := os.Open(filepath.Join(dir, "cs1.jsonl"))
+		require.NoError(t, err)
+		defer func() {
+			_ = f.Close()
+		}()
+		frames, err = server.ReadCapture(f)
+		require.NoError(t, err)
+		return len(frames) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, server.CaptureFromChargeStation, frames[0].Direction)
+	assert.Equal(t, call, frames[0].Frame)
+	assert.Equal(t, server.CaptureToChargeStation, frames[1].Direction)
+	assert.Equal(t, string(resp), frames[1].Frame)
+	for _, frame := range frames {
+		assert.Equal(t, "cs1", frame.ClientId)
+		assert.Equal(t, "ocpp2.0.1", frame.Protocol)
+		assert.False(t, frame.Timestamp.IsZero())
+	}
+
+	// replaying the capture produces the same response
+	var output bytes.Buffer
+	summary, err := server.NewReplayer(addr,
+		server.WithReplayClientId("cs2"),
+		server.WithReplayOutput(&output)).Replay(ctx, frames)
+	require.NoError(t, err)
+	assert.Equal(t, &server.ReplaySummary{Calls: 1, Matched: 1}, summary)
+
+	var result server.ReplayResult
+	require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+	assert.Equal(t, "1", result.MessageId)
+	assert.Equal(t, "Heartbeat", result.Action)
+	assert.True(t, result.Matched)
+
+	// a different response is reported as a mismatch
+	frames[1].Frame = `[3,"1",{"currentTime":"2023-06-15T10:30:00Z"}]`
+	summary, err = server.NewReplayer(addr).Replay(ctx, frames)
+	require.NoError(t, err)
+	assert.Equal(t, &server.ReplaySummary{Calls: 1}, summary)
+}
+
+func TestReplayRejectsMixedCaptures(t *testing.T) {
+	frames := []server.CapturedFrame{
+		{ClientId: "cs1", Protocol: "ocpp2.0.1", Direction: server.CaptureFromChargeStation, Frame: `[2,"1","Heartbeat",{}]`},
+		{ClientId: "cs2", Protocol: "ocpp2.0.1", Direction: server.CaptureFromChargeStation, Frame: `[2,"1","Heartbeat",{}]`},
+	}
+	_, err := server.NewReplayer(&url.URL{Scheme: "mqtt", Host: "127.0.0.1:1883"}).Replay(context.Background(), frames)
+	assert.Error(t, err)
+}
@@ -0,0 +1,418 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/exp/slog"
+)
+
+// RevocationMode determines whether a client certificate is accepted when its revocation
+// status cannot be determined
+type RevocationMode string
+
+const (
+	// RevocationSoftFail accepts certificates whose revocation status is unknown
+	RevocationSoftFail RevocationMode = "soft-fail"
+	// RevocationHardFail rejects certificates whose revocation status is unknown
+	RevocationHardFail RevocationMode = "hard-fail"
+)
+
+// ParseRevocationMode converts a string to a RevocationMode
+func ParseRevocationMode(mode string) (RevocationMode, error) {
+	switch m := RevocationMode(mode); m {
+	case RevocationSoftFail, RevocationHardFail:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown revocation mode: %s", mode)
+	}
+}
+
+var (
+	errCertificateRevoked = errors.New("certificate revoked")
+	errRevocationUnknown  = errors.New("certificate revocation status unknown")
+)
+
+type revocationStatus int
+
+const (
+	revocationUnknown revocationStatus = iota
+	revocationGood
+	revocationRevoked
+)
+
+// RevocationChecker checks whether charge station client certificates have been revoked
+// using certificate revocation lists (CRLs) loaded from files and, optionally, the OCSP
+// responders named in the certificates.
+type RevocationChecker struct {
+	crlFiles     []string
+	issuerFiles  []string
+	crlRefresh   time.Duration
+	ocspEnabled  bool
+	ocspTimeout  time.Duration
+	ocspCacheTTL time.Duration
+	mode         RevocationMode
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mu        sync.RWMutex
+	crls      []*x509.RevocationList
+	issuers   []*x509.Certificate
+	ocspCache map[string]ocspCacheEntry
+}
+
+type ocspCacheEntry struct {
+	status  revocationStatus
+	expires time.Time
+}
+
+type RevocationOpt func(*RevocationChecker)
+
+// WithCRLFiles sets the files that the PEM or DER encoded CRLs are read from
+func WithCRLFiles(files ...string) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.crlFiles = append(c.crlFiles, files...)
+	}
+}
+
+// WithIssuerCertificateFiles sets the files that PEM encoded CA certificates are read from.
+// These are used to find the issuer of a client certificate for OCSP requests when the
+// charge station does not present its certificate chain, e.g. when TLS is terminated by
+// a proxy.
+func WithIssuerCertificateFiles(files ...string) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.issuerFiles = append(c.issuerFiles, files...)
+	}
+}
+
+// WithCRLRefreshInterval sets how often the CRL files are read
+func WithCRLRefreshInterval(interval time.Duration) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.crlRefresh = interval
+	}
+}
+
+// WithOCSP enables OCSP checking, waiting up to timeout for the OCSP responder and caching
+// responses for up to cacheTTL (or until the response's next update, if sooner)
+func WithOCSP(timeout, cacheTTL time.Duration) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.ocspEnabled = true
+		c.ocspTimeout = timeout
+		c.ocspCacheTTL = cacheTTL
+	}
+}
+
+// WithRevocationMode sets what happens when the revocation status cannot be determined
+func WithRevocationMode(mode RevocationMode) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.mode = mode
+	}
+}
+
+// WithOCSPHttpClient sets the HTTP client used to make OCSP requests
+func WithOCSPHttpClient(httpClient *http.Client) RevocationOpt {
+	return func(c *RevocationChecker) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewRevocationChecker creates a RevocationChecker and reads the CRL files
+func NewRevocationChecker(opts ...RevocationOpt) (*RevocationChecker, error) {
+	c := &RevocationChecker{
+		crlRefresh:   time.Hour,
+		ocspTimeout:  5 * time.Second,
+		ocspCacheTTL: time.Hour,
+		mode:         RevocationSoftFail,
+		httpClient:   http.DefaultClient,
+		now:          time.Now,
+		ocspCache:    make(map[string]ocspCacheEntry),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	crls, err := readCRLs(c.crlFiles)
+	if err != nil {
+		return nil, err
+	}
+	c.crls = crls
+
+	for _, file := range c.issuerFiles {
+		issuers, err := readIssuerCertificates(file)
+		if err != nil {
+			return nil, err
+		}
+		c.issuers = append(c.issuers, issuers...)
+	}
+	return c, nil
+}
+
+// Watch reads the CRL files every refresh interval until ctx is done. If a file cannot be
+// read then the error is logged and the previous CRLs are kept.
+func (c *RevocationChecker) Watch(ctx context.Context) {
+	if len(c.crlFiles) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.crlRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			crls, err := readCRLs(c.crlFiles)
+			if err != nil {
+				slog.Error("refreshing CRLs - keeping previous CRLs", "err", err)
+				continue
+			}
+			c.mu.Lock()
+			c.crls = crls
+			c.mu.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check returns an error if the client certificate in the connection state has been revoked
+// or, in hard-fail mode, if its revocation status cannot be determined
+func (c *RevocationChecker) Check(ctx context.Context, state *tls.ConnectionState) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := state.PeerCertificates[0]
+	issuer := c.findIssuer(state)
+
+	status := c.checkCRLs(leaf, issuer)
+	if status != revocationRevoked && c.ocspEnabled && issuer != nil && len(leaf.OCSPServer) > 0 {
+		if ocspStatus := c.checkOCSP(ctx, leaf, issuer); ocspStatus != revocationUnknown {
+			status = ocspStatus
+		}
+	}
+
+	span := trace.SpanFromContext(ctx)
+	switch status {
+	case revocationRevoked:
+		span.SetAttributes(attribute.String("cert.revocation", "revoked"))
+		return errCertificateRevoked
+	case revocationGood:
+		span.SetAttributes(attribute.String("cert.revocation", "good"))
+		return nil
+	default:
+		span.SetAttributes(attribute.String("cert.revocation", "unknown"))
+		if c.mode == RevocationHardFail {
+			return errRevocationUnknown
+		}
+		return nil
+	}
+}
+
+func (c *RevocationChecker) checkCRLs(cert, issuer *x509.Certificate) revocationStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status := revocationUnknown
+	for _, crl := range c.crls {
+		if !bytes.Equal(crl.RawIssuer, cert.RawIssuer) {
+			continue
+		}
+		// when the issuer is known, ignore CRLs from other CAs with the same name
+		if issuer != nil && crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		for _, revoked := range crl.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return revocationRevoked
+			}
+		}
+		// a CRL that has passed its next update may not list recent revocations
+		if crl.NextUpdate.IsZero() || c.now().Before(crl.NextUpdate) {
+			status = revocationGood
+		}
+	}
+	return status
+}
+
+func (c *RevocationChecker) checkOCSP(ctx context.Context, cert, issuer *x509.Certificate) revocationStatus {
+	key := ocspCacheKey(issuer, cert.SerialNumber)
+
+	c.mu.RLock()
+	entry, ok := c.ocspCache[key]
+	c.mu.RUnlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.status
+	}
+
+	resp, err := c.requestOCSP(ctx, cert, issuer)
+	if err != nil {
+		slog.Warn("checking certificate revocation using OCSP", "serialNumber", cert.SerialNumber, "err", err)
+		return revocationUnknown
+	}
+
+	var status revocationStatus
+	switch resp.Status {
+	case ocsp.Good:
+		status = revocationGood
+	case ocsp.Revoked:
+		status = revocationRevoked
+	default:
+		status = revocationUnknown
+	}
+
+	expires := c.now().Add(c.ocspCacheTTL)
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(expires) {
+		expires = resp.NextUpdate
+	}
+	c.mu.Lock()
+	c.ocspCache[key] = ocspCacheEntry{status: status, expires: expires}
+	c.mu.Unlock()
+
+	return status
+}
+
+func (c *RevocationChecker) requestOCSP(ctx context.Context, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	reqBytes, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating OCSP request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.ocspTimeout)
+	defer cancel()
+
+	var lastErr error
+	for _, server := range cert.OCSPServer {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(reqBytes))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		req.Header.Set("content-type", "application/ocsp-request")
+		req.Header.Set("accept", "application/ocsp-response")
+
+		httpResp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+		_ = httpResp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if httpResp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("OCSP responder %s returned status %d", server, httpResp.StatusCode)
+			continue
+		}
+
+		resp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+		if err != nil {
+			lastErr = fmt.Errorf("parsing OCSP response from %s: %w", server, err)
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// findIssuer returns the certificate that issued the client certificate, if known
+func (c *RevocationChecker) findIssuer(state *tls.ConnectionState) *x509.Certificate {
+	leaf := state.PeerCertificates[0]
+	for _, chain := range state.VerifiedChains {
+		if len(chain) > 1 {
+			return chain[1]
+		}
+	}
+	candidates := append(state.PeerCertificates[1:len(state.PeerCertificates):len(state.PeerCertificates)], c.issuers...)
+	for _, cert := range candidates {
+		if bytes.Equal(cert.RawSubject, leaf.RawIssuer) && leaf.CheckSignatureFrom(cert) == nil {
+			return cert
+		}
+	}
+	return nil
+}
+
+func ocspCacheKey(issuer *x509.Certificate, serialNumber *big.Int) string {
+	return string(issuer.RawSubjectPublicKeyInfo) + "/" + serialNumber.String()
+}
+
+func readCRLs(files []string) ([]*x509.RevocationList, error) {
+	var crls []*x509.RevocationList
+	for _, file := range files {
+		//#nosec G304 - only files specified by the person running the application will be loaded
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading CRL file: %w", err)
+		}
+
+		var ders [][]byte
+		for rest := b; ; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type == "X509 CRL" {
+				ders = append(ders, block.Bytes)
+			}
+		}
+		if len(ders) == 0 {
+			// not PEM, so assume DER
+			ders = append(ders, b)
+		}
+
+		for _, der := range ders {
+			crl, err := x509.ParseRevocationList(der)
+			if err != nil {
+				return nil, fmt.Errorf("parsing CRL in %s: %w", file, err)
+			}
+			crls = append(crls, crl)
+		}
+	}
+	return crls, nil
+}
+
+func readIssuerCertificates(file string) ([]*x509.Certificate, error) {
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading issuer certificate file: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing issuer certificate in %s: %w", file, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return certs, nil
+}
@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"golang.org/x/crypto/ocsp"
+	"nhooyr.io/websocket"
+)
+
+func TestRevocationCheckerRejectsCertificateInCRL(t *testing.T) {
+	caCert, caKey, clientCert, _ := createTestKeyPairs(t, "cs001")
+	crlFile := writeTestCRL(t, t.TempDir(), caCert, caKey, clientCert.SerialNumber)
+
+	checker, err := server.NewRevocationChecker(server.WithCRLFiles(crlFile))
+	require.NoError(t, err)
+
+	err = checker.Check(context.Background(), &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}})
+	assert.EqualError(t, err, "certificate revoked")
+}
+
+func TestRevocationCheckerAcceptsCertificateNotInCRL(t *testing.T) {
+	caCert, caKey, clientCert, _ := createTestKeyPairs(t, "cs001")
+	crlFile := writeTestCRL(t, t.TempDir(), caCert, caKey)
+
+	checker, err := server.NewRevocationChecker(
+		server.WithCRLFiles(crlFile),
+		server.WithRevocationMode(server.RevocationHardFail))
+	require.NoError(t, err)
+
+	err = checker.Check(context.Background(), &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}})
+	assert.NoError(t, err)
+}
+
+func TestRevocationCheckerWithUnknownStatus(t *testing.T) {
+	otherCACert, otherCAKey, _, _ := createTestKeyPairs(t, "other")
+	caCert, _, clientCert, _ := createTestKeyPairs(t, "cs001")
+	// the other CA has the same name, but its CRL does not apply to the client certificate
+	crlFile := writeTestCRL(t, t.TempDir(), otherCACert, otherCAKey, clientCert.SerialNumber)
+
+	softFail, err := server.NewRevocationChecker(server.WithCRLFiles(crlFile))
+	require.NoError(t, err)
+	hardFail, err := server.NewRevocationChecker(
+		server.WithCRLFiles(crlFile),
+		server.WithRevocationMode(server.RevocationHardFail))
+	require.NoError(t, err)
+
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert, caCert}}
+	assert.NoError(t, softFail.Check(context.Background(), state))
+	assert.EqualError(t, hardFail.Check(context.Background(), state), "certificate revocation status unknown")
+}
+
+func TestRevocationCheckerRefreshesCRLs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	caCert, caKey, clientCert, _ := createTestKeyPairs(t, "cs001")
+	crlFile := writeTestCRL(t, dir, caCert, caKey)
+
+	checker, err := server.NewRevocationChecker(
+		server.WithCRLFiles(crlFile),
+		server.WithCRLRefreshInterval(10*time.Millisecond))
+	require.NoError(t, err)
+	go checker.Watch(ctx)
+
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}
+	require.NoError(t, checker.Check(ctx, state))
+
+	writeTestCRL(t, dir, caCert, caKey, clientCert.SerialNumber)
+
+	require.Eventually(t, func() bool {
+		return checker.Check(ctx, state) != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewRevocationCheckerWithInvalidCRL(t *testing.T) {
+	crlFile := filepath.Join(t.TempDir(), "crl.pem")
+	require.NoError(t, os.WriteFile(crlFile, []byte("not a crl"), 0600))
+
+	_, err := server.NewRevocationChecker(server.WithCRLFiles(crlFile))
+	assert.ErrorContains(t, err, "parsing CRL")
+}
+
+func TestRevocationCheckerUsesOCSP(t *testing.T) {
+	tests := map[string]struct {
+		status  int
+		wantErr string
+	}{
+		"good":    {status: ocsp.Good},
+		"revoked": {status: ocsp.Revoked, wantErr: "certificate revoked"},
+		"unknown": {status: ocsp.Unknown, wantErr: "certificate revocation status unknown"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			caCert, caKey, _, _ := createTestKeyPairs(t, "ca")
+			responder, requests := startTestOCSPResponder(t, caCert, caKey, tc.status)
+			clientCert := createTestClientCertificate(t, caCert, caKey, responder.URL)
+
+			checker, err := server.NewRevocationChecker(
+				server.WithOCSP(time.Second, time.Hour),
+				server.WithRevocationMode(server.RevocationHardFail))
+			require.NoError(t, err)
+
+			state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert, caCert}}
+			err = checker.Check(context.Background(), state)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+
+			// the response is cached
+			_ = checker.Check(context.Background(), state)
+			assert.Equal(t, int32(1), requests.Load())
+		})
+	}
+}
+
+func TestRevocationCheckerWithUnavailableOCSPResponder(t *testing.T) {
+	caCert, caKey, _, _ := createTestKeyPairs(t, "ca")
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}))
+	defer responder.Close()
+	clientCert := createTestClientCertificate(t, caCert, caKey, responder.URL)
+
+	softFail, err := server.NewRevocationChecker(server.WithOCSP(time.Second, time.Hour))
+	require.NoError(t, err)
+	hardFail, err := server.NewRevocationChecker(
+		server.WithOCSP(time.Second, time.Hour),
+		server.WithRevocationMode(server.RevocationHardFail))
+	require.NoError(t, err)
+
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert, caCert}}
+	assert.NoError(t, softFail.Check(context.Background(), state))
+	assert.EqualError(t, hardFail.Check(context.Background(), state), "certificate revocation status unknown")
+}
+
+func TestRevocationCheckerUsesIssuerCertificateFilesForOCSP(t *testing.T) {
+	caCert, caKey, _, _ := createTestKeyPairs(t, "ca")
+	responder, _ := startTestOCSPResponder(t, caCert, caKey, ocsp.Revoked)
+	clientCert := createTestClientCertificate(t, caCert, caKey, responder.URL)
+
+	issuerFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(issuerFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}), 0600))
+
+	checker, err := server.NewRevocationChecker(
+		server.WithOCSP(time.Second, time.Hour),
+		server.WithIssuerCertificateFiles(issuerFile))
+	require.NoError(t, err)
+
+	// only the leaf certificate is available when TLS is terminated by a proxy
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}
+	assert.EqualError(t, checker.Check(context.Background(), state), "certificate revoked")
+}
+
+func TestTlsConnectionWithCertificateAuthRevoked(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:        "tlsWithCertificateAuthRevoked",
+		SecurityProfile: registry.TLSWithClientSideCertificates,
+	}
+
+	caCert, caKey, clientCert, clientKeyPair := createTestKeyPairs(t, cs.ClientId)
+	crlFile := writeTestCRL(t, t.TempDir(), caCert, caKey, clientCert.SerialNumber)
+
+	checker, err := server.NewRevocationChecker(server.WithCRLFiles(crlFile))
+	require.NoError(t, err)
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewUnstartedServer(server.NewWebsocketHandler(
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithOrgName("Thoughtworks"),
+		server.WithRevocationChecker(checker)))
+	clientCAPool := x509.NewCertPool()
+	clientCAPool.AddCert(caCert)
+	srv.TLS = &tls.Config{
+		ClientCAs:  clientCAPool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAPool := x509.NewCertPool()
+	rootCAPool.AddCert(srv.Certificate())
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: rootCAPool,
+				Certificates: []tls.Certificate{{
+					Certificate: [][]byte{clientCert.Raw, caCert.Raw},
+					PrivateKey:  clientKeyPair,
+				}},
+			},
+		},
+	}
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   httpClient,
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+	}
+
+	conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err == nil {
+		_ = conn.Close(websocket.StatusGoingAway, "Shutdown")
+		t.Fatalf("expected error dialing CSMS")
+	}
+	require.NotNil(t, resp)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+// writeTestCRL writes a PEM encoded CRL that revokes the serial numbers to crl.pem in dir
+func writeTestCRL(t *testing.T, dir string, caCert *x509.Certificate, caKey crypto.Signer, revoked ...*big.Int) string {
+	var entries []x509.RevocationListEntry
+	for _, serialNumber := range revoked {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: time.Now(),
+		})
+	}
+
+	crlBytes, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, caCert, caKey)
+	require.NoError(t, err)
+
+	crlFile := filepath.Join(dir, "crl.pem")
+	err = os.WriteFile(crlFile, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlBytes}), 0600)
+	require.NoError(t, err)
+	return crlFile
+}
+
+// startTestOCSPResponder starts an OCSP responder that gives every certificate the status
+// and returns the number of requests that it has received
+func startTestOCSPResponder(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, status int) (*httptest.Server, *atomic.Int32) {
+	requests := new(atomic.Int32)
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := ocsp.CreateResponse(caCert, caCert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now(),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now(),
+		}, caKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("content-type", "application/ocsp-response")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(responder.Close)
+	return responder, requests
+}
+
+// createTestClientCertificate creates a client certificate issued by the CA that names the
+// OCSP responder
+func createTestClientCertificate(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, ocspServer string) *x509.Certificate {
+	clientKeyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	require.NoError(t, err)
+
+	clientCertBytes, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   "cs001",
+			Organization: []string{"Thoughtworks"},
+		},
+		KeyUsage:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		OCSPServer:  []string{ocspServer},
+		NotBefore:   time.Now(),
+		NotAfter:    time.Now().Add(time.Minute),
+	}, caCert, &clientKeyPair.PublicKey, caKey)
+	require.NoError(t, err)
+
+	clientCert, err := x509.ParseCertificate(clientCertBytes)
+	require.NoError(t, err)
+	return clientCert
+}
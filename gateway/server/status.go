@@ -9,15 +9,78 @@ import (
 	"net/http"
 )
 
-func NewStatusHandler() http.Handler {
+type statusHandler struct {
+	connections *ConnectionTracker
+	adminToken  string
+	capture     *FrameCapture
+	drainer     *Drainer
+}
+
+type StatusOpt func(handler *statusHandler)
+
+// WithAdminApi enables the admin API, which allows the live connections recorded by
+// connections to be inspected and closed. Requests must present adminToken as a
+// bearer token.
+func WithAdminApi(connections *ConnectionTracker, adminToken string) StatusOpt {
+	return func(handler *statusHandler) {
+		handler.connections = connections
+		handler.adminToken = adminToken
+	}
+}
+
+// WithCaptureControl adds endpoints to the admin API that turn the capture of the frames
+// exchanged with a charge station on and off
+func WithCaptureControl(capture *FrameCapture) StatusOpt {
+	return func(handler *statusHandler) {
+		handler.capture = capture
+	}
+}
+
+// WithDrainControl reports the gateway as unhealthy whilst it is draining and adds an
+// endpoint to the admin API that starts the drain
+func WithDrainControl(drainer *Drainer) StatusOpt {
+	return func(handler *statusHandler) {
+		handler.drainer = drainer
+	}
+}
+
+func NewStatusHandler(opts ...StatusOpt) http.Handler {
+	s := new(statusHandler)
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
-	r.Get("/health", health)
+	r.Get("/health", health(s.drainer))
 	r.Handle("/metrics", promhttp.Handler())
+	if s.connections != nil && s.adminToken != "" {
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(RequireBearerToken(s.adminToken))
+			r.Get("/connections", listConnections(s.connections))
+			r.Get("/connections/{id}", getConnection(s.connections))
+			r.Post("/connections/{id}/disconnect", disconnectConnection(s.connections))
+			if s.capture != nil {
+				r.Get("/capture", listCapturedStations(s.capture))
+				r.Put("/capture/{id}", enableCapture(s.capture))
+				r.Delete("/capture/{id}", disableCapture(s.capture))
+			}
+			if s.drainer != nil {
+				r.Post("/drain", startDrain(s.drainer))
+			}
+		})
+	}
 	return r
 }
 
-func health(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status":"OK"}`))
+func health(drainer *Drainer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if drainer.Draining() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"DRAINING"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}
 }
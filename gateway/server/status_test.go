@@ -3,10 +3,21 @@
 package server_test
 
 import (
-	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"nhooyr.io/websocket"
 )
 
 func TestHealthHandler(t *testing.T) {
@@ -27,3 +38,211 @@ func TestHealthHandler(t *testing.T) {
 		t.Errorf("status code: want %d, got %d", http.StatusOK, res.StatusCode)
 	}
 }
+
+func TestAdminApiRequiresBearerToken(t *testing.T) {
+	handler := server.NewStatusHandler(server.WithAdminApi(server.NewConnectionTracker(), "secret"))
+
+	for _, authHeader := range []string{"", "Bearer wrong", "Basic c2VjcmV0"} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/connections", nil)
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, authHeader)
+	}
+}
+
+func TestAdminApiDisabledWithoutToken(t *testing.T) {
+	handler := server.NewStatusHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/connections", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminApiListsAndDisconnectsConnections(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	defer func() {
+		_ = broker.Close()
+	}()
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["adminCS"] = &registry.ChargeStation{
+		ClientId:             "adminCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	connections := server.NewConnectionTracker()
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections)))
+	defer srv.Close()
+	admin := server.NewStatusHandler(server.WithAdminApi(connections, "secret"))
+
+	adminRequest := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		admin.ServeHTTP(w, req)
+		return w
+	}
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("adminCS:password"))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/adminCS", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp1.6"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	// the connection is tracked once the websocket has been accepted
+	var infos []server.ConnectionInfo
+	require.Eventually(t, func() bool {
+		w := adminRequest(http.MethodGet, "/admin/connections", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &infos))
+		return len(infos) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "adminCS", infos[0].Id)
+	assert.Equal(t, "ocpp1.6", infos[0].Protocol)
+	assert.False(t, infos[0].ConnectedSince.IsZero())
+
+	w := adminRequest(http.MethodGet, "/admin/connections/unknownCS", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = adminRequest(http.MethodPost, "/admin/connections/adminCS/disconnect", `{"code":1005}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = adminRequest(http.MethodPost, "/admin/connections/adminCS/disconnect", `{"code":4000,"reason":"password rotated"}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	_, _, err = conn.Read(ctx)
+	var closeErr websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, websocket.StatusCode(4000), closeErr.Code)
+	assert.Equal(t, "password rotated", closeErr.Reason)
+}
+
+func TestAdminApiControlsCapture(t *testing.T) {
+	capture, err := server.NewFrameCapture(t.TempDir(), server.WithCaptureStations("cs001"))
+	require.NoError(t, err)
+	defer capture.Close()
+	admin := server.NewStatusHandler(
+		server.WithAdminApi(server.NewConnectionTracker(), "secret"),
+		server.WithCaptureControl(capture))
+
+	adminRequest := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		w := httptest.NewRecorder()
+		admin.ServeHTTP(w, req)
+		return w
+	}
+
+	w := adminRequest(http.MethodPut, "/admin/capture/cs002")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = adminRequest(http.MethodDelete, "/admin/capture/cs001")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = adminRequest(http.MethodGet, "/admin/capture")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list server.CaptureList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, []string{"cs002"}, list.Stations)
+	assert.True(t, capture.Enabled("cs002"))
+	assert.False(t, capture.Enabled("cs001"))
+}
+
+func TestAdminApiStartsDrain(t *testing.T) {
+	drainer := server.NewDrainer(server.NewConnectionTracker())
+	admin := server.NewStatusHandler(
+		server.WithAdminApi(server.NewConnectionTracker(), "secret"),
+		server.WithDrainControl(drainer))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/drain", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	admin.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.True(t, drainer.Draining())
+
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"DRAINING"}`, w.Body.String())
+}
+
+func TestConnectionsReportQueuedCsmsCalls(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	require.NoError(t, broker.Serve())
+	defer func() {
+		_ = broker.Close()
+	}()
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["queueCS"] = &registry.ChargeStation{
+		ClientId:             "queueCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	connections := server.NewConnectionTracker()
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithConnectionTracker(connections)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("queueCS:password"))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/queueCS", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+	require.Eventually(t, func() bool {
+		return len(connections.List()) == 1 &&
+			len(broker.Topics.Subscribers("cs/out/ocpp2.0.1/queueCS").Subscriptions) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the charge station does not answer the first call, so the second is queued
+	for _, id := range []string{"1", "2"} {
+		err = broker.Publish("cs/out/ocpp2.0.1/queueCS",
+			[]byte(fmt.Sprintf(`{"type":2,"id":"%s","action":"Reset","request":{"type":"Immediate"}}`, id)), false, 0)
+		require.NoError(t, err)
+	}
+	_, _, err = conn.Read(ctx)
+	require.NoError(t, err)
+
+	var info server.ConnectionInfo
+	require.Eventually(t, func() bool {
+		info, _ = connections.Get("queueCS")
+		return info.QueuedCsmsCalls == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotNil(t, info.PendingCsmsCall)
+	assert.Equal(t, "1", info.PendingCsmsCall.MessageId)
+	require.NotNil(t, info.OldestQueuedCsmsCallAt)
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/eclipse/paho.golang/paho"
+	"golang.org/x/exp/slog"
+)
+
+// csmsTransport carries the messages exchanged with the CSMS. Whatever the transport, the
+// messages are represented as MQTT publish packets addressed to MQTT topics: a transport
+// that uses a different protocol translates them.
+type csmsTransport interface {
+	// start connects to the broker and subscribes to the messages from the CSMS. It is
+	// called when the gateway starts, and again by awaitConnection and publish, which
+	// return the error from the first call.
+	start() error
+	// register arranges for the messages published by the CSMS for the charge station to
+	// be passed to handler. Messages that arrive whilst handler is too far behind are passed
+	// to overflow instead, on a separate goroutine. The returned function must be called to
+	// stop receiving messages.
+	register(protocol, clientId string, handler, overflow func(*paho.Publish)) func()
+	// awaitConnection waits until the messages for the charge station can be published
+	awaitConnection(ctx context.Context, clientId string) error
+	// publish sends a message to the CSMS, blocking until it has been handed to the broker
+	publish(ctx context.Context, clientId string, msg *paho.Publish) error
+	// close disconnects from the broker
+	close()
+}
+
+// csmsRouter passes the messages received from the CSMS to the charge station, gateway
+// event or registry handler that they are for
+type csmsRouter struct {
+	topicPrefix      string
+	subscriberBufLen int
+	// eventHandler receives the connection events published by all the gateway instances
+	eventHandler func(*paho.Publish)
+	// registryHandler receives the notifications that the details in the device registry
+	// have changed
+	registryHandler func(*paho.Publish)
+
+	mu          sync.RWMutex
+	subscribers map[string]*mqttSubscriber
+}
+
+// mqttSubscriber receives the messages published by the CSMS for a single charge station
+type mqttSubscriber struct {
+	protocol string
+	clientId string
+	ch       chan *paho.Publish
+	overflow func(*paho.Publish)
+}
+
+func newCsmsRouter(handler *WebsocketHandler) *csmsRouter {
+	return &csmsRouter{
+		topicPrefix:      handler.mqttTopicPrefix,
+		subscriberBufLen: handler.mqttSubscriberBufLen,
+		subscribers:      make(map[string]*mqttSubscriber),
+	}
+}
+
+func (m *csmsRouter) register(protocol, clientId string, handler, overflow func(*paho.Publish)) func() {
+	sub := &mqttSubscriber{
+		protocol: protocol,
+		clientId: clientId,
+		ch:       make(chan *paho.Publish, m.subscriberBufLen),
+		overflow: overflow,
+	}
+
+	key := subscriberKey(protocol, clientId)
+
+	m.mu.Lock()
+	if prev, ok := m.subscribers[key]; ok {
+		slog.Warn("replacing existing mqtt subscriber", "clientId", clientId, "protocol", protocol)
+		close(prev.ch)
+	}
+	m.subscribers[key] = sub
+	m.mu.Unlock()
+
+	go func() {
+		for msg := range sub.ch {
+			handler(msg)
+		}
+	}()
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.subscribers[key] == sub {
+			delete(m.subscribers, key)
+			close(sub.ch)
+		}
+	}
+}
+
+func (m *csmsRouter) dispatch(msg *paho.Publish) {
+	if m.eventHandler != nil && strings.HasPrefix(msg.Topic, m.topicPrefix+"/events/") {
+		// the handler may publish, which must not be done on the router's goroutine
+		go m.eventHandler(msg)
+		return
+	}
+
+	if m.registryHandler != nil && msg.Topic == m.topicPrefix+"/registry" {
+		m.registryHandler(msg)
+		return
+	}
+
+	protocol, clientId, ok := m.parseTopic(msg.Topic)
+	if !ok {
+		slog.Warn("unexpected mqtt topic", "topic", msg.Topic)
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sub, ok := m.subscribers[subscriberKey(protocol, clientId)]
+	if !ok {
+		// the charge station is not connected to this gateway instance
+		return
+	}
+
+	select {
+	case sub.ch <- msg:
+	default:
+		// waiting for the charge station would hold up the messages for every other charge
+		// station, so the message is handed to the overflow handler, which may publish
+		slog.Warn("mqtt subscriber buffer full", "clientId", clientId, "protocol", protocol)
+		if sub.overflow != nil {
+			go sub.overflow(msg)
+		}
+	}
+}
+
+func (m *csmsRouter) parseTopic(topic string) (protocol, clientId string, ok bool) {
+	rest, found := strings.CutPrefix(topic, m.topicPrefix+"/out/")
+	if !found {
+		return "", "", false
+	}
+	protocol, clientId, found = strings.Cut(rest, "/")
+	if !found || protocol == "" || clientId == "" {
+		return "", "", false
+	}
+	return protocol, clientId, true
+}
+
+func subscriberKey(protocol, clientId string) string {
+	return protocol + "/" + clientId
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
+	"github.com/thoughtworks/maeve-csms/gateway/pipe"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCsmsRouterPassesMessagesToOverflowWhenSubscriberIsBehind(t *testing.T) {
+	router := &csmsRouter{
+		topicPrefix:      "cs",
+		subscriberBufLen: 1,
+		subscribers:      make(map[string]*mqttSubscriber),
+	}
+
+	handled := make(chan *paho.Publish)
+	overflowed := make(chan *paho.Publish, 10)
+	unregister := router.register("ocpp2.0.1", "cs001", func(msg *paho.Publish) {
+		handled <- msg
+	}, func(msg *paho.Publish) {
+		overflowed <- msg
+	})
+	defer unregister()
+
+	// the first message is taken by the handler, which blocks, and the second is buffered
+	for _, id := range []string{"1", "2", "3"} {
+		router.dispatch(&paho.Publish{Topic: "cs/out/ocpp2.0.1/cs001", Payload: []byte(id)})
+		if id == "1" {
+			require.Eventually(t, func() bool { return len(router.subscribers["ocpp2.0.1/cs001"].ch) == 0 }, time.Second, time.Millisecond)
+		}
+	}
+
+	select {
+	case msg := <-overflowed:
+		assert.Equal(t, "3", string(msg.Payload))
+	case <-time.After(time.Second):
+		t.Fatal("message not passed to overflow")
+	}
+	assert.Equal(t, "1", string((<-handled).Payload))
+	assert.Equal(t, "2", string((<-handled).Payload))
+}
+
+func TestRejectCsmsMessageAnswersCallsWithCallError(t *testing.T) {
+	local := NewLocalTransport(1)
+	handler := &WebsocketHandler{
+		mqttTopicPrefix:    "cs",
+		mqttConnectTimeout: time.Second,
+		transport:          local,
+		tracer:             trace.NewNoopTracerProvider().Tracer(""),
+	}
+
+	call, err := json.Marshal(&pipe.GatewayMessage{
+		MessageType:    ocpp.MessageTypeCall,
+		Action:         "Reset",
+		MessageId:      "1234",
+		RequestPayload: json.RawMessage(`{"type":"Immediate"}`),
+	})
+	require.NoError(t, err)
+	handler.rejectCsmsMessage("ocpp2.0.1", "cs001", &paho.Publish{Topic: "cs/out/ocpp2.0.1/cs001", Payload: call})
+
+	msg := <-local.Messages()
+	assert.Equal(t, "cs/in/ocpp2.0.1/cs001", msg.Topic)
+	var got pipe.GatewayMessage
+	require.NoError(t, json.Unmarshal(msg.Payload, &got))
+	assert.Equal(t, ocpp.MessageTypeCallError, got.MessageType)
+	assert.Equal(t, "Reset", got.Action)
+	assert.Equal(t, "1234", got.MessageId)
+	assert.Equal(t, ocpp.ErrorGenericError, got.ErrorCode)
+
+	// there is nothing to answer a response with
+	result, err := json.Marshal(&pipe.GatewayMessage{
+		MessageType: ocpp.MessageTypeCallResult,
+		Action:      "Heartbeat",
+		MessageId:   "5678",
+	})
+	require.NoError(t, err)
+	handler.rejectCsmsMessage("ocpp2.0.1", "cs001", &paho.Publish{Topic: "cs/out/ocpp2.0.1/cs001", Payload: result})
+	assert.Empty(t, local.Messages())
+}
@@ -20,7 +20,6 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/eclipse/paho.golang/autopaho"
 	"github.com/eclipse/paho.golang/paho"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -33,16 +32,37 @@ import (
 )
 
 type WebsocketHandler struct {
-	mqttBrokerURLs        []*url.URL
-	mqttTopicPrefix       string
-	mqttConnectTimeout    time.Duration
-	mqttConnectRetryDelay time.Duration
-	mqttKeepAliveInterval uint16
-	deviceRegistry        registry.DeviceRegistry
-	orgNames              []string
-	pipeOptions           []pipe.Opt
-	trustProxyHeaders     bool
-	tracer                trace.Tracer
+	mqttBrokerURLs            []*url.URL
+	mqttTopicPrefix           string
+	mqttConnectTimeout        time.Duration
+	mqttConnectRetryDelay     time.Duration
+	mqttKeepAliveInterval     uint16
+	mqttPoolSize              int
+	mqttClientIdPrefix        string
+	mqttSubscriberBufLen      int
+	natsURLs                  []string
+	natsStream                string
+	localTransport            *LocalTransport
+	transport                 csmsTransport
+	outboundQueueLen          int
+	outboundSpillDir          string
+	outboundSpillLen          int
+	deviceRegistry            registry.DeviceRegistry
+	connections               *ConnectionTracker
+	duplicateConnectionPolicy DuplicateConnectionPolicy
+	pingInterval              time.Duration
+	pongTimeout               time.Duration
+	idleTimeout               time.Duration
+	maxMessageSize            int64
+	defaultRateLimit          *RateLimit
+	actionRateLimits          []actionRateLimit
+	orgNames                  []string
+	revocationChecker         *RevocationChecker
+	capture                   *FrameCapture
+	drainer                   *Drainer
+	pipeOptions               []pipe.Opt
+	trustProxyHeaders         bool
+	tracer                    trace.Tracer
 }
 
 type WebsocketOpt func(handler *WebsocketHandler)
@@ -83,12 +103,136 @@ func WithMqttConnectSettings(mqttConnectTimeout, mqttConnectRetryDelay, mqttKeep
 	}
 }
 
+// WithMqttPoolSize sets the number of MQTT connections that are shared by all
+// the charge stations connected to the gateway
+func WithMqttPoolSize(poolSize int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.mqttPoolSize = poolSize
+	}
+}
+
+// WithMqttClientIdPrefix sets the prefix of the MQTT client ids used by the
+// connection pool. Each gateway instance must use a different prefix.
+func WithMqttClientIdPrefix(clientIdPrefix string) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.mqttClientIdPrefix = clientIdPrefix
+	}
+}
+
+// WithNatsUrl uses NATS JetStream to exchange messages with the CSMS instead of MQTT.
+// The topic prefix, client id prefix and connection retry delay are used for NATS too.
+func WithNatsUrl(natsUrl string) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.natsURLs = append(handler.natsURLs, natsUrl)
+	}
+}
+
+// WithNatsStream sets the name of the JetStream stream that holds the messages to the CSMS
+func WithNatsStream(stream string) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.natsStream = stream
+	}
+}
+
+// WithMqttSubscriberBufLen sets the number of messages from the CSMS that will be
+// buffered for each charge station before further calls are rejected with a CALLERROR
+// and further responses are dropped
+func WithMqttSubscriberBufLen(bufLen int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.mqttSubscriberBufLen = bufLen
+	}
+}
+
+// WithOutboundQueueLen sets the number of messages from each charge station that will be
+// held in memory whilst the MQTT broker is unavailable. The messages held in memory are
+// discarded when the charge station disconnects unless WithOutboundSpill is also used.
+func WithOutboundQueueLen(queueLen int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.outboundQueueLen = queueLen
+	}
+}
+
+// WithOutboundSpill enables messages from each charge station that do not fit in memory
+// whilst the MQTT broker is unavailable to be written to a file in spillDir, up to spillLen
+// messages per charge station. The messages that have not been published when the charge
+// station disconnects are also written to the file and are published once it reconnects.
+func WithOutboundSpill(spillDir string, spillLen int) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.outboundSpillDir = spillDir
+		handler.outboundSpillLen = spillLen
+	}
+}
+
 func WithDeviceRegistry(deviceRegistry registry.DeviceRegistry) WebsocketOpt {
 	return func(handler *WebsocketHandler) {
 		handler.deviceRegistry = deviceRegistry
 	}
 }
 
+// WithConnectionTracker sets the tracker that records the live charge station connections
+func WithConnectionTracker(connections *ConnectionTracker) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.connections = connections
+	}
+}
+
+// WithDuplicateConnectionPolicy sets what happens when a charge station connects whilst it
+// already has a connection to this, or another, gateway instance
+func WithDuplicateConnectionPolicy(policy DuplicateConnectionPolicy) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.duplicateConnectionPolicy = policy
+	}
+}
+
+// WithPingInterval sets the interval at which websocket pings are sent to the charge
+// station. Zero disables pings.
+func WithPingInterval(pingInterval time.Duration) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.pingInterval = pingInterval
+	}
+}
+
+// WithPongTimeout sets how long to wait for the charge station to answer a ping before
+// the connection is closed
+func WithPongTimeout(pongTimeout time.Duration) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.pongTimeout = pongTimeout
+	}
+}
+
+// WithIdleTimeout sets how long the charge station can go without sending a message
+// before the connection is closed. Zero disables the idle timeout.
+func WithIdleTimeout(idleTimeout time.Duration) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.idleTimeout = idleTimeout
+	}
+}
+
+// WithMaxMessageSize sets the size of the largest message, in bytes, that will be accepted
+// from a charge station. Calls that are too large are answered with a CALLERROR and the
+// connection is closed.
+func WithMaxMessageSize(maxMessageSize int64) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.maxMessageSize = maxMessageSize
+	}
+}
+
+// WithRateLimit limits the rate at which each charge station can make calls. If actions are
+// provided then the calls for those actions share a token bucket, otherwise the limit
+// applies to each call that does not have a limit for its action.
+func WithRateLimit(limit RateLimit, actions ...string) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		if len(actions) == 0 {
+			handler.defaultRateLimit = &limit
+		} else {
+			handler.actionRateLimits = append(handler.actionRateLimits, actionRateLimit{
+				actions: actions,
+				limit:   limit,
+			})
+		}
+	}
+}
+
 func WithOrgName(orgName string) WebsocketOpt {
 	return func(handler *WebsocketHandler) {
 		handler.orgNames = append(handler.orgNames, orgName)
@@ -101,6 +245,30 @@ func WithOrgNames(orgNames []string) WebsocketOpt {
 	}
 }
 
+// WithRevocationChecker sets the checker used to reject client certificates that have
+// been revoked
+func WithRevocationChecker(revocationChecker *RevocationChecker) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.revocationChecker = revocationChecker
+	}
+}
+
+// WithFrameCapture records the frames exchanged with the charge stations for which
+// capture is enabled
+func WithFrameCapture(capture *FrameCapture) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.capture = capture
+	}
+}
+
+// WithDrainer refuses new connections whilst the gateway is draining. The drainer must
+// use the same connection tracker as the handler.
+func WithDrainer(drainer *Drainer) WebsocketOpt {
+	return func(handler *WebsocketHandler) {
+		handler.drainer = drainer
+	}
+}
+
 func WithTrustProxyHeaders(trustProxyHeaders bool) WebsocketOpt {
 	return func(handler *WebsocketHandler) {
 		handler.trustProxyHeaders = trustProxyHeaders
@@ -125,7 +293,20 @@ func WithOtelTracer(tracer trace.Tracer) WebsocketOpt {
 	}
 }
 
-func NewWebsocketHandler(opts ...WebsocketOpt) http.Handler {
+// WebsocketServer is the http.Handler that accepts the charge station websocket connections.
+// Close must be called when the gateway shuts down, once the connections have been drained,
+// to disconnect from the broker.
+type WebsocketServer struct {
+	http.Handler
+	transport csmsTransport
+}
+
+// Close disconnects the gateway from the broker that carries the messages to and from the CSMS
+func (s *WebsocketServer) Close() {
+	s.transport.close()
+}
+
+func NewWebsocketHandler(opts ...WebsocketOpt) *WebsocketServer {
 	s := new(WebsocketHandler)
 
 	for _, opt := range opts {
@@ -134,6 +315,25 @@ func NewWebsocketHandler(opts ...WebsocketOpt) http.Handler {
 
 	ensureDefaults(s)
 
+	router := newCsmsRouter(s)
+	router.eventHandler = s.handleGatewayEvent
+	if invalidator, ok := s.deviceRegistry.(registry.Invalidator); ok {
+		router.registryHandler = registryInvalidationHandler(invalidator)
+	}
+	if s.localTransport != nil {
+		s.localTransport.csmsRouter = router
+		s.transport = s.localTransport
+	} else if s.natsURLs != nil {
+		s.transport = newNatsPool(s, router)
+	} else {
+		s.transport = newMqttPool(s, router)
+	}
+	// connect straight away, rather than when the first charge station connects, so that the
+	// connection events and registry notifications are received from startup
+	if err := s.transport.start(); err != nil {
+		slog.Error("connecting to the csms transport", "err", err)
+	}
+
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
 	r.Use(TraceRequest(s.tracer))
@@ -141,7 +341,7 @@ func NewWebsocketHandler(opts ...WebsocketOpt) http.Handler {
 		r.Use(TLSOffload(s.deviceRegistry))
 	}
 	r.Handle("/ws/{id}", s)
-	return r
+	return &WebsocketServer{Handler: r, transport: s.transport}
 }
 
 func ensureDefaults(handler *WebsocketHandler) {
@@ -165,10 +365,46 @@ func ensureDefaults(handler *WebsocketHandler) {
 		handler.mqttKeepAliveInterval = 10
 	}
 
+	if handler.mqttPoolSize <= 0 {
+		handler.mqttPoolSize = 1
+	}
+
+	if handler.mqttClientIdPrefix == "" {
+		handler.mqttClientIdPrefix = randomClientIdPrefix()
+	}
+
+	if handler.natsStream == "" {
+		handler.natsStream = "csms"
+	}
+
+	if handler.mqttSubscriberBufLen <= 0 {
+		handler.mqttSubscriberBufLen = 10
+	}
+
+	if handler.outboundQueueLen <= 0 {
+		handler.outboundQueueLen = 100
+	}
+
 	if handler.deviceRegistry == nil {
 		panic("must provide device registry implementation")
 	}
 
+	if handler.connections == nil {
+		handler.connections = NewConnectionTracker()
+	}
+
+	if handler.pongTimeout <= 0 {
+		handler.pongTimeout = 10 * time.Second
+	}
+
+	if handler.maxMessageSize <= 0 {
+		handler.maxMessageSize = defaultMaxMessageSize
+	}
+
+	if handler.duplicateConnectionPolicy == "" {
+		handler.duplicateConnectionPolicy = DuplicateConnectionCloseOld
+	}
+
 	if handler.tracer == nil {
 		handler.tracer = trace.NewNoopTracerProvider().Tracer("")
 	}
@@ -188,11 +424,20 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	span.SetAttributes(attribute.String("csId", clientId))
 
+	if s.drainer.Draining() {
+		span.SetStatus(codes.Error, "gateway draining")
+		span.SetAttributes(semconv.HTTPStatusCode(http.StatusServiceUnavailable))
+		upgradeFailures.WithLabelValues(upgradeFailureDraining).Inc()
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
 	cs, err := s.deviceRegistry.LookupChargeStation(clientId)
 	if err != nil {
 		span.SetStatus(codes.Error, "lookup charge station failed")
 		span.RecordError(err)
 		span.SetAttributes(semconv.HTTPStatusCode(http.StatusInternalServerError))
+		upgradeFailures.WithLabelValues(upgradeFailureRegistry).Inc()
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
@@ -200,6 +445,7 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		span.SetStatus(codes.Error, "unknown charge station")
 		span.RecordError(err)
 		span.SetAttributes(semconv.HTTPStatusCode(http.StatusNotFound))
+		upgradeFailures.WithLabelValues(upgradeFailureUnknownStation).Inc()
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		return
 	}
@@ -209,9 +455,12 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch cs.SecurityProfile {
 	case registry.UnsecuredTransportWithBasicAuth:
 		if r.TLS != nil || !checkAuthorization(r.Context(), r, cs) {
+			reason := upgradeFailureUnauthorized
 			if r.TLS != nil {
 				span.SetAttributes(attribute.String("auth.failure_reason", "tls for unsecured transport"))
+				reason = upgradeFailureTLS
 			}
+			upgradeFailures.WithLabelValues(reason).Inc()
 			span.SetStatus(codes.Error, "unauthorized")
 			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
@@ -219,47 +468,87 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	case registry.TLSWithBasicAuth:
 		if r.TLS == nil || !checkAuthorization(r.Context(), r, cs) {
+			reason := upgradeFailureUnauthorized
 			if r.TLS == nil {
 				span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
+				reason = upgradeFailureTLS
 			}
+			upgradeFailures.WithLabelValues(reason).Inc()
 			span.SetStatus(codes.Error, "unauthorized")
 			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
 		}
 	case registry.TLSWithClientSideCertificates:
-		if r.TLS == nil || !checkCertificate(r.Context(), r, s.orgNames, cs) {
+		if r.TLS == nil || !checkCertificate(r.Context(), r, s.orgNames, cs, s.revocationChecker) {
+			reason := upgradeFailureUnauthorized
 			if r.TLS == nil {
 				span.SetAttributes(attribute.String("auth.failure_reason", "no tls for secured transport"))
+				reason = upgradeFailureTLS
 			}
+			upgradeFailures.WithLabelValues(reason).Inc()
 			span.SetStatus(codes.Error, "unauthorized")
 			span.SetAttributes(semconv.HTTPStatusCode(http.StatusUnauthorized))
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 			return
 		}
 	default:
+		upgradeFailures.WithLabelValues(upgradeFailureUnauthorized).Inc()
 		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
 		return
 	}
 
-	wsConn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: []string{"ocpp2.0.1", "ocpp1.6"}, InsecureSkipVerify: true})
+	if s.duplicateConnectionPolicy == DuplicateConnectionRejectNew {
+		if existing := s.connections.get(clientId); existing != nil {
+			span.AddEvent("duplicate connection", trace.WithAttributes(
+				attribute.String("duplicate.policy", string(DuplicateConnectionRejectNew)),
+				attribute.String("duplicate.gateway_id", s.mqttClientIdPrefix),
+				attribute.String("duplicate.connected_since", existing.connectedSince.Format(time.RFC3339Nano))))
+			span.SetStatus(codes.Error, "duplicate connection")
+			span.SetAttributes(semconv.HTTPStatusCode(http.StatusConflict))
+			slog.Warn("duplicate connection - rejecting new connection", "clientId", clientId,
+				"connectedSince", existing.connectedSince)
+			upgradeFailures.WithLabelValues(upgradeFailureDuplicate).Inc()
+			http.Error(w, http.StatusText(http.StatusConflict), http.StatusConflict)
+			return
+		}
+	}
+
+	wsConn, err := websocket.Accept(w, r, &websocket.AcceptOptions{Subprotocols: ocppSubprotocols, InsecureSkipVerify: true})
 	if err != nil {
 		span.SetAttributes(attribute.String("websocket.accept_failure_reason", err.Error()))
+		upgradeFailures.WithLabelValues(upgradeFailureWebsocket).Inc()
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 		return
 	}
 
+	wsConn.SetReadLimit(s.maxMessageSize)
+
 	protocol := wsConn.Subprotocol()
 	if protocol == "" {
-		protocol = "ocpp2.0.1"
+		protocol = defaultOcppSubprotocol
 	}
 
 	span.SetAttributes(attribute.String("ocpp.protocol", protocol))
 
+	untrack := trackConnection(protocol, cs.SecurityProfile)
+	defer untrack()
+
+	connectedEvent := newConnectionEvent(r, clientId, cs, protocol, s.mqttClientIdPrefix, s.trustProxyHeaders)
+
 	p := pipe.NewPipe(s.pipeOptions...)
 	p.Start()
 	defer p.Close()
 
+	conn := newTrackedConnection(wsConn, connectedEvent, p.QueueStats)
+	remove, existing := s.connections.add(conn)
+	defer remove()
+	if existing != nil {
+		// either the policy is to close the existing connection or the charge station
+		// connected twice at the same time
+		s.replace(r.Context(), existing)
+	}
+
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 
@@ -267,99 +556,105 @@ func (s *WebsocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	for i, u := range s.mqttBrokerURLs {
 		mqttBrokerURLStrings[i] = u.String()
 	}
-	span.SetAttributes(attribute.StringSlice("mqtt.broker_urls", mqttBrokerURLStrings))
-
-	var mqttConn *autopaho.ConnectionManager
-	mqttConn, err = autopaho.NewConnection(ctx, autopaho.ClientConfig{
-		BrokerUrls:        s.mqttBrokerURLs,
-		KeepAlive:         s.mqttKeepAliveInterval,
-		ConnectRetryDelay: s.mqttConnectRetryDelay,
-		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
-			topicName := fmt.Sprintf("%s/out/%s/%s", s.mqttTopicPrefix, protocol, clientId)
-			span.SetAttributes(attribute.String("mqtt.topic", topicName))
-			_, err = manager.Subscribe(ctx, &paho.Subscribe{
-				Subscriptions: map[string]paho.SubscribeOptions{
-					topicName: {},
-				},
-			})
-			if err != nil {
-				span.SetStatus(codes.Error, "subscribing to mqtt topic failed")
-				span.RecordError(err)
-				span.SetAttributes(semconv.HTTPStatusCode(http.StatusInternalServerError))
+	span.SetAttributes(
+		attribute.StringSlice("mqtt.broker_urls", mqttBrokerURLStrings),
+		attribute.String("mqtt.topic", fmt.Sprintf("%s/out/%s/%s", s.mqttTopicPrefix, protocol, clientId)))
 
-				_ = wsConn.Close(websocket.StatusProtocolError, http.StatusText(http.StatusInternalServerError))
-				return
-			}
-		},
-		ClientConfig: paho.ClientConfig{
-			ClientID: clientId,
-			Router: paho.NewSingleHandlerRouter(func(mqttMsg *paho.Publish) {
-				// route requests from the CSMS
-				var msg pipe.GatewayMessage
-				err := json.Unmarshal(mqttMsg.Payload, &msg)
-				if err != nil {
-					slog.Error("unmarshalling CSMS message", "err", err)
-					return
-				}
+	unregister := s.transport.register(protocol, clientId, func(mqttMsg *paho.Publish) {
+		// route requests from the CSMS
+		var msg pipe.GatewayMessage
+		err := json.Unmarshal(mqttMsg.Payload, &msg)
+		if err != nil {
+			slog.Error("unmarshalling CSMS message", "err", err)
+			return
+		}
 
-				correlationMap := make(map[string]string)
-				err = json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
-				if err != nil {
-					slog.Warn("unmarshalling correlation map", "err", err)
-				}
-				requestContext := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(correlationMap))
-
-				newCtx, span := s.tracer.Start(requestContext, fmt.Sprintf("%s/out/%s/# receive", s.mqttTopicPrefix, protocol),
-					trace.WithSpanKind(trace.SpanKindConsumer),
-					trace.WithAttributes(
-						semconv.MessagingSystem("mqtt"),
-						semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
-						semconv.MessagingMessageConversationID(msg.MessageId),
-						semconv.MessagingOperationKey.String("receive"),
-						attribute.String("csId", clientId),
-					))
-				defer span.End()
-
-				msg.Context = newCtx
-
-				p.CSMSRx <- &msg
-			}),
-			OnServerDisconnect: func(disconnect *paho.Disconnect) {
-				span.SetAttributes(attribute.String("mqtt.disconnect_reason", disconnect.Properties.ReasonString))
-			},
-		},
+		correlationMap := make(map[string]string)
+		err = json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
+		if err != nil {
+			slog.Warn("unmarshalling correlation map", "err", err)
+		}
+		requestContext := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(correlationMap))
+
+		newCtx, span := s.tracer.Start(requestContext, fmt.Sprintf("%s/out/%s/# receive", s.mqttTopicPrefix, protocol),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("mqtt"),
+				semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
+				semconv.MessagingMessageConversationID(msg.MessageId),
+				semconv.MessagingOperationKey.String("receive"),
+				attribute.String("csId", clientId),
+			))
+		defer span.End()
+
+		msg.Context = newCtx
+
+		select {
+		case p.CSMSRx <- &msg:
+		case <-ctx.Done():
+		}
+	}, func(mqttMsg *paho.Publish) {
+		s.rejectCsmsMessage(protocol, clientId, mqttMsg)
 	})
+	defer unregister()
+
+	queue, err := newOutboundQueue(clientId, s.outboundQueueLen, s.outboundSpillDir, s.outboundSpillLen)
 	if err != nil {
-		span.SetStatus(codes.Error, "connecting to mqtt")
+		span.SetStatus(codes.Error, "creating outbound buffer")
 		span.RecordError(err)
-		span.SetAttributes(semconv.HTTPStatusCode(http.StatusInternalServerError))
-		slog.Error("connecting to mqtt", "mqttBrokerURLs", s.mqttBrokerURLs, "err", err)
-		_ = wsConn.Close(websocket.StatusProtocolError, http.StatusText(http.StatusInternalServerError))
+		slog.Error("creating outbound buffer", "clientId", clientId, "err", err)
+		_ = wsConn.Close(websocket.StatusInternalError, http.StatusText(http.StatusInternalServerError))
 		return
 	}
-	defer func() {
-		err := mqttConn.Disconnect(context.Background())
-		if err != nil {
-			slog.Error("disconnecting from mqtt", "err", err)
-		}
-	}()
-	err = mqttConn.AwaitConnection(ctx)
+
+	// wait a while for the connection to the broker: if it is not available then messages
+	// from the charge station will be buffered until it is
+	awaitCtx, awaitCancel := context.WithTimeout(ctx, s.mqttConnectTimeout)
+	err = s.transport.awaitConnection(awaitCtx, clientId)
+	awaitCancel()
 	if err != nil {
-		slog.Error("waiting for mqtt", "mqttBrokerURLs", s.mqttBrokerURLs, "err", err)
-		return
+		span.SetAttributes(attribute.Bool("mqtt.connected", false))
+		slog.Warn("mqtt not available - buffering messages", "mqttBrokerURLs", s.mqttBrokerURLs, "clientId", clientId, "err", err)
 	}
 
+	// tell the CSMS that the charge station has connected
+	go publishConnectionEvent(context.Background(), s.tracer, s.transport, s.mqttConnectTimeout, s.mqttTopicPrefix, connectedEvent)
+
 	// we've finished connecting... complete this span so we get to see the details in the trace
 	span.End()
 
 	// listen on the CSMS Tx channel and publish those messages on the inbound topic
-	goPublishToCSMS(ctx, s.tracer, p.CSMSTx, mqttConn, s.mqttTopicPrefix, protocol, clientId)
+	published := goPublishToCSMS(ctx, s.tracer, queue, p.CSMSTx, p.CSMSRx, s.transport, s.mqttConnectRetryDelay, s.mqttTopicPrefix, protocol, clientId)
 
 	// listen the CS Tx channel and write those messages to the websocket
-	goWriteToChargeStation(ctx, s.tracer, p.ChargeStationTx, wsConn, protocol, clientId)
+	goWriteToChargeStation(ctx, s.tracer, p.ChargeStationTx, wsConn, conn, s.capture, protocol, clientId)
+
+	// close the websocket if the charge station stops responding
+	goMonitorLiveness(ctx, s.tracer, conn, s.pingInterval, s.pongTimeout, s.idleTimeout)
 
 	// read from the websocket and send to the CS Rx channel (CS Tx used for error)
-	readFromChargeStation(ctx, s.tracer, wsConn, p.ChargeStationRx, p.ChargeStationTx, protocol, clientId)
+	limits := &messageLimits{
+		maxMessageSize: s.maxMessageSize,
+		rateLimiter:    newRateLimiter(s.defaultRateLimit, s.actionRateLimits),
+	}
+	err = readFromChargeStation(ctx, s.tracer, wsConn, conn, limits, s.capture, p.ChargeStationRx, p.ChargeStationTx, protocol, clientId)
+
+	// wait for the outbound buffer to be released so that a replacement connection can use it
+	cancel()
+	<-published
+
+	if conn.superseded.Load() {
+		// the charge station is still connected via another connection
+		return
+	}
+
+	// tell the CSMS that the charge station has disconnected
+	disconnectedEvent := connectedEvent.disconnected(err)
+	if code, reason, ok := conn.closedByGateway(); ok {
+		disconnectedEvent.CloseCode = int(code)
+		disconnectedEvent.CloseReason = reason
+	}
+	publishConnectionEvent(context.Background(), s.tracer, s.transport, s.mqttConnectTimeout, s.mqttTopicPrefix, disconnectedEvent)
 }
 
 func getScheme(r *http.Request) string {
@@ -394,7 +689,7 @@ func checkAuthorization(ctx context.Context, r *http.Request, cs *registry.Charg
 	return result
 }
 
-func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, cs *registry.ChargeStation) bool {
+func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, cs *registry.ChargeStation, revocationChecker *RevocationChecker) bool {
 	span := trace.SpanFromContext(ctx)
 
 	if len(r.TLS.PeerCertificates) == 0 {
@@ -422,6 +717,20 @@ func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, c
 		return false
 	}
 
+	if !cs.AllowsCertificate(leafCertificate) {
+		span.SetAttributes(attribute.String("auth.failure_reason", "certificate not allowed"))
+		return false
+	}
+
+	if revocationChecker != nil {
+		if err := revocationChecker.Check(ctx, r.TLS); err != nil {
+			slog.Warn("rejecting client certificate", "clientId", cs.ClientId,
+				"serialNumber", leafCertificate.SerialNumber, "err", err)
+			span.SetAttributes(attribute.String("auth.failure_reason", err.Error()))
+			return false
+		}
+	}
+
 	//result := cs.ClientId == leafCertificate.Subject.CommonName
 	//
 	//if !result {
@@ -431,28 +740,140 @@ func checkCertificate(ctx context.Context, r *http.Request, orgNames []string, c
 	return foundOrg
 }
 
-func goPublishToCSMS(ctx context.Context, tracer trace.Tracer, csmsTx chan *pipe.GatewayMessage, mqttConn *autopaho.ConnectionManager, topicPrefix, protocol, clientId string) {
+// goPublishToCSMS publishes the messages from the charge station to the CSMS until ctx is done.
+// The returned channel is closed once the outbound buffer has been released.
+func goPublishToCSMS(ctx context.Context, tracer trace.Tracer, queue *outboundQueue, csmsTx, csmsRx chan *pipe.GatewayMessage, transport csmsTransport, retryDelay time.Duration, topicPrefix, protocol, clientId string) <-chan struct{} {
+	done := make(chan struct{})
+
+	// queue messages from the charge station so they are held while the broker is unavailable
 	go func() {
 		for {
 			select {
 			case msg := <-csmsTx:
-				data, err := json.Marshal(msg)
+				ok, err := queue.push(msg)
 				if err != nil {
-					slog.Error("marshaling message for publication", "err", err)
+					slog.Error("buffering message for publication", "clientId", clientId, "err", err)
+				}
+				if ok {
 					continue
 				}
-				err = publish(msg.Context, tracer, mqttConn, topicPrefix, protocol, clientId, msg.MessageId, data)
-				if err != nil {
-					slog.Error("publishing message", "err", err)
+				slog.Warn("outbound buffer exhausted - dropping message", "clientId", clientId, "messageId", msg.MessageId)
+				if msg.MessageType == ocpp.MessageTypeCall {
+					// reply to the charge station as if the CSMS had rejected the call
+					errMsg := &pipe.GatewayMessage{
+						Context:          msg.Context,
+						MessageType:      ocpp.MessageTypeCallError,
+						MessageId:        msg.MessageId,
+						ErrorCode:        ocpp.ErrorInternalError,
+						ErrorDescription: "CSMS unavailable",
+					}
+					select {
+					case csmsRx <- errMsg:
+					case <-ctx.Done():
+						return
+					}
 				}
 			case <-ctx.Done():
 				return
 			}
 		}
 	}()
+
+	// publish queued messages in order, retrying until each has been published
+	go func() {
+		defer close(done)
+		defer func() {
+			dropped, err := queue.close()
+			if err != nil {
+				slog.Error("persisting outbound buffer", "clientId", clientId, "err", err)
+			}
+			if dropped > 0 {
+				slog.Warn("discarding unpublished messages", "clientId", clientId, "count", dropped)
+			}
+		}()
+
+		for {
+			msg, err := queue.peek()
+			if err != nil {
+				slog.Error("reading outbound buffer", "clientId", clientId, "err", err)
+			}
+			if msg == nil {
+				select {
+				case <-queue.notify:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			data, err := json.Marshal(msg)
+			if err != nil {
+				slog.Error("marshaling message for publication", "err", err)
+				queue.pop()
+				continue
+			}
+
+			err = transport.awaitConnection(ctx, clientId)
+			if err == nil {
+				err = publish(msg.Context, tracer, transport, topicPrefix, protocol, clientId, msg.MessageId, data)
+			}
+			if err == nil {
+				queue.pop()
+				continue
+			}
+
+			slog.Error("publishing message", "clientId", clientId, "messageId", msg.MessageId, "buffered", queue.len(), "err", err)
+			select {
+			case <-time.After(retryDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return done
+}
+
+// rejectCsmsMessage handles a message from the CSMS that could not be passed to the charge
+// station because too many messages are waiting for it. A call is answered with a CALLERROR
+// as if the charge station had rejected it: any other message is dropped.
+func (s *WebsocketHandler) rejectCsmsMessage(protocol, clientId string, mqttMsg *paho.Publish) {
+	var msg pipe.GatewayMessage
+	err := json.Unmarshal(mqttMsg.Payload, &msg)
+	if err != nil {
+		slog.Error("unmarshalling CSMS message", "err", err)
+		return
+	}
+	if msg.MessageType != ocpp.MessageTypeCall {
+		slog.Warn("too many CSMS messages waiting for the charge station - dropping message",
+			"clientId", clientId, "messageId", msg.MessageId)
+		return
+	}
+
+	slog.Warn("too many CSMS messages waiting for the charge station - rejecting call",
+		"clientId", clientId, "messageId", msg.MessageId, "action", msg.Action)
+	data, err := json.Marshal(&pipe.GatewayMessage{
+		MessageType:      ocpp.MessageTypeCallError,
+		Action:           msg.Action,
+		MessageId:        msg.MessageId,
+		RequestPayload:   msg.RequestPayload,
+		ErrorCode:        ocpp.ErrorGenericError,
+		ErrorDescription: "too many messages waiting for the charge station",
+	})
+	if err != nil {
+		slog.Error("marshaling message for publication", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.mqttConnectTimeout)
+	defer cancel()
+	err = publish(ctx, s.tracer, s.transport, s.mqttTopicPrefix, protocol, clientId, msg.MessageId, data)
+	if err != nil {
+		slog.Error("publishing message", "clientId", clientId, "messageId", msg.MessageId, "err", err)
+	}
 }
 
-func publish(ctx context.Context, tracer trace.Tracer, mqttConn *autopaho.ConnectionManager, topicPrefix, protocol, clientId, messageId string, data []byte) error {
+func publish(ctx context.Context, tracer trace.Tracer, transport csmsTransport, topicPrefix, protocol, clientId, messageId string, data []byte) error {
 	topic := fmt.Sprintf("%s/in/%s/%s", topicPrefix, protocol, clientId)
 
 	newCtx, span := tracer.Start(ctx,
@@ -475,7 +896,7 @@ func publish(ctx context.Context, tracer trace.Tracer, mqttConn *autopaho.Connec
 		slog.Warn("marshalling correlation map: %v", err)
 	}
 
-	_, err = mqttConn.Publish(newCtx, &paho.Publish{
+	err = transport.publish(newCtx, clientId, &paho.Publish{
 		Topic:   topic,
 		Payload: data,
 		Properties: &paho.PublishProperties{
@@ -484,24 +905,20 @@ func publish(ctx context.Context, tracer trace.Tracer, mqttConn *autopaho.Connec
 			CorrelationData: correlationData,
 		},
 	})
+	if err != nil {
+		span.SetStatus(codes.Error, "publish failed")
+		span.RecordError(err)
+	}
 
 	return err
 }
 
-func goWriteToChargeStation(ctx context.Context, tracer trace.Tracer, chargeStationTx chan *pipe.GatewayMessage, wsConn *websocket.Conn, protocol, clientId string) {
+func goWriteToChargeStation(ctx context.Context, tracer trace.Tracer, chargeStationTx chan *pipe.GatewayMessage, wsConn *websocket.Conn, conn *trackedConnection, capture *FrameCapture, protocol, clientId string) {
 	go func() {
 		for {
 			select {
 			case msg := <-chargeStationTx:
-				data, err := marshalGatewayMessageAsOcpp(msg)
-				if err != nil {
-					slog.Error("marshaling gateway message for charge station", "err", err)
-					continue
-				}
-				err = write(msg.Context, tracer, wsConn, protocol, clientId, msg.MessageId, data)
-				if err != nil {
-					slog.Error("writing to charge station", "err", err)
-				}
+				writeToChargeStation(tracer, wsConn, conn, capture, msg, protocol, clientId)
 			case <-ctx.Done():
 				return
 			}
@@ -509,6 +926,26 @@ func goWriteToChargeStation(ctx context.Context, tracer trace.Tracer, chargeStat
 	}()
 }
 
+// writeToChargeStation writes a message to the websocket, logging any error
+func writeToChargeStation(tracer trace.Tracer, wsConn *websocket.Conn, conn *trackedConnection, capture *FrameCapture, msg *pipe.GatewayMessage, protocol, clientId string) {
+	data, err := marshalGatewayMessageAsOcpp(msg)
+	if err != nil {
+		slog.Error("marshaling gateway message for charge station", "err", err)
+		return
+	}
+	err = write(msg.Context, tracer, wsConn, protocol, clientId, msg.MessageId, data)
+	if err != nil {
+		slog.Error("writing to charge station", "err", err)
+		return
+	}
+	conn.sent(len(data))
+	countMessage(messageDirectionOut, msg.MessageType, msg.Action)
+	capture.record(clientId, protocol, CaptureToChargeStation, websocket.MessageText, data)
+	if msg.MessageType == ocpp.MessageTypeCall {
+		conn.called(msg.MessageId, msg.Action)
+	}
+}
+
 func write(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, protocol, clientId, messageId string, data []byte) error {
 	newCtx, span := tracer.Start(ctx, fmt.Sprintf("%s publish", protocol), trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(
@@ -523,30 +960,58 @@ func write(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, pro
 	return wsConn.Write(newCtx, websocket.MessageText, data)
 }
 
-func readFromChargeStation(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, csRx, csTx chan *pipe.GatewayMessage, protocol, clientId string) {
+// readFromChargeStation reads messages until the connection is closed, returning the
+// error that terminated the connection
+func readFromChargeStation(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, conn *trackedConnection, limits *messageLimits, capture *FrameCapture, csRx, csTx chan *pipe.GatewayMessage, protocol, clientId string) error {
 	for {
-		msg, err := read(ctx, tracer, wsConn, protocol, clientId)
+		msg, err := read(ctx, tracer, wsConn, conn, limits, capture, protocol, clientId)
 		if err != nil {
+			if errors.Is(err, errMessageTooLarge) {
+				// the CALLERROR is written before the connection is closed so that it is not
+				// lost when the writer stops
+				if msg != nil {
+					writeToChargeStation(tracer, wsConn, conn, capture, msg, protocol, clientId)
+				}
+				conn.close(websocket.StatusMessageTooBig, "message too large")
+				return err
+			}
 			if msg != nil {
 				slog.Warn("sending error message to client", "err", err)
 				csTx <- msg
 				continue
 			}
 			// connection closed
-			break
+			return err
 		} else if msg != nil {
 			csRx <- msg
 		} else {
 			// deadline exceeded
-			break
+			return context.DeadlineExceeded
 		}
 	}
 }
 
 var errClient = errors.New("client error")
 
-func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, protocol, clientId string) (*pipe.GatewayMessage, error) {
-	typ, b, err := wsConn.Read(context.Background())
+// messageLimits are the limits applied to the messages read from a charge station
+type messageLimits struct {
+	maxMessageSize int64
+	rateLimiter    *rateLimiter
+}
+
+// readMessage reads a message from the websocket. At most one byte more than the maximum
+// message size is read so that an oversized message is detected without reading all of it.
+func readMessage(wsConn *websocket.Conn, maxMessageSize int64) (websocket.MessageType, []byte, error) {
+	typ, r, err := wsConn.Reader(context.Background())
+	if err != nil {
+		return 0, nil, err
+	}
+	b, err := io.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	return typ, b, err
+}
+
+func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, conn *trackedConnection, limits *messageLimits, capture *FrameCapture, protocol, clientId string) (*pipe.GatewayMessage, error) {
+	typ, b, err := readMessage(wsConn, limits.maxMessageSize)
 	if errors.Is(err, context.DeadlineExceeded) {
 		return nil, nil
 	} else if status := websocket.CloseStatus(err); status != -1 {
@@ -559,6 +1024,9 @@ func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, prot
 		return nil, err
 	}
 
+	conn.received(len(b))
+	capture.record(clientId, protocol, CaptureFromChargeStation, typ, b)
+
 	newCtx, span := tracer.Start(context.Background(), fmt.Sprintf("%s receive", protocol), trace.WithSpanKind(trace.SpanKindConsumer),
 		trace.WithAttributes(
 			semconv.MessagingSystem("websocket"),
@@ -571,6 +1039,26 @@ func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, prot
 		}))
 	defer span.End()
 
+	if int64(len(b)) > limits.maxMessageSize {
+		conn.limited(limitReasonSize)
+		span.SetAttributes(attribute.String("ocpp.limit", limitReasonSize))
+		span.SetStatus(codes.Error, "message too large")
+		messageId, ok := callMessageId(b)
+		slog.Warn("message exceeds maximum size", "clientId", clientId, "messageId", messageId,
+			"maxMessageSize", limits.maxMessageSize)
+		if !ok {
+			return nil, errMessageTooLarge
+		}
+		span.SetAttributes(semconv.MessagingMessageConversationID(messageId))
+		return &pipe.GatewayMessage{
+			Context:          newCtx,
+			MessageType:      ocpp.MessageTypeCallError,
+			MessageId:        messageId,
+			ErrorCode:        ocpp.ErrorFormatViolation,
+			ErrorDescription: fmt.Sprintf("message exceeds maximum size of %d bytes", limits.maxMessageSize),
+		}, errMessageTooLarge
+	}
+
 	if typ != websocket.MessageText {
 		msg := pipe.GatewayMessage{
 			Context:          newCtx,
@@ -605,6 +1093,26 @@ func read(ctx context.Context, tracer trace.Tracer, wsConn *websocket.Conn, prot
 
 	span.SetAttributes(semconv.MessagingMessageConversationID(msg.MessageId))
 
+	if msg.MessageType != ocpp.MessageTypeCall {
+		countMessage(messageDirectionIn, msg.MessageType, conn.responded(msg.MessageId))
+		return msg, nil
+	}
+
+	countMessage(messageDirectionIn, msg.MessageType, msg.Action)
+	if !limits.rateLimiter.allow(msg.Action) {
+		conn.limited(limitReasonRate)
+		span.SetAttributes(attribute.String("ocpp.limit", limitReasonRate))
+		span.SetStatus(codes.Error, "rate limit exceeded")
+		slog.Warn("rate limit exceeded", "clientId", clientId, "messageId", msg.MessageId, "action", msg.Action)
+		return &pipe.GatewayMessage{
+			Context:          newCtx,
+			MessageType:      ocpp.MessageTypeCallError,
+			MessageId:        msg.MessageId,
+			ErrorCode:        ocpp.ErrorGenericError,
+			ErrorDescription: "rate limit exceeded",
+		}, errClient
+	}
+
 	return msg, nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"github.com/eclipse/paho.golang/autopaho"
 	"github.com/eclipse/paho.golang/paho"
+	"github.com/mochi-co/mqtt/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/gateway/ocpp"
 	"github.com/thoughtworks/maeve-csms/gateway/pipe"
@@ -31,6 +32,16 @@ import (
 )
 
 func TestWebSocketHandler(t *testing.T) {
+	testWebSocketHandler(t, []string{"ocpp1.6", "ocpp2.0.1"}, "ocpp2.0.1")
+}
+
+func TestWebSocketHandlerWithOcpp21(t *testing.T) {
+	testWebSocketHandler(t, []string{"ocpp1.6", "ocpp2.0.1", "ocpp2.1"}, "ocpp2.1")
+}
+
+// testWebSocketHandler checks that a call from a charge station that offers the given
+// subprotocols is routed to the manager using the topics for the negotiated subprotocol
+func testWebSocketHandler(t *testing.T, subprotocols []string, wantSubprotocol string) {
 	//defer goleak.VerifyNone(t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
@@ -56,7 +67,7 @@ func TestWebSocketHandler(t *testing.T) {
 		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
 			_, err = manager.Subscribe(ctx, &paho.Subscribe{
 				Subscriptions: map[string]paho.SubscribeOptions{
-					"cs/in/ocpp2.0.1/+": {},
+					fmt.Sprintf("cs/in/%s/+", wantSubprotocol): {},
 				},
 			})
 			require.NoError(t, err)
@@ -109,7 +120,7 @@ func TestWebSocketHandler(t *testing.T) {
 
 	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
 	dialOptions := &websocket.DialOptions{
-		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+		Subprotocols: subprotocols,
 		HTTPHeader: http.Header{
 			"authorization": []string{authHeader},
 		},
@@ -126,8 +137,8 @@ func TestWebSocketHandler(t *testing.T) {
 		}
 	}()
 
-	if conn.Subprotocol() != "ocpp2.0.1" {
-		t.Errorf("subprotocol: want %s, got %s", "ocpp2.0.1", conn.Subprotocol())
+	if conn.Subprotocol() != wantSubprotocol {
+		t.Errorf("subprotocol: want %s, got %s", wantSubprotocol, conn.Subprotocol())
 	}
 
 	call := ocpp.Message{
@@ -179,6 +190,370 @@ func TestWebSocketHandler(t *testing.T) {
 	}
 }
 
+func TestWebSocketHandlerSharesMqttConnection(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	defer func() {
+		err := broker.Close()
+		if err != nil {
+			t.Logf("WARN: broker close: %v", err)
+		}
+	}()
+
+	client := startEchoManager(ctx, t, broker, addr)
+	defer func() {
+		err := client.Disconnect(ctx)
+		if err != nil {
+			t.Logf("WARN: mqtt client disconnect: %v", err)
+		}
+	}()
+
+	mockRegistry := registry.NewMockRegistry()
+	for _, id := range []string{"sharedCS1", "sharedCS2"} {
+		mockRegistry.ChargeStations[id] = &registry.ChargeStation{
+			ClientId:             id,
+			SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+			Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+		}
+	}
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithMqttClientIdPrefix("gateway-test"),
+		server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	var conns []*websocket.Conn
+	for _, id := range []string{"sharedCS1", "sharedCS2"} {
+		authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", id, "password")))
+		conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, id), &websocket.DialOptions{
+			Subprotocols: []string{"ocpp2.0.1"},
+			HTTPHeader: http.Header{
+				"authorization": []string{authHeader},
+			},
+		})
+		require.NoError(t, err)
+		defer func() {
+			_ = conn.Close(websocket.StatusNormalClosure, "OK")
+		}()
+		conns = append(conns, conn)
+	}
+
+	for i, conn := range conns {
+		payload := fmt.Sprintf(`"Payload%d"`, i)
+		data, err := json.Marshal(ocpp.Message{
+			MessageTypeId: ocpp.MessageTypeCall,
+			MessageId:     "1",
+			Data: []json.RawMessage{
+				json.RawMessage(`"EchoRequest"`),
+				json.RawMessage(payload),
+			},
+		})
+		require.NoError(t, err)
+		err = conn.Write(ctx, websocket.MessageText, data)
+		require.NoError(t, err)
+
+		_, b, err := conn.Read(ctx)
+		require.NoError(t, err)
+		var msg ocpp.Message
+		err = json.Unmarshal(b, &msg)
+		require.NoError(t, err)
+		require.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+		require.Equal(t, payload, string(msg.Data[0]))
+	}
+
+	// the manager's client plus a single gateway connection
+	require.Equal(t, 2, broker.Clients.Len())
+	_, ok := broker.Clients.Get("gateway-test-0")
+	require.True(t, ok)
+}
+
+func TestWebSocketHandlerBuffersMessagesWhilstMqttUnavailable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// the broker is not started until the charge station has sent its message
+	broker, addr := server.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		if err != nil {
+			t.Logf("WARN: broker close: %v", err)
+		}
+	}()
+
+	cs := &registry.ChargeStation{
+		ClientId:             "bufferedCS1",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		// retry slowly enough for the manager to subscribe before the buffered message is published
+		server.WithMqttConnectSettings(100*time.Millisecond, time.Second, 5*time.Second),
+		server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	data, err := json.Marshal(ocpp.Message{
+		MessageTypeId: ocpp.MessageTypeCall,
+		MessageId:     "1",
+		Data: []json.RawMessage{
+			json.RawMessage(`"TransactionEvent"`),
+			json.RawMessage(`"Payload"`),
+		},
+	})
+	require.NoError(t, err)
+	err = conn.Write(ctx, websocket.MessageText, data)
+	require.NoError(t, err)
+
+	time.Sleep(300 * time.Millisecond)
+
+	err = broker.Serve()
+	require.NoError(t, err)
+	client := startEchoManager(ctx, t, broker, addr)
+	defer func() {
+		_ = client.Disconnect(ctx)
+	}()
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	var msg ocpp.Message
+	err = json.Unmarshal(b, &msg)
+	require.NoError(t, err)
+	require.Equal(t, ocpp.MessageTypeCallResult, msg.MessageTypeId)
+	require.Equal(t, `"Payload"`, string(msg.Data[0]))
+}
+
+func TestWebSocketHandlerRepliesWithCallErrorWhenBufferExhausted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// the broker is never started
+	_, addr := server.NewBroker(t)
+
+	cs := &registry.ChargeStation{
+		ClientId:             "exhaustedCS1",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithMqttConnectSettings(100*time.Millisecond, 100*time.Millisecond, 5*time.Second),
+		server.WithOutboundQueueLen(1),
+		server.WithPipeOption(pipe.WithResponseTimeout(200*time.Millisecond)),
+		server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", cs.ClientId, "password")))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	for i := 1; i <= 2; i++ {
+		data, err := json.Marshal(ocpp.Message{
+			MessageTypeId: ocpp.MessageTypeCall,
+			MessageId:     fmt.Sprintf("%d", i),
+			Data: []json.RawMessage{
+				json.RawMessage(`"TransactionEvent"`),
+				json.RawMessage(`"Payload"`),
+			},
+		})
+		require.NoError(t, err)
+		err = conn.Write(ctx, websocket.MessageText, data)
+		require.NoError(t, err)
+
+		// wait for the pipe to time out the call
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	_, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	var msg ocpp.Message
+	err = json.Unmarshal(b, &msg)
+	require.NoError(t, err)
+	require.Equal(t, ocpp.MessageTypeCallError, msg.MessageTypeId)
+	require.Equal(t, "2", msg.MessageId)
+	require.Equal(t, `"InternalError"`, string(msg.Data[0]))
+}
+
+// startEchoManager simulates a manager that responds to every call with a call result
+// containing the request payload
+func TestWebSocketHandlerPublishesConnectionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	broker, addr := server.NewBroker(t)
+	err := broker.Serve()
+	require.NoError(t, err)
+	defer func() {
+		err := broker.Close()
+		if err != nil {
+			t.Logf("WARN: broker close: %v", err)
+		}
+	}()
+
+	// subscribe to the connection events
+	eventCh := make(chan map[string]any, 2)
+	readyCh := make(chan struct{})
+	client, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{addr},
+		KeepAlive:         10,
+		ConnectRetryDelay: 2 * time.Second,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					"cs/events/#": {QoS: 1},
+				},
+			})
+			require.NoError(t, err)
+			close(readyCh)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "test",
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				require.Equal(t, "cs/events/ocpp2.0.1/eventCS", publish.Topic)
+				var event map[string]any
+				err := json.Unmarshal(publish.Payload, &event)
+				require.NoError(t, err)
+				eventCh <- event
+			}),
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		err := client.Disconnect(ctx)
+		if err != nil {
+			t.Logf("WARN: mqtt client disconnect: %v", err)
+		}
+	}()
+	select {
+	case <-readyCh:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription")
+	}
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations["eventCS"] = &registry.ChargeStation{
+		ClientId:             "eventCS",
+		SecurityProfile:      registry.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	}
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithMqttBrokerUrl(addr),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithMqttClientIdPrefix("gateway-test"),
+		server.WithDeviceRegistry(mockRegistry)))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("eventCS:password"))
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/eventCS", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+
+	nextEvent := func() map[string]any {
+		select {
+		case event := <-eventCh:
+			return event
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for connection event")
+			return nil
+		}
+	}
+
+	event := nextEvent()
+	require.Equal(t, "connected", event["type"])
+	require.Equal(t, "eventCS", event["clientId"])
+	require.Equal(t, "ocpp2.0.1", event["protocol"])
+	require.Equal(t, float64(registry.UnsecuredTransportWithBasicAuth), event["securityProfile"])
+	require.Equal(t, "gateway-test", event["gatewayId"])
+	require.NotEmpty(t, event["remoteAddr"])
+	require.NotEmpty(t, event["timestamp"])
+
+	err = conn.Close(websocket.StatusGoingAway, "rebooting")
+	require.NoError(t, err)
+
+	event = nextEvent()
+	require.Equal(t, "disconnected", event["type"])
+	require.Equal(t, "eventCS", event["clientId"])
+	require.Equal(t, float64(websocket.StatusGoingAway), event["closeCode"])
+	require.Equal(t, "rebooting", event["closeReason"])
+}
+
+func startEchoManager(ctx context.Context, t *testing.T, broker *mqtt.Server, addr *url.URL) *autopaho.ConnectionManager {
+	client, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{addr},
+		KeepAlive:         10,
+		ConnectRetryDelay: 2 * time.Second,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(ctx, &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					"cs/in/#": {},
+				},
+			})
+			require.NoError(t, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "test",
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				var reqMsg pipe.GatewayMessage
+				err := json.Unmarshal(publish.Payload, &reqMsg)
+				require.NoError(t, err)
+
+				b, err := json.Marshal(pipe.GatewayMessage{
+					MessageType:     ocpp.MessageTypeCallResult,
+					MessageId:       reqMsg.MessageId,
+					ResponsePayload: reqMsg.RequestPayload,
+				})
+				require.NoError(t, err)
+				err = broker.Publish(publish.Properties.ResponseTopic, b, false, 0)
+				require.NoError(t, err)
+			}),
+		},
+	})
+	require.NoError(t, err)
+
+	err = client.AwaitConnection(ctx)
+	require.NoError(t, err)
+
+	return client
+}
+
 func TestConnectionFromUnknownChargeStation(t *testing.T) {
 	//defer goleak.VerifyNone(t)
 
@@ -502,6 +877,61 @@ func TestTlsConnectionWithCertificateAuth(t *testing.T) {
 	}
 }
 
+func TestTlsConnectionWithCertificateAuthCertificateNotAllowed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs := &registry.ChargeStation{
+		ClientId:          "tlsWithCertificateNotAllowed",
+		SecurityProfile:   registry.TLSWithClientSideCertificates,
+		CertificateHashes: []string{"0B8Mdw4SBLJNK4pR1USwnfYJW+6bCx8yG7p1IvNBpP0="},
+	}
+
+	caCert, _, clientCert, clientKeyPair := createTestKeyPairs(t, cs.ClientId)
+
+	mockRegistry := registry.NewMockRegistry()
+	mockRegistry.ChargeStations[cs.ClientId] = cs
+
+	srv := httptest.NewUnstartedServer(server.NewWebsocketHandler(
+		server.WithDeviceRegistry(mockRegistry),
+		server.WithOrgName("Thoughtworks")))
+	clientCAPool := x509.NewCertPool()
+	clientCAPool.AddCert(caCert)
+	srv.TLS = &tls.Config{
+		ClientCAs:  clientCAPool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	rootCAPool := x509.NewCertPool()
+	rootCAPool.AddCert(srv.Certificate())
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: rootCAPool,
+				Certificates: []tls.Certificate{{
+					Certificate: [][]byte{clientCert.Raw, caCert.Raw},
+					PrivateKey:  clientKeyPair,
+				}},
+			},
+		},
+	}
+	dialOptions := &websocket.DialOptions{
+		HTTPClient:   httpClient,
+		Subprotocols: []string{"ocpp1.6", "ocpp2.0.1"},
+	}
+
+	conn, resp, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/%s", srv.URL, cs.ClientId), dialOptions)
+	if err == nil {
+		_ = conn.Close(websocket.StatusGoingAway, "Shutdown")
+		t.Fatalf("expected error dialing CSMS")
+	}
+	require.NotNil(t, resp)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
 func TestTlsConnectionWithCertificateAuthUntrustedRoot(t *testing.T) {
 	//defer goleak.VerifyNone(t)
 
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+// maxWait is the longest that a request can wait for a charge station to answer
+const maxWait = 60 * time.Second
+
+// waitTime converts the wait query parameter into the time to wait for the charge station
+// to answer: zero means that the request should not wait
+func waitTime(wait *int) (time.Duration, error) {
+	if wait == nil {
+		return 0, nil
+	}
+	d := time.Duration(*wait) * time.Second
+	if d < 0 || d > maxWait {
+		return 0, fmt.Errorf("wait must be between 0 and %d seconds", int(maxWait.Seconds()))
+	}
+	return d, nil
+}
+
+// callAndWait sends the request to the charge station and waits for its answer. If there
+// is no answer then an error response is rendered and nil is returned.
+func (s *Server) callAndWait(w http.ResponseWriter, r *http.Request, csId, ocppVersion string, request ocpp.Request, wait time.Duration) *transport.Message {
+	callMaker, ok := s.callMakers[ocppVersion]
+	if !ok {
+		_ = render.Render(w, r, ErrNotImplemented(fmt.Errorf("unable to wait for an answer from an OCPP %q charge station", ocppVersion)))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+
+	answer, err := callMaker.Call(ctx, csId, request)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			_ = render.Render(w, r, ErrGatewayTimeout(err))
+		} else {
+			_ = render.Render(w, r, ErrInternalError(err))
+		}
+		return nil
+	}
+	return answer
+}
+
+// answerAccepted returns true if the charge station answered with a CallResult that has an
+// Accepted (or Scheduled) status
+func answerAccepted(answer *transport.Message) bool {
+	if answer.MessageType != transport.MessageTypeCallResult {
+		return false
+	}
+	var response struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(answer.ResponsePayload, &response); err != nil {
+		return false
+	}
+	return response.Status == "Accepted" || response.Status == "Scheduled"
+}
+
+// renderAnswer renders the charge station's answer as a ChargeStationAnswer
+func renderAnswer(w http.ResponseWriter, r *http.Request, answer *transport.Message) {
+	resp := ChargeStationAnswer{
+		MessageId: answer.MessageId,
+		Action:    answer.Action,
+	}
+	if answer.MessageType == transport.MessageTypeCallResult {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(answer.ResponsePayload, &payload); err != nil {
+			_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unmarshalling %s response: %w", answer.Action, err)))
+			return
+		}
+		resp.Response = &payload
+	} else {
+		errorCode := string(answer.ErrorCode)
+		resp.ErrorCode = &errorCode
+		resp.ErrorDescription = &answer.ErrorDescription
+	}
+	_ = render.Render(w, r, resp)
+}
+
+// Render implementations
+
+func (a ChargeStationAnswer) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
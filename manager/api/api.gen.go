@@ -110,6 +110,16 @@ const (
 	ChargingScheduleChargingRateUnitW ChargingScheduleChargingRateUnit = "W"
 )
 
+// Defines values for CommandStatus.
+const (
+	CommandStatusAccepted CommandStatus = "Accepted"
+	CommandStatusFailed   CommandStatus = "Failed"
+	CommandStatusQueued   CommandStatus = "Queued"
+	CommandStatusRejected CommandStatus = "Rejected"
+	CommandStatusSent     CommandStatus = "Sent"
+	CommandStatusTimedOut CommandStatus = "TimedOut"
+)
+
 // Defines values for ConfigurationChangeResponseResultsStatus.
 const (
 	ConfigurationChangeResponseResultsStatusAccepted       ConfigurationChangeResponseResultsStatus = "Accepted"
@@ -298,6 +308,13 @@ const (
 	MessageStateEnumUnavailable MessageStateEnum = "Unavailable"
 )
 
+// Defines values for MeterValueInterval.
+const (
+	N1d MeterValueInterval = "1d"
+	N1h MeterValueInterval = "1h"
+	N5m MeterValueInterval = "5m"
+)
+
 // Defines values for OperationResponseStatus.
 const (
 	OperationResponseStatusAccepted OperationResponseStatus = "Accepted"
@@ -488,6 +505,24 @@ type ChangeAvailabilityRequest struct {
 // ChangeAvailabilityRequestType Target availability state
 type ChangeAvailabilityRequestType string
 
+// ChargeStationAnswer The answer that a charge station gave to a call made by the CSMS
+type ChargeStationAnswer struct {
+	// Action The OCPP action of the call
+	Action string `json:"action"`
+
+	// ErrorCode The OCPP error code, when the charge station answered with a CallError
+	ErrorCode *string `json:"errorCode,omitempty"`
+
+	// ErrorDescription The description of the error, when the charge station answered with a CallError
+	ErrorDescription *string `json:"errorDescription,omitempty"`
+
+	// MessageId The OCPP message id of the call
+	MessageId string `json:"messageId"`
+
+	// Response The OCPP response payload, when the charge station answered with a CallResult
+	Response *map[string]interface{} `json:"response,omitempty"`
+}
+
 // ChargeStationAuth Connection details for a charge station
 type ChargeStationAuth struct {
 	// Base64SHA256Password The base64 encoded, SHA-256 hash of the charge station password
@@ -560,6 +595,12 @@ type ChargeStationStatusResponse struct {
 	// Id The charge station identifier
 	Id string `json:"id"`
 
+	// LastConnected Timestamp at which the gateway last reported the charge station connecting
+	LastConnected *time.Time `json:"lastConnected,omitempty"`
+
+	// LastDisconnected Timestamp at which the gateway last reported the charge station disconnecting
+	LastDisconnected *time.Time `json:"lastDisconnected,omitempty"`
+
 	// LastHeartbeat Timestamp of the last heartbeat received
 	LastHeartbeat *time.Time `json:"lastHeartbeat,omitempty"`
 
@@ -652,6 +693,59 @@ type ChargingSchedulePeriod struct {
 	StartPeriod  int32   `json:"startPeriod"`
 }
 
+// Command A call made by the CSMS to a charge station
+type Command struct {
+	// Action The OCPP action of the call
+	Action string `json:"action"`
+
+	// ChargeStationId The charge station that the call was made to
+	ChargeStationId string `json:"chargeStationId"`
+
+	// CreatedAt When the call was made
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ErrorCode The OCPP error code, when the charge station answered with a CallError
+	ErrorCode *string `json:"errorCode,omitempty"`
+
+	// ErrorDescription The description of the error, when the call failed
+	ErrorDescription *string `json:"errorDescription,omitempty"`
+
+	// Id The command identifier, which is the OCPP message id of the call
+	Id string `json:"id"`
+
+	// OcppVersion The OCPP version of the call
+	OcppVersion string `json:"ocppVersion"`
+
+	// Request The OCPP request payload
+	Request map[string]interface{} `json:"request"`
+
+	// Response The OCPP response payload, when the charge station answered with a CallResult
+	Response *map[string]interface{} `json:"response,omitempty"`
+
+	// Status Queued: the call is about to be sent; Sent: the call has been sent and no answer has been received; Accepted or Rejected: the charge station answered with a CallResult; TimedOut: no answer was received in time; Failed: the call could not be sent or the charge station answered with a CallError
+	Status CommandStatus `json:"status"`
+
+	// UpdatedAt When the status last changed
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CommandStatus Queued: the call is about to be sent; Sent: the call has been sent and no answer has been received; Accepted or Rejected: the charge station answered with a CallResult; TimedOut: no answer was received in time; Failed: the call could not be sent or the charge station answered with a CallError
+type CommandStatus string
+
+// CommandsResponse defines model for CommandsResponse.
+type CommandsResponse struct {
+	Commands []Command `json:"commands"`
+
+	// Limit Maximum number of commands returned
+	Limit int `json:"limit"`
+
+	// Offset Number of commands skipped
+	Offset int `json:"offset"`
+
+	// Total Total number of commands sent to the charge station
+	Total int `json:"total"`
+}
+
 // Component defines model for Component.
 type Component struct {
 	Evse *struct {
@@ -775,6 +869,50 @@ type DataTransferResponse struct {
 // DataTransferResponseStatus Status of the data transfer
 type DataTransferResponseStatus string
 
+// DeadLetter A message received from a charge station that the manager was unable to process
+type DeadLetter struct {
+	// Action The OCPP action of the message
+	Action string `json:"action"`
+
+	// ChargeStationId The charge station that sent the message
+	ChargeStationId string `json:"chargeStationId"`
+
+	// CreatedAt When the message was received
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error The error that stopped the message being processed
+	Error string `json:"error"`
+
+	// Id The dead letter identifier
+	Id string `json:"id"`
+
+	// Message The message as received by the manager
+	Message map[string]interface{} `json:"message"`
+
+	// MessageId The OCPP message id of the message
+	MessageId string `json:"messageId"`
+
+	// MessageType The type of the message, either call or call_result
+	MessageType string `json:"messageType"`
+
+	// OcppVersion The OCPP version of the message
+	OcppVersion string `json:"ocppVersion"`
+}
+
+// DeadLettersResponse defines model for DeadLettersResponse.
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+
+	// Limit Maximum number of dead letters returned
+	Limit int `json:"limit"`
+
+	// Offset Number of dead letters skipped
+	Offset int `json:"offset"`
+
+	// Total Total number of dead letters
+	Total int `json:"total"`
+}
+
 // DeviceReport defines model for DeviceReport.
 type DeviceReport struct {
 	// GeneratedAt When the report was generated (ISO8601)
@@ -1102,18 +1240,51 @@ type MeterValue struct {
 	TransactionId *string `json:"transactionId,omitempty"`
 }
 
-// MeterValuesResponse Paginated list of meter values
+// MeterValueInterval The length of the buckets that meter values are rolled up into
+type MeterValueInterval string
+
+// MeterValueRollup A summary of the meter values of an EVSE over one bucket
+type MeterValueRollup struct {
+	// AverageVoltage Mean voltage in the bucket in V
+	AverageVoltage *float64 `json:"averageVoltage,omitempty"`
+
+	// End End of the bucket
+	End time.Time `json:"end"`
+
+	// EnergyDelta Active energy imported during the bucket in Wh, including the energy imported since the last reading of the previous bucket when the buckets are adjacent
+	EnergyDelta *float64 `json:"energyDelta,omitempty"`
+
+	// EvseId EVSE ID (connector ID for OCPP 1.6)
+	EvseId int `json:"evseId"`
+
+	// MaxPower Highest active power import in the bucket in W
+	MaxPower *float64 `json:"maxPower,omitempty"`
+
+	// SampleCount Number of meter values in the bucket
+	SampleCount int `json:"sampleCount"`
+
+	// Start Start of the bucket
+	Start time.Time `json:"start"`
+}
+
+// MeterValuesResponse Paginated list of meter values, or of meter value rollups when an interval is requested
 type MeterValuesResponse struct {
+	// Interval The length of the buckets that meter values are rolled up into
+	Interval *MeterValueInterval `json:"interval,omitempty"`
+
 	// Limit Maximum number of results returned
 	Limit int `json:"limit"`
 
-	// MeterValues Array of meter value records
+	// MeterValues Array of meter value records (empty when an interval is requested)
 	MeterValues []MeterValue `json:"meterValues"`
 
 	// Offset Number of results skipped
 	Offset int `json:"offset"`
 
-	// Total Total number of matching records
+	// Rollups Array of meter value rollups, ordered by EVSE and then by start, when an interval is requested
+	Rollups *[]MeterValueRollup `json:"rollups,omitempty"`
+
+	// Total Total number of matching records (or rollups)
 	Total int `json:"total"`
 }
 
@@ -1618,6 +1789,15 @@ type ClearChargingProfileParams struct {
 // ClearChargingProfileParamsChargingProfilePurpose defines parameters for ClearChargingProfile.
 type ClearChargingProfileParamsChargingProfilePurpose string
 
+// ListChargeStationCommandsParams defines parameters for ListChargeStationCommands.
+type ListChargeStationCommandsParams struct {
+	// Limit Maximum number of commands to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of commands to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // GetCompositeScheduleParams defines parameters for GetCompositeSchedule.
 type GetCompositeScheduleParams struct {
 	ConnectorId      int                                         `form:"connectorId" json:"connectorId"`
@@ -1681,6 +1861,9 @@ type GetMeterValuesParams struct {
 	// EndTime Filter by end time (ISO 8601)
 	EndTime *time.Time `form:"endTime,omitempty" json:"endTime,omitempty"`
 
+	// Interval Return rollups of the meter values for each bucket of this length instead of the meter values themselves. Rollups cannot be filtered by transaction.
+	Interval *MeterValueInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
 	// Limit Maximum number of results to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
@@ -1712,6 +1895,24 @@ type ListReservationsParams struct {
 // ListReservationsParamsStatus defines parameters for ListReservations.
 type ListReservationsParamsStatus string
 
+// ResetChargeStationParams defines parameters for ResetChargeStation.
+type ResetChargeStationParams struct {
+	// Wait The number of seconds (at most 60) to wait for the charge station to answer. When given, the answer is returned instead of 202 Accepted.
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// RemoteStartTransactionParams defines parameters for RemoteStartTransaction.
+type RemoteStartTransactionParams struct {
+	// Wait The number of seconds (at most 60) to wait for the charge station to answer. When given, the answer is returned instead of 202 Accepted.
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// RemoteStopTransactionParams defines parameters for RemoteStopTransaction.
+type RemoteStopTransactionParams struct {
+	// Wait The number of seconds (at most 60) to wait for the charge station to answer. When given, the answer is returned instead of 202 Accepted.
+	Wait *int `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
 // ListTransactionsParams defines parameters for ListTransactions.
 type ListTransactionsParams struct {
 	// Status Filter by transaction status (default: all)
@@ -1742,6 +1943,15 @@ type GetChargeStationVariablesParams struct {
 	Variable *string `form:"variable,omitempty" json:"variable,omitempty"`
 }
 
+// ListDeadLettersParams defines parameters for ListDeadLetters.
+type ListDeadLettersParams struct {
+	// Limit Maximum number of dead letters to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of dead letters to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListTokensParams defines parameters for ListTokens.
 type ListTokensParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
@@ -1843,6 +2053,9 @@ type ServerInterface interface {
 	// Lookup a certificate
 	// (GET /certificate/{certificateHash})
 	LookupCertificate(w http.ResponseWriter, r *http.Request, certificateHash string)
+	// Get a command
+	// (GET /commands/{commandId})
+	LookupCommand(w http.ResponseWriter, r *http.Request, commandId string)
 	// Register a new charge station
 	// (POST /cs/{csId})
 	RegisterChargeStation(w http.ResponseWriter, r *http.Request, csId string)
@@ -1873,6 +2086,9 @@ type ServerInterface interface {
 	// Clear charging profile
 	// (DELETE /cs/{csId}/charging-profile/{profileId})
 	ClearChargingProfile(w http.ResponseWriter, r *http.Request, csId string, profileId int, params ClearChargingProfileParams)
+	// List the commands sent to a charge station
+	// (GET /cs/{csId}/commands)
+	ListChargeStationCommands(w http.ResponseWriter, r *http.Request, csId string, params ListChargeStationCommandsParams)
 	// Get composite schedule
 	// (GET /cs/{csId}/composite-schedule)
 	GetCompositeSchedule(w http.ResponseWriter, r *http.Request, csId string, params GetCompositeScheduleParams)
@@ -1962,16 +2178,16 @@ type ServerInterface interface {
 	ListReservations(w http.ResponseWriter, r *http.Request, csId string, params ListReservationsParams)
 	// Request a charge station reset
 	// (POST /cs/{csId}/reset)
-	ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string)
+	ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams)
 	// Remote start transaction
 	// (POST /cs/{csId}/start-transaction)
-	RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string)
+	RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStartTransactionParams)
 	// Get charge station status
 	// (GET /cs/{csId}/status)
 	GetChargeStationStatus(w http.ResponseWriter, r *http.Request, csId string)
 	// Remote stop transaction
 	// (POST /cs/{csId}/stop-transaction)
-	RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string)
+	RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStopTransactionParams)
 	// Get transaction details
 	// (GET /cs/{csId}/transaction/{transactionId})
 	GetTransactionDetails(w http.ResponseWriter, r *http.Request, csId string, transactionId string)
@@ -1990,6 +2206,18 @@ type ServerInterface interface {
 	// Set charge station variables (OCPP 2.0.1)
 	// (PATCH /cs/{csId}/variables)
 	SetChargeStationVariables(w http.ResponseWriter, r *http.Request, csId string)
+	// List dead letters
+	// (GET /dead-letters)
+	ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams)
+	// Discard a dead letter
+	// (DELETE /dead-letters/{deadLetterId})
+	DeleteDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string)
+	// Get a dead letter
+	// (GET /dead-letters/{deadLetterId})
+	LookupDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string)
+	// Re-drive a dead letter
+	// (POST /dead-letters/{deadLetterId}/redrive)
+	RedriveDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string)
 	// Registers a location with the CSMS
 	// (POST /location/{locationId})
 	RegisterLocation(w http.ResponseWriter, r *http.Request, locationId string)
@@ -2029,6 +2257,12 @@ func (_ Unimplemented) LookupCertificate(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get a command
+// (GET /commands/{commandId})
+func (_ Unimplemented) LookupCommand(w http.ResponseWriter, r *http.Request, commandId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Register a new charge station
 // (POST /cs/{csId})
 func (_ Unimplemented) RegisterChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
@@ -2089,6 +2323,12 @@ func (_ Unimplemented) ClearChargingProfile(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the commands sent to a charge station
+// (GET /cs/{csId}/commands)
+func (_ Unimplemented) ListChargeStationCommands(w http.ResponseWriter, r *http.Request, csId string, params ListChargeStationCommandsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get composite schedule
 // (GET /cs/{csId}/composite-schedule)
 func (_ Unimplemented) GetCompositeSchedule(w http.ResponseWriter, r *http.Request, csId string, params GetCompositeScheduleParams) {
@@ -2265,13 +2505,13 @@ func (_ Unimplemented) ListReservations(w http.ResponseWriter, r *http.Request,
 
 // Request a charge station reset
 // (POST /cs/{csId}/reset)
-func (_ Unimplemented) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+func (_ Unimplemented) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Remote start transaction
 // (POST /cs/{csId}/start-transaction)
-func (_ Unimplemented) RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string) {
+func (_ Unimplemented) RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStartTransactionParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -2283,7 +2523,7 @@ func (_ Unimplemented) GetChargeStationStatus(w http.ResponseWriter, r *http.Req
 
 // Remote stop transaction
 // (POST /cs/{csId}/stop-transaction)
-func (_ Unimplemented) RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string) {
+func (_ Unimplemented) RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStopTransactionParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -2322,6 +2562,30 @@ func (_ Unimplemented) SetChargeStationVariables(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List dead letters
+// (GET /dead-letters)
+func (_ Unimplemented) ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Discard a dead letter
+// (DELETE /dead-letters/{deadLetterId})
+func (_ Unimplemented) DeleteDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a dead letter
+// (GET /dead-letters/{deadLetterId})
+func (_ Unimplemented) LookupDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Re-drive a dead letter
+// (POST /dead-letters/{deadLetterId}/redrive)
+func (_ Unimplemented) RedriveDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Registers a location with the CSMS
 // (POST /location/{locationId})
 func (_ Unimplemented) RegisterLocation(w http.ResponseWriter, r *http.Request, locationId string) {
@@ -2425,6 +2689,31 @@ func (siw *ServerInterfaceWrapper) LookupCertificate(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// LookupCommand operation middleware
+func (siw *ServerInterfaceWrapper) LookupCommand(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "commandId" -------------
+	var commandId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commandId", chi.URLParam(r, "commandId"), &commandId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "commandId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupCommand(w, r, commandId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // RegisterChargeStation operation middleware
 func (siw *ServerInterfaceWrapper) RegisterChargeStation(w http.ResponseWriter, r *http.Request) {
 
@@ -2749,6 +3038,50 @@ func (siw *ServerInterfaceWrapper) ClearChargingProfile(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
+// ListChargeStationCommands operation middleware
+func (siw *ServerInterfaceWrapper) ListChargeStationCommands(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "csId" -------------
+	var csId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "csId", chi.URLParam(r, "csId"), &csId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "csId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListChargeStationCommandsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChargeStationCommands(w, r, csId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetCompositeSchedule operation middleware
 func (siw *ServerInterfaceWrapper) GetCompositeSchedule(w http.ResponseWriter, r *http.Request) {
 
@@ -3375,6 +3708,14 @@ func (siw *ServerInterfaceWrapper) GetMeterValues(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", r.URL.Query(), &params.Interval)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "interval", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "limit" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
@@ -3730,8 +4071,19 @@ func (siw *ServerInterfaceWrapper) ResetChargeStation(w http.ResponseWriter, r *
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResetChargeStationParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ResetChargeStation(w, r, csId)
+		siw.Handler.ResetChargeStation(w, r, csId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3755,8 +4107,19 @@ func (siw *ServerInterfaceWrapper) RemoteStartTransaction(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoteStartTransactionParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoteStartTransaction(w, r, csId)
+		siw.Handler.RemoteStartTransaction(w, r, csId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3805,8 +4168,19 @@ func (siw *ServerInterfaceWrapper) RemoteStopTransaction(w http.ResponseWriter,
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoteStopTransactionParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoteStopTransaction(w, r, csId)
+		siw.Handler.RemoteStopTransaction(w, r, csId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4037,6 +4411,116 @@ func (siw *ServerInterfaceWrapper) SetChargeStationVariables(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
+// ListDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeadLettersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeadLetters(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterId" -------------
+	var deadLetterId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterId", chi.URLParam(r, "deadLetterId"), &deadLetterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDeadLetter(w, r, deadLetterId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) LookupDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterId" -------------
+	var deadLetterId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterId", chi.URLParam(r, "deadLetterId"), &deadLetterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupDeadLetter(w, r, deadLetterId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RedriveDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) RedriveDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deadLetterId" -------------
+	var deadLetterId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deadLetterId", chi.URLParam(r, "deadLetterId"), &deadLetterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deadLetterId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RedriveDeadLetter(w, r, deadLetterId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // RegisterLocation operation middleware
 func (siw *ServerInterfaceWrapper) RegisterLocation(w http.ResponseWriter, r *http.Request) {
 
@@ -4272,6 +4756,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/certificate/{certificateHash}", wrapper.LookupCertificate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/commands/{commandId}", wrapper.LookupCommand)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cs/{csId}", wrapper.RegisterChargeStation)
 	})
@@ -4302,6 +4789,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/cs/{csId}/charging-profile/{profileId}", wrapper.ClearChargingProfile)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cs/{csId}/commands", wrapper.ListChargeStationCommands)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cs/{csId}/composite-schedule", wrapper.GetCompositeSchedule)
 	})
@@ -4419,6 +4909,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Patch(options.BaseURL+"/cs/{csId}/variables", wrapper.SetChargeStationVariables)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dead-letters", wrapper.ListDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dead-letters/{deadLetterId}", wrapper.DeleteDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dead-letters/{deadLetterId}", wrapper.LookupDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dead-letters/{deadLetterId}/redrive", wrapper.RedriveDeadLetter)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/location/{locationId}", wrapper.RegisterLocation)
 	})
@@ -4441,228 +4943,260 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+y9aW/burow+lcI3Qvs5EAZu1qsVWDjXDdJ25yV6cZOi/PuFCkt0TZPZNKbpJL6FP3v",
-	"LziKkqjBGdq0zZc2liiOz8Rn/BoldL6gBBHBo9dfI57M0ByqP/cQE3iCEyiQ/JkinjC8EJiS6HU0AEmG",
-	"EREg8VrF0YLRhXyAVA9JWw+jGQJnB8cAkYSmKPU7ArdYzABBtxkmiAOGFhlMUArGS/D58pJ8juJILBco",
-	"eh1xwTCZRt++xRFD/84xQ2n0+l+lgT+5xnT8PygR0bfYX9p7yGf7UMBz9O8ccVGfp9cWzCCfgRQKCCaU",
-	"AZwiIvBkickUQMAXKJHt+u6IHVg+LjeSowyyKWVYzOaBrbevQM5RCgQFU0QQk/MTMz3HKI4QyedyK4bv",
-	"B7svX0Wx/OPFn3/oP17u7Hr7YjcxjjDnOWJ/o6WcXH1k+RTQiRpGN/0HB4t8nOEEXKNlFEdz+OUIkamY",
-	"Ra93dv9sHOEEztEKQ6SYC0ymOeYzlAIC56jPUBwxDLOTfD5GrP1YdUtAdNNS139sd8Fa+bRqK6xuamVe",
-	"dehsBmUHMUGQnkEyRYMbiDM4xhkWy0aINi8k5CTqK/kfmyLABZRNAGUgoYSgRFAGoNdlHZ5ts8NUjzOB",
-	"eSai19txdbtdf4f7YG0b/BNI3GFuzBhc5tvbL5B8U2CS/WhdHgomeC5hujgRTASaIiaXj244snPwxz34",
-	"MDyQQ0p8Pd07OwO7m9ubO2CNqgYw6+5ZP6lRL7lhorQ5ainIQ73ThcRKfCOfHRLqfn3qAin1tuGQ2RQN",
-	"9ZYNchFAILPR8hhTJCDOuFo7rJxx7STHkKNXf2hqcQY5v6UsDRNt3dLS7RgM3w82dl++0sTRoG4FoBa2",
-	"wxJuvfojRCDIDcxwesERk4g+yDJ6iwIzOZwAjhQMC5YrykcAJMB8Lkmj+h7c4iwDhAqwYOhGMqzA9Ayc",
-	"yRm4GY0pzRAkmpAkOcNiecboBGcNrMw2AgvdSs4s50htfn3I1+A/wOftz2AD5ER9KQk5g4QvKBOa/Y0h",
-	"xwmAuZjJtjuy7ehoGHq3W3pX58uXJKoDdgXkqmvshL4DuZl13uWkiRAy7tmXQMygcGwrVRukTicKQIR6",
-	"YTllhRWmKdZ4rD+3IN/YzSiMzMsFkpCr+1hDm9PNGLzFbH4LGbpYpHKOMThHY0rVX0OzWUd0+hHyvQxB",
-	"htL1KMiDbpBsGSBMaiz7XpPEhGGBE5jF4C/wT4DJhLI59OgU/KLp1F+dNAslsz2ahpaKkhmRYwDEGGVb",
-	"chAgETk0e9nNIZnQ1o0XrkdvwsHe8BxxAeeLencfJfY6IAA0SXImUWLtcHj656vtHbl63Xf0OpLHsSE7",
-	"Cw1yAxmG4yzICz6YdwByThOsIE+hTAv4VSmzW4MPUJ3Icki4gFnmyRy8iYoICYge8nJJSLD+HlASoCWb",
-	"QH5Z+kQRvbHsjohLImlknehBviTJjFFCc54tNy9Jm7CqfmOB5nea9w+8GMSRXG/eNG31LgZGblFzPkMk",
-	"1ZzA8vFBkqCFQJJ7nSN5vupP2y4kR1uZwfbwYfddFEfHp/Kft1Ec7Q2Phz3FgLjzMmMeQMbgsk185N1w",
-	"OkRCckG1W9Ch+Fnp7Oq7eI2WAHMFY4rlGhmA6842wduy7OXa8RnNsxTM4I2+uUyoZPbyNrWAQiBGXl8S",
-	"JRUmjqtoIXFLP7W4rh8aNHAsRg2RKJEgyfIUSenASn1eMwWiJDFTgiQFUpQEOL0kHC2g5k/jJeBojjcS",
-	"mlHC9Uh29PaBXKv6OFAIhse55M/yVED7cIb4g0wJT4U8u7P5Sm7+y+1theAwEYhxjc3+DWl7ezsAp+Wz",
-	"tKffJDB2wI7CpXPEF5TwAFrvSZIuWZ5qJ4GkUyY1cllI/Ps4Q2KGQqKV3I1Ej5UtQdFFSLKbGB7/ATGu",
-	"+m2YdLa0pAylwH4EbsxXISm2QXauTlVrELC6ddY6ySAX7xFkYoxg4Bo3srzIIpxsD2b2A8BQgvCNWnk/",
-	"3jmnKcoCe1Ces261+n273Ev1yl1n5IiktLsf3WxrDkk+gYnIWai3Ck3EcksKwOikiiOGp9PQmsyLGiAD",
-	"mLTBc3Fb9js7LWhTcVXeBOdm4gopOZ0jMEecwykCctIcrGm8O6GGzKu79DESiH2AWY54jwtusTzLr95Q",
-	"KvweoziqDyMf4in5sPtur6Txkg/V/mEyNTsYaEDnY0xQWn7jzTuKo30Mp4RygRMeHN1K6MGXBeLEkXq1",
-	"PEfycuV+HlOCBZUA4l6c5eMM81lrv0d0GnjezcvNJjcCGyZT735ZgZtyAw09DqcxES92o9DJVr77G5PU",
-	"P+XBmNMsVxt/jjKrqTiXlxvWJNlUujzL2YLykqwz+mLXEUejL/tasioeacw6o5iIY/ilft2sDzVMZijN",
-	"9b78vwxNotfR/7NVaK23jMp6a6/aXh2BZgXJsrr4fYizZRRHHxG6zpbBCXABk+sjdKOJYm2/u7AKEq7p",
-	"QO/zUqqLt4zOS+077jsZTke07wdV6bAGWKVVNx53GLQCR9YD3I8wD+gRjBalfO/oc/qm149YzJzOsVNK",
-	"dqP1mG+5ZykoZ9npJHr9r5XmF32Lv7Zzh054qZ6l93l9GZ+8hfgIFSY051CgC4KFjzAfozga9ELUM8Qw",
-	"TVc+ucrn3xSGHeoedqoHGEdpzjTt7Ydbc+yY0rm5dhYIQ/Nx5mGLkUo0CWDC3697IJnb1MYda4O++uaW",
-	"jy7Dc31ePVal/zqbQa6/bSZtO6GtVJtSzGMlwlhVO3pdxWYNwV2wYFNfuLyuhfSQJWSqLwIHn38LjG1v",
-	"brK9d6V6GbpQKdtUZ7vKJqiPGlfNsUDdGHsfJrkq4YmVlVh+PZTHd1e08Eat9tiXk1AywVNDB7QBzDN6",
-	"9VNglPoA12i5cSPlT7CAmPHCRBZ13p4DVrVS16pbDtbsrV0K5ugLnC/0oTl59ZAIxG5gFr2OXm1vl4Ti",
-	"oWrtNdjZ3Y6+9d2Ypou5fQMmjM6Bbl3eldKcy/DHEM8zEdjXM8Q2rtHS7oVtFxc8odzRNVr2OBxr/O2t",
-	"5TtX4zqzVKk/d7Ltij6t97f3MH1/GOYLeWco3R4b4Fwbxs38Vtbf2X3rBP8WxUtp0ax02u+Q6H3Upc37",
-	"O3RcUpRTauA2nHo0EGAIppRky3ZlkVFWysYbqnVIL6Sm26zC0qsJwpQ+7T4Q4abbDRNxlJNrQm/bd51p",
-	"AoRSOQmuTWyQIWC+9fe9QWHdpEOuHnwDMBYicflcLXMoZMnh6d7fByN5Gxy8OToIu6KkwYnO4ZcrOF8g",
-	"Bqeor+QHv1zd0Ez0/2JBbxG7qirxB3tXO1dn7wfDAykJ7129cD/295rujySFrHTt3Hs/2D9QhoC994PT",
-	"/zqUX58eHwxHh3tXA//HG//Hnv9j3/9x4P946/945/947/8oDfpf/o+//R9HURy9ezO6GuyZP/blH4cH",
-	"e1evtl9s/3W1e8UxmWboaudV5bmYMdT4WO15/fGrP+zj3Z2/Xl2Ndio/r/ZOj9+clh/uVn6G2rwYVH7L",
-	"RZwcHA+uXl7tbtu/X1298P5+6f7e2fZe7Gz7b/7w3/yh35wNTkan784HZ++v3pyORqfHVxdn5cej07Or",
-	"/dOPJ1EcjQ6GR4Orc/fXMIqji5O/T+TbTq5ioDjWyswSVpQhvgTNHky24vCddPmOAKyk9tRGm0Lj6Wur",
-	"WzRbegKjQskS8BDZdyTaTNfTyYA1PAGQLINWe2UaD9vPD+QrazC3SH1C1ePI28Ejmly/hTjLmWx48GGP",
-	"zuc5MepC2/odozlJi2bv8XQ2QuochX6iZD0CM/vFEU1gJgm+ZIsZTiQEnEreZhuc3iBmTqfoVz784ODh",
-	"TMKDEiqLFurZ8BaLZFY8PEcw9RudI468Xi9I6nf7EcHrIZ4SmIXpeZcfgec9AOCY5tpTx8hNK0h8ddgU",
-	"Poj54p523irUktrs+xbmmZb83mKC+Uw/PWNoAZn+W24E05aVYc4XiKQoPfhQ/qUYwwWBboxPq7lEFNad",
-	"W+scYVZ0C7k29eTaM6Wnfaf9+mW3uQD9EIHYhwIqnJsg1se9kCOSGhPNhvPqU/6z+pbUZvxLwx4/bIwF",
-	"g2wJ9Lp0b2t6A0CKJphoA6oeNYjexoDSaoSxRpbCQuc8ufT8zS6UzaxBnYCeSdAhRb3xx1irbNZ6uf/d",
-	"ly+7ztWN1n1+/S6EpeVaIbPnabmetM+07C5gsA0tuje6D0toXj2b9pvdhZaMjx08uEcf6rvo7Xd9X9EN",
-	"TpCxI9XkX+fnNhAtrk8Mad8/yD3HuNUdoHQ3+w3HoYZQm4SIYBi13MYSX+XWqlJyDT3/q66PrC+W/83A",
-	"OkSUVMc1WUKKMMK7HHj3tnkurKeyJ3NLPnaqr3ofGRbI/C0fq99B0rxAjGMuUNNQYbdgtwRlnQVrg0Tk",
-	"MIuBdheOwTEmQ/U//DJEYr3BotN293ReI/oWGvWCz9r1rvPCqcGo3V3SQKzxlyxd1A/JDSKCsmUMqnbW",
-	"9TDUKrISIpKWnRzu6yutsaQqr1F1kTfG2w5NczFCXELIIJksDM99uFy+yChMQVp8pUldB4PLaALDkuvF",
-	"+aHk+gyV+jTuUmNkBixz/pzhCi/a2Q1utEb72pjab0Mfq2oC8MSubGJ8ap0D6s52l/lTdrIsNJU12dy8",
-	"AZgAjhJKUg7GSNwiRNT4SwnnaL5QGsP2kZQJQUpLLdxctQGSZCou7u9qQrPM+MsvrB2iH6Xlgi46xpXi",
-	"z4OOWgFqB0IdUDzskJX9qZkzdxJhRZeDM3QCQ4s+8Vz/SqiAMwTGSMpqHtwGnZ20q3VfTycjDmspeIVT",
-	"u/tWWIZymKo7w0WxHP2nvBt5P8M+FHULWJNieB/zRQaXRjwJWL5Iug8FCsOgkyusJGvIh4Racxyp7l+K",
-	"GX44TL99DHm4XRD87xwFZOdOJJ4Xa2yTGsxW7FEijLyxYJhaB/seX56Z5gfyJC3xuMsmSnryALuoQ4b6",
-	"zV0iMbITr7mXVCgQyZZysrdu5mmurkuKZ5b0IKVIqIJ9vHjVy43O7X9xhiFQVtEOJYVS1YhrA1D7uwyU",
-	"I1ECMowziZf35th40hLH8fTokvHkjPjkyYNQOplwJNr4pumFX+PFoqETQUWIG47k4/p85lAkM31oSJJR",
-	"4fs2Nsk5ZiftWHYX3ALCp9NqQl/hXJo9bnSI3pUmGyTPtFLktWA5CuBF3kxesmVBWLTTuYrxu8VihjWy",
-	"7p2dcrDIoJA4CNYgSS8Jz8c6AI8y94qvb3Zy2ByX1CPenoQ2suw52MxknAvxKs7Q+tsHd1X+Xox3oeMl",
-	"GudvXliqSm+J5q5bxXLWlEgqR5Fy44LRKUOcr9+FzbudMf018fh9Nw/vh+Pz9oFWC5ooH3V8rol5WGrR",
-	"x2zcIheUI9P63VDUIit6F7sHd7qZaJpkNjGB8ty8QwNr70ejsy35z3Dr7ehs/dGvKnZsfVkBayak5zXY",
-	"Xl/95oLRDdoPYkRxpTCOKUbAF9ReMvx9sZPaBIcTE4RKb7CKmnXzVZ9xgOdzlGIokNJN9NX1PNAlq7Zd",
-	"7TcuPCXaQFEb8k0pNhi4lpZ+uJ1R3ux4SlBqgDOs48dTgsm0NRNFU6iYTY4g+yiNfb9b1TtEjzz0qCAO",
-	"FFjk2m5UJ7SUTJveVmdg+/G/Cs9GVPUrHj1oUOh98HR0DdCdGZ8CLxTfMXurrlPeUUYHRMmPVyTWDBy2",
-	"N6+zT71UX426KHPBMS1qxgEGk2srr62ulwrN7TAdwWlDCG4uZpTh/zWRRJ71TIVvEaUwg9MacUdfFpgt",
-	"w9RtX8VY2kuP7gCoDxR+BqlSpyy3gEzFg4/gNIC96qUdSs58ymi+ULHtbnUVI8h2j0Gb+H9512pc37MT",
-	"vMlocq3+OlAboJm58rfXhl5rg/5yL2auLLqlWR0QwQJuPkPlbKH09lLE0+KY/Li8Vwp1a4eOw7s/mlVv",
-	"6eVtDtz6PXhsQ8sCcOvXxpGCyqKrfttyhLnoYbNKKBEQE4uGcmoZEitslXzYKKSOPG8CI1N797aOI+nh",
-	"15AFlx0ygzK4VNeG0kABo07bITXBXpdDmL9FjXNuPNWj4uvVz1Nvr2JPxZ3myRxgyz41bUdYzh5UZXUn",
-	"k1RXC9NUXoKCEkdiFGP1F5SyFBMb2N8GI768o77MLXkK9KreXSW0QQJCN3yFiB6llfjWSOsdv7Y+930Y",
-	"kWTPdce+o9OTd1fHp6PT84+D/1b+Wud/H568u3o3OB+8O/AeHJ2Oojg6PbnaPz/8cKAbn55cDUfnB8qd",
-	"8eJk/+D83fnpxcm+/fhTPw4pllcNHo8LKu+MblM7OqtAoIUOAwvF+VVOqwwS3ozCYDtdwSyW0WnQHmZc",
-	"jVVOgPXAFXQaFK8RF6Nmp5lCflUtgfOvUZJFRqcehex3xaJZ2nNI3fIBhmRoTgU66nEHV1v7AGbBmkha",
-	"mkAIBDI6bbcNy4Wr64G+THsilmeWOqJSEPCy6QQt8f3lcSlFUi2Ga0W3nEXhsdJ01/91rKG1i6w+pbhk",
-	"/c5K+1xC6y69pdzPdvvg99Im9nMYqCf6wbzmUli2Ja+8/qBRUKv7ggZC7Tz5BqbWrlcOKDFJ4xSnP0Ns",
-	"jrkUH/YRwffUGlZsZyF1v31RF5msFck0ApSBi/PDPlq8wvm/h4nrrWpsbVwZJNPcWAUrIQ/mjXLEtQ4o",
-	"/0DkH/JfLv9N0T8qBq0/Feq4200P/0ihlQdmBS176k27bm2q7Jzu7fUl+Q/weTDcOzz8DDbAWQbljQ59",
-	"Eer5+9HxkXx8jpOZemq+EphMVYOLc/XZxfmRJHjGAgnW8BxOUQxu0XgBp2hdJYFxQo4cK4oj2beEx/PD",
-	"ILEN2UnragIzoIUKawTUqzqRc832lkmG1CKocJmbqArDMZ9x1fqQvGWUCNlyOKO3YIwmlCHdkkuCyKiW",
-	"F/SOZbdwyd0X+ie4wRyPMxSDGZ7OJOrbCZV2wJuXusqrXqI48vps25LC/BrOSSJvKFw4BUrFYjxGhaFY",
-	"b5P9SK5jXxtnYSLwjVHTq+6QQn7V3Hgly9bKKm2lqBnkABKdzU1vaKr3XbVy7sdgnAulgcYE5BxpKCq8",
-	"k90HNNd6P8RucIJK+xd0kbZUr8PRuYhhDN11TPawuWxk4ySh8HPq+nnXVgguKGUdtf67DFn6fN80omEe",
-	"ooI006bF2ou7aWaW2/fG7iVIGfrjBNSYvXzLDSPWO19sTD8e3OGD4AVnqKSveALktUIbO71v11fKulfa",
-	"3jBNdlvUrF44g1N110mdptsHvoBKoacngQnXbHclmHtZbpoBxJsQYCihLL0DlIQAo9uTwa7iAVwZnA9D",
-	"sYQOoXVeygG0ghdDE26ECI7WpZZwUFJGWMOEgIz0JXjtVR8A08CKJHoemzrBAU5i4OHE5hs0xSRovC5k",
-	"pmoCSzlPE+uwdg5vpRw2VFa0fShgsK9mW6694GlggzxnaK7yn+5BxUwPPsTgkGRIxOA0F+r/NzRdNoRU",
-	"yO8hSZtvhKUh9PYcEMSmy82BYnubh3MpAW+eoynmArEYqKCk8tvg4IsZDOK4fGyHTcHa0U4MjnZjcPQi",
-	"BicxONrZkP/uqn9fbOgn6v3uhmxy9GLjaCc4Xk5CxOCCYFFb58dZDK7lPzeQyT/1fx/lwxh8GMTgWv5z",
-	"A5l+F4NBDD7E4O8Y7KGM45zH4C2cMURmCIsYnCGWILKS3/ixXb8G8jWSzxHDCYDcRNB0k9+bZlrrbI/h",
-	"TPbfP4agmFFvPeOH+qfdEdEB22JwEqFdc1e8PiE4lJUytQJqP65zKfeqRVXSZrJ0398h6u6QYIFhFurD",
-	"yo9FYlVnZesXXaMpAmsgY/sq5kt7gmEziyScBF02Od07OwTM6xEsGE20jrS8nd2JY634VOrOaCaUy4d+",
-	"aYzFHMwhu0apRL3P5wfvDoejg/OD/c86d7lsKug1Ii55L9Spz4Ggl2Rc+DPARM5WvgWIpAuKieAA3lCc",
-	"2nMkyKQLbF1v+wQvyeezg5P9w5N34flRki3Lk7QTkw0/b9FkgbeMgYV/ju2T3c3dzyr5afF7K2FIASXM",
-	"+OdL4ta0Wbp8mMlEcVTsXDikUs4xfGh6+l5e9qQIyiVTLxX08fAMrO2dH+wfnIwOB0fDq9Hp3wcnVwPl",
-	"L9iVwD5nWXh4eVs3AKNGsLvjjlGdiPUQ0p6Mw+Oh3m+YCHksOq8uSQtzlevFwl1d+9shXKsN+xTEuzkV",
-	"OiOPJ7f0Uf1rDXK2ND5RsLjSenJ/Xbqq50FcLaPaHRJsFo5bbora36A1A1Yvc7oOV/LU8xaBjWlR+9CV",
-	"N6Td/h60pLedHF3c/eDoou+5dd0Dy7ti4Nb3QVdnQBfdoFoaKLxwKSW2OJqvcJNTPd3XKdx203aVMm16",
-	"CyylaNPQzb/f1cxO7U5u5nbOK93QdNx8i1tDkcrGNeShlFPFy7575g3tgKNH7qVioI719Cq2wxAUyE+V",
-	"4S/0fmkzNPKqtAT9PE3a3MAOh6fgz1fbO54x87YIU3Yz7vIMC3sPdRIIyZlvZ0r1XY8Vn0HuLbWSdqTL",
-	"e6nVE82xB90KWD+lrk69DVlB7tahq/7h3yffZ+k4Y+diVZ5aJwS3XEbckRcFVh4/x4tCl2DQfF8AvYXc",
-	"YF3/sM6fBjHchjpQCkdW3gnqfxyYr5rZpdx9wJGzMFacJkm+wPUsENBPBANJgrKs5Pj5qdtjwt+cuA9u",
-	"tlhqVZ6dRjdu0RGTb6piLRCTW+1XAqQTyZ/fl7M/rVqCbIhERfBucThvzyva7jOR8Mo4fOULQSvFDPTf",
-	"sN5yHG7jcnvGsFaieuvq7+YoyqHv7f8G8pa5lJqF4zRAhm5QplSWrrUSU0wih1JmTFXE5y2Uu7c0Od0N",
-	"LH2UMz8lwRzq1bWVZ9W5RJWFvHGNzbW1hraqll6hmDHEZzRLdZWtOeWiUmorQ9B7tkqtrVoZNTOnhrXV",
-	"dY390iiJopCMd1iUdIcNzmsq2n4mpPJnXaJyZZQVVt90UfMSyzbElYRCRE3PxvdHxxeNlwFBciXGU84x",
-	"5B1A4VsW4DsmldCepyu22YUKlfEF4dbzx8zdeGx5DGo/X2TV8k9VjWxnmTbDNFtrtPV3JrpzztomD7P3",
-	"o9FZk0+Z8m0IS0fqVVvVvx5KXP/FPbZkFFY8DkjFl1zr22ryM0xm6DiYcvCQpLasm8p/Yzi91mbK7yQk",
-	"Ym5VsT4wHn0c/PcwiqPB0dHpx4P94q+r07dvjw5PDlQCyw8H5+G8/pQIBhPRItKr98q6j44Hh/vrwdJ6",
-	"eqZr2gOoXoHL1L2iTEXymtJf0eto7V+Djf8DN/7309fdb+trG/+5Xjx4UX6wvfHXp69/1Z+t/2cUN7qp",
-	"N9RHVOtSDbRzmVeLWO4zOh6eVS6FHX5lcaQimsKbiDnAqQ554uoymy+y4nTVVXcOrxEQtxRQBuaUIfvq",
-	"lrJrADmgBHVmbrBFiAPAZdYljwOSZay5o9XRyVtOra6baQoWTJLO1BYlPH97uA8SyNJY+RkRlEhJhuFs",
-	"6VTe4fBz7crXfBwLhiZIlYO0ba0O34Z9Qg7kzezVi782dopGxrF9paMq3FcbYD61Zba1P0MtGSNYw1NC",
-	"md4WffXc0q/6JwVRzvdNSKdeSqDpBMwXpdW+aClTGDCV+ETGpyj7V+9P964uhgfnkpicndk/T0fv1f8S",
-	"CoLEJG8qiZbrm6MmEjjtAcs6Bi8Ayia7ierJBuoFkntjnjfXKVOl/VSLLYZgqiv8qbZb9nKbWDuag39I",
-	"CvDvkXmzoD/FYce23GNuy5NZ2uuQ16489rhFkBMVavJ9xRlDRlOhEhSU1PB+DKmuZSgBq9Uxqp/iwoVi",
-	"uwj5sjkh4E4iEHPFJaoODYVfFCbg4wxAYXqWABvquOp7NRR00bNjRNJKt2CN5FkG8MS4a67fwccry8rO",
-	"jpqYoLTI0lPdogfw+2pJlNam1/KXrrpA6b2TbZUF6vJKLanRu6tQQYdwpg3ib1MWtv6LoouFpNz1c+3p",
-	"BykJzqgfLVW4cBdnysqk2ypHtlrNShooAw8lhPOy3fhA3EFo2u053hS4lgsX2gEzHL/5kM6W39XbsbTO",
-	"sF3N14KpnPetpeP6G7i8sxjm8znsEcZbGmclQ15gsDqO6xc1d99nHtKXhzwT7Mci2E+O1oaQ7IJkNLl2",
-	"IQx91eyNRRCcvUjenlTf9y0qGEf6ruTC+lfIRbVS+orOkH6Cbqvh/GvznAswRmCqbmHKHYfY2P/1B07H",
-	"EMrCoHx90nTLRTbeNydDVyC8Hqg9Ltfs/trbPMv+aernywljhtTWx2AfTyaIaYe8f9rsaDYOxyxt3UN7",
-	"2ZMq1lt81m0JKGeQ8CYeAjI/X1GFdfyw0nwfgv7GKymoXeZNZSqVEGw0yzwGzK/6bBJkNTCJvlaQh7d9",
-	"lAhpc9UtT19ezj3qEv4Hr+ntds6iUw8ULxYLxEbW2qPKpdyWH+yjTEAdWasiMrw/9yRFHGTKZhBkXA0O",
-	"9q57WxastOIFZFARfJ/gTDIKRb0wZ9Dt3ukFWk1LFhx5rQpiP6NSqThhQ0oGl/crFJjipQSTQmNjMjCX",
-	"vr4hNkeHuLheAsrb+xUCbYoPDPH4WrXQQExg63dd1USrk7EacdemhYw1fRuujteXrN30IrXhw7/DtN2n",
-	"95l1v9iMCuCtbNQqoL8X/vWrreLZRDuQr7XOpkPjvsU23WYMe5XNrPTft65Ku+XTT3rgymY442ep5Oan",
-	"1QiBhbUHx4F7dbwimA4fwfjqYK0ZPgtwrBUK7QmpLWzCqoOKDJLWRohZIBC6JZbsmfI/U/7G0kENIznU",
-	"agWyorxQcw1ZRw8xB+6LuLMeUY8OTSJlTWd9U/4D1DDqMbz5gAOYMMolDZB0mK9SI/dDSaS8Y3miFWIt",
-	"i5N/UJb+zStZqFIQJrqA7VyZ06I5RDdoQyA4///EjObTmbil7JpvJnQeWZCPjuHBBwRko3q1cFvYEQzO",
-	"DqW4KymH8qVwXhP6673h8TAG6ItpnWRYFxnQUUU51wF+m0ptmyBD0s34gwVMZkjSaZ0PQmTFrGS/chet",
-	"UiXa3tzW7egCEbjA0evohXqkXDJmCju2knIi5gUN3TF0qiPlzqBmW8rKLKgLbNsEIxviJsFerkXpqcqt",
-	"YS5mktDK35ckEBGQc2WlzEUOMzA6GhY6Q/mjCJdVtZB1cnk6mZg05XIAIP/eGMNMUjCmww5LobRmRX4W",
-	"apeA6g1Nl5XsSXCh3bUwJVv/w/XNWGt3Oh1YvRG+laFVsBxpL2zFlNVx7G7vBPiBcXdXEKf9NB9qekYk",
-	"UTOren+jLwsl/WmPLIVq3FoGzP5JgCgtMC4B1NZX78d7yGff9OIyFMxUrJ43AdkMChUpMkaIuLR4PuwZ",
-	"qIFgXE5YPnw/2Nh9+Up+PLskRvzdPzgH46VAPAQbeiJl2HCXfh69/pdkoNFrhUQFaagsNaoedewdSXvA",
-	"6bdPNaj4I2DtojYBlQSMP3STRwaKEyrAhObkacGiPq8qLMbRNGQkPKL0Ol/8eCDT83hSQLb9eFSvQtC8",
-	"PbeOnr85DBdgWaOnfOtrwg/Tb83s2eZXkbSToNsKO9VMmS+5QHMTec55PjfgXme/l0SiAKECLJHQqKAi",
-	"2KVUgVIV6q960YaGAPvGRPFgUx1GZ1C7JJwCLIw0jEgRkpBq7o6FioKXS5ACqhzf+WWF8MeuuVQ3qo5D",
-	"AVfQymT9O18I47iuphxCq90/G9DqEeQIf5mDXMx+KWnCHmYQfitosCXFR12DN4gKImdEe7jaPCHO8mLC",
-	"BhQhn0KBbuFSJVuU4DLHBIEZve0joDaT89opPRGAfCw6H4bKQCrFYn1yc50m6vuRfaOtrMHWk8KCAnY9",
-	"EPRjdKuooKMcnToizB60ApuXEwIV8SOBSmWAMr9OjNGrbV6SC+OyI+A1AhKtFiopVjnFpLJwzqG8/xJ5",
-	"AVP2M/kBFl72SjiFWFJ2ozHmYEzFTGdi3Nl8pdhMoZMMcQC9rIG/Bb8y+a+s1doDe7GB3YB/g1Hzgw3g",
-	"Q1Fhb9BWRZ6P51g8NX6ht6N6liVsqGCKcq/eSjIEWZscpRbNQ0KNoEB9DbDgQS8XNcImuCTaeeWLqLy3",
-	"O6pkp3/niC09tcndkUDOqeRbsifn8ST5TjsUqv0ze/zEoU/NMXD6KkCkVXZJqmXPwqqQDkBM7YW3oNHe",
-	"jcodpBJ4JLjKWylIoYCagnMNUzU1h80WdFPX2imDUX0yLdoTXzJoveX+MiS6fDXfhwKuTqMfZCb1ZIQB",
-	"GLduI9BgYKwJkwEt/+z9XIXZ8slrfppgtQUVeeN14v/PEbM1aaulNinzyrL6vW2CAsneIeEqhHoQcpjy",
-	"S+IV0ygtwKGrzmjn9Wx9HUsVYU2wXjdmNsyFPwGcjBuTmGgve0nJSpo67VSgRla8NKgiM64HxSys6e3D",
-	"7rtzSkWZLh2f1p9J7lx/+mH3nfdgbwax3OxjSPIJTETOEKt+86k3f/zhFECLJlXR6unSgHdINOBhMyWI",
-	"nQBYxhCDHk3Mi/8m+iW7Df7Ke/Gw7UA2or+fFLSYpZXhJEhBa/zCpJbZWBS5LA3PqJHZWhqaXrp9DQnN",
-	"Cv0A0QwSwFJOHA+wWnOeNPRVXshZzhaUh2nq6ItN0hNHoy8moUzxSAPYGcVEHEPX9FPcd1VcwORaZY/p",
-	"v6hHV30VO6OCCALgaP2jXNbNhQWJZ81XmYYHdyh8Tx8iwf1MpuYLnVk7oLhqFJGUOkvVrZ77+WJj5T8A",
-	"tP+A+i0v4umSwDlOwIJh+TAkYdUzaT0O5j8SE2hOBPYAWqbaadlL/r9zlOsb/jM+GHwYBvChix9tfTV/",
-	"GFNhoVsIaIy+C5TGwW7cLNuN13dhVs+Mr5HxdeCm0mg9I2ZP1V8nasoRORZoQw6Y5h3Com09tI1/nLT4",
-	"sAiZ5q7uYdGRO8sXr7a327OydyHnORTogqjI9kD/0cBztv0YxdGg3zX8AWXE2smGzKO2EXDA8oxxFdEw",
-	"sEVVjNPeJK6gSJNzgNK2GX2eyT1b+jZoDdVOX84qo3DW+2bzklh1Vbb0FFa+IOqNcI2WvEE9V1Y8lNb0",
-	"aJqHXg5kvXR2e3Q+hxsm95tXh62+/Eod4RCOX6Nl1CkCPxreehNuU6GVGj47NbRe7QrALMPDmsWrdZ0o",
-	"TSSzZheG8qcmxDWQM7WKsvr7KtZah4vd7V3gpCGdywaxjWu0tGF3m2BI5zaGg4M5XFqnIgBNREWzh8JP",
-	"hdSPpV70l10OaP7OxrHgTJox3BeSS7Bn/CR0eSrxLCb38M9oxvsaM9ciKe/l5pfUcsgrBa/rxGp/DDtG",
-	"aWVe8SUpUuPpwEXfkyQ2GWkTmiKuNUGmqK+fbKmJodtZ6K18TAvC/VD+Hpy0Vw6Uyka0FGwJMVmjxuN2",
-	"E59xrSog17eojFMpFHBDAewEtXhADZUL9g0iKWUul4e2RSuf7pBnubVUz6FQ2MHRHBKBE35JIEMgVcX8",
-	"nPOt7vseHk9yivtQwJFdzC9rk/NX+YO4ZXkKvdikBhbzzRP35JKwVJmwoB12wBTDKaFcAngzHh0asYAD",
-	"CLwPTExRYZhu4kked9zUHoWV98pUn9AsQ4kojSARyIwiZmhug5dcDUJbPDgcU6EOqySw7nvL/W3EVW/R",
-	"D2gB8Y/JVeh8OvJjxRddz8+fs4La3sixVSQe7C3CBVDFSHW29mkTwvRRqHinOnRlCX4xYawnVDdDh9fI",
-	"7v3T0WvUBJ9GgKkDpirRs+FV9GmTgCAwDSX9HCNgPm/ytgM8YQipsImjg33b2g9uWDBMmbpNGBWmMiur",
-	"0LiNMeQotR/pG8Y8zwReZMiG+Br5Sl4zCulIVextMENXShL9sjJSYy2nB6DXFgSelj1MApmCAZcck0rx",
-	"yqRdenJmbAPWbjM7XeQrmLr11fxRM2tX2cmc3ih5y11b7JANboAemvbAKWX5e3JYFYfGtOv26xab6JXw",
-	"qG6HH9vC/IxJ97I7V3Gpl0BWwqY2ccwLOXGWG+du7oVrV2bBW4JDwmYxyfTk3lue2A8B31Up/ZP0W3+r",
-	"l+n2ZrwsvAXUwhvMXfZdPzgyOyDBCR2QfN5zJnbDG+bgvV5pGmfmOzOTVUnDFIlnwnAf7V8YIdvpArqR",
-	"43dayoFup3usqv9ig9RGubdcICW7CjxHgEEyRZuX5EB/Dxmy2gpk1B8nVODJUr0vJXvoZRvX3f4MRvG3",
-	"ju6prWyLXFENajErfUcwJwUnOjE65vog1g6Hp3++2lZZHRsIj0tjXwzaJ+l+10zGaKJqm/WeCiLpA02k",
-	"XljEzEn7HeSMNEzBFsoIuBPtKG8lm0d7Z7uP81JTfZJiNvwaLxrmYop1BCezHf84z3eNe23aYIP0z/4Q",
-	"/fwhDC2uUOgJZvNbyNBd9Gj2W1sboKcSrWQJtX0ZHhi7fD6WKa7hCYBkuR4bnbMaacHolCHei4i/NbN8",
-	"bGXck1C+VRYbABzb4ump3Z4Y+oShuxF/TLGOXraaatcdrgNVMyZD0zyD7JIoO6iuVVzpkmujacick9Jb",
-	"orMqktSGNJpKU7qLS+KuWr3sObqUSxDnfl2NoF2hXvwDqgOrsFEy3TwZBBkxPJ0iFoLk9huBymmykZli",
-	"ON2sxlSEaqz4c1eTzVG4QM+vzR3Ci26TsY6atv2psA15iSbUgIcCq6fGRxprVTUGHmqawlsrXXXyjGrK",
-	"q0meZcDUbVLZtFSwoVd7yXGORhL/tHHm4Wl8Q42yByDybnefo59CSZ8VK2nGmyaOsnVTVHrrfYmpFH8z",
-	"SXWbBr8kXNgi3b3ToTgIKgqX/fJcxltuN39RBM0exDNnuS9nsVtZx5RpT2eyjE5XdSLTZhUF7dP1vm5f",
-	"R3JGv42/1xGdPiAPkWf0s/h3VeGp644wvZNflzfKA/pzHdHpk/XjarEDOP8ECyOH+w0aYNOgwkC+q7K3",
-	"2OMgj5j+BD5iNeCrQrWCm40bV9m/I3Z0hqWkgSWFL1X8X0MEsakylfN8vtAWsgW9RSwGNzQTcIpigESy",
-	"uQ4uCUPamGjd4hv1StrEpooOkNSrsd6AIMdeffenax4fL72QhUbwv2s+h6Yh/QLQjYNWyz2vbojTLg5M",
-	"FEYv8J0NcOOlKlDeb/zHtLrZOvxPxOzmTYdf4wVYK9Bp/Sczwnl43ibFH/sEykZcp4DnSYI4n+SZTub3",
-	"fMP1+EWJqPcQiubletHhFOzWfaoozOZVFA4FMK9VXKLa4kBMV54wVZRj1BFZJAUwQ0rlpJyN7SW58OoS",
-	"tuYxb/AoDpTH/m3uB8Hl/6CQrIa59IrN8mCuHIz7HMbc5L8cwNdmArAlsasPFZDIJ9uaQu6l6ue9yUEI",
-	"SwuQeAM5+q0wtLz0h4wDKI5Gndkz6vRDncq+taCNwoK+eON1awvoWzTqiTinJFtaHyyV7sP1AwWgDIxR",
-	"Rm+145ruWHHZMQL21taJfDZf2++IfWrtj4N++jSe8W9l/NMI1oyAGrA7K6MA6HeqPwr7BjfKr+Yji1Fc",
-	"GTxL2cXBDYbGO9gXcNRnfRyFqx/9PmgYWPzj4KE5xCeo5H5qd8nqnrXg4Ffzd69ANz/OrSqiroCR4TC3",
-	"n+KqFw59MzvQP/TN7nmfXJp3D3/zL166itRTQRlVnE3P7glGvPW4fTHktBgtUd25XAHiurxkSX5QVVBN",
-	"8fUxAmqpRdHfesIbzAFW5VEviapwZyoEjxHIOUoB5ACCKSKIway61a6MqipvjpIZJJjPY4CF7NL2dkkm",
-	"KmU50gpcm3TOVbFMcwVHAnGByXQTDFTER7ENJjYnVLaykGPHlMqd53qV9V1JIDFF/iYTlAiAJ8ojleXq",
-	"wAQN25HdSfyOVVmHSMgD+WVKZXjH2aM8hmZvPSLKUqRqRJr2TZFlPE9mEpeqsr68tFG2vCQF2SqYK98E",
-	"56bbxoAzI0hCkt5HxtxXizCD/VzmZyO7tcSh6RZ3DkQbLx/Mst3LxKQP/MmYmNx0fsLQLgPQ7VWj9AKf",
-	"o1OaonFL9K1OJjliNy5NdYO+S5Xo5iq3rGu+enWTJnPRGJV5uRkFWZOQ6VZew9WV4Vy/PqG3wRuDmuy5",
-	"t67f5r7tLfoB79n+mTfcsLe/A6i/gakd/hnH/YuJgncpsjhM8ZG6Gd+3vno/Oi75e5AkKOMAEpts1oeK",
-	"eyN8orpXnfj9OozXw3vgHUT8aqOfRUngL7lrAqUja52J85nBRLzYjR4iZ47a4KyVEvxYxUEJ8J8Sjmr4",
-	"hj0xs/nWcoS5lnVc0z6eq2+WwGyFkUl5AIt5Y6YbE/pVzO4nu18U2GWcQ9fMbrw229DiCqe9eUPVXPSn",
-	"XkmX4sGXhVpEHMEs+941XryD6qoBWIK4Z5ZaoOuRiVv0dqeOqyXrVFXxxCuu4T9V2T01/fsKkKoTxyVs",
-	"TV9PXKzGlNzADJckvJfhdgIxAjOgrgCsQVFkjYMVKqSPrXKWyt91w3OzbbM6zqlAmfGnLZV89H15uwMu",
-	"rdYWmkrGXpifUv9aKo3nc5RK7pottWOAXxkkrPKUMxwqF15vRb+swjO83ge9/MgBjAP104ywP/en6MNx",
-	"HdBXjZMpSlj0T9OibyGW26IYZJALMEOQiTGCIi4yAbhMLpCkYAZZeqvz8wuIs14JW37LrMmBHWgtCVVJ",
-	"DGyg7Jnbt2VACsflcEEXKzMKuvBvzU+VX9DFb8UuSst9DG5BF0+dWdBFG6/wXm19LYUgfesRkaZJOEoB",
-	"JloLIUEEjmkufIWxjwKOf1wSmJXj2BoYgXeA+5pj/CzqntK6OyZQjf7qNZMXr74vR6odRQguvUaWxX8/",
-	"LuQPTqgAE5qTp5c3VYS2qBEvu5REftNOJZHmFtSkRy6CPC+JSZCc8xioZBsqkWrcI/hTzmLkT/en0hr5",
-	"Z1HXGmXZ3VRGWRbSF9nUVS0aox6G59J5O+uzN+uX2zGYwy+vwc729vrqVumXFaP03W3S1Zmq2EcJoQVA",
-	"edPefoxQyKaTL01N3ahMAAxlfiZbhQm9MtnuV9No3yuQtm16fnrbXvPTdcvuMLvvxEu61JclWvh8oamo",
-	"L2vkH3Y4TAmdqK9Zq2ky+f2OrnRm6T+zJ13ltHOS0eR6w9mMm4/9QrV0RS9/Kk12Ze73venp7n6sUltQ",
-	"oM+uZPEPRJpV8duFYPe4wBXh2mHXfa+atcvmZJ30eVOdDXc+Unjcop4ztYSaPvo+N8TPJUsWC1djNSUX",
-	"MY3u6uNY2s2GQWybH1bJ3p1gm8rSNXp26uupryzw1QuqaatfP0SiwPKedes1pg/LmN5est6NYOvWh+NU",
-	"fxokfyQ25Zb8QyvR12bRK4WDO+LnAvQd4a89cVZybZtEfeur/csoXpvMDVPMJZaohIyJp9ERMwT2hsfD",
-	"sO5ff3VkvuiDb673Lkwr5t0ky7/64zumU0ysSNQDo3aaHKCfmia/69A1LDHTrhf4EEnqD8ECMrGsQBDY",
-	"R7biRiVbjxdMpoLPzBfK1/OSICxmqAgwVE5wcjxWmbEe0+gp7QSyDNxCXORu188FLbq7JE0ddsH9mewr",
-	"eixDUzGjXxXqmmFFA56g14h0KKlVRQnZjmtDZgKJi1wU1GWsRU4waVI0qz4a7qbdesMuXWE/VekK2tH7",
-	"ythYoDnv1KWpA/jmhoeMwWWrRk3v4pPTZZUTFxezbI9WocwmqgdQf3R3GBsiDWKPRC7MSf1CdELPydS6",
-	"UX4L9TP0yMTWV/XfBW6xLx9Rep0v7n+Wuh97nN3KLDuzJ2t+LYCnIqXXt/z7m15PnqS51cJSE1zKxkoX",
-	"1yYIZyBFNyijC1UlQ7eP4ihnWfQ6mgmxeL2law7MKBev//pjZ3sLLvDWzXb07dO3/xsAAP//EBIwBxZZ",
-	"AQA=",
+	"H4sIAAAAAAAC/+19aXPbyJLgX0FoN+JJE9TpI177xYtZWZJtTesaUbZj9smhhsgShTEIcAFQMsfh/755",
+	"VBWqgCoA1GHTtj60WwQKdWZW3plflwbpeJImIinypVdfl/LBtRiH9OeOyIroKhqEhcCfQ5EPsmhSRGmy",
+	"9GppOxjEEXwUDIxWvaVJlk7wgaAeBk09nF2L4GTvMBDJIB2KodlRcBsV10EibuMoEXmQiUkcDqDJ5Sz4",
+	"6/w8+QsGKmYT6HMpL7IoGS19+9ZbysT/m0aZGC69+pc18CfdOL38bzEolqCtsbR3YX69GxbhKXwu8qI+",
+	"T6NtcA2NgyG0Dq7SLIiGsAHR1QxmEIRBPhEDbNd1R9TA+NhuhKNsx6M0g10YO7ZevQqmOWxKkQYjkYgM",
+	"51dc8xxhXJFMx7gV/XfbWy9ewgP449nfn/MfLza3jH1Rm9hbivJ8KrI/xQwnVx8ZnwbpFQ3DTf+WB5Pp",
+	"ZQyr/ixm0Pc4/HIgklEBH29u/d07wlE4FnMMMYzyAr6fRvk1LDiBj7sMlYssCuOj6fhSZM3Hyi2DhJta",
+	"XT/faIM1+7RqK6xuamVedej0g7KGGCdIX4fJSGzfhFEcXkZxVMy8EC1fIOQM6Cv8Xwb/y4sQmwQA24M0",
+	"SaBn+Cs0uqzDs2q2P+RxrsJpDEPCrlW2W/e3vxssbwT/DBB3Mj1mLzifbmw8E/imxCT10QoeSpREY4Tp",
+	"8kSipBAj2ERYvrjJhZqDOe7eh/4eDon4erxzchJsrW2sbQbLKTUI4/ae+Unt9sINK6zNoaUIA/WOJ4iV",
+	"0Q0+209S/etTG0jRW88hw7B93rLtJL91QTZerCG9AywKYY7V4x2FNwIPH16EcRyMw6HAqxVRbqd/2K8d",
+	"cjjgnl0D0Z5yA4W22OmSAx9FlqXZDtz1DT1RmwAJQi+4vRYJd2hPn9cGFwFRiTDYgfH28DvvoLvmYK6x",
+	"jSdqFfThA01iLPI8HDnhU69ctgGi0raPmciBYOdN26iaBJNwFqfhcL51nIockbjtZipX1VMg0g6z08Jx",
+	"6cvLAeczFAVgVE74WgXcGmBehrl4+Zwp3EmY57dp5tlibql4jV4A36zCR0zQ1X7bOzNRHVr04OVzF1FL",
+	"bsI4Gr6Hmx2J03Ycp7fCMZP9KyA1dO8W2ZSoNR5AID9Hck7fw1kAWiZpEUwycYNMlmN68m7EGegZXaZp",
+	"LMKEid9gChRpdpKlV1HsgRXVCMahVjgzmARtfn3IV8G/BX9t/BWsBtOEvkTmIwMAmqRZwfAD2wzXdgiH",
+	"jG03se3ZQd/1bst6V+clz5Ol+mVcAcDqGluhbw83s85vaQ7YhaA76iVfporVGvIlQR26rh18obi7Cvs2",
+	"HEZMe/hzBfLebs7cBAieIuRyH8tibbTWC95E2fg2zMT7yRDn2AtOBcAE/dWXm3WQjj6G+Q7ACeziypKT",
+	"b7oR2NJBTGks9Z7JOLwu4MTiXvAH/IoSgJ1xaNDW8AvT1j9a6awYXHuoA7xJcAy+lNdxEKIRrtljN/vQ",
+	"oHHjC92jMWFnbxHcckU4ntS7+6huVD6BdAD7iyixvN8//vvLjU1cPfcNjfE4VrEz1yA3ITCEl7GTPnyQ",
+	"7wK4jdJBRJBHKNMAflVuQq/BBKhWZNlP4KM4Nvjk3HeLFAiIBvLmeJFE/H2QuujOWoBfWp/QpXeJ3SXF",
+	"eYJ3pINa5bNkcJ2lSTrN49naedIkYNHvqBDjO837BwqzgIKw3qlv2vSuF0hem+Z8IpIhUwLFe24PBmIC",
+	"wAKPTgWeL/2p2rlkP8Xnqh4+bL2FLw6P8Z838A+xht1Y116rAC4fhFkWzppEnrwdTvuiQCrInKpG8RPr",
+	"7Oq7COIqyJcEY0RyJQ+Qc2drwRtbXtDt8ut0Gg+Bb7hhafsqRWKPGoBJWBRAv1+dJyTJDDRVYcFmnZ8q",
+	"XOeHEg00iaEhBsQSDOLpEPn4QEkqRjMC0WQgpxQmwwDFH2Adz4EwT0KmTwBfuRhHq4M0BlaQR1KjNw+k",
+	"W9XHgTVm0eUU6TOeStA8nLz8g5iYp1IG21x7iZv/YmODEByYR5HljM2mVL+xseGAU/ss1en7GMYW2CFc",
+	"OvUy1Dt4pSPJo3YIJK08qeTLXOwfUAwAGRdrhbsx4LHiWVB24eLsriSN/wB75hRodnRH8iqD01EfBTfy",
+	"KxcX6+Gdq1NlrVcknKJOHObFjn8LzhQtAkgCmSQaMCEbAQjdhrMAv8YbEhhKyWE1cb3dqCv2uRvlg8eb",
+	"1FD3Pu+83gEXVlyKsGialLyaaBrX6gOY0EBENwQj3cYbA2GKHdBir4Vbza9Ns3upKtTqLA8QorS9H262",
+	"Pg6T6RXcEiByZK3sToRbUp52K/04y6LRyKlH4Rd1/YkUdlt1YWZnx+UtXirC1oJTOXG6vvIUblmlB8BJ",
+	"58Ey31BHqSSIpCk7FHBffgjjqcg7qK/K5SnK/hpEArNH1AnXhsGH0SgBLmDH0mfjQ9o/2Hu5g44G6fgS",
+	"uJ2h/caYN/zajcJRkuYgPOTO0ZUs43xZIk5viV7NTglB9c/DNIlgk2GS+sUJKqrz68Z+QTpyPG/neuQm",
+	"e4ENPjIk8Qrc2A0YejROw1E+21pynWzluz+jZGie8vZlnsZT2vhTESs95CmKgZmPB6x0eTLNJmlucYVn",
+	"X9Q6evD3LvOg5SPGrJMUpnkYfqkL5vWh+oNrMZzyvvzvTFxBo/+1Xtqk1qVBan2n2p6OgInmYFZd/C5I",
+	"1Kit/ijEZ/jjk5vHHnw+AIEodu53G1aFSc73QOfzIiXPmywdW+1bJEP45Czt+kGVj64BlrVq73G7Qctx",
+	"ZB3A/SDKHRoXqW+yJbQupy97/QgilbYotMoTerQO87V7RpEijo9hWv+aa37Q8ddm6tAKL9WzND6vL+OT",
+	"sRATodwXzSlA0Hu4Hk2E+QhT2O6EqCdA29Ph3CdX+fwbYdg+97BZPUDg8qdZqPT1HXAL+toxVmcjTAoX",
+	"v4EtkivhKyArzP26B5LpTfXuWBP01TfXPro4GvN5dVgV/3VyHeb8rf9q23RtJW1KOY+5LsaqgtboqifX",
+	"4NyFdAws3tDpZeCyUEnjVbMg9kBWq4GlE+smIpGmWHUa3IY5r6BInQNkAgXo7aJBxWj105nj/8kNbrjm",
+	"K6DjphzcQVplWDLE1J6U6aS6Z05rWzqYTLyitt5GKVa3m+48JnnDcse2eWm4c2kwfpj9z6+X/M+pmIrh",
+	"q/LcYK/Dy3RKti6p0/1H0Id/jTZwQcE7kdBb0i4lqTJc63dKzP1HoDSa6J2gVJqv5lvSPwKUqofHU5hG",
+	"ORbilRomiGCXoM0/gjcEecZ0B6T4Q6ucXFDgtJI1YouitrxfKCyxAt+trVWTRWGI8cBFn6ds62m8P6T+",
+	"ilQI7PLRVW/gFK4rV6KNJNoYrMGlhHvztjNn3kAULO1czW5HLbrzIpLOfKvzG5rA2jt4KDWYTFPJXCAH",
+	"BZApplliXk4GEU2vrnLh6O+o3k/+OZpMPN0UaRE6dDZn+Ng1JwJLp+mkC4cpd1MNqzZFr8ZzSrzL9eNB",
+	"nbTr0Cw+uL7myPn8m2NspZ7G9obe+IVLa0xOY63tKltCH3lXnQPMtTPb95Fv55UZeuS+iV/3kfO6K0dr",
+	"jFrtsasQmCZX0Uiy8OyZZnijdbPSWH2gvWb1BlVHQNKiLC9915ZaTQQOdzera+o2D5aVaQJ1auJLOJ7w",
+	"oWlV0z5sc3aD+Lj0cmPD0mf1qbXRYHNrY+lb143xkXP1JrjK0nHAre1dseZsw19GFM+xr8CPr6L1S+6F",
+	"atcrr1C7I3TsbD8c5ZXZ2ZTJFFkzTFZ/+mSbrZns3KBUqKz6608nrKhvV9qxx6qc39xGSrVvreDfYF2y",
+	"Fp1Zp/1WFJ2P2tq8P13HhVoYJhQNOPVoIAAkf5gm8azZIiYtsth4lVq7jF80Xb+djlfjhCk+7S4Qoafb",
+	"DhPAwySfk/S2edcl9wMsIQyQS6fMTATyW3PfPVZ5n6G8evAeYCy1Wfa5KuJQqoH6xzt/7p2hInf79cGe",
+	"20d86JwoENYLuAVFBmJVV6UNfHKTxkX3LyYpsNYXVU+F7Z2LzYuTd9v9PWSldy6e6R+7Oz7VbzIMM0tj",
+	"vPNue3ePvB3gr+P/2Mevjw/3+mf7Oxfb5o/X5o8d88eu+WPP/PHG/PHW/PHO/GEN+h/mjz/NHwfw4+3r",
+	"s4vtHfnHLv6xv7dz8XLj2cYfF1sXOSw0FhebLyvPi+tMeB/Tntcfv3yuHm9t/vHy4myz8vNi5/jw9bH9",
+	"cKvy09Xm2XblNy7iaO9w++LFxdaG+vvlxTPj7xf6780N4wX8MN48N9885zcn20dnx29Pt0/eXbw+Pjs7",
+	"Prx4f2I/Pjs+udg9/niEctde/2D74lT/hR7R74/+PMK3rVRFQnGPRSULK2yIt6DZgMlGHL6Tw4K+AOay",
+	"WLIypTRWmgJjg1GKJ3BW2kccbrC7+oqW0zXMKcFyBJNOZitzarT2tCLL4BuOUiV56x08SAefUZqeZthw",
+	"7wPKhNNEWvpU67dZOk2GZbN30ej6TNA5FvyEeD3gYNUX0G0Y44WPZDGOBggBx0jbVIPjG5HJ0yn7xYcf",
+	"NDycIDwQU1m2oGf926gYXJcPT4FCmY0AHoTR6/tkaHb7UYSf0SgLjKnzPm9zljRcJJVSR6sU5uH46rBZ",
+	"mCBmsnscVVFaFNmv4g3aGonRexMlUX7NT08y9EHiv3EjMnaK6E/ziYCNGO59sH8RYXifhHqMT/P5fZaO",
+	"GbcV9QoqkkjFIhUbd1OxVMQvtc0l6LsuCHQ1Jpy7ElmXuJ8cNkN6V6zqcBsKbGMpqUmxPnS7NWeXEaBx",
+	"hmEwuC7ubZk3AJ0V0R8Alfg86sqccRLaf6LU3Cr9rnZX5/nLXbB9yZw6AZ6J0+uW3phjLFc2a8Xuf+vF",
+	"i7Zz1aO1n183gdBablCq2Lqclu6JgxmxO4cq07Xozujet9C8ejbNkt175owPjQgT+ehDfReN/a7vK9yU",
+	"BwIdM13GJQVLWvtLGxF6zTnjMAlHUmM8TchFEtAF9nsAHd3XACUn86A2qFwFjzR13sH+pDbKVJXPZ4Zy",
+	"z5mtTzzVIkU1qDXcpSDHWt7f+cxAQzj4IKaTb/FYVFvj7EbNxDQRSEOkBAaXmeRu8V4NZyRfeSJBrtlb",
+	"rNJPLxARCddkvUj5/xdZxbZzT0OXd853sRuYqzSsCGacWTmekHxVCb6fGvG/wY4wLBt1NiUYF8t9rAkG",
+	"mN7bomD19QBWBbO/dvOBuYlzWRB2xU00ENJLr3Y2Ot6q8X5i71y6nsoArfkDcbibXQ/FpCGIjgEkZJFo",
+	"UJgNTKtIi0VKNjTigNo+UjFB5jfbyjHfguCauIdSZmHobwzV2nhaqChvQy2CosYxa+M+ZtCv/Bsf028n",
+	"9zxBlM4L4RvKHVKtl8C32fL2oJhiXBmHWveCwwhjPeD/4Rf4/4rHX65JPaijF1hRuNSJhahp4Fp1ggxG",
+	"zWF7EmJl3J6lS91PMEgrzWaw1IoX60qDU4GL2iiOHyRuZmLYT5XILOlapWtsC26XI/QshHSic+nW20UQ",
+	"mU7QPyEYll8xE9Yig8TpIHRzV+9P91Ewy4TVpwzbuRRyQJt9mWZRRVzY3HJuNKN9wx0smwTRlVrZlYzt",
+	"1IGQmxttzqXYyaw0JtXUJ/IN+irkApB6iL4SxS27S8CnCOdiPCGjTvNI5KCFAm2DwEVtyCmCBC1zVwdp",
+	"HMu47Yny8up20yK31zIuSqgPOmoFqDUItUBxv0WdYU5NnrkW2ivq9igWR6Fr0UdGCJqFChiUzVywAbfO",
+	"UBIO+e0aRyI1FqyomOPU7r4ViqDsD0mt875cDv/5Rnl68U+3h3rdv9Bnu9uN8kkczg5L5r7inJAMgc4L",
+	"NwzW5B55fSDUyuMYcv/IZpipRLrto0toeZ9EcCk61ButSGwIME1cg9wKIDWF5DcmgDwq0LvDlyey+R6e",
+	"pLo87rKJeJ88wC5yupVuc0ckFmriNef9yg0ELA5O9lbPfDgljRbRTEtVbWWRKcnHs5ed5CG9/+UZukCZ",
+	"ou4bxBeK6Z7Tld7OiHAfCYZHv6/sInt5AKlF9gTwM7jmQxN4jRampO7jc+ROziW+7DV7Oc3jnOaNZ+D0",
+	"Rhd8bSTTmPXWrzCJiMsh0H+9YEyouljYG5byI6GnYsTIunNynAeAkwXiYLAcJhhTPL3k5EVAiNWrfGWt",
+	"lcJOI0uDbeyJayPtuCw/kdGhrPME5fK3Dx4y+70I74Tj9r3zly/UrZreJkxd18vlLBNLiqMg3wjbM8rg",
+	"zlm5C5nXOyP789H4XT0P44em8+oBW25ktgk6Pt1EPrRadPHsaeAL7Awp3SQUWmRFvar24E6SCd9JchMx",
+	"Ch/kkvLQguV3Z2cn6/hPf/3N2cnKo4sqamwWVoJlmVriVbCxMr/kEsEtuuvEiFKkkL6DksFHexQLGea+",
+	"qEmtBftXMhlSehNR9iY9X/oMljAeiyHmRiHdRFddzwMJWbXtapa40BBLNuTakK+tHFWBbqnuD70zFCsM",
+	"b6ERA6fbDAst4M/GLJ6+lCUqsST2YY19P6nqrUgPDPSoIA48L6Zs2q9ftGky8r2tzkD1Y37lnk1R1a8Y",
+	"94FHoffB0NF5oDuWbl9GGkNN7JW6jhxYpQ4oTX68IrHuCy57Mzr71En15dVFSQFHBbhU7bdZOPis+LX5",
+	"9VKuue0Pz8KRJxXUtLiGY/8fmdHCcHCgNCIJKczCUe1yF18mUTZz3267lOtHCT3cQUAfEH46b6VWXm4S",
+	"ZpSXDFbiwF56qYbCmY+ydDqhHGt6dRU79UaHQX303961GtU3TLmv4Rr4TH/t0QYwMadoZvbFUW5CX+5F",
+	"zMnpxprVXgJXs8MWTf5wpLefBZLTxZsqtveKULd26JF799EkZkvp9jY7pH4DHpvQsgTcuth4RlBZdtVt",
+	"W9AxqYNbAZC5IowShYY4tVgUc2wVPmy0ISqHL2VGLOW2liPp4HoWO5ft8lSBy4rEBmsgh1Gn6ZB8sNfm",
+	"s2tukXfO3lM9KL+e/zx5e4k8lTLNwhxgwz75tsPNZ29XeXXNk9Q8NIZDFIKcHMdAKsbqL9I0A6FFJZhr",
+	"ghGT36Evp+p6cvRK7y4GqYcDQg1Ad2UCaSW+ee96Ta9VWFQXQoTkue57fXB89Pbi8Pjs+PTj9n+RS+3p",
+	"n/vw6O326fbbPePBwTH6lR8fXeye7n/Y48bwq392ukce5++PdvdO354ew//Vx5+6UchiduFxSp+kKDPq",
+	"TW3prAKBCjokLJTnVzktGySMGbnBdjSHWSxOR057mIwGodx0Kw4RdORkr6HvM79fY8m/UstAu0ASZwF9",
+	"GjdkNxErjYcdh+SWDzBkJsZpIQ46yOC0tQ9gFqyxpNYEXCAAIzfbhnHhJB6wMG2wWIZZCgCJ4od1Vlen",
+	"Jb47P45cZMpsOCu6cRalU6FP1v91rKE1QXYkHZFM63ds7bOF1m16S9zPZvvg99ImdnMYqCecBaCoen3b",
+	"tuS51+80CrK6z2kgZP/21+HwUPuBmTF/MuE+UfoTkY2jHNmHXZFE99QaVmxnLnW/euH3IZSN0BUPrqEu",
+	"WrwyPquDiesNNVY2rjhMRlOnW+OBfEOxEsoB5W8i+Rv+m+O/Q/G3ikHr74Q6Wrrp4MJesPJArqBhT41p",
+	"161NlZ3j3l6dJ/8W/LXd39nfxyTiJ3GIEp34UtDzd2eHB/j4lNI4wlP5FeZmpAaw9/j+/ekBXnjSAhks",
+	"R2PymbwVlxP4Y4WSkWomB8fCMBDoG+ERTs912brspHU1gRxQQYUyAvKqjnCu8c5sEAtaRFroDMIpOXPK",
+	"z3JqvZ+8yWBrsGUf7ZWXAtYquGWOF2KWMr/AOxbfhrNcf8E/g5soj4Al6gXX0eiaMo/ICVk7YMyLRHnq",
+	"BaPtyj6btqQ0v7ozPqKEkhdagVKxGKN2XBmKeZvUR7iOXTbOokn2RqrpqTtByE/NZeAItiartOKiMMtI",
+	"mLAXMm/okPedWukIkeByWpAGGnZ0mguGojKARH+AoTGo9wN6FA2EtX/OKBZ167XEopRh5i5ZR2axHmMj",
+	"FcpORT+0+tHM/z1H/JdVsUWFWGBsrFVy4M4lWNw0hOLoh77FKsFdNpPL7SqxG+kn++Y4DjVmp/Af7QJd",
+	"UPiF2phuNLjFB8GIn6OCOcBBoVjBxk7j25W5sr9b2+u+k9UW+RkuJGkyobPcgcvp4LMoZGCzDYhwG2Up",
+	"2R+nE0AfyoClcOIFVkvaxIpIm8MWwD+FPqYTJ/hPx2MMOrIOQ46Om5WwhTu9QY40UXOtawJuKETzQxmN",
+	"XKVE0JOM3FQKRO4Kf32wjt2bmE24UpztJUN7H7vHVSQiG812RewMx+LrkNsE0VhmL9aeLOb0P173ZB5w",
+	"9a76WR5hFnDNdkpgV/PGKiVRCnyo7FIrwRVgIBiEw/8OBxZT0LhVLXfJ8qB6O5npEZyh5hRJ6Sg4Jsme",
+	"pB8UkysXXj/oj91mz3i2g0qCJvHIAlZrrCWvs6Yzzior7gRCNe+XXOcIJeuLoKyf5mKa74yGuOSTcET6",
+	"kaG2jpmL7yFPbD+ja2M6yRmYKFG9kvPyMqFCXVdvXFvdaIG+6OZxepLJP5q9nsZGumM/LbPWDPJrBvLr",
+	"Msqrs+alr8xP9lyUrt01S621yTdLHlbXZXJrPPUhOaNfzviWxhxwVBTpcsam/V7r8c+5BZKQuEh+N/cy",
+	"7VemzwpAVy5opV2rPbZSYM/hZuZjXlwkkY1dFpOE10tYY1UcQuwXp16Sr3vZQMmMPI81zu8ZDXqBwbSs",
+	"vRaA8E7volKorVa6wXnKeOHl0/AWL4U+uTlgRI6zL7+zjdLAMeyF+TQTYyqUtBOStLP3oRfsJzHGkxxP",
+	"C/r/63Q484Ql4/fOBKVKZWcNwduzRzR0jQnx2j5RlLVT2BaA3KwXEDmy3zoHn2AeV8eFio/VsMNg+WCz",
+	"FxxswX/PesER/G9zFf/don+frfITer+1ik3g2cGmc7xp4roCMbNtbZ3IN3zGf27CDP/k/33Eh73gwzb8",
+	"H/+Bp/yuF8Av2PY/e8GOiPMIi9i8Ca8zkVyLCPYfAAkZhHkCew7V+hnIlwFPARoHGK7J37bzxzd+Zlg7",
+	"h7jLtH7/IK9yRp0NQR/qn7ZnFXI4fzgn4do1rYPrEsaOzhZGSacgVR/XlaP6VYMuu8mnRH9/h8wV+7Bi",
+	"rGfh6EMJM2UFJu0G0S1CnW+EzHON7VLeBHbVjeQsBu5qiTK37T7c72WP3uD09gpTiqW0upOkl3zy+KX0",
+	"5kGP6+wzYCKg3l+ne2/3+2d7p3u7fzE9x6ZF+hn+UlW+Qq6RCE/Pk8vS4Swc4GzxLcb/TLCOAPy6SSMt",
+	"miRCVstoXG/zBM+Tv072jnb3j96654fZv+xJqolhw7/W08EkWpcW8Pyvnnqytbb1F/Ew5e/1ASAVAmUY",
+	"53+dJ3pNa5Z2SE4G4yr1zrnTkuAcPRHgNH2jgOOgTGwDW1fWjDvsnwAVhBH2js72tw/6F2fHf+4dXWyT",
+	"Q3dbpctp5tEIoDpVJXLGEdTu6GOkE1EunGUmb9pv4BnwWLgAFyavUV3pXhTc1c1zLdoP2rBPTrxDQx0J",
+	"Twbf0sU2yya+WLKoyvmc4NPIetRrrvIxZ8EDRzbQDvVlSs9aPUV2CGtMAN/J30kmbSjtpwqBpe8Hawvs",
+	"DWl2kHK6OjWdXDq5+8Glk67n1qaos3dFwq0ZJERnkE7aQdUayL1w5BIbIoHmkF+pp/tG7ahuGkVDbjNH",
+	"YgUjHcDd5TQ1tTvFAak5zyWhce6pBr+zMh2kbpi70raWL7vumTG0Bo4O+UvLgVrW06mSPOXhMNPNmQu9",
+	"X+o5Rl5K7dXNFbDJT3e/fxxgWgjD2+S2zCOhZ9zmuut272y9IJAylyXmKl49aI8ql1pJ3dfmXtroKqzJ",
+	"A7cKlCNpW6fGhszBd3NuAfPw71PuxjrOnvaBtafWCsENwog+8rIS8+PnSfRnXeoKoJjtRHbT3WbwsyCG",
+	"3lANSu7Q9ztB/Y8D83mzI9rdOzztS2vy8WAwnUT1TGqhmUwR0+XHseWZ/6ndpc3cnF4X3GxwpaFcld44",
+	"m6IlaQpauzHlmshwq40t6adXSJ/f2RlUm4oUuybXF0WF8W6ICGrOzd/s1DbIK+PkcwsEjTemo3/Peu1E",
+	"Cd7ldkwyUEm7UFd/+8Pc+2Y4FsbC+ediNXMH0gUx1rgjlaVuTWyKzLRjZZenYj1vQty9mSxpKGHpI878",
+	"OHGWEKyuzZ5V6xKpCJ93jTm8VbkZqhWP+Y1cISZpBjEMpLDljeCfsFos8QLvsbp9L/gDHsUiNJ5ZEZ1/",
+	"zFfOS83Js7a6rrFbKtKirDhtHBZmm22L6x7XVLTd7EH2Z22scmWUOVbvE9SM4gyewD9XDL/sWTpncgCo",
+	"TEbYWuyla55O4wBK518H3ZHpOHcMXbHK0FmqjN8nuXLNlHOXLrUGgdqdTuJqnfiqRna/LUWxJJpGIN/S",
+	"Pbw971z3wecCjKHcPqff1uyYJVPaWV9tKHHNF/fYkjO34nE7qQT7sL6txj+HgHiHzrTd+8mQTh8N/Zig",
+	"TOWxJG0mfoeQGOVKFWsC48HH7f/C1OzbBwfHH/d2y78ujt+8Odg/2qMk8B/2Tt1lLdME7QNFA0tP78nb",
+	"RBxu7++uOFyild51mV00SyWcRAu43YD3xMwZZB2eoC96hkMs/2t79f+Gq//z6evWt5Xl1X9fKR88sx9s",
+	"rP7x6esf9Wcr/+7M5sqRKf6qf7IBe/9KpI/yfIr7jJrbilDY4vjbW6KQU/cmwrFFQ45JzUmYBVQvT5dE",
+	"3XH4GbbwNkVb7xh9V+Wr2zT7jCph2MTW1DrAdsL8Xb49+3JdeBxhMusxdVQ6OpRy2KRgZN+STdELNqFi",
+	"cyz/nL4BMBgAQ9AjR1DgtJCTyaJ4plXe7vwg7GvtP44JkCnAc3SMUX7ZUoev4vJhF1Aye/nsj9XNspGM",
+	"PJrrqMr4Al/SXNTgsNiBzg21hObBMhCdNONtYdFznV91z9pE0VE+pKOXRkpcP2A+s1b7rOernNKYLFde",
+	"VvpG2b14d7xz8b6/h7Uftk9O1J/HZ+/o/wgF7hp8vjTEU5Yc+ZJQFSEaYZmDpB2gLNNPUU8qktpRICfK",
+	"p2HMKlr3lLjFOnqBEMvFOtN1JdwOlB1Nw3+YlODfIXt9ef+Uhy2/6smUQMbdq5FXrbxnUAsnJSrV5LtE",
+	"GV1G04IyyFhqeDPIv3R2NL3Q7hb4XebK0ClMbHOCw50Exuy7nfkODTcp8swMKDW28vBzdVz1OEPDSMeO",
+	"hfQ9NVOaYYwkBmyxP+TKHTzbsGCv6dnIl4ntfGqv5AH82BoyWTbptcylUxdieO9siDZDba9UXTW8u4QK",
+	"HGM/9LC/vjSZ3RfFadUd59rRUR0vnLNudynhwl283SuTbkrY3mg1szRQEh4shDPSkZlA3HLRNNtzjCnk",
+	"zBdO2NvVHWD/kC6mD+O92dGkZq3TbVcztWBUN8oxmNFLZ4HdOIs+O/y3ymnWOHMZ8hyD1XG8DDsIG83H",
+	"TzTEQ0OeLuzHurAX7q51Idn7BFME6Rizrmp2byExbS9C6Yn6ns/I6JwjiTY678ocyQLnyi/UmnMlEbfV",
+	"fCvL42lOhchHJIWRO06ikrOsPHC+HFeaHPL1GQ7Xdej5fZPmtGUq4YGaEyfI3V9+A339MxOTOBxQ0qcI",
+	"8z3A2nrBbnQF4jY75P1Tpa9UgZJyaSsG2mNPqKQ0Pmu3BNgpfoyJu4DMTChXjWX5UeWtPzj9jedSUOvU",
+	"yGQqRQiWmuW8F6g50CuZwdBDJLpaQR7e9mFdpP7KtYa+3E4OrYtmOcX0Zjtn2akBiu+BLGRnytpDJQdv",
+	"7QccCkipDygiw/hzB2/E7ZhsBk7C5XGw192r0rrWilG1SRe+eeFcxWlY1KPinG73Wi/QaFpS4JjXKol3",
+	"MypZBb49OXN0YkZXYIqRszGn6DqP0UbXF/HE5nCIi+7Fobw1IgqqSb4b00w3B3C7aLzl8OkJtGz8zuXM",
+	"bl5Z1ckojbhu03CN+b51V5jueq3ddLpq3Yd/h2nrT+8z626xGRXAm9uoVUJ/J/zrVp/QsIm2IF9jrXqN",
+	"xl0L1uvN6HcqPV/pv2ttwmbLp5mVRtc10sZPq2z9p/kuAgVrD44D9+p4TjDtP4LxVcOaHz5LcMwsSH3b",
+	"GVIbyIRSB5UpfpWNMMocmSoaYsmebv6nm99b280zkkatRiAr67+5uVnrPoyw1JL8otdaMK5DhzLTPd+z",
+	"pin/AYrMdRhefpAH4SBLc7wD8B7O3WY0HwNnspR3rB83R6xlefIPStK/GWW/KUfsgPYQRHQ0p4GIBNz4",
+	"aiHC8f8BSX06ui7QHJ+vwWSXFMgvHYZ7H4CXhUY4FUdOPpT9tk/2kd2l4H30pdBeE/w1RoD1AvFFth7E",
+	"EVeB4agiNIJjgN8aqW0HQl7pcvztCZoJ8Z7mhD1FXM4K+8VdVEqVpQ24zakdoEMSTiJ49IwekUvGNWHH",
+	"+sDOlI+5Qx2uwJSLjtwZaLZW2nxMoCgD29aCMxXihmCPayE9ld0atUN40eLv88QRETDNyUo5xUqIwdlB",
+	"v9QZ4o8yXJZSrHD1j/TqStaRwAEC/Hv1MozxBss47NAKpZUrMssE6AyBGBFfSW8XTthdC75e/++cJWPW",
+	"7rQ6sBojfLOhFTU+7IVNRJmOY2tj00EPpLs7QRz7aT7U9CRLQjOren+LLxPi/tgji1AtV5YBuX8IENYC",
+	"exZArX81frwL8+tvvDjUJLsM2PjcB2ToJ4WRIpeY4lLlLTVhT0JNGFzaFSX677ZXt168xI+vzxPJ/u7u",
+	"nQaXMxjNBRs8ERs2tNAPx/QvJKDQDpGovBoqS12qHnXPOJLmgNNvn2pQ8dxh7UpVhkAEjOfc5JGBAhj8",
+	"4CqdJosFi3xeVVjEop0u+2Wafp5OfjyQ8TwWCsg2Hu/Wq1xoxp4rR8/fHIZLsKzdpym0SYY5XKb81/7w",
+	"G9eIdioD0W5OdyhmE+Dytwp2EbLHANFMIm2a2wtCrBvDvEpUqJy3KjlRpfXf8EWOqVwC9q6kiaELW5Sr",
+	"FAWuYu84qQZk4G7qiODy51QjGhKbC1/Uli0spsg1u7BErlED6/fBD6nWUVu8UFjyVlDmAb1niB6IGLnE",
+	"CTf3qtIPIVqgTcaGZQbhfAYtxjIxQw4jihJ57PbnCeIR+sPORMGUghI8INONjqvJkHthO5yDu8WEkDAT",
+	"Wd2OM8CeJzkIDIUUFtEJXEXsDJn5jQpKEoFLQPkNx9duiy6MUmu26l52wqzKZNsQLOfkeS5c2vq7B5ce",
+	"gc02l4mm1l+K2VaH6YTfChqso3TVSh+o5LJMo6MNkzKqhmjFCDbhNpxRsmgEl3GUiACzLXeQ3/wXfO2U",
+	"FgQgH+tyd0OlIxV0uT7cXK2o/QG3fgW2FgoLStg1QNAMYa+iAgcBa22dmzywfSe382WV4VWOSqtoEDbq",
+	"3Em1M1zD76VHW4EBHohWE8oZZ6fIJgeAcYjqoQT1E2Rexg/gji+zb4cjaAFdSoMKyCQpAIbK90pkplTZ",
+	"uygAL2vb3IJf+fqvrFWZyzuRgS1XJmG2ggWrgQlFpTmOje759HIcFYtGL3g7qmdpYUMFUyj6YH0QizBr",
+	"4qNo0bmLqcEgY/yaZAeXExiNsBacJ+zb9aWovFc7SrwT/JnNDK3i3ZEA52S5Xu3gPBaS7jRDIe2f3OMF",
+	"hz6ao+P0KX6qkXcZVMu2ujWFLYA4VPqg8o42FA76IInhQXBFpQ1GgYV8g+cMUzUtoEqmdVNXapM9tT6Z",
+	"BuWiyRk0KoF+mSva1lxhTPn8d/SDzKSeq9MB48qrKpQY2OOLSYKWefZmKs94tvCKUR+sNqBi7hUn/hPu",
+	"6Ug48RCZnLIOu9nbWlAiGQj0usK5ASH7w/w8MYqBWQvQ6MoJH42elSuwVdFexrK2Y6ZnLvkC4GTPm+OH",
+	"g1DwJrMU2exzQyMTLXVqkKVnTjkLZZn+sPX2NE0L+146PK4/Q+pcfwqfGw/gqotwsw/DZHoVDrC2d1b9",
+	"5lNn+vjDbwBmTaqs1eLeAW9F4cFD/03Q0wygjSESPXzEK/9N9EtqG8yVd6JhG45kXX8uFLTIpdlw4rxB",
+	"a/RCZl5anZSpXiXNqF2ztSxNnUxfeYsW33FpOi9AK2WUAViNKYE8fdkLOZlmgDjuO/Xsi8ph1YO/Zb6l",
+	"8hED2AnmDD4MddNPva6rglMZfKbkSt0X9eiqr3JnKMbGAY7KfVAnpZ0okHjSfNl3uHOH3HJ6n6oP1b7g",
+	"xPMOxZWXRSJ1Fn6Vj810yj1yrwnYvYZ+oyA+nAEoQreTLMKHLg6rnmjucTD/kYiAP0/eA2iZaqelhHz4",
+	"dyqGT/hg4EPfgQ9t9Gj9q/xDmgpL3YJDY/RdoLTn7EbPstlifRdi9UT4vISvBTdJo/WEmB1Vf62oKT1a",
+	"vGqFg0gr94AZzedyY6FwyEwMqHJulGH4q+HZwnEJHX1bXHZMmJkt/qi1PJro08ltpZPSoJ5uQ52ELLw+",
+	"zRKP1kAlkCgH1zCHEbc6vBRYyOZCC/VZHTlng5k7PHORSSyck2mpq/49XHnyJqWCamNZeBfHCQ258aJ0",
+	"r8rR56Rw4ZkDpYEARIVYxUkMpy3yn2rdV41/nAD4sDR2ONWl2B3Q+exlF/RoorenIJBjVTJ3/0vbRngJ",
+	"Vu3c7qZZe1AcqJysGwm4UaCB5YmIVqQ9xxZVMY4dxHQJLZ+/DynQJTWV2datb50ODuzmrA2thLPGN0Aa",
+	"lQY6nhk6aFO2NEb4LGa5R+NeIabmmn4CikrX+arMdmqUea0vPzCsGR66Bs2WWqXaR8NbY8LNBMxc2ZOf",
+	"UpO2pgRMGx6Wy6rNlBq0GFz7vZLsT1WN76QVZfn7KtYqHyqQeAIt4HD2NpGtAgSqQPO1oJ+OVdQi8tsz",
+	"5ScII3MMod/p6KdC6seyGJjLtlN4fGd7t3Mmfgw35V4L9qTrExdkLJ4k3w4uV368rxFzZknzTp67g1rV",
+	"FLLZ6E6UQleSY7T72VLyeVImg2WR2HQO68kc7BjalLNyV9aJN9ML+gi6mgVvpcgXFeXvQUk7Zf2qbERD",
+	"iTIXkZWa+Vxt4hOuVRnk+hbZOIUeIqsEsFeiwamxT1EVN/BvmunsVexe4pB7OVhEOZ9gzW7EjhzWg87I",
+	"+XmCEclDKl+r/em573s4MeIU0V/qTC3mlzWzm6v8QdTSnkInMsnAIr9ZcOdMhKXKhNF1t1G5M4zCUQLI",
+	"AwDux6N9yRag5dH4QEbRlr4mPppkUMc1dhKuvCfvm0Eax7A4awREIDkKDDBW4bq66i56I/vDpOiwLIZ1",
+	"11jub8OuGot+QKOmeUy6JvXi8I+V8BKenzlngtrOyLFeptrtzMI5UEVydaratw9huihUjFPt60I8vxgz",
+	"1hGq/dBhNFJ7vzh6jRrj4wWYOmBSUbpVo4ZdEwcU6nBtuD8vgYnhz30OtAH0gHGqAKQHe7uqtRmvNMmi",
+	"NCNpQqowyVOEol1XMXvCUH3EEsYYyGOElXLkRkv+CsWMkjuiGvUez5JKEb5flkfyVi98gPtagcBimbgR",
+	"yAgGdDroFNkrmWhw4TxTJFjrzWyNeqlg6vpX+UfNU6VKTjAWJTddutSQHs9eA0074BQZ8xcOq3quMctM",
+	"E7q4gApIc4+qd/ixnUaeMOleriRVXOrEkFnY1MSOGVFk2nKjI0iMDAyVWeQN8V5usxgSPdx7RRO7IeDb",
+	"6k2/kKEob3iZem8uZ6UDEC3cY+5S77rBkdwBBCexhxbubjNRG+6Zg/F6rmmcyO/kTOa9GgAcny6G+2j/",
+	"3AjZfC+IGxy/1VIecDvuse5exkgtlXuYuJ6cyKIx8ExoAAA03uPvUREotRVCqj+OMGfLjN5b+Vs62ca5",
+	"25/BKP5G33u0lU3BaNSgFobWdQR5UuEVlwLB1FN4EMv7/WOsObPiv3h04ZZy0C5lZtpmcimuqJpn56mA",
+	"5PVAE6n79sk53cOzb3PDdO2DX/fw7Stn8xN69jHuNWmDJdI/+UN084eQd3Hlhr6KsvEt3Jt30aOpb1U1",
+	"nI5KNMsSqvqSNLCnU3QporiMhamS2UpP6pxppEmWjuDcO13ib+QsH1sZtxDKt8piHYCjWiye2m3B0McN",
+	"3V78keWpOtlqql23uA5UzZiZGE3jMDtPyA5KlXaqXeYySaTDnDOEvec8wpjLUcaecm1F7uI80aJWJ3sO",
+	"Fy9z4tyvqxFUK+TFP6A6sAoblulmYRDkLItGwAO4ILlZIqA0RauxLP/WTmpkDURvjbu7mmwO3CXpfm3q",
+	"4F50E4914Nv2RSEbKEQnqQQPAqtFoyPe6ozeWGK+U/LG2o6tNKOaxe4Ki3zKSoWUII/ih41qg5pyeK/4",
+	"xcaZh7/jPVU5H+CS17v7FNDoKnNApMSPNz6Ksn5T1jbtLMRUyp3KpNm+wc+BX0qzOTMcaQgqS3X+8lTG",
+	"WG47faELTR3EE2W5L2VRW1nHlFFHZzJoOa8TGZtVCNpHK13dvg5wRr+Nvxes9gFpCJ7Rz+LfVYWnNhlh",
+	"dCe/LmOUB/TnglNbWD+uBjuA9k9QMLK/69EAywYVAvJdlb3lHjtpxOgn8BGrAV8VqgluVmV5xvbY0esI",
+	"OQ2sJR3Ql7q2sEhENiJTOUxgwhayCRZo7gU3aVyEI9ELRDFYWwnOE+D3yZio3OK9eiU2sVGZHRALJuEo",
+	"SpoQ5BBn9EFVmlxU8/jlzAhZ8IL/XVO0+IY04nX8gxqN3HjXCcXRqFYavYLvbIBDY6MyxLaO/3BWN775",
+	"gyyN4+kkVwy7hSN47YtwcB1cTgefhay/G+VBTEBESk8RDp2fojd7LuIb1KCeyiEGYYKm+UthW6LLI1zz",
+	"LJqqEULHc7g6KLzaV592sjvKANJFMTwa00HLY7BcXigrP5kZ0rjpmuSYQxOGVMw5UIIp8Gt5jrqXGdOs",
+	"je9As14DbGeK0XxSLFhk2r4n2nnRcQqsdUp3kb+YhfJaKyvAlp8548aXK55oTeE3siuDhy3rPnMgHMaK",
+	"xoI0feTjrXQTpTNdcQ0Icp3Gw9zjyK3KzR6Wy/1txDLn8n9QJJxnLp1C4gyYs2Ogn6LHfW7jDnz1XwDr",
+	"iF1dbgFEPmwL/MaNiJEg3uE6cGFpCRKvw1z8VhhqL/0hwy/Ko6Eze0KdbqhT2bcGtCEs6Io3Rrc5fIcO",
+	"vwqNOiIOlnRXrm+UZUX3Exao070UMZZUY3kAOyYqe4kBOCwstyKfynz5O2Ifrf1x0I9P4wn/5sY/RjA/",
+	"AjJgt9aYwnDEslP+yO2S7eVf5UcKoyiToV2nIbiJQumUbTI49FkX/+zqR78PGjoW/zh4KA9xAW0LiyZL",
+	"VvesAQe/yr87xRea4YVVFnUOjHRHF/4Uop474lDuQPeIQ7XnXVKY3j3q0BS8uB7foqAMlbnk2S1goGEH",
+	"6SsTWovREEw/xRWInAv1WvwDZbHOr9NpPESCREuV5UId5pAzZAojKjSNPuqwQUNuDJ9OUbMSonF8hBaY",
+	"MK5utS5IjXAwFpi5LcrHPawwir3K3s6TKyr+IFhvrnL96XrAwynBUQE3LxZ0CLYp0KbcBhkS5SoAXPKx",
+	"mCkQtY+8yvquYMVrLpd6dYWZXaIr0olnUzqwInWb7/VJ/I71rYHlwgP5ZYoOGcfZodAQk7cOgXxDQdV2",
+	"ZXtfQF8+HVwjLlV5fRTa0mx2npTXVklc0SAju/XG+UlGEtWi9+Axd2kRcrCfy+ovebeG8D9ucef4v8vZ",
+	"gzkUdLJr8YEvjF1LT+cnjKiTAN1cf48X+BQU5AuCtu63+jWJ5cZ1dnCPvisT0svOaD5/nSifuehS2LRc",
+	"jiKUSUh2i2I4iQyn/PoovXVKDDTZU2Ndv428bSz6AeVs88w9EvaTifgHCiYE78iyaEwxkdqP7+tfjR8t",
+	"Qv5OmAxEjNZblePXhIp7I/yAuqdOzH41xvPwBng7Eb/a6GdREphLbpuAdWSNM9GuSkCqn20tPUSqItrg",
+	"uPEm+LGKAwvwFwlHGb7DjpjZVvLKbNrFYfj1LJBbIXnS3IHFuTfBkIy4K2f3k8kXJXZJn9xluRuv5DY0",
+	"eCCyE7WriA5/alTSKR98mdAiekthHH/v0jrGQbVVU7Ug7omkVgpc2btTx1XLOlVVPOUVj/xHq1xVx7xS",
+	"FM1RYTIEcA8Lrnf3cmMFqe1tGJVByBVExWTmXNYu+HgtkmAEIC2Vd/wcNYN8iYih6ZZqlgrxOZfiwFUt",
+	"GQuWLztJmY/DMc9nk9p4HGXdNm2uC1bP/KUHe8EtnhFuKyqd8EgjYo6dlJzWqum2qldvMPDV4KqbMI4s",
+	"nvuFux3AdBLGAQllmUIobP283vrMlW1ySHnEJHyhA0TEDCUBKjlZu2N0aiYlxssKspIf+arh+9xkVh6n",
+	"hYiln7pVHdn0kW8PZFZqeeSX0ZhshM+Sfl+R4Wg8FkNkn2BM8vyw0Mip08YZ9sk13ljRgibcfLqL5rqL",
+	"XEf7m1xOlr4B90GGiljixYPeKAtl2TBWbF5U9Zts3gDDsvZP9/xWrEdQ/LLoBXEICHstYHaXIix6ZQoV",
+	"nQILzRfQ4/CWC5sUYRR3ynT1W6abd+xAYy29SkZ1CWVP/HpT6jh3QGNepJO5OYF0Yuq9FpUhSCdP/MCv",
+	"yQ9YJ/sbswPp5DfjBmDBDcyA8Wr9qxWc+61DrDbTaEITVhTjToWX6bQwbXrmHacZhHPMW25FpXkovQG2",
+	"u8wS/CwaeWvdLROoxkV3msmzl9+X5agdhRN7jVVLHu77sRnm4IitV+k0WbyM4oVri7x42abHN5u26vGZ",
+	"HUhl4YAy/cF5IksHTPNeQGmoKMV4r0NaBJzFmTndn0qxb55FXbEfx3fT6sexS6Wvkjo2KPU7+AZZ560d",
+	"hIxZv9joBbAhr4LNjY2V+R2HXlT8hu7uNlSdKcXEI4SWAGVMe+MxQuR9J29NjURmGaOI7ihljnfChE45",
+	"3nerBSbulWKiaXpm4vdO8+OKnneY3XeiJW0WJusufJJYKxam2vUftvi0FpzC1m94kjluf0dvZ7n0n9nZ",
+	"uXLa0yROB59XtVuP/9jfU0tdDvpxjI2PlbHUnvt9/da4u+9m5XKapYBe8tlZTlmOYOAqfussGR0EuDKj",
+	"hju6apoj3bHzHKo4qtxXgUqfDzKP66kR74JQ00Whq4f4uXjJcuE0li/tlmx0Vzd0azc9g6g2S624+Ejk",
+	"XZ9gk05aN3ryu+6okC7x1Yh7XKGM2mExuHbVWy1KLFfpf1wZBWqY3rcxXZmFTC0oC5MTyu4nR5AZsDyp",
+	"BH4aJH8kMqWXvEPhbz8o105tFp2y7Ogj5tC9p/Bob4aCjjiLVHsowuFqLAqG/GY1T1l6FO/LgYhuhDOr",
+	"bM62IGofJiFWq7gNc2Am6PSArZhkKSZn67HJBLvimkZ54VPv7MI0D+QsW5C1rq/ANQZyjfcJaLL0Elv3",
+	"0UtUZ/QTxjQZJ9KEv7vmSk0iu1gyrHkgdcRY/zrUq22JMNiN8kGYUYVxo0+iU2iUkJBPhtcSnVxQv0sD",
+	"lLvchUaZI7YRKHNFXQnVy+ddVDQOc5YBBVjNEndowcrqyHOzjw1n2OiiEho1xM37sMbcdL0QwzgF0CCu",
+	"BlvTTJ13Ypp+nk5+Ruh4jBuo5eIpjTDfl0uoQNJCsfUVOG+58dbh7DM0IXgdTU7CPBcWjxBcixjtspWb",
+	"8DIcfMasmOl0dG1iRI9zB38J0UIBAsJAULm2L8F1iKVGBW7nJE5nYrgW7F9ZA0W5QqBK4ldqZYF9Tnb4",
+	"G+xkO5lpgkQ+GcEADcKchIITKDmTVWD4fFQQAnOWpYQ3mR0DzHlhE+Jq9O3g9nyhrf1FbnpZMto4EKp2",
+	"ZExXHsAPR8fnW1vfwx5swMOAkqHIhNZ6g3ouKP0MQg8r8Ta/0yStethpDcFw1oLo1XDBvExWCX1cF5oq",
+	"nbj+Vf0lmTefr9wIWEHkU0NddbEkxTv9w74bffmrA/lFF+zVvbehbjnvpXkR9TGKqAyUureDtmDTF3+/",
+	"aPDTdugMS5ls1wl8EsSm/QDgoJhVICjYFarObiVZtJHLiHIfyS8o1Pg8EdCLKPNbUQwmjpdVZsxjSh8M",
+	"NQEga5ZDIj8H6qa7O098HbbB/Qn2tfRYroPljH5VqPPDCgNekX4WSYtmhurIYjupecEsUypxFnI2sk6V",
+	"0HKJz4mG+vDY3dr1Em1+EN3ULXN4ftxXuogKMc5b/QToAL7p4cMsC2eN3gK8iwun47DLlZWzbE6WAleG",
+	"LE8J0EMf3R3G+oJB7JGuC3lSv9A9wXOSFa7J6b5+hsY1sf6V/vc+avCdZc3B/c+S+1HH2W6oVzNbWNfS",
+	"EngqFoj6ln9/t9KjhXQlVbDkg0tsTH4GTYxwjKmdRJxOqDYutwcgmWYxNLouismrda40eg331Ks/nm9u",
+	"rIeTaP1mY+nbp2//H0gNh3LbigEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
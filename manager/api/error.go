@@ -40,6 +40,33 @@ func ErrInternalError(err error) render.Renderer {
 	}
 }
 
+func ErrUnprocessableEntity(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+		StatusText:     http.StatusText(http.StatusUnprocessableEntity),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrNotImplemented(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusNotImplemented,
+		StatusText:     http.StatusText(http.StatusNotImplemented),
+		ErrorText:      err.Error(),
+	}
+}
+
+func ErrGatewayTimeout(err error) render.Renderer {
+	return &ErrResponse{
+		Err:            err,
+		HTTPStatusCode: http.StatusGatewayTimeout,
+		StatusText:     http.StatusText(http.StatusGatewayTimeout),
+		ErrorText:      err.Error(),
+	}
+}
+
 var ErrNotFound = &ErrResponse{
 	HTTPStatusCode: http.StatusNotFound,
 	StatusText:     http.StatusText(http.StatusNotFound),
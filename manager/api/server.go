@@ -4,8 +4,13 @@ package api
 
 import (
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"k8s.io/utils/clock"
 )
 
@@ -14,17 +19,70 @@ type Server struct {
 	clock   clock.PassiveClock
 	swagger *openapi3.T
 	ocpi    ocpi.Api
+	// callMakers are used to wait for the charge station's answer, indexed by the OCPP
+	// version recorded in the charge station's runtime details
+	callMakers map[string]handlers.SyncCallMaker
+	// redrivers are used to re-drive dead letters, indexed by the OCPP version recorded in
+	// the dead letter
+	redrivers map[string]handlers.Redriver
 }
 
-func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api) (*Server, error) {
+type ServerOpt func(*Server)
+
+// WithCallMakers sets the call makers used to send calls to charge stations and wait for
+// their answers, indexed by the OCPP version recorded in the charge station's runtime
+// details ("1.6", "2.0.1" or "2.1")
+func WithCallMakers(callMakers map[string]handlers.SyncCallMaker) ServerOpt {
+	return func(s *Server) {
+		s.callMakers = callMakers
+	}
+}
+
+// WithRedrivers sets the routers used to re-drive dead letters, indexed by OCPP version
+// ("1.6", "2.0.1" or "2.1")
+func WithRedrivers(redrivers map[string]handlers.Redriver) ServerOpt {
+	return func(s *Server) {
+		s.redrivers = redrivers
+	}
+}
+
+// WithCallCorrelation allows requests to wait for the charge station to answer the calls
+// that they make: the calls are sent using emitter and the answers are awaited using
+// correlator
+func WithCallCorrelation(emitter transport.Emitter, correlator *handlers.Correlator) ServerOpt {
+	v16CallMaker := ocpp16.NewCallMaker(emitter)
+	v16CallMaker.Correlator = correlator
+	v201CallMaker := ocpp201.NewCallMaker(emitter)
+	v201CallMaker.Correlator = correlator
+	v21CallMaker := ocpp21.NewCallMaker(emitter)
+	v21CallMaker.Correlator = correlator
+
+	return WithCallMakers(map[string]handlers.SyncCallMaker{
+		"1.6":   v16CallMaker,
+		"2.0.1": v201CallMaker,
+		"2.1":   v21CallMaker,
+	})
+}
+
+func NewServer(engine store.Engine, clock clock.PassiveClock, ocpi ocpi.Api, opts ...ServerOpt) (*Server, error) {
 	swagger, err := GetSwagger()
 	if err != nil {
 		return nil, err
 	}
-	return &Server{
+	s := &Server{
 		store:   engine,
 		clock:   clock,
 		ocpi:    ocpi,
 		swagger: swagger,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// supportsOcpp201Messages returns true if a charge station using the given OCPP version
+// (as recorded in its runtime details) accepts the OCPP 2.0.1 messages
+func supportsOcpp201Messages(ocppVersion string) bool {
+	return ocppVersion == "2.0.1" || ocppVersion == "2.1"
 }
@@ -6,16 +6,25 @@ import (
 	"net/http"
 
 	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
-func (s *Server) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string) {
+func (s *Server) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId string, params ResetChargeStationParams) {
 	req := new(ResetRequest)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
+	wait, err := waitTime(params.Wait)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
 	// Check if charge station exists
 	auth, err := s.store.LookupChargeStationAuth(r.Context(), csId)
 	if err != nil {
@@ -41,7 +50,48 @@ func (s *Server) ResetChargeStation(w http.ResponseWriter, r *http.Request, csId
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	if wait == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if details == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	var ocppReq ocpp.Request
+	if supportsOcpp201Messages(details.OcppVersion) {
+		resetType := ocpp201.ResetEnumTypeOnIdle
+		if req.Type == Hard {
+			resetType = ocpp201.ResetEnumTypeImmediate
+		}
+		ocppReq = &ocpp201.ResetRequestJson{Type: resetType}
+	} else {
+		ocppReq = &ocpp16.ResetJson{Type: ocpp16.ResetJsonType(req.Type)}
+	}
+
+	answer := s.callAndWait(w, r, csId, details.OcppVersion, ocppReq, wait)
+	if answer == nil {
+		return
+	}
+
+	resetReq.Status = store.ResetRequestStatusRejected
+	if answerAccepted(answer) {
+		resetReq.Status = store.ResetRequestStatusAccepted
+	}
+	err = s.store.SetResetRequest(r.Context(), csId, resetReq)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	renderAnswer(w, r, answer)
 }
 
 func (s *Server) UnlockConnector(w http.ResponseWriter, r *http.Request, csId string) {
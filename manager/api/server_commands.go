@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Server) ListChargeStationCommands(w http.ResponseWriter, r *http.Request, csId string, params ListChargeStationCommandsParams) {
+	limit := 50
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+		if limit > 200 {
+			limit = 200
+		}
+	}
+	offset := 0
+	if params.Offset != nil && *params.Offset >= 0 {
+		offset = *params.Offset
+	}
+
+	commands, total, err := s.store.ListCommands(r.Context(), csId, offset, limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	apiCommands := make([]Command, len(commands))
+	for i, command := range commands {
+		apiCommand, err := newCommand(command)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		apiCommands[i] = *apiCommand
+	}
+
+	resp := CommandsResponse{
+		Commands: apiCommands,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+func (s *Server) LookupCommand(w http.ResponseWriter, r *http.Request, commandId string) {
+	command, err := s.store.LookupCommand(r.Context(), commandId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if command == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp, err := newCommand(command)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func newCommand(command *store.Command) (*Command, error) {
+	resp := &Command{
+		Id:               command.Id,
+		ChargeStationId:  command.ChargeStationId,
+		OcppVersion:      command.OcppVersion,
+		Action:           command.Action,
+		Status:           CommandStatus(command.Status),
+		ErrorCode:        command.ErrorCode,
+		ErrorDescription: command.ErrorDescription,
+		CreatedAt:        command.CreatedAt,
+		UpdatedAt:        command.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(command.Request), &resp.Request); err != nil {
+		return nil, fmt.Errorf("unmarshalling command %s request: %w", command.Id, err)
+	}
+	if command.Response != nil {
+		var response map[string]interface{}
+		if err := json.Unmarshal([]byte(*command.Response), &response); err != nil {
+			return nil, fmt.Errorf("unmarshalling command %s response: %w", command.Id, err)
+		}
+		resp.Response = &response
+	}
+	return resp, nil
+}
+
+// Render implementations
+
+func (c Command) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
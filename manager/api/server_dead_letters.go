@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+)
+
+func (s *Server) ListDeadLetters(w http.ResponseWriter, r *http.Request, params ListDeadLettersParams) {
+	limit := 50
+	if params.Limit != nil && *params.Limit > 0 {
+		limit = *params.Limit
+		if limit > 200 {
+			limit = 200
+		}
+	}
+	offset := 0
+	if params.Offset != nil && *params.Offset >= 0 {
+		offset = *params.Offset
+	}
+
+	deadLetters, total, err := s.store.ListDeadLetters(r.Context(), offset, limit)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	apiDeadLetters := make([]DeadLetter, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		apiDeadLetter, err := newDeadLetter(deadLetter)
+		if err != nil {
+			_ = render.Render(w, r, ErrInternalError(err))
+			return
+		}
+		apiDeadLetters[i] = *apiDeadLetter
+	}
+
+	resp := DeadLettersResponse{
+		DeadLetters: apiDeadLetters,
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+func (s *Server) LookupDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	deadLetter, err := s.store.LookupDeadLetter(r.Context(), deadLetterId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if deadLetter == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	resp, err := newDeadLetter(deadLetter)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
+func (s *Server) DeleteDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	err := s.store.DeleteDeadLetter(r.Context(), deadLetterId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) RedriveDeadLetter(w http.ResponseWriter, r *http.Request, deadLetterId string) {
+	deadLetter, err := s.store.LookupDeadLetter(r.Context(), deadLetterId)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+	if deadLetter == nil {
+		_ = render.Render(w, r, ErrNotFound)
+		return
+	}
+
+	redriver, ok := s.redrivers[deadLetter.OcppVersion]
+	if !ok {
+		_ = render.Render(w, r, ErrNotImplemented(fmt.Errorf("OCPP %s is not enabled", deadLetter.OcppVersion)))
+		return
+	}
+
+	var msg transport.Message
+	err = json.Unmarshal([]byte(deadLetter.Message), &msg)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(fmt.Errorf("unmarshalling dead letter %s message: %w", deadLetter.Id, err)))
+		return
+	}
+
+	err = redriver.Redrive(r.Context(), deadLetter.ChargeStationId, &msg)
+	if err != nil {
+		_ = render.Render(w, r, ErrUnprocessableEntity(err))
+		return
+	}
+
+	err = s.store.DeleteDeadLetter(r.Context(), deadLetter.Id)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newDeadLetter(deadLetter *store.DeadLetter) (*DeadLetter, error) {
+	resp := &DeadLetter{
+		Id:              deadLetter.Id,
+		ChargeStationId: deadLetter.ChargeStationId,
+		OcppVersion:     deadLetter.OcppVersion,
+		MessageType:     deadLetter.MessageType,
+		Action:          deadLetter.Action,
+		MessageId:       deadLetter.MessageId,
+		Error:           deadLetter.Error,
+		CreatedAt:       deadLetter.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(deadLetter.Message), &resp.Message); err != nil {
+		return nil, fmt.Errorf("unmarshalling dead letter %s message: %w", deadLetter.Id, err)
+	}
+	return resp, nil
+}
+
+// Render implementations
+
+func (d DeadLetter) Render(_ http.ResponseWriter, _ *http.Request) error {
+	return nil
+}
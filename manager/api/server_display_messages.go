@@ -17,7 +17,7 @@ func (s *Server) SetDisplayMessage(w http.ResponseWriter, r *http.Request, csId
 		return
 	}
 
-	// Check OCPP version - display messages only supported in OCPP 2.0.1
+	// Check OCPP version - display messages only supported in OCPP 2.0.1 and 2.1
 	details, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -27,8 +27,8 @@ func (s *Server) SetDisplayMessage(w http.ResponseWriter, r *http.Request, csId
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -86,8 +86,8 @@ func (s *Server) GetDisplayMessages(w http.ResponseWriter, r *http.Request, csId
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -129,8 +129,8 @@ func (s *Server) ClearDisplayMessage(w http.ResponseWriter, r *http.Request, csI
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("display messages only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -3,6 +3,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -38,6 +39,11 @@ func (s *Server) GetMeterValues(w http.ResponseWriter, r *http.Request, csId str
 		filter.Offset = *params.Offset
 	}
 
+	if params.Interval != nil {
+		s.getMeterValueRollups(w, r, filter, *params.Interval, params.StartTime, params.EndTime)
+		return
+	}
+
 	result, err := s.store.QueryMeterValues(r.Context(), filter)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -96,6 +102,76 @@ func (s *Server) GetMeterValues(w http.ResponseWriter, r *http.Request, csId str
 	_ = render.Render(w, r, resp)
 }
 
+// getMeterValueRollups serves the rollups for the requested interval instead of the meter values.
+// The bucket before the first one that is requested is read as well so that the energy imported
+// between the two buckets is included in the first one.
+func (s *Server) getMeterValueRollups(w http.ResponseWriter, r *http.Request, filter store.MeterValuesFilter, interval MeterValueInterval, startTime, endTime *time.Time) {
+	storeInterval := store.MeterValueInterval(interval)
+	if storeInterval.Duration() == 0 {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("unknown interval: %s", interval)))
+		return
+	}
+	if filter.TransactionId != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("meter value rollups cannot be filtered by transaction")))
+		return
+	}
+
+	rollupsFilter := store.MeterValueRollupsFilter{
+		ChargeStationId: filter.ChargeStationId,
+		Interval:        storeInterval,
+		ConnectorId:     filter.ConnectorId,
+		EndTime:         endTime,
+	}
+	var first *time.Time
+	if startTime != nil {
+		start := storeInterval.BucketStart(*startTime)
+		previous := start.Add(-storeInterval.Duration())
+		first = &start
+		rollupsFilter.StartTime = &previous
+	}
+
+	rollups, err := s.store.QueryMeterValueRollups(r.Context(), rollupsFilter)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	apiRollups := make([]MeterValueRollup, 0, len(rollups))
+	for i, rollup := range rollups {
+		if first != nil && rollup.Start.Before(*first) {
+			continue
+		}
+		var previous *store.MeterValueRollup
+		if i > 0 && rollups[i-1].EvseId == rollup.EvseId {
+			previous = rollups[i-1]
+		}
+		apiRollups = append(apiRollups, MeterValueRollup{
+			EvseId:         rollup.EvseId,
+			Start:          rollup.Start,
+			End:            rollup.End(),
+			SampleCount:    rollup.SampleCount,
+			EnergyDelta:    rollup.EnergyDelta(previous),
+			MaxPower:       rollup.MaxPower,
+			AverageVoltage: rollup.AverageVoltage(),
+		})
+	}
+
+	start := min(filter.Offset, len(apiRollups))
+	end := min(start+filter.Limit, len(apiRollups))
+	page := apiRollups[start:end]
+
+	resp := &MeterValuesResponse{
+		MeterValues: []MeterValue{},
+		Interval:    &interval,
+		Rollups:     &page,
+		Total:       len(apiRollups),
+		Limit:       filter.Limit,
+		Offset:      filter.Offset,
+	}
+
+	_ = render.Render(w, r, resp)
+}
+
 // Render implementations
 
 func (m MeterValuesResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -27,8 +27,8 @@ func (s *Server) SetVariableMonitoring(w http.ResponseWriter, r *http.Request, c
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("variable monitoring only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("variable monitoring only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -103,8 +103,8 @@ func (s *Server) SetMonitoringBase(w http.ResponseWriter, r *http.Request, csId
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring base only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring base only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -130,8 +130,8 @@ func (s *Server) SetMonitoringLevel(w http.ResponseWriter, r *http.Request, csId
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring level only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring level only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -161,8 +161,8 @@ func (s *Server) GetMonitoringReport(w http.ResponseWriter, r *http.Request, csI
 		_ = render.Render(w, r, ErrNotFound)
 		return
 	}
-	if details.OcppVersion != "2.0.1" {
-		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring report only supported on OCPP 2.0.1 charge stations")))
+	if !supportsOcpp201Messages(details.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("monitoring report only supported on OCPP 2.0.1 and 2.1 charge stations")))
 		return
 	}
 
@@ -24,6 +24,14 @@ func (s *Server) GetChargeStationStatus(w http.ResponseWriter, r *http.Request,
 		response.LastHeartbeat = status.LastHeartbeat
 	}
 
+	if status.LastConnected != nil {
+		response.LastConnected = status.LastConnected
+	}
+
+	if status.LastDisconnected != nil {
+		response.LastDisconnected = status.LastDisconnected
+	}
+
 	if status.FirmwareVersion != nil {
 		response.FirmwareVersion = status.FirmwareVersion
 	}
@@ -14,6 +14,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -27,9 +28,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"k8s.io/utils/clock"
 	clockTest "k8s.io/utils/clock/testing"
 )
@@ -959,3 +965,505 @@ func TestSetChargeStationVariables(t *testing.T) {
 	assert.Equal(t, "OCPPCommCtrlr", got.Results[0].Component.Name)
 	assert.Equal(t, "HeartbeatInterval", got.Results[0].Variable.Name)
 }
+
+// fakeSyncCallMaker answers every call with the configured answer, or waits until the
+// context is done if there is no answer
+type fakeSyncCallMaker struct {
+	requests []ocpp.Request
+	answer   *transport.Message
+}
+
+func (f *fakeSyncCallMaker) Send(_ context.Context, _ string, request ocpp.Request) error {
+	f.requests = append(f.requests, request)
+	return nil
+}
+
+func (f *fakeSyncCallMaker) Call(ctx context.Context, _ string, request ocpp.Request) (*transport.Message, error) {
+	f.requests = append(f.requests, request)
+	if f.answer == nil {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.answer, nil
+}
+
+func setupServerWithCallMaker(t *testing.T, ocppVersion string, callMaker handlers.SyncCallMaker) (*chi.Mux, store.Engine) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationAuth(context.Background(), "cs001", &store.ChargeStationAuth{
+		SecurityProfile: store.TLSWithClientSideCertificates,
+	})
+	require.NoError(t, err)
+	err = engine.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: ocppVersion,
+	})
+	require.NoError(t, err)
+
+	var opts []api.ServerOpt
+	if callMaker != nil {
+		opts = append(opts, api.WithCallMakers(map[string]handlers.SyncCallMaker{ocppVersion: callMaker}))
+	}
+	srv, err := api.NewServer(engine, clock.RealClock{}, nil, opts...)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware)
+	r.Mount("/", api.Handler(srv))
+	return r, engine
+}
+
+func TestResetChargeStationWithoutWaitDoesNotWait(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	r, engine := setupServerWithCallMaker(t, "2.0.1", callMaker)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/reset", strings.NewReader(`{"type":"Hard"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Result().StatusCode)
+	assert.Empty(t, callMaker.requests)
+
+	resetReq, err := engine.GetResetRequest(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ResetRequestStatusPending, resetReq.Status)
+}
+
+func TestResetChargeStationWaitsForAnswer(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		answer: &transport.Message{
+			MessageType:     transport.MessageTypeCallResult,
+			Action:          "Reset",
+			MessageId:       "1234",
+			ResponsePayload: []byte(`{"status":"Accepted"}`),
+		},
+	}
+	r, engine := setupServerWithCallMaker(t, "2.0.1", callMaker)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/reset?wait=5", strings.NewReader(`{"type":"Hard"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.JSONEq(t, `{"messageId":"1234","action":"Reset","response":{"status":"Accepted"}}`, rr.Body.String())
+	assert.Equal(t, []ocpp.Request{&ocpp201.ResetRequestJson{Type: ocpp201.ResetEnumTypeImmediate}}, callMaker.requests)
+
+	resetReq, err := engine.GetResetRequest(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.ResetRequestStatusAccepted, resetReq.Status)
+}
+
+func TestResetChargeStationRejectsTooLongWait(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	r, _ := setupServerWithCallMaker(t, "2.0.1", callMaker)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/reset?wait=61", strings.NewReader(`{"type":"Hard"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	assert.Empty(t, callMaker.requests)
+}
+
+func TestRemoteStartTransactionWaitsForCallError(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{
+		answer: &transport.Message{
+			MessageType:      transport.MessageTypeCallError,
+			Action:           "RemoteStartTransaction",
+			MessageId:        "1234",
+			ErrorCode:        transport.ErrorNotSupported,
+			ErrorDescription: "remote start is disabled",
+		},
+	}
+	r, engine := setupServerWithCallMaker(t, "1.6", callMaker)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/start-transaction?wait=5",
+		strings.NewReader(`{"idTag":"USER001","connectorId":1}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.JSONEq(t, `{"messageId":"1234","action":"RemoteStartTransaction","errorCode":"NotSupported","errorDescription":"remote start is disabled"}`,
+		rr.Body.String())
+	connectorId := 1
+	assert.Equal(t, []ocpp.Request{&ocpp16.RemoteStartTransactionJson{IdTag: "USER001", ConnectorId: &connectorId}}, callMaker.requests)
+
+	startReq, err := engine.GetRemoteStartTransactionRequest(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.RemoteTransactionRequestStatusRejected, startReq.Status)
+}
+
+func TestRemoteStopTransactionWaitTimesOut(t *testing.T) {
+	callMaker := &fakeSyncCallMaker{}
+	r, engine := setupServerWithCallMaker(t, "1.6", callMaker)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/stop-transaction?wait=1",
+		strings.NewReader(`{"transactionId":"42"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rr.Result().StatusCode)
+	assert.Equal(t, []ocpp.Request{&ocpp16.RemoteStopTransactionJson{TransactionId: 42}}, callMaker.requests)
+
+	stopReq, err := engine.GetRemoteStopTransactionRequest(context.Background(), "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, store.RemoteTransactionRequestStatusPending, stopReq.Status)
+}
+
+func TestRemoteStopTransactionWaitWithoutCallMaker(t *testing.T) {
+	r, _ := setupServerWithCallMaker(t, "1.6", nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/cs/cs001/stop-transaction?wait=1",
+		strings.NewReader(`{"transactionId":"42"}`))
+	req.Header.Set("content-type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotImplemented, rr.Result().StatusCode)
+}
+
+func TestLookupCommand(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+	now := c.Now()
+
+	response := `{"status":"Rejected"}`
+	err := engine.CreateCommand(context.Background(), &store.Command{
+		Id:              "1234",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusRejected,
+		Request:         `{"type":"Immediate"}`,
+		Response:        &response,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/commands/1234", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.Command
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := api.Command{
+		Id:              "1234",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          api.CommandStatusRejected,
+		Request:         map[string]interface{}{"type": "Immediate"},
+		Response:        &map[string]interface{}{"status": "Rejected"},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupUnknownCommand(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/commands/unknown", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestListChargeStationCommands(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+	now := c.Now()
+
+	for i, id := range []string{"a", "b", "c"} {
+		createdAt := now.Add(time.Duration(i) * time.Second)
+		err := engine.CreateCommand(context.Background(), &store.Command{
+			Id:              id,
+			ChargeStationId: "cs001",
+			OcppVersion:     "1.6",
+			Action:          "ClearCache",
+			Status:          store.CommandStatusSent,
+			Request:         `{}`,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		})
+		require.NoError(t, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/commands?limit=2", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.CommandsResponse
+	err := json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, got.Total)
+	assert.Equal(t, 2, got.Limit)
+	assert.Equal(t, 0, got.Offset)
+	require.Len(t, got.Commands, 2)
+	assert.Equal(t, "c", got.Commands[0].Id)
+	assert.Equal(t, "b", got.Commands[1].Id)
+	assert.Equal(t, api.CommandStatusSent, got.Commands[0].Status)
+}
+
+// fakeRedriver records the messages that it is asked to re-drive and fails them with the
+// configured error
+type fakeRedriver struct {
+	err             error
+	chargeStationId string
+	msg             *transport.Message
+}
+
+func (f *fakeRedriver) Redrive(_ context.Context, chargeStationId string, msg *transport.Message) error {
+	f.chargeStationId = chargeStationId
+	f.msg = msg
+	return f.err
+}
+
+func setupServerWithDeadLetter(t *testing.T, redriver handlers.Redriver) (*chi.Mux, store.Engine, time.Time) {
+	now := time.Now().UTC()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
+	err := engine.AddDeadLetter(context.Background(), &store.DeadLetter{
+		Id:              "abc",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		MessageType:     "call",
+		Action:          "Heartbeat",
+		MessageId:       "1234",
+		Message:         `{"type":2,"action":"Heartbeat","id":"1234","request":{}}`,
+		Error:           "store unavailable",
+		CreatedAt:       now,
+	})
+	require.NoError(t, err)
+
+	redrivers := map[string]handlers.Redriver{}
+	if redriver != nil {
+		redrivers["2.0.1"] = redriver
+	}
+	srv, err := api.NewServer(engine, clockTest.NewFakePassiveClock(now), nil, api.WithRedrivers(redrivers))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(api.ValidationMiddleware)
+	r.Mount("/", api.Handler(srv))
+	return r, engine, now
+}
+
+func TestListDeadLetters(t *testing.T) {
+	r, _, now := setupServerWithDeadLetter(t, &fakeRedriver{})
+
+	req := httptest.NewRequest(http.MethodGet, "/dead-letters?limit=10", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.DeadLettersResponse
+	err := json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	want := api.DeadLettersResponse{
+		DeadLetters: []api.DeadLetter{
+			{
+				Id:              "abc",
+				ChargeStationId: "cs001",
+				OcppVersion:     "2.0.1",
+				MessageType:     "call",
+				Action:          "Heartbeat",
+				MessageId:       "1234",
+				Message: map[string]interface{}{
+					"type":    float64(2),
+					"action":  "Heartbeat",
+					"id":      "1234",
+					"request": map[string]interface{}{},
+				},
+				Error:     "store unavailable",
+				CreatedAt: now,
+			},
+		},
+		Total:  1,
+		Limit:  10,
+		Offset: 0,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestLookupUnknownDeadLetter(t *testing.T) {
+	r, _, _ := setupServerWithDeadLetter(t, &fakeRedriver{})
+
+	req := httptest.NewRequest(http.MethodGet, "/dead-letters/unknown", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDeleteDeadLetter(t *testing.T) {
+	r, engine, _ := setupServerWithDeadLetter(t, &fakeRedriver{})
+
+	req := httptest.NewRequest(http.MethodDelete, "/dead-letters/abc", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	deadLetter, err := engine.LookupDeadLetter(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Nil(t, deadLetter)
+}
+
+func TestRedriveDeadLetter(t *testing.T) {
+	redriver := &fakeRedriver{}
+	r, engine, _ := setupServerWithDeadLetter(t, redriver)
+
+	req := httptest.NewRequest(http.MethodPost, "/dead-letters/abc/redrive", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	assert.Equal(t, "cs001", redriver.chargeStationId)
+	require.NotNil(t, redriver.msg)
+	assert.Equal(t, transport.MessageTypeCall, redriver.msg.MessageType)
+	assert.Equal(t, "Heartbeat", redriver.msg.Action)
+	assert.Equal(t, "1234", redriver.msg.MessageId)
+
+	deadLetter, err := engine.LookupDeadLetter(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Nil(t, deadLetter)
+}
+
+func TestRedriveDeadLetterThatFailsAgain(t *testing.T) {
+	r, engine, _ := setupServerWithDeadLetter(t, &fakeRedriver{err: errors.New("still failing")})
+
+	req := httptest.NewRequest(http.MethodPost, "/dead-letters/abc/redrive", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Result().StatusCode)
+	deadLetter, err := engine.LookupDeadLetter(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.NotNil(t, deadLetter)
+}
+
+func TestRedriveUnknownDeadLetter(t *testing.T) {
+	r, _, _ := setupServerWithDeadLetter(t, &fakeRedriver{})
+
+	req := httptest.NewRequest(http.MethodPost, "/dead-letters/unknown/redrive", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestRedriveDeadLetterWhenOcppVersionNotEnabled(t *testing.T) {
+	r, engine, _ := setupServerWithDeadLetter(t, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/dead-letters/abc/redrive", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotImplemented, rr.Result().StatusCode)
+	deadLetter, err := engine.LookupDeadLetter(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.NotNil(t, deadLetter)
+}
+
+func TestGetMeterValueRollups(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	energy := "Energy.Active.Import.Register"
+	power := "Power.Active.Import"
+	meterValue := func(timestamp string, measurand *string, unit string, value float64) store.MeterValue {
+		return store.MeterValue{
+			Timestamp: timestamp,
+			SampledValues: []store.SampledValue{
+				{Measurand: measurand, UnitOfMeasure: &store.UnitOfMeasure{Unit: unit}, Value: value},
+			},
+		}
+	}
+	err := engine.StoreMeterValues(context.Background(), "cs001", 1, "", []store.MeterValue{
+		meterValue("2026-01-01T10:01:00Z", &energy, "Wh", 1000),
+		meterValue("2026-01-01T10:04:00Z", &energy, "Wh", 1100),
+		meterValue("2026-01-01T10:06:00Z", &energy, "kWh", 1.25),
+		meterValue("2026-01-01T10:07:00Z", &power, "kW", 7.2),
+		meterValue("2026-01-01T10:21:00Z", &energy, "Wh", 1600),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/meter-values?interval=5m&startTime=2026-01-01T10:05:00Z", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var got api.MeterValuesResponse
+	err = json.NewDecoder(rr.Result().Body).Decode(&got)
+	require.NoError(t, err)
+
+	interval := api.N5m
+	float := func(v float64) *float64 { return &v }
+	want := api.MeterValuesResponse{
+		MeterValues: []api.MeterValue{},
+		Interval:    &interval,
+		Rollups: &[]api.MeterValueRollup{
+			{
+				EvseId:      1,
+				Start:       time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC),
+				End:         time.Date(2026, 1, 1, 10, 10, 0, 0, time.UTC),
+				SampleCount: 2,
+				// includes the energy imported since the last reading of the previous bucket
+				EnergyDelta: float(150),
+				MaxPower:    float(7200),
+			},
+			{
+				EvseId:      1,
+				Start:       time.Date(2026, 1, 1, 10, 20, 0, 0, time.UTC),
+				End:         time.Date(2026, 1, 1, 10, 25, 0, 0, time.UTC),
+				SampleCount: 1,
+				EnergyDelta: float(0),
+			},
+		},
+		Total:  2,
+		Limit:  100,
+		Offset: 0,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestGetMeterValueRollupsRejectsTransactionFilter(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/meter-values?interval=1h&transactionId=tx001", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestGetMeterValueRollupsRejectsUnknownInterval(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/cs/cs001/meter-values?interval=15m", nil)
+	req.Header.Set("accept", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
@@ -4,11 +4,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/render"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
@@ -197,13 +203,19 @@ func (s *Server) GetTransactionDetails(w http.ResponseWriter, r *http.Request, c
 	_ = render.Render(w, r, response)
 }
 
-func (s *Server) RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string) {
+func (s *Server) RemoteStartTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStartTransactionParams) {
 	req := new(RemoteStartTransactionRequest)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
+	wait, err := waitTime(params.Wait)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
 	runtime, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -235,22 +247,79 @@ func (s *Server) RemoteStartTransaction(w http.ResponseWriter, r *http.Request,
 		RequestType:     store.RemoteTransactionRequestTypeStart,
 	}
 
+	if wait != 0 && req.ChargingProfile != nil && supportsOcpp201Messages(runtime.OcppVersion) {
+		_ = render.Render(w, r, ErrInvalidRequest(errors.New("unable to wait for a remote start with a charging profile on an OCPP 2.x charge station")))
+		return
+	}
+
+	err = s.store.SetRemoteStartTransactionRequest(r.Context(), csId, transactionReq)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	if wait == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	var ocppReq ocpp.Request
+	if supportsOcpp201Messages(runtime.OcppVersion) {
+		ocppReq = &ocpp201.RequestStartTransactionRequestJson{
+			EvseId: req.ConnectorId,
+			IdToken: ocpp201.IdTokenType{
+				IdToken: req.IdTag,
+				Type:    ocpp201.IdTokenEnumTypeCentral,
+			},
+			//#nosec G404 - the remote start id only needs to distinguish between requests
+			RemoteStartId: int(rand.Int31()),
+		}
+	} else {
+		v16Req := &ocpp16.RemoteStartTransactionJson{
+			ConnectorId: req.ConnectorId,
+			IdTag:       req.IdTag,
+		}
+		if chargingProfileJSON != nil {
+			err = json.Unmarshal([]byte(*chargingProfileJSON), &v16Req.ChargingProfile)
+			if err != nil {
+				_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("invalid charging profile: %w", err)))
+				return
+			}
+		}
+		ocppReq = v16Req
+	}
+
+	answer := s.callAndWait(w, r, csId, runtime.OcppVersion, ocppReq, wait)
+	if answer == nil {
+		return
+	}
+
+	transactionReq.Status = store.RemoteTransactionRequestStatusRejected
+	if answerAccepted(answer) {
+		transactionReq.Status = store.RemoteTransactionRequestStatusAccepted
+	}
 	err = s.store.SetRemoteStartTransactionRequest(r.Context(), csId, transactionReq)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	renderAnswer(w, r, answer)
 }
 
-func (s *Server) RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string) {
+func (s *Server) RemoteStopTransaction(w http.ResponseWriter, r *http.Request, csId string, params RemoteStopTransactionParams) {
 	req := new(RemoteStopTransactionRequest)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, ErrInvalidRequest(err))
 		return
 	}
 
+	wait, err := waitTime(params.Wait)
+	if err != nil {
+		_ = render.Render(w, r, ErrInvalidRequest(err))
+		return
+	}
+
 	runtime, err := s.store.LookupChargeStationRuntimeDetails(r.Context(), csId)
 	if err != nil {
 		_ = render.Render(w, r, ErrInternalError(err))
@@ -275,7 +344,43 @@ func (s *Server) RemoteStopTransaction(w http.ResponseWriter, r *http.Request, c
 		return
 	}
 
-	w.WriteHeader(http.StatusAccepted)
+	if wait == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	var ocppReq ocpp.Request
+	if supportsOcpp201Messages(runtime.OcppVersion) {
+		ocppReq = &ocpp201.RequestStopTransactionRequestJson{
+			TransactionId: req.TransactionId,
+		}
+	} else {
+		transactionId, err := strconv.Atoi(req.TransactionId)
+		if err != nil {
+			_ = render.Render(w, r, ErrInvalidRequest(fmt.Errorf("OCPP 1.6 transaction ids are integers: %w", err)))
+			return
+		}
+		ocppReq = &ocpp16.RemoteStopTransactionJson{
+			TransactionId: transactionId,
+		}
+	}
+
+	answer := s.callAndWait(w, r, csId, runtime.OcppVersion, ocppReq, wait)
+	if answer == nil {
+		return
+	}
+
+	transactionReq.Status = store.RemoteTransactionRequestStatusRejected
+	if answerAccepted(answer) {
+		transactionReq.Status = store.RemoteTransactionRequestStatusAccepted
+	}
+	err = s.store.SetRemoteStopTransactionRequest(r.Context(), csId, transactionReq)
+	if err != nil {
+		_ = render.Render(w, r, ErrInternalError(err))
+		return
+	}
+
+	renderAnswer(w, r, answer)
 }
 
 func toRendererList(response []ConnectorStatusResponse) []render.Renderer {
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var deadLetterApiUrl string
+
+// deadLetterCmd represents the dead-letter command
+var deadLetterCmd = &cobra.Command{
+	Use:   "dead-letter",
+	Short: "Inspect and re-drive the messages the manager was unable to process",
+	Long: `Inspect and re-drive the messages the manager was unable to process.
+The commands use the API of a running manager.`,
+}
+
+func init() {
+	rootCmd.AddCommand(deadLetterCmd)
+
+	deadLetterCmd.PersistentFlags().StringVar(&deadLetterApiUrl, "api-url", "http://localhost:9410/api/v0",
+		"The base URL of the manager API")
+}
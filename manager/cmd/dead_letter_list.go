@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+)
+
+var deadLetterListLimit int
+
+// listDeadLettersCmd represents the dead-letter list command
+var listDeadLettersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the most recent dead letters",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		url := fmt.Sprintf("%s/dead-letters?limit=%d", deadLetterApiUrl, deadLetterListLimit)
+		//#nosec G107 - the URL is provided by the person running the application
+		resp, err := http.Get(url)
+		if err != nil {
+			return fmt.Errorf("listing dead letters: %w", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("listing dead letters: %s", resp.Status)
+		}
+
+		var deadLetters api.DeadLettersResponse
+		err = json.NewDecoder(resp.Body).Decode(&deadLetters)
+		if err != nil {
+			return fmt.Errorf("decoding dead letters: %w", err)
+		}
+
+		for _, deadLetter := range deadLetters.DeadLetters {
+			fmt.Printf("%s %s %s %s %s: %s\n", deadLetter.Id, deadLetter.CreatedAt.Format(time.RFC3339),
+				deadLetter.ChargeStationId, deadLetter.MessageType, deadLetter.Action, deadLetter.Error)
+		}
+		fmt.Printf("showing %d of %d dead letters\n", len(deadLetters.DeadLetters), deadLetters.Total)
+
+		return nil
+	},
+}
+
+func init() {
+	deadLetterCmd.AddCommand(listDeadLettersCmd)
+
+	listDeadLettersCmd.Flags().IntVar(&deadLetterListLimit, "limit", 50,
+		"The maximum number of dead letters to list")
+}
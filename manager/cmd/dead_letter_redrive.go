@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+)
+
+// redriveDeadLettersCmd represents the dead-letter redrive command
+var redriveDeadLettersCmd = &cobra.Command{
+	Use:   "redrive <dead-letter-id>...",
+	Short: "Re-drive dead letters",
+	Long: `Passes the messages held in the given dead letters back through the manager.
+Dead letters that are processed successfully are removed.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var failed int
+		for _, deadLetterId := range args {
+			err := redriveDeadLetter(deadLetterId)
+			if err != nil {
+				fmt.Printf("%s: %v\n", deadLetterId, err)
+				failed++
+			} else {
+				fmt.Printf("%s: OK\n", deadLetterId)
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d dead letters could not be re-driven", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func redriveDeadLetter(deadLetterId string) error {
+	//#nosec G107 - the URL is provided by the person running the application
+	resp, err := http.Post(fmt.Sprintf("%s/dead-letters/%s/redrive", deadLetterApiUrl, url.PathEscape(deadLetterId)), "", nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		var status api.Status
+		if err := json.NewDecoder(resp.Body).Decode(&status); err == nil && status.Error != nil {
+			return fmt.Errorf("%s: %s", resp.Status, *status.Error)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+func init() {
+	deadLetterCmd.AddCommand(redriveDeadLettersCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/api"
 	"github.com/thoughtworks/maeve-csms/manager/config"
 	"github.com/thoughtworks/maeve-csms/manager/server"
 	"github.com/thoughtworks/maeve-csms/manager/sync"
@@ -45,49 +46,69 @@ the gateway and send appropriate responses.`,
 		}()
 
 		apiServer := server.New("api", cfg.Api.Addr, nil,
-			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService))
+			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService,
+				api.WithCallCorrelation(settings.MsgEmitter, settings.Correlator),
+				api.WithRedrivers(settings.Redrivers)))
 
-		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter)
+		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter, settings.Retention)
 
-		errCh := make(chan error, 1)
+		// each of the api and ocpi servers reports at most one error
+		errCh := make(chan error, 2)
 		apiServer.Start(errCh)
-		var ocpp16Connection transport.Connection
-		if settings.Ocpp16Handler != nil {
-			ocpp16Connection, err = settings.MsgListener.Connect(context.Background(), transport.OcppVersion16, nil, settings.Ocpp16Handler)
-			if err != nil {
-				errCh <- err
+
+		// the connections are closed however serve returns, including when a later
+		// connection cannot be made
+		var connections []transport.Connection
+		defer func() {
+			for _, conn := range connections {
+				err := conn.Disconnect(context.Background())
+				if err != nil {
+					slog.Warn("disconnecting from broker", "err", err)
+				}
 			}
-		}
+		}()
 
-		var ocpp201Connection transport.Connection
-		if settings.Ocpp201Handler != nil {
-			ocpp201Connection, err = settings.MsgListener.Connect(context.Background(), transport.OcppVersion201, nil, settings.Ocpp201Handler)
+		for _, listener := range []struct {
+			ocppVersion transport.OcppVersion
+			handler     transport.MessageHandler
+		}{
+			{transport.OcppVersion16, settings.Ocpp16Handler},
+			{transport.OcppVersion201, settings.Ocpp201Handler},
+			{transport.OcppVersion21, settings.Ocpp21Handler},
+		} {
+			if listener.handler == nil {
+				continue
+			}
+			conn, err := settings.MsgListener.Connect(context.Background(), listener.ocppVersion, nil, listener.handler)
 			if err != nil {
-				errCh <- err
+				return err
 			}
+			connections = append(connections, conn)
 		}
 
-		if settings.OcpiApi != nil {
-			ocpiServer := server.New("ocpi", cfg.Ocpi.Addr, nil, server.NewOcpiHandler(settings.Storage, clock.RealClock{}, settings.OcpiApi, settings.MsgEmitter))
-			ocpiServer.Start(errCh)
-		}
-
-		err = <-errCh
-
-		if ocpp16Connection != nil {
-			err := ocpp16Connection.Disconnect(context.Background())
+		if settings.ConnectionEventHandler != nil {
+			conn, err := settings.MsgListener.ConnectEvents(context.Background(), settings.ConnectionEventHandler)
 			if err != nil {
-				slog.Warn("disconnecting from broker", "err", err)
+				return err
 			}
+			connections = append(connections, conn)
 		}
-		if ocpp201Connection != nil {
-			err := ocpp201Connection.Disconnect(context.Background())
+
+		// receive the answers to calls that other manager instances receive
+		if resultListener, ok := settings.MsgListener.(transport.ResultListener); ok {
+			conn, err := resultListener.ConnectResults(context.Background(), settings.Correlator)
 			if err != nil {
-				slog.Warn("disconnecting from broker", "err", err)
+				return err
 			}
+			connections = append(connections, conn)
+		}
+
+		if settings.OcpiApi != nil {
+			ocpiServer := server.New("ocpi", cfg.Ocpi.Addr, nil, server.NewOcpiHandler(settings.Storage, clock.RealClock{}, settings.OcpiApi, settings.MsgEmitter))
+			ocpiServer.Start(errCh)
 		}
 
-		return err
+		return <-errCh
 	},
 }
 
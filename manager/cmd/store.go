@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/archive"
+)
+
+var (
+	storeConfigFile  string
+	storeArchiveFile string
+)
+
+// storeCmd represents the store command
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Export and import the contents of the store",
+	Long: `Export and import the contents of the store.
+The store is configured by the storage section of the configuration file, so
+data can be moved between storage types by exporting it using the configuration
+for one storage type and importing it using the configuration for another.`,
+}
+
+func init() {
+	rootCmd.AddCommand(storeCmd)
+
+	storeCmd.PersistentFlags().StringVarP(&storeConfigFile, "config-file", "c", "/config/config.toml",
+		"The config file that configures the store")
+	storeCmd.PersistentFlags().StringVarP(&storeArchiveFile, "file", "f", "-",
+		"The archive file (- for standard output or input)")
+}
+
+// openStorage returns the store configured by the config file
+func openStorage(ctx context.Context) (store.Engine, error) {
+	cfg := config.DefaultConfig
+	if storeConfigFile != "" {
+		err := cfg.LoadFromFile(storeConfigFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return config.ConfigureStorage(ctx, &cfg)
+}
+
+// closeStorage releases the connections held by the stores that are backed by a database
+func closeStorage(engine store.Engine) {
+	switch s := engine.(type) {
+	case interface{ Close() error }:
+		_ = s.Close()
+	case interface{ Close() }:
+		s.Close()
+	}
+}
+
+// printCounts writes the number of records of each kind in kind order
+func printCounts(w io.Writer, counts archive.Counts) {
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		_, _ = fmt.Fprintf(w, "%s: %d\n", kind, counts[archive.Kind(kind)])
+	}
+	_, _ = fmt.Fprintf(w, "total: %d\n", counts.Total())
+}
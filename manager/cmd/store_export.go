@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/store/archive"
+)
+
+// storeExportCmd represents the store export command
+var storeExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export every record in the store to an archive",
+	Long: `Export every record in the store to a newline-delimited JSON archive.
+The manager should not be running while the store is exported.
+The number of records of each kind is written to standard error.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		engine, err := openStorage(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStorage(engine)
+
+		w := os.Stdout
+		if storeArchiveFile != "-" {
+			w, err = os.Create(storeArchiveFile)
+			if err != nil {
+				return fmt.Errorf("creating archive: %w", err)
+			}
+		}
+
+		counts, err := archive.Export(ctx, engine, w)
+		if w != os.Stdout {
+			if closeErr := w.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("writing archive: %w", closeErr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("exporting store: %w", err)
+		}
+
+		printCounts(os.Stderr, counts)
+		return nil
+	},
+}
+
+func init() {
+	storeCmd.AddCommand(storeExportCmd)
+}
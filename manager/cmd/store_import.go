@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/thoughtworks/maeve-csms/manager/store/archive"
+)
+
+var storeImportDryRun bool
+
+// storeImportCmd represents the store import command
+var storeImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the records in an archive into the store",
+	Long: `Import the records in an archive written by the export command into the store.
+The store is expected to be empty: records that already exist are overwritten.
+The number of records of each kind is written to standard error.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		var r io.Reader = os.Stdin
+		if storeArchiveFile != "-" {
+			f, err := os.Open(storeArchiveFile)
+			if err != nil {
+				return fmt.Errorf("opening archive: %w", err)
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			r = f
+		}
+
+		engine, err := openStorage(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStorage(engine)
+
+		var opts []archive.Option
+		if storeImportDryRun {
+			opts = append(opts, archive.WithDryRun())
+		}
+		counts, err := archive.Import(ctx, engine, r, opts...)
+		if err != nil {
+			return fmt.Errorf("importing archive: %w", err)
+		}
+
+		printCounts(os.Stderr, counts)
+		if storeImportDryRun {
+			_, _ = fmt.Fprintln(os.Stderr, "dry run: no records were written")
+		}
+		return nil
+	},
+}
+
+func init() {
+	storeCmd.AddCommand(storeImportCmd)
+
+	storeImportCmd.Flags().BoolVar(&storeImportDryRun, "dry-run", false,
+		"Read and count the records in the archive without writing them to the store")
+}
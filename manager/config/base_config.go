@@ -24,6 +24,7 @@ type BaseConfig struct {
 	ChargeStationCertProvider ChargeStationCertProviderConfig `mapstructure:"charge_station_cert_provider" toml:"charge_station_cert_provider" validate:"required"`
 	TariffService             TariffServiceConfig             `mapstructure:"tariff_service" toml:"tariff_service" validate:"required"`
 	Ocpi                      *OcpiConfig                     `mapstructure:"ocpi,omitempty" toml:"ocpi,omitempty"`
+	Retention                 RetentionConfig                 `mapstructure:"retention" toml:"retention" validate:"required"`
 }
 
 // DefaultConfig provides the default configuration. The configuration
@@ -46,11 +47,20 @@ var DefaultConfig = BaseConfig{
 			ConnectRetryDelay: "1s",
 			KeepAliveInterval: "10s",
 		},
+		Nats: &NatsSettingsConfig{
+			Urls:           []string{"nats://localhost:4222"},
+			Prefix:         "cs",
+			Group:          "manager",
+			Stream:         "csms",
+			ConnectTimeout: "10s",
+			ReconnectWait:  "1s",
+		},
 	},
 	Ocpp: OcppSettingsConfig{
 		HeartbeatInterval: "5m",
 		Ocpp16Enabled:     true,
 		Ocpp201Enabled:    true,
+		Ocpp21Enabled:     true,
 	},
 	Observability: ObservabilitySettingsConfig{
 		LogFormat: "text",
@@ -79,6 +89,10 @@ var DefaultConfig = BaseConfig{
 	TariffService: TariffServiceConfig{
 		Type: "kwh",
 	},
+	Retention: RetentionConfig{
+		RunEvery:  "1h",
+		BatchSize: 1000,
+	},
 }
 
 // Load reads TOML configuration from a reader.
@@ -34,11 +34,20 @@ func TestParseConfig(t *testing.T) {
 				ConnectRetryDelay: "1s",
 				KeepAliveInterval: "10s",
 			},
+			Nats: &config.NatsSettingsConfig{
+				Urls:           []string{"nats://localhost:4222"},
+				Prefix:         "cs",
+				Group:          "manager",
+				Stream:         "csms",
+				ConnectTimeout: "10s",
+				ReconnectWait:  "1s",
+			},
 		},
 		Ocpp: config.OcppSettingsConfig{
 			HeartbeatInterval: "10m",
 			Ocpp16Enabled:     false,
 			Ocpp201Enabled:    true,
+			Ocpp21Enabled:     true,
 		},
 		Observability: config.ObservabilitySettingsConfig{
 			LogFormat:         "text",
@@ -97,6 +106,12 @@ func TestParseConfig(t *testing.T) {
 		TariffService: config.TariffServiceConfig{
 			Type: "kwh",
 		},
+		Retention: config.RetentionConfig{
+			RunEvery:            "1h",
+			BatchSize:           1000,
+			MeterValues:         "2160h",
+			ChargeStationEvents: "8760h",
+		},
 	}
 
 	assert.Equal(t, want, cfg)
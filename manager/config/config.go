@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/subnova/slog-exporter/slogtrace"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
 	"github.com/thoughtworks/maeve-csms/manager/ocpi"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
 	"github.com/thoughtworks/maeve-csms/manager/services"
@@ -21,8 +23,12 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
 	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/store/postgres"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/inprocess"
 	mqtt2 "github.com/thoughtworks/maeve-csms/manager/transport/mqtt"
+	nats2 "github.com/thoughtworks/maeve-csms/manager/transport/nats"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -53,13 +59,18 @@ type Config struct {
 	Storage                          store.Engine
 	MsgEmitter                       transport.Emitter
 	MsgListener                      transport.Listener
+	Correlator                       *handlers.Correlator
 	Ocpp16Handler                    transport.MessageHandler
 	Ocpp201Handler                   transport.MessageHandler
+	Ocpp21Handler                    transport.MessageHandler
+	Redrivers                        map[string]handlers.Redriver // indexed by OCPP version ("1.6", "2.0.1" or "2.1")
+	ConnectionEventHandler           transport.ConnectionEventHandler
 	ContractCertValidationService    services.CertificateValidationService
 	ContractCertProviderService      services.ContractCertificateProvider
 	ChargeStationCertProviderService services.ChargeStationCertificateProvider
 	TariffService                    services.TariffService
 	OcpiApi                          ocpi.Api
+	Retention                        sync.Retention
 }
 
 func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
@@ -97,6 +108,11 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.Retention, err = getRetention(&cfg.Retention)
+	if err != nil {
+		return nil, err
+	}
+
 	c.TracerProvider, err = getTracerProvider(ctx, cfg.Observability.OtelCollectorAddr)
 	if err != nil {
 		return nil, err
@@ -109,6 +125,16 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.MsgEmitter, err = getMsgEmitter(&cfg.Transport, c.Tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	// tell the gateway when the details it uses to authenticate charge stations change
+	if notifier, ok := c.MsgEmitter.(store.AuthChangeNotifier); ok {
+		c.Storage = store.NotifyAuthChanges(c.Storage, notifier)
+	}
+
 	c.ContractCertValidationService, err = getContractCertValidator(&cfg.ContractCertValidator, httpClient)
 	if err != nil {
 		return nil, err
@@ -129,15 +155,20 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
-	c.MsgEmitter, err = getMsgEmitter(&cfg.Transport, c.Tracer)
+	c.MsgListener, err = getMsgListener(&cfg.Transport, c.Tracer, c.MsgEmitter)
 	if err != nil {
 		return nil, err
 	}
 
-	c.MsgListener, err = getMsgListener(&cfg.Transport, c.Tracer)
-	if err != nil {
-		return nil, err
-	}
+	// answers to calls made by the CSMS are passed to the correlator so they can be awaited
+	c.Correlator = handlers.NewCorrelator(c.MsgEmitter, c.Storage)
+
+	// every call made by the CSMS is recorded as a command along with its answer
+	commandTracker := handlers.NewCommandTracker(c.MsgEmitter, c.Storage, c.Correlator, clock.RealClock{})
+	c.MsgEmitter = commandTracker
+
+	// messages that the routers fail to process are recorded as dead letters and can be re-driven
+	c.Redrivers = make(map[string]handlers.Redriver)
 
 	if cfg.Ocpp.Ocpp16Enabled {
 		c.Ocpp16Handler = ocpp16.NewRouter(c.MsgEmitter,
@@ -148,6 +179,8 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			c.ContractCertProviderService,
 			heartbeatInterval,
 			schemas.OcppSchemas)
+		c.Redrivers["1.6"] = c.Ocpp16Handler.(handlers.Redriver)
+		c.Ocpp16Handler = c.Correlator.ResolveAnswers(transport.OcppVersion16, commandTracker.TrackAnswers(c.Ocpp16Handler))
 	}
 	if cfg.Ocpp.Ocpp201Enabled {
 		c.Ocpp201Handler = ocpp201.NewRouter(c.MsgEmitter,
@@ -159,6 +192,25 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 			c.ContractCertProviderService,
 			heartbeatInterval,
 			schemas.OcppSchemas)
+		c.Redrivers["2.0.1"] = c.Ocpp201Handler.(handlers.Redriver)
+		c.Ocpp201Handler = c.Correlator.ResolveAnswers(transport.OcppVersion201, commandTracker.TrackAnswers(c.Ocpp201Handler))
+	}
+	if cfg.Ocpp.Ocpp21Enabled {
+		c.Ocpp21Handler = ocpp21.NewRouter(c.MsgEmitter,
+			clock.RealClock{},
+			c.Storage,
+			c.TariffService,
+			c.ContractCertValidationService,
+			c.ChargeStationCertProviderService,
+			c.ContractCertProviderService,
+			heartbeatInterval,
+			schemas.OcppSchemas)
+		c.Redrivers["2.1"] = c.Ocpp21Handler.(handlers.Redriver)
+		c.Ocpp21Handler = c.Correlator.ResolveAnswers(transport.OcppVersion21, commandTracker.TrackAnswers(c.Ocpp21Handler))
+	}
+
+	c.ConnectionEventHandler = handlers.ConnectionEventHandler{
+		StatusStore: c.Storage,
 	}
 
 	if cfg.Ocpi != nil {
@@ -203,6 +255,17 @@ func getHttpClient(keylogFile string) (*http.Client, error) {
 	return &http.Client{Transport: httpTransport}, nil
 }
 
+// ConfigureStorage returns the store.Engine that is configured by the storage section of the
+// configuration without configuring any of the other services
+func ConfigureStorage(ctx context.Context, cfg *BaseConfig) (store.Engine, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return getStorage(ctx, &cfg.Storage)
+}
+
 func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
 	switch cfg.Type {
 	case "firestore":
@@ -271,6 +334,11 @@ func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, e
 		if err != nil {
 			return nil, fmt.Errorf("create postgres storage: %w", err)
 		}
+	case "sqlite":
+		engine, err = sqlite.NewStore(ctx, cfg.SqliteStorage.Path, clock.RealClock{})
+		if err != nil {
+			return nil, fmt.Errorf("create sqlite storage: %w", err)
+		}
 	case "in_memory":
 		engine = inmemory.NewStore(clock.RealClock{})
 	default:
@@ -486,6 +554,31 @@ func getTariffService(cfg *TariffServiceConfig) (tariffService services.TariffSe
 	return
 }
 
+func getRetention(cfg *RetentionConfig) (retention sync.Retention, err error) {
+	retention.BatchSize = cfg.BatchSize
+	for _, duration := range []struct {
+		name   string
+		value  string
+		result *time.Duration
+	}{
+		{"run every", cfg.RunEvery, &retention.RunEvery},
+		{"meter values", cfg.MeterValues, &retention.MeterValues},
+		{"charge station events", cfg.ChargeStationEvents, &retention.ChargeStationEvents},
+		{"device reports", cfg.DeviceReports, &retention.DeviceReports},
+		{"transactions", cfg.Transactions, &retention.Transactions},
+	} {
+		// an empty retention period keeps the records forever
+		if duration.value == "" {
+			continue
+		}
+		*duration.result, err = time.ParseDuration(duration.value)
+		if err != nil {
+			return retention, fmt.Errorf("failed to parse retention %s: %s", duration.name, err)
+		}
+	}
+	return retention, nil
+}
+
 func getMsgEmitter(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Emitter, error) {
 	switch cfg.Type {
 	case "mqtt":
@@ -520,12 +613,33 @@ func getMsgEmitter(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Emi
 			mqtt2.WithOtelTracer[mqtt2.Emitter](tracer))
 
 		return mqttEmitter, nil
+	case "nats":
+		natsConnectTimeout, err := time.ParseDuration(cfg.Nats.ConnectTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats connect timeout: %w", err)
+		}
+
+		natsReconnectWait, err := time.ParseDuration(cfg.Nats.ReconnectWait)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats reconnect wait: %w", err)
+		}
+
+		natsEmitter := nats2.NewEmitter(
+			nats2.WithNatsUrls[nats2.Emitter](cfg.Nats.Urls),
+			nats2.WithNatsPrefix[nats2.Emitter](cfg.Nats.Prefix),
+			nats2.WithNatsStream[nats2.Emitter](cfg.Nats.Stream),
+			nats2.WithNatsConnectSettings[nats2.Emitter](natsConnectTimeout, natsReconnectWait),
+			nats2.WithOtelTracer[nats2.Emitter](tracer))
+
+		return natsEmitter, nil
+	case "in_process":
+		return inprocess.NewBroker(inprocess.WithOtelTracer(tracer)), nil
 	default:
 		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
 	}
 }
 
-func getMsgListener(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Listener, error) {
+func getMsgListener(cfg *TransportConfig, tracer oteltrace.Tracer, emitter transport.Emitter) (transport.Listener, error) {
 	switch cfg.Type {
 	case "mqtt":
 		var mqttUrls []*url.URL
@@ -561,6 +675,32 @@ func getMsgListener(cfg *TransportConfig, tracer oteltrace.Tracer) (transport.Li
 		}
 
 		return mqtt2.NewListener(opts...), nil
+	case "nats":
+		natsConnectTimeout, err := time.ParseDuration(cfg.Nats.ConnectTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats connect timeout: %w", err)
+		}
+
+		natsReconnectWait, err := time.ParseDuration(cfg.Nats.ReconnectWait)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nats reconnect wait: %w", err)
+		}
+
+		return nats2.NewListener(
+			nats2.WithNatsUrls[nats2.Listener](cfg.Nats.Urls),
+			nats2.WithNatsPrefix[nats2.Listener](cfg.Nats.Prefix),
+			nats2.WithNatsStream[nats2.Listener](cfg.Nats.Stream),
+			nats2.WithNatsConnectSettings[nats2.Listener](natsConnectTimeout, natsReconnectWait),
+			nats2.WithNatsGroup[nats2.Listener](cfg.Nats.Group),
+			nats2.WithOtelTracer[nats2.Listener](tracer),
+		), nil
+	case "in_process":
+		// messages are received from the same broker that they are emitted to
+		broker, ok := emitter.(*inprocess.Broker)
+		if !ok {
+			return nil, fmt.Errorf("in_process transport requires an in_process emitter")
+		}
+		return broker, nil
 	default:
 		return nil, fmt.Errorf("unknown transport type: %s", cfg.Type)
 	}
@@ -5,12 +5,15 @@ package config_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	clone "github.com/huandu/go-clone/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
 )
 
 func TestConfigure(t *testing.T) {
@@ -36,6 +39,7 @@ func TestConfigure(t *testing.T) {
 	assert.NotNil(t, settings.MsgListener)
 	assert.NotNil(t, settings.Ocpp16Handler)
 	assert.NotNil(t, settings.Ocpp201Handler)
+	assert.NotNil(t, settings.Ocpp21Handler)
 	assert.NotNil(t, settings.ContractCertValidationService)
 	assert.NotNil(t, settings.ContractCertProviderService)
 	assert.NotNil(t, settings.ChargeStationCertProviderService)
@@ -67,6 +71,28 @@ func TestConfigureInMemoryStorage(t *testing.T) {
 	require.NotNil(t, settings.Storage)
 }
 
+func TestConfigureSqliteStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "csms.db"),
+	}
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureSqliteStorageRequiresPath(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Storage.Type = "sqlite"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.Error(t, err)
+}
+
 func TestConfigureOcspContractCertValidator(t *testing.T) {
 	cfg := clone.Clone(&config.DefaultConfig)
 	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
@@ -257,3 +283,30 @@ func TestConfigureKwHTariffService(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, settings.ContractCertProviderService)
 }
+
+func TestConfigureRetention(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Retention.MeterValues = "2160h"
+	cfg.Retention.Transactions = "8760h"
+
+	settings, err := config.Configure(context.TODO(), cfg)
+	require.NoError(t, err)
+
+	want := sync.Retention{
+		MeterValues:  90 * 24 * time.Hour,
+		Transactions: 365 * 24 * time.Hour,
+		RunEvery:     time.Hour,
+		BatchSize:    1000,
+	}
+	assert.Equal(t, want, settings.Retention)
+}
+
+func TestConfigureRetentionRejectsInvalidPeriod(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.ContractCertValidator.Ocsp.RootCertProvider.File.FileNames = []string{"testdata/root_ca.pem"}
+	cfg.Retention.DeviceReports = "30 days"
+
+	_, err := config.Configure(context.TODO(), cfg)
+	assert.ErrorContains(t, err, "retention device reports")
+}
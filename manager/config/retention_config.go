@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+type RetentionConfig struct {
+	RunEvery            string `mapstructure:"run_every" toml:"run_every" validate:"required"`
+	BatchSize           int    `mapstructure:"batch_size" toml:"batch_size" validate:"min=1"`
+	MeterValues         string `mapstructure:"meter_values,omitempty" toml:"meter_values,omitempty"`
+	ChargeStationEvents string `mapstructure:"charge_station_events,omitempty" toml:"charge_station_events,omitempty"`
+	DeviceReports       string `mapstructure:"device_reports,omitempty" toml:"device_reports,omitempty"`
+	Transactions        string `mapstructure:"transactions,omitempty" toml:"transactions,omitempty"`
+}
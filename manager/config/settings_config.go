@@ -12,8 +12,9 @@ type ApiSettingsConfig struct {
 
 type OcppSettingsConfig struct {
 	HeartbeatInterval string `mapstructure:"heartbeat_interval" toml:"heartbeat_interval" validate:"required"`
-	Ocpp16Enabled     bool   `mapstructure:"ocpp16_enabled" toml:"ocpp16_enabled" validate:"required_without=Ocpp201Enabled"`
-	Ocpp201Enabled    bool   `mapstructure:"ocpp201_enabled" toml:"ocpp201_enabled" validate:"required_without=Ocpp16Enabled"`
+	Ocpp16Enabled     bool   `mapstructure:"ocpp16_enabled" toml:"ocpp16_enabled" validate:"required_without_all=Ocpp201Enabled Ocpp21Enabled"`
+	Ocpp201Enabled    bool   `mapstructure:"ocpp201_enabled" toml:"ocpp201_enabled" validate:"required_without_all=Ocpp16Enabled Ocpp21Enabled"`
+	Ocpp21Enabled     bool   `mapstructure:"ocpp21_enabled" toml:"ocpp21_enabled" validate:"required_without_all=Ocpp16Enabled Ocpp201Enabled"`
 }
 
 type ObservabilitySettingsConfig struct {
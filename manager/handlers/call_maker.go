@@ -5,6 +5,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -19,26 +20,56 @@ type OcppCallMaker struct {
 	Emitter     transport.Emitter       // used to send the message to the charge station
 	OcppVersion transport.OcppVersion   // identifies the OCPP version that the messages are for
 	Actions     map[reflect.Type]string // the OCPP Action associated with a specific ocpp.Request object
+	Correlator  *Correlator             // used to await the answer to a call (only required by Call)
 }
 
 func (b OcppCallMaker) Send(ctx context.Context, chargeStationId string, request ocpp.Request) error {
+	msg, err := b.newCall(request)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("sending message", "action", msg.Action, "chargeStationId", chargeStationId)
+	return b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+}
+
+func (b OcppCallMaker) Call(ctx context.Context, chargeStationId string, request ocpp.Request) (*transport.Message, error) {
+	if b.Correlator == nil {
+		return nil, errors.New("no correlator configured to await answers")
+	}
+
+	msg, err := b.newCall(request)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := b.Correlator.Expect(chargeStationId, msg.MessageId)
+	defer pending.Cancel()
+
+	slog.Info("sending message", "action", msg.Action, "chargeStationId", chargeStationId)
+	err = b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return pending.Await(ctx)
+}
+
+func (b OcppCallMaker) newCall(request ocpp.Request) (*transport.Message, error) {
 	action, ok := b.Actions[reflect.TypeOf(request)]
 	if !ok {
-		return fmt.Errorf("unknown request type: %T", request)
+		return nil, fmt.Errorf("unknown request type: %T", request)
 	}
 
 	requestBytes, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	msg := &transport.Message{
+	return &transport.Message{
 		MessageType:    transport.MessageTypeCall,
 		MessageId:      uuid.New().String(),
 		Action:         action,
 		RequestPayload: requestBytes,
-	}
-
-	slog.Info("sending message", "action", msg.Action, "chargeStationId", chargeStationId)
-	return b.Emitter.Emit(ctx, b.OcppVersion, chargeStationId, msg)
+	}, nil
 }
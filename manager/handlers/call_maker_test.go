@@ -7,11 +7,15 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
 )
 
 type FakeEmitter struct {
@@ -68,3 +72,64 @@ func TestCallMakerWithUnknownMessageType(t *testing.T) {
 	assert.ErrorContains(t, err, "unknown request type")
 	assert.Nil(t, emitter.msg)
 }
+
+// answeringEmitter answers every call by passing the answer to the correlator
+type answeringEmitter struct {
+	correlator *handlers.Correlator
+}
+
+func (e *answeringEmitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	e.correlator.Resolve(ctx, ocppVersion, chargeStationId, &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          message.Action,
+		MessageId:       message.MessageId,
+		RequestPayload:  message.RequestPayload,
+		ResponsePayload: []byte(`{"status":"Accepted"}`),
+	})
+	return nil
+}
+
+func TestCallMakerCallAwaitsAnswer(t *testing.T) {
+	emitter := &answeringEmitter{}
+	emitter.correlator = handlers.NewCorrelator(emitter, inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now())))
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     emitter,
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+		Correlator: emitter.correlator,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	answer, err := callMaker.Call(ctx, "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, transport.MessageTypeCallResult, answer.MessageType)
+	assert.Equal(t, "Reset", answer.Action)
+	assert.JSONEq(t, `{"status":"Accepted"}`, string(answer.ResponsePayload))
+}
+
+func TestCallMakerCallTimesOutWithoutAnswer(t *testing.T) {
+	emitter := &FakeEmitter{}
+	callMaker := &handlers.OcppCallMaker{
+		Emitter:     emitter,
+		OcppVersion: transport.OcppVersion201,
+		Actions: map[reflect.Type]string{
+			reflect.TypeOf(&ocpp201.ResetRequestJson{}): "Reset",
+		},
+		Correlator: handlers.NewCorrelator(emitter, inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := callMaker.Call(ctx, "cs001", &ocpp201.ResetRequestJson{
+		Type: ocpp201.ResetEnumTypeImmediate,
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, emitter.called)
+}
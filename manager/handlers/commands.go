@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// acceptedStatuses are the values of the status field in a CallResult that mean that the
+// charge station has accepted the call
+var acceptedStatuses = map[string]bool{
+	"Accepted":         true,
+	"AcceptedCanceled": true,
+	"RebootRequired":   true,
+	"Scheduled":        true,
+	"Unlocked":         true,
+}
+
+// CommandTracker is a transport.Emitter that records each call made by the CSMS to a charge
+// station as a store.Command, using the call's message id as the command id. The answers
+// given by the charge stations are recorded by the transport.MessageHandler returned by
+// TrackAnswers. A command is marked as awaited if the correlator is expecting the answer
+// to its call, so that the answer can be shared with the instance that made the call.
+//
+// Recording is best effort: failures are logged and do not stop the call being sent.
+type CommandTracker struct {
+	emitter    transport.Emitter
+	store      store.CommandStore
+	correlator *Correlator
+	clock      clock.PassiveClock
+}
+
+// NewCommandTracker creates a CommandTracker that sends messages using emitter. The
+// correlator may be nil if no answers are awaited.
+func NewCommandTracker(emitter transport.Emitter, commandStore store.CommandStore, correlator *Correlator, clock clock.PassiveClock) *CommandTracker {
+	return &CommandTracker{
+		emitter:    emitter,
+		store:      commandStore,
+		correlator: correlator,
+		clock:      clock,
+	}
+}
+
+func (t *CommandTracker) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	if message.MessageType != transport.MessageTypeCall {
+		return t.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+	}
+
+	now := t.clock.Now()
+	err := t.store.CreateCommand(ctx, &store.Command{
+		Id:              message.MessageId,
+		ChargeStationId: chargeStationId,
+		OcppVersion:     strings.TrimPrefix(string(ocppVersion), "ocpp"),
+		Action:          message.Action,
+		Status:          store.CommandStatusQueued,
+		Request:         string(message.RequestPayload),
+		Awaited:         t.correlator != nil && t.correlator.Expecting(chargeStationId, message.MessageId),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	if err != nil {
+		slog.Warn("recording command", "chargeStationId", chargeStationId, "messageId", message.MessageId, "err", err)
+	}
+
+	err = t.emitter.Emit(ctx, ocppVersion, chargeStationId, message)
+	if err != nil {
+		errorDescription := err.Error()
+		t.setResult(ctx, chargeStationId, message.MessageId, &store.CommandResult{
+			Status:           store.CommandStatusFailed,
+			ErrorDescription: &errorDescription,
+			UpdatedAt:        t.clock.Now(),
+		})
+		return err
+	}
+
+	// the charge station may already have answered, in which case the command is not changed
+	err = t.store.SetCommandSent(ctx, message.MessageId, t.clock.Now())
+	if err != nil {
+		slog.Warn("recording command sent", "chargeStationId", chargeStationId, "messageId", message.MessageId, "err", err)
+	}
+	return nil
+}
+
+// TrackAnswers returns a transport.MessageHandler that passes each message to handler and
+// then records the answers to calls made by the CSMS against their commands
+func (t *CommandTracker) TrackAnswers(handler transport.MessageHandler) transport.MessageHandler {
+	return transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		handler.Handle(ctx, chargeStationId, msg)
+
+		switch msg.MessageType {
+		case transport.MessageTypeCallResult:
+			response := string(msg.ResponsePayload)
+			t.setResult(ctx, chargeStationId, msg.MessageId, &store.CommandResult{
+				Status:    callResultStatus(msg.ResponsePayload),
+				Response:  &response,
+				UpdatedAt: t.clock.Now(),
+			})
+		case transport.MessageTypeCallError:
+			errorCode := string(msg.ErrorCode)
+			errorDescription := msg.ErrorDescription
+			t.setResult(ctx, chargeStationId, msg.MessageId, &store.CommandResult{
+				Status:           store.CommandStatusFailed,
+				ErrorCode:        &errorCode,
+				ErrorDescription: &errorDescription,
+				UpdatedAt:        t.clock.Now(),
+			})
+		}
+	})
+}
+
+func (t *CommandTracker) setResult(ctx context.Context, chargeStationId, commandId string, result *store.CommandResult) {
+	err := t.store.SetCommandResult(ctx, chargeStationId, commandId, result)
+	if err != nil {
+		slog.Warn("recording command result", "chargeStationId", chargeStationId, "messageId", commandId, "err", err)
+	}
+}
+
+// callResultStatus works out whether a call was accepted from the status in its CallResult:
+// a CallResult without a status is treated as accepted
+func callResultStatus(responsePayload []byte) store.CommandStatus {
+	var response struct {
+		Status *string `json:"status"`
+	}
+	err := json.Unmarshal(responsePayload, &response)
+	if err == nil && response.Status != nil && !acceptedStatuses[*response.Status] {
+		return store.CommandStatusRejected
+	}
+	return store.CommandStatusAccepted
+}
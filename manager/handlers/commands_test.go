@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+type failingEmitter struct{}
+
+func (failingEmitter) Emit(context.Context, transport.OcppVersion, string, *transport.Message) error {
+	return errors.New("broker unavailable")
+}
+
+var resetCall = &transport.Message{
+	MessageType:    transport.MessageTypeCall,
+	Action:         "Reset",
+	MessageId:      "1234",
+	RequestPayload: []byte(`{"type":"Immediate"}`),
+}
+
+func TestCommandTrackerRecordsSentCall(t *testing.T) {
+	now := time.Now().UTC()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
+	emitter := &FakeEmitter{}
+	tracker := handlers.NewCommandTracker(emitter, engine, nil, clockTest.NewFakePassiveClock(now))
+
+	err := tracker.Emit(context.Background(), transport.OcppVersion201, "cs001", resetCall)
+	require.NoError(t, err)
+	assert.Equal(t, resetCall, emitter.msg)
+
+	command, err := engine.LookupCommand(context.Background(), "1234")
+	require.NoError(t, err)
+	want := &store.Command{
+		Id:              "1234",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusSent,
+		Request:         `{"type":"Immediate"}`,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	assert.Equal(t, want, command)
+}
+
+func TestCommandTrackerRecordsAwaitedCall(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	correlator := handlers.NewCorrelator(&FakeEmitter{}, engine)
+	tracker := handlers.NewCommandTracker(&FakeEmitter{}, engine, correlator, clockTest.NewFakePassiveClock(time.Now()))
+
+	pending := correlator.Expect("cs001", "1234")
+	defer pending.Cancel()
+
+	err := tracker.Emit(context.Background(), transport.OcppVersion201, "cs001", resetCall)
+	require.NoError(t, err)
+
+	command, err := engine.LookupCommand(context.Background(), "1234")
+	require.NoError(t, err)
+	require.NotNil(t, command)
+	assert.True(t, command.Awaited)
+}
+
+func TestCommandTrackerRecordsFailedCall(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	tracker := handlers.NewCommandTracker(failingEmitter{}, engine, nil, clockTest.NewFakePassiveClock(time.Now()))
+
+	err := tracker.Emit(context.Background(), transport.OcppVersion201, "cs001", resetCall)
+	require.Error(t, err)
+
+	command, err := engine.LookupCommand(context.Background(), "1234")
+	require.NoError(t, err)
+	require.NotNil(t, command)
+	assert.Equal(t, store.CommandStatusFailed, command.Status)
+	require.NotNil(t, command.ErrorDescription)
+	assert.Equal(t, "broker unavailable", *command.ErrorDescription)
+}
+
+func TestCommandTrackerDoesNotRecordOtherMessages(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	emitter := &FakeEmitter{}
+	tracker := handlers.NewCommandTracker(emitter, engine, nil, clockTest.NewFakePassiveClock(time.Now()))
+
+	err := tracker.Emit(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+	require.NoError(t, err)
+	assert.True(t, emitter.called)
+
+	command, err := engine.LookupCommand(context.Background(), "1234")
+	require.NoError(t, err)
+	assert.Nil(t, command)
+}
+
+func TestCommandTrackerRecordsAnswers(t *testing.T) {
+	tests := map[string]struct {
+		answer     *transport.Message
+		wantStatus store.CommandStatus
+	}{
+		"accepted": {
+			answer:     resetResult,
+			wantStatus: store.CommandStatusAccepted,
+		},
+		"rejected": {
+			answer: &transport.Message{
+				MessageType:     transport.MessageTypeCallResult,
+				Action:          "Reset",
+				MessageId:       "1234",
+				ResponsePayload: []byte(`{"status":"Rejected"}`),
+			},
+			wantStatus: store.CommandStatusRejected,
+		},
+		"no status": {
+			answer: &transport.Message{
+				MessageType:     transport.MessageTypeCallResult,
+				Action:          "GetConfiguration",
+				MessageId:       "1234",
+				ResponsePayload: []byte(`{"configurationKey":[]}`),
+			},
+			wantStatus: store.CommandStatusAccepted,
+		},
+		"error": {
+			answer: &transport.Message{
+				MessageType:      transport.MessageTypeCallError,
+				MessageId:        "1234",
+				ErrorCode:        transport.ErrorNotImplemented,
+				ErrorDescription: "not supported",
+			},
+			wantStatus: store.CommandStatusFailed,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+			tracker := handlers.NewCommandTracker(&FakeEmitter{}, engine, nil, clockTest.NewFakePassiveClock(time.Now()))
+
+			err := tracker.Emit(context.Background(), transport.OcppVersion201, "cs001", resetCall)
+			require.NoError(t, err)
+
+			var handled bool
+			handler := tracker.TrackAnswers(transport.MessageHandlerFunc(
+				func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+					handled = true
+				}))
+			handler.Handle(context.Background(), "cs001", tc.answer)
+			assert.True(t, handled)
+
+			command, err := engine.LookupCommand(context.Background(), "1234")
+			require.NoError(t, err)
+			require.NotNil(t, command)
+			assert.Equal(t, tc.wantStatus, command.Status)
+			if tc.answer.MessageType == transport.MessageTypeCallResult {
+				require.NotNil(t, command.Response)
+				assert.Equal(t, string(tc.answer.ResponsePayload), *command.Response)
+			} else {
+				require.NotNil(t, command.ErrorCode)
+				assert.Equal(t, string(tc.answer.ErrorCode), *command.ErrorCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// ConnectionEventHandler records the connection events published by the gateway
+// against the charge station's status.
+type ConnectionEventHandler struct {
+	StatusStore store.StatusStore
+}
+
+func (h ConnectionEventHandler) HandleConnectionEvent(ctx context.Context, event *transport.ConnectionEvent) {
+	span := trace.SpanFromContext(ctx)
+
+	var connected bool
+	switch event.Type {
+	case transport.ConnectionEventConnected:
+		connected = true
+	case transport.ConnectionEventDisconnected:
+		connected = false
+	default:
+		slog.Warn("unknown connection event type", "type", event.Type, "chargeStationId", event.ChargeStationId)
+		return
+	}
+
+	slog.Info("charge station "+string(event.Type),
+		"chargeStationId", event.ChargeStationId,
+		"protocol", event.Protocol,
+		"securityProfile", event.SecurityProfile,
+		"remoteAddr", event.RemoteAddr,
+		"closeCode", event.CloseCode,
+		"closeReason", event.CloseReason,
+		"gatewayId", event.GatewayId)
+
+	err := h.StatusStore.SetChargeStationConnected(ctx, event.ChargeStationId, connected, event.Timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to record connection event")
+		slog.Error("failed to record connection event", "err", err, "chargeStationId", event.ChargeStationId)
+	}
+}
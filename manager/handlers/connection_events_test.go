@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+func TestConnectionEventHandlerRecordsConnectAndDisconnect(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.ConnectionEventHandler{StatusStore: engine}
+
+	connectedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	disconnectedAt := connectedAt.Add(time.Hour)
+
+	handler.HandleConnectionEvent(ctx, &transport.ConnectionEvent{
+		Type:            transport.ConnectionEventConnected,
+		ChargeStationId: "cs001",
+		Timestamp:       connectedAt,
+	})
+
+	status, err := engine.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	assert.True(t, status.Connected)
+	require.NotNil(t, status.LastConnected)
+	assert.Equal(t, connectedAt, *status.LastConnected)
+	assert.Nil(t, status.LastDisconnected)
+
+	handler.HandleConnectionEvent(ctx, &transport.ConnectionEvent{
+		Type:            transport.ConnectionEventDisconnected,
+		ChargeStationId: "cs001",
+		CloseCode:       1001,
+		Timestamp:       disconnectedAt,
+	})
+
+	status, err = engine.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	assert.False(t, status.Connected)
+	require.NotNil(t, status.LastDisconnected)
+	assert.Equal(t, disconnectedAt, *status.LastDisconnected)
+	assert.Equal(t, connectedAt, *status.LastConnected)
+}
+
+func TestConnectionEventHandlerIgnoresOutOfOrderEvents(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.ConnectionEventHandler{StatusStore: engine}
+
+	connectedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// the disconnect from the previous connection arrives after the reconnect
+	handler.HandleConnectionEvent(ctx, &transport.ConnectionEvent{
+		Type:            transport.ConnectionEventConnected,
+		ChargeStationId: "cs001",
+		Timestamp:       connectedAt,
+	})
+	handler.HandleConnectionEvent(ctx, &transport.ConnectionEvent{
+		Type:            transport.ConnectionEventDisconnected,
+		ChargeStationId: "cs001",
+		Timestamp:       connectedAt.Add(-time.Second),
+	})
+
+	status, err := engine.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	assert.True(t, status.Connected)
+	assert.Nil(t, status.LastDisconnected)
+}
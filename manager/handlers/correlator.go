@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"golang.org/x/exp/slog"
+)
+
+// Correlator matches the answers (CallResults and CallErrors) that charge stations give to
+// calls made by the CSMS with the callers that are waiting for them.
+//
+// The answer to a call is received by whichever manager instance the broker chooses. When
+// the emitter is a transport.ResultEmitter, answers that nobody on this instance is waiting
+// for are shared with the other instances if the call's command shows that a caller is
+// waiting for it (see CommandTracker). The other instances should pass the answers they
+// receive from transport.ResultListener.ConnectResults to Handle.
+type Correlator struct {
+	emitter  transport.ResultEmitter
+	commands store.CommandStore
+
+	mu      sync.Mutex
+	pending map[correlationKey]chan *transport.Message
+}
+
+type correlationKey struct {
+	chargeStationId string
+	messageId       string
+}
+
+// NewCorrelator creates a Correlator that shares answers using emitter if it is a
+// transport.ResultEmitter. The commands are used to find out whether a caller is waiting for
+// an answer.
+func NewCorrelator(emitter transport.Emitter, commands store.CommandStore) *Correlator {
+	c := &Correlator{
+		commands: commands,
+		pending:  make(map[correlationKey]chan *transport.Message),
+	}
+	if resultEmitter, ok := emitter.(transport.ResultEmitter); ok {
+		c.emitter = resultEmitter
+	}
+	return c
+}
+
+// PendingCall is a call made by the CSMS whose answer is awaited
+type PendingCall struct {
+	correlator *Correlator
+	key        correlationKey
+	answerCh   chan *transport.Message
+}
+
+// Expect registers interest in the answer to the call with the given message id. It must
+// be called before the call is sent so that a quick answer is not missed.
+func (c *Correlator) Expect(chargeStationId, messageId string) *PendingCall {
+	key := correlationKey{chargeStationId: chargeStationId, messageId: messageId}
+	answerCh := make(chan *transport.Message, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[key] = answerCh
+
+	return &PendingCall{
+		correlator: c,
+		key:        key,
+		answerCh:   answerCh,
+	}
+}
+
+// Expecting returns true if a caller on this instance is waiting for the answer to the call
+func (c *Correlator) Expecting(chargeStationId, messageId string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.pending[correlationKey{chargeStationId: chargeStationId, messageId: messageId}]
+	return ok
+}
+
+// Await waits for the answer to the call until the context is done
+func (p *PendingCall) Await(ctx context.Context) (*transport.Message, error) {
+	select {
+	case msg := <-p.answerCh:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("awaiting answer to call %s: %w", p.key.messageId, ctx.Err())
+	}
+}
+
+// Cancel stops waiting for the answer to the call
+func (p *PendingCall) Cancel() {
+	p.correlator.mu.Lock()
+	defer p.correlator.mu.Unlock()
+	if p.correlator.pending[p.key] == p.answerCh {
+		delete(p.correlator.pending, p.key)
+	}
+}
+
+// Resolve passes an answer received from a charge station to the caller waiting for it. If
+// no caller on this instance is waiting then the answer is shared with the other instances,
+// but only if a caller on another instance may be waiting for it.
+func (c *Correlator) Resolve(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, msg *transport.Message) {
+	if msg.MessageType == transport.MessageTypeCall || c.deliver(chargeStationId, msg) || c.emitter == nil {
+		return
+	}
+	if !c.awaited(ctx, chargeStationId, msg.MessageId) {
+		return
+	}
+	err := c.emitter.EmitResult(ctx, ocppVersion, chargeStationId, msg)
+	if err != nil {
+		slog.Warn("sharing answer to call", "chargeStationId", chargeStationId, "messageId", msg.MessageId, "err", err)
+	}
+}
+
+// Handle passes an answer shared by another instance to the caller waiting for it, if they
+// are on this instance
+func (c *Correlator) Handle(_ context.Context, chargeStationId string, msg *transport.Message) {
+	c.deliver(chargeStationId, msg)
+}
+
+// ResolveAnswers returns a transport.MessageHandler that passes each message to handler and
+// then resolves the answers to calls made by the CSMS
+func (c *Correlator) ResolveAnswers(ocppVersion transport.OcppVersion, handler transport.MessageHandler) transport.MessageHandler {
+	return transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		handler.Handle(ctx, chargeStationId, msg)
+		c.Resolve(ctx, ocppVersion, chargeStationId, msg)
+	})
+}
+
+// awaited returns true if a caller was waiting for the answer to the call when it was made.
+// If the call's command cannot be looked up then the answer is shared so that it is not lost.
+func (c *Correlator) awaited(ctx context.Context, chargeStationId, messageId string) bool {
+	command, err := c.commands.LookupCommand(ctx, messageId)
+	if err != nil {
+		slog.Warn("looking up command for answer", "chargeStationId", chargeStationId, "messageId", messageId, "err", err)
+		return true
+	}
+	return command != nil && command.ChargeStationId == chargeStationId && command.Awaited
+}
+
+func (c *Correlator) deliver(chargeStationId string, msg *transport.Message) bool {
+	key := correlationKey{chargeStationId: chargeStationId, messageId: msg.MessageId}
+
+	c.mu.Lock()
+	answerCh, ok := c.pending[key]
+	delete(c.pending, key)
+	c.mu.Unlock()
+
+	if ok {
+		answerCh <- msg
+	}
+	return ok
+}
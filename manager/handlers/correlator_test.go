@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+type fakeResultEmitter struct {
+	FakeEmitter
+	results []*transport.Message
+}
+
+func (e *fakeResultEmitter) EmitResult(_ context.Context, _ transport.OcppVersion, _ string, message *transport.Message) error {
+	e.results = append(e.results, message)
+	return nil
+}
+
+var resetResult = &transport.Message{
+	MessageType:     transport.MessageTypeCallResult,
+	Action:          "Reset",
+	MessageId:       "1234",
+	RequestPayload:  []byte(`{"type":"Immediate"}`),
+	ResponsePayload: []byte(`{"status":"Accepted"}`),
+}
+
+// newCommandStore returns a store holding a Reset command sent to cs001
+func newCommandStore(t *testing.T, awaited bool) store.CommandStore {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	err := engine.CreateCommand(context.Background(), &store.Command{
+		Id:              "1234",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusSent,
+		Request:         `{"type":"Immediate"}`,
+		Awaited:         awaited,
+	})
+	require.NoError(t, err)
+	return engine
+}
+
+func TestCorrelatorDeliversAnswerToWaitingCaller(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, newCommandStore(t, true))
+
+	pending := correlator.Expect("cs001", "1234")
+	defer pending.Cancel()
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+
+	msg, err := pending.Await(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resetResult, msg)
+	assert.Empty(t, emitter.results)
+}
+
+func TestCorrelatorSharesAnswersNobodyIsWaitingFor(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, newCommandStore(t, true))
+
+	// a call with the same message id to a different charge station is not the same call
+	pending := correlator.Expect("cs002", "1234")
+	defer pending.Cancel()
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+
+	assert.Equal(t, []*transport.Message{resetResult}, emitter.results)
+}
+
+func TestCorrelatorDoesNotShareAnswersNobodyIsAwaiting(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, newCommandStore(t, false))
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+
+	assert.Empty(t, emitter.results)
+}
+
+func TestCorrelatorDoesNotShareAnswersToUnknownCommands(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now())))
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+
+	assert.Empty(t, emitter.results)
+}
+
+func TestCorrelatorDoesNotShareAnswersFromAnotherChargeStation(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, newCommandStore(t, true))
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs002", resetResult)
+
+	assert.Empty(t, emitter.results)
+}
+
+func TestCorrelatorExpecting(t *testing.T) {
+	correlator := handlers.NewCorrelator(&FakeEmitter{}, newCommandStore(t, false))
+
+	pending := correlator.Expect("cs001", "1234")
+	assert.True(t, correlator.Expecting("cs001", "1234"))
+	assert.False(t, correlator.Expecting("cs002", "1234"))
+
+	pending.Cancel()
+	assert.False(t, correlator.Expecting("cs001", "1234"))
+}
+
+func TestCorrelatorDeliversSharedAnswers(t *testing.T) {
+	correlator := handlers.NewCorrelator(&FakeEmitter{}, newCommandStore(t, true))
+
+	pending := correlator.Expect("cs001", "1234")
+	defer pending.Cancel()
+
+	correlator.Handle(context.Background(), "cs001", resetResult)
+
+	msg, err := pending.Await(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resetResult, msg)
+}
+
+func TestCorrelatorAwaitTimesOut(t *testing.T) {
+	correlator := handlers.NewCorrelator(&FakeEmitter{}, newCommandStore(t, true))
+
+	pending := correlator.Expect("cs001", "1234")
+	defer pending.Cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := pending.Await(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCorrelatorIgnoresAnswersAfterCancel(t *testing.T) {
+	emitter := &fakeResultEmitter{}
+	correlator := handlers.NewCorrelator(emitter, newCommandStore(t, true))
+
+	pending := correlator.Expect("cs001", "1234")
+	pending.Cancel()
+
+	correlator.Resolve(context.Background(), transport.OcppVersion201, "cs001", resetResult)
+
+	assert.Equal(t, []*transport.Message{resetResult}, emitter.results)
+}
+
+func TestCorrelatorResolvesAnswersPassedToHandler(t *testing.T) {
+	correlator := handlers.NewCorrelator(&FakeEmitter{}, newCommandStore(t, true))
+
+	pending := correlator.Expect("cs001", "1234")
+	defer pending.Cancel()
+
+	var handled bool
+	handler := correlator.ResolveAnswers(transport.OcppVersion201, transport.MessageHandlerFunc(
+		func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+			handled = true
+		}))
+	handler.Handle(context.Background(), "cs001", resetResult)
+
+	msg, err := pending.Await(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resetResult, msg)
+	assert.True(t, handled)
+}
@@ -8,14 +8,75 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
 	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/store"
+	"golang.org/x/exp/slog"
 )
 
 type MeterValuesHandler struct {
 	TransactionStore store.TransactionStore
+	MeterValuesStore store.MeterValuesStore
 }
 
 func (m MeterValuesHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (response ocpp.Response, err error) {
-	// TODO: store in transaction store
+	req := request.(*types.MeterValuesJson)
+
+	meterValues := convertMeterValuesRequest(req.MeterValue)
+
+	var transactionId string
+	if req.TransactionId != nil {
+		transactionId = ConvertToUUID(*req.TransactionId)
+		err = m.TransactionStore.UpdateTransaction(ctx, chargeStationId, transactionId, meterValues)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the connector id is used as the evse id: connector 0 is the main power meter
+	if m.MeterValuesStore != nil {
+		err = m.MeterValuesStore.StoreMeterValues(ctx, chargeStationId, req.ConnectorId, transactionId, meterValues)
+		if err != nil {
+			slog.Error("failed to store meter values", "charge_station_id", chargeStationId, "connector_id", req.ConnectorId, "error", err)
+		}
+	}
 
 	return &types.MeterValuesResponseJson{}, nil
 }
+
+// convertMeterValuesRequest converts the meter values in a MeterValues request. Sampled values
+// that are signed, or that cannot be parsed, are skipped rather than rejecting the whole message.
+func convertMeterValuesRequest(meterValues []types.MeterValuesJsonMeterValueElem) []store.MeterValue {
+	converted := make([]store.MeterValue, 0, len(meterValues))
+	for _, meterValue := range meterValues {
+		sampledValues := make([]store.SampledValue, 0, len(meterValue.SampledValue))
+		for _, sampledValue := range meterValue.SampledValue {
+			value, err := convertValue((*types.StopTransactionJsonTransactionDataElemSampledValueElemFormat)(sampledValue.Format), sampledValue.Value)
+			if err != nil {
+				slog.Warn("skipping sampled value", "timestamp", meterValue.Timestamp, "error", err)
+				continue
+			}
+			sampledValues = append(sampledValues, store.SampledValue{
+				Context:       (*string)(sampledValue.Context),
+				Location:      (*string)(sampledValue.Location),
+				Measurand:     (*string)(sampledValue.Measurand),
+				Phase:         (*string)(sampledValue.Phase),
+				UnitOfMeasure: convertMeterValuesUnit(sampledValue.Unit),
+				Value:         value,
+			})
+		}
+		converted = append(converted, store.MeterValue{
+			SampledValues: sampledValues,
+			Timestamp:     meterValue.Timestamp,
+		})
+	}
+	return converted
+}
+
+// convertMeterValuesUnit converts the unit of a sampled value. OCPP 1.6 does not have a
+// multiplier, so the power of ten that the stored value is multiplied by is always 0.
+func convertMeterValuesUnit(unit *types.MeterValuesJsonMeterValueElemSampledValueElemUnit) *store.UnitOfMeasure {
+	if unit == nil {
+		return nil
+	}
+	return &store.UnitOfMeasure{
+		Unit: string(*unit),
+	}
+}
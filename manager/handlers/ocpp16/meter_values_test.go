@@ -1,3 +1,110 @@
 // SPDX-License-Identifier: Apache-2.0
 
 package ocpp16_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	handlers "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp16"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+func TestMeterValuesHandlerStoresMeterValues(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.MeterValuesHandler{
+		TransactionStore: engine,
+		MeterValuesStore: engine,
+	}
+
+	measurand := types.MeterValuesJsonMeterValueElemSampledValueElemMeasurandEnergyActiveImportRegister
+	unit := types.MeterValuesJsonMeterValueElemSampledValueElemUnitKWh
+	signed := types.MeterValuesJsonMeterValueElemSampledValueElemFormatSignedData
+	transactionId := 42
+	req := &types.MeterValuesJson{
+		ConnectorId:   1,
+		TransactionId: &transactionId,
+		MeterValue: []types.MeterValuesJsonMeterValueElem{
+			{
+				Timestamp: "2026-01-01T10:01:00Z",
+				SampledValue: []types.MeterValuesJsonMeterValueElemSampledValueElem{
+					{Measurand: &measurand, Unit: &unit, Value: "1.5"},
+					{Measurand: &measurand, Format: &signed, Value: "c2lnbmVk"},
+				},
+			},
+		},
+	}
+
+	resp, err := handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.MeterValuesResponseJson{}, resp)
+
+	want := []store.MeterValue{
+		{
+			Timestamp: "2026-01-01T10:01:00Z",
+			SampledValues: []store.SampledValue{
+				{
+					Measurand:     (*string)(&measurand),
+					UnitOfMeasure: &store.UnitOfMeasure{Unit: "kWh"},
+					Value:         1.5,
+				},
+			},
+		},
+	}
+
+	transaction, err := engine.FindTransaction(ctx, "cs001", handlers.ConvertToUUID(transactionId))
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, want, transaction.MeterValues)
+
+	stored, err := engine.GetMeterValues(ctx, "cs001", 1, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, handlers.ConvertToUUID(transactionId), stored[0].TransactionId)
+	assert.Equal(t, want[0], stored[0].MeterValue)
+
+	rollups, err := engine.QueryMeterValueRollups(ctx, store.MeterValueRollupsFilter{
+		ChargeStationId: "cs001",
+		Interval:        store.MeterValueInterval5m,
+	})
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, 1500.0, *rollups[0].EnergyLast)
+}
+
+func TestMeterValuesHandlerStoresMeterValuesWithoutTransaction(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.MeterValuesHandler{
+		TransactionStore: engine,
+		MeterValuesStore: engine,
+	}
+
+	req := &types.MeterValuesJson{
+		ConnectorId: 0,
+		MeterValue: []types.MeterValuesJsonMeterValueElem{
+			{
+				Timestamp: "2026-01-01T10:01:00Z",
+				SampledValue: []types.MeterValuesJsonMeterValueElemSampledValueElem{
+					{Value: "1000"},
+				},
+			},
+		},
+	}
+
+	_, err := handler.HandleCall(ctx, "cs001", req)
+	require.NoError(t, err)
+
+	stored, err := engine.GetMeterValues(ctx, "cs001", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "", stored[0].TransactionId)
+}
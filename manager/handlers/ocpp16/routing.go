@@ -103,6 +103,7 @@ func NewRouter(emitter transport.Emitter,
 				ResponseSchema: "ocpp16/MeterValuesResponse.json",
 				Handler: MeterValuesHandler{
 					TransactionStore: engine,
+					MeterValuesStore: engine,
 				},
 			},
 			"SecurityEventNotification": {
@@ -475,6 +476,8 @@ func NewRouter(emitter transport.Emitter,
 				Handler:        GetLogResultHandler{},
 			},
 		},
+		DeadLetterStore: engine,
+		Clock:           clk,
 	}
 }
 
@@ -19,6 +19,9 @@ type BootNotificationHandler struct {
 	Clock               clock.PassiveClock
 	RuntimeDetailsStore store.ChargeStationRuntimeDetailsStore
 	HeartbeatInterval   int
+	// OcppVersion is the version recorded in the runtime details of the charge station:
+	// defaults to 2.0.1 so the handler can be shared with later versions of the protocol
+	OcppVersion string
 }
 
 func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
@@ -39,8 +42,13 @@ func (b BootNotificationHandler) HandleCall(ctx context.Context, chargeStationId
 		span.SetAttributes(attribute.String("boot.firmware", *req.ChargingStation.FirmwareVersion))
 	}
 
+	ocppVersion := b.OcppVersion
+	if ocppVersion == "" {
+		ocppVersion = "2.0.1"
+	}
+
 	err := b.RuntimeDetailsStore.SetChargeStationRuntimeDetails(ctx, chargeStationId, &store.ChargeStationRuntimeDetails{
-		OcppVersion: "2.0.1",
+		OcppVersion: ocppVersion,
 	})
 	if err != nil {
 		return nil, err
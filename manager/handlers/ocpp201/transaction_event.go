@@ -69,6 +69,10 @@ func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId
 		return nil, err
 	}
 
+	if len(req.MeterValue) > 0 {
+		t.storeMeterValues(ctx, chargeStationId, req)
+	}
+
 	if req.EventType == types.TransactionEventEnumTypeEnded {
 		transaction, err := t.Store.FindTransaction(ctx, chargeStationId, req.TransactionInfo.TransactionId)
 		if err != nil {
@@ -86,6 +90,37 @@ func (t TransactionEventHandler) HandleCall(ctx context.Context, chargeStationId
 	return response, nil
 }
 
+// storeMeterValues stores the meter values from the event with the meter values received in
+// MeterValues messages so that they are included in the meter value queries and rollups. The
+// EVSE is only sent in the first event after it is known, so later events use the EVSE of the
+// meter values that have already been stored for the transaction.
+func (t TransactionEventHandler) storeMeterValues(ctx context.Context, chargeStationId string, req *types.TransactionEventRequestJson) {
+	transactionId := req.TransactionInfo.TransactionId
+	var evseId int
+	if req.Evse != nil {
+		evseId = req.Evse.Id
+	} else {
+		result, err := t.Store.QueryMeterValues(ctx, store.MeterValuesFilter{
+			ChargeStationId: chargeStationId,
+			TransactionId:   &transactionId,
+			Limit:           1,
+		})
+		if err != nil {
+			slog.Error("failed to find evse for transaction meter values", "charge_station_id", chargeStationId, "transaction_id", transactionId, "error", err)
+			return
+		}
+		if len(result.MeterValues) == 0 {
+			slog.Warn("no evse known for transaction meter values", "charge_station_id", chargeStationId, "transaction_id", transactionId)
+			return
+		}
+		evseId = result.MeterValues[0].EvseId
+	}
+
+	if err := t.Store.StoreMeterValues(ctx, chargeStationId, evseId, transactionId, convertMeterValues(req.MeterValue)); err != nil {
+		slog.Error("failed to store meter values", "charge_station_id", chargeStationId, "evse_id", evseId, "error", err)
+	}
+}
+
 func convertMeterValues(meterValues []types.MeterValueType) []store.MeterValue {
 	var converted []store.MeterValue
 	for _, meterValue := range meterValues {
@@ -241,3 +241,68 @@ func TestTransactionEventHandlerWithEndedEvent(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, transaction)
 }
+
+func TestTransactionEventHandlerStoresMeterValuesForEvse(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	handler := handlers.TransactionEventHandler{
+		Store: engine,
+		TokenAuthService: &services.OcppTokenAuthService{
+			Clock:      clock.RealClock{},
+			TokenStore: engine,
+		},
+		TariffService: services.BasicKwhTariffService{},
+	}
+
+	meterValue := func(timestamp string, value float64) []types.MeterValueType {
+		return []types.MeterValueType{
+			{
+				Timestamp: timestamp,
+				SampledValue: []types.SampledValueType{
+					{
+						Measurand: makePtr(types.MeasurandEnumTypeEnergyActiveImportRegister),
+						Value:     value,
+					},
+				},
+			},
+		}
+	}
+
+	_, err := handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+		EventType:       types.TransactionEventEnumTypeStarted,
+		TriggerReason:   types.TriggerReasonEnumTypeCablePluggedIn,
+		Timestamp:       "2026-01-01T10:01:00Z",
+		Evse:            &types.EVSEType{Id: 2},
+		MeterValue:      meterValue("2026-01-01T10:01:00Z", 100),
+		TransactionInfo: types.TransactionType{TransactionId: "5555"},
+	})
+	require.NoError(t, err)
+
+	// the evse is not repeated in later events for the transaction
+	_, err = handler.HandleCall(ctx, "cs001", &types.TransactionEventRequestJson{
+		EventType:       types.TransactionEventEnumTypeUpdated,
+		TriggerReason:   types.TriggerReasonEnumTypeMeterValuePeriodic,
+		Timestamp:       "2026-01-01T10:02:00Z",
+		SeqNo:           1,
+		MeterValue:      meterValue("2026-01-01T10:02:00Z", 300),
+		TransactionInfo: types.TransactionType{TransactionId: "5555"},
+	})
+	require.NoError(t, err)
+
+	stored, err := engine.GetMeterValues(ctx, "cs001", 2, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for _, mv := range stored {
+		assert.Equal(t, "5555", mv.TransactionId)
+	}
+
+	rollups, err := engine.QueryMeterValueRollups(ctx, store.MeterValueRollupsFilter{
+		ChargeStationId: "cs001",
+		Interval:        store.MeterValueInterval5m,
+	})
+	require.NoError(t, err)
+	require.Len(t, rollups, 1)
+	assert.Equal(t, 2, rollups[0].EvseId)
+	assert.Equal(t, 200.0, *rollups[0].EnergyDelta(nil))
+}
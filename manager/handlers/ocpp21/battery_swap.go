@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// BatterySwapHandler handles the BatterySwap message sent by a battery swap station when
+// batteries are inserted or removed. The CSMS does not manage battery swaps, so the event
+// is only recorded.
+type BatterySwapHandler struct{}
+
+func (h BatterySwapHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.BatterySwapRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("battery_swap.event_type", string(req.EventType)),
+		attribute.Int("battery_swap.request_id", req.RequestId),
+		attribute.Int("battery_swap.battery_count", len(req.BatteryData)))
+
+	slog.Info("battery swap", "chargeStationId", chargeStationId,
+		"eventType", req.EventType, "requestId", req.RequestId, "batteries", len(req.BatteryData))
+
+	return &types.BatterySwapResponseJson{}, nil
+}
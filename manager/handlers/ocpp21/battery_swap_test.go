@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestBatterySwapHandler(t *testing.T) {
+	handler := ocpp21.BatterySwapHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.BatterySwapRequestJson{
+			BatteryData: []types.BatteryDataType{
+				{EvseId: 1, SerialNumber: "BAT-0001", SoC: 12, SoH: 90},
+				{EvseId: 2, SerialNumber: "BAT-0002", SoC: 15, SoH: 85},
+			},
+			EventType: types.BatterySwapEventEnumTypeBatteryIn,
+			IdToken:   types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+			RequestId: 42,
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.BatterySwapResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"battery_swap.event_type":    "BatteryIn",
+		"battery_swap.request_id":    42,
+		"battery_swap.battery_count": 2,
+	})
+}
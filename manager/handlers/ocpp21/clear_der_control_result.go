@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// ClearDERControlResultHandler handles the result of a ClearDERControl call, which
+// removes default or scheduled DER controls from a charge station.
+type ClearDERControlResultHandler struct{}
+
+func (h ClearDERControlResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.ClearDERControlRequestJson)
+	resp := response.(*types.ClearDERControlResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Bool("clear_der_control.is_default", req.IsDefault),
+		attribute.String("clear_der_control.status", string(resp.Status)))
+	if req.ControlType != nil {
+		span.SetAttributes(attribute.String("clear_der_control.control_type", string(*req.ControlType)))
+	}
+	if req.ControlId != nil {
+		span.SetAttributes(attribute.String("clear_der_control.control_id", *req.ControlId))
+	}
+
+	if resp.Status != types.DERControlStatusEnumTypeAccepted {
+		slog.Warn("clear DER control not accepted", statusLogAttrs(chargeStationId, string(resp.Status), resp.StatusInfo)...)
+	}
+
+	return nil
+}
+
+// statusLogAttrs returns the attributes used to log a status returned by a charge station
+func statusLogAttrs(chargeStationId, status string, statusInfo *types.StatusInfoType) []any {
+	logAttrs := []any{
+		"chargeStationId", chargeStationId,
+		"status", status,
+	}
+	if statusInfo != nil {
+		logAttrs = append(logAttrs, "reasonCode", statusInfo.ReasonCode)
+		if statusInfo.AdditionalInfo != nil {
+			logAttrs = append(logAttrs, "additionalInfo", *statusInfo.AdditionalInfo)
+		}
+	}
+	return logAttrs
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestClearDERControlResultHandler(t *testing.T) {
+	handler := ocpp21.ClearDERControlResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		controlType := types.DERControlEnumTypeFreqDroop
+		req := &types.ClearDERControlRequestJson{
+			IsDefault:   true,
+			ControlType: &controlType,
+		}
+		resp := &types.ClearDERControlResponseJson{
+			Status: types.DERControlStatusEnumTypeNotFound,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"clear_der_control.is_default":   true,
+		"clear_der_control.control_type": "FreqDroop",
+		"clear_der_control.status":       "NotFound",
+	})
+}
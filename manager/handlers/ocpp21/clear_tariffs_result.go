@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// ClearTariffsResultHandler handles the result of a ClearTariffs call, which removes
+// tariffs from a charge station.
+type ClearTariffsResultHandler struct{}
+
+func (h ClearTariffsResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.ClearTariffsRequestJson)
+	resp := response.(*types.ClearTariffsResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	if len(req.TariffIds) > 0 {
+		span.SetAttributes(attribute.StringSlice("clear_tariffs.tariff_ids", req.TariffIds))
+	}
+	if req.EvseId != nil {
+		span.SetAttributes(attribute.Int("clear_tariffs.evse_id", *req.EvseId))
+	}
+
+	var statuses []string
+	for _, result := range resp.ClearTariffsResult {
+		statuses = append(statuses, string(result.Status))
+		if result.Status == types.TariffClearStatusEnumTypeRejected {
+			logAttrs := statusLogAttrs(chargeStationId, string(result.Status), result.StatusInfo)
+			if result.TariffId != nil {
+				logAttrs = append(logAttrs, "tariffId", *result.TariffId)
+			}
+			slog.Warn("clear tariff rejected", logAttrs...)
+		}
+	}
+	span.SetAttributes(attribute.StringSlice("clear_tariffs.statuses", statuses))
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestClearTariffsResultHandler(t *testing.T) {
+	handler := ocpp21.ClearTariffsResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		tariff1, tariff2 := "tariff-1", "tariff-2"
+		req := &types.ClearTariffsRequestJson{
+			TariffIds: []string{tariff1, tariff2},
+		}
+		resp := &types.ClearTariffsResponseJson{
+			ClearTariffsResult: []types.ClearTariffsResultType{
+				{TariffId: &tariff1, Status: types.TariffClearStatusEnumTypeAccepted},
+				{TariffId: &tariff2, Status: types.TariffClearStatusEnumTypeRejected},
+			},
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"clear_tariffs.tariff_ids": func(v attribute.Value) bool {
+			return slices.Equal([]string{"tariff-1", "tariff-2"}, v.AsStringSlice())
+		},
+		"clear_tariffs.statuses": func(v attribute.Value) bool {
+			return slices.Equal([]string{"Accepted", "Rejected"}, v.AsStringSlice())
+		},
+	})
+}
@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocpp21 defines handlers for processing OCPP 2.1 messages. Messages that are
+// unchanged from OCPP 2.0.1 are processed by the handlers in the ocpp201 package.
+package ocpp21
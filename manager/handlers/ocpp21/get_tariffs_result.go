@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// GetTariffsResultHandler handles the result of a GetTariffs call, which reports the
+// tariffs that are assigned to the EVSEs of a charge station.
+type GetTariffsResultHandler struct{}
+
+func (h GetTariffsResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.GetTariffsRequestJson)
+	resp := response.(*types.GetTariffsResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("get_tariffs.evse_id", req.EvseId),
+		attribute.String("get_tariffs.status", string(resp.Status)),
+		attribute.Int("get_tariffs.tariff_count", len(resp.TariffAssignments)))
+
+	for _, assignment := range resp.TariffAssignments {
+		slog.Info("tariff assigned", "chargeStationId", chargeStationId,
+			"tariffId", assignment.TariffId, "tariffKind", assignment.TariffKind, "evseIds", assignment.EvseIds)
+	}
+
+	return nil
+}
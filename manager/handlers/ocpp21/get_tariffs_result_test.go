@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestGetTariffsResultHandler(t *testing.T) {
+	handler := ocpp21.GetTariffsResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.GetTariffsRequestJson{
+			EvseId: 0,
+		}
+		resp := &types.GetTariffsResponseJson{
+			Status: types.TariffGetStatusEnumTypeAccepted,
+			TariffAssignments: []types.TariffAssignmentType{
+				{TariffId: "tariff-1", TariffKind: types.TariffKindEnumTypeDefaultTariff, EvseIds: []int{1, 2}},
+			},
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"get_tariffs.evse_id":      0,
+		"get_tariffs.status":       "Accepted",
+		"get_tariffs.tariff_count": 1,
+	})
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// NotifyDERAlarmHandler handles the NotifyDERAlarm message sent by a charge station with
+// a distributed energy resource (e.g. a bidirectional EV) when a grid event causes a DER
+// control to raise or clear an alarm.
+type NotifyDERAlarmHandler struct{}
+
+func (h NotifyDERAlarmHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifyDERAlarmRequestJson)
+
+	alarmEnded := req.AlarmEnded != nil && *req.AlarmEnded
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("notify_der_alarm.control_type", string(req.ControlType)),
+		attribute.Bool("notify_der_alarm.alarm_ended", alarmEnded))
+	if req.GridEventFault != nil {
+		span.SetAttributes(attribute.String("notify_der_alarm.grid_event_fault", string(*req.GridEventFault)))
+	}
+
+	logAttrs := []any{
+		"chargeStationId", chargeStationId,
+		"controlType", req.ControlType,
+		"timestamp", req.Timestamp,
+	}
+	if req.GridEventFault != nil {
+		logAttrs = append(logAttrs, "gridEventFault", *req.GridEventFault)
+	}
+	if alarmEnded {
+		slog.Info("DER alarm ended", logAttrs...)
+	} else {
+		slog.Warn("DER alarm started", logAttrs...)
+	}
+
+	return &types.NotifyDERAlarmResponseJson{}, nil
+}
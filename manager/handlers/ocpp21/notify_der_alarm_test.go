@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestNotifyDERAlarmHandlerAlarmStarted(t *testing.T) {
+	handler := ocpp21.NotifyDERAlarmHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		fault := types.GridEventFaultEnumTypeOverVoltage
+		req := &types.NotifyDERAlarmRequestJson{
+			ControlType:    types.DERControlEnumTypeHVMustTrip,
+			GridEventFault: &fault,
+			Timestamp:      "2025-06-15T15:05:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERAlarmResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_der_alarm.control_type":     "HVMustTrip",
+		"notify_der_alarm.alarm_ended":      false,
+		"notify_der_alarm.grid_event_fault": "OverVoltage",
+	})
+}
+
+func TestNotifyDERAlarmHandlerAlarmEnded(t *testing.T) {
+	handler := ocpp21.NotifyDERAlarmHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		alarmEnded := true
+		req := &types.NotifyDERAlarmRequestJson{
+			ControlType: types.DERControlEnumTypeLVMustTrip,
+			AlarmEnded:  &alarmEnded,
+			Timestamp:   "2025-06-15T15:05:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERAlarmResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_der_alarm.control_type": "LVMustTrip",
+		"notify_der_alarm.alarm_ended":  true,
+	})
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NotifyDERStartStopHandler handles the NotifyDERStartStop message sent by a charge
+// station when a scheduled DER control starts or stops.
+type NotifyDERStartStopHandler struct{}
+
+func (h NotifyDERStartStopHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifyDERStartStopRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("notify_der_start_stop.control_id", req.ControlId),
+		attribute.Bool("notify_der_start_stop.started", req.Started))
+	if len(req.SupersededIds) > 0 {
+		span.SetAttributes(attribute.StringSlice("notify_der_start_stop.superseded_ids", req.SupersededIds))
+	}
+
+	return &types.NotifyDERStartStopResponseJson{}, nil
+}
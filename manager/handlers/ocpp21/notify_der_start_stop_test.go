@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestNotifyDERStartStopHandler(t *testing.T) {
+	handler := ocpp21.NotifyDERStartStopHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyDERStartStopRequestJson{
+			ControlId: "ctrl-2",
+			Started:   false,
+			Timestamp: "2025-06-15T15:05:00Z",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyDERStartStopResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_der_start_stop.control_id": "ctrl-2",
+		"notify_der_start_stop.started":    false,
+	})
+}
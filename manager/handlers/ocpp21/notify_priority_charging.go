@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NotifyPriorityChargingHandler handles the NotifyPriorityCharging message sent by a
+// charge station when priority charging of a transaction starts or stops.
+type NotifyPriorityChargingHandler struct{}
+
+func (h NotifyPriorityChargingHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifyPriorityChargingRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("notify_priority_charging.transaction_id", req.TransactionId),
+		attribute.Bool("notify_priority_charging.activated", req.Activated))
+
+	return &types.NotifyPriorityChargingResponseJson{}, nil
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestNotifyPriorityChargingHandler(t *testing.T) {
+	handler := ocpp21.NotifyPriorityChargingHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.NotifyPriorityChargingRequestJson{
+			Activated:     true,
+			TransactionId: "tx-1",
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifyPriorityChargingResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_priority_charging.transaction_id": "tx-1",
+		"notify_priority_charging.activated":      true,
+	})
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// NotifySettlementHandler handles the NotifySettlement message sent by a charge station
+// with a payment terminal once an ad hoc payment has been settled (or has failed). The
+// CSMS does not generate receipts, so the response does not contain a receipt.
+type NotifySettlementHandler struct{}
+
+func (h NotifySettlementHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.NotifySettlementRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("notify_settlement.psp_ref", req.PspRef),
+		attribute.String("notify_settlement.status", string(req.Status)),
+		attribute.Float64("notify_settlement.amount", req.SettlementAmount))
+	if req.TransactionId != nil {
+		span.SetAttributes(attribute.String("notify_settlement.transaction_id", *req.TransactionId))
+	}
+
+	logAttrs := []any{
+		"chargeStationId", chargeStationId,
+		"pspRef", req.PspRef,
+		"status", req.Status,
+		"amount", req.SettlementAmount,
+	}
+	if req.TransactionId != nil {
+		logAttrs = append(logAttrs, "transactionId", *req.TransactionId)
+	}
+	if req.Status == types.PaymentStatusEnumTypeSettled {
+		slog.Info("payment settled", logAttrs...)
+	} else {
+		slog.Warn("payment not settled", logAttrs...)
+	}
+
+	return &types.NotifySettlementResponseJson{}, nil
+}
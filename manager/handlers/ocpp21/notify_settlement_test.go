@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestNotifySettlementHandler(t *testing.T) {
+	handler := ocpp21.NotifySettlementHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		transactionId := "tx-1"
+		req := &types.NotifySettlementRequestJson{
+			PspRef:           "psp-1",
+			SettlementAmount: 12.5,
+			SettlementTime:   "2025-06-15T15:05:00Z",
+			Status:           types.PaymentStatusEnumTypeSettled,
+			TransactionId:    &transactionId,
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.NotifySettlementResponseJson{}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"notify_settlement.psp_ref":        "psp-1",
+		"notify_settlement.status":         "Settled",
+		"notify_settlement.amount":         12.5,
+		"notify_settlement.transaction_id": "tx-1",
+	})
+}
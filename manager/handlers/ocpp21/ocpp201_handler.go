@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+)
+
+// Ocpp201Handler handles an OCPP 2.1 message that extends an OCPP 2.0.1 message with the
+// OCPP 2.0.1 handler. OCPP 2.1 only adds optional fields and widens the values of existing
+// fields in these messages, so the request is converted through its JSON encoding, which
+// drops the fields that are only in OCPP 2.1, and the OCPP 2.0.1 response is converted to
+// the OCPP 2.1 response in the same way.
+type Ocpp201Handler struct {
+	Handler201    handlers.CallHandler
+	NewRequest201 func() ocpp.Request
+	NewResponse   func() ocpp.Response
+}
+
+func (h Ocpp201Handler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req201 := h.NewRequest201()
+	err := convert(request, req201)
+	if err != nil {
+		return nil, fmt.Errorf("converting %T to %T: %w", request, req201, err)
+	}
+
+	res201, err := h.Handler201.HandleCall(ctx, chargeStationId, req201)
+	if err != nil {
+		return nil, err
+	}
+
+	res := h.NewResponse()
+	err = convert(res201, res)
+	if err != nil {
+		return nil, fmt.Errorf("converting %T to %T: %w", res201, res, err)
+	}
+	return res, nil
+}
+
+func convert(from, to any) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
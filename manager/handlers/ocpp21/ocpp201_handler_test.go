@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+)
+
+func TestOcpp201HandlerConvertsRequestAndResponse(t *testing.T) {
+	var received *ocpp201.TransactionEventRequestJson
+	handler := ocpp21.Ocpp201Handler{
+		Handler201: handlers.CallHandlerFunc(func(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+			received = request.(*ocpp201.TransactionEventRequestJson)
+			return &ocpp201.TransactionEventResponseJson{
+				TotalCost: makePtr(3.0),
+				IdTokenInfo: &ocpp201.IdTokenInfoType{
+					Status: ocpp201.AuthorizationStatusEnumTypeAccepted,
+				},
+			}, nil
+		}),
+		NewRequest201: func() ocpp.Request { return new(ocpp201.TransactionEventRequestJson) },
+		NewResponse:   func() ocpp.Response { return new(types.TransactionEventResponseJson) },
+	}
+
+	resp, err := handler.HandleCall(context.Background(), "cs001", &types.TransactionEventRequestJson{
+		EventType: types.TransactionEventEnumTypeUpdated,
+		EvseSleep: makePtr(true),
+		IdToken:   &types.IdTokenType{IdToken: "DEADBEEF", Type: "MacAddress"},
+		MeterValue: []types.MeterValueType{
+			{
+				SampledValue: []types.SampledValueType{
+					{Measurand: makePtr(types.MeasurandEnumTypeDisplayPresentSOC), Value: 64},
+				},
+				Timestamp: "2025-06-15T15:05:00+01:00",
+			},
+		},
+		SeqNo:     1,
+		Timestamp: "2025-06-15T15:05:00+01:00",
+		TransactionInfo: types.TransactionType{
+			TransactionId: "tx-1",
+			TariffId:      makePtr("tariff-1"),
+		},
+		TriggerReason: types.TriggerReasonEnumTypeRunningCost,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "tx-1", received.TransactionInfo.TransactionId)
+	assert.Equal(t, ocpp201.IdTokenEnumType("MacAddress"), received.IdToken.Type)
+	assert.Equal(t, ocpp201.TriggerReasonEnumType("RunningCost"), received.TriggerReason)
+	assert.Equal(t, ocpp201.MeasurandEnumType("Display.PresentSOC"), *received.MeterValue[0].SampledValue[0].Measurand)
+
+	assert.Equal(t, &types.TransactionEventResponseJson{
+		TotalCost: makePtr(3.0),
+		IdTokenInfo: &types.IdTokenInfoType{
+			Status: types.AuthorizationStatusEnumTypeAccepted,
+		},
+	}, resp)
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PullDynamicScheduleUpdateHandler handles the PullDynamicScheduleUpdate message sent by a
+// charge station to get the latest values for a dynamic charging profile. The CSMS does not
+// set dynamic charging profiles, so every request is rejected.
+type PullDynamicScheduleUpdateHandler struct{}
+
+func (h PullDynamicScheduleUpdateHandler) HandleCall(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+	req := request.(*types.PullDynamicScheduleUpdateRequestJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("pull_dynamic_schedule_update.charging_profile_id", req.ChargingProfileId),
+		attribute.String("pull_dynamic_schedule_update.status", string(types.ChargingProfileStatusEnumTypeRejected)))
+
+	return &types.PullDynamicScheduleUpdateResponseJson{
+		Status: types.ChargingProfileStatusEnumTypeRejected,
+		StatusInfo: &types.StatusInfoType{
+			ReasonCode: "UnknownProfile",
+		},
+	}, nil
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestPullDynamicScheduleUpdateHandler(t *testing.T) {
+	handler := ocpp21.PullDynamicScheduleUpdateHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.PullDynamicScheduleUpdateRequestJson{
+			ChargingProfileId: 7,
+		}
+
+		resp, err := handler.HandleCall(ctx, "cs001", req)
+		require.NoError(t, err)
+
+		assert.Equal(t, &types.PullDynamicScheduleUpdateResponseJson{
+			Status: types.ChargingProfileStatusEnumTypeRejected,
+			StatusInfo: &types.StatusInfoType{
+				ReasonCode: "UnknownProfile",
+			},
+		}, resp)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"pull_dynamic_schedule_update.charging_profile_id": 7,
+		"pull_dynamic_schedule_update.status":              "Rejected",
+	})
+}
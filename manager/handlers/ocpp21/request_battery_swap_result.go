@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// RequestBatterySwapResultHandler handles the result of a RequestBatterySwap call, which
+// asks a battery swap station to release a battery to the holder of an id token.
+type RequestBatterySwapResultHandler struct{}
+
+func (h RequestBatterySwapResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.RequestBatterySwapRequestJson)
+	resp := response.(*types.RequestBatterySwapResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("request_battery_swap.request_id", req.RequestId),
+		attribute.String("request_battery_swap.status", string(resp.Status)))
+
+	if resp.Status != types.GenericStatusEnumTypeAccepted {
+		slog.Warn("battery swap request rejected", statusLogAttrs(chargeStationId, string(resp.Status), resp.StatusInfo)...)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestRequestBatterySwapResultHandler(t *testing.T) {
+	handler := ocpp21.RequestBatterySwapResultHandler{}
+
+	tests := []struct {
+		name   string
+		status types.GenericStatusEnumType
+	}{
+		{name: "accepted", status: types.GenericStatusEnumTypeAccepted},
+		{name: "rejected", status: types.GenericStatusEnumTypeRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer, exporter := testutil.GetTracer()
+
+			ctx := context.Background()
+
+			func() {
+				ctx, span := tracer.Start(ctx, "test")
+				defer span.End()
+
+				req := &types.RequestBatterySwapRequestJson{
+					IdToken:   types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+					RequestId: 42,
+				}
+				resp := &types.RequestBatterySwapResponseJson{
+					Status: tt.status,
+				}
+
+				err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+				require.NoError(t, err)
+			}()
+
+			testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+				"request_battery_swap.request_id": 42,
+				"request_battery_swap.status":     string(tt.status),
+			})
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"io/fs"
+	"reflect"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
+	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"k8s.io/utils/clock"
+)
+
+// unchangedCalls are the OCPP 2.0.1 messages sent by charge stations that are unchanged in
+// OCPP 2.1: they are routed to the OCPP 2.0.1 handlers and validated with the OCPP 2.0.1 schemas.
+var unchangedCalls = []string{
+	"DataTransfer",
+	"FirmwareStatusNotification",
+	"GetCertificateStatus",
+	"Heartbeat",
+	"LogStatusNotification",
+	"NotifyCustomerInformation",
+	"ReservationStatusUpdate",
+	"SecurityEventNotification",
+	"StatusNotification",
+}
+
+// unchangedCallResults are the OCPP 2.0.1 messages sent by the CSMS whose responses are
+// unchanged in OCPP 2.1 and whose OCPP 2.0.1 requests are valid OCPP 2.1 requests.
+var unchangedCallResults = []string{
+	"CertificateSigned",
+	"ChangeAvailability",
+	"ClearCache",
+	"ClearChargingProfile",
+	"ClearVariableMonitoring",
+	"CostUpdated",
+	"DeleteCertificate",
+	"GetBaseReport",
+	"GetChargingProfiles",
+	"GetDisplayMessages",
+	"GetLocalListVersion",
+	"GetLog",
+	"GetMonitoringReport",
+	"GetReport",
+	"GetTransactionStatus",
+	"GetVariables",
+	"InstallCertificate",
+	"RequestStartTransaction",
+	"RequestStopTransaction",
+	"Reset",
+	"SendLocalList",
+	"SetChargingProfile",
+	"SetMonitoringBase",
+	"SetMonitoringLevel",
+	"SetNetworkProfile",
+	"SetVariables",
+	"TriggerMessage",
+	"UnlockConnector",
+}
+
+// NewRouter creates the router for OCPP 2.1. Messages that are unchanged from OCPP 2.0.1
+// are routed to the OCPP 2.0.1 handlers. Messages that OCPP 2.1 extends are validated with
+// the OCPP 2.1 schemas and then handled by the OCPP 2.0.1 handlers. The other messages that
+// OCPP 2.1 changes, such as those that carry charging profiles, are not yet supported.
+func NewRouter(emitter transport.Emitter,
+	clk clock.PassiveClock,
+	engine store.Engine,
+	tariffService services.TariffService,
+	certValidationService services.CertificateValidationService,
+	chargeStationCertProvider services.ChargeStationCertificateProvider,
+	contractCertProvider services.ContractCertificateProvider,
+	heartbeatInterval time.Duration,
+	schemaFS fs.FS) transport.MessageHandler {
+
+	router201 := handlers201.NewRouter(emitter,
+		clk,
+		engine,
+		tariffService,
+		certValidationService,
+		chargeStationCertProvider,
+		contractCertProvider,
+		heartbeatInterval,
+		schemaFS).(*handlers.Router)
+
+	callRoutes := make(map[string]handlers.CallRoute)
+	for _, action := range unchangedCalls {
+		callRoutes[action] = router201.CallRoutes[action]
+	}
+	callResultRoutes := make(map[string]handlers.CallResultRoute)
+	for _, action := range unchangedCallResults {
+		callResultRoutes[action] = router201.CallResultRoutes[action]
+	}
+
+	callRoutes["Authorize"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.AuthorizeRequestJson) },
+		RequestSchema:  "ocpp21/AuthorizeRequest.json",
+		ResponseSchema: "ocpp21/AuthorizeResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["Authorize"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.AuthorizeRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.AuthorizeResponseJson) },
+		},
+	}
+	callRoutes["BootNotification"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.BootNotificationRequestJson) },
+		RequestSchema:  "ocpp21/BootNotificationRequest.json",
+		ResponseSchema: "ocpp21/BootNotificationResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201: handlers201.BootNotificationHandler{
+				Clock:               clk,
+				HeartbeatInterval:   int(heartbeatInterval.Seconds()),
+				RuntimeDetailsStore: engine,
+				OcppVersion:         "2.1",
+			},
+			NewRequest201: func() ocpp.Request { return new(ocpp201.BootNotificationRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.BootNotificationResponseJson) },
+		},
+	}
+	callRoutes["ClearedChargingLimit"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.ClearedChargingLimitRequestJson) },
+		RequestSchema:  "ocpp21/ClearedChargingLimitRequest.json",
+		ResponseSchema: "ocpp21/ClearedChargingLimitResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["ClearedChargingLimit"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.ClearedChargingLimitRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.ClearedChargingLimitResponseJson) },
+		},
+	}
+	callRoutes["Get15118EVCertificate"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.Get15118EVCertificateRequestJson) },
+		RequestSchema:  "ocpp21/Get15118EVCertificateRequest.json",
+		ResponseSchema: "ocpp21/Get15118EVCertificateResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["Get15118EVCertificate"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.Get15118EVCertificateRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.Get15118EVCertificateResponseJson) },
+		},
+	}
+	callRoutes["MeterValues"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.MeterValuesRequestJson) },
+		RequestSchema:  "ocpp21/MeterValuesRequest.json",
+		ResponseSchema: "ocpp21/MeterValuesResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["MeterValues"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.MeterValuesRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.MeterValuesResponseJson) },
+		},
+	}
+	callRoutes["NotifyEvent"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyEventRequestJson) },
+		RequestSchema:  "ocpp21/NotifyEventRequest.json",
+		ResponseSchema: "ocpp21/NotifyEventResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["NotifyEvent"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.NotifyEventRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.NotifyEventResponseJson) },
+		},
+	}
+	callRoutes["NotifyReport"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyReportRequestJson) },
+		RequestSchema:  "ocpp21/NotifyReportRequest.json",
+		ResponseSchema: "ocpp21/NotifyReportResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["NotifyReport"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.NotifyReportRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.NotifyReportResponseJson) },
+		},
+	}
+	callRoutes["SignCertificate"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.SignCertificateRequestJson) },
+		RequestSchema:  "ocpp21/SignCertificateRequest.json",
+		ResponseSchema: "ocpp21/SignCertificateResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["SignCertificate"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.SignCertificateRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.SignCertificateResponseJson) },
+		},
+	}
+	callRoutes["TransactionEvent"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.TransactionEventRequestJson) },
+		RequestSchema:  "ocpp21/TransactionEventRequest.json",
+		ResponseSchema: "ocpp21/TransactionEventResponse.json",
+		Handler: Ocpp201Handler{
+			Handler201:    router201.CallRoutes["TransactionEvent"].Handler,
+			NewRequest201: func() ocpp.Request { return new(ocpp201.TransactionEventRequestJson) },
+			NewResponse:   func() ocpp.Response { return new(ocpp21.TransactionEventResponseJson) },
+		},
+	}
+	callRoutes["BatterySwap"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.BatterySwapRequestJson) },
+		RequestSchema:  "ocpp21/BatterySwapRequest.json",
+		ResponseSchema: "ocpp21/BatterySwapResponse.json",
+		Handler:        BatterySwapHandler{},
+	}
+	callRoutes["NotifyDERAlarm"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyDERAlarmRequestJson) },
+		RequestSchema:  "ocpp21/NotifyDERAlarmRequest.json",
+		ResponseSchema: "ocpp21/NotifyDERAlarmResponse.json",
+		Handler:        NotifyDERAlarmHandler{},
+	}
+	callRoutes["NotifyDERStartStop"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyDERStartStopRequestJson) },
+		RequestSchema:  "ocpp21/NotifyDERStartStopRequest.json",
+		ResponseSchema: "ocpp21/NotifyDERStartStopResponse.json",
+		Handler:        NotifyDERStartStopHandler{},
+	}
+	callRoutes["NotifyPriorityCharging"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifyPriorityChargingRequestJson) },
+		RequestSchema:  "ocpp21/NotifyPriorityChargingRequest.json",
+		ResponseSchema: "ocpp21/NotifyPriorityChargingResponse.json",
+		Handler:        NotifyPriorityChargingHandler{},
+	}
+	callRoutes["NotifySettlement"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.NotifySettlementRequestJson) },
+		RequestSchema:  "ocpp21/NotifySettlementRequest.json",
+		ResponseSchema: "ocpp21/NotifySettlementResponse.json",
+		Handler:        NotifySettlementHandler{},
+	}
+	callRoutes["PullDynamicScheduleUpdate"] = handlers.CallRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.PullDynamicScheduleUpdateRequestJson) },
+		RequestSchema:  "ocpp21/PullDynamicScheduleUpdateRequest.json",
+		ResponseSchema: "ocpp21/PullDynamicScheduleUpdateResponse.json",
+		Handler:        PullDynamicScheduleUpdateHandler{},
+	}
+
+	callResultRoutes["ClearDERControl"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.ClearDERControlRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.ClearDERControlResponseJson) },
+		RequestSchema:  "ocpp21/ClearDERControlRequest.json",
+		ResponseSchema: "ocpp21/ClearDERControlResponse.json",
+		Handler:        ClearDERControlResultHandler{},
+	}
+	callResultRoutes["ClearTariffs"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.ClearTariffsRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.ClearTariffsResponseJson) },
+		RequestSchema:  "ocpp21/ClearTariffsRequest.json",
+		ResponseSchema: "ocpp21/ClearTariffsResponse.json",
+		Handler:        ClearTariffsResultHandler{},
+	}
+	callResultRoutes["GetTariffs"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.GetTariffsRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.GetTariffsResponseJson) },
+		RequestSchema:  "ocpp21/GetTariffsRequest.json",
+		ResponseSchema: "ocpp21/GetTariffsResponse.json",
+		Handler:        GetTariffsResultHandler{},
+	}
+	callResultRoutes["RequestBatterySwap"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.RequestBatterySwapRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.RequestBatterySwapResponseJson) },
+		RequestSchema:  "ocpp21/RequestBatterySwapRequest.json",
+		ResponseSchema: "ocpp21/RequestBatterySwapResponse.json",
+		Handler:        RequestBatterySwapResultHandler{},
+	}
+	callResultRoutes["SetDefaultTariff"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.SetDefaultTariffRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.SetDefaultTariffResponseJson) },
+		RequestSchema:  "ocpp21/SetDefaultTariffRequest.json",
+		ResponseSchema: "ocpp21/SetDefaultTariffResponse.json",
+		Handler:        SetDefaultTariffResultHandler{},
+	}
+	callResultRoutes["UsePriorityCharging"] = handlers.CallResultRoute{
+		NewRequest:     func() ocpp.Request { return new(ocpp21.UsePriorityChargingRequestJson) },
+		NewResponse:    func() ocpp.Response { return new(ocpp21.UsePriorityChargingResponseJson) },
+		RequestSchema:  "ocpp21/UsePriorityChargingRequest.json",
+		ResponseSchema: "ocpp21/UsePriorityChargingResponse.json",
+		Handler:        UsePriorityChargingResultHandler{},
+	}
+
+	return &handlers.Router{
+		Emitter:          emitter,
+		SchemaFS:         schemaFS,
+		OcppVersion:      transport.OcppVersion21,
+		CallRoutes:       callRoutes,
+		CallResultRoutes: callResultRoutes,
+		DeadLetterStore:  engine,
+		Clock:            clk,
+	}
+}
+
+// NewCallMaker creates the call maker for OCPP 2.1. It accepts the OCPP 2.0.1 request
+// types for the messages that the OCPP 2.1 router routes to the OCPP 2.0.1 handlers.
+func NewCallMaker(e transport.Emitter) *handlers.OcppCallMaker {
+	callMaker201 := handlers201.NewCallMaker(e)
+	unchanged := map[string]bool{
+		"CancelReservation": true,
+		"DataTransfer":      true,
+	}
+	for _, action := range unchangedCallResults {
+		unchanged[action] = true
+	}
+	actions := make(map[reflect.Type]string)
+	for typ, action := range callMaker201.Actions {
+		if unchanged[action] {
+			actions[typ] = action
+		}
+	}
+	actions[reflect.TypeOf(&ocpp21.ClearDERControlRequestJson{})] = "ClearDERControl"
+	actions[reflect.TypeOf(&ocpp21.ClearTariffsRequestJson{})] = "ClearTariffs"
+	actions[reflect.TypeOf(&ocpp21.GetTariffsRequestJson{})] = "GetTariffs"
+	actions[reflect.TypeOf(&ocpp21.RequestBatterySwapRequestJson{})] = "RequestBatterySwap"
+	actions[reflect.TypeOf(&ocpp21.SetDefaultTariffRequestJson{})] = "SetDefaultTariff"
+	actions[reflect.TypeOf(&ocpp21.UsePriorityChargingRequestJson{})] = "UsePriorityCharging"
+
+	return &handlers.OcppCallMaker{
+		Emitter:     e,
+		OcppVersion: transport.OcppVersion21,
+		Actions:     actions,
+	}
+}
@@ -0,0 +1,507 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp201"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/services"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/codes"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+type fakeEmitter struct {
+	Called          bool
+	OcppVersion     transport.OcppVersion
+	ChargeStationId string
+	Message         *transport.Message
+}
+
+func (f *fakeEmitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	f.Called = true
+	f.OcppVersion = ocppVersion
+	f.ChargeStationId = chargeStationId
+	f.Message = message
+	return nil
+}
+
+type fakeTariffService struct{}
+
+func (f fakeTariffService) CalculateCost(transaction *store.Transaction) (float64, error) {
+	return 42.0, nil
+}
+
+type fakeCertValidationService struct{}
+
+func (f fakeCertValidationService) ValidatePEMCertificateChain(ctx context.Context, pemChain []byte, eMAID string) (*string, error) {
+	return nil, nil
+}
+
+func (f fakeCertValidationService) ValidateHashedCertificateChain(ctx context.Context, ocspRequestData []ocpp201.OCSPRequestDataType) (*string, error) {
+	return nil, nil
+}
+
+type fakeChargeStationCertProvider struct{}
+
+func (f fakeChargeStationCertProvider) ProvideCertificate(ctx context.Context, typ services.CertificateType, pemEncodedCSR string, csId string) (pemEncodedCertificateChain string, err error) {
+	return "", nil
+}
+
+type fakeContractCertProvider struct{}
+
+func (f fakeContractCertProvider) ProvideCertificate(ctx context.Context, exiRequest string) (services.EvCertificate15118Response, error) {
+	return services.EvCertificate15118Response{
+		Status: ocpp201.Iso15118EVCertificateStatusEnumTypeAccepted,
+	}, nil
+}
+
+func makePtr[T any](t T) *T {
+	v := t
+	return &v
+}
+
+func newRouter(t *testing.T, engine store.Engine) transport.MessageHandler {
+	now, err := time.Parse(time.RFC3339, "2025-06-15T15:05:00+01:00")
+	require.NoError(t, err)
+	clock := clockTest.NewFakePassiveClock(now)
+
+	return ocpp21.NewRouter(&fakeEmitter{},
+		clock,
+		engine,
+		&fakeTariffService{},
+		&fakeCertValidationService{},
+		&fakeChargeStationCertProvider{},
+		&fakeContractCertProvider{},
+		5*time.Minute,
+		schemas.OcppSchemas,
+	)
+}
+
+func TestRoutingCalls(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	router := newRouter(t, engine)
+
+	inputMessages := map[string]ocpp.Request{
+		// unchanged from OCPP 2.0.1
+		"Heartbeat": &ocpp201.HeartbeatRequestJson{},
+		"StatusNotification": &ocpp201.StatusNotificationRequestJson{
+			ConnectorId:     2,
+			ConnectorStatus: ocpp201.ConnectorStatusEnumTypeOccupied,
+			EvseId:          1,
+			Timestamp:       "2025-06-15T15:05:00+01:00",
+		},
+		// extended in OCPP 2.1
+		"Authorize": &types.AuthorizeRequestJson{
+			IdToken: types.IdTokenType{IdToken: "DEADBEEF", Type: "MacAddress"},
+		},
+		"BootNotification": &types.BootNotificationRequestJson{
+			ChargingStation: types.ChargingStationType{
+				Model:      "Powergen",
+				VendorName: "Vendor",
+			},
+			Reason: types.BootReasonEnumTypePowerUp,
+		},
+		"ClearedChargingLimit": &types.ClearedChargingLimitRequestJson{
+			ChargingLimitSource: "CSO",
+			EvseId:              makePtr(1),
+		},
+		"Get15118EVCertificate": &types.Get15118EVCertificateRequestJson{
+			Action:                           types.CertificateActionEnumTypeInstall,
+			Iso15118SchemaVersion:            "urn:iso:std:iso:15118:-20:CommonMessages",
+			MaximumContractCertificateChains: makePtr(2),
+			PrioritizedEMAIDs:                []string{"EMP77TWTW00001"},
+		},
+		"MeterValues": &types.MeterValuesRequestJson{
+			EvseId: 1,
+			MeterValue: []types.MeterValueType{
+				{
+					SampledValue: []types.SampledValueType{
+						{
+							Measurand: makePtr(types.MeasurandEnumTypeDisplayPresentSOC),
+							Value:     64,
+						},
+						{
+							SignedMeterValue: &types.SignedMeterValueType{
+								EncodingMethod:  "OCMF",
+								SignedMeterData: "T0NNRnx7fQ==",
+							},
+							Value: 1200,
+						},
+					},
+					Timestamp: "2025-06-15T15:05:00+01:00",
+				},
+			},
+		},
+		"NotifyEvent": &types.NotifyEventRequestJson{
+			GeneratedAt: "2025-06-15T15:05:00+01:00",
+			EventData: []types.EventDataType{
+				{
+					EventId:               1,
+					Timestamp:             "2025-06-15T15:05:00+01:00",
+					Trigger:               types.EventTriggerEnumTypeAlerting,
+					ActualValue:           "OverCurrent",
+					EventNotificationType: types.EventNotificationEnumTypeCustomMonitor,
+					Component:             types.ComponentType{Name: "Connector"},
+					Variable:              types.VariableType{Name: "Current"},
+					Severity:              makePtr(2),
+				},
+			},
+		},
+		"NotifyReport": &types.NotifyReportRequestJson{
+			GeneratedAt: "2025-06-15T15:05:00+01:00",
+			RequestId:   33,
+			ReportData: []types.ReportDataType{
+				{
+					Component: types.ComponentType{Name: "OCPPCommCtrlr"},
+					Variable:  types.VariableType{Name: "MessageTimeout"},
+					VariableAttribute: []types.VariableAttributeType{
+						{Value: makePtr("30")},
+					},
+					VariableCharacteristics: &types.VariableCharacteristicsType{
+						DataType:           types.DataEnumTypeSequenceList,
+						MaxElements:        makePtr(3),
+						SupportsMonitoring: false,
+					},
+				},
+			},
+		},
+		"SignCertificate": &types.SignCertificateRequestJson{
+			CertificateType: makePtr(types.CertificateSigningUseEnumTypeV2G20Certificate),
+			Csr:             "-----BEGIN CERTIFICATE REQUEST-----\n-----END CERTIFICATE REQUEST-----",
+			RequestId:       makePtr(5),
+		},
+		"TransactionEvent": &types.TransactionEventRequestJson{
+			EventType: types.TransactionEventEnumTypeEnded,
+			IdToken:   &types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+			CostDetails: &types.CostDetailsType{
+				ChargingPeriods: []types.ChargingPeriodType{
+					{
+						StartPeriod: "2025-06-15T14:05:00+01:00",
+						Dimensions: []types.CostDimensionType{
+							{Type: types.CostDimensionEnumTypeEnergy, Volume: 12000},
+						},
+					},
+				},
+				TotalCost: types.TotalCostType{
+					Currency:   "EUR",
+					TypeOfCost: types.TariffCostEnumTypeNormalCost,
+					Energy:     &types.PriceType{ExclTax: makePtr(3.0)},
+					Total:      types.TotalPriceType{ExclTax: makePtr(3.0)},
+				},
+				TotalUsage: types.TotalUsageType{Energy: 12000, ChargingTime: 3600},
+			},
+			PreconditioningStatus: makePtr(types.PreconditioningStatusEnumTypeReady),
+			SeqNo:                 2,
+			Timestamp:             "2025-06-15T15:05:00+01:00",
+			TransactionInfo: types.TransactionType{
+				TransactionId:    "tx-1",
+				StoppedReason:    makePtr(types.ReasonEnumTypeCostLimitReached),
+				TariffId:         makePtr("tariff-1"),
+				TransactionLimit: &types.TransactionLimitType{MaxCost: makePtr(3.0)},
+			},
+			TriggerReason: types.TriggerReasonEnumTypeCostLimitReached,
+		},
+		// new in OCPP 2.1
+		"BatterySwap": &types.BatterySwapRequestJson{
+			BatteryData: []types.BatteryDataType{
+				{EvseId: 1, SerialNumber: "BAT-0001", SoC: 95, SoH: 98},
+			},
+			EventType: types.BatterySwapEventEnumTypeBatteryOut,
+			IdToken:   types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+			RequestId: 42,
+		},
+		"NotifyDERAlarm": &types.NotifyDERAlarmRequestJson{
+			ControlType:    types.DERControlEnumTypeHVMustTrip,
+			GridEventFault: makePtr(types.GridEventFaultEnumTypeOverVoltage),
+			Timestamp:      "2025-06-15T15:05:00+01:00",
+		},
+		"NotifyDERStartStop": &types.NotifyDERStartStopRequestJson{
+			ControlId:     "ctrl-1",
+			Started:       true,
+			SupersededIds: []string{"ctrl-0"},
+			Timestamp:     "2025-06-15T15:05:00+01:00",
+		},
+		"NotifyPriorityCharging": &types.NotifyPriorityChargingRequestJson{
+			Activated:     true,
+			TransactionId: "tx-1",
+		},
+		"NotifySettlement": &types.NotifySettlementRequestJson{
+			PspRef:           "psp-1",
+			SettlementAmount: 12.5,
+			SettlementTime:   "2025-06-15T15:05:00+01:00",
+			Status:           types.PaymentStatusEnumTypeSettled,
+			TransactionId:    makePtr("tx-1"),
+		},
+		"PullDynamicScheduleUpdate": &types.PullDynamicScheduleUpdateRequestJson{
+			ChargingProfileId: 7,
+		},
+	}
+
+	for action, req := range inputMessages {
+		t.Run(action, func(t *testing.T) {
+			reqBytes, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			messageId, err := uuid.NewUUID()
+			require.NoError(t, err)
+
+			msg := transport.Message{
+				MessageType:    transport.MessageTypeCall,
+				Action:         action,
+				MessageId:      messageId.String(),
+				RequestPayload: reqBytes,
+			}
+
+			tracer, exporter := testutil.GetTracer()
+
+			func() {
+				ctx, span := tracer.Start(context.TODO(), "test")
+				defer span.End()
+				router.Handle(ctx, "cs001", &msg)
+			}()
+
+			require.Greater(t, len(exporter.GetSpans()), 0)
+			assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
+		})
+	}
+}
+
+func TestBootNotificationRecordsOcpp21(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	emitter := &fakeEmitter{}
+	router := ocpp21.NewRouter(emitter,
+		clockTest.NewFakePassiveClock(time.Now()),
+		engine,
+		&fakeTariffService{},
+		&fakeCertValidationService{},
+		&fakeChargeStationCertProvider{},
+		&fakeContractCertProvider{},
+		5*time.Minute,
+		schemas.OcppSchemas,
+	)
+
+	reqBytes, err := json.Marshal(&types.BootNotificationRequestJson{
+		ChargingStation: types.ChargingStationType{
+			Model:      "Powergen",
+			VendorName: "Vendor",
+		},
+		Reason: types.BootReasonEnumTypePowerUp,
+	})
+	require.NoError(t, err)
+
+	router.Handle(context.TODO(), "cs001", &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "BootNotification",
+		MessageId:      "boot-1",
+		RequestPayload: reqBytes,
+	})
+
+	details, err := engine.LookupChargeStationRuntimeDetails(context.TODO(), "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.Equal(t, "2.1", details.OcppVersion)
+
+	require.True(t, emitter.Called)
+	assert.Equal(t, transport.OcppVersion21, emitter.OcppVersion)
+	assert.Equal(t, transport.MessageTypeCallResult, emitter.Message.MessageType)
+}
+
+func TestRoutingDoesNotRouteChangedMessagesToOcpp201(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	emitter := &fakeEmitter{}
+	router := ocpp21.NewRouter(emitter,
+		clockTest.NewFakePassiveClock(time.Now()),
+		engine,
+		&fakeTariffService{},
+		&fakeCertValidationService{},
+		&fakeChargeStationCertProvider{},
+		&fakeContractCertProvider{},
+		5*time.Minute,
+		schemas.OcppSchemas,
+	)
+
+	// NotifyEVChargingNeeds is changed in OCPP 2.1 and is not yet supported
+	router.Handle(context.TODO(), "cs001", &transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "NotifyEVChargingNeeds",
+		MessageId:      "needs-1",
+		RequestPayload: []byte(`{"evseId":1,"chargingNeeds":{"requestedEnergyTransfer":"AC_BPT"}}`),
+	})
+
+	require.True(t, emitter.Called)
+	assert.Equal(t, transport.MessageTypeCallError, emitter.Message.MessageType)
+	assert.Equal(t, transport.ErrorNotImplemented, emitter.Message.ErrorCode)
+}
+
+func TestRoutingCallResults(t *testing.T) {
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+	router := newRouter(t, engine)
+
+	inputMessages := map[string]struct {
+		request  ocpp.Request
+		response ocpp.Response
+	}{
+		// unchanged from OCPP 2.0.1
+		"ClearCache": {
+			request: &ocpp201.ClearCacheRequestJson{},
+			response: &ocpp201.ClearCacheResponseJson{
+				Status: ocpp201.ClearCacheStatusEnumTypeAccepted,
+			},
+		},
+		// new in OCPP 2.1
+		"ClearDERControl": {
+			request: &types.ClearDERControlRequestJson{
+				IsDefault:   false,
+				ControlType: makePtr(types.DERControlEnumTypeFreqDroop),
+			},
+			response: &types.ClearDERControlResponseJson{
+				Status: types.DERControlStatusEnumTypeNotFound,
+			},
+		},
+		"ClearTariffs": {
+			request: &types.ClearTariffsRequestJson{
+				TariffIds: []string{"tariff-1"},
+			},
+			response: &types.ClearTariffsResponseJson{
+				ClearTariffsResult: []types.ClearTariffsResultType{
+					{TariffId: makePtr("tariff-1"), Status: types.TariffClearStatusEnumTypeAccepted},
+				},
+			},
+		},
+		"GetTariffs": {
+			request: &types.GetTariffsRequestJson{EvseId: 0},
+			response: &types.GetTariffsResponseJson{
+				Status: types.TariffGetStatusEnumTypeAccepted,
+				TariffAssignments: []types.TariffAssignmentType{
+					{TariffId: "tariff-1", TariffKind: types.TariffKindEnumTypeDefaultTariff, EvseIds: []int{1}},
+				},
+			},
+		},
+		"RequestBatterySwap": {
+			request: &types.RequestBatterySwapRequestJson{
+				IdToken:   types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+				RequestId: 42,
+			},
+			response: &types.RequestBatterySwapResponseJson{
+				Status: types.GenericStatusEnumTypeAccepted,
+			},
+		},
+		"SetDefaultTariff": {
+			request: &types.SetDefaultTariffRequestJson{
+				EvseId: 0,
+				Tariff: types.TariffType{
+					TariffId: "tariff-1",
+					Currency: "EUR",
+				},
+			},
+			response: &types.SetDefaultTariffResponseJson{
+				Status: types.TariffSetStatusEnumTypeAccepted,
+			},
+		},
+		"UsePriorityCharging": {
+			request: &types.UsePriorityChargingRequestJson{
+				Activate:      true,
+				TransactionId: "tx-1",
+			},
+			response: &types.UsePriorityChargingResponseJson{
+				Status: types.PriorityChargingStatusEnumTypeNoProfile,
+			},
+		},
+	}
+
+	for action, input := range inputMessages {
+		t.Run(action, func(t *testing.T) {
+			reqBytes, err := json.Marshal(input.request)
+			require.NoError(t, err)
+
+			respBytes, err := json.Marshal(input.response)
+			require.NoError(t, err)
+
+			messageId, err := uuid.NewUUID()
+			require.NoError(t, err)
+
+			msg := transport.Message{
+				MessageType:     transport.MessageTypeCallResult,
+				Action:          action,
+				MessageId:       messageId.String(),
+				RequestPayload:  reqBytes,
+				ResponsePayload: respBytes,
+			}
+
+			tracer, exporter := testutil.GetTracer()
+
+			func() {
+				ctx, span := tracer.Start(context.TODO(), "test")
+				defer span.End()
+				router.Handle(ctx, "cs001", &msg)
+			}()
+
+			require.Greater(t, len(exporter.GetSpans()), 0)
+			assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
+		})
+	}
+}
+
+func TestCallMaker(t *testing.T) {
+	emitter := &fakeEmitter{}
+	callMaker := ocpp21.NewCallMaker(emitter)
+
+	inputMessages := map[string]ocpp.Request{
+		// unchanged from OCPP 2.0.1
+		"Reset": &ocpp201.ResetRequestJson{
+			Type: ocpp201.ResetEnumTypeImmediate,
+		},
+		"TriggerMessage": &ocpp201.TriggerMessageRequestJson{
+			RequestedMessage: ocpp201.MessageTriggerEnumTypeHeartbeat,
+		},
+		// new in OCPP 2.1
+		"ClearDERControl": &types.ClearDERControlRequestJson{IsDefault: true},
+		"ClearTariffs":    &types.ClearTariffsRequestJson{},
+		"GetTariffs":      &types.GetTariffsRequestJson{EvseId: 1},
+		"RequestBatterySwap": &types.RequestBatterySwapRequestJson{
+			IdToken:   types.IdTokenType{IdToken: "DEADBEEF", Type: "ISO14443"},
+			RequestId: 42,
+		},
+		"SetDefaultTariff": &types.SetDefaultTariffRequestJson{
+			Tariff: types.TariffType{TariffId: "tariff-1", Currency: "EUR"},
+		},
+		"UsePriorityCharging": &types.UsePriorityChargingRequestJson{
+			Activate:      true,
+			TransactionId: "tx-1",
+		},
+	}
+
+	for action, req := range inputMessages {
+		t.Run(action, func(t *testing.T) {
+			err := callMaker.Send(context.TODO(), "cs001", req)
+			require.NoError(t, err)
+
+			assert.Equal(t, transport.OcppVersion21, emitter.OcppVersion)
+			assert.Equal(t, "cs001", emitter.ChargeStationId)
+			assert.Equal(t, action, emitter.Message.Action)
+		})
+	}
+}
+
+func TestCallMakerDoesNotSendChangedMessages(t *testing.T) {
+	callMaker := ocpp21.NewCallMaker(&fakeEmitter{})
+
+	// the OCPP 2.1 response to ClearDisplayMessage is changed and is not yet supported
+	err := callMaker.Send(context.TODO(), "cs001", &ocpp201.ClearDisplayMessageRequestJson{Id: 1})
+	assert.ErrorContains(t, err, "unknown request type")
+}
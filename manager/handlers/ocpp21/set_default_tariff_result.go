@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// SetDefaultTariffResultHandler handles the result of a SetDefaultTariff call, which sets
+// the tariff used by a charge station to calculate the cost of a transaction locally.
+type SetDefaultTariffResultHandler struct{}
+
+func (h SetDefaultTariffResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.SetDefaultTariffRequestJson)
+	resp := response.(*types.SetDefaultTariffResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("set_default_tariff.evse_id", req.EvseId),
+		attribute.String("set_default_tariff.tariff_id", req.Tariff.TariffId),
+		attribute.String("set_default_tariff.status", string(resp.Status)))
+
+	if resp.Status != types.TariffSetStatusEnumTypeAccepted {
+		logAttrs := statusLogAttrs(chargeStationId, string(resp.Status), resp.StatusInfo)
+		logAttrs = append(logAttrs, "tariffId", req.Tariff.TariffId)
+		slog.Warn("default tariff not accepted", logAttrs...)
+	}
+
+	return nil
+}
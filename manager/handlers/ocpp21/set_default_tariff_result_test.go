@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestSetDefaultTariffResultHandler(t *testing.T) {
+	handler := ocpp21.SetDefaultTariffResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.SetDefaultTariffRequestJson{
+			EvseId: 1,
+			Tariff: types.TariffType{
+				TariffId: "tariff-1",
+				Currency: "EUR",
+			},
+		}
+		resp := &types.SetDefaultTariffResponseJson{
+			Status: types.TariffSetStatusEnumTypeConditionNotSupported,
+			StatusInfo: &types.StatusInfoType{
+				ReasonCode: "UnsupportedCondition",
+			},
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"set_default_tariff.evse_id":   1,
+		"set_default_tariff.tariff_id": "tariff-1",
+		"set_default_tariff.status":    "ConditionNotSupported",
+	})
+}
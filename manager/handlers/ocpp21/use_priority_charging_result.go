@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// UsePriorityChargingResultHandler handles the result of a UsePriorityCharging call, which
+// asks a charge station to switch a transaction to (or from) its priority charging profile.
+type UsePriorityChargingResultHandler struct{}
+
+func (h UsePriorityChargingResultHandler) HandleCallResult(ctx context.Context, chargeStationId string, request ocpp.Request, response ocpp.Response, state any) error {
+	req := request.(*types.UsePriorityChargingRequestJson)
+	resp := response.(*types.UsePriorityChargingResponseJson)
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("use_priority_charging.transaction_id", req.TransactionId),
+		attribute.Bool("use_priority_charging.activate", req.Activate),
+		attribute.String("use_priority_charging.status", string(resp.Status)))
+
+	if resp.Status != types.PriorityChargingStatusEnumTypeAccepted {
+		logAttrs := statusLogAttrs(chargeStationId, string(resp.Status), resp.StatusInfo)
+		logAttrs = append(logAttrs, "transactionId", req.TransactionId)
+		slog.Warn("priority charging not accepted", logAttrs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
+	types "github.com/thoughtworks/maeve-csms/manager/ocpp/ocpp21"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+)
+
+func TestUsePriorityChargingResultHandler(t *testing.T) {
+	handler := ocpp21.UsePriorityChargingResultHandler{}
+
+	tracer, exporter := testutil.GetTracer()
+
+	ctx := context.Background()
+
+	func() {
+		ctx, span := tracer.Start(ctx, "test")
+		defer span.End()
+
+		req := &types.UsePriorityChargingRequestJson{
+			Activate:      true,
+			TransactionId: "tx-1",
+		}
+		resp := &types.UsePriorityChargingResponseJson{
+			Status: types.PriorityChargingStatusEnumTypeNoProfile,
+		}
+
+		err := handler.HandleCallResult(ctx, "cs001", req, resp, nil)
+		require.NoError(t, err)
+	}()
+
+	testutil.AssertSpan(t, &exporter.GetSpans()[0], "test", map[string]any{
+		"use_priority_charging.transaction_id": "tx-1",
+		"use_priority_charging.activate":       true,
+		"use_priority_charging.status":         "NoProfile",
+	})
+}
@@ -8,15 +8,26 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/santhosh-tekuri/jsonschema"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
 )
 
+// Redriver passes messages that could not be processed when they were received back
+// through a router
+type Redriver interface {
+	// Redrive processes a message received from a charge station and returns any error
+	Redrive(ctx context.Context, chargeStationId string, msg *transport.Message) error
+}
+
 // Router is the primary implementation of the transport.Router interface.
 type Router struct {
 	Emitter          transport.Emitter          // used to send responses to the gateway
@@ -24,6 +35,8 @@ type Router struct {
 	OcppVersion      transport.OcppVersion      // the OCPP version that this router supports
 	CallRoutes       map[string]CallRoute       // the set of routes for incoming calls (indexed by action)
 	CallResultRoutes map[string]CallResultRoute // the set of routes for call results (indexed by action)
+	DeadLetterStore  store.DeadLetterStore      // used to record messages that cannot be processed (optional)
+	Clock            clock.PassiveClock         // used to timestamp dead letters (required if DeadLetterStore is set)
 }
 
 func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transport.Message) {
@@ -35,6 +48,8 @@ func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transpo
 		span.SetStatus(codes.Error, "routing request failed")
 		span.RecordError(err)
 
+		r.recordDeadLetter(ctx, chargeStationId, msg, err)
+
 		// only emit an error on a call (the charge station will not be expecting any response message)
 		if msg.MessageType == transport.MessageTypeCall {
 			var mqttError *transport.Error
@@ -54,6 +69,44 @@ func (r Router) Handle(ctx context.Context, chargeStationId string, msg *transpo
 	}
 }
 
+// Redrive passes a message that was recorded as a dead letter back through the router. Unlike
+// Handle, the error is returned to the caller rather than sent to the charge station and no
+// further dead letter is recorded. Any response to a call is discarded as the charge station
+// will have been sent an error when the message was first received.
+func (r Router) Redrive(ctx context.Context, chargeStationId string, msg *transport.Message) error {
+	r.Emitter = transport.EmitterFunc(func(context.Context, transport.OcppVersion, string, *transport.Message) error {
+		return nil
+	})
+	return r.route(ctx, chargeStationId, msg)
+}
+
+func (r Router) recordDeadLetter(ctx context.Context, chargeStationId string, msg *transport.Message, routeErr error) {
+	if r.DeadLetterStore == nil {
+		return
+	}
+
+	message, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("unable to marshal dead letter", slog.String("chargeStationId", chargeStationId), "err", err)
+		return
+	}
+
+	err = r.DeadLetterStore.AddDeadLetter(ctx, &store.DeadLetter{
+		Id:              uuid.New().String(),
+		ChargeStationId: chargeStationId,
+		OcppVersion:     strings.TrimPrefix(string(r.OcppVersion), "ocpp"),
+		MessageType:     msg.MessageType.String(),
+		Action:          msg.Action,
+		MessageId:       msg.MessageId,
+		Message:         string(message),
+		Error:           routeErr.Error(),
+		CreatedAt:       r.Clock.Now(),
+	})
+	if err != nil {
+		slog.Error("unable to record dead letter", slog.String("chargeStationId", chargeStationId), slog.String("action", msg.Action), "err", err)
+	}
+}
+
 func (r Router) route(ctx context.Context, chargeStationId string, message *transport.Message) error {
 	switch message.MessageType {
 	case transport.MessageTypeCall:
@@ -132,8 +185,9 @@ func (r Router) route(ctx context.Context, chargeStationId string, message *tran
 			return err
 		}
 	case transport.MessageTypeCallError:
-		// TODO: what do we want to do with errors?
-		return errors.New("we shouldn't get here at the moment")
+		slog.Warn("charge station rejected call", slog.String("chargeStationId", chargeStationId),
+			slog.String("action", message.Action), slog.String("messageId", message.MessageId),
+			slog.String("errorCode", string(message.ErrorCode)), slog.String("errorDescription", message.ErrorDescription))
 	}
 
 	return nil
@@ -4,20 +4,24 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	handlers201 "github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
 	"github.com/thoughtworks/maeve-csms/manager/schemas"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
 	"github.com/thoughtworks/maeve-csms/manager/testutil"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
@@ -284,6 +288,36 @@ func TestRouterErrorWhenNoCallResultRoute(t *testing.T) {
 	})
 }
 
+func TestRouterHandlesCallError(t *testing.T) {
+	tracer, exporter := testutil.GetTracer()
+
+	emitter := new(FakeEmitter)
+
+	router := handlers.Router{
+		Emitter:  emitter,
+		SchemaFS: os.DirFS("testdata"),
+	}
+
+	func() {
+		ctx, span := tracer.Start(context.Background(), "test")
+		defer span.End()
+		router.Handle(ctx, "id", &transport.Message{
+			MessageType:      transport.MessageTypeCallError,
+			Action:           "Result",
+			MessageId:        "1234",
+			RequestPayload:   []byte("{}"),
+			ErrorCode:        transport.ErrorNotSupported,
+			ErrorDescription: "not supported",
+		})
+	}()
+
+	// for a call error the emitter should never be called
+	assert.False(t, emitter.called)
+
+	require.Greater(t, len(exporter.GetSpans()), 0)
+	assert.Equal(t, codes.Ok, exporter.GetSpans()[0].Status.Code)
+}
+
 func TestRouterErrorWhenInvalidCallResultRequestPayload(t *testing.T) {
 	tracer, exporter := testutil.GetTracer()
 
@@ -553,6 +587,126 @@ func TestRouterErrorWhenCallResultHandlerErrors(t *testing.T) {
 	})
 }
 
+func TestRouterRecordsDeadLetter(t *testing.T) {
+	now := time.Now().UTC()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(now))
+
+	router := handlers.Router{
+		Emitter:         new(FakeEmitter),
+		SchemaFS:        schemas.OcppSchemas,
+		OcppVersion:     transport.OcppVersion201,
+		CallRoutes:      map[string]handlers.CallRoute{},
+		DeadLetterStore: engine,
+		Clock:           clockTest.NewFakePassiveClock(now),
+	}
+
+	msg := heartbeatMsg
+	msg.MessageId = "1234"
+	router.Handle(context.Background(), "cs001", &msg)
+
+	deadLetters, total, err := engine.ListDeadLetters(context.Background(), 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	deadLetter := deadLetters[0]
+	assert.NotEmpty(t, deadLetter.Id)
+	assert.Equal(t, "cs001", deadLetter.ChargeStationId)
+	assert.Equal(t, "2.0.1", deadLetter.OcppVersion)
+	assert.Equal(t, "call", deadLetter.MessageType)
+	assert.Equal(t, "Heartbeat", deadLetter.Action)
+	assert.Equal(t, "1234", deadLetter.MessageId)
+	assert.Contains(t, deadLetter.Error, "Heartbeat not implemented")
+	assert.Equal(t, now, deadLetter.CreatedAt)
+
+	var got transport.Message
+	err = json.Unmarshal([]byte(deadLetter.Message), &got)
+	require.NoError(t, err)
+	assert.Equal(t, msg, got)
+}
+
+func TestRouterDoesNotRecordDeadLetterOnSuccess(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	router := handlers.Router{
+		Emitter:     new(FakeEmitter),
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler: handlers201.HeartbeatHandler{
+					Clock: clock.RealClock{},
+				},
+			},
+		},
+		DeadLetterStore: engine,
+		Clock:           clock.RealClock{},
+	}
+
+	router.Handle(context.Background(), "cs001", &heartbeatMsg)
+
+	_, total, err := engine.ListDeadLetters(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func TestRouterRedrive(t *testing.T) {
+	emitter := new(FakeEmitter)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	var handled bool
+	handler := func(ctx context.Context, chargeStationId string, request ocpp.Request) (ocpp.Response, error) {
+		handled = true
+		return &ocpp201.HeartbeatResponseJson{CurrentTime: "2023-06-15T15:05:00Z"}, nil
+	}
+
+	router := handlers.Router{
+		Emitter:     emitter,
+		SchemaFS:    schemas.OcppSchemas,
+		OcppVersion: transport.OcppVersion201,
+		CallRoutes: map[string]handlers.CallRoute{
+			"Heartbeat": {
+				NewRequest:     func() ocpp.Request { return new(ocpp201.HeartbeatRequestJson) },
+				RequestSchema:  "ocpp201/HeartbeatRequest.json",
+				ResponseSchema: "ocpp201/HeartbeatResponse.json",
+				Handler:        handlers.CallHandlerFunc(handler),
+			},
+		},
+		DeadLetterStore: engine,
+		Clock:           clock.RealClock{},
+	}
+
+	err := router.Redrive(context.Background(), "cs001", &heartbeatMsg)
+	require.NoError(t, err)
+	assert.True(t, handled)
+
+	// the charge station has already been sent an error so the response is discarded
+	assert.False(t, emitter.called)
+}
+
+func TestRouterRedriveReturnsError(t *testing.T) {
+	emitter := new(FakeEmitter)
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	router := handlers.Router{
+		Emitter:         emitter,
+		SchemaFS:        schemas.OcppSchemas,
+		OcppVersion:     transport.OcppVersion201,
+		CallRoutes:      map[string]handlers.CallRoute{},
+		DeadLetterStore: engine,
+		Clock:           clock.RealClock{},
+	}
+
+	err := router.Redrive(context.Background(), "cs001", &heartbeatMsg)
+	assert.ErrorContains(t, err, "Heartbeat not implemented")
+	assert.False(t, emitter.called)
+
+	_, total, err := engine.ListDeadLetters(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
 type fakeRequest struct{}
 
 func (*fakeRequest) IsRequest() {}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/thoughtworks/maeve-csms/manager/ocpp"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
 )
 
 // CallHandler is the interface implemented by handlers that are designed to process an OCPP Call.
@@ -61,3 +62,12 @@ type CallMaker interface {
 	// Send receives the charge station id and the request to send. It may return an error.
 	Send(ctx context.Context, chargeStationId string, request ocpp.Request) error
 }
+
+// SyncCallMaker is a CallMaker that can also wait for the charge station to answer a call.
+type SyncCallMaker interface {
+	CallMaker
+	// Call sends the request to the charge station and returns the CallResult or CallError
+	// that the charge station answers with. It returns an error if the context is done
+	// before the answer is received.
+	Call(ctx context.Context, chargeStationId string, request ocpp.Request) (*transport.Message, error)
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Contains a case insensitive identifier to use for the authorization and the type
+// of authorization to support multiple forms of identifiers.
+type AdditionalInfoType struct {
+	// *(2.1)* This field specifies the additional IdToken.
+	AdditionalIdToken string `json:"additionalIdToken" yaml:"additionalIdToken" mapstructure:"additionalIdToken"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// _additionalInfo_ can be used to send extra information to CSMS in addition to
+	// the regular authorization with _IdToken_. _AdditionalInfo_ contains one or more
+	// custom _types_, which need to be agreed upon by all parties involved. When the
+	// _type_ is not supported, the CSMS/Charging Station MAY ignore the
+	// _additionalInfo_.
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type AuthorizationStatusEnumType string
+
+const AuthorizationStatusEnumTypeAccepted AuthorizationStatusEnumType = "Accepted"
+const AuthorizationStatusEnumTypeBlocked AuthorizationStatusEnumType = "Blocked"
+const AuthorizationStatusEnumTypeConcurrentTx AuthorizationStatusEnumType = "ConcurrentTx"
+const AuthorizationStatusEnumTypeExpired AuthorizationStatusEnumType = "Expired"
+const AuthorizationStatusEnumTypeInvalid AuthorizationStatusEnumType = "Invalid"
+const AuthorizationStatusEnumTypeNoCredit AuthorizationStatusEnumType = "NoCredit"
+const AuthorizationStatusEnumTypeNotAllowedTypeEVSE AuthorizationStatusEnumType = "NotAllowedTypeEVSE"
+const AuthorizationStatusEnumTypeNotAtThisLocation AuthorizationStatusEnumType = "NotAtThisLocation"
+const AuthorizationStatusEnumTypeNotAtThisTime AuthorizationStatusEnumType = "NotAtThisTime"
+const AuthorizationStatusEnumTypeUnknown AuthorizationStatusEnumType = "Unknown"
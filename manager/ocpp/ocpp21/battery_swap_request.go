@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type BatteryDataType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Slot number where battery is inserted or removed.
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+
+	// Production date of battery.
+	ProductionDate *string `json:"productionDate,omitempty" yaml:"productionDate,omitempty" mapstructure:"productionDate,omitempty"`
+
+	// Serial number of battery.
+	SerialNumber string `json:"serialNumber" yaml:"serialNumber" mapstructure:"serialNumber"`
+
+	// State of charge
+	SoC float64 `json:"soC" yaml:"soC" mapstructure:"soC"`
+
+	// State of health
+	SoH float64 `json:"soH" yaml:"soH" mapstructure:"soH"`
+
+	// Vendor-specific info from battery in undefined format.
+	VendorInfo *string `json:"vendorInfo,omitempty" yaml:"vendorInfo,omitempty" mapstructure:"vendorInfo,omitempty"`
+}
+
+// Battery in/out
+type BatterySwapEventEnumType string
+
+const BatterySwapEventEnumTypeBatteryIn BatterySwapEventEnumType = "BatteryIn"
+const BatterySwapEventEnumTypeBatteryOut BatterySwapEventEnumType = "BatteryOut"
+const BatterySwapEventEnumTypeBatteryOutTimeout BatterySwapEventEnumType = "BatteryOutTimeout"
+
+type BatterySwapRequestJson struct {
+	// BatteryData corresponds to the JSON schema field "batteryData".
+	BatteryData []BatteryDataType `json:"batteryData" yaml:"batteryData" mapstructure:"batteryData"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EventType corresponds to the JSON schema field "eventType".
+	EventType BatterySwapEventEnumType `json:"eventType" yaml:"eventType" mapstructure:"eventType"`
+
+	// IdToken corresponds to the JSON schema field "idToken".
+	IdToken IdTokenType `json:"idToken" yaml:"idToken" mapstructure:"idToken"`
+
+	// RequestId to correlate BatteryIn/Out events and optional
+	// RequestBatterySwapRequest.
+	RequestId int `json:"requestId" yaml:"requestId" mapstructure:"requestId"`
+}
+
+func (*BatterySwapRequestJson) IsRequest() {}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type ClearDERControlRequestJson struct {
+	// Id of control setting to clear. When omitted all settings for _controlType_ are
+	// cleared.
+	ControlId *string `json:"controlId,omitempty" yaml:"controlId,omitempty" mapstructure:"controlId,omitempty"`
+
+	// ControlType corresponds to the JSON schema field "controlType".
+	ControlType *DERControlEnumType `json:"controlType,omitempty" yaml:"controlType,omitempty" mapstructure:"controlType,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// True: clearing default DER controls. False: clearing scheduled controls.
+	IsDefault bool `json:"isDefault" yaml:"isDefault" mapstructure:"isDefault"`
+}
+
+func (*ClearDERControlRequestJson) IsRequest() {}
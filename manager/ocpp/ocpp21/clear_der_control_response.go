@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Result of operation.
+type DERControlStatusEnumType string
+
+const DERControlStatusEnumTypeAccepted DERControlStatusEnumType = "Accepted"
+const DERControlStatusEnumTypeRejected DERControlStatusEnumType = "Rejected"
+const DERControlStatusEnumTypeNotSupported DERControlStatusEnumType = "NotSupported"
+const DERControlStatusEnumTypeNotFound DERControlStatusEnumType = "NotFound"
+
+type ClearDERControlResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status DERControlStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*ClearDERControlResponseJson) IsResponse() {}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type ClearTariffsRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// When present only clear tariffs matching _tariffIds_ at EVSE _evseId_.
+	EvseId *int `json:"evseId,omitempty" yaml:"evseId,omitempty" mapstructure:"evseId,omitempty"`
+
+	// List of tariff Ids to clear. When absent clears all tariffs at _evseId_.
+	TariffIds []string `json:"tariffIds,omitempty" yaml:"tariffIds,omitempty" mapstructure:"tariffIds,omitempty"`
+}
+
+func (*ClearTariffsRequestJson) IsRequest() {}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type ClearTariffsResultType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status TariffClearStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+
+	// Id of tariff for which _status_ is reported. If no tariffs were found, then this
+	// field is absent, and _status_ will be `NoTariff`.
+	TariffId *string `json:"tariffId,omitempty" yaml:"tariffId,omitempty" mapstructure:"tariffId,omitempty"`
+}
+
+// Status of the operation.
+type TariffClearStatusEnumType string
+
+const TariffClearStatusEnumTypeAccepted TariffClearStatusEnumType = "Accepted"
+const TariffClearStatusEnumTypeRejected TariffClearStatusEnumType = "Rejected"
+const TariffClearStatusEnumTypeNoTariff TariffClearStatusEnumType = "NoTariff"
+
+type ClearTariffsResponseJson struct {
+	// ClearTariffsResult corresponds to the JSON schema field "clearTariffsResult".
+	ClearTariffsResult []ClearTariffsResultType `json:"clearTariffsResult" yaml:"clearTariffsResult" mapstructure:"clearTariffsResult"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+}
+
+func (*ClearTariffsResponseJson) IsResponse() {}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type ClearedChargingLimitRequestJson struct {
+	// *(2.1)* Source of the charging limit. Allowed values defined in Appendix as
+	// ChargingLimitSourceEnumStringType.
+	ChargingLimitSource string `json:"chargingLimitSource" yaml:"chargingLimitSource" mapstructure:"chargingLimitSource"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EVSE Identifier.
+	EvseId *int `json:"evseId,omitempty" yaml:"evseId,omitempty" mapstructure:"evseId,omitempty"`
+}
+
+func (*ClearedChargingLimitRequestJson) IsRequest() {}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// A physical or logical component
+type ComponentType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Evse corresponds to the JSON schema field "evse".
+	Evse *EVSEType `json:"evse,omitempty" yaml:"evse,omitempty" mapstructure:"evse,omitempty"`
+
+	// Name of instance in case the component exists as multiple instances. Case
+	// Insensitive. strongly advised to use Camel Case.
+	//
+	Instance *string `json:"instance,omitempty" yaml:"instance,omitempty" mapstructure:"instance,omitempty"`
+
+	// Name of the component. Name should be taken from the list of standardized
+	// component names whenever possible. Case Insensitive. strongly advised to use
+	// Camel Case.
+	//
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+}
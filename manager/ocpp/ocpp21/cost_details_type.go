@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// A ChargingPeriodType consists of a start time, and a list of possible values
+// that influence this period, for example: amount of energy charged this period,
+// maximum current during this period etc.
+type ChargingPeriodType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Dimensions corresponds to the JSON schema field "dimensions".
+	Dimensions []CostDimensionType `json:"dimensions,omitempty" yaml:"dimensions,omitempty" mapstructure:"dimensions,omitempty"`
+
+	// Start timestamp of charging period. A period ends when the next period starts.
+	// The last period ends when the session ends.
+	StartPeriod string `json:"startPeriod" yaml:"startPeriod" mapstructure:"startPeriod"`
+
+	// Unique identifier of the Tariff that was used to calculate cost. If not
+	// provided, then cost was calculated by some other means.
+	TariffId *string `json:"tariffId,omitempty" yaml:"tariffId,omitempty" mapstructure:"tariffId,omitempty"`
+}
+
+// CostDetailsType contains the cost as calculated by Charging Station based on
+// provided TariffType.
+type CostDetailsType struct {
+	// ChargingPeriods corresponds to the JSON schema field "chargingPeriods".
+	ChargingPeriods []ChargingPeriodType `json:"chargingPeriods,omitempty" yaml:"chargingPeriods,omitempty" mapstructure:"chargingPeriods,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Optional human-readable reason text in case of failure to calculate.
+	FailureReason *string `json:"failureReason,omitempty" yaml:"failureReason,omitempty" mapstructure:"failureReason,omitempty"`
+
+	// If set to true, then Charging Station has failed to calculate the cost.
+	FailureToCalculate *bool `json:"failureToCalculate,omitempty" yaml:"failureToCalculate,omitempty" mapstructure:"failureToCalculate,omitempty"`
+
+	// TotalCost corresponds to the JSON schema field "totalCost".
+	TotalCost TotalCostType `json:"totalCost" yaml:"totalCost" mapstructure:"totalCost"`
+
+	// TotalUsage corresponds to the JSON schema field "totalUsage".
+	TotalUsage TotalUsageType `json:"totalUsage" yaml:"totalUsage" mapstructure:"totalUsage"`
+}
+
+// Type of cost dimension: energy, power, time, etc.
+type CostDimensionEnumType string
+
+const CostDimensionEnumTypeChargingTime CostDimensionEnumType = "ChargingTime"
+const CostDimensionEnumTypeEnergy CostDimensionEnumType = "Energy"
+const CostDimensionEnumTypeIdleTIme CostDimensionEnumType = "IdleTIme"
+const CostDimensionEnumTypeMaxCurrent CostDimensionEnumType = "MaxCurrent"
+const CostDimensionEnumTypeMaxPower CostDimensionEnumType = "MaxPower"
+const CostDimensionEnumTypeMinCurrent CostDimensionEnumType = "MinCurrent"
+const CostDimensionEnumTypeMinPower CostDimensionEnumType = "MinPower"
+
+// Volume consumed of cost dimension.
+type CostDimensionType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Type corresponds to the JSON schema field "type".
+	Type CostDimensionEnumType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// Volume of the dimension consumed, measured according to the dimension type.
+	Volume float64 `json:"volume" yaml:"volume" mapstructure:"volume"`
+}
+
+// Type of cost: normal or the minimum or maximum cost.
+type TariffCostEnumType string
+
+const TariffCostEnumTypeMaxCost TariffCostEnumType = "MaxCost"
+const TariffCostEnumTypeMinCost TariffCostEnumType = "MinCost"
+const TariffCostEnumTypeNormalCost TariffCostEnumType = "NormalCost"
+
+// This contains the cost calculated during a transaction. It is used both for
+// running cost and final cost of the transaction.
+type TotalCostType struct {
+	// ChargingTime corresponds to the JSON schema field "chargingTime".
+	ChargingTime *PriceType `json:"chargingTime,omitempty" yaml:"chargingTime,omitempty" mapstructure:"chargingTime,omitempty"`
+
+	// Currency of the costs in ISO 4217 Code.
+	Currency string `json:"currency" yaml:"currency" mapstructure:"currency"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Energy corresponds to the JSON schema field "energy".
+	Energy *PriceType `json:"energy,omitempty" yaml:"energy,omitempty" mapstructure:"energy,omitempty"`
+
+	// Fixed corresponds to the JSON schema field "fixed".
+	Fixed *PriceType `json:"fixed,omitempty" yaml:"fixed,omitempty" mapstructure:"fixed,omitempty"`
+
+	// IdleTime corresponds to the JSON schema field "idleTime".
+	IdleTime *PriceType `json:"idleTime,omitempty" yaml:"idleTime,omitempty" mapstructure:"idleTime,omitempty"`
+
+	// ReservationFixed corresponds to the JSON schema field "reservationFixed".
+	ReservationFixed *PriceType `json:"reservationFixed,omitempty" yaml:"reservationFixed,omitempty" mapstructure:"reservationFixed,omitempty"`
+
+	// ReservationTime corresponds to the JSON schema field "reservationTime".
+	ReservationTime *PriceType `json:"reservationTime,omitempty" yaml:"reservationTime,omitempty" mapstructure:"reservationTime,omitempty"`
+
+	// Total corresponds to the JSON schema field "total".
+	Total TotalPriceType `json:"total" yaml:"total" mapstructure:"total"`
+
+	// TypeOfCost corresponds to the JSON schema field "typeOfCost".
+	TypeOfCost TariffCostEnumType `json:"typeOfCost" yaml:"typeOfCost" mapstructure:"typeOfCost"`
+}
+
+// Total cost with and without tax. Contains the total of energy, charging time,
+// idle time, fixed and reservation costs including and/or excluding tax.
+type TotalPriceType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Price/cost excluding tax. Can be absent if _inclTax_ is present.
+	ExclTax *float64 `json:"exclTax,omitempty" yaml:"exclTax,omitempty" mapstructure:"exclTax,omitempty"`
+
+	// Price/cost including tax. Can be absent if _exclTax_ is present.
+	InclTax *float64 `json:"inclTax,omitempty" yaml:"inclTax,omitempty" mapstructure:"inclTax,omitempty"`
+}
+
+// This contains the calculated usage of energy, charging time and idle time during
+// a transaction.
+type TotalUsageType struct {
+	// Total duration of the charging session (including the duration of charging and
+	// not charging), in seconds.
+	ChargingTime int `json:"chargingTime" yaml:"chargingTime" mapstructure:"chargingTime"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Energy corresponds to the JSON schema field "energy".
+	Energy float64 `json:"energy" yaml:"energy" mapstructure:"energy"`
+
+	// Total duration of the charging session where the EV was not charging (no energy
+	// was transferred between EVSE and EV), in seconds.
+	IdleTime int `json:"idleTime" yaml:"idleTime" mapstructure:"idleTime"`
+
+	// Total time of reservation in seconds.
+	ReservationTime *int `json:"reservationTime,omitempty" yaml:"reservationTime,omitempty" mapstructure:"reservationTime,omitempty"`
+}
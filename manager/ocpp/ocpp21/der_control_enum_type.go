@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Name of DER control, e.g. LFMustTrip
+type DERControlEnumType string
+
+const DERControlEnumTypeEnterService DERControlEnumType = "EnterService"
+const DERControlEnumTypeFreqDroop DERControlEnumType = "FreqDroop"
+const DERControlEnumTypeFreqWatt DERControlEnumType = "FreqWatt"
+const DERControlEnumTypeFixedPFAbsorb DERControlEnumType = "FixedPFAbsorb"
+const DERControlEnumTypeFixedPFInject DERControlEnumType = "FixedPFInject"
+const DERControlEnumTypeFixedVar DERControlEnumType = "FixedVar"
+const DERControlEnumTypeGradients DERControlEnumType = "Gradients"
+const DERControlEnumTypeHFMustTrip DERControlEnumType = "HFMustTrip"
+const DERControlEnumTypeHFMayTrip DERControlEnumType = "HFMayTrip"
+const DERControlEnumTypeHVMustTrip DERControlEnumType = "HVMustTrip"
+const DERControlEnumTypeHVMomCess DERControlEnumType = "HVMomCess"
+const DERControlEnumTypeHVMayTrip DERControlEnumType = "HVMayTrip"
+const DERControlEnumTypeLimitMaxDischarge DERControlEnumType = "LimitMaxDischarge"
+const DERControlEnumTypeLFMustTrip DERControlEnumType = "LFMustTrip"
+const DERControlEnumTypeLVMustTrip DERControlEnumType = "LVMustTrip"
+const DERControlEnumTypeLVMomCess DERControlEnumType = "LVMomCess"
+const DERControlEnumTypeLVMayTrip DERControlEnumType = "LVMayTrip"
+const DERControlEnumTypePowerMonitoringMustTrip DERControlEnumType = "PowerMonitoringMustTrip"
+const DERControlEnumTypeVoltVar DERControlEnumType = "VoltVar"
+const DERControlEnumTypeVoltWatt DERControlEnumType = "VoltWatt"
+const DERControlEnumTypeWattPF DERControlEnumType = "WattPF"
+const DERControlEnumTypeWattVar DERControlEnumType = "WattVar"
@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ocpp21 contains types that represent the protocol messages that were added
+// or changed in OCPP 2.1. Messages that are unchanged from OCPP 2.0.1 use the types in
+// the ocpp201 package. The files have been generated from the OCPP 2.1 JSON schemas in
+// the same way as those in the ocpp201 package.
+package ocpp21
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type CertificateActionEnumType string
+
+const CertificateActionEnumTypeInstall CertificateActionEnumType = "Install"
+const CertificateActionEnumTypeUpdate CertificateActionEnumType = "Update"
+
+type Get15118EVCertificateRequestJson struct {
+	// Action corresponds to the JSON schema field "action".
+	Action CertificateActionEnumType `json:"action" yaml:"action" mapstructure:"action"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	ExiRequest string `json:"exiRequest" yaml:"exiRequest" mapstructure:"exiRequest"`
+
+	Iso15118SchemaVersion string `json:"iso15118SchemaVersion" yaml:"iso15118SchemaVersion" mapstructure:"iso15118SchemaVersion"`
+
+	// *(2.1)* Absent during ISO 15118-2 session. Required during ISO 15118-20
+	// session.
+	// Maximum number of contracts that EV wants to install.
+	MaximumContractCertificateChains *int `json:"maximumContractCertificateChains,omitempty" yaml:"maximumContractCertificateChains,omitempty" mapstructure:"maximumContractCertificateChains,omitempty"`
+
+	// *(2.1)* Absent during ISO 15118-2 session. Optional during ISO 15118-20
+	// session. List of EMAIDs for which contract certificates must be requested
+	// first, in case there are more certificates than allowed by
+	// _maximumContractCertificateChains_.
+	PrioritizedEMAIDs []string `json:"prioritizedEMAIDs,omitempty" yaml:"prioritizedEMAIDs,omitempty" mapstructure:"prioritizedEMAIDs,omitempty"`
+}
+
+func (*Get15118EVCertificateRequestJson) IsRequest() {}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type GetTariffsRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EVSE id to get tariff from. When _evseId_ = 0, this gets tariffs from all EVSEs.
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+}
+
+func (*GetTariffsRequestJson) IsRequest() {}
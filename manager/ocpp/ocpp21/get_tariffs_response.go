@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Status of operation
+type TariffGetStatusEnumType string
+
+const TariffGetStatusEnumTypeAccepted TariffGetStatusEnumType = "Accepted"
+const TariffGetStatusEnumTypeRejected TariffGetStatusEnumType = "Rejected"
+const TariffGetStatusEnumTypeNoTariff TariffGetStatusEnumType = "NoTariff"
+
+// Shows assignment of tariffs to EVSE or IdToken.
+type TariffAssignmentType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EvseIds corresponds to the JSON schema field "evseIds".
+	EvseIds []int `json:"evseIds,omitempty" yaml:"evseIds,omitempty" mapstructure:"evseIds,omitempty"`
+
+	// IdTokens related to tariff
+	IdTokens []string `json:"idTokens,omitempty" yaml:"idTokens,omitempty" mapstructure:"idTokens,omitempty"`
+
+	// Tariff id.
+	TariffId string `json:"tariffId" yaml:"tariffId" mapstructure:"tariffId"`
+
+	// TariffKind corresponds to the JSON schema field "tariffKind".
+	TariffKind TariffKindEnumType `json:"tariffKind" yaml:"tariffKind" mapstructure:"tariffKind"`
+
+	// Date/time when this tariff become active.
+	ValidFrom *string `json:"validFrom,omitempty" yaml:"validFrom,omitempty" mapstructure:"validFrom,omitempty"`
+}
+
+// Kind of tariff (driver/default)
+type TariffKindEnumType string
+
+const TariffKindEnumTypeDefaultTariff TariffKindEnumType = "DefaultTariff"
+const TariffKindEnumTypeDriverTariff TariffKindEnumType = "DriverTariff"
+
+type GetTariffsResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status TariffGetStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+
+	// TariffAssignments corresponds to the JSON schema field "tariffAssignments".
+	TariffAssignments []TariffAssignmentType `json:"tariffAssignments,omitempty" yaml:"tariffAssignments,omitempty" mapstructure:"tariffAssignments,omitempty"`
+}
+
+func (*GetTariffsResponseJson) IsResponse() {}
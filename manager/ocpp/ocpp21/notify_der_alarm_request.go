@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Type of grid event that caused this
+type GridEventFaultEnumType string
+
+const GridEventFaultEnumTypeCurrentImbalance GridEventFaultEnumType = "CurrentImbalance"
+const GridEventFaultEnumTypeLocalEmergency GridEventFaultEnumType = "LocalEmergency"
+const GridEventFaultEnumTypeLowInputPower GridEventFaultEnumType = "LowInputPower"
+const GridEventFaultEnumTypeOverCurrent GridEventFaultEnumType = "OverCurrent"
+const GridEventFaultEnumTypeOverFrequency GridEventFaultEnumType = "OverFrequency"
+const GridEventFaultEnumTypeOverVoltage GridEventFaultEnumType = "OverVoltage"
+const GridEventFaultEnumTypePhaseRotation GridEventFaultEnumType = "PhaseRotation"
+const GridEventFaultEnumTypeRemoteEmergency GridEventFaultEnumType = "RemoteEmergency"
+const GridEventFaultEnumTypeUnderFrequency GridEventFaultEnumType = "UnderFrequency"
+const GridEventFaultEnumTypeUnderVoltage GridEventFaultEnumType = "UnderVoltage"
+const GridEventFaultEnumTypeVoltageImbalance GridEventFaultEnumType = "VoltageImbalance"
+
+type NotifyDERAlarmRequestJson struct {
+	// True when error condition has ended.
+	// Absent or false when alarm has started.
+	AlarmEnded *bool `json:"alarmEnded,omitempty" yaml:"alarmEnded,omitempty" mapstructure:"alarmEnded,omitempty"`
+
+	// ControlType corresponds to the JSON schema field "controlType".
+	ControlType DERControlEnumType `json:"controlType" yaml:"controlType" mapstructure:"controlType"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Optional info provided by EV.
+	ExtraInfo *string `json:"extraInfo,omitempty" yaml:"extraInfo,omitempty" mapstructure:"extraInfo,omitempty"`
+
+	// GridEventFault corresponds to the JSON schema field "gridEventFault".
+	GridEventFault *GridEventFaultEnumType `json:"gridEventFault,omitempty" yaml:"gridEventFault,omitempty" mapstructure:"gridEventFault,omitempty"`
+
+	// Time of start or end of alarm.
+	Timestamp string `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+}
+
+func (*NotifyDERAlarmRequestJson) IsRequest() {}
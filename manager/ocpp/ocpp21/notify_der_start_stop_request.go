@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type NotifyDERStartStopRequestJson struct {
+	// Id of the started or stopped DER control.
+	// Corresponds to the _controlId_ of the SetDERControlRequest.
+	ControlId string `json:"controlId" yaml:"controlId" mapstructure:"controlId"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// True if DER control has started. False if it has ended.
+	Started bool `json:"started" yaml:"started" mapstructure:"started"`
+
+	// List of controlIds that are superseded as a result of this control starting.
+	SupersededIds []string `json:"supersededIds,omitempty" yaml:"supersededIds,omitempty" mapstructure:"supersededIds,omitempty"`
+
+	// Time of start or end of event.
+	Timestamp string `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+}
+
+func (*NotifyDERStartStopRequestJson) IsRequest() {}
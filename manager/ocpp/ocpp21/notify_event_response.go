@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// NotifyEventResponseJson acknowledges NotifyEvent.
+type NotifyEventResponseJson struct {
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+}
+
+func (*NotifyEventResponseJson) IsResponse() {}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type NotifyPriorityChargingRequestJson struct {
+	// True if priority charging was activated. False if it has stopped using the
+	// priority charging profile.
+	Activated bool `json:"activated" yaml:"activated" mapstructure:"activated"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// The transaction for which priority charging is requested.
+	TransactionId string `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
+
+func (*NotifyPriorityChargingRequestJson) IsRequest() {}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// The status of the settlement attempt.
+type PaymentStatusEnumType string
+
+const PaymentStatusEnumTypeSettled PaymentStatusEnumType = "Settled"
+const PaymentStatusEnumTypeCanceled PaymentStatusEnumType = "Canceled"
+const PaymentStatusEnumTypeRejected PaymentStatusEnumType = "Rejected"
+const PaymentStatusEnumTypeFailed PaymentStatusEnumType = "Failed"
+
+// *(2.1)* A generic address format.
+type AddressType struct {
+	// Address line 1
+	Address1 string `json:"address1" yaml:"address1" mapstructure:"address1"`
+
+	// Address line 2
+	Address2 *string `json:"address2,omitempty" yaml:"address2,omitempty" mapstructure:"address2,omitempty"`
+
+	// City
+	City string `json:"city" yaml:"city" mapstructure:"city"`
+
+	// Country name
+	Country string `json:"country" yaml:"country" mapstructure:"country"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Name of person/company
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Postal code
+	PostalCode *string `json:"postalCode,omitempty" yaml:"postalCode,omitempty" mapstructure:"postalCode,omitempty"`
+}
+
+type NotifySettlementRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// The payment reference received from the payment terminal and is used as the
+	// value for _idToken_.
+	PspRef string `json:"pspRef" yaml:"pspRef" mapstructure:"pspRef"`
+
+	// ReceiptId corresponds to the JSON schema field "receiptId".
+	ReceiptId *string `json:"receiptId,omitempty" yaml:"receiptId,omitempty" mapstructure:"receiptId,omitempty"`
+
+	// The receipt URL, to be used if the receipt is generated by the payment terminal
+	// or the CS.
+	ReceiptUrl *string `json:"receiptUrl,omitempty" yaml:"receiptUrl,omitempty" mapstructure:"receiptUrl,omitempty"`
+
+	// The amount that was settled, or attempted to be settled (in case of failure).
+	SettlementAmount float64 `json:"settlementAmount" yaml:"settlementAmount" mapstructure:"settlementAmount"`
+
+	// The time when the settlement was done.
+	SettlementTime string `json:"settlementTime" yaml:"settlementTime" mapstructure:"settlementTime"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status PaymentStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// Additional information from payment terminal/payment process.
+	StatusInfo *string `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+
+	// The _transactionId_ that the settlement belongs to. Can be empty if the payment
+	// transaction is canceled prior to the start of the OCPP transaction.
+	TransactionId *string `json:"transactionId,omitempty" yaml:"transactionId,omitempty" mapstructure:"transactionId,omitempty"`
+
+	// VatCompany corresponds to the JSON schema field "vatCompany".
+	VatCompany *AddressType `json:"vatCompany,omitempty" yaml:"vatCompany,omitempty" mapstructure:"vatCompany,omitempty"`
+
+	// VAT number for a company receipt.
+	VatNumber *string `json:"vatNumber,omitempty" yaml:"vatNumber,omitempty" mapstructure:"vatNumber,omitempty"`
+}
+
+func (*NotifySettlementRequestJson) IsRequest() {}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type NotifySettlementResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// The receipt id if the receipt is generated by CSMS.
+	ReceiptId *string `json:"receiptId,omitempty" yaml:"receiptId,omitempty" mapstructure:"receiptId,omitempty"`
+
+	// The receipt URL if receipt generated by CSMS. The Charging Station can QR encode
+	// it and show it to the EV Driver.
+	ReceiptUrl *string `json:"receiptUrl,omitempty" yaml:"receiptUrl,omitempty" mapstructure:"receiptUrl,omitempty"`
+}
+
+func (*NotifySettlementResponseJson) IsResponse() {}
@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type PullDynamicScheduleUpdateRequestJson struct {
+	// Id of charging profile to update.
+	ChargingProfileId int `json:"chargingProfileId" yaml:"chargingProfileId" mapstructure:"chargingProfileId"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+}
+
+func (*PullDynamicScheduleUpdateRequestJson) IsRequest() {}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Updates to a ChargingSchedulePeriodType for dynamic charging profiles.
+type ChargingScheduleUpdateType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// DischargeLimit corresponds to the JSON schema field "dischargeLimit".
+	DischargeLimit *float64 `json:"dischargeLimit,omitempty" yaml:"dischargeLimit,omitempty" mapstructure:"dischargeLimit,omitempty"`
+
+	// DischargeLimitL2 corresponds to the JSON schema field "dischargeLimit_L2".
+	DischargeLimitL2 *float64 `json:"dischargeLimit_L2,omitempty" yaml:"dischargeLimit_L2,omitempty" mapstructure:"dischargeLimit_L2,omitempty"`
+
+	// DischargeLimitL3 corresponds to the JSON schema field "dischargeLimit_L3".
+	DischargeLimitL3 *float64 `json:"dischargeLimit_L3,omitempty" yaml:"dischargeLimit_L3,omitempty" mapstructure:"dischargeLimit_L3,omitempty"`
+
+	// Limit corresponds to the JSON schema field "limit".
+	Limit *float64 `json:"limit,omitempty" yaml:"limit,omitempty" mapstructure:"limit,omitempty"`
+
+	// LimitL2 corresponds to the JSON schema field "limit_L2".
+	LimitL2 *float64 `json:"limit_L2,omitempty" yaml:"limit_L2,omitempty" mapstructure:"limit_L2,omitempty"`
+
+	// LimitL3 corresponds to the JSON schema field "limit_L3".
+	LimitL3 *float64 `json:"limit_L3,omitempty" yaml:"limit_L3,omitempty" mapstructure:"limit_L3,omitempty"`
+
+	// Setpoint corresponds to the JSON schema field "setpoint".
+	Setpoint *float64 `json:"setpoint,omitempty" yaml:"setpoint,omitempty" mapstructure:"setpoint,omitempty"`
+
+	// SetpointL2 corresponds to the JSON schema field "setpoint_L2".
+	SetpointL2 *float64 `json:"setpoint_L2,omitempty" yaml:"setpoint_L2,omitempty" mapstructure:"setpoint_L2,omitempty"`
+
+	// SetpointL3 corresponds to the JSON schema field "setpoint_L3".
+	SetpointL3 *float64 `json:"setpoint_L3,omitempty" yaml:"setpoint_L3,omitempty" mapstructure:"setpoint_L3,omitempty"`
+
+	// SetpointReactive corresponds to the JSON schema field "setpointReactive".
+	SetpointReactive *float64 `json:"setpointReactive,omitempty" yaml:"setpointReactive,omitempty" mapstructure:"setpointReactive,omitempty"`
+
+	// SetpointReactiveL2 corresponds to the JSON schema field "setpointReactive_L2".
+	SetpointReactiveL2 *float64 `json:"setpointReactive_L2,omitempty" yaml:"setpointReactive_L2,omitempty" mapstructure:"setpointReactive_L2,omitempty"`
+
+	// SetpointReactiveL3 corresponds to the JSON schema field "setpointReactive_L3".
+	SetpointReactiveL3 *float64 `json:"setpointReactive_L3,omitempty" yaml:"setpointReactive_L3,omitempty" mapstructure:"setpointReactive_L3,omitempty"`
+}
+
+// Result of request.
+type ChargingProfileStatusEnumType string
+
+const ChargingProfileStatusEnumTypeAccepted ChargingProfileStatusEnumType = "Accepted"
+const ChargingProfileStatusEnumTypeRejected ChargingProfileStatusEnumType = "Rejected"
+
+type PullDynamicScheduleUpdateResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// ScheduleUpdate corresponds to the JSON schema field "scheduleUpdate".
+	ScheduleUpdate *ChargingScheduleUpdateType `json:"scheduleUpdate,omitempty" yaml:"scheduleUpdate,omitempty" mapstructure:"scheduleUpdate,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status ChargingProfileStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*PullDynamicScheduleUpdateResponseJson) IsResponse() {}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type RequestBatterySwapRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// IdToken corresponds to the JSON schema field "idToken".
+	IdToken IdTokenType `json:"idToken" yaml:"idToken" mapstructure:"idToken"`
+
+	// Request id to match with BatterySwapRequest.
+	RequestId int `json:"requestId" yaml:"requestId" mapstructure:"requestId"`
+}
+
+func (*RequestBatterySwapRequestJson) IsRequest() {}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Accepted or rejected the request.
+type GenericStatusEnumType string
+
+const GenericStatusEnumTypeAccepted GenericStatusEnumType = "Accepted"
+const GenericStatusEnumTypeRejected GenericStatusEnumType = "Rejected"
+
+type RequestBatterySwapResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status GenericStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*RequestBatterySwapResponseJson) IsResponse() {}
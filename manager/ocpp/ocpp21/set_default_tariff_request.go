@@ -0,0 +1,382 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// A tariff is described by fields with prices for:
+// energy,
+// charging time,
+// idle time,
+// fixed fee,
+// reservation time,
+// reservation fixed fee.
+// Each of these fields may have (optional) conditions that specify when a price is
+// applicable.
+// The _description_ contains a human-readable explanation of the tariff to be
+// shown to the user.
+// The other fields are parameters that define the tariff. These are used by the
+// charging station to calculate the price.
+type TariffType struct {
+	// ChargingTime corresponds to the JSON schema field "chargingTime".
+	ChargingTime *TariffTimeType `json:"chargingTime,omitempty" yaml:"chargingTime,omitempty" mapstructure:"chargingTime,omitempty"`
+
+	// Currency code according to ISO 4217
+	Currency string `json:"currency" yaml:"currency" mapstructure:"currency"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Description of the tariff as (multi-language) human readable text.
+	Description []MessageContentType `json:"description,omitempty" yaml:"description,omitempty" mapstructure:"description,omitempty"`
+
+	// Energy corresponds to the JSON schema field "energy".
+	Energy *TariffEnergyType `json:"energy,omitempty" yaml:"energy,omitempty" mapstructure:"energy,omitempty"`
+
+	// FixedFee corresponds to the JSON schema field "fixedFee".
+	FixedFee *TariffFixedType `json:"fixedFee,omitempty" yaml:"fixedFee,omitempty" mapstructure:"fixedFee,omitempty"`
+
+	// IdleTime corresponds to the JSON schema field "idleTime".
+	IdleTime *TariffTimeType `json:"idleTime,omitempty" yaml:"idleTime,omitempty" mapstructure:"idleTime,omitempty"`
+
+	// MaxCost corresponds to the JSON schema field "maxCost".
+	MaxCost *PriceType `json:"maxCost,omitempty" yaml:"maxCost,omitempty" mapstructure:"maxCost,omitempty"`
+
+	// MinCost corresponds to the JSON schema field "minCost".
+	MinCost *PriceType `json:"minCost,omitempty" yaml:"minCost,omitempty" mapstructure:"minCost,omitempty"`
+
+	// ReservationFixed corresponds to the JSON schema field "reservationFixed".
+	ReservationFixed *TariffFixedType `json:"reservationFixed,omitempty" yaml:"reservationFixed,omitempty" mapstructure:"reservationFixed,omitempty"`
+
+	// ReservationTime corresponds to the JSON schema field "reservationTime".
+	ReservationTime *TariffTimeType `json:"reservationTime,omitempty" yaml:"reservationTime,omitempty" mapstructure:"reservationTime,omitempty"`
+
+	// Unique id of tariff
+	TariffId string `json:"tariffId" yaml:"tariffId" mapstructure:"tariffId"`
+
+	// Time when this tariff becomes active. When absent, it is immediately active.
+	ValidFrom *string `json:"validFrom,omitempty" yaml:"validFrom,omitempty" mapstructure:"validFrom,omitempty"`
+}
+
+// Contains message details, for a message to be displayed on a Charging Station.
+type MessageContentType struct {
+	// *(2.1)* Required. Message contents.
+	// Maximum length supports at least 1024 characters.
+	Content string `json:"content" yaml:"content" mapstructure:"content"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Format corresponds to the JSON schema field "format".
+	Format MessageFormatEnumType `json:"format" yaml:"format" mapstructure:"format"`
+
+	// Message language identifier. Contains a language code as defined in
+	// <<ref-RFC5646,[RFC5646]>>.
+	Language *string `json:"language,omitempty" yaml:"language,omitempty" mapstructure:"language,omitempty"`
+}
+
+// Price elements and tax for energy
+type TariffEnergyType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Prices corresponds to the JSON schema field "prices".
+	Prices []TariffEnergyPriceType `json:"prices" yaml:"prices" mapstructure:"prices"`
+
+	// TaxRates corresponds to the JSON schema field "taxRates".
+	TaxRates []TaxRateType `json:"taxRates,omitempty" yaml:"taxRates,omitempty" mapstructure:"taxRates,omitempty"`
+}
+
+// Price elements and tax for time
+type TariffTimeType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Prices corresponds to the JSON schema field "prices".
+	Prices []TariffTimePriceType `json:"prices" yaml:"prices" mapstructure:"prices"`
+
+	// TaxRates corresponds to the JSON schema field "taxRates".
+	TaxRates []TaxRateType `json:"taxRates,omitempty" yaml:"taxRates,omitempty" mapstructure:"taxRates,omitempty"`
+}
+
+type TariffFixedType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Prices corresponds to the JSON schema field "prices".
+	Prices []TariffFixedPriceType `json:"prices" yaml:"prices" mapstructure:"prices"`
+
+	// TaxRates corresponds to the JSON schema field "taxRates".
+	TaxRates []TaxRateType `json:"taxRates,omitempty" yaml:"taxRates,omitempty" mapstructure:"taxRates,omitempty"`
+}
+
+// Price with and without tax. At least one of _exclTax_, _inclTax_ must be
+// present.
+type PriceType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Price/cost excluding tax. Can be absent if _inclTax_ is present.
+	ExclTax *float64 `json:"exclTax,omitempty" yaml:"exclTax,omitempty" mapstructure:"exclTax,omitempty"`
+
+	// Price/cost including tax. Can be absent if _exclTax_ is present.
+	InclTax *float64 `json:"inclTax,omitempty" yaml:"inclTax,omitempty" mapstructure:"inclTax,omitempty"`
+
+	// TaxRates corresponds to the JSON schema field "taxRates".
+	TaxRates []TaxRateType `json:"taxRates,omitempty" yaml:"taxRates,omitempty" mapstructure:"taxRates,omitempty"`
+}
+
+// Format of the message.
+type MessageFormatEnumType string
+
+const MessageFormatEnumTypeASCII MessageFormatEnumType = "ASCII"
+const MessageFormatEnumTypeHTML MessageFormatEnumType = "HTML"
+const MessageFormatEnumTypeURI MessageFormatEnumType = "URI"
+const MessageFormatEnumTypeUTF8 MessageFormatEnumType = "UTF8"
+const MessageFormatEnumTypeQRCODE MessageFormatEnumType = "QRCODE"
+
+// Tariff with optional conditions for an energy price.
+type TariffEnergyPriceType struct {
+	// Conditions corresponds to the JSON schema field "conditions".
+	Conditions *TariffConditionsType `json:"conditions,omitempty" yaml:"conditions,omitempty" mapstructure:"conditions,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Price per kWh (excl. tax) for this element.
+	PriceKwh float64 `json:"priceKwh" yaml:"priceKwh" mapstructure:"priceKwh"`
+}
+
+// Tax percentage
+type TaxRateType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Stack level for this type of tax. Default value, when absent, is 0.
+	// _stack_ = 0: tax on net price;
+	// _stack_ = 1: tax added on top of _stack_ 0;
+	// _stack_ = 2: tax added on top of _stack_ 1, etc.
+	Stack *int `json:"stack,omitempty" yaml:"stack,omitempty" mapstructure:"stack,omitempty"`
+
+	// Tax percentage
+	Tax float64 `json:"tax" yaml:"tax" mapstructure:"tax"`
+
+	// Type of this tax, e.g. "Federal ", "State", for information on receipt.
+	Type string `json:"type" yaml:"type" mapstructure:"type"`
+}
+
+// Tariff with optional conditions for a time duration price.
+type TariffTimePriceType struct {
+	// Conditions corresponds to the JSON schema field "conditions".
+	Conditions *TariffConditionsType `json:"conditions,omitempty" yaml:"conditions,omitempty" mapstructure:"conditions,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Price per minute (excl. tax) for this element.
+	PriceMinute float64 `json:"priceMinute" yaml:"priceMinute" mapstructure:"priceMinute"`
+}
+
+// Tariff with optional conditions for a fixed price.
+type TariffFixedPriceType struct {
+	// Conditions corresponds to the JSON schema field "conditions".
+	Conditions *TariffConditionsFixedType `json:"conditions,omitempty" yaml:"conditions,omitempty" mapstructure:"conditions,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Fixed price for this element e.g. a start fee.
+	PriceFixed float64 `json:"priceFixed" yaml:"priceFixed" mapstructure:"priceFixed"`
+}
+
+// These conditions describe if and when a TariffEnergyType or TariffTimeType
+// applies during a transaction.
+//
+// When more than one restriction is set, they are to be treated as a logical AND.
+// All need to be valid before this price is active.
+//
+// For reverse energy flow (discharging) negative values of energy, power and
+// current are used.
+type TariffConditionsType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Day(s) of the week this is tariff applies.
+	DayOfWeek []DayOfWeekEnumType `json:"dayOfWeek,omitempty" yaml:"dayOfWeek,omitempty" mapstructure:"dayOfWeek,omitempty"`
+
+	// End time of day in local time. Same syntax as _startTimeOfDay_.
+	// If end time < start time then the period wraps around to the next day.
+	// To stop at end of the day use: 00:00.
+	EndTimeOfDay *string `json:"endTimeOfDay,omitempty" yaml:"endTimeOfDay,omitempty" mapstructure:"endTimeOfDay,omitempty"`
+
+	// EvseKind corresponds to the JSON schema field "evseKind".
+	EvseKind *EvseKindEnumType `json:"evseKind,omitempty" yaml:"evseKind,omitempty" mapstructure:"evseKind,omitempty"`
+
+	// Maximum duration in seconds the charging MUST last (exclusive). When the
+	// duration of a charging is shorter than the given value, this price is or becomes
+	// active. After that moment, this price is no longer active.
+	MaxChargingTime *int `json:"maxChargingTime,omitempty" yaml:"maxChargingTime,omitempty" mapstructure:"maxChargingTime,omitempty"`
+
+	// Sum of the maximum current (in Amperes) over all phases, for example 20. When
+	// the EV is charging with less than the defined amount of current, this price
+	// becomes/is active. If the charging current is or becomes higher, this price is
+	// not or no longer valid and becomes inactive.
+	// This is NOT about the maximum current over the entire transaction.
+	MaxCurrent *float64 `json:"maxCurrent,omitempty" yaml:"maxCurrent,omitempty" mapstructure:"maxCurrent,omitempty"`
+
+	// Maximum consumed energy in Wh, for example 50000 Wh.
+	// Valid until this amount of energy (exclusive) being used.
+	MaxEnergy *float64 `json:"maxEnergy,omitempty" yaml:"maxEnergy,omitempty" mapstructure:"maxEnergy,omitempty"`
+
+	// Maximum duration in seconds the idle period (i.e. not charging) MUST last
+	// (exclusive). When the duration of idle time is shorter than the given value,
+	// this price is or becomes active. After that moment, this price is no longer
+	// active.
+	MaxIdleTime *int `json:"maxIdleTime,omitempty" yaml:"maxIdleTime,omitempty" mapstructure:"maxIdleTime,omitempty"`
+
+	// Maximum power in W, for example 20000. When the EV is charging with less than
+	// the defined amount of power, this price becomes/is active. If the charging power
+	// is or becomes higher, this price is not or no longer valid and becomes inactive.
+	// This is NOT about the maximum power over the entire transaction.
+	MaxPower *float64 `json:"maxPower,omitempty" yaml:"maxPower,omitempty" mapstructure:"maxPower,omitempty"`
+
+	// Maximum duration in seconds the transaction (charging & idle) MUST last
+	// (exclusive). When the duration of a transaction is shorter than the given value,
+	// this price is or becomes active. After that moment, this price is no longer
+	// active.
+	MaxTime *int `json:"maxTime,omitempty" yaml:"maxTime,omitempty" mapstructure:"maxTime,omitempty"`
+
+	// Minimum duration in seconds the charging MUST last (inclusive). When the
+	// duration of a charging is longer than the given value, this price is or becomes
+	// active. Before that moment, this price is not yet active.
+	MinChargingTime *int `json:"minChargingTime,omitempty" yaml:"minChargingTime,omitempty" mapstructure:"minChargingTime,omitempty"`
+
+	// Sum of the minimum current (in Amperes) over all phases, for example 5. When the
+	// EV is charging with more than, or equal to, the defined amount of current, this
+	// price is/becomes active. If the charging current is or becomes lower, this price
+	// is not or no longer valid and becomes inactive.
+	// This is NOT about the minimum current over the entire transaction.
+	MinCurrent *float64 `json:"minCurrent,omitempty" yaml:"minCurrent,omitempty" mapstructure:"minCurrent,omitempty"`
+
+	// Minimum consumed energy in Wh, for example 20000 Wh.
+	// Valid from this amount of energy (inclusive) being used.
+	MinEnergy *float64 `json:"minEnergy,omitempty" yaml:"minEnergy,omitempty" mapstructure:"minEnergy,omitempty"`
+
+	// Minimum duration in seconds the idle period (i.e. not charging) MUST last
+	// (inclusive). When the duration of the idle time is longer than the given value,
+	// this price is or becomes active. Before that moment, this price is not yet
+	// active.
+	MinIdleTime *int `json:"minIdleTime,omitempty" yaml:"minIdleTime,omitempty" mapstructure:"minIdleTime,omitempty"`
+
+	// Minimum power in W, for example 5000. When the EV is charging with more than, or
+	// equal to, the defined amount of power, this price is/becomes active. If the
+	// charging power is or becomes lower, this price is not or no longer valid and
+	// becomes inactive.
+	// This is NOT about the minimum power over the entire transaction.
+	MinPower *float64 `json:"minPower,omitempty" yaml:"minPower,omitempty" mapstructure:"minPower,omitempty"`
+
+	// Minimum duration in seconds the transaction (charging & idle) MUST last
+	// (inclusive). When the duration of a transaction is longer than the given value,
+	// this price is or becomes active. Before that moment, this price is not yet
+	// active.
+	MinTime *int `json:"minTime,omitempty" yaml:"minTime,omitempty" mapstructure:"minTime,omitempty"`
+
+	// Start time of day in local time.
+	// Format as per RFC 3339: time-hour ":" time-minute
+	// Must be in 24h format with leading zeros. Hour/Minute separator: ":"
+	// Regex: ([0-1][0-9]\|2[0-3]):[0-5][0-9]
+	StartTimeOfDay *string `json:"startTimeOfDay,omitempty" yaml:"startTimeOfDay,omitempty" mapstructure:"startTimeOfDay,omitempty"`
+
+	// Start date in local time, for example: 2015-12-24.
+	// Valid from this day (inclusive).
+	// Format as per RFC 3339: full-date
+	//
+	// Regex: ([12][0-9]{3})-(0[1-9]\|1[0-2])-(0[1-9]\|[12][0-9]\|3[01])
+	ValidFromDate *string `json:"validFromDate,omitempty" yaml:"validFromDate,omitempty" mapstructure:"validFromDate,omitempty"`
+
+	// End date in local time, for example: 2015-12-27.
+	// Valid until this day (exclusive). Same syntax as _validFromDate_.
+	ValidToDate *string `json:"validToDate,omitempty" yaml:"validToDate,omitempty" mapstructure:"validToDate,omitempty"`
+}
+
+// These conditions describe if a FixedPrice applies at start of the transaction.
+//
+// When more than one restriction is set, they are to be treated as a logical AND.
+// All need to be valid before this price is active.
+//
+// NOTE: _startTimeOfDay_ and _endTimeOfDay_ are in local time, because it is the
+// time in the tariff as it is shown to the EV driver at the Charging Station.
+// A Charging Station will convert this to the internal time zone that it uses
+// (which is recommended to be UTC, see section Generic chapter 3.1) when
+// performing cost calculation.
+type TariffConditionsFixedType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Day(s) of the week this is tariff applies.
+	DayOfWeek []DayOfWeekEnumType `json:"dayOfWeek,omitempty" yaml:"dayOfWeek,omitempty" mapstructure:"dayOfWeek,omitempty"`
+
+	// End time of day in local time. Same syntax as _startTimeOfDay_.
+	// If end time < start time then the period wraps around to the next day.
+	// To stop at end of the day use: 00:00.
+	EndTimeOfDay *string `json:"endTimeOfDay,omitempty" yaml:"endTimeOfDay,omitempty" mapstructure:"endTimeOfDay,omitempty"`
+
+	// EvseKind corresponds to the JSON schema field "evseKind".
+	EvseKind *EvseKindEnumType `json:"evseKind,omitempty" yaml:"evseKind,omitempty" mapstructure:"evseKind,omitempty"`
+
+	// For which payment brand this (adhoc) tariff applies. Can be used to add a
+	// surcharge for certain payment brands.
+	// Based on value of _additionalIdToken_ from _idToken.additionalInfo.type_ =
+	// "PaymentBrand".
+	PaymentBrand *string `json:"paymentBrand,omitempty" yaml:"paymentBrand,omitempty" mapstructure:"paymentBrand,omitempty"`
+
+	// Type of adhoc payment, e.g. CC, Debit.
+	// Based on value of _additionalIdToken_ from _idToken.additionalInfo.type_ =
+	// "PaymentRecognition".
+	PaymentRecognition *string `json:"paymentRecognition,omitempty" yaml:"paymentRecognition,omitempty" mapstructure:"paymentRecognition,omitempty"`
+
+	// Start time of day in local time.
+	// Format as per RFC 3339: time-hour ":" time-minute
+	// Must be in 24h format with leading zeros. Hour/Minute separator: ":"
+	// Regex: ([0-1][0-9]\|2[0-3]):[0-5][0-9]
+	StartTimeOfDay *string `json:"startTimeOfDay,omitempty" yaml:"startTimeOfDay,omitempty" mapstructure:"startTimeOfDay,omitempty"`
+
+	// Start date in local time, for example: 2015-12-24.
+	// Valid from this day (inclusive).
+	// Format as per RFC 3339: full-date
+	//
+	// Regex: ([12][0-9]{3})-(0[1-9]\|1[0-2])-(0[1-9]\|[12][0-9]\|3[01])
+	ValidFromDate *string `json:"validFromDate,omitempty" yaml:"validFromDate,omitempty" mapstructure:"validFromDate,omitempty"`
+
+	// End date in local time, for example: 2015-12-27.
+	// Valid until this day (exclusive). Same syntax as _validFromDate_.
+	ValidToDate *string `json:"validToDate,omitempty" yaml:"validToDate,omitempty" mapstructure:"validToDate,omitempty"`
+}
+
+type DayOfWeekEnumType string
+
+const DayOfWeekEnumTypeMonday DayOfWeekEnumType = "Monday"
+const DayOfWeekEnumTypeTuesday DayOfWeekEnumType = "Tuesday"
+const DayOfWeekEnumTypeWednesday DayOfWeekEnumType = "Wednesday"
+const DayOfWeekEnumTypeThursday DayOfWeekEnumType = "Thursday"
+const DayOfWeekEnumTypeFriday DayOfWeekEnumType = "Friday"
+const DayOfWeekEnumTypeSaturday DayOfWeekEnumType = "Saturday"
+const DayOfWeekEnumTypeSunday DayOfWeekEnumType = "Sunday"
+
+// Type of EVSE (AC, DC) this tariff applies to.
+type EvseKindEnumType string
+
+const EvseKindEnumTypeAC EvseKindEnumType = "AC"
+const EvseKindEnumTypeDC EvseKindEnumType = "DC"
+
+type SetDefaultTariffRequestJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// EVSE that tariff applies to. When _evseId_ = 0, then tarriff applies to all
+	// EVSEs.
+	EvseId int `json:"evseId" yaml:"evseId" mapstructure:"evseId"`
+
+	// Tariff corresponds to the JSON schema field "tariff".
+	Tariff TariffType `json:"tariff" yaml:"tariff" mapstructure:"tariff"`
+}
+
+func (*SetDefaultTariffRequestJson) IsRequest() {}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Status of the operation.
+type TariffSetStatusEnumType string
+
+const TariffSetStatusEnumTypeAccepted TariffSetStatusEnumType = "Accepted"
+const TariffSetStatusEnumTypeRejected TariffSetStatusEnumType = "Rejected"
+const TariffSetStatusEnumTypeTooManyElements TariffSetStatusEnumType = "TooManyElements"
+const TariffSetStatusEnumTypeConditionNotSupported TariffSetStatusEnumType = "ConditionNotSupported"
+const TariffSetStatusEnumTypeDuplicateTariffId TariffSetStatusEnumType = "DuplicateTariffId"
+
+type SetDefaultTariffResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status TariffSetStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*SetDefaultTariffResponseJson) IsResponse() {}
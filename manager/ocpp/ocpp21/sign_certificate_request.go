@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type CertificateSigningUseEnumType string
+
+const CertificateSigningUseEnumTypeChargingStationCertificate CertificateSigningUseEnumType = "ChargingStationCertificate"
+const CertificateSigningUseEnumTypeV2GCertificate CertificateSigningUseEnumType = "V2GCertificate"
+const CertificateSigningUseEnumTypeV2G20Certificate CertificateSigningUseEnumType = "V2G20Certificate"
+
+type CertificateHashDataType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// HashAlgorithm corresponds to the JSON schema field "hashAlgorithm".
+	HashAlgorithm HashAlgorithmEnumType `json:"hashAlgorithm" yaml:"hashAlgorithm" mapstructure:"hashAlgorithm"`
+
+	// The hash of the DER encoded public key: the value (excluding tag and length) of
+	// the subject public key field in the issuer’s certificate.
+	IssuerKeyHash string `json:"issuerKeyHash" yaml:"issuerKeyHash" mapstructure:"issuerKeyHash"`
+
+	// The hash of the issuer’s distinguished name (DN), that must be calculated over
+	// the DER encoding of the issuer’s name field in the certificate being checked.
+	IssuerNameHash string `json:"issuerNameHash" yaml:"issuerNameHash" mapstructure:"issuerNameHash"`
+
+	// The string representation of the hexadecimal value of the serial number without
+	// the prefix "0x" and without leading zeroes.
+	SerialNumber string `json:"serialNumber" yaml:"serialNumber" mapstructure:"serialNumber"`
+}
+
+type SignCertificateRequestJson struct {
+	// CertificateType corresponds to the JSON schema field "certificateType".
+	CertificateType *CertificateSigningUseEnumType `json:"certificateType,omitempty" yaml:"certificateType,omitempty" mapstructure:"certificateType,omitempty"`
+
+	// The Charging Station SHALL send the public key in form of a Certificate Signing
+	// Request (CSR) as described in RFC 2986 [22] and then PEM encoded, using the
+	// &lt;&lt;signcertificaterequest,SignCertificateRequest&gt;&gt; message.
+	//
+	Csr string `json:"csr" yaml:"csr" mapstructure:"csr"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// HashRootCertificate corresponds to the JSON schema field "hashRootCertificate".
+	HashRootCertificate *CertificateHashDataType `json:"hashRootCertificate,omitempty" yaml:"hashRootCertificate,omitempty" mapstructure:"hashRootCertificate,omitempty"`
+
+	// *(2.1)* RequestId to match this message with the CertificateSignedRequest.
+	RequestId *int `json:"requestId,omitempty" yaml:"requestId,omitempty" mapstructure:"requestId,omitempty"`
+}
+
+func (*SignCertificateRequestJson) IsRequest() {}
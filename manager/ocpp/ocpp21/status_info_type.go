@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Element providing more information about the status.
+type StatusInfoType struct {
+	// Additional text to provide detailed information.
+	AdditionalInfo *string `json:"additionalInfo,omitempty" yaml:"additionalInfo,omitempty" mapstructure:"additionalInfo,omitempty"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// A predefined code for the reason why the status is returned in this response.
+	// The string is case-insensitive.
+	ReasonCode string `json:"reasonCode" yaml:"reasonCode" mapstructure:"reasonCode"`
+}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+type UsePriorityChargingRequestJson struct {
+	// True to request priority charging.
+	// False to request stopping priority charging.
+	Activate bool `json:"activate" yaml:"activate" mapstructure:"activate"`
+
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// The transaction for which priority charging is requested.
+	TransactionId string `json:"transactionId" yaml:"transactionId" mapstructure:"transactionId"`
+}
+
+func (*UsePriorityChargingRequestJson) IsRequest() {}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Result of the request.
+type PriorityChargingStatusEnumType string
+
+const PriorityChargingStatusEnumTypeAccepted PriorityChargingStatusEnumType = "Accepted"
+const PriorityChargingStatusEnumTypeRejected PriorityChargingStatusEnumType = "Rejected"
+const PriorityChargingStatusEnumTypeNoProfile PriorityChargingStatusEnumType = "NoProfile"
+
+type UsePriorityChargingResponseJson struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status PriorityChargingStatusEnumType `json:"status" yaml:"status" mapstructure:"status"`
+
+	// StatusInfo corresponds to the JSON schema field "statusInfo".
+	StatusInfo *StatusInfoType `json:"statusInfo,omitempty" yaml:"statusInfo,omitempty" mapstructure:"statusInfo,omitempty"`
+}
+
+func (*UsePriorityChargingResponseJson) IsResponse() {}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package ocpp21
+
+// Reference key to a component-variable.
+type VariableType struct {
+	// CustomData corresponds to the JSON schema field "customData".
+	CustomData *CustomDataType `json:"customData,omitempty" yaml:"customData,omitempty" mapstructure:"customData,omitempty"`
+
+	// Name of instance in case the variable exists as multiple instances. Case
+	// Insensitive. strongly advised to use Camel Case.
+	//
+	Instance *string `json:"instance,omitempty" yaml:"instance,omitempty" mapstructure:"instance,omitempty"`
+
+	// Name of the variable. Name should be taken from the list of standardized
+	// variable names whenever possible. Case Insensitive. strongly advised to use
+	// Camel Case.
+	//
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+}
@@ -23,8 +23,8 @@ import (
 	"github.com/thoughtworks/maeve-csms/manager/templates"
 )
 
-func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider) http.Handler {
-	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi)
+func NewApiHandler(settings config.ApiSettings, engine store.Engine, ocpi ocpi.Api, csCertProvider services.ChargeStationCertificateProvider, opts ...api.ServerOpt) http.Handler {
+	apiServer, err := api.NewServer(engine, clock.RealClock{}, ocpi, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive copies the contents of a store.Engine to and from an archive so that the
+// data can be moved between storage backends.
+//
+// An archive is newline-delimited JSON: the first line is a header that identifies the
+// format and its version, and every following line holds a single record. A record has a
+// kind, the id of the charge station that it belongs to (if any), a key for records that are
+// not stored under their own id and the data of the record itself.
+//
+// Records are written in an order that allows them to be imported into a backend that
+// enforces references between records: charge station authentication details first, then
+// the records that belong to a charge station.
+package archive
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Format identifies an archive
+	Format = "maeve-csms-store"
+	// Version is the version of the archive format that is written and that can be read
+	Version = 1
+)
+
+// Kind identifies the type of record held by an archive line
+type Kind string
+
+const (
+	KindChargeStationAuth                Kind = "chargeStationAuth"
+	KindChargeStationSettings            Kind = "chargeStationSettings"
+	KindChargeStationInstallCertificates Kind = "chargeStationInstallCertificates"
+	KindChargeStationTriggerMessage      Kind = "chargeStationTriggerMessage"
+	KindChargeStationDataTransfer        Kind = "chargeStationDataTransfer"
+	KindChargeStationClearCache          Kind = "chargeStationClearCache"
+	KindChargeStationChangeAvailability  Kind = "chargeStationChangeAvailability"
+	KindChargeStationCertificateQuery    Kind = "chargeStationCertificateQuery"
+	KindChargeStationCertificateDeletion Kind = "chargeStationCertificateDeletion"
+	KindFirmwareUpdateRequest            Kind = "firmwareUpdateRequest"
+	KindDiagnosticsRequest               Kind = "diagnosticsRequest"
+	KindLogRequest                       Kind = "logRequest"
+	KindRemoteStartTransactionRequest    Kind = "remoteStartTransactionRequest"
+	KindRemoteStopTransactionRequest     Kind = "remoteStopTransactionRequest"
+	KindToken                            Kind = "token"
+	KindCertificate                      Kind = "certificate"
+	KindLocation                         Kind = "location"
+	KindOcpiParty                        Kind = "ocpiParty"
+	KindOcpiRegistration                 Kind = "ocpiRegistration"
+	KindTransaction                      Kind = "transaction"
+	KindReservation                      Kind = "reservation"
+	KindChargeStationRuntimeDetails      Kind = "chargeStationRuntimeDetails"
+	KindChargeStationStatus              Kind = "chargeStationStatus"
+	KindConnectorStatus                  Kind = "connectorStatus"
+	KindLocalAuthList                    Kind = "localAuthList"
+	KindChargingProfile                  Kind = "chargingProfile"
+	KindDisplayMessage                   Kind = "displayMessage"
+	KindVariableMonitoring               Kind = "variableMonitoring"
+	KindChargeStationEvent               Kind = "chargeStationEvent"
+	KindDeviceReport                     Kind = "deviceReport"
+	KindCommand                          Kind = "command"
+	KindMeterValue                       Kind = "meterValue"
+	KindFirmwareUpdateStatus             Kind = "firmwareUpdateStatus"
+	KindDiagnosticsStatus                Kind = "diagnosticsStatus"
+	KindPublishFirmwareStatus            Kind = "publishFirmwareStatus"
+	KindLogStatus                        Kind = "logStatus"
+	KindResetRequest                     Kind = "resetRequest"
+	KindUnlockConnectorRequest           Kind = "unlockConnectorRequest"
+	KindDeadLetter                       Kind = "deadLetter"
+)
+
+// Counts holds the number of records of each kind that were exported or imported
+type Counts map[Kind]int
+
+// Total returns the number of records of every kind
+func (c Counts) Total() int {
+	total := 0
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type record struct {
+	Kind            Kind            `json:"kind"`
+	ChargeStationId string          `json:"chargeStationId,omitempty"`
+	Key             string          `json:"key,omitempty"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// localAuthList is the data of a KindLocalAuthList record: the whole list is held by a
+// single record so that it can be replaced in one update
+type localAuthList struct {
+	Version int                         `json:"version"`
+	Entries []*store.LocalAuthListEntry `json:"entries"`
+}
+
+type options struct {
+	clock    clock.PassiveClock
+	pageSize int
+	dryRun   bool
+}
+
+// Option configures an Export or an Import
+type Option func(*options)
+
+// WithClock sets the clock that is used to timestamp the archive header
+func WithClock(clock clock.PassiveClock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithPageSize sets the number of records that are read from the engine at a time
+func WithPageSize(pageSize int) Option {
+	return func(o *options) {
+		o.pageSize = pageSize
+	}
+}
+
+// WithDryRun makes Import read and count the records of the archive without writing them
+// to the engine
+func WithDryRun() Option {
+	return func(o *options) {
+		o.dryRun = true
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		clock:    clock.RealClock{},
+		pageSize: 100,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
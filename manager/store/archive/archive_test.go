@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/archive"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newPemCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cs001"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// populate adds records of most kinds to the engine
+func populate(t *testing.T, engine store.Engine, now time.Time) {
+	ctx := context.Background()
+
+	for _, chargeStationId := range []string{"cs001", "cs002"} {
+		require.NoError(t, engine.SetChargeStationAuth(ctx, chargeStationId, &store.ChargeStationAuth{
+			SecurityProfile:      store.TLSWithBasicAuth,
+			Base64SHA256Password: "DEADBEEF",
+		}))
+	}
+	require.NoError(t, engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		ChargeStationId: "cs001",
+		Settings: map[string]*store.ChargeStationSetting{
+			"HeartbeatInterval": {Value: "60", Status: store.ChargeStationSettingStatusPending, SendAfter: now},
+		},
+	}))
+	require.NoError(t, engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "1.6",
+	}))
+	require.NoError(t, engine.SetChargeStationStatus(ctx, "cs001", &store.ChargeStationStatus{
+		ChargeStationId: "cs001",
+		LastHeartbeat:   ptr(now),
+	}))
+	require.NoError(t, engine.SetChargeStationConnected(ctx, "cs001", false, now.Add(-time.Hour)))
+	require.NoError(t, engine.SetChargeStationConnected(ctx, "cs001", true, now.Add(-time.Minute)))
+	require.NoError(t, engine.SetConnectorStatus(ctx, "cs001", 1, &store.ConnectorStatus{
+		ChargeStationId: "cs001",
+		ConnectorId:     1,
+		Status:          store.ConnectorStatusAvailable,
+		ErrorCode:       store.ConnectorErrorCodeNoError,
+	}))
+	require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 3, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+		{IdTag: "token001", IdTagInfo: &store.IdTagInfo{Status: "Accepted"}},
+	}))
+	require.NoError(t, engine.SetChargingProfile(ctx, &store.ChargingProfile{
+		ChargeStationId:        "cs001",
+		ConnectorId:            1,
+		ChargingProfileId:      1,
+		ChargingProfilePurpose: store.ChargingProfilePurposeTxDefaultProfile,
+		ChargingProfileKind:    store.ChargingProfileKindAbsolute,
+		ChargingSchedule: store.ChargingSchedule{
+			ChargingRateUnit:       store.ChargingRateUnitA,
+			ChargingSchedulePeriod: []store.ChargingSchedulePeriod{{StartPeriod: 0, Limit: 16}},
+		},
+	}))
+	require.NoError(t, engine.SetDisplayMessage(ctx, &store.DisplayMessage{
+		ChargeStationId: "cs001",
+		Id:              1,
+		Priority:        store.MessagePriorityNormalCycle,
+		Message:         store.MessageContent{Content: "Hello", Format: store.MessageFormatASCII},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs001", &store.ChargeStationEvent{
+			Timestamp: now.Add(time.Duration(i) * time.Minute),
+			EventType: "Alerting",
+		}))
+	}
+	require.NoError(t, engine.CreateCommand(ctx, &store.Command{
+		Id:              "cmd001",
+		ChargeStationId: "cs001",
+		OcppVersion:     "1.6",
+		Action:          "Reset",
+		Status:          store.CommandStatusAccepted,
+		Request:         `{"type":"Soft"}`,
+		Response:        ptr(`{"status":"Accepted"}`),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}))
+	require.NoError(t, engine.SetResetRequest(ctx, "cs002", &store.ResetRequest{
+		ChargeStationId: "cs002",
+		Type:            store.ResetTypeSoft,
+		Status:          store.ResetRequestStatusPending,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}))
+
+	require.NoError(t, engine.SetToken(ctx, &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "token001",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   "ALWAYS",
+		LastUpdated: now.Format(time.RFC3339),
+	}))
+	require.NoError(t, engine.SetCertificate(ctx, newPemCertificate(t)))
+	require.NoError(t, engine.SetPartyDetails(ctx, &store.OcpiParty{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Role:        "EMSP",
+		Url:         "https://example.com/ocpi/versions",
+		Token:       "token001",
+	}))
+	require.NoError(t, engine.SetRegistrationDetails(ctx, "token002", &store.OcpiRegistration{
+		Status: store.OcpiRegistrationStatusRegistered,
+	}))
+
+	meterValues := []store.MeterValue{{
+		Timestamp:     now.Format(time.RFC3339),
+		SampledValues: []store.SampledValue{{Measurand: ptr("Energy.Active.Import.Register"), Value: 100}},
+	}}
+	require.NoError(t, engine.CreateTransaction(ctx, "cs001", "txn001", "token001", "ISO14443", meterValues, 1, false))
+	require.NoError(t, engine.EndTransaction(ctx, "cs001", "txn001", "token001", "ISO14443", nil, 2))
+	require.NoError(t, engine.UpdateTransactionCost(ctx, "cs001", "txn001", 1.5))
+	require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "txn001", meterValues))
+	require.NoError(t, engine.CreateReservation(ctx, &store.Reservation{
+		ReservationId:   1,
+		ChargeStationId: "cs001",
+		ConnectorId:     1,
+		IdTag:           "token001",
+		ExpiryDate:      now.Add(time.Hour),
+		Status:          store.ReservationStatusAccepted,
+		CreatedAt:       now,
+	}))
+
+	require.NoError(t, engine.AddDeadLetter(ctx, &store.DeadLetter{
+		Id:              "dl001",
+		ChargeStationId: "cs001",
+		OcppVersion:     "1.6",
+		MessageType:     "call",
+		Action:          "Heartbeat",
+		MessageId:       "msg001",
+		Message:         `{}`,
+		Error:           "failed",
+		CreatedAt:       now,
+	}))
+}
+
+// readRecords returns the records of an archive: the times that the in-memory store sets from
+// the real clock are removed
+func readRecords(t *testing.T, b []byte) []map[string]any {
+	var records []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Scan() // header
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		if data, ok := record["data"].(map[string]any); ok {
+			delete(data, "UpdatedAt")
+		}
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestExportAndImport(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	source := inmemory.NewStore(clock)
+	populate(t, source, clock.Now())
+
+	var exported bytes.Buffer
+	exportCounts, err := archive.Export(ctx, source, &exported, archive.WithClock(clock), archive.WithPageSize(2))
+	require.NoError(t, err)
+
+	header, _, _ := strings.Cut(exported.String(), "\n")
+	assert.JSONEq(t, `{"format":"maeve-csms-store","version":1,"createdAt":"2024-01-02T03:04:05Z"}`, header)
+	assert.Equal(t, 2, exportCounts[archive.KindChargeStationAuth])
+	assert.Equal(t, 3, exportCounts[archive.KindChargeStationEvent])
+	assert.Equal(t, 1, exportCounts[archive.KindTransaction])
+	assert.Equal(t, 1, exportCounts[archive.KindMeterValue])
+
+	target := inmemory.NewStore(clock)
+	importCounts, err := archive.Import(ctx, target, bytes.NewReader(exported.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, exportCounts, importCounts)
+
+	var reexported bytes.Buffer
+	_, err = archive.Export(ctx, target, &reexported, archive.WithClock(clock), archive.WithPageSize(2))
+	require.NoError(t, err)
+	assert.Equal(t, readRecords(t, exported.Bytes()), readRecords(t, reexported.Bytes()))
+
+	transaction, err := target.FindTransaction(ctx, "cs001", "txn001")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, 2, transaction.EndedSeqNo)
+	assert.Equal(t, ptr(1.5), transaction.LastCost)
+
+	status, err := target.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	assert.True(t, status.Connected)
+	assert.Equal(t, ptr(clock.Now().Add(-time.Minute)), status.LastConnected)
+	assert.Equal(t, ptr(clock.Now().Add(-time.Hour)), status.LastDisconnected)
+}
+
+func TestImportDryRun(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Now())
+
+	source := inmemory.NewStore(clock)
+	populate(t, source, clock.Now())
+
+	var exported bytes.Buffer
+	exportCounts, err := archive.Export(ctx, source, &exported)
+	require.NoError(t, err)
+
+	target := inmemory.NewStore(clock)
+	importCounts, err := archive.Import(ctx, target, &exported, archive.WithDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, exportCounts, importCounts)
+
+	ids, err := target.ListChargeStationIds(ctx, 10, "")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestImportRejectsUnsupportedArchives(t *testing.T) {
+	ctx := context.Background()
+	engine := inmemory.NewStore(clockTest.NewFakePassiveClock(time.Now()))
+
+	_, err := archive.Import(ctx, engine, strings.NewReader(`{"format":"other","version":1}`+"\n"))
+	assert.ErrorContains(t, err, "unknown archive format")
+
+	_, err = archive.Import(ctx, engine, strings.NewReader(`{"format":"maeve-csms-store","version":2}`+"\n"))
+	assert.ErrorContains(t, err, "unsupported archive version: 2")
+
+	_, err = archive.Import(ctx, engine, strings.NewReader(`{"format":"maeve-csms-store","version":1}`+"\n"+
+		`{"kind":"unknown","data":{}}`+"\n"))
+	assert.ErrorContains(t, err, "import record 1 (unknown): unknown record kind")
+}
+
+func TestImportIntoAnotherBackend(t *testing.T) {
+	ctx := context.Background()
+	clock := clockTest.NewFakePassiveClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	source := inmemory.NewStore(clock)
+	populate(t, source, clock.Now())
+
+	var exported bytes.Buffer
+	exportCounts, err := archive.Export(ctx, source, &exported, archive.WithClock(clock))
+	require.NoError(t, err)
+
+	target, err := sqlite.NewStore(ctx, filepath.Join(t.TempDir(), "csms.db"), clock)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = target.Close()
+	})
+	_, err = archive.Import(ctx, target, bytes.NewReader(exported.Bytes()))
+	require.NoError(t, err)
+
+	var reexported bytes.Buffer
+	reexportCounts, err := archive.Export(ctx, target, &reexported, archive.WithClock(clock))
+	require.NoError(t, err)
+	assert.Equal(t, exportCounts, reexportCounts)
+	assert.Equal(t, readRecords(t, exported.Bytes()), readRecords(t, reexported.Bytes()))
+}
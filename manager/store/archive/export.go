@@ -0,0 +1,567 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// ocpiRoles are the roles that OCPI parties can be registered with
+var ocpiRoles = []string{"CPO", "EMSP", "HUB", "NAP", "NSP", "OTHER", "SCSP"}
+
+type exporter struct {
+	engine   store.Engine
+	encoder  *json.Encoder
+	pageSize int
+	counts   Counts
+	// chargeStationIds holds the ids of the charge stations whose records are exported
+	chargeStationIds map[string]struct{}
+}
+
+// Export writes every record held by the engine to w and returns the number of records of
+// each kind that were written. The engine should not be in use while it is exported as
+// records that are changed during the export may be missed.
+func Export(ctx context.Context, engine store.Engine, w io.Writer, opts ...Option) (Counts, error) {
+	o := newOptions(opts)
+
+	bw := bufio.NewWriter(w)
+	e := &exporter{
+		engine:           engine,
+		encoder:          json.NewEncoder(bw),
+		pageSize:         o.pageSize,
+		counts:           make(Counts),
+		chargeStationIds: make(map[string]struct{}),
+	}
+
+	err := e.encoder.Encode(header{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: o.clock.Now().UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+
+	for _, export := range []func(context.Context) error{
+		e.exportChargeStationAuth,
+		e.exportChargeStationRecords,
+		e.exportTokens,
+		e.exportCertificates,
+		e.exportLocations,
+		e.exportOcpi,
+		e.exportTransactions,
+		e.exportReservations,
+		e.exportChargeStationDetails,
+		e.exportDeadLetters,
+	} {
+		if err := export(ctx); err != nil {
+			return e.counts, err
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return e.counts, fmt.Errorf("write archive: %w", err)
+	}
+	return e.counts, nil
+}
+
+// write writes a single record to the archive
+func (e *exporter) write(kind Kind, chargeStationId, key string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", kind, err)
+	}
+	err = e.encoder.Encode(record{
+		Kind:            kind,
+		ChargeStationId: chargeStationId,
+		Key:             key,
+		Data:            b,
+	})
+	if err != nil {
+		return fmt.Errorf("write %s: %w", kind, err)
+	}
+	e.counts[kind]++
+	return nil
+}
+
+func (e *exporter) exportChargeStationAuth(ctx context.Context) error {
+	previousChargeStationId := ""
+	for {
+		chargeStationIds, err := e.engine.ListChargeStationIds(ctx, e.pageSize, previousChargeStationId)
+		if err != nil {
+			return err
+		}
+		for _, chargeStationId := range chargeStationIds {
+			auth, err := e.engine.LookupChargeStationAuth(ctx, chargeStationId)
+			if err != nil {
+				return err
+			}
+			if auth == nil {
+				continue
+			}
+			if err := e.write(KindChargeStationAuth, chargeStationId, "", auth); err != nil {
+				return err
+			}
+			e.chargeStationIds[chargeStationId] = struct{}{}
+		}
+		if len(chargeStationIds) < e.pageSize {
+			return nil
+		}
+		previousChargeStationId = chargeStationIds[len(chargeStationIds)-1]
+	}
+}
+
+// exportPages returns an export function that writes the records returned by a list function
+// that pages through the charge stations in id order
+func exportPages[T any](e *exporter, kind Kind,
+	list func(ctx context.Context, pageSize int, previousChargeStationId string) ([]*T, error),
+	chargeStationId func(*T) string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		previousChargeStationId := ""
+		for {
+			records, err := list(ctx, e.pageSize, previousChargeStationId)
+			if err != nil {
+				return err
+			}
+			for _, r := range records {
+				id := chargeStationId(r)
+				if err := e.write(kind, id, "", r); err != nil {
+					return err
+				}
+				e.chargeStationIds[id] = struct{}{}
+			}
+			if len(records) < e.pageSize {
+				return nil
+			}
+			previousChargeStationId = chargeStationId(records[len(records)-1])
+		}
+	}
+}
+
+func (e *exporter) exportChargeStationRecords(ctx context.Context) error {
+	engine := e.engine
+	for _, export := range []func(context.Context) error{
+		exportPages(e, KindChargeStationSettings, engine.ListChargeStationSettings,
+			func(r *store.ChargeStationSettings) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationInstallCertificates, engine.ListChargeStationInstallCertificates,
+			func(r *store.ChargeStationInstallCertificates) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationTriggerMessage, engine.ListChargeStationTriggerMessages,
+			func(r *store.ChargeStationTriggerMessage) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationDataTransfer, engine.ListChargeStationDataTransfers,
+			func(r *store.ChargeStationDataTransfer) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationClearCache, engine.ListChargeStationClearCaches,
+			func(r *store.ChargeStationClearCache) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationChangeAvailability, engine.ListChargeStationChangeAvailabilities,
+			func(r *store.ChargeStationChangeAvailability) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationCertificateQuery, engine.ListChargeStationCertificateQueries,
+			func(r *store.ChargeStationCertificateQuery) string { return r.ChargeStationId }),
+		exportPages(e, KindChargeStationCertificateDeletion, engine.ListChargeStationCertificateDeletions,
+			func(r *store.ChargeStationCertificateDeletion) string { return r.ChargeStationId }),
+		exportPages(e, KindFirmwareUpdateRequest, engine.ListFirmwareUpdateRequests,
+			func(r *store.FirmwareUpdateRequest) string { return r.ChargeStationId }),
+		exportPages(e, KindDiagnosticsRequest, engine.ListDiagnosticsRequests,
+			func(r *store.DiagnosticsRequest) string { return r.ChargeStationId }),
+		exportPages(e, KindLogRequest, engine.ListLogRequests,
+			func(r *store.LogRequest) string { return r.ChargeStationId }),
+		exportPages(e, KindRemoteStartTransactionRequest, engine.ListRemoteStartTransactionRequests,
+			func(r *store.RemoteStartTransactionRequest) string { return r.ChargeStationId }),
+		exportPages(e, KindRemoteStopTransactionRequest, engine.ListRemoteStopTransactionRequests,
+			func(r *store.RemoteStopTransactionRequest) string { return r.ChargeStationId }),
+	} {
+		if err := export(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportTokens(ctx context.Context) error {
+	for offset := 0; ; offset += e.pageSize {
+		tokens, err := e.engine.ListTokens(ctx, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			if err := e.write(KindToken, "", "", token); err != nil {
+				return err
+			}
+		}
+		if len(tokens) < e.pageSize {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) exportCertificates(ctx context.Context) error {
+	previousCertificateHash := ""
+	for {
+		certificateHashes, err := e.engine.ListCertificateHashes(ctx, e.pageSize, previousCertificateHash)
+		if err != nil {
+			return err
+		}
+		for _, certificateHash := range certificateHashes {
+			pemCertificate, err := e.engine.LookupCertificate(ctx, certificateHash)
+			if err != nil {
+				return err
+			}
+			if pemCertificate == "" {
+				continue
+			}
+			if err := e.write(KindCertificate, "", "", pemCertificate); err != nil {
+				return err
+			}
+		}
+		if len(certificateHashes) < e.pageSize {
+			return nil
+		}
+		previousCertificateHash = certificateHashes[len(certificateHashes)-1]
+	}
+}
+
+func (e *exporter) exportLocations(ctx context.Context) error {
+	for offset := 0; ; offset += e.pageSize {
+		locations, err := e.engine.ListLocations(ctx, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, location := range locations {
+			if err := e.write(KindLocation, "", "", location); err != nil {
+				return err
+			}
+		}
+		if len(locations) < e.pageSize {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) exportOcpi(ctx context.Context) error {
+	for _, role := range ocpiRoles {
+		parties, err := e.engine.ListPartyDetailsForRole(ctx, role)
+		if err != nil {
+			return err
+		}
+		for _, party := range parties {
+			if err := e.write(KindOcpiParty, "", "", party); err != nil {
+				return err
+			}
+		}
+	}
+
+	previousToken := ""
+	for {
+		tokens, err := e.engine.ListRegistrationTokens(ctx, e.pageSize, previousToken)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			registration, err := e.engine.GetRegistrationDetails(ctx, token)
+			if err != nil {
+				return err
+			}
+			if registration == nil {
+				continue
+			}
+			if err := e.write(KindOcpiRegistration, "", token, registration); err != nil {
+				return err
+			}
+		}
+		if len(tokens) < e.pageSize {
+			return nil
+		}
+		previousToken = tokens[len(tokens)-1]
+	}
+}
+
+func (e *exporter) exportTransactions(ctx context.Context) error {
+	transactions, err := e.engine.Transactions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if err := e.write(KindTransaction, transaction.ChargeStationId, "", transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportReservations(ctx context.Context) error {
+	previousReservationId := 0
+	for {
+		reservations, err := e.engine.ListReservations(ctx, e.pageSize, previousReservationId)
+		if err != nil {
+			return err
+		}
+		for _, reservation := range reservations {
+			if err := e.write(KindReservation, reservation.ChargeStationId, "", reservation); err != nil {
+				return err
+			}
+		}
+		if len(reservations) < e.pageSize {
+			return nil
+		}
+		previousReservationId = reservations[len(reservations)-1].ReservationId
+	}
+}
+
+// exportChargeStationDetails writes the records that can only be read for a given charge
+// station for each of the charge stations that have been exported so far
+func (e *exporter) exportChargeStationDetails(ctx context.Context) error {
+	chargeStationIds := make([]string, 0, len(e.chargeStationIds))
+	for chargeStationId := range e.chargeStationIds {
+		chargeStationIds = append(chargeStationIds, chargeStationId)
+	}
+	sort.Strings(chargeStationIds)
+
+	for _, chargeStationId := range chargeStationIds {
+		for _, export := range []func(context.Context, string) error{
+			e.exportRuntimeDetails,
+			e.exportStatus,
+			e.exportLocalAuthList,
+			e.exportChargingProfiles,
+			e.exportDisplayMessages,
+			e.exportVariableMonitoring,
+			e.exportEvents,
+			e.exportDeviceReports,
+			e.exportCommands,
+			e.exportMeterValues,
+			e.exportSingletons,
+		} {
+			if err := export(ctx, chargeStationId); err != nil {
+				return fmt.Errorf("export charge station %s: %w", chargeStationId, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportRuntimeDetails(ctx context.Context, chargeStationId string) error {
+	details, err := e.engine.LookupChargeStationRuntimeDetails(ctx, chargeStationId)
+	if err != nil || details == nil {
+		return err
+	}
+	return e.write(KindChargeStationRuntimeDetails, chargeStationId, "", details)
+}
+
+func (e *exporter) exportStatus(ctx context.Context, chargeStationId string) error {
+	status, err := e.engine.GetChargeStationStatus(ctx, chargeStationId)
+	if err != nil && !errors.Is(err, store.ErrChargeStationStatusNotFound) {
+		return err
+	}
+	if status != nil {
+		if err := e.write(KindChargeStationStatus, chargeStationId, "", status); err != nil {
+			return err
+		}
+	}
+
+	connectorStatuses, err := e.engine.ListConnectorStatuses(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	for _, connectorStatus := range connectorStatuses {
+		if err := e.write(KindConnectorStatus, chargeStationId, "", connectorStatus); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportLocalAuthList(ctx context.Context, chargeStationId string) error {
+	version, err := e.engine.GetLocalListVersion(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	entries, err := e.engine.GetLocalAuthList(ctx, chargeStationId)
+	if err != nil {
+		return err
+	}
+	if version == 0 && len(entries) == 0 {
+		return nil
+	}
+	return e.write(KindLocalAuthList, chargeStationId, "", localAuthList{Version: version, Entries: entries})
+}
+
+func (e *exporter) exportChargingProfiles(ctx context.Context, chargeStationId string) error {
+	profiles, err := e.engine.GetChargingProfiles(ctx, chargeStationId, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		if err := e.write(KindChargingProfile, chargeStationId, "", profile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportDisplayMessages(ctx context.Context, chargeStationId string) error {
+	messages, err := e.engine.ListDisplayMessages(ctx, chargeStationId, nil, nil)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if err := e.write(KindDisplayMessage, chargeStationId, "", message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportVariableMonitoring(ctx context.Context, chargeStationId string) error {
+	for offset := 0; ; offset += e.pageSize {
+		configs, err := e.engine.ListVariableMonitoring(ctx, chargeStationId, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, config := range configs {
+			if err := e.write(KindVariableMonitoring, chargeStationId, "", config); err != nil {
+				return err
+			}
+		}
+		if len(configs) < e.pageSize {
+			return nil
+		}
+	}
+}
+
+// exportEvents writes the events oldest first so that they are given ids in the same order
+// when they are imported
+func (e *exporter) exportEvents(ctx context.Context, chargeStationId string) error {
+	var events []*store.ChargeStationEvent
+	for offset := 0; ; offset += e.pageSize {
+		page, total, err := e.engine.ListChargeStationEvents(ctx, chargeStationId, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		events = append(events, page...)
+		if len(page) == 0 || offset+len(page) >= total {
+			break
+		}
+	}
+	slices.Reverse(events)
+	for _, event := range events {
+		if err := e.write(KindChargeStationEvent, chargeStationId, "", event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportDeviceReports writes the reports oldest first so that they are given ids in the same
+// order when they are imported
+func (e *exporter) exportDeviceReports(ctx context.Context, chargeStationId string) error {
+	var reports []*store.DeviceReport
+	for offset := 0; ; offset += e.pageSize {
+		page, total, err := e.engine.ListDeviceReports(ctx, chargeStationId, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, page...)
+		if len(page) == 0 || offset+len(page) >= total {
+			break
+		}
+	}
+	slices.Reverse(reports)
+	for _, report := range reports {
+		if err := e.write(KindDeviceReport, chargeStationId, "", report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportCommands(ctx context.Context, chargeStationId string) error {
+	for offset := 0; ; offset += e.pageSize {
+		commands, total, err := e.engine.ListCommands(ctx, chargeStationId, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, command := range commands {
+			if err := e.write(KindCommand, chargeStationId, "", command); err != nil {
+				return err
+			}
+		}
+		if len(commands) == 0 || offset+len(commands) >= total {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) exportMeterValues(ctx context.Context, chargeStationId string) error {
+	for offset := 0; ; offset += e.pageSize {
+		result, err := e.engine.QueryMeterValues(ctx, store.MeterValuesFilter{
+			ChargeStationId: chargeStationId,
+			Limit:           e.pageSize,
+			Offset:          offset,
+		})
+		if err != nil {
+			return err
+		}
+		for _, meterValue := range result.MeterValues {
+			if err := e.write(KindMeterValue, chargeStationId, "", meterValue); err != nil {
+				return err
+			}
+		}
+		if len(result.MeterValues) == 0 || offset+len(result.MeterValues) >= result.Total {
+			return nil
+		}
+	}
+}
+
+// exportSingletons writes the records that a charge station has at most one of and that
+// cannot be listed across charge stations
+func (e *exporter) exportSingletons(ctx context.Context, chargeStationId string) error {
+	for _, export := range []func(context.Context, string) error{
+		exportSingleton(e, KindFirmwareUpdateStatus, e.engine.GetFirmwareUpdateStatus),
+		exportSingleton(e, KindDiagnosticsStatus, e.engine.GetDiagnosticsStatus),
+		exportSingleton(e, KindPublishFirmwareStatus, e.engine.GetPublishFirmwareStatus),
+		exportSingleton(e, KindLogStatus, e.engine.GetLogStatus),
+		exportSingleton(e, KindResetRequest, e.engine.GetResetRequest),
+		exportSingleton(e, KindUnlockConnectorRequest, e.engine.GetUnlockConnectorRequest),
+	} {
+		if err := export(ctx, chargeStationId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportSingleton returns an export function that writes the record returned by a lookup
+// function if the charge station has one
+func exportSingleton[T any](e *exporter, kind Kind,
+	get func(ctx context.Context, chargeStationId string) (*T, error)) func(context.Context, string) error {
+	return func(ctx context.Context, chargeStationId string) error {
+		r, err := get(ctx, chargeStationId)
+		if err != nil || r == nil {
+			return err
+		}
+		return e.write(kind, chargeStationId, "", r)
+	}
+}
+
+func (e *exporter) exportDeadLetters(ctx context.Context) error {
+	for offset := 0; ; offset += e.pageSize {
+		deadLetters, total, err := e.engine.ListDeadLetters(ctx, offset, e.pageSize)
+		if err != nil {
+			return err
+		}
+		for _, deadLetter := range deadLetters {
+			if err := e.write(KindDeadLetter, deadLetter.ChargeStationId, "", deadLetter); err != nil {
+				return err
+			}
+		}
+		if len(deadLetters) == 0 || offset+len(deadLetters) >= total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// Import reads the records from an archive written by Export and writes them to the engine,
+// returning the number of records of each kind that were read. The engine is expected to be
+// empty: records that already exist are overwritten.
+//
+// Charge station events and device reports are given new ids by the engine. The number of
+// updates that have been received for a transaction is not restored.
+func Import(ctx context.Context, engine store.Engine, r io.Reader, opts ...Option) (Counts, error) {
+	o := newOptions(opts)
+	decoder := json.NewDecoder(r)
+
+	var h header
+	if err := decoder.Decode(&h); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if h.Format != Format {
+		return nil, fmt.Errorf("unknown archive format: %q", h.Format)
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version: %d", h.Version)
+	}
+
+	counts := make(Counts)
+	for n := 1; ; n++ {
+		var rec record
+		err := decoder.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			return counts, nil
+		}
+		if err != nil {
+			return counts, fmt.Errorf("read record %d: %w", n, err)
+		}
+		if err := importRecord(ctx, engine, &rec, o.dryRun); err != nil {
+			return counts, fmt.Errorf("import record %d (%s): %w", n, rec.Kind, err)
+		}
+		counts[rec.Kind]++
+	}
+}
+
+// importRecord decodes the data of the record and, unless this is a dry run, writes it to the engine
+func importRecord(ctx context.Context, engine store.Engine, rec *record, dryRun bool) error {
+	chargeStationId := rec.ChargeStationId
+	switch rec.Kind {
+	case KindChargeStationAuth:
+		return importData(rec, dryRun, func(auth *store.ChargeStationAuth) error {
+			return engine.SetChargeStationAuth(ctx, chargeStationId, auth)
+		})
+	case KindChargeStationSettings:
+		return importData(rec, dryRun, func(settings *store.ChargeStationSettings) error {
+			return engine.UpdateChargeStationSettings(ctx, chargeStationId, settings)
+		})
+	case KindChargeStationInstallCertificates:
+		return importData(rec, dryRun, func(certificates *store.ChargeStationInstallCertificates) error {
+			return engine.UpdateChargeStationInstallCertificates(ctx, chargeStationId, certificates)
+		})
+	case KindChargeStationTriggerMessage:
+		return importData(rec, dryRun, func(triggerMessage *store.ChargeStationTriggerMessage) error {
+			return engine.SetChargeStationTriggerMessage(ctx, chargeStationId, triggerMessage)
+		})
+	case KindChargeStationDataTransfer:
+		return importData(rec, dryRun, func(dataTransfer *store.ChargeStationDataTransfer) error {
+			return engine.SetChargeStationDataTransfer(ctx, chargeStationId, dataTransfer)
+		})
+	case KindChargeStationClearCache:
+		return importData(rec, dryRun, func(clearCache *store.ChargeStationClearCache) error {
+			return engine.SetChargeStationClearCache(ctx, chargeStationId, clearCache)
+		})
+	case KindChargeStationChangeAvailability:
+		return importData(rec, dryRun, func(changeAvailability *store.ChargeStationChangeAvailability) error {
+			return engine.SetChargeStationChangeAvailability(ctx, chargeStationId, changeAvailability)
+		})
+	case KindChargeStationCertificateQuery:
+		return importData(rec, dryRun, func(query *store.ChargeStationCertificateQuery) error {
+			return engine.SetChargeStationCertificateQuery(ctx, chargeStationId, query)
+		})
+	case KindChargeStationCertificateDeletion:
+		return importData(rec, dryRun, func(deletion *store.ChargeStationCertificateDeletion) error {
+			return engine.SetChargeStationCertificateDeletion(ctx, chargeStationId, deletion)
+		})
+	case KindFirmwareUpdateRequest:
+		return importData(rec, dryRun, func(request *store.FirmwareUpdateRequest) error {
+			return engine.SetFirmwareUpdateRequest(ctx, chargeStationId, request)
+		})
+	case KindDiagnosticsRequest:
+		return importData(rec, dryRun, func(request *store.DiagnosticsRequest) error {
+			return engine.SetDiagnosticsRequest(ctx, chargeStationId, request)
+		})
+	case KindLogRequest:
+		return importData(rec, dryRun, func(request *store.LogRequest) error {
+			return engine.SetLogRequest(ctx, chargeStationId, request)
+		})
+	case KindRemoteStartTransactionRequest:
+		return importData(rec, dryRun, func(request *store.RemoteStartTransactionRequest) error {
+			return engine.SetRemoteStartTransactionRequest(ctx, chargeStationId, request)
+		})
+	case KindRemoteStopTransactionRequest:
+		return importData(rec, dryRun, func(request *store.RemoteStopTransactionRequest) error {
+			return engine.SetRemoteStopTransactionRequest(ctx, chargeStationId, request)
+		})
+	case KindToken:
+		return importData(rec, dryRun, func(token *store.Token) error {
+			return engine.SetToken(ctx, token)
+		})
+	case KindCertificate:
+		return importData(rec, dryRun, func(pemCertificate *string) error {
+			return engine.SetCertificate(ctx, *pemCertificate)
+		})
+	case KindLocation:
+		return importData(rec, dryRun, func(location *store.Location) error {
+			return engine.SetLocation(ctx, location)
+		})
+	case KindOcpiParty:
+		return importData(rec, dryRun, func(party *store.OcpiParty) error {
+			return engine.SetPartyDetails(ctx, party)
+		})
+	case KindOcpiRegistration:
+		return importData(rec, dryRun, func(registration *store.OcpiRegistration) error {
+			return engine.SetRegistrationDetails(ctx, rec.Key, registration)
+		})
+	case KindTransaction:
+		return importData(rec, dryRun, func(transaction *store.Transaction) error {
+			return importTransaction(ctx, engine, transaction)
+		})
+	case KindReservation:
+		return importData(rec, dryRun, func(reservation *store.Reservation) error {
+			return engine.CreateReservation(ctx, reservation)
+		})
+	case KindChargeStationRuntimeDetails:
+		return importData(rec, dryRun, func(details *store.ChargeStationRuntimeDetails) error {
+			return engine.SetChargeStationRuntimeDetails(ctx, chargeStationId, details)
+		})
+	case KindChargeStationStatus:
+		return importData(rec, dryRun, func(status *store.ChargeStationStatus) error {
+			return importChargeStationStatus(ctx, engine, chargeStationId, status)
+		})
+	case KindConnectorStatus:
+		return importData(rec, dryRun, func(status *store.ConnectorStatus) error {
+			return engine.SetConnectorStatus(ctx, chargeStationId, status.ConnectorId, status)
+		})
+	case KindLocalAuthList:
+		return importData(rec, dryRun, func(list *localAuthList) error {
+			return engine.UpdateLocalAuthList(ctx, chargeStationId, list.Version, store.LocalAuthListUpdateTypeFull, list.Entries)
+		})
+	case KindChargingProfile:
+		return importData(rec, dryRun, func(profile *store.ChargingProfile) error {
+			return engine.SetChargingProfile(ctx, profile)
+		})
+	case KindDisplayMessage:
+		return importData(rec, dryRun, func(message *store.DisplayMessage) error {
+			return engine.SetDisplayMessage(ctx, message)
+		})
+	case KindVariableMonitoring:
+		return importData(rec, dryRun, func(config *store.VariableMonitoringConfig) error {
+			return engine.SetVariableMonitoring(ctx, chargeStationId, config)
+		})
+	case KindChargeStationEvent:
+		return importData(rec, dryRun, func(event *store.ChargeStationEvent) error {
+			return engine.AddChargeStationEvent(ctx, chargeStationId, event)
+		})
+	case KindDeviceReport:
+		return importData(rec, dryRun, func(report *store.DeviceReport) error {
+			return engine.AddDeviceReport(ctx, chargeStationId, report)
+		})
+	case KindCommand:
+		return importData(rec, dryRun, func(command *store.Command) error {
+			return engine.CreateCommand(ctx, command)
+		})
+	case KindMeterValue:
+		return importData(rec, dryRun, func(meterValue *store.StoredMeterValue) error {
+			return engine.StoreMeterValues(ctx, chargeStationId, meterValue.EvseId, meterValue.TransactionId,
+				[]store.MeterValue{meterValue.MeterValue})
+		})
+	case KindFirmwareUpdateStatus:
+		return importData(rec, dryRun, func(status *store.FirmwareUpdateStatus) error {
+			return engine.SetFirmwareUpdateStatus(ctx, chargeStationId, status)
+		})
+	case KindDiagnosticsStatus:
+		return importData(rec, dryRun, func(status *store.DiagnosticsStatus) error {
+			return engine.SetDiagnosticsStatus(ctx, chargeStationId, status)
+		})
+	case KindPublishFirmwareStatus:
+		return importData(rec, dryRun, func(status *store.PublishFirmwareStatus) error {
+			return engine.SetPublishFirmwareStatus(ctx, chargeStationId, status)
+		})
+	case KindLogStatus:
+		return importData(rec, dryRun, func(status *store.LogStatus) error {
+			return engine.SetLogStatus(ctx, chargeStationId, status)
+		})
+	case KindResetRequest:
+		return importData(rec, dryRun, func(request *store.ResetRequest) error {
+			return engine.SetResetRequest(ctx, chargeStationId, request)
+		})
+	case KindUnlockConnectorRequest:
+		return importData(rec, dryRun, func(request *store.UnlockConnectorRequest) error {
+			return engine.SetUnlockConnectorRequest(ctx, chargeStationId, request)
+		})
+	case KindDeadLetter:
+		return importData(rec, dryRun, func(deadLetter *store.DeadLetter) error {
+			return engine.AddDeadLetter(ctx, deadLetter)
+		})
+	default:
+		return fmt.Errorf("unknown record kind")
+	}
+}
+
+// importData decodes the data of the record and passes it to set unless this is a dry run
+func importData[T any](rec *record, dryRun bool, set func(*T) error) error {
+	var data T
+	if err := json.Unmarshal(rec.Data, &data); err != nil {
+		return fmt.Errorf("decode data: %w", err)
+	}
+	if dryRun {
+		return nil
+	}
+	return set(&data)
+}
+
+// importTransaction recreates the transaction through the same calls that are made as a
+// transaction progresses
+func importTransaction(ctx context.Context, engine store.Engine, transaction *store.Transaction) error {
+	err := engine.CreateTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId,
+		transaction.IdToken, transaction.TokenType, transaction.MeterValues, transaction.StartSeqNo, transaction.Offline)
+	if err != nil {
+		return err
+	}
+	if transaction.EndedSeqNo != 0 {
+		err = engine.EndTransaction(ctx, transaction.ChargeStationId, transaction.TransactionId,
+			transaction.IdToken, transaction.TokenType, nil, transaction.EndedSeqNo)
+		if err != nil {
+			return err
+		}
+	}
+	if transaction.LastCost != nil {
+		return engine.UpdateTransactionCost(ctx, transaction.ChargeStationId, transaction.TransactionId, *transaction.LastCost)
+	}
+	return nil
+}
+
+// importChargeStationStatus sets the status and then replays the connection changes as the
+// connection times are only maintained by SetChargeStationConnected
+func importChargeStationStatus(ctx context.Context, engine store.Engine, chargeStationId string, status *store.ChargeStationStatus) error {
+	if err := engine.SetChargeStationStatus(ctx, chargeStationId, status); err != nil {
+		return err
+	}
+
+	type connectionChange struct {
+		connected bool
+		timestamp *time.Time
+	}
+	changes := []connectionChange{
+		{connected: true, timestamp: status.LastConnected},
+		{connected: false, timestamp: status.LastDisconnected},
+	}
+	if status.LastConnected != nil && status.LastDisconnected != nil && status.LastConnected.After(*status.LastDisconnected) {
+		changes[0], changes[1] = changes[1], changes[0]
+	}
+	for _, change := range changes {
+		if change.timestamp == nil {
+			continue
+		}
+		if err := engine.SetChargeStationConnected(ctx, chargeStationId, change.connected, *change.timestamp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
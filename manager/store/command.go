@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// CommandStatus represents where a command is in its lifecycle
+type CommandStatus string
+
+var (
+	CommandStatusQueued   CommandStatus = "Queued"
+	CommandStatusSent     CommandStatus = "Sent"
+	CommandStatusAccepted CommandStatus = "Accepted"
+	CommandStatusRejected CommandStatus = "Rejected"
+	CommandStatusTimedOut CommandStatus = "TimedOut"
+	CommandStatusFailed   CommandStatus = "Failed"
+)
+
+// Command represents a call made by the CSMS to a charge station. The command's id is the
+// OCPP message id of the call.
+type Command struct {
+	Id               string
+	ChargeStationId  string
+	OcppVersion      string // e.g. 1.6 or 2.0.1
+	Action           string
+	Status           CommandStatus
+	Request          string  // JSON-encoded request payload
+	Response         *string // JSON-encoded response payload
+	ErrorCode        *string
+	ErrorDescription *string
+	// Awaited is true if a caller, possibly on another manager instance, is waiting for the
+	// answer to the command
+	Awaited   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CommandResult represents the outcome of a command
+type CommandResult struct {
+	Status           CommandStatus
+	Response         *string // JSON-encoded response payload
+	ErrorCode        *string
+	ErrorDescription *string
+	UpdatedAt        time.Time
+}
+
+// CommandStore defines the interface for recording the calls made by the CSMS to charge stations
+type CommandStore interface {
+	// CreateCommand records a new command
+	CreateCommand(ctx context.Context, command *Command) error
+	// SetCommandSent marks a command as sent if it is still queued
+	SetCommandSent(ctx context.Context, commandId string, sentAt time.Time) error
+	// SetCommandResult records the outcome of a command. The command is only changed if it was
+	// sent to the charge station, so that one charge station cannot answer another's command.
+	SetCommandResult(ctx context.Context, chargeStationId, commandId string, result *CommandResult) error
+	// LookupCommand returns the command with the given id, or nil if there is no such command
+	LookupCommand(ctx context.Context, commandId string) (*Command, error)
+	// ListCommands returns the commands sent to a charge station, most recent first, along
+	// with the total number of commands
+	ListCommands(ctx context.Context, chargeStationId string, offset int, limit int) ([]*Command, int, error)
+	// TimeOutCommands marks the queued and sent commands that were last updated before the
+	// given time as timed out and returns how many were changed
+	TimeOutCommands(ctx context.Context, updatedBefore time.Time, timedOutAt time.Time) (int, error)
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// DeadLetter represents a message received from a charge station that the manager was
+// unable to process
+type DeadLetter struct {
+	Id              string
+	ChargeStationId string
+	OcppVersion     string // e.g. 1.6 or 2.0.1
+	MessageType     string // e.g. call or call_result
+	Action          string
+	MessageId       string
+	Message         string // JSON-encoded transport message
+	Error           string
+	CreatedAt       time.Time
+}
+
+// DeadLetterStore defines the interface for recording the messages that the manager was
+// unable to process so that they can be inspected and re-driven
+type DeadLetterStore interface {
+	// AddDeadLetter records a message that could not be processed
+	AddDeadLetter(ctx context.Context, deadLetter *DeadLetter) error
+	// LookupDeadLetter returns the dead letter with the given id, or nil if there is no such
+	// dead letter
+	LookupDeadLetter(ctx context.Context, deadLetterId string) (*DeadLetter, error)
+	// ListDeadLetters returns the dead letters, most recent first, along with the total
+	// number of dead letters
+	ListDeadLetters(ctx context.Context, offset int, limit int) ([]*DeadLetter, int, error)
+	// DeleteDeadLetter removes a dead letter: it is not an error if there is no such dead
+	// letter
+	DeleteDeadLetter(ctx context.Context, deadLetterId string) error
+}
@@ -34,4 +34,8 @@ type Engine interface {
 	VariableMonitoringStore
 	ChargeStationEventStore
 	DeviceReportStore
+	CommandStore
+	DeadLetterStore
+	EnumerationStore
+	RetentionStore
 }
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import "context"
+
+// EnumerationStore defines the interface for listing the records that cannot be listed
+// through the other stores, so that the whole contents of a store can be read. Each method
+// returns the page of keys (or records) that follows the previous key in key order.
+type EnumerationStore interface {
+	// ListChargeStationIds returns the ids of the charge stations that have been registered
+	ListChargeStationIds(ctx context.Context, pageSize int, previousChargeStationId string) ([]string, error)
+	// ListCertificateHashes returns the hashes that the certificates are stored under
+	ListCertificateHashes(ctx context.Context, pageSize int, previousCertificateHash string) ([]string, error)
+	// ListRegistrationTokens returns the tokens that the OCPI registrations are stored under
+	ListRegistrationTokens(ctx context.Context, pageSize int, previousToken string) ([]string, error)
+	// ListReservations returns the reservations whatever their status
+	ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*Reservation, error)
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const commandsCollection = "Command"
+
+type command struct {
+	ChargeStationId  string    `firestore:"chargeStationId"`
+	OcppVersion      string    `firestore:"ocppVersion"`
+	Action           string    `firestore:"action"`
+	Status           string    `firestore:"status"`
+	Request          string    `firestore:"request"`
+	Response         *string   `firestore:"response,omitempty"`
+	ErrorCode        *string   `firestore:"errorCode,omitempty"`
+	ErrorDescription *string   `firestore:"errorDescription,omitempty"`
+	Awaited          bool      `firestore:"awaited,omitempty"`
+	CreatedAt        time.Time `firestore:"createdAt"`
+	UpdatedAt        time.Time `firestore:"updatedAt"`
+}
+
+func (s *Store) CreateCommand(ctx context.Context, cmd *store.Command) error {
+	ref := s.client.Collection(commandsCollection).Doc(cmd.Id)
+	_, err := ref.Set(ctx, &command{
+		ChargeStationId:  cmd.ChargeStationId,
+		OcppVersion:      cmd.OcppVersion,
+		Action:           cmd.Action,
+		Status:           string(cmd.Status),
+		Request:          cmd.Request,
+		Response:         cmd.Response,
+		ErrorCode:        cmd.ErrorCode,
+		ErrorDescription: cmd.ErrorDescription,
+		Awaited:          cmd.Awaited,
+		CreatedAt:        cmd.CreatedAt,
+		UpdatedAt:        cmd.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("create command %s: %w", cmd.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) SetCommandSent(ctx context.Context, commandId string, sentAt time.Time) error {
+	ref := s.client.Collection(commandsCollection).Doc(commandId)
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			return err
+		}
+		commandStatus, err := snap.DataAt("status")
+		if err != nil {
+			return err
+		}
+		if commandStatus != string(store.CommandStatusQueued) {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "status", Value: string(store.CommandStatusSent)},
+			{Path: "updatedAt", Value: sentAt},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("set command %s sent: %w", commandId, err)
+	}
+	return nil
+}
+
+func (s *Store) SetCommandResult(ctx context.Context, chargeStationId, commandId string, result *store.CommandResult) error {
+	ref := s.client.Collection(commandsCollection).Doc(commandId)
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			return err
+		}
+		commandChargeStationId, err := snap.DataAt("chargeStationId")
+		if err != nil {
+			return err
+		}
+		if commandChargeStationId != chargeStationId {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{
+			{Path: "status", Value: string(result.Status)},
+			{Path: "response", Value: result.Response},
+			{Path: "errorCode", Value: result.ErrorCode},
+			{Path: "errorDescription", Value: result.ErrorDescription},
+			{Path: "updatedAt", Value: result.UpdatedAt},
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("set command %s result: %w", commandId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupCommand(ctx context.Context, commandId string) (*store.Command, error) {
+	snap, err := s.client.Collection(commandsCollection).Doc(commandId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup command %s: %w", commandId, err)
+	}
+	return mapCommand(snap)
+}
+
+func (s *Store) ListCommands(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.Command, int, error) {
+	query := s.client.Collection(commandsCollection).Where("chargeStationId", "==", chargeStationId)
+
+	allDocs := query.Documents(ctx)
+	total := 0
+	for {
+		_, err := allDocs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			allDocs.Stop()
+			return nil, 0, fmt.Errorf("count commands for %s: %w", chargeStationId, err)
+		}
+		total++
+	}
+	allDocs.Stop()
+
+	iter := query.
+		OrderBy("createdAt", firestore.Desc).
+		Offset(offset).
+		Limit(limit).
+		Documents(ctx)
+	defer iter.Stop()
+
+	commands := []*store.Command{}
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("list commands for %s: %w", chargeStationId, err)
+		}
+		cmd, err := mapCommand(snap)
+		if err != nil {
+			return nil, 0, err
+		}
+		commands = append(commands, cmd)
+	}
+	return commands, total, nil
+}
+
+func (s *Store) TimeOutCommands(ctx context.Context, updatedBefore time.Time, timedOutAt time.Time) (int, error) {
+	iter := s.client.Collection(commandsCollection).
+		Where("status", "in", []string{string(store.CommandStatusQueued), string(store.CommandStatusSent)}).
+		Where("updatedAt", "<", updatedBefore).
+		Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("iterate commands to time out: %w", err)
+		}
+		_, err = snap.Ref.Update(ctx, []firestore.Update{
+			{Path: "status", Value: string(store.CommandStatusTimedOut)},
+			{Path: "updatedAt", Value: timedOutAt},
+		})
+		if err != nil {
+			return count, fmt.Errorf("time out command %s: %w", snap.Ref.ID, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func mapCommand(snap *firestore.DocumentSnapshot) (*store.Command, error) {
+	var data command
+	if err := snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map command %s: %w", snap.Ref.ID, err)
+	}
+	return &store.Command{
+		Id:               snap.Ref.ID,
+		ChargeStationId:  data.ChargeStationId,
+		OcppVersion:      data.OcppVersion,
+		Action:           data.Action,
+		Status:           store.CommandStatus(data.Status),
+		Request:          data.Request,
+		Response:         data.Response,
+		ErrorCode:        data.ErrorCode,
+		ErrorDescription: data.ErrorDescription,
+		Awaited:          data.Awaited,
+		CreatedAt:        data.CreatedAt,
+		UpdatedAt:        data.UpdatedAt,
+	}, nil
+}
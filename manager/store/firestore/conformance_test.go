@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package firestore_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store/storetest"
+	"k8s.io/utils/clock"
+)
+
+var conformanceProjects atomic.Int64
+
+// TestConformance runs the conformance suite against the emulator: each engine uses its own
+// project so that it starts without any data
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock clock.PassiveClock) store.Engine {
+		project := fmt.Sprintf("conformance%d", conformanceProjects.Add(1))
+		engine, err := firestore.NewStore(context.Background(), project, clock)
+		require.NoError(t, err)
+		return engine
+	})
+}
@@ -352,6 +352,235 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 	return triggerMessages, nil
 }
 
+type chargeStationDataTransfer struct {
+	VendorId     string    `firestore:"v"`
+	MessageId    *string   `firestore:"m,omitempty"`
+	Data         *string   `firestore:"d,omitempty"`
+	Status       string    `firestore:"s"`
+	ResponseData *string   `firestore:"r,omitempty"`
+	SendAfter    time.Time `firestore:"u"`
+}
+
+func (s *Store) SetChargeStationDataTransfer(ctx context.Context, chargeStationId string, dataTransfer *store.ChargeStationDataTransfer) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDataTransfer/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationDataTransfer{
+		VendorId:     dataTransfer.VendorId,
+		MessageId:    dataTransfer.MessageId,
+		Data:         dataTransfer.Data,
+		Status:       string(dataTransfer.Status),
+		ResponseData: dataTransfer.ResponseData,
+		SendAfter:    dataTransfer.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("set charge station data transfer %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationDataTransfer(ctx context.Context, chargeStationId string) (*store.ChargeStationDataTransfer, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDataTransfer/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station data transfer %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationDataTransfer
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station data transfer %s: %w", chargeStationId, err)
+	}
+	return csData.toStore(chargeStationId), nil
+}
+
+func (s *Store) ListChargeStationDataTransfers(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationDataTransfer, error) {
+	snaps, err := s.listChargeStationDocuments(ctx, "ChargeStationDataTransfer", pageSize, previousCsId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station data transfers: %w", err)
+	}
+	var dataTransfers []*store.ChargeStationDataTransfer
+	for _, snap := range snaps {
+		var dataTransfer chargeStationDataTransfer
+		if err = snap.DataTo(&dataTransfer); err != nil {
+			return nil, fmt.Errorf("map charge station data transfer: %w", err)
+		}
+		dataTransfers = append(dataTransfers, dataTransfer.toStore(snap.Ref.ID))
+	}
+	return dataTransfers, nil
+}
+
+func (s *Store) DeleteChargeStationDataTransfer(ctx context.Context, chargeStationId string) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationDataTransfer/%s", chargeStationId))
+	_, err := csRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete charge station data transfer %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (d *chargeStationDataTransfer) toStore(chargeStationId string) *store.ChargeStationDataTransfer {
+	return &store.ChargeStationDataTransfer{
+		ChargeStationId: chargeStationId,
+		VendorId:        d.VendorId,
+		MessageId:       d.MessageId,
+		Data:            d.Data,
+		Status:          store.DataTransferStatus(d.Status),
+		ResponseData:    d.ResponseData,
+		SendAfter:       d.SendAfter,
+	}
+}
+
+type chargeStationClearCache struct {
+	Status    string    `firestore:"s"`
+	SendAfter time.Time `firestore:"u"`
+}
+
+func (s *Store) SetChargeStationClearCache(ctx context.Context, chargeStationId string, clearCache *store.ChargeStationClearCache) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationClearCache/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationClearCache{
+		Status:    string(clearCache.Status),
+		SendAfter: clearCache.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("set charge station clear cache %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationClearCache(ctx context.Context, chargeStationId string) (*store.ChargeStationClearCache, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationClearCache/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station clear cache %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationClearCache
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station clear cache %s: %w", chargeStationId, err)
+	}
+	return &store.ChargeStationClearCache{
+		ChargeStationId: chargeStationId,
+		Status:          store.ClearCacheStatus(csData.Status),
+		SendAfter:       csData.SendAfter,
+	}, nil
+}
+
+func (s *Store) ListChargeStationClearCaches(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationClearCache, error) {
+	snaps, err := s.listChargeStationDocuments(ctx, "ChargeStationClearCache", pageSize, previousCsId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station clear caches: %w", err)
+	}
+	var clearCaches []*store.ChargeStationClearCache
+	for _, snap := range snaps {
+		var clearCache chargeStationClearCache
+		if err = snap.DataTo(&clearCache); err != nil {
+			return nil, fmt.Errorf("map charge station clear cache: %w", err)
+		}
+		clearCaches = append(clearCaches, &store.ChargeStationClearCache{
+			ChargeStationId: snap.Ref.ID,
+			Status:          store.ClearCacheStatus(clearCache.Status),
+			SendAfter:       clearCache.SendAfter,
+		})
+	}
+	return clearCaches, nil
+}
+
+func (s *Store) DeleteChargeStationClearCache(ctx context.Context, chargeStationId string) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationClearCache/%s", chargeStationId))
+	_, err := csRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete charge station clear cache %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+type chargeStationChangeAvailability struct {
+	ConnectorId *int      `firestore:"c,omitempty"`
+	EvseId      *int      `firestore:"e,omitempty"`
+	Type        string    `firestore:"t"`
+	Status      string    `firestore:"s"`
+	SendAfter   time.Time `firestore:"u"`
+}
+
+func (s *Store) SetChargeStationChangeAvailability(ctx context.Context, chargeStationId string, changeAvailability *store.ChargeStationChangeAvailability) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationChangeAvailability/%s", chargeStationId))
+	_, err := csRef.Set(ctx, &chargeStationChangeAvailability{
+		ConnectorId: changeAvailability.ConnectorId,
+		EvseId:      changeAvailability.EvseId,
+		Type:        string(changeAvailability.Type),
+		Status:      string(changeAvailability.Status),
+		SendAfter:   changeAvailability.SendAfter,
+	})
+	if err != nil {
+		return fmt.Errorf("set charge station change availability %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupChargeStationChangeAvailability(ctx context.Context, chargeStationId string) (*store.ChargeStationChangeAvailability, error) {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationChangeAvailability/%s", chargeStationId))
+	snap, err := csRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup charge station change availability %s: %w", chargeStationId, err)
+	}
+	var csData chargeStationChangeAvailability
+	if err = snap.DataTo(&csData); err != nil {
+		return nil, fmt.Errorf("map charge station change availability %s: %w", chargeStationId, err)
+	}
+	return csData.toStore(chargeStationId), nil
+}
+
+func (s *Store) ListChargeStationChangeAvailabilities(ctx context.Context, pageSize int, previousCsId string) ([]*store.ChargeStationChangeAvailability, error) {
+	snaps, err := s.listChargeStationDocuments(ctx, "ChargeStationChangeAvailability", pageSize, previousCsId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station change availabilities: %w", err)
+	}
+	var changeAvailabilities []*store.ChargeStationChangeAvailability
+	for _, snap := range snaps {
+		var changeAvailability chargeStationChangeAvailability
+		if err = snap.DataTo(&changeAvailability); err != nil {
+			return nil, fmt.Errorf("map charge station change availability: %w", err)
+		}
+		changeAvailabilities = append(changeAvailabilities, changeAvailability.toStore(snap.Ref.ID))
+	}
+	return changeAvailabilities, nil
+}
+
+func (s *Store) DeleteChargeStationChangeAvailability(ctx context.Context, chargeStationId string) error {
+	csRef := s.client.Doc(fmt.Sprintf("ChargeStationChangeAvailability/%s", chargeStationId))
+	_, err := csRef.Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete charge station change availability %s: %w", chargeStationId, err)
+	}
+	return nil
+}
+
+func (c *chargeStationChangeAvailability) toStore(chargeStationId string) *store.ChargeStationChangeAvailability {
+	return &store.ChargeStationChangeAvailability{
+		ChargeStationId: chargeStationId,
+		ConnectorId:     c.ConnectorId,
+		EvseId:          c.EvseId,
+		Type:            store.AvailabilityType(c.Type),
+		Status:          store.AvailabilityStatus(c.Status),
+		SendAfter:       c.SendAfter,
+	}
+}
+
+// listChargeStationDocuments returns a page of the documents in a collection that is keyed by
+// charge station id
+func (s *Store) listChargeStationDocuments(ctx context.Context, collection string, pageSize int, previousCsId string) ([]*firestore.DocumentSnapshot, error) {
+	query := s.client.Collection(collection).OrderBy(firestore.DocumentID, firestore.Asc)
+	if previousCsId != "" {
+		query = query.StartAfter(previousCsId)
+	}
+	return query.Limit(pageSize).Documents(ctx).GetAll()
+}
+
 type resetRequest struct {
 	Type      string    `firestore:"type"`
 	Status    string    `firestore:"status"`
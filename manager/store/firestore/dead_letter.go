@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const deadLettersCollection = "DeadLetter"
+
+type deadLetter struct {
+	ChargeStationId string    `firestore:"chargeStationId"`
+	OcppVersion     string    `firestore:"ocppVersion"`
+	MessageType     string    `firestore:"messageType"`
+	Action          string    `firestore:"action"`
+	MessageId       string    `firestore:"messageId"`
+	Message         string    `firestore:"message"`
+	Error           string    `firestore:"error"`
+	CreatedAt       time.Time `firestore:"createdAt"`
+}
+
+func (s *Store) AddDeadLetter(ctx context.Context, dl *store.DeadLetter) error {
+	ref := s.client.Collection(deadLettersCollection).Doc(dl.Id)
+	_, err := ref.Set(ctx, &deadLetter{
+		ChargeStationId: dl.ChargeStationId,
+		OcppVersion:     dl.OcppVersion,
+		MessageType:     dl.MessageType,
+		Action:          dl.Action,
+		MessageId:       dl.MessageId,
+		Message:         dl.Message,
+		Error:           dl.Error,
+		CreatedAt:       dl.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("add dead letter %s: %w", dl.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupDeadLetter(ctx context.Context, deadLetterId string) (*store.DeadLetter, error) {
+	snap, err := s.client.Collection(deadLettersCollection).Doc(deadLetterId).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup dead letter %s: %w", deadLetterId, err)
+	}
+	return mapDeadLetter(snap)
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, offset int, limit int) ([]*store.DeadLetter, int, error) {
+	collection := s.client.Collection(deadLettersCollection)
+
+	allDocs := collection.Documents(ctx)
+	total := 0
+	for {
+		_, err := allDocs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			allDocs.Stop()
+			return nil, 0, fmt.Errorf("count dead letters: %w", err)
+		}
+		total++
+	}
+	allDocs.Stop()
+
+	iter := collection.
+		OrderBy("createdAt", firestore.Desc).
+		Offset(offset).
+		Limit(limit).
+		Documents(ctx)
+	defer iter.Stop()
+
+	deadLetters := []*store.DeadLetter{}
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("list dead letters: %w", err)
+		}
+		dl, err := mapDeadLetter(snap)
+		if err != nil {
+			return nil, 0, err
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	return deadLetters, total, nil
+}
+
+func (s *Store) DeleteDeadLetter(ctx context.Context, deadLetterId string) error {
+	_, err := s.client.Collection(deadLettersCollection).Doc(deadLetterId).Delete(ctx)
+	if err != nil {
+		return fmt.Errorf("delete dead letter %s: %w", deadLetterId, err)
+	}
+	return nil
+}
+
+func mapDeadLetter(snap *firestore.DocumentSnapshot) (*store.DeadLetter, error) {
+	var data deadLetter
+	if err := snap.DataTo(&data); err != nil {
+		return nil, fmt.Errorf("map dead letter %s: %w", snap.Ref.ID, err)
+	}
+	return &store.DeadLetter{
+		Id:              snap.Ref.ID,
+		ChargeStationId: data.ChargeStationId,
+		OcppVersion:     data.OcppVersion,
+		MessageType:     data.MessageType,
+		Action:          data.Action,
+		MessageId:       data.MessageId,
+		Message:         data.Message,
+		Error:           data.Error,
+		CreatedAt:       data.CreatedAt,
+	}, nil
+}
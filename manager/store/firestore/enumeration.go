@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// listDocumentIds returns the ids of the documents in the collection that follow the previous id
+func (s *Store) listDocumentIds(ctx context.Context, collection string, pageSize int, previousId string) ([]string, error) {
+	query := s.client.Collection(collection).OrderBy(firestore.DocumentID, firestore.Asc)
+	if previousId != "" {
+		query = query.StartAfter(previousId)
+	}
+	snaps, err := query.Limit(pageSize).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(snaps))
+	for _, snap := range snaps {
+		ids = append(ids, snap.Ref.ID)
+	}
+	return ids, nil
+}
+
+func (s *Store) ListChargeStationIds(ctx context.Context, pageSize int, previousChargeStationId string) ([]string, error) {
+	ids, err := s.listDocumentIds(ctx, "ChargeStation", pageSize, previousChargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (s *Store) ListCertificateHashes(ctx context.Context, pageSize int, previousCertificateHash string) ([]string, error) {
+	hashes, err := s.listDocumentIds(ctx, "Certificate", pageSize, previousCertificateHash)
+	if err != nil {
+		return nil, fmt.Errorf("list certificate hashes: %w", err)
+	}
+	return hashes, nil
+}
+
+func (s *Store) ListRegistrationTokens(ctx context.Context, pageSize int, previousToken string) ([]string, error) {
+	tokens, err := s.listDocumentIds(ctx, "OcpiRegistration", pageSize, previousToken)
+	if err != nil {
+		return nil, fmt.Errorf("list registration tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	// reservation document ids do not sort numerically so the reservations are ordered by their id field
+	snaps, err := s.client.Collection("Reservation").
+		Where("reservationId", ">", previousReservationId).
+		OrderBy("reservationId", firestore.Asc).
+		Limit(pageSize).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	reservations := make([]*store.Reservation, 0, len(snaps))
+	for _, snap := range snaps {
+		var reservation store.Reservation
+		if err := snap.DataTo(&reservation); err != nil {
+			return nil, fmt.Errorf("map reservation %s: %w", snap.Ref.ID, err)
+		}
+		reservations = append(reservations, &reservation)
+	}
+	return reservations, nil
+}
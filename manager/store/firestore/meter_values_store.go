@@ -5,6 +5,7 @@ package firestore
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"cloud.google.com/go/firestore"
@@ -12,7 +13,10 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-const meterValuesCollection = "MeterValues"
+const (
+	meterValuesCollection       = "MeterValues"
+	meterValueRollupsCollection = "MeterValueRollups"
+)
 
 type firestoreMeterValue struct {
 	ChargeStationId string               `firestore:"chargeStationId"`
@@ -23,27 +27,88 @@ type firestoreMeterValue struct {
 	ReceivedAt      time.Time            `firestore:"receivedAt"`
 }
 
-// StoreMeterValues stores meter values received from a charge station.
+type firestoreMeterValueRollup struct {
+	ChargeStationId string     `firestore:"chargeStationId"`
+	EvseId          int        `firestore:"evseId"`
+	Interval        string     `firestore:"interval"`
+	Start           time.Time  `firestore:"start"`
+	SampleCount     int        `firestore:"sampleCount"`
+	EnergyFirst     *float64   `firestore:"energyFirst"`
+	EnergyFirstAt   *time.Time `firestore:"energyFirstAt"`
+	EnergyLast      *float64   `firestore:"energyLast"`
+	EnergyLastAt    *time.Time `firestore:"energyLastAt"`
+	MaxPower        *float64   `firestore:"maxPower"`
+	VoltageSum      float64    `firestore:"voltageSum"`
+	VoltageCount    int        `firestore:"voltageCount"`
+}
+
+// StoreMeterValues stores meter values received from a charge station. The meter values are
+// created, and merged into the rollups, in a transaction so that a meter value that has already
+// been stored is neither stored nor rolled up again.
 func (s *Store) StoreMeterValues(ctx context.Context, chargeStationId string, evseId int, transactionId string, meterValues []store.MeterValue) error {
-	for _, mv := range meterValues {
-		doc := firestoreMeterValue{
-			ChargeStationId: chargeStationId,
-			EvseId:          evseId,
-			TransactionId:   transactionId,
-			Timestamp:       mv.Timestamp,
-			SampledValues:   mv.SampledValues,
-			ReceivedAt:      s.clock.Now(),
+	refs := make([]*firestore.DocumentRef, len(meterValues))
+	for i, mv := range meterValues {
+		// the document id identifies the meter value for the EVSE
+		docId := fmt.Sprintf("%s_%d_%s_%s", chargeStationId, evseId, mv.Timestamp, store.MeterValueDigest(mv))
+		refs[i] = s.client.Collection(meterValuesCollection).Doc(docId)
+	}
+
+	return s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// all reads must happen before any writes in a transaction
+		snaps, err := tx.GetAll(refs)
+		if err != nil {
+			return fmt.Errorf("reading meter values: %w", err)
+		}
+		var added []store.MeterValue
+		var addedRefs []*firestore.DocumentRef
+		for i, snap := range snaps {
+			if !snap.Exists() {
+				added = append(added, meterValues[i])
+				addedRefs = append(addedRefs, refs[i])
+			}
 		}
 
-		// Use charge station ID, EVSE ID, and timestamp as document ID for uniqueness
-		docId := fmt.Sprintf("%s_%d_%s", chargeStationId, evseId, mv.Timestamp)
-		_, err := s.client.Collection(meterValuesCollection).Doc(docId).Set(ctx, doc)
+		rollups := store.RollUpMeterValues(chargeStationId, evseId, added)
+		rollupRefs := make([]*firestore.DocumentRef, len(rollups))
+		for i, rollup := range rollups {
+			docId := fmt.Sprintf("%s_%d_%s_%d", rollup.ChargeStationId, rollup.EvseId, rollup.Interval, rollup.Start.Unix())
+			rollupRefs[i] = s.client.Collection(meterValueRollupsCollection).Doc(docId)
+		}
+		rollupSnaps, err := tx.GetAll(rollupRefs)
 		if err != nil {
-			return fmt.Errorf("storing meter value: %w", err)
+			return fmt.Errorf("reading meter value rollups: %w", err)
+		}
+
+		for i, mv := range added {
+			err := tx.Create(addedRefs[i], firestoreMeterValue{
+				ChargeStationId: chargeStationId,
+				EvseId:          evseId,
+				TransactionId:   transactionId,
+				Timestamp:       mv.Timestamp,
+				SampledValues:   mv.SampledValues,
+				ReceivedAt:      s.clock.Now(),
+			})
+			if err != nil {
+				return fmt.Errorf("storing meter value: %w", err)
+			}
 		}
-	}
 
-	return nil
+		for i, rollup := range rollups {
+			merged := *rollup
+			if rollupSnaps[i].Exists() {
+				var existing firestoreMeterValueRollup
+				if err := rollupSnaps[i].DataTo(&existing); err != nil {
+					return err
+				}
+				merged = existing.toStore()
+				merged.Merge(rollup)
+			}
+			if err := tx.Set(rollupRefs[i], toFirestoreMeterValueRollup(&merged)); err != nil {
+				return fmt.Errorf("storing meter value rollup: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 // GetMeterValues retrieves meter values for a specific charge station and EVSE.
@@ -75,16 +140,136 @@ func (s *Store) GetMeterValues(ctx context.Context, chargeStationId string, evse
 			return nil, fmt.Errorf("unmarshaling meter value: %w", err)
 		}
 
-		result = append(result, store.StoredMeterValue{
-			ChargeStationId: fmv.ChargeStationId,
-			EvseId:          fmv.EvseId,
-			TransactionId:   fmv.TransactionId,
-			MeterValue: store.MeterValue{
-				Timestamp:     fmv.Timestamp,
-				SampledValues: fmv.SampledValues,
-			},
-		})
+		result = append(result, fmv.toStore())
+	}
+
+	return result, nil
+}
+
+// QueryMeterValues retrieves the meter values that match the filter, most recent first. Firestore
+// cannot count the matching documents without reading them, so the page is taken from all of the
+// matching meter values.
+func (s *Store) QueryMeterValues(ctx context.Context, filter store.MeterValuesFilter) (*store.MeterValuesResult, error) {
+	query := s.client.Collection(meterValuesCollection).
+		Where("chargeStationId", "==", filter.ChargeStationId)
+	if filter.ConnectorId != nil {
+		query = query.Where("evseId", "==", *filter.ConnectorId)
+	}
+	if filter.TransactionId != nil {
+		query = query.Where("transactionId", "==", *filter.TransactionId)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("timestamp", ">=", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("timestamp", "<=", *filter.EndTime)
+	}
+
+	snaps, err := query.OrderBy("timestamp", firestore.Desc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("querying meter values: %w", err)
+	}
+
+	start := min(filter.Offset, len(snaps))
+	end := min(start+filter.Limit, len(snaps))
+
+	result := &store.MeterValuesResult{
+		Total: len(snaps),
+	}
+	for _, snap := range snaps[start:end] {
+		var fmv firestoreMeterValue
+		if err := snap.DataTo(&fmv); err != nil {
+			return nil, fmt.Errorf("unmarshaling meter value: %w", err)
+		}
+		result.MeterValues = append(result.MeterValues, fmv.toStore())
+	}
+
+	return result, nil
+}
+
+// QueryMeterValueRollups retrieves the rollups for a charge station, ordered by EVSE and start. The
+// rollups are sorted once they have been read, but filtering by start still requires a composite
+// index on chargeStationId, interval, evseId and start.
+func (s *Store) QueryMeterValueRollups(ctx context.Context, filter store.MeterValueRollupsFilter) ([]*store.MeterValueRollup, error) {
+	query := s.client.Collection(meterValueRollupsCollection).
+		Where("chargeStationId", "==", filter.ChargeStationId).
+		Where("interval", "==", string(filter.Interval))
+	if filter.ConnectorId != nil {
+		query = query.Where("evseId", "==", *filter.ConnectorId)
+	}
+	if filter.StartTime != nil {
+		query = query.Where("start", ">=", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		query = query.Where("start", "<=", *filter.EndTime)
+	}
+
+	snaps, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("querying meter value rollups: %w", err)
 	}
 
+	result := make([]*store.MeterValueRollup, 0, len(snaps))
+	for _, snap := range snaps {
+		var rollup firestoreMeterValueRollup
+		if err := snap.DataTo(&rollup); err != nil {
+			return nil, fmt.Errorf("unmarshaling meter value rollup: %w", err)
+		}
+		converted := rollup.toStore()
+		result = append(result, &converted)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EvseId != result[j].EvseId {
+			return result[i].EvseId < result[j].EvseId
+		}
+		return result[i].Start.Before(result[j].Start)
+	})
+
 	return result, nil
 }
+
+func toFirestoreMeterValueRollup(rollup *store.MeterValueRollup) *firestoreMeterValueRollup {
+	return &firestoreMeterValueRollup{
+		ChargeStationId: rollup.ChargeStationId,
+		EvseId:          rollup.EvseId,
+		Interval:        string(rollup.Interval),
+		Start:           rollup.Start,
+		SampleCount:     rollup.SampleCount,
+		EnergyFirst:     rollup.EnergyFirst,
+		EnergyFirstAt:   rollup.EnergyFirstAt,
+		EnergyLast:      rollup.EnergyLast,
+		EnergyLastAt:    rollup.EnergyLastAt,
+		MaxPower:        rollup.MaxPower,
+		VoltageSum:      rollup.VoltageSum,
+		VoltageCount:    rollup.VoltageCount,
+	}
+}
+
+func (r *firestoreMeterValueRollup) toStore() store.MeterValueRollup {
+	return store.MeterValueRollup{
+		ChargeStationId: r.ChargeStationId,
+		EvseId:          r.EvseId,
+		Interval:        store.MeterValueInterval(r.Interval),
+		Start:           r.Start.UTC(),
+		SampleCount:     r.SampleCount,
+		EnergyFirst:     r.EnergyFirst,
+		EnergyFirstAt:   r.EnergyFirstAt,
+		EnergyLast:      r.EnergyLast,
+		EnergyLastAt:    r.EnergyLastAt,
+		MaxPower:        r.MaxPower,
+		VoltageSum:      r.VoltageSum,
+		VoltageCount:    r.VoltageCount,
+	}
+}
+
+func (fmv *firestoreMeterValue) toStore() store.StoredMeterValue {
+	return store.StoredMeterValue{
+		ChargeStationId: fmv.ChargeStationId,
+		EvseId:          fmv.EvseId,
+		TransactionId:   fmv.TransactionId,
+		MeterValue: store.MeterValue{
+			Timestamp:     fmv.Timestamp,
+			SampledValues: fmv.SampledValues,
+		},
+	}
+}
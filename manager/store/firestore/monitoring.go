@@ -3,13 +3,18 @@
 package firestore
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Variable Monitoring
@@ -30,13 +35,6 @@ type firestoreVariableMonitoringConfig struct {
 func (s *Store) SetVariableMonitoring(ctx context.Context, chargeStationId string, config *store.VariableMonitoringConfig) error {
 	collection := s.client.Collection("ChargeStations").Doc(chargeStationId).Collection("VariableMonitoring")
 
-	var docRef *firestore.DocumentRef
-	if config.Id != 0 {
-		docRef = collection.Doc(fmt.Sprintf("%d", config.Id))
-	} else {
-		docRef = collection.NewDoc()
-	}
-
 	doc := &firestoreVariableMonitoringConfig{
 		ChargeStationId:   chargeStationId,
 		ComponentName:     config.ComponentName,
@@ -47,23 +45,95 @@ func (s *Store) SetVariableMonitoring(ctx context.Context, chargeStationId strin
 		Value:             config.Value,
 		Severity:          config.Severity,
 		Transaction:       config.Transaction,
-		CreatedAt:         time.Now().Format(time.RFC3339),
+		CreatedAt:         s.clock.Now().Format(time.RFC3339),
+	}
+
+	if config.Id != 0 {
+		_, err := collection.Doc(strconv.Itoa(config.Id)).Set(ctx, doc)
+		if err != nil {
+			return fmt.Errorf("setting variable monitoring for %s: %w", chargeStationId, err)
+		}
+		return nil
 	}
 
-	_, err := docRef.Set(ctx, doc)
+	// a config without an id is given the id after the highest one in use
+	var id int
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snaps, err := tx.Documents(collection).GetAll()
+		if err != nil {
+			return err
+		}
+		id = 1
+		for _, snap := range snaps {
+			if existing, err := strconv.Atoi(snap.Ref.ID); err == nil && existing >= id {
+				id = existing + 1
+			}
+		}
+		return tx.Create(collection.Doc(strconv.Itoa(id)), doc)
+	})
 	if err != nil {
 		return fmt.Errorf("setting variable monitoring for %s: %w", chargeStationId, err)
 	}
+	config.Id = id
 	return nil
 }
 
 func (s *Store) GetVariableMonitoring(ctx context.Context, chargeStationId string, monitorId int) (*store.VariableMonitoringConfig, error) {
 	snap, err := s.client.Collection("ChargeStations").Doc(chargeStationId).
-		Collection("VariableMonitoring").Doc(fmt.Sprintf("%d", monitorId)).Get(ctx)
+		Collection("VariableMonitoring").Doc(strconv.Itoa(monitorId)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting variable monitoring %d for %s: %w", monitorId, chargeStationId, err)
+	}
+
+	return toVariableMonitoringConfig(chargeStationId, monitorId, snap)
+}
+
+func (s *Store) DeleteVariableMonitoring(ctx context.Context, chargeStationId string, monitorId int) error {
+	_, err := s.client.Collection("ChargeStations").Doc(chargeStationId).
+		Collection("VariableMonitoring").Doc(strconv.Itoa(monitorId)).Delete(ctx)
 	if err != nil {
-		return nil, nil
+		return fmt.Errorf("deleting variable monitoring %d for %s: %w", monitorId, chargeStationId, err)
+	}
+	return nil
+}
+
+// ListVariableMonitoring lists the configs in id order: the document ids are strings, so the
+// configs are sorted once they have been read
+func (s *Store) ListVariableMonitoring(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.VariableMonitoringConfig, error) {
+	snaps, err := s.client.Collection("ChargeStations").Doc(chargeStationId).
+		Collection("VariableMonitoring").
+		Documents(ctx).
+		GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("listing variable monitoring for %s: %w", chargeStationId, err)
 	}
 
+	results := make([]*store.VariableMonitoringConfig, 0, len(snaps))
+	for _, snap := range snaps {
+		monitorId, err := strconv.Atoi(snap.Ref.ID)
+		if err != nil {
+			return nil, fmt.Errorf("parsing variable monitoring id %s: %w", snap.Ref.ID, err)
+		}
+		result, err := toVariableMonitoringConfig(chargeStationId, monitorId, snap)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	slices.SortFunc(results, func(a, b *store.VariableMonitoringConfig) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	start := min(offset, len(results))
+	end := min(start+limit, len(results))
+	return results[start:end], nil
+}
+
+func toVariableMonitoringConfig(chargeStationId string, monitorId int, snap *firestore.DocumentSnapshot) (*store.VariableMonitoringConfig, error) {
 	var doc firestoreVariableMonitoringConfig
 	if err := snap.DataTo(&doc); err != nil {
 		return nil, fmt.Errorf("decoding variable monitoring: %w", err)
@@ -89,62 +159,31 @@ func (s *Store) GetVariableMonitoring(ctx context.Context, chargeStationId strin
 	return result, nil
 }
 
-func (s *Store) DeleteVariableMonitoring(ctx context.Context, chargeStationId string, monitorId int) error {
-	_, err := s.client.Collection("ChargeStations").Doc(chargeStationId).
-		Collection("VariableMonitoring").Doc(fmt.Sprintf("%d", monitorId)).Delete(ctx)
-	if err != nil {
-		return fmt.Errorf("deleting variable monitoring %d for %s: %w", monitorId, chargeStationId, err)
-	}
-	return nil
-}
-
-func (s *Store) ListVariableMonitoring(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.VariableMonitoringConfig, error) {
-	iter := s.client.Collection("ChargeStations").Doc(chargeStationId).
-		Collection("VariableMonitoring").
-		OrderBy(firestore.DocumentID, firestore.Asc).
-		Offset(offset).
-		Limit(limit).
-		Documents(ctx)
-	defer iter.Stop()
-
-	var results []*store.VariableMonitoringConfig
-	for {
-		snap, err := iter.Next()
-		if err == iterator.Done {
-			break
+// addWithId adds the document to the collection with the next id from the named counter of the
+// charge station: the id is used as the document id
+func (s *Store) addWithId(ctx context.Context, chargeStationId, counter string, collection *firestore.CollectionRef, doc any) (int, error) {
+	counterRef := s.client.Collection("ChargeStations").Doc(chargeStationId).Collection("Counters").Doc(counter)
+
+	var id int
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		id = 1
+		snap, err := tx.Get(counterRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
 		}
-		if err != nil {
-			return nil, fmt.Errorf("listing variable monitoring for %s: %w", chargeStationId, err)
+		if err == nil {
+			lastId, err := snap.DataAt("lastId")
+			if err != nil {
+				return err
+			}
+			id = int(lastId.(int64)) + 1
 		}
-
-		var doc firestoreVariableMonitoringConfig
-		if err := snap.DataTo(&doc); err != nil {
-			return nil, fmt.Errorf("decoding variable monitoring: %w", err)
+		if err := tx.Set(counterRef, map[string]any{"lastId": id}); err != nil {
+			return err
 		}
-
-		result := &store.VariableMonitoringConfig{
-			ChargeStationId:   chargeStationId,
-			ComponentName:     doc.ComponentName,
-			ComponentInstance: doc.ComponentInstance,
-			VariableName:      doc.VariableName,
-			VariableInstance:  doc.VariableInstance,
-			MonitorType:       store.MonitoringType(doc.MonitorType),
-			Value:             doc.Value,
-			Severity:          doc.Severity,
-			Transaction:       doc.Transaction,
-		}
-
-		if createdAt, err := time.Parse(time.RFC3339, doc.CreatedAt); err == nil {
-			result.CreatedAt = createdAt
-		}
-
-		results = append(results, result)
-	}
-
-	if results == nil {
-		return []*store.VariableMonitoringConfig{}, nil
-	}
-	return results, nil
+		return tx.Create(collection.Doc(strconv.Itoa(id)), doc)
+	})
+	return id, err
 }
 
 // Charge Station Events
@@ -167,7 +206,7 @@ func (s *Store) AddChargeStationEvent(ctx context.Context, chargeStationId strin
 
 	doc := &firestoreChargeStationEvent{
 		ChargeStationId: chargeStationId,
-		Timestamp:       event.Timestamp.Format(time.RFC3339),
+		Timestamp:       event.Timestamp.UTC().Format(time.RFC3339),
 		EventType:       event.EventType,
 		TechCode:        event.TechCode,
 		TechInfo:        event.TechInfo,
@@ -175,10 +214,10 @@ func (s *Store) AddChargeStationEvent(ctx context.Context, chargeStationId strin
 		ComponentId:     event.ComponentId,
 		VariableId:      event.VariableId,
 		Cleared:         event.Cleared,
-		CreatedAt:       time.Now().Format(time.RFC3339),
+		CreatedAt:       s.clock.Now().Format(time.RFC3339),
 	}
 
-	_, _, err := collection.Add(ctx, doc)
+	_, err := s.addWithId(ctx, chargeStationId, "Events", collection, doc)
 	if err != nil {
 		return fmt.Errorf("adding event for %s: %w", chargeStationId, err)
 	}
@@ -228,6 +267,7 @@ func (s *Store) ListChargeStationEvents(ctx context.Context, chargeStationId str
 		}
 
 		result := &store.ChargeStationEvent{
+			Id:              documentId(snap),
 			ChargeStationId: chargeStationId,
 			EventType:       doc.EventType,
 			TechCode:        doc.TechCode,
@@ -271,13 +311,13 @@ func (s *Store) AddDeviceReport(ctx context.Context, chargeStationId string, rep
 	doc := &firestoreDeviceReport{
 		ChargeStationId: chargeStationId,
 		RequestId:       report.RequestId,
-		GeneratedAt:     report.GeneratedAt.Format(time.RFC3339),
+		GeneratedAt:     report.GeneratedAt.UTC().Format(time.RFC3339),
 		ReportType:      report.ReportType,
 		ReportData:      report.ReportData,
-		CreatedAt:       time.Now().Format(time.RFC3339),
+		CreatedAt:       s.clock.Now().Format(time.RFC3339),
 	}
 
-	_, _, err := collection.Add(ctx, doc)
+	_, err := s.addWithId(ctx, chargeStationId, "DeviceReports", collection, doc)
 	if err != nil {
 		return fmt.Errorf("adding device report for %s: %w", chargeStationId, err)
 	}
@@ -327,6 +367,7 @@ func (s *Store) ListDeviceReports(ctx context.Context, chargeStationId string, o
 		}
 
 		result := &store.DeviceReport{
+			Id:              documentId(snap),
 			ChargeStationId: chargeStationId,
 			RequestId:       doc.RequestId,
 			ReportType:      doc.ReportType,
@@ -348,3 +389,13 @@ func (s *Store) ListDeviceReports(ctx context.Context, chargeStationId string, o
 	}
 	return results, total, nil
 }
+
+// documentId returns the numeric id of a document: documents added before ids were allocated
+// have random ids, so they have an id of 0
+func documentId(snap *firestore.DocumentSnapshot) int {
+	id, err := strconv.Atoi(snap.Ref.ID)
+	if err != nil {
+		return 0
+	}
+	return id
+}
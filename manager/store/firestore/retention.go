@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// deleteBefore deletes at most limit of the documents returned by the query whose field, an
+// RFC3339 timestamp, is before the given time, oldest first
+func (s *Store) deleteBefore(ctx context.Context, query firestore.Query, field string, before time.Time, limit int) (int, error) {
+	snaps, err := query.
+		Where(field, "<", before.UTC().Format(time.RFC3339)).
+		OrderBy(field, firestore.Asc).
+		Limit(limit).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return 0, err
+	}
+	return deleteDocuments(ctx, snaps)
+}
+
+func deleteDocuments(ctx context.Context, snaps []*firestore.DocumentSnapshot) (int, error) {
+	for i, snap := range snaps {
+		if _, err := snap.Ref.Delete(ctx); err != nil {
+			return i, err
+		}
+	}
+	return len(snaps), nil
+}
+
+func (s *Store) DeleteMeterValuesBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	deleted, err := s.deleteBefore(ctx, s.client.Collection(meterValuesCollection).Query, "timestamp", before, limit)
+	if err != nil {
+		return deleted, fmt.Errorf("delete meter values before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return deleted, nil
+}
+
+// DeleteChargeStationEventsBefore queries the events of every charge station, which requires
+// the single-field index on timestamp to be enabled for the Events collection group
+func (s *Store) DeleteChargeStationEventsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	deleted, err := s.deleteBefore(ctx, s.client.CollectionGroup("Events").Query, "timestamp", before, limit)
+	if err != nil {
+		return deleted, fmt.Errorf("delete charge station events before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return deleted, nil
+}
+
+// DeleteDeviceReportsBefore queries the reports of every charge station, which requires the
+// single-field index on generatedAt to be enabled for the DeviceReports collection group
+func (s *Store) DeleteDeviceReportsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	deleted, err := s.deleteBefore(ctx, s.client.CollectionGroup("DeviceReports").Query, "generatedAt", before, limit)
+	if err != nil {
+		return deleted, fmt.Errorf("delete device reports before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return deleted, nil
+}
+
+// DeleteFinishedTransactionsBefore dates each transaction by its last meter value as the time
+// that a transaction ended is not recorded
+func (s *Store) DeleteFinishedTransactionsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	snaps, err := s.client.Collection("Transaction").Where("EndedSeqNo", ">", 0).Documents(ctx).GetAll()
+	if err != nil {
+		return 0, fmt.Errorf("delete finished transactions before %s: %w", before.Format(time.RFC3339), err)
+	}
+
+	type finished struct {
+		snap  *firestore.DocumentSnapshot
+		ended time.Time
+	}
+	var candidates []finished
+	for _, snap := range snaps {
+		var transaction store.Transaction
+		if err := snap.DataTo(&transaction); err != nil {
+			return 0, fmt.Errorf("map transaction %s: %w", snap.Ref.ID, err)
+		}
+		if len(transaction.MeterValues) == 0 {
+			continue
+		}
+		ended, err := time.Parse(time.RFC3339, transaction.MeterValues[len(transaction.MeterValues)-1].Timestamp)
+		if err == nil && ended.Before(before) {
+			candidates = append(candidates, finished{snap: snap, ended: ended})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ended.Before(candidates[j].ended)
+	})
+
+	candidates = candidates[:min(limit, len(candidates))]
+	selected := make([]*firestore.DocumentSnapshot, 0, len(candidates))
+	for _, candidate := range candidates {
+		selected = append(selected, candidate.snap)
+	}
+	deleted, err := deleteDocuments(ctx, selected)
+	if err != nil {
+		return deleted, fmt.Errorf("delete finished transactions before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return deleted, nil
+}
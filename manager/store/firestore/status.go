@@ -28,14 +28,16 @@ type connectorStatus struct {
 }
 
 type chargeStationStatus struct {
-	ChargeStationId string     `firestore:"chargeStationId"`
-	Connected       bool       `firestore:"connected"`
-	LastHeartbeat   *time.Time `firestore:"lastHeartbeat,omitempty"`
-	FirmwareVersion *string    `firestore:"firmwareVersion,omitempty"`
-	Model           *string    `firestore:"model,omitempty"`
-	Vendor          *string    `firestore:"vendor,omitempty"`
-	SerialNumber    *string    `firestore:"serialNumber,omitempty"`
-	UpdatedAt       time.Time  `firestore:"updatedAt"`
+	ChargeStationId  string     `firestore:"chargeStationId"`
+	Connected        bool       `firestore:"connected"`
+	LastHeartbeat    *time.Time `firestore:"lastHeartbeat,omitempty"`
+	FirmwareVersion  *string    `firestore:"firmwareVersion,omitempty"`
+	Model            *string    `firestore:"model,omitempty"`
+	Vendor           *string    `firestore:"vendor,omitempty"`
+	SerialNumber     *string    `firestore:"serialNumber,omitempty"`
+	LastConnected    *time.Time `firestore:"lastConnected,omitempty"`
+	LastDisconnected *time.Time `firestore:"lastDisconnected,omitempty"`
+	UpdatedAt        time.Time  `firestore:"updatedAt"`
 }
 
 func (s *Store) SetConnectorStatus(ctx context.Context, chargeStationId string, connectorId int, status *store.ConnectorStatus) error {
@@ -133,30 +135,39 @@ func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId strin
 func (s *Store) SetChargeStationStatus(ctx context.Context, chargeStationId string, status *store.ChargeStationStatus) error {
 	docRef := s.client.Doc(fmt.Sprintf("ChargeStationStatus/%s", chargeStationId))
 
-	data := &chargeStationStatus{
-		ChargeStationId: chargeStationId,
-		Connected:       status.Connected,
-		LastHeartbeat:   status.LastHeartbeat,
-		FirmwareVersion: status.FirmwareVersion,
-		Model:           status.Model,
-		Vendor:          status.Vendor,
-		SerialNumber:    status.SerialNumber,
-		UpdatedAt:       s.clock.Now(),
+	// merge so that the connection times, which are only maintained by
+	// SetChargeStationConnected, are left untouched
+	data := map[string]any{
+		"chargeStationId": chargeStationId,
+		"connected":       status.Connected,
+		"lastHeartbeat":   valueOrDelete(status.LastHeartbeat),
+		"firmwareVersion": valueOrDelete(status.FirmwareVersion),
+		"model":           valueOrDelete(status.Model),
+		"vendor":          valueOrDelete(status.Vendor),
+		"serialNumber":    valueOrDelete(status.SerialNumber),
+		"updatedAt":       s.clock.Now(),
 	}
 
-	_, err := docRef.Set(ctx, data)
+	_, err := docRef.Set(ctx, data, firestore.MergeAll)
 	if err != nil {
 		return fmt.Errorf("setting charge station status for %s: %w", chargeStationId, err)
 	}
 	return nil
 }
 
+func valueOrDelete[T any](v *T) any {
+	if v == nil {
+		return firestore.Delete
+	}
+	return *v
+}
+
 func (s *Store) GetChargeStationStatus(ctx context.Context, chargeStationId string) (*store.ChargeStationStatus, error) {
 	docRef := s.client.Doc(fmt.Sprintf("ChargeStationStatus/%s", chargeStationId))
 	snap, err := docRef.Get(ctx)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, fmt.Errorf("charge station %s not found", chargeStationId)
+			return nil, fmt.Errorf("charge station %s %w", chargeStationId, store.ErrChargeStationStatusNotFound)
 		}
 		return nil, fmt.Errorf("getting charge station status for %s: %w", chargeStationId, err)
 	}
@@ -167,14 +178,16 @@ func (s *Store) GetChargeStationStatus(ctx context.Context, chargeStationId stri
 	}
 
 	return &store.ChargeStationStatus{
-		ChargeStationId: data.ChargeStationId,
-		Connected:       data.Connected,
-		LastHeartbeat:   data.LastHeartbeat,
-		FirmwareVersion: data.FirmwareVersion,
-		Model:           data.Model,
-		Vendor:          data.Vendor,
-		SerialNumber:    data.SerialNumber,
-		UpdatedAt:       data.UpdatedAt,
+		ChargeStationId:  data.ChargeStationId,
+		Connected:        data.Connected,
+		LastHeartbeat:    data.LastHeartbeat,
+		FirmwareVersion:  data.FirmwareVersion,
+		Model:            data.Model,
+		Vendor:           data.Vendor,
+		SerialNumber:     data.SerialNumber,
+		LastConnected:    data.LastConnected,
+		LastDisconnected: data.LastDisconnected,
+		UpdatedAt:        data.UpdatedAt,
 	}, nil
 }
 
@@ -207,3 +220,40 @@ func (s *Store) UpdateHeartbeat(ctx context.Context, chargeStationId string, tim
 	}
 	return nil
 }
+
+func (s *Store) SetChargeStationConnected(ctx context.Context, chargeStationId string, connected bool, timestamp time.Time) error {
+	docRef := s.client.Doc(fmt.Sprintf("ChargeStationStatus/%s", chargeStationId))
+
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		var data chargeStationStatus
+		snap, err := tx.Get(docRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			if err := snap.DataTo(&data); err != nil {
+				return err
+			}
+		}
+
+		if (data.LastConnected != nil && data.LastConnected.After(timestamp)) ||
+			(data.LastDisconnected != nil && data.LastDisconnected.After(timestamp)) {
+			return nil
+		}
+
+		data.ChargeStationId = chargeStationId
+		data.Connected = connected
+		if connected {
+			data.LastConnected = &timestamp
+		} else {
+			data.LastDisconnected = &timestamp
+		}
+		data.UpdatedAt = s.clock.Now()
+
+		return tx.Set(docRef, &data)
+	})
+	if err != nil {
+		return fmt.Errorf("setting charge station connected for %s: %w", chargeStationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	clock2 "k8s.io/utils/clock"
+)
+
+func newDeadLetter(id string, createdAt time.Time) *store.DeadLetter {
+	return &store.DeadLetter{
+		Id:              id,
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		MessageType:     "call",
+		Action:          "Heartbeat",
+		MessageId:       "msg-" + id,
+		Message:         `{"type":2,"action":"Heartbeat","id":"msg-` + id + `","request":{}}`,
+		Error:           "store unavailable",
+		CreatedAt:       createdAt,
+	}
+}
+
+func TestDeadLetterLifecycle(t *testing.T) {
+	s := inmemory.NewStore(clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	want := newDeadLetter("abc", now)
+	err := s.AddDeadLetter(ctx, want)
+	require.NoError(t, err)
+
+	got, err := s.LookupDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	err = s.DeleteDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+
+	got, err = s.LookupDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	err = s.DeleteDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+}
+
+func TestListDeadLetters(t *testing.T) {
+	s := inmemory.NewStore(clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	for i, id := range []string{"a", "b", "c"} {
+		err := s.AddDeadLetter(ctx, newDeadLetter(id, now.Add(time.Duration(i)*time.Minute)))
+		require.NoError(t, err)
+	}
+
+	deadLetters, total, err := s.ListDeadLetters(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, deadLetters, 2)
+	assert.Equal(t, "c", deadLetters[0].Id)
+	assert.Equal(t, "b", deadLetters[1].Id)
+
+	deadLetters, total, err = s.ListDeadLetters(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "a", deadLetters[0].Id)
+
+	deadLetters, _, err = s.ListDeadLetters(ctx, 5, 2)
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
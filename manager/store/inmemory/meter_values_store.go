@@ -5,6 +5,7 @@ package inmemory
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
@@ -14,6 +15,12 @@ type meterValueKey struct {
 	evseId          int
 }
 
+type meterValueRollupKey struct {
+	meterValueKey
+	interval store.MeterValueInterval
+	start    time.Time
+}
+
 // StoreMeterValues stores meter values received from a charge station.
 func (s *Store) StoreMeterValues(_ context.Context, chargeStationId string, evseId int, transactionId string, meterValues []store.MeterValue) error {
 	s.Lock()
@@ -28,8 +35,13 @@ func (s *Store) StoreMeterValues(_ context.Context, chargeStationId string, evse
 		s.meterValues = make(map[meterValueKey][]store.StoredMeterValue)
 	}
 
-	// Append new meter values
+	// Append new meter values, ignoring those that have already been stored
+	var added []store.MeterValue
 	for _, mv := range meterValues {
+		if s.hasMeterValue(key, mv) {
+			continue
+		}
+		added = append(added, mv)
 		s.meterValues[key] = append(s.meterValues[key], store.StoredMeterValue{
 			ChargeStationId: chargeStationId,
 			EvseId:          evseId,
@@ -43,9 +55,39 @@ func (s *Store) StoreMeterValues(_ context.Context, chargeStationId string, evse
 		return s.meterValues[key][i].MeterValue.Timestamp > s.meterValues[key][j].MeterValue.Timestamp
 	})
 
+	if s.meterValueRollups == nil {
+		s.meterValueRollups = make(map[meterValueRollupKey]*store.MeterValueRollup)
+	}
+
+	for _, rollup := range store.RollUpMeterValues(chargeStationId, evseId, added) {
+		rollupKey := meterValueRollupKey{meterValueKey: key, interval: rollup.Interval, start: rollup.Start}
+		if existing, ok := s.meterValueRollups[rollupKey]; ok {
+			existing.Merge(rollup)
+		} else {
+			s.meterValueRollups[rollupKey] = rollup
+		}
+	}
+
 	return nil
 }
 
+// hasMeterValue reports whether the meter value has already been stored for the EVSE
+func (s *Store) hasMeterValue(key meterValueKey, mv store.MeterValue) bool {
+	var digest string
+	for _, existing := range s.meterValues[key] {
+		if existing.MeterValue.Timestamp != mv.Timestamp {
+			continue
+		}
+		if digest == "" {
+			digest = store.MeterValueDigest(mv)
+		}
+		if store.MeterValueDigest(existing.MeterValue) == digest {
+			return true
+		}
+	}
+	return false
+}
+
 // GetMeterValues retrieves meter values for a specific charge station and EVSE.
 func (s *Store) GetMeterValues(_ context.Context, chargeStationId string, evseId int, limit int) ([]store.StoredMeterValue, error) {
 	s.Lock()
@@ -124,3 +166,37 @@ func (s *Store) QueryMeterValues(_ context.Context, filter store.MeterValuesFilt
 
 	return result, nil
 }
+
+// QueryMeterValueRollups retrieves the rollups for a charge station, ordered by EVSE and start.
+func (s *Store) QueryMeterValueRollups(_ context.Context, filter store.MeterValueRollupsFilter) ([]*store.MeterValueRollup, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	result := []*store.MeterValueRollup{}
+	for key, rollup := range s.meterValueRollups {
+		if key.chargeStationId != filter.ChargeStationId || key.interval != filter.Interval {
+			continue
+		}
+		if filter.ConnectorId != nil && key.evseId != *filter.ConnectorId {
+			continue
+		}
+		if filter.StartTime != nil && key.start.Before(*filter.StartTime) {
+			continue
+		}
+		if filter.EndTime != nil && key.start.After(*filter.EndTime) {
+			continue
+		}
+
+		copied := *rollup
+		result = append(result, &copied)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EvseId != result[j].EvseId {
+			return result[i].EvseId < result[j].EvseId
+		}
+		return result[i].Start.Before(result[j].Start)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// dated identifies a record held in one of the store's maps along with the time it is dated by
+type dated[K comparable] struct {
+	key   K
+	index int
+	date  time.Time
+}
+
+// oldest returns at most limit of the records, oldest first
+func oldest[K comparable](records []dated[K], limit int) []dated[K] {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].date.Before(records[j].date)
+	})
+	return records[:min(limit, len(records))]
+}
+
+// pruneSlices removes the selected records from the slices held by the map
+func pruneSlices[K comparable, V any](m map[K][]V, records []dated[K]) {
+	selected := make(map[K]map[int]bool)
+	for _, r := range records {
+		if selected[r.key] == nil {
+			selected[r.key] = make(map[int]bool)
+		}
+		selected[r.key][r.index] = true
+	}
+
+	for key, indexes := range selected {
+		var kept []V
+		for i, v := range m[key] {
+			if !indexes[i] {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) == 0 {
+			delete(m, key)
+		} else {
+			m[key] = kept
+		}
+	}
+}
+
+func (s *Store) DeleteMeterValuesBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var records []dated[meterValueKey]
+	for key, values := range s.meterValues {
+		for i, value := range values {
+			timestamp, err := time.Parse(time.RFC3339, value.MeterValue.Timestamp)
+			if err == nil && timestamp.Before(before) {
+				records = append(records, dated[meterValueKey]{key: key, index: i, date: timestamp})
+			}
+		}
+	}
+
+	records = oldest(records, limit)
+	pruneSlices(s.meterValues, records)
+	return len(records), nil
+}
+
+func (s *Store) DeleteChargeStationEventsBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var records []dated[string]
+	for chargeStationId, events := range s.chargeStationEvents {
+		for i, event := range events {
+			if event.Timestamp.Before(before) {
+				records = append(records, dated[string]{key: chargeStationId, index: i, date: event.Timestamp})
+			}
+		}
+	}
+
+	records = oldest(records, limit)
+	pruneSlices(s.chargeStationEvents, records)
+	return len(records), nil
+}
+
+func (s *Store) DeleteDeviceReportsBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var records []dated[string]
+	for chargeStationId, reports := range s.deviceReports {
+		for i, report := range reports {
+			if report.GeneratedAt.Before(before) {
+				records = append(records, dated[string]{key: chargeStationId, index: i, date: report.GeneratedAt})
+			}
+		}
+	}
+
+	records = oldest(records, limit)
+	pruneSlices(s.deviceReports, records)
+	return len(records), nil
+}
+
+func (s *Store) DeleteFinishedTransactionsBefore(_ context.Context, before time.Time, limit int) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var records []dated[string]
+	for key, transaction := range s.transactions {
+		if ended, ok := transactionEnded(transaction); ok && ended.Before(before) {
+			records = append(records, dated[string]{key: key, date: ended})
+		}
+	}
+
+	records = oldest(records, limit)
+	for _, r := range records {
+		delete(s.transactions, r.key)
+	}
+	return len(records), nil
+}
+
+// transactionEnded returns the time of the last meter value of a transaction that has ended
+func transactionEnded(transaction *store.Transaction) (time.Time, bool) {
+	if transaction.EndedSeqNo == 0 || len(transaction.MeterValues) == 0 {
+		return time.Time{}, false
+	}
+	ended, err := time.Parse(time.RFC3339, transaction.MeterValues[len(transaction.MeterValues)-1].Timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ended, true
+}
@@ -9,7 +9,6 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
-	"math"
 	"sort"
 	"sync"
 	"time"
@@ -55,6 +54,7 @@ type Store struct {
 	localAuthListEntries             map[string]map[string]*store.LocalAuthListEntry
 	reservations                     map[int]*store.Reservation
 	meterValues                      map[meterValueKey][]store.StoredMeterValue
+	meterValueRollups                map[meterValueRollupKey]*store.MeterValueRollup
 	displayMessages                  map[string]map[int]*store.DisplayMessage
 	resetRequests                    map[string]*store.ResetRequest
 	unlockConnectorRequests          map[string]*store.UnlockConnectorRequest
@@ -68,6 +68,18 @@ type Store struct {
 	chargeStationEventNextId         int
 	deviceReports                    map[string][]*store.DeviceReport
 	deviceReportNextId               int
+	commands                         map[string]*store.Command
+	deadLetters                      map[string]*store.DeadLetter
+}
+
+// pageKeys sorts the keys and returns the first pageSize keys that come after previousKey
+func pageKeys(keys []string, pageSize int, previousKey string) []string {
+	sort.Strings(keys)
+	i, found := slices.BinarySearch(keys, previousKey)
+	if found {
+		i++
+	}
+	return keys[i:min(i+pageSize, len(keys))]
 }
 
 func NewStore(clock clock.PassiveClock) *Store {
@@ -101,6 +113,7 @@ func NewStore(clock clock.PassiveClock) *Store {
 		localAuthListEntries:             make(map[string]map[string]*store.LocalAuthListEntry),
 		reservations:                     make(map[int]*store.Reservation),
 		meterValues:                      make(map[meterValueKey][]store.StoredMeterValue),
+		meterValueRollups:                make(map[meterValueRollupKey]*store.MeterValueRollup),
 		displayMessages:                  make(map[string]map[int]*store.DisplayMessage),
 		resetRequests:                    make(map[string]*store.ResetRequest),
 		unlockConnectorRequests:          make(map[string]*store.UnlockConnectorRequest),
@@ -114,6 +127,8 @@ func NewStore(clock clock.PassiveClock) *Store {
 		chargeStationEventNextId:         1,
 		deviceReports:                    make(map[string][]*store.DeviceReport),
 		deviceReportNextId:               1,
+		commands:                         make(map[string]*store.Command),
+		deadLetters:                      make(map[string]*store.DeadLetter),
 	}
 }
 
@@ -198,19 +213,9 @@ func (s *Store) ListFirmwareUpdateRequests(_ context.Context, pageSize int, prev
 	s.Lock()
 	defer s.Unlock()
 
-	ids := maps.Keys(s.firmwareUpdateRequests)
-	sort.Strings(ids)
-
 	var requests []*store.FirmwareUpdateRequest
-	count := 0
-	startIndex := 0
-	if previousChargeStationId != "" {
-		startIndex = sort.SearchStrings(ids, previousChargeStationId) + 1
-	}
-
-	for i := startIndex; i < len(ids) && count < pageSize; i++ {
-		requests = append(requests, s.firmwareUpdateRequests[ids[i]])
-		count++
+	for _, id := range pageKeys(maps.Keys(s.firmwareUpdateRequests), pageSize, previousChargeStationId) {
+		requests = append(requests, s.firmwareUpdateRequests[id])
 	}
 
 	return requests, nil
@@ -265,19 +270,8 @@ func (s *Store) ListChargeStationSettings(_ context.Context, pageSize int, previ
 	s.Lock()
 	defer s.Unlock()
 
-	keys := maps.Keys(s.chargeStationSettings)
-	sort.Strings(keys)
-
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
-
 	var settings []*store.ChargeStationSettings
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.chargeStationSettings), pageSize, previousChargeStationId) {
 		settings = append(settings, s.chargeStationSettings[k])
 	}
 	return settings, nil
@@ -325,19 +319,8 @@ func (s *Store) ListChargeStationInstallCertificates(_ context.Context, pageSize
 	s.Lock()
 	defer s.Unlock()
 
-	keys := maps.Keys(s.chargeStationInstallCertificates)
-	sort.Strings(keys)
-
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
-
 	var installCertificates []*store.ChargeStationInstallCertificates
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.chargeStationInstallCertificates), pageSize, previousChargeStationId) {
 		installCertificates = append(installCertificates, s.chargeStationInstallCertificates[k])
 	}
 	return installCertificates, nil
@@ -380,19 +363,8 @@ func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize i
 	s.Lock()
 	defer s.Unlock()
 
-	keys := maps.Keys(s.chargeStationTriggerMessage)
-	sort.Strings(keys)
-
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
-
 	var triggerMessages []*store.ChargeStationTriggerMessage
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.chargeStationTriggerMessage), pageSize, previousChargeStationId) {
 		triggerMessages = append(triggerMessages, s.chargeStationTriggerMessage[k])
 	}
 	return triggerMessages, nil
@@ -416,12 +388,12 @@ func (s *Store) ListTokens(_ context.Context, offset int, limit int) ([]*store.T
 	s.Lock()
 	defer s.Unlock()
 	var tokens []*store.Token
-	count := 0
-	for _, token := range s.tokens {
-		if count >= offset && count < offset+limit {
-			tokens = append(tokens, token)
+	uids := maps.Keys(s.tokens)
+	slices.Sort(uids)
+	for i, uid := range uids {
+		if i >= offset && i < offset+limit {
+			tokens = append(tokens, s.tokens[uid])
 		}
-		count++
 	}
 	if tokens == nil {
 		tokens = make([]*store.Token, 0)
@@ -579,6 +551,9 @@ func (s *Store) ListTransactionsForChargeStation(_ context.Context, chargeStatio
 			allTransactions = append(allTransactions, txn)
 		}
 	}
+	sort.Slice(allTransactions, func(i, j int) bool {
+		return allTransactions[i].TransactionId < allTransactions[j].TransactionId
+	})
 
 	total := int64(len(allTransactions))
 
@@ -619,17 +594,8 @@ func (s *Store) DeleteRemoteStartTransactionRequest(_ context.Context, chargeSta
 func (s *Store) ListRemoteStartTransactionRequests(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.RemoteStartTransactionRequest, error) {
 	s.Lock()
 	defer s.Unlock()
-	keys := maps.Keys(s.remoteStartTransactionRequests)
-	sort.Strings(keys)
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
 	var result []*store.RemoteStartTransactionRequest
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.remoteStartTransactionRequests), pageSize, previousChargeStationId) {
 		result = append(result, s.remoteStartTransactionRequests[k])
 	}
 	return result, nil
@@ -658,17 +624,8 @@ func (s *Store) DeleteRemoteStopTransactionRequest(_ context.Context, chargeStat
 func (s *Store) ListRemoteStopTransactionRequests(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.RemoteStopTransactionRequest, error) {
 	s.Lock()
 	defer s.Unlock()
-	keys := maps.Keys(s.remoteStopTransactionRequests)
-	sort.Strings(keys)
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
 	var result []*store.RemoteStopTransactionRequest
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.remoteStopTransactionRequests), pageSize, previousChargeStationId) {
 		result = append(result, s.remoteStopTransactionRequests[k])
 	}
 	return result, nil
@@ -805,12 +762,12 @@ func (s *Store) ListLocations(_ context.Context, offset int, limit int) ([]*stor
 	s.Lock()
 	defer s.Unlock()
 	var locations []*store.Location
-	count := 0
-	for _, location := range s.locations {
-		if count >= offset && count < offset+limit {
-			locations = append(locations, location)
+	ids := maps.Keys(s.locations)
+	slices.Sort(ids)
+	for i, id := range ids {
+		if i >= offset && i < offset+limit {
+			locations = append(locations, s.locations[id])
 		}
-		count++
 	}
 	if locations == nil {
 		locations = make([]*store.Location, 0)
@@ -1160,10 +1117,9 @@ func (s *Store) ListDisplayMessages(_ context.Context, chargeStationId string, s
 		}
 		result = append(result, msg)
 	}
-
-	if result == nil {
-		result = make([]*store.DisplayMessage, 0)
-	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
 
 	return result, nil
 }
@@ -1269,19 +1225,8 @@ func (s *Store) ListChargeStationCertificateQueries(_ context.Context, pageSize
 	s.Lock()
 	defer s.Unlock()
 
-	keys := maps.Keys(s.chargeStationCertificateQuery)
-	sort.Strings(keys)
-
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
-
 	var queries []*store.ChargeStationCertificateQuery
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.chargeStationCertificateQuery), pageSize, previousChargeStationId) {
 		queries = append(queries, s.chargeStationCertificateQuery[k])
 	}
 	return queries, nil
@@ -1311,19 +1256,8 @@ func (s *Store) ListChargeStationCertificateDeletions(_ context.Context, pageSiz
 	s.Lock()
 	defer s.Unlock()
 
-	keys := maps.Keys(s.chargeStationCertificateDeletion)
-	sort.Strings(keys)
-
-	i, found := slices.BinarySearch(keys, previousChargeStationId)
-	if !found {
-		i = 0
-	} else {
-		i++
-	}
-
 	var deletions []*store.ChargeStationCertificateDeletion
-	max := int(math.Min(float64(i+pageSize), float64(len(keys))))
-	for _, k := range keys[i:max] {
+	for _, k := range pageKeys(maps.Keys(s.chargeStationCertificateDeletion), pageSize, previousChargeStationId) {
 		deletions = append(deletions, s.chargeStationCertificateDeletion[k])
 	}
 	return deletions, nil
@@ -1353,17 +1287,9 @@ func (s *Store) DeleteDiagnosticsRequest(_ context.Context, chargeStationId stri
 func (s *Store) ListDiagnosticsRequests(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.DiagnosticsRequest, error) {
 	s.Lock()
 	defer s.Unlock()
-	ids := maps.Keys(s.diagnosticsRequests)
-	sort.Strings(ids)
 	var requests []*store.DiagnosticsRequest
-	count := 0
-	startIndex := 0
-	if previousChargeStationId != "" {
-		startIndex = sort.SearchStrings(ids, previousChargeStationId) + 1
-	}
-	for i := startIndex; i < len(ids) && count < pageSize; i++ {
-		requests = append(requests, s.diagnosticsRequests[ids[i]])
-		count++
+	for _, id := range pageKeys(maps.Keys(s.diagnosticsRequests), pageSize, previousChargeStationId) {
+		requests = append(requests, s.diagnosticsRequests[id])
 	}
 	return requests, nil
 }
@@ -1392,17 +1318,9 @@ func (s *Store) DeleteLogRequest(_ context.Context, chargeStationId string) erro
 func (s *Store) ListLogRequests(_ context.Context, pageSize int, previousChargeStationId string) ([]*store.LogRequest, error) {
 	s.Lock()
 	defer s.Unlock()
-	ids := maps.Keys(s.logRequests)
-	sort.Strings(ids)
 	var requests []*store.LogRequest
-	count := 0
-	startIndex := 0
-	if previousChargeStationId != "" {
-		startIndex = sort.SearchStrings(ids, previousChargeStationId) + 1
-	}
-	for i := startIndex; i < len(ids) && count < pageSize; i++ {
-		requests = append(requests, s.logRequests[ids[i]])
-		count++
+	for _, id := range pageKeys(maps.Keys(s.logRequests), pageSize, previousChargeStationId) {
+		requests = append(requests, s.logRequests[id])
 	}
 	return requests, nil
 }
@@ -1468,6 +1386,11 @@ func (s *Store) SetChargeStationStatus(_ context.Context, chargeStationId string
 
 	statusCopy := *status
 	statusCopy.UpdatedAt = time.Now()
+	if existing, ok := s.chargeStationStatuses[chargeStationId]; ok {
+		// connection times are only maintained by SetChargeStationConnected
+		statusCopy.LastConnected = existing.LastConnected
+		statusCopy.LastDisconnected = existing.LastDisconnected
+	}
 	s.chargeStationStatuses[chargeStationId] = &statusCopy
 
 	return nil
@@ -1479,7 +1402,7 @@ func (s *Store) GetChargeStationStatus(_ context.Context, chargeStationId string
 
 	status, ok := s.chargeStationStatuses[chargeStationId]
 	if !ok {
-		return nil, fmt.Errorf("charge station %s not found", chargeStationId)
+		return nil, fmt.Errorf("charge station %s %w", chargeStationId, store.ErrChargeStationStatusNotFound)
 	}
 
 	statusCopy := *status
@@ -1507,6 +1430,34 @@ func (s *Store) UpdateHeartbeat(_ context.Context, chargeStationId string, times
 	return nil
 }
 
+func (s *Store) SetChargeStationConnected(_ context.Context, chargeStationId string, connected bool, timestamp time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	status, ok := s.chargeStationStatuses[chargeStationId]
+	if !ok {
+		status = &store.ChargeStationStatus{
+			ChargeStationId: chargeStationId,
+		}
+	}
+
+	if (status.LastConnected != nil && status.LastConnected.After(timestamp)) ||
+		(status.LastDisconnected != nil && status.LastDisconnected.After(timestamp)) {
+		return nil
+	}
+
+	status.Connected = connected
+	if connected {
+		status.LastConnected = &timestamp
+	} else {
+		status.LastDisconnected = &timestamp
+	}
+	status.UpdatedAt = time.Now()
+	s.chargeStationStatuses[chargeStationId] = status
+
+	return nil
+}
+
 // VariableMonitoringStore implementation
 
 func (s *Store) SetVariableMonitoring(_ context.Context, chargeStationId string, config *store.VariableMonitoringConfig) error {
@@ -1600,7 +1551,10 @@ func (s *Store) ListChargeStationEvents(_ context.Context, chargeStationId strin
 	s.Lock()
 	defer s.Unlock()
 
-	events := s.chargeStationEvents[chargeStationId]
+	events := slices.Clone(s.chargeStationEvents[chargeStationId])
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
 	total := len(events)
 
 	if offset >= total {
@@ -1635,7 +1589,10 @@ func (s *Store) ListDeviceReports(_ context.Context, chargeStationId string, off
 	s.Lock()
 	defer s.Unlock()
 
-	reports := s.deviceReports[chargeStationId]
+	reports := slices.Clone(s.deviceReports[chargeStationId])
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].GeneratedAt.After(reports[j].GeneratedAt)
+	})
 	total := len(reports)
 
 	if offset >= total {
@@ -1649,3 +1606,208 @@ func (s *Store) ListDeviceReports(_ context.Context, chargeStationId string, off
 
 	return reports[offset:end], total, nil
 }
+
+// CommandStore implementation
+
+func (s *Store) CreateCommand(_ context.Context, command *store.Command) error {
+	s.Lock()
+	defer s.Unlock()
+
+	commandCopy := *command
+	s.commands[command.Id] = &commandCopy
+	return nil
+}
+
+func (s *Store) SetCommandSent(_ context.Context, commandId string, sentAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	command, ok := s.commands[commandId]
+	if !ok || command.Status != store.CommandStatusQueued {
+		return nil
+	}
+	commandCopy := *command
+	commandCopy.Status = store.CommandStatusSent
+	commandCopy.UpdatedAt = sentAt
+	s.commands[commandId] = &commandCopy
+	return nil
+}
+
+func (s *Store) SetCommandResult(_ context.Context, chargeStationId, commandId string, result *store.CommandResult) error {
+	s.Lock()
+	defer s.Unlock()
+
+	command, ok := s.commands[commandId]
+	if !ok || command.ChargeStationId != chargeStationId {
+		return nil
+	}
+	commandCopy := *command
+	commandCopy.Status = result.Status
+	commandCopy.Response = result.Response
+	commandCopy.ErrorCode = result.ErrorCode
+	commandCopy.ErrorDescription = result.ErrorDescription
+	commandCopy.UpdatedAt = result.UpdatedAt
+	s.commands[commandId] = &commandCopy
+	return nil
+}
+
+func (s *Store) LookupCommand(_ context.Context, commandId string) (*store.Command, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	command, ok := s.commands[commandId]
+	if !ok {
+		return nil, nil
+	}
+	commandCopy := *command
+	return &commandCopy, nil
+}
+
+func (s *Store) ListCommands(_ context.Context, chargeStationId string, offset int, limit int) ([]*store.Command, int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var commands []*store.Command
+	for _, command := range s.commands {
+		if command.ChargeStationId == chargeStationId {
+			commandCopy := *command
+			commands = append(commands, &commandCopy)
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].CreatedAt.Equal(commands[j].CreatedAt) {
+			return commands[i].Id < commands[j].Id
+		}
+		return commands[i].CreatedAt.After(commands[j].CreatedAt)
+	})
+	total := len(commands)
+
+	if offset >= total {
+		return []*store.Command{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return commands[offset:end], total, nil
+}
+
+func (s *Store) TimeOutCommands(_ context.Context, updatedBefore time.Time, timedOutAt time.Time) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	count := 0
+	for id, command := range s.commands {
+		if (command.Status == store.CommandStatusQueued || command.Status == store.CommandStatusSent) &&
+			command.UpdatedAt.Before(updatedBefore) {
+			commandCopy := *command
+			commandCopy.Status = store.CommandStatusTimedOut
+			commandCopy.UpdatedAt = timedOutAt
+			s.commands[id] = &commandCopy
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeadLetterStore implementation
+
+func (s *Store) AddDeadLetter(_ context.Context, deadLetter *store.DeadLetter) error {
+	s.Lock()
+	defer s.Unlock()
+
+	deadLetterCopy := *deadLetter
+	s.deadLetters[deadLetter.Id] = &deadLetterCopy
+	return nil
+}
+
+func (s *Store) LookupDeadLetter(_ context.Context, deadLetterId string) (*store.DeadLetter, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	deadLetter, ok := s.deadLetters[deadLetterId]
+	if !ok {
+		return nil, nil
+	}
+	deadLetterCopy := *deadLetter
+	return &deadLetterCopy, nil
+}
+
+func (s *Store) ListDeadLetters(_ context.Context, offset int, limit int) ([]*store.DeadLetter, int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	deadLetters := make([]*store.DeadLetter, 0, len(s.deadLetters))
+	for _, deadLetter := range s.deadLetters {
+		deadLetterCopy := *deadLetter
+		deadLetters = append(deadLetters, &deadLetterCopy)
+	}
+	sort.Slice(deadLetters, func(i, j int) bool {
+		if deadLetters[i].CreatedAt.Equal(deadLetters[j].CreatedAt) {
+			return deadLetters[i].Id < deadLetters[j].Id
+		}
+		return deadLetters[i].CreatedAt.After(deadLetters[j].CreatedAt)
+	})
+	total := len(deadLetters)
+
+	if offset >= total {
+		return []*store.DeadLetter{}, total, nil
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return deadLetters[offset:end], total, nil
+}
+
+func (s *Store) DeleteDeadLetter(_ context.Context, deadLetterId string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.deadLetters, deadLetterId)
+	return nil
+}
+
+func (s *Store) ListChargeStationIds(_ context.Context, pageSize int, previousChargeStationId string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return pageKeys(maps.Keys(s.chargeStationAuth), pageSize, previousChargeStationId), nil
+}
+
+func (s *Store) ListCertificateHashes(_ context.Context, pageSize int, previousCertificateHash string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return pageKeys(maps.Keys(s.certificates), pageSize, previousCertificateHash), nil
+}
+
+func (s *Store) ListRegistrationTokens(_ context.Context, pageSize int, previousToken string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return pageKeys(maps.Keys(s.registrations), pageSize, previousToken), nil
+}
+
+func (s *Store) ListReservations(_ context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	ids := maps.Keys(s.reservations)
+	slices.Sort(ids)
+	i, found := slices.BinarySearch(ids, previousReservationId)
+	if found {
+		i++
+	}
+
+	reservations := make([]*store.Reservation, 0)
+	for _, id := range ids[i:min(i+pageSize, len(ids))] {
+		r := *s.reservations[id]
+		reservations = append(reservations, &r)
+	}
+	return reservations, nil
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MeterValueInterval is the length of the buckets that meter values are rolled up into
+type MeterValueInterval string
+
+var (
+	MeterValueInterval5m MeterValueInterval = "5m"
+	MeterValueInterval1h MeterValueInterval = "1h"
+	MeterValueInterval1d MeterValueInterval = "1d"
+)
+
+// MeterValueIntervals are the intervals that rollups are maintained for
+var MeterValueIntervals = []MeterValueInterval{MeterValueInterval5m, MeterValueInterval1h, MeterValueInterval1d}
+
+// Duration returns the length of the interval, or zero if the interval is not known
+func (i MeterValueInterval) Duration() time.Duration {
+	switch i {
+	case MeterValueInterval5m:
+		return 5 * time.Minute
+	case MeterValueInterval1h:
+		return time.Hour
+	case MeterValueInterval1d:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// BucketStart returns the start of the bucket that contains the time. Buckets are aligned to UTC.
+func (i MeterValueInterval) BucketStart(t time.Time) time.Time {
+	return t.UTC().Truncate(i.Duration())
+}
+
+// MeterValueRollup summarises the meter values received for an EVSE over one interval. The
+// summary is built so that rollups for the same bucket can be merged as more meter values arrive.
+type MeterValueRollup struct {
+	ChargeStationId string
+	EvseId          int
+	Interval        MeterValueInterval
+	Start           time.Time
+	// SampleCount is the number of meter values that have been rolled up into the bucket
+	SampleCount int
+	// EnergyFirst is the earliest reading of the active energy import register in the bucket, in Wh
+	EnergyFirst   *float64
+	EnergyFirstAt *time.Time
+	// EnergyLast is the latest reading of the active energy import register in the bucket, in Wh
+	EnergyLast   *float64
+	EnergyLastAt *time.Time
+	// MaxPower is the highest active power import in the bucket, in W
+	MaxPower *float64
+	// VoltageSum and VoltageCount are kept, rather than the average, so that rollups can be merged
+	VoltageSum   float64
+	VoltageCount int
+}
+
+// End returns the end of the bucket
+func (r *MeterValueRollup) End() time.Time {
+	return r.Start.Add(r.Interval.Duration())
+}
+
+// Merge adds the meter values summarised by another rollup of the same bucket to the rollup
+func (r *MeterValueRollup) Merge(other *MeterValueRollup) {
+	r.SampleCount += other.SampleCount
+	if other.EnergyFirstAt != nil && (r.EnergyFirstAt == nil || other.EnergyFirstAt.Before(*r.EnergyFirstAt)) {
+		r.EnergyFirst, r.EnergyFirstAt = other.EnergyFirst, other.EnergyFirstAt
+	}
+	if other.EnergyLastAt != nil && (r.EnergyLastAt == nil || !other.EnergyLastAt.Before(*r.EnergyLastAt)) {
+		r.EnergyLast, r.EnergyLastAt = other.EnergyLast, other.EnergyLastAt
+	}
+	if other.MaxPower != nil && (r.MaxPower == nil || *other.MaxPower > *r.MaxPower) {
+		r.MaxPower = other.MaxPower
+	}
+	r.VoltageSum += other.VoltageSum
+	r.VoltageCount += other.VoltageCount
+}
+
+// AverageVoltage returns the mean of the voltages in the bucket, in V
+func (r *MeterValueRollup) AverageVoltage() *float64 {
+	if r.VoltageCount == 0 {
+		return nil
+	}
+	average := r.VoltageSum / float64(r.VoltageCount)
+	return &average
+}
+
+// EnergyDelta returns the energy imported during the bucket, in Wh. The previous rollup, which
+// may be nil, is used to include the energy imported between the last reading of the previous
+// bucket and the first reading of this one when the buckets are adjacent.
+func (r *MeterValueRollup) EnergyDelta(previous *MeterValueRollup) *float64 {
+	if r.EnergyLast == nil {
+		return nil
+	}
+	from := r.EnergyFirst
+	if previous != nil && previous.EnergyLast != nil && previous.End().Equal(r.Start) {
+		from = previous.EnergyLast
+	}
+	delta := *r.EnergyLast - *from
+	return &delta
+}
+
+// RollUpMeterValues summarises meter values received for an EVSE into a rollup for each
+// interval and bucket that they fall into. Meter values with timestamps that cannot be parsed
+// are ignored. The rollups are returned ordered by interval and then by start.
+func RollUpMeterValues(chargeStationId string, evseId int, meterValues []MeterValue) []*MeterValueRollup {
+	type bucket struct {
+		interval MeterValueInterval
+		start    time.Time
+	}
+	rollups := make(map[bucket]*MeterValueRollup)
+	for _, mv := range meterValues {
+		timestamp, err := time.Parse(time.RFC3339, mv.Timestamp)
+		if err != nil {
+			continue
+		}
+		timestamp = timestamp.UTC()
+		sample := rollUpMeterValue(timestamp, mv.SampledValues)
+		for _, interval := range MeterValueIntervals {
+			key := bucket{interval: interval, start: interval.BucketStart(timestamp)}
+			rollup, ok := rollups[key]
+			if !ok {
+				rollup = &MeterValueRollup{
+					ChargeStationId: chargeStationId,
+					EvseId:          evseId,
+					Interval:        interval,
+					Start:           key.start,
+				}
+				rollups[key] = rollup
+			}
+			rollup.Merge(sample)
+		}
+	}
+
+	result := make([]*MeterValueRollup, 0, len(rollups))
+	for _, rollup := range rollups {
+		result = append(result, rollup)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Interval != result[j].Interval {
+			return result[i].Interval.Duration() < result[j].Interval.Duration()
+		}
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result
+}
+
+// rollUpMeterValue summarises a single meter value. Only the energy and power values that are
+// not for a single phase are used so that the totals for the EVSE are not mixed up with them.
+func rollUpMeterValue(timestamp time.Time, sampledValues []SampledValue) *MeterValueRollup {
+	rollup := &MeterValueRollup{SampleCount: 1}
+	for _, sv := range sampledValues {
+		measurand := "Energy.Active.Import.Register"
+		if sv.Measurand != nil {
+			measurand = *sv.Measurand
+		}
+		singlePhase := sv.Phase != nil && *sv.Phase != ""
+		value := normalisedValue(sv)
+
+		switch {
+		case measurand == "Energy.Active.Import.Register" && !singlePhase:
+			rollup.EnergyFirst, rollup.EnergyFirstAt = &value, &timestamp
+			rollup.EnergyLast, rollup.EnergyLastAt = &value, &timestamp
+		case measurand == "Power.Active.Import" && !singlePhase:
+			if rollup.MaxPower == nil || value > *rollup.MaxPower {
+				rollup.MaxPower = &value
+			}
+		case measurand == "Voltage":
+			rollup.VoltageSum += value
+			rollup.VoltageCount++
+		}
+	}
+	return rollup
+}
+
+// normalisedValue returns the sampled value in its base unit (Wh, W or V) with the multiplier applied
+func normalisedValue(sv SampledValue) float64 {
+	value := sv.Value
+	if sv.UnitOfMeasure != nil {
+		value *= math.Pow10(sv.UnitOfMeasure.Multipler)
+		if strings.HasPrefix(sv.UnitOfMeasure.Unit, "k") {
+			value *= 1000
+		}
+	}
+	return value
+}
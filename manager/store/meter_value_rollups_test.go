@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func energyMeterValue(timestamp string, value float64) store.MeterValue {
+	return store.MeterValue{
+		Timestamp: timestamp,
+		SampledValues: []store.SampledValue{
+			// the measurand defaults to the energy register
+			{UnitOfMeasure: &store.UnitOfMeasure{Unit: "Wh"}, Value: value},
+		},
+	}
+}
+
+func TestRollUpMeterValuesIgnoresUnparseableTimestamps(t *testing.T) {
+	rollups := store.RollUpMeterValues("cs001", 1, []store.MeterValue{
+		energyMeterValue("not a timestamp", 100),
+		energyMeterValue("2026-01-01T10:01:00+01:00", 200),
+	})
+
+	require.Len(t, rollups, len(store.MeterValueIntervals))
+	for _, rollup := range rollups {
+		assert.Equal(t, 1, rollup.SampleCount)
+		assert.Equal(t, 200.0, *rollup.EnergyLast)
+	}
+	// buckets are aligned to UTC
+	assert.Equal(t, time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), rollups[0].Start)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), rollups[2].Start)
+}
+
+func TestMeterValueRollupEnergyDelta(t *testing.T) {
+	rollups := store.RollUpMeterValues("cs001", 1, []store.MeterValue{
+		energyMeterValue("2026-01-01T10:01:00Z", 1000),
+		energyMeterValue("2026-01-01T10:04:00Z", 1100),
+		energyMeterValue("2026-01-01T10:06:00Z", 1250),
+		energyMeterValue("2026-01-01T10:09:00Z", 1300),
+		energyMeterValue("2026-01-01T10:21:00Z", 1600),
+		energyMeterValue("2026-01-01T10:24:00Z", 1650),
+	})
+
+	var fiveMinutes []*store.MeterValueRollup
+	for _, rollup := range rollups {
+		if rollup.Interval == store.MeterValueInterval5m {
+			fiveMinutes = append(fiveMinutes, rollup)
+		}
+	}
+	require.Len(t, fiveMinutes, 3)
+
+	// the first bucket only has its own readings
+	assert.Equal(t, 100.0, *fiveMinutes[0].EnergyDelta(nil))
+	// the energy imported between adjacent buckets is included in the later bucket
+	assert.Equal(t, 200.0, *fiveMinutes[1].EnergyDelta(fiveMinutes[0]))
+	// the energy imported over a gap between buckets is not attributed to either of them
+	assert.Equal(t, 50.0, *fiveMinutes[2].EnergyDelta(fiveMinutes[1]))
+
+	assert.Nil(t, (&store.MeterValueRollup{Interval: store.MeterValueInterval5m}).EnergyDelta(nil))
+}
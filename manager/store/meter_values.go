@@ -2,14 +2,22 @@
 
 package store
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
 
-// MeterValuesStore stores standalone meter values received via MeterValues messages.
-// These are separate from transaction-associated meter values which are stored
-// as part of TransactionEvent messages.
+// MeterValuesStore stores the meter values received from charge stations, whether they are
+// sent in MeterValues messages or with transaction events, and maintains rollups of them.
+// The meter values sent with transaction events are also stored with the transaction.
 type MeterValuesStore interface {
 	// StoreMeterValues stores meter values received from a charge station.
 	// The transactionId can be empty if the meter values are not associated with a transaction.
+	// A meter value that has already been stored for the EVSE, e.g. because the message that
+	// carried it was redelivered, is ignored so that it is not counted twice in the rollups.
 	StoreMeterValues(ctx context.Context, chargeStationId string, evseId int, transactionId string, meterValues []MeterValue) error
 
 	// GetMeterValues retrieves meter values for a specific charge station and EVSE.
@@ -20,6 +28,10 @@ type MeterValuesStore interface {
 	// QueryMeterValues retrieves meter values with advanced filtering and pagination.
 	// Filters can be empty strings/zero values to skip that filter.
 	QueryMeterValues(ctx context.Context, filter MeterValuesFilter) (*MeterValuesResult, error)
+
+	// QueryMeterValueRollups retrieves the rollups that are maintained as meter values are stored,
+	// ordered by EVSE and then by the start of the bucket.
+	QueryMeterValueRollups(ctx context.Context, filter MeterValueRollupsFilter) ([]*MeterValueRollup, error)
 }
 
 // MeterValuesFilter defines filtering and pagination options for meter values queries.
@@ -33,6 +45,15 @@ type MeterValuesFilter struct {
 	Offset          int     // Number of results to skip
 }
 
+// MeterValueRollupsFilter defines filtering options for meter value rollup queries.
+type MeterValueRollupsFilter struct {
+	ChargeStationId string             // Required
+	Interval        MeterValueInterval // Required
+	ConnectorId     *int               // Optional: filter by connector (OCPP 1.6) or EVSE (OCPP 2.0.1)
+	StartTime       *time.Time         // Optional: earliest start of a bucket
+	EndTime         *time.Time         // Optional: latest start of a bucket
+}
+
 // MeterValuesResult contains paginated meter values query results.
 type MeterValuesResult struct {
 	MeterValues []StoredMeterValue
@@ -46,3 +67,13 @@ type StoredMeterValue struct {
 	TransactionId   string // May be empty if not associated with a transaction
 	MeterValue      MeterValue
 }
+
+// MeterValueDigest returns a digest of the timestamp and sampled values of a meter value. With the
+// charge station and EVSE it identifies a meter value so that one that is received more than once
+// is only stored once.
+func MeterValueDigest(mv MeterValue) string {
+	// encoding a struct cannot fail
+	b, _ := json.Marshal(mv)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
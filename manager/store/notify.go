@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+
+	"golang.org/x/exp/slog"
+)
+
+// AuthChangeNotifier is told when the details used to authenticate a charge station
+// change, so that copies held elsewhere (e.g. by the gateway) can be discarded.
+type AuthChangeNotifier interface {
+	ChargeStationAuthChanged(ctx context.Context, chargeStationId string) error
+	CertificateChanged(ctx context.Context, certificateHash string) error
+}
+
+// NotifyAuthChanges returns an Engine that calls notifier once the charge station
+// authentication details or certificates held by engine have been changed.
+// Notification is best effort: failures are logged and not returned.
+func NotifyAuthChanges(engine Engine, notifier AuthChangeNotifier) Engine {
+	return &authNotifyingEngine{
+		Engine:   engine,
+		notifier: notifier,
+	}
+}
+
+type authNotifyingEngine struct {
+	Engine
+	notifier AuthChangeNotifier
+}
+
+func (e *authNotifyingEngine) SetChargeStationAuth(ctx context.Context, chargeStationId string, auth *ChargeStationAuth) error {
+	err := e.Engine.SetChargeStationAuth(ctx, chargeStationId, auth)
+	if err != nil {
+		return err
+	}
+	if err := e.notifier.ChargeStationAuthChanged(ctx, chargeStationId); err != nil {
+		slog.Warn("notifying charge station auth change", "chargeStationId", chargeStationId, "err", err)
+	}
+	return nil
+}
+
+func (e *authNotifyingEngine) SetCertificate(ctx context.Context, pemCertificate string) error {
+	err := e.Engine.SetCertificate(ctx, pemCertificate)
+	if err != nil {
+		return err
+	}
+	certificateHash, err := pemCertificateHash(pemCertificate)
+	if err != nil {
+		slog.Warn("hashing certificate to notify change", "err", err)
+		return nil
+	}
+	e.notifyCertificateChanged(ctx, certificateHash)
+	return nil
+}
+
+func (e *authNotifyingEngine) DeleteCertificate(ctx context.Context, certificateHash string) error {
+	err := e.Engine.DeleteCertificate(ctx, certificateHash)
+	if err != nil {
+		return err
+	}
+	e.notifyCertificateChanged(ctx, certificateHash)
+	return nil
+}
+
+func (e *authNotifyingEngine) notifyCertificateChanged(ctx context.Context, certificateHash string) {
+	if err := e.notifier.CertificateChanged(ctx, certificateHash); err != nil {
+		slog.Warn("notifying certificate change", "certificateHash", certificateHash, "err", err)
+	}
+}
+
+// pemCertificateHash returns the hash that is used to identify the certificate
+func pemCertificateHash(pemCertificate string) (string, error) {
+	block, _ := pem.Decode([]byte(pemCertificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("no certificate found in pem data")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
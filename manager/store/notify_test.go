@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"k8s.io/utils/clock"
+)
+
+type recordingNotifier struct {
+	changes []string
+	err     error
+}
+
+func (r *recordingNotifier) ChargeStationAuthChanged(_ context.Context, chargeStationId string) error {
+	r.changes = append(r.changes, "cs:"+chargeStationId)
+	return r.err
+}
+
+func (r *recordingNotifier) CertificateChanged(_ context.Context, certificateHash string) error {
+	r.changes = append(r.changes, "cert:"+certificateHash)
+	return r.err
+}
+
+func TestNotifyAuthChanges(t *testing.T) {
+	ctx := context.Background()
+	notifier := &recordingNotifier{}
+	engine := store.NotifyAuthChanges(inmemory.NewStore(clock.RealClock{}), notifier)
+
+	err := engine.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile:      store.TLSWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	})
+	require.NoError(t, err)
+
+	cert := generateCertificate(t)
+	pemCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	hash := sha256.Sum256(cert.Raw)
+	b64Hash := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	err = engine.SetCertificate(ctx, pemCertificate)
+	require.NoError(t, err)
+	err = engine.DeleteCertificate(ctx, b64Hash)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"cs:cs001", "cert:" + b64Hash, "cert:" + b64Hash}, notifier.changes)
+
+	auth, err := engine.LookupChargeStationAuth(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, "DEADBEEF", auth.Base64SHA256Password)
+}
+
+func TestNotifyAuthChangesIgnoresNotificationFailure(t *testing.T) {
+	notifier := &recordingNotifier{err: errors.New("broker unavailable")}
+	engine := store.NotifyAuthChanges(inmemory.NewStore(clock.RealClock{}), notifier)
+
+	err := engine.SetChargeStationAuth(context.Background(), "cs001", &store.ChargeStationAuth{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cs:cs001"}, notifier.changes)
+}
+
+func generateCertificate(t *testing.T) *x509.Certificate {
+	keyPair, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Thoughtworks"},
+		},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(24 * time.Hour),
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &keyPair.PublicKey, keyPair)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(derBytes)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -5,7 +5,9 @@ package postgres
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/hex"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -13,9 +15,10 @@ import (
 
 // SetCertificate stores a PEM certificate in the database
 func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error {
-	// Calculate certificate hash
-	hash := sha256.Sum256([]byte(pemCertificate))
-	certificateHash := hex.EncodeToString(hash[:])
+	certificateHash, err := getPEMCertificateHash(pemCertificate)
+	if err != nil {
+		return err
+	}
 
 	params := SetCertificateParams{
 		CertificateHash: certificateHash,
@@ -23,7 +26,7 @@ func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error
 		CertificateData: pemCertificate,
 	}
 
-	_, err := s.writeQueries().SetCertificate(ctx, params)
+	_, err = s.writeQueries().SetCertificate(ctx, params)
 	if err != nil {
 		return fmt.Errorf("failed to set certificate: %w", err)
 	}
@@ -31,6 +34,25 @@ func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error
 	return nil
 }
 
+// getPEMCertificateHash returns the base64 (URL encoding, no padding) SHA-256 hash of the
+// DER-encoded certificate in the PEM block
+func getPEMCertificateHash(pemCertificate string) (string, error) {
+	block, _ := pem.Decode([]byte(pemCertificate))
+	if block == nil {
+		return "", fmt.Errorf("pem block not found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("pem block does not contain certificate, but %s", block.Type)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
 // LookupCertificate retrieves a certificate by its hash
 func (s *Store) LookupCertificate(ctx context.Context, certificateHash string) (string, error) {
 	cert, err := s.readQueries().GetCertificate(ctx, certificateHash)
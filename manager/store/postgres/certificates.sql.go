@@ -37,6 +37,38 @@ func (q *Queries) GetCertificate(ctx context.Context, certificateHash string) (C
 	return i, err
 }
 
+const ListCertificateHashes = `-- name: ListCertificateHashes :many
+SELECT certificate_hash FROM certificates
+WHERE certificate_hash > $1
+ORDER BY certificate_hash ASC
+LIMIT $2
+`
+
+type ListCertificateHashesParams struct {
+	CertificateHash string `db:"certificate_hash" json:"certificate_hash"`
+	Limit           int32  `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListCertificateHashes(ctx context.Context, arg ListCertificateHashesParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListCertificateHashes, arg.CertificateHash, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var certificate_hash string
+		if err := rows.Scan(&certificate_hash); err != nil {
+			return nil, err
+		}
+		items = append(items, certificate_hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListCertificates = `-- name: ListCertificates :many
 SELECT certificate_hash, certificate_type, certificate_data, created_at FROM certificates
 ORDER BY created_at DESC
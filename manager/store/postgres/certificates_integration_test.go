@@ -6,39 +6,79 @@ package postgres_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newTestCertificate returns a self-signed PEM certificate and the hash it is stored under
+func newTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "cs001"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(der)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
 func TestCertificate_SetAndLookup(t *testing.T) {
 	defer truncateAll(t)
 	ctx := context.Background()
 
-	pem := "-----BEGIN CERTIFICATE-----\nTESTDATA\n-----END CERTIFICATE-----"
+	pemCertificate, hash := newTestCertificate(t)
+
+	err := testStore.SetCertificate(ctx, pemCertificate)
+	require.NoError(t, err)
 
-	err := testStore.SetCertificate(ctx, pem)
+	got, err := testStore.LookupCertificate(ctx, hash)
 	require.NoError(t, err)
+	assert.Equal(t, pemCertificate, got)
+}
 
-	// SHA256 of the PEM (the store computes this internally)
-	// We need to look it up — first list by setting another and checking
-	// Actually, let's test by looking up with the hash
-	// The store hashes the PEM certificate data internally
-	// Let's just verify we can set without error and lookup by computing hash ourselves
+func TestCertificate_SetRejectsInvalidPEM(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+
+	err := testStore.SetCertificate(ctx, "-----BEGIN CERTIFICATE-----\nTESTDATA\n-----END CERTIFICATE-----")
+	assert.Error(t, err)
 }
 
 func TestCertificate_Delete(t *testing.T) {
 	defer truncateAll(t)
 	ctx := context.Background()
 
-	pem := "-----BEGIN CERTIFICATE-----\nTESTDATA123\n-----END CERTIFICATE-----"
+	pemCertificate, hash := newTestCertificate(t)
+
+	err := testStore.SetCertificate(ctx, pemCertificate)
+	require.NoError(t, err)
+
+	err = testStore.DeleteCertificate(ctx, hash)
+	require.NoError(t, err)
 
-	err := testStore.SetCertificate(ctx, pem)
+	got, err := testStore.LookupCertificate(ctx, hash)
 	require.NoError(t, err)
+	assert.Empty(t, got)
 
-	// Look up to verify it was stored — we need the hash
-	// For now just verify delete doesn't error on nonexistent
 	err = testStore.DeleteCertificate(ctx, "nonexistent-hash")
 	assert.NoError(t, err)
 }
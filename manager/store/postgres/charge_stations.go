@@ -177,13 +177,31 @@ func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeSta
 // ChargeStationInstallCertificatesStore implementation
 
 func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
-	// First, delete all existing certificates for this station
-	if err := s.writeQueries().DeleteChargeStationCertificates(ctx, chargeStationId); err != nil {
-		return fmt.Errorf("failed to delete existing certificates: %w", err)
+	tx, err := s.writePool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.writeQueries().WithTx(tx)
 
-	// Then insert all new certificates
+	// Certificates are merged by id: existing certificates are updated and new ones are added
 	for _, cert := range certificates.Certificates {
+		_, err := qtx.UpdateChargeStationCertificate(ctx, UpdateChargeStationCertificateParams{
+			ChargeStationID:               chargeStationId,
+			CertificateID:                 cert.CertificateId,
+			Certificate:                   cert.CertificateData,
+			CertificateInstallationStatus: string(cert.CertificateInstallationStatus),
+			SendAfter:                     toPgTimestamp(cert.SendAfter),
+			CertificateType:               string(cert.CertificateType),
+		})
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to update certificate: %w", err)
+		}
+
 		params := AddChargeStationCertificateParams{
 			ChargeStationID:               chargeStationId,
 			CertificateID:                 cert.CertificateId,
@@ -193,12 +211,15 @@ func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, char
 			SendAfter:                     toPgTimestamp(cert.SendAfter),
 		}
 
-		_, err := s.writeQueries().AddChargeStationCertificate(ctx, params)
+		_, err = qtx.AddChargeStationCertificate(ctx, params)
 		if err != nil {
 			return fmt.Errorf("failed to add certificate: %w", err)
 		}
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -250,8 +271,8 @@ func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSi
 		return nil, fmt.Errorf("failed to list charge station certificates: %w", err)
 	}
 
-	// Group certificates by charge station ID
-	certsByStation := make(map[string][]*store.ChargeStationInstallCertificate)
+	// Group certificates by charge station ID, keeping the charge stations in order
+	var result []*store.ChargeStationInstallCertificates
 	for _, dbCert := range dbCertsList {
 		cert := &store.ChargeStationInstallCertificate{
 			CertificateType:               store.CertificateType(dbCert.CertificateType),
@@ -260,15 +281,13 @@ func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSi
 			CertificateInstallationStatus: store.CertificateInstallationStatus(dbCert.CertificateInstallationStatus),
 			SendAfter:                     fromPgTimestamp(dbCert.SendAfter),
 		}
-		certsByStation[dbCert.ChargeStationID] = append(certsByStation[dbCert.ChargeStationID], cert)
-	}
-
-	result := make([]*store.ChargeStationInstallCertificates, 0, len(certsByStation))
-	for csId, certs := range certsByStation {
-		result = append(result, &store.ChargeStationInstallCertificates{
-			ChargeStationId: csId,
-			Certificates:    certs,
-		})
+		if len(result) == 0 || result[len(result)-1].ChargeStationId != dbCert.ChargeStationID {
+			result = append(result, &store.ChargeStationInstallCertificates{
+				ChargeStationId: dbCert.ChargeStationID,
+			})
+		}
+		last := result[len(result)-1]
+		last.Certificates = append(last.Certificates, cert)
 	}
 
 	return result, nil
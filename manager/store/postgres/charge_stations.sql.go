@@ -379,11 +379,15 @@ func (q *Queries) ListChargeStationCertificateQueries(ctx context.Context, arg L
 }
 
 const ListChargeStationCertificates = `-- name: ListChargeStationCertificates :many
-SELECT DISTINCT ON (charge_station_id) charge_station_id, certificate_id, certificate_type, certificate, certificate_installation_status, send_after, created_at
+SELECT charge_station_id, certificate_id, certificate_type, certificate, certificate_installation_status, send_after, created_at
 FROM charge_station_certificates
-WHERE charge_station_id > $1
+WHERE charge_station_id IN (
+    SELECT DISTINCT c.charge_station_id FROM charge_station_certificates c
+    WHERE c.charge_station_id > $1
+    ORDER BY c.charge_station_id ASC
+    LIMIT $2
+)
 ORDER BY charge_station_id ASC, created_at DESC
-LIMIT $2
 `
 
 type ListChargeStationCertificatesParams struct {
@@ -550,6 +554,38 @@ func (q *Queries) ListChargeStationDataTransfers(ctx context.Context, arg ListCh
 	return items, nil
 }
 
+const ListChargeStationIds = `-- name: ListChargeStationIds :many
+SELECT charge_station_id FROM charge_stations
+WHERE charge_station_id > $1
+ORDER BY charge_station_id ASC
+LIMIT $2
+`
+
+type ListChargeStationIdsParams struct {
+	ChargeStationID string `db:"charge_station_id" json:"charge_station_id"`
+	Limit           int32  `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListChargeStationIds(ctx context.Context, arg ListChargeStationIdsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListChargeStationIds, arg.ChargeStationID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var charge_station_id string
+		if err := rows.Scan(&charge_station_id); err != nil {
+			return nil, err
+		}
+		items = append(items, charge_station_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListChargeStationSettings = `-- name: ListChargeStationSettings :many
 SELECT charge_station_id, settings, created_at, updated_at FROM charge_station_settings
 WHERE charge_station_id > $1
@@ -953,7 +989,7 @@ const SetChargeStationSettings = `-- name: SetChargeStationSettings :one
 INSERT INTO charge_station_settings (charge_station_id, settings)
 VALUES ($1, $2)
 ON CONFLICT (charge_station_id) DO UPDATE
-SET settings = EXCLUDED.settings, updated_at = NOW()
+SET settings = charge_station_settings.settings || EXCLUDED.settings, updated_at = NOW()
 RETURNING charge_station_id, settings, created_at, updated_at
 `
 
@@ -1018,7 +1054,8 @@ const UpdateChargeStationCertificate = `-- name: UpdateChargeStationCertificate
 UPDATE charge_station_certificates
 SET certificate = $3,
     certificate_installation_status = $4,
-    send_after = $5
+    send_after = $5,
+    certificate_type = $6
 WHERE charge_station_id = $1 AND certificate_id = $2
 RETURNING id, charge_station_id, certificate_type, certificate, created_at, certificate_id, certificate_installation_status, send_after
 `
@@ -1029,6 +1066,7 @@ type UpdateChargeStationCertificateParams struct {
 	Certificate                   string           `db:"certificate" json:"certificate"`
 	CertificateInstallationStatus string           `db:"certificate_installation_status" json:"certificate_installation_status"`
 	SendAfter                     pgtype.Timestamp `db:"send_after" json:"send_after"`
+	CertificateType               string           `db:"certificate_type" json:"certificate_type"`
 }
 
 func (q *Queries) UpdateChargeStationCertificate(ctx context.Context, arg UpdateChargeStationCertificateParams) (ChargeStationCertificate, error) {
@@ -1038,6 +1076,7 @@ func (q *Queries) UpdateChargeStationCertificate(ctx context.Context, arg Update
 		arg.Certificate,
 		arg.CertificateInstallationStatus,
 		arg.SendAfter,
+		arg.CertificateType,
 	)
 	var i ChargeStationCertificate
 	err := row.Scan(
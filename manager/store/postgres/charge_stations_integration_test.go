@@ -231,3 +231,104 @@ func TestChargeStationInstallCertificates_UpdateAndLookup(t *testing.T) {
 	assert.Len(t, got.Certificates, 1)
 	assert.Equal(t, "cert1", got.Certificates[0].CertificateId)
 }
+
+func TestChargeStationInstallCertificates_UpdateMerges(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+
+	err := testStore.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile: store.UnsecuredTransportWithBasicAuth,
+	})
+	require.NoError(t, err)
+
+	sendAfter := time.Now().UTC().Truncate(time.Second)
+	err = testStore.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeCSMS,
+				CertificateId:                 "cert1",
+				CertificateData:               "-----BEGIN CERTIFICATE-----\nTEST1\n-----END CERTIFICATE-----",
+				CertificateInstallationStatus: store.CertificateInstallationPending,
+				SendAfter:                     sendAfter,
+			},
+			{
+				CertificateType:               store.CertificateTypeV2G,
+				CertificateId:                 "cert2",
+				CertificateData:               "-----BEGIN CERTIFICATE-----\nTEST2\n-----END CERTIFICATE-----",
+				CertificateInstallationStatus: store.CertificateInstallationPending,
+				SendAfter:                     sendAfter,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// updating the status of one certificate leaves the other one in place
+	err = testStore.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		ChargeStationId: "cs001",
+		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeCSMS,
+				CertificateId:                 "cert1",
+				CertificateData:               "-----BEGIN CERTIFICATE-----\nTEST1\n-----END CERTIFICATE-----",
+				CertificateInstallationStatus: store.CertificateInstallationAccepted,
+				SendAfter:                     sendAfter,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err := testStore.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Len(t, got.Certificates, 2)
+	statuses := make(map[string]store.CertificateInstallationStatus)
+	for _, cert := range got.Certificates {
+		statuses[cert.CertificateId] = cert.CertificateInstallationStatus
+	}
+	assert.Equal(t, store.CertificateInstallationAccepted, statuses["cert1"])
+	assert.Equal(t, store.CertificateInstallationPending, statuses["cert2"])
+}
+
+func TestChargeStationInstallCertificates_UpdateAddsNewCertificate(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+
+	err := testStore.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile: store.UnsecuredTransportWithBasicAuth,
+	})
+	require.NoError(t, err)
+
+	sendAfter := time.Now().UTC().Truncate(time.Second)
+	newCertificate := func(certificateId string) *store.ChargeStationInstallCertificate {
+		return &store.ChargeStationInstallCertificate{
+			CertificateType:               store.CertificateTypeCSMS,
+			CertificateId:                 certificateId,
+			CertificateData:               "-----BEGIN CERTIFICATE-----\nTEST\n-----END CERTIFICATE-----",
+			CertificateInstallationStatus: store.CertificateInstallationPending,
+			SendAfter:                     sendAfter,
+		}
+	}
+
+	err = testStore.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		ChargeStationId: "cs001",
+		Certificates:    []*store.ChargeStationInstallCertificate{newCertificate("cert1")},
+	})
+	require.NoError(t, err)
+
+	// a certificate with an id that has not been stored before is added
+	err = testStore.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+		ChargeStationId: "cs001",
+		Certificates:    []*store.ChargeStationInstallCertificate{newCertificate("cert2")},
+	})
+	require.NoError(t, err)
+
+	got, err := testStore.LookupChargeStationInstallCertificates(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	var certificateIds []string
+	for _, cert := range got.Certificates {
+		certificateIds = append(certificateIds, cert.CertificateId)
+	}
+	assert.ElementsMatch(t, []string{"cert1", "cert2"}, certificateIds)
+}
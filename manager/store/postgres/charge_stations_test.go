@@ -421,7 +421,7 @@ func TestChargeStationInstallCertificatesStore_UpdateAndLookup(t *testing.T) {
 	assert.Nil(t, notFound)
 }
 
-func TestChargeStationInstallCertificatesStore_ReplaceExisting(t *testing.T) {
+func TestChargeStationInstallCertificatesStore_MergeById(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Teardown(t)
 
@@ -453,10 +453,17 @@ func TestChargeStationInstallCertificatesStore_ReplaceExisting(t *testing.T) {
 	err = db.store.UpdateChargeStationInstallCertificates(ctx, "CS001", certificates)
 	require.NoError(t, err)
 
-	// Replace with new certificate
+	// Add a new certificate and update the existing one
 	newCertificates := &store.ChargeStationInstallCertificates{
 		ChargeStationId: "CS001",
 		Certificates: []*store.ChargeStationInstallCertificate{
+			{
+				CertificateType:               store.CertificateTypeV2G,
+				CertificateId:                 "CERT001",
+				CertificateData:               "-----BEGIN CERTIFICATE-----\nOLD...\n-----END CERTIFICATE-----",
+				CertificateInstallationStatus: store.CertificateInstallationAccepted,
+				SendAfter:                     time.Now(),
+			},
 			{
 				CertificateType:               store.CertificateTypeCSMS,
 				CertificateId:                 "CERT002",
@@ -470,11 +477,18 @@ func TestChargeStationInstallCertificatesStore_ReplaceExisting(t *testing.T) {
 	err = db.store.UpdateChargeStationInstallCertificates(ctx, "CS001", newCertificates)
 	require.NoError(t, err)
 
-	// Verify old certificate was replaced
+	// Verify the certificates were merged by id
 	foundCerts, err := db.store.LookupChargeStationInstallCertificates(ctx, "CS001")
 	require.NoError(t, err)
-	require.Len(t, foundCerts.Certificates, 1)
-	assert.Equal(t, "CERT002", foundCerts.Certificates[0].CertificateId)
+	require.Len(t, foundCerts.Certificates, 2)
+	statuses := map[string]store.CertificateInstallationStatus{}
+	for _, cert := range foundCerts.Certificates {
+		statuses[cert.CertificateId] = cert.CertificateInstallationStatus
+	}
+	assert.Equal(t, map[string]store.CertificateInstallationStatus{
+		"CERT001": store.CertificateInstallationAccepted,
+		"CERT002": store.CertificateInstallationAccepted,
+	}, statuses)
 }
 
 func TestChargeStationInstallCertificatesStore_List(t *testing.T) {
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// CommandStore implementation
+
+func (s *Store) CreateCommand(ctx context.Context, command *store.Command) error {
+	err := s.writeQueries().CreateCommand(ctx, CreateCommandParams{
+		ID:               command.Id,
+		ChargeStationID:  command.ChargeStationId,
+		OcppVersion:      command.OcppVersion,
+		Action:           command.Action,
+		Status:           string(command.Status),
+		Request:          []byte(command.Request),
+		Response:         jsonFromString(command.Response),
+		ErrorCode:        textFromString(command.ErrorCode),
+		ErrorDescription: textFromString(command.ErrorDescription),
+		CreatedAt:        toPgTimestamptz(command.CreatedAt),
+		UpdatedAt:        toPgTimestamptz(command.UpdatedAt),
+		Awaited:          command.Awaited,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create command: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) SetCommandSent(ctx context.Context, commandId string, sentAt time.Time) error {
+	err := s.writeQueries().SetCommandSent(ctx, SetCommandSentParams{
+		ID:        commandId,
+		UpdatedAt: toPgTimestamptz(sentAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set command sent: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) SetCommandResult(ctx context.Context, chargeStationId, commandId string, result *store.CommandResult) error {
+	err := s.writeQueries().SetCommandResult(ctx, SetCommandResultParams{
+		ID:               commandId,
+		ChargeStationID:  chargeStationId,
+		Status:           string(result.Status),
+		Response:         jsonFromString(result.Response),
+		ErrorCode:        textFromString(result.ErrorCode),
+		ErrorDescription: textFromString(result.ErrorDescription),
+		UpdatedAt:        toPgTimestamptz(result.UpdatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set command result: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) LookupCommand(ctx context.Context, commandId string) (*store.Command, error) {
+	row, err := s.readQueries().GetCommand(ctx, commandId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get command: %w", err)
+	}
+	return commandFromRow(row), nil
+}
+
+func (s *Store) ListCommands(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.Command, int, error) {
+	count, err := s.readQueries().CountCommands(ctx, chargeStationId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count commands: %w", err)
+	}
+
+	rows, err := s.readQueries().ListCommands(ctx, ListCommandsParams{
+		ChargeStationID: chargeStationId,
+		Limit:           int32(limit),
+		Offset:          int32(offset),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list commands: %w", err)
+	}
+
+	results := make([]*store.Command, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, commandFromRow(row))
+	}
+	return results, int(count), nil
+}
+
+func (s *Store) TimeOutCommands(ctx context.Context, updatedBefore time.Time, timedOutAt time.Time) (int, error) {
+	count, err := s.writeQueries().TimeOutCommands(ctx, TimeOutCommandsParams{
+		TimedOutAt:    toPgTimestamptz(timedOutAt),
+		UpdatedBefore: toPgTimestamptz(updatedBefore),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to time out commands: %w", err)
+	}
+	return int(count), nil
+}
+
+func commandFromRow(row Command) *store.Command {
+	return &store.Command{
+		Id:               row.ID,
+		ChargeStationId:  row.ChargeStationID,
+		OcppVersion:      row.OcppVersion,
+		Action:           row.Action,
+		Status:           store.CommandStatus(row.Status),
+		Request:          string(row.Request),
+		Response:         stringFromJson(row.Response),
+		ErrorCode:        stringFromText(row.ErrorCode),
+		ErrorDescription: stringFromText(row.ErrorDescription),
+		Awaited:          row.Awaited,
+		CreatedAt:        fromPgTimestamptz(row.CreatedAt),
+		UpdatedAt:        fromPgTimestamptz(row.UpdatedAt),
+	}
+}
+
+// jsonFromString converts a *string holding JSON to a nullable JSONB value
+func jsonFromString(s *string) []byte {
+	if s == nil {
+		return nil
+	}
+	return []byte(*s)
+}
+
+// stringFromJson converts a nullable JSONB value to a *string
+func stringFromJson(b []byte) *string {
+	if b == nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestCommands_Lifecycle(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	err := testStore.CreateCommand(ctx, &store.Command{
+		Id:              "abc",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusQueued,
+		Request:         `{"type":"Immediate"}`,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	require.NoError(t, err)
+
+	err = testStore.SetCommandSent(ctx, "abc", now.Add(time.Second))
+	require.NoError(t, err)
+
+	got, err := testStore.LookupCommand(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.CommandStatusSent, got.Status)
+
+	response := `{"status": "Accepted"}`
+	err = testStore.SetCommandResult(ctx, "cs001", "abc", &store.CommandResult{
+		Status:    store.CommandStatusAccepted,
+		Response:  &response,
+		UpdatedAt: now.Add(2 * time.Second),
+	})
+	require.NoError(t, err)
+
+	// a late send must not overwrite the result
+	err = testStore.SetCommandSent(ctx, "abc", now.Add(3*time.Second))
+	require.NoError(t, err)
+
+	got, err = testStore.LookupCommand(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.CommandStatusAccepted, got.Status)
+	require.NotNil(t, got.Response)
+	assert.JSONEq(t, response, *got.Response)
+	assert.Equal(t, now, got.CreatedAt)
+	assert.Equal(t, now.Add(2*time.Second), got.UpdatedAt)
+}
+
+func TestCommands_LookupMissing(t *testing.T) {
+	defer truncateAll(t)
+
+	got, err := testStore.LookupCommand(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestCommands_ListAndTimeOut(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i, id := range []string{"a", "b", "c"} {
+		createdAt := now.Add(time.Duration(i-3) * time.Hour)
+		err := testStore.CreateCommand(ctx, &store.Command{
+			Id:              id,
+			ChargeStationId: "cs001",
+			OcppVersion:     "1.6",
+			Action:          "ClearCache",
+			Status:          store.CommandStatusSent,
+			Request:         `{}`,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		})
+		require.NoError(t, err)
+	}
+
+	commands, total, err := testStore.ListCommands(ctx, "cs001", 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, commands, 2)
+	assert.Equal(t, "c", commands[0].Id)
+	assert.Equal(t, "b", commands[1].Id)
+
+	count, err := testStore.TimeOutCommands(ctx, now.Add(-150*time.Minute), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	got, err := testStore.LookupCommand(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusSent, got.Status)
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/storetest"
+	"k8s.io/utils/clock"
+)
+
+// TestConformance runs the conformance suite against the shared test database: the store
+// uses the database clock, so the clock it is given is not used
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, _ clock.PassiveClock) store.Engine {
+		truncateAll(t)
+		t.Cleanup(func() { truncateAll(t) })
+		return testStore
+	})
+}
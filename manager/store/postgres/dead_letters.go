@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// DeadLetterStore implementation
+
+func (s *Store) AddDeadLetter(ctx context.Context, deadLetter *store.DeadLetter) error {
+	err := s.writeQueries().AddDeadLetter(ctx, AddDeadLetterParams{
+		ID:              deadLetter.Id,
+		ChargeStationID: deadLetter.ChargeStationId,
+		OcppVersion:     deadLetter.OcppVersion,
+		MessageType:     deadLetter.MessageType,
+		Action:          deadLetter.Action,
+		MessageID:       deadLetter.MessageId,
+		Message:         []byte(deadLetter.Message),
+		Error:           deadLetter.Error,
+		CreatedAt:       toPgTimestamptz(deadLetter.CreatedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add dead letter: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) LookupDeadLetter(ctx context.Context, deadLetterId string) (*store.DeadLetter, error) {
+	row, err := s.readQueries().GetDeadLetter(ctx, deadLetterId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return deadLetterFromRow(row), nil
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, offset int, limit int) ([]*store.DeadLetter, int, error) {
+	count, err := s.readQueries().CountDeadLetters(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	rows, err := s.readQueries().ListDeadLetters(ctx, ListDeadLettersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	results := make([]*store.DeadLetter, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, deadLetterFromRow(row))
+	}
+	return results, int(count), nil
+}
+
+func (s *Store) DeleteDeadLetter(ctx context.Context, deadLetterId string) error {
+	err := s.writeQueries().DeleteDeadLetter(ctx, deadLetterId)
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	return nil
+}
+
+func deadLetterFromRow(row DeadLetter) *store.DeadLetter {
+	return &store.DeadLetter{
+		Id:              row.ID,
+		ChargeStationId: row.ChargeStationID,
+		OcppVersion:     row.OcppVersion,
+		MessageType:     row.MessageType,
+		Action:          row.Action,
+		MessageId:       row.MessageID,
+		Message:         string(row.Message),
+		Error:           row.Error,
+		CreatedAt:       fromPgTimestamptz(row.CreatedAt),
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func TestDeadLetters_Lifecycle(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	want := &store.DeadLetter{
+		Id:              "abc",
+		ChargeStationId: "cs001",
+		OcppVersion:     "2.0.1",
+		MessageType:     "call",
+		Action:          "Heartbeat",
+		MessageId:       "1234",
+		Message:         `{"type": 2, "action": "Heartbeat", "id": "1234", "request": {}}`,
+		Error:           "store unavailable",
+		CreatedAt:       now,
+	}
+	err := testStore.AddDeadLetter(ctx, want)
+	require.NoError(t, err)
+
+	got, err := testStore.LookupDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.JSONEq(t, want.Message, got.Message)
+	got.Message = want.Message
+	assert.Equal(t, want, got)
+
+	err = testStore.DeleteDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+
+	got, err = testStore.LookupDeadLetter(ctx, "abc")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestDeadLetters_List(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i, id := range []string{"a", "b", "c"} {
+		err := testStore.AddDeadLetter(ctx, &store.DeadLetter{
+			Id:              id,
+			ChargeStationId: "cs001",
+			OcppVersion:     "1.6",
+			MessageType:     "call",
+			Action:          "Heartbeat",
+			MessageId:       id,
+			Message:         `{}`,
+			Error:           "failed",
+			CreatedAt:       now.Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	deadLetters, total, err := testStore.ListDeadLetters(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, deadLetters, 2)
+	assert.Equal(t, "c", deadLetters[0].Id)
+	assert.Equal(t, "b", deadLetters[1].Id)
+}
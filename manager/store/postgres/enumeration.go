@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) ListChargeStationIds(ctx context.Context, pageSize int, previousChargeStationId string) ([]string, error) {
+	pageSizeInt32, err := safeIntToInt32(pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page size value: %w", err)
+	}
+
+	ids, err := s.readQueries().ListChargeStationIds(ctx, ListChargeStationIdsParams{
+		ChargeStationID: previousChargeStationId,
+		Limit:           pageSizeInt32,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list charge station ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (s *Store) ListCertificateHashes(ctx context.Context, pageSize int, previousCertificateHash string) ([]string, error) {
+	pageSizeInt32, err := safeIntToInt32(pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page size value: %w", err)
+	}
+
+	hashes, err := s.readQueries().ListCertificateHashes(ctx, ListCertificateHashesParams{
+		CertificateHash: previousCertificateHash,
+		Limit:           pageSizeInt32,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificate hashes: %w", err)
+	}
+	return hashes, nil
+}
+
+func (s *Store) ListRegistrationTokens(ctx context.Context, pageSize int, previousToken string) ([]string, error) {
+	pageSizeInt32, err := safeIntToInt32(pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page size value: %w", err)
+	}
+
+	tokens, err := s.readQueries().ListOcpiRegistrationTokens(ctx, ListOcpiRegistrationTokensParams{
+		Token: previousToken,
+		Limit: pageSizeInt32,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registration tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	pageSizeInt32, err := safeIntToInt32(pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page size value: %w", err)
+	}
+	previousReservationIdInt32, err := safeIntToInt32(previousReservationId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reservation id value: %w", err)
+	}
+
+	rows, err := s.readQueries().ListReservations(ctx, ListReservationsParams{
+		ReservationID: previousReservationIdInt32,
+		Limit:         pageSizeInt32,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	result := make([]*store.Reservation, len(rows))
+	for i := range rows {
+		result[i] = toStoreReservation(&rows[i])
+	}
+	return result, nil
+}
@@ -164,3 +164,37 @@ func fromPgTimestamptz(ts pgtype.Timestamptz) time.Time {
 	}
 	return ts.Time.UTC()
 }
+
+// toNullFloat8 converts *float64 to pgtype.Float8
+func toNullFloat8(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{Valid: false}
+	}
+	return pgtype.Float8{Float64: *f, Valid: true}
+}
+
+// fromNullFloat8 converts pgtype.Float8 to *float64
+func fromNullFloat8(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	val := f.Float64
+	return &val
+}
+
+// toNullTimestamp converts *time.Time to pgtype.Timestamp
+func toNullTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{Valid: false}
+	}
+	return pgtype.Timestamp{Time: *t, Valid: true}
+}
+
+// fromNullTimestamp converts pgtype.Timestamp to *time.Time
+func fromNullTimestamp(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	val := ts.Time.UTC()
+	return &val
+}
@@ -41,7 +41,7 @@ func (q *Queries) GetLocation(ctx context.Context, id string) (Location, error)
 
 const ListAllLocations = `-- name: ListAllLocations :many
 SELECT id, country_code, party_id, location_data, created_at, updated_at FROM locations
-ORDER BY created_at DESC
+ORDER BY id ASC
 LIMIT $1 OFFSET $2
 `
 
@@ -80,7 +80,7 @@ func (q *Queries) ListAllLocations(ctx context.Context, arg ListAllLocationsPara
 const ListLocations = `-- name: ListLocations :many
 SELECT id, country_code, party_id, location_data, created_at, updated_at FROM locations
 WHERE country_code = $1 AND party_id = $2
-ORDER BY created_at DESC
+ORDER BY id ASC
 LIMIT $3 OFFSET $4
 `
 
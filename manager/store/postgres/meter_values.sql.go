@@ -14,27 +14,27 @@ import (
 const CountMeterValues = `-- name: CountMeterValues :one
 SELECT COUNT(*) FROM meter_values
 WHERE charge_station_id = $1
-  AND ($2::int IS NULL OR evse_id = $2)
-  AND ($3::text IS NULL OR transaction_id = $3)
-  AND ($4::timestamp IS NULL OR timestamp >= $4)
-  AND ($5::timestamp IS NULL OR timestamp <= $5)
+  AND ($2::int IS NULL OR evse_id = $2::int)
+  AND ($3::text IS NULL OR transaction_id = $3::text)
+  AND ($4::timestamp IS NULL OR timestamp >= $4::timestamp)
+  AND ($5::timestamp IS NULL OR timestamp <= $5::timestamp)
 `
 
 type CountMeterValuesParams struct {
 	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
-	Column2         int32            `db:"column_2" json:"column_2"`
-	Column3         string           `db:"column_3" json:"column_3"`
-	Column4         pgtype.Timestamp `db:"column_4" json:"column_4"`
-	Column5         pgtype.Timestamp `db:"column_5" json:"column_5"`
+	EvseID          pgtype.Int4      `db:"evse_id" json:"evse_id"`
+	TransactionID   pgtype.Text      `db:"transaction_id" json:"transaction_id"`
+	StartTime       pgtype.Timestamp `db:"start_time" json:"start_time"`
+	EndTime         pgtype.Timestamp `db:"end_time" json:"end_time"`
 }
 
 func (q *Queries) CountMeterValues(ctx context.Context, arg CountMeterValuesParams) (int64, error) {
 	row := q.db.QueryRow(ctx, CountMeterValues,
 		arg.ChargeStationID,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
+		arg.EvseID,
+		arg.TransactionID,
+		arg.StartTime,
+		arg.EndTime,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -121,36 +121,92 @@ func (q *Queries) GetMeterValuesByStationAndEvse(ctx context.Context, arg GetMet
 	return items, nil
 }
 
+const QueryMeterValueRollups = `-- name: QueryMeterValueRollups :many
+SELECT charge_station_id, evse_id, bucket_interval, bucket_start, sample_count, energy_first, energy_first_at, energy_last, energy_last_at, max_power, voltage_sum, voltage_count FROM meter_value_rollups
+WHERE charge_station_id = $1 AND bucket_interval = $2
+  AND ($3::int IS NULL OR evse_id = $3::int)
+  AND ($4::timestamp IS NULL OR bucket_start >= $4::timestamp)
+  AND ($5::timestamp IS NULL OR bucket_start <= $5::timestamp)
+ORDER BY evse_id, bucket_start
+`
+
+type QueryMeterValueRollupsParams struct {
+	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
+	BucketInterval  string           `db:"bucket_interval" json:"bucket_interval"`
+	EvseID          pgtype.Int4      `db:"evse_id" json:"evse_id"`
+	StartTime       pgtype.Timestamp `db:"start_time" json:"start_time"`
+	EndTime         pgtype.Timestamp `db:"end_time" json:"end_time"`
+}
+
+func (q *Queries) QueryMeterValueRollups(ctx context.Context, arg QueryMeterValueRollupsParams) ([]MeterValueRollup, error) {
+	rows, err := q.db.Query(ctx, QueryMeterValueRollups,
+		arg.ChargeStationID,
+		arg.BucketInterval,
+		arg.EvseID,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MeterValueRollup{}
+	for rows.Next() {
+		var i MeterValueRollup
+		if err := rows.Scan(
+			&i.ChargeStationID,
+			&i.EvseID,
+			&i.BucketInterval,
+			&i.BucketStart,
+			&i.SampleCount,
+			&i.EnergyFirst,
+			&i.EnergyFirstAt,
+			&i.EnergyLast,
+			&i.EnergyLastAt,
+			&i.MaxPower,
+			&i.VoltageSum,
+			&i.VoltageCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const QueryMeterValues = `-- name: QueryMeterValues :many
 SELECT id, charge_station_id, evse_id, transaction_id, timestamp, sampled_values, received_at FROM meter_values
 WHERE charge_station_id = $1
-  AND ($2::int IS NULL OR evse_id = $2)
-  AND ($3::text IS NULL OR transaction_id = $3)
-  AND ($4::timestamp IS NULL OR timestamp >= $4)
-  AND ($5::timestamp IS NULL OR timestamp <= $5)
-ORDER BY timestamp DESC
-LIMIT $6 OFFSET $7
+  AND ($2::int IS NULL OR evse_id = $2::int)
+  AND ($3::text IS NULL OR transaction_id = $3::text)
+  AND ($4::timestamp IS NULL OR timestamp >= $4::timestamp)
+  AND ($5::timestamp IS NULL OR timestamp <= $5::timestamp)
+ORDER BY timestamp DESC, id
+LIMIT $7 OFFSET $6
 `
 
 type QueryMeterValuesParams struct {
 	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
-	Column2         int32            `db:"column_2" json:"column_2"`
-	Column3         string           `db:"column_3" json:"column_3"`
-	Column4         pgtype.Timestamp `db:"column_4" json:"column_4"`
-	Column5         pgtype.Timestamp `db:"column_5" json:"column_5"`
-	Limit           int32            `db:"limit" json:"limit"`
+	EvseID          pgtype.Int4      `db:"evse_id" json:"evse_id"`
+	TransactionID   pgtype.Text      `db:"transaction_id" json:"transaction_id"`
+	StartTime       pgtype.Timestamp `db:"start_time" json:"start_time"`
+	EndTime         pgtype.Timestamp `db:"end_time" json:"end_time"`
 	Offset          int32            `db:"offset" json:"offset"`
+	Limit           int32            `db:"limit" json:"limit"`
 }
 
 func (q *Queries) QueryMeterValues(ctx context.Context, arg QueryMeterValuesParams) ([]MeterValue, error) {
 	rows, err := q.db.Query(ctx, QueryMeterValues,
 		arg.ChargeStationID,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Limit,
+		arg.EvseID,
+		arg.TransactionID,
+		arg.StartTime,
+		arg.EndTime,
 		arg.Offset,
+		arg.Limit,
 	)
 	if err != nil {
 		return nil, err
@@ -178,9 +234,10 @@ func (q *Queries) QueryMeterValues(ctx context.Context, arg QueryMeterValuesPara
 	return items, nil
 }
 
-const StoreMeterValue = `-- name: StoreMeterValue :exec
+const StoreMeterValue = `-- name: StoreMeterValue :execrows
 INSERT INTO meter_values (charge_station_id, evse_id, transaction_id, timestamp, sampled_values)
 VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (charge_station_id, evse_id, timestamp, (md5(sampled_values::text))) DO NOTHING
 `
 
 type StoreMeterValueParams struct {
@@ -191,13 +248,66 @@ type StoreMeterValueParams struct {
 	SampledValues   []byte           `db:"sampled_values" json:"sampled_values"`
 }
 
-func (q *Queries) StoreMeterValue(ctx context.Context, arg StoreMeterValueParams) error {
-	_, err := q.db.Exec(ctx, StoreMeterValue,
+func (q *Queries) StoreMeterValue(ctx context.Context, arg StoreMeterValueParams) (int64, error) {
+	result, err := q.db.Exec(ctx, StoreMeterValue,
 		arg.ChargeStationID,
 		arg.EvseID,
 		arg.TransactionID,
 		arg.Timestamp,
 		arg.SampledValues,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UpsertMeterValueRollup = `-- name: UpsertMeterValueRollup :exec
+INSERT INTO meter_value_rollups (charge_station_id, evse_id, bucket_interval, bucket_start, sample_count,
+    energy_first, energy_first_at, energy_last, energy_last_at, max_power, voltage_sum, voltage_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (charge_station_id, bucket_interval, evse_id, bucket_start) DO UPDATE SET
+    sample_count = meter_value_rollups.sample_count + EXCLUDED.sample_count,
+    energy_first = CASE WHEN meter_value_rollups.energy_first_at IS NULL OR EXCLUDED.energy_first_at < meter_value_rollups.energy_first_at
+        THEN EXCLUDED.energy_first ELSE meter_value_rollups.energy_first END,
+    energy_first_at = LEAST(meter_value_rollups.energy_first_at, EXCLUDED.energy_first_at),
+    energy_last = CASE WHEN meter_value_rollups.energy_last_at IS NULL OR EXCLUDED.energy_last_at >= meter_value_rollups.energy_last_at
+        THEN EXCLUDED.energy_last ELSE meter_value_rollups.energy_last END,
+    energy_last_at = GREATEST(meter_value_rollups.energy_last_at, EXCLUDED.energy_last_at),
+    max_power = GREATEST(meter_value_rollups.max_power, EXCLUDED.max_power),
+    voltage_sum = meter_value_rollups.voltage_sum + EXCLUDED.voltage_sum,
+    voltage_count = meter_value_rollups.voltage_count + EXCLUDED.voltage_count
+`
+
+type UpsertMeterValueRollupParams struct {
+	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
+	EvseID          int32            `db:"evse_id" json:"evse_id"`
+	BucketInterval  string           `db:"bucket_interval" json:"bucket_interval"`
+	BucketStart     pgtype.Timestamp `db:"bucket_start" json:"bucket_start"`
+	SampleCount     int32            `db:"sample_count" json:"sample_count"`
+	EnergyFirst     pgtype.Float8    `db:"energy_first" json:"energy_first"`
+	EnergyFirstAt   pgtype.Timestamp `db:"energy_first_at" json:"energy_first_at"`
+	EnergyLast      pgtype.Float8    `db:"energy_last" json:"energy_last"`
+	EnergyLastAt    pgtype.Timestamp `db:"energy_last_at" json:"energy_last_at"`
+	MaxPower        pgtype.Float8    `db:"max_power" json:"max_power"`
+	VoltageSum      float64          `db:"voltage_sum" json:"voltage_sum"`
+	VoltageCount    int32            `db:"voltage_count" json:"voltage_count"`
+}
+
+func (q *Queries) UpsertMeterValueRollup(ctx context.Context, arg UpsertMeterValueRollupParams) error {
+	_, err := q.db.Exec(ctx, UpsertMeterValueRollup,
+		arg.ChargeStationID,
+		arg.EvseID,
+		arg.BucketInterval,
+		arg.BucketStart,
+		arg.SampleCount,
+		arg.EnergyFirst,
+		arg.EnergyFirstAt,
+		arg.EnergyLast,
+		arg.EnergyLastAt,
+		arg.MaxPower,
+		arg.VoltageSum,
+		arg.VoltageCount,
+	)
 	return err
 }
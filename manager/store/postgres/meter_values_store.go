@@ -5,14 +5,26 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 )
 
-// StoreMeterValues stores meter values received from a charge station.
+// StoreMeterValues stores meter values received from a charge station and merges them into the
+// rollups for the buckets that they fall into. Meter values that have already been stored for the
+// EVSE are ignored.
 func (s *Store) StoreMeterValues(ctx context.Context, chargeStationId string, evseId int, transactionId string, meterValues []store.MeterValue) error {
+	tx, err := s.writePool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	qtx := s.writeQueries().WithTx(tx)
+
+	var added []store.MeterValue
 	for _, mv := range meterValues {
 		// Marshal sampled values to JSON
 		sampledValuesJSON, err := json.Marshal(mv.SampledValues)
@@ -32,8 +44,8 @@ func (s *Store) StoreMeterValues(ctx context.Context, chargeStationId string, ev
 			txIdParam = pgtype.Text{String: transactionId, Valid: true}
 		}
 
-		// Store the meter value
-		err = s.writeQueries().StoreMeterValue(ctx, StoreMeterValueParams{
+		// Store the meter value, unless it has already been stored
+		stored, err := qtx.StoreMeterValue(ctx, StoreMeterValueParams{
 			ChargeStationID: chargeStationId,
 			EvseID:          int32(evseId),
 			TransactionID:   txIdParam,
@@ -43,8 +55,34 @@ func (s *Store) StoreMeterValues(ctx context.Context, chargeStationId string, ev
 		if err != nil {
 			return err
 		}
+		if stored > 0 {
+			added = append(added, mv)
+		}
+	}
+
+	for _, rollup := range store.RollUpMeterValues(chargeStationId, evseId, added) {
+		err := qtx.UpsertMeterValueRollup(ctx, UpsertMeterValueRollupParams{
+			ChargeStationID: chargeStationId,
+			EvseID:          int32(evseId),
+			BucketInterval:  string(rollup.Interval),
+			BucketStart:     timestampFromTime(rollup.Start),
+			SampleCount:     int32(rollup.SampleCount),
+			EnergyFirst:     toNullFloat8(rollup.EnergyFirst),
+			EnergyFirstAt:   toNullTimestamp(rollup.EnergyFirstAt),
+			EnergyLast:      toNullFloat8(rollup.EnergyLast),
+			EnergyLastAt:    toNullTimestamp(rollup.EnergyLastAt),
+			MaxPower:        toNullFloat8(rollup.MaxPower),
+			VoltageSum:      rollup.VoltageSum,
+			VoltageCount:    int32(rollup.VoltageCount),
+		})
+		if err != nil {
+			return fmt.Errorf("store meter value rollup for %s: %w", chargeStationId, err)
+		}
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -107,26 +145,26 @@ func (s *Store) QueryMeterValues(ctx context.Context, filter store.MeterValuesFi
 		Offset:          int32(filter.Offset),
 	}
 
-	// Optional filters - using Column2, Column3, etc. as generated by sqlc
+	// Optional filters are passed as NULL when they are not set
 	if filter.ConnectorId != nil {
-		params.Column2 = int32(*filter.ConnectorId)
+		params.EvseID = pgtype.Int4{Int32: int32(*filter.ConnectorId), Valid: true}
 	}
 	if filter.TransactionId != nil {
-		params.Column3 = *filter.TransactionId
+		params.TransactionID = pgtype.Text{String: *filter.TransactionId, Valid: true}
 	}
 	if filter.StartTime != nil {
 		startTime, err := time.Parse(time.RFC3339, *filter.StartTime)
 		if err != nil {
 			return nil, err
 		}
-		params.Column4 = pgtype.Timestamp{Time: startTime, Valid: true}
+		params.StartTime = pgtype.Timestamp{Time: startTime, Valid: true}
 	}
 	if filter.EndTime != nil {
 		endTime, err := time.Parse(time.RFC3339, *filter.EndTime)
 		if err != nil {
 			return nil, err
 		}
-		params.Column5 = pgtype.Timestamp{Time: endTime, Valid: true}
+		params.EndTime = pgtype.Timestamp{Time: endTime, Valid: true}
 	}
 
 	// Get paginated results
@@ -138,10 +176,10 @@ func (s *Store) QueryMeterValues(ctx context.Context, filter store.MeterValuesFi
 	// Get total count for the same filters (without limit/offset)
 	countParams := CountMeterValuesParams{
 		ChargeStationID: params.ChargeStationID,
-		Column2:         params.Column2,
-		Column3:         params.Column3,
-		Column4:         params.Column4,
-		Column5:         params.Column5,
+		EvseID:          params.EvseID,
+		TransactionID:   params.TransactionID,
+		StartTime:       params.StartTime,
+		EndTime:         params.EndTime,
 	}
 	total, err := s.readQueries().CountMeterValues(ctx, countParams)
 	if err != nil {
@@ -177,3 +215,42 @@ func (s *Store) QueryMeterValues(ctx context.Context, filter store.MeterValuesFi
 		Total:       int(total),
 	}, nil
 }
+
+// QueryMeterValueRollups retrieves the rollups for a charge station, ordered by EVSE and start.
+func (s *Store) QueryMeterValueRollups(ctx context.Context, filter store.MeterValueRollupsFilter) ([]*store.MeterValueRollup, error) {
+	params := QueryMeterValueRollupsParams{
+		ChargeStationID: filter.ChargeStationId,
+		BucketInterval:  string(filter.Interval),
+		EvseID:          toNullInt32(filter.ConnectorId),
+	}
+	if filter.StartTime != nil {
+		params.StartTime = timestampFromTime(filter.StartTime.UTC())
+	}
+	if filter.EndTime != nil {
+		params.EndTime = timestampFromTime(filter.EndTime.UTC())
+	}
+
+	rows, err := s.readQueries().QueryMeterValueRollups(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("query meter value rollups for %s: %w", filter.ChargeStationId, err)
+	}
+
+	result := make([]*store.MeterValueRollup, len(rows))
+	for i, row := range rows {
+		result[i] = &store.MeterValueRollup{
+			ChargeStationId: row.ChargeStationID,
+			EvseId:          int(row.EvseID),
+			Interval:        store.MeterValueInterval(row.BucketInterval),
+			Start:           timeFromTimestamp(row.BucketStart),
+			SampleCount:     int(row.SampleCount),
+			EnergyFirst:     fromNullFloat8(row.EnergyFirst),
+			EnergyFirstAt:   fromNullTimestamp(row.EnergyFirstAt),
+			EnergyLast:      fromNullFloat8(row.EnergyLast),
+			EnergyLastAt:    fromNullTimestamp(row.EnergyLastAt),
+			MaxPower:        fromNullFloat8(row.MaxPower),
+			VoltageSum:      row.VoltageSum,
+			VoltageCount:    int(row.VoltageCount),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,3 @@
+ALTER TABLE charge_station_status
+    DROP COLUMN IF EXISTS last_connected,
+    DROP COLUMN IF EXISTS last_disconnected;
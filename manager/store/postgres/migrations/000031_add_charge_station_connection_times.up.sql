@@ -0,0 +1,3 @@
+ALTER TABLE charge_station_status
+    ADD COLUMN IF NOT EXISTS last_connected TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS last_disconnected TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS command (
+    id VARCHAR(64) PRIMARY KEY,
+    charge_station_id VARCHAR(48) NOT NULL,
+    ocpp_version VARCHAR(16) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    request JSONB NOT NULL,
+    response JSONB,
+    error_code TEXT,
+    error_description TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_command_cs_created ON command(charge_station_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_command_pending ON command(updated_at) WHERE status IN ('Queued', 'Sent');
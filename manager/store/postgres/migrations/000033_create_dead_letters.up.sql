@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS dead_letter (
+    id VARCHAR(64) PRIMARY KEY,
+    charge_station_id VARCHAR(48) NOT NULL,
+    ocpp_version VARCHAR(16) NOT NULL,
+    message_type VARCHAR(16) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    message_id VARCHAR(64) NOT NULL,
+    message JSONB NOT NULL,
+    error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letter_created ON dead_letter(created_at DESC);
@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS ended_seq_no;
+ALTER TABLE transactions DROP COLUMN IF EXISTS start_seq_no;
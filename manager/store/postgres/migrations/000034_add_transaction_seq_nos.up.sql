@@ -0,0 +1,3 @@
+-- Record the sequence numbers of the messages that started and ended a transaction
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS start_seq_no INT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS ended_seq_no INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_device_report_generated_all;
+DROP INDEX IF EXISTS idx_cs_event_timestamp_all;
@@ -0,0 +1,3 @@
+-- Allow the records that are older than their retention period to be found across all charge stations
+CREATE INDEX IF NOT EXISTS idx_cs_event_timestamp_all ON charge_station_event(timestamp);
+CREATE INDEX IF NOT EXISTS idx_device_report_generated_all ON device_report(generated_at);
@@ -0,0 +1,18 @@
+-- Rollups of the standalone meter values for each EVSE over 5 minute, hourly and daily buckets,
+-- maintained as meter values are stored so that long periods can be charted without reading
+-- every meter value
+CREATE TABLE meter_value_rollups (
+    charge_station_id VARCHAR(255) NOT NULL,
+    evse_id INT NOT NULL,
+    bucket_interval VARCHAR(8) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    sample_count INT NOT NULL,
+    energy_first DOUBLE PRECISION, -- Wh
+    energy_first_at TIMESTAMP,
+    energy_last DOUBLE PRECISION, -- Wh
+    energy_last_at TIMESTAMP,
+    max_power DOUBLE PRECISION, -- W
+    voltage_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+    voltage_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (charge_station_id, bucket_interval, evse_id, bucket_start)
+);
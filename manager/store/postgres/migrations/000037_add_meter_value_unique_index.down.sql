@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_meter_values_unique;
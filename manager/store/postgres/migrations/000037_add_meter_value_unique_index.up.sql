@@ -0,0 +1,10 @@
+-- A meter value that is received more than once, e.g. when a message is redelivered, is only stored
+-- (and rolled up) once: remove the duplicates that have already been stored before adding the index
+DELETE FROM meter_values a USING meter_values b
+WHERE a.id > b.id
+  AND a.charge_station_id = b.charge_station_id
+  AND a.evse_id = b.evse_id
+  AND a.timestamp = b.timestamp
+  AND a.sampled_values = b.sampled_values;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_meter_values_unique ON meter_values(charge_station_id, evse_id, timestamp, (md5(sampled_values::text)));
@@ -0,0 +1,5 @@
+-- This migration is not reversed. The up migration only re-keyed the certificates that were stored
+-- under the hash of their PEM data, and removed certificates that were stored more than once, so
+-- neither the certificates it re-keyed nor the ones it removed can be identified any more. Re-keying
+-- every certificate by the hash of its PEM data would break the lookup of certificates that were
+-- stored after the up migration ran.
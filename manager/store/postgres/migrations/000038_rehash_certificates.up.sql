@@ -0,0 +1,33 @@
+-- Certificates were stored under the hex SHA-256 hash of the PEM data, but are looked up by the
+-- base64 (URL encoding, no padding) SHA-256 hash of the DER-encoded certificate: re-key the
+-- existing certificates so that they can still be found. Rows that do not hold a PEM certificate
+-- are left as they are.
+DO $$
+DECLARE
+    cert RECORD;
+    der BYTEA;
+    new_hash VARCHAR(255);
+BEGIN
+    FOR cert IN SELECT certificate_hash, certificate_data FROM certificates ORDER BY created_at ASC LOOP
+        BEGIN
+            der := decode(regexp_replace(
+                substring(cert.certificate_data FROM '-----BEGIN CERTIFICATE-----(.*?)-----END CERTIFICATE-----'),
+                '\s', '', 'g'), 'base64');
+        EXCEPTION WHEN OTHERS THEN
+            CONTINUE;
+        END;
+        IF der IS NULL OR length(der) = 0 THEN
+            CONTINUE;
+        END IF;
+
+        new_hash := translate(rtrim(encode(sha256(der), 'base64'), '='), '+/', '-_');
+        IF new_hash = cert.certificate_hash THEN
+            CONTINUE;
+        END IF;
+
+        -- the same certificate may have been stored more than once with different PEM encodings:
+        -- keep the one that was stored last
+        DELETE FROM certificates WHERE certificate_hash = new_hash;
+        UPDATE certificates SET certificate_hash = new_hash WHERE certificate_hash = cert.certificate_hash;
+    END LOOP;
+END $$;
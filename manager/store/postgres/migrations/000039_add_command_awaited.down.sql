@@ -0,0 +1 @@
+ALTER TABLE command DROP COLUMN IF EXISTS awaited;
@@ -0,0 +1,3 @@
+-- Record whether a caller is waiting for the answer to a command so that answers are only shared
+-- between manager instances when another instance may be waiting for them
+ALTER TABLE command ADD COLUMN IF NOT EXISTS awaited BOOLEAN NOT NULL DEFAULT FALSE;
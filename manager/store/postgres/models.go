@@ -118,14 +118,16 @@ type ChargeStationSetting struct {
 }
 
 type ChargeStationStatus struct {
-	ChargeStationID string             `db:"charge_station_id" json:"charge_station_id"`
-	Connected       bool               `db:"connected" json:"connected"`
-	LastHeartbeat   pgtype.Timestamptz `db:"last_heartbeat" json:"last_heartbeat"`
-	FirmwareVersion pgtype.Text        `db:"firmware_version" json:"firmware_version"`
-	Model           pgtype.Text        `db:"model" json:"model"`
-	Vendor          pgtype.Text        `db:"vendor" json:"vendor"`
-	SerialNumber    pgtype.Text        `db:"serial_number" json:"serial_number"`
-	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ChargeStationID  string             `db:"charge_station_id" json:"charge_station_id"`
+	Connected        bool               `db:"connected" json:"connected"`
+	LastHeartbeat    pgtype.Timestamptz `db:"last_heartbeat" json:"last_heartbeat"`
+	FirmwareVersion  pgtype.Text        `db:"firmware_version" json:"firmware_version"`
+	Model            pgtype.Text        `db:"model" json:"model"`
+	Vendor           pgtype.Text        `db:"vendor" json:"vendor"`
+	SerialNumber     pgtype.Text        `db:"serial_number" json:"serial_number"`
+	UpdatedAt        pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	LastConnected    pgtype.Timestamptz `db:"last_connected" json:"last_connected"`
+	LastDisconnected pgtype.Timestamptz `db:"last_disconnected" json:"last_disconnected"`
 }
 
 type ChargeStationTrigger struct {
@@ -159,6 +161,21 @@ type ChargingProfile struct {
 	UpdatedAt               pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 }
 
+type Command struct {
+	ID               string             `db:"id" json:"id"`
+	ChargeStationID  string             `db:"charge_station_id" json:"charge_station_id"`
+	OcppVersion      string             `db:"ocpp_version" json:"ocpp_version"`
+	Action           string             `db:"action" json:"action"`
+	Status           string             `db:"status" json:"status"`
+	Request          []byte             `db:"request" json:"request"`
+	Response         []byte             `db:"response" json:"response"`
+	ErrorCode        pgtype.Text        `db:"error_code" json:"error_code"`
+	ErrorDescription pgtype.Text        `db:"error_description" json:"error_description"`
+	CreatedAt        pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	Awaited          bool               `db:"awaited" json:"awaited"`
+}
+
 type ConnectorStatus struct {
 	ChargeStationID      string             `db:"charge_station_id" json:"charge_station_id"`
 	ConnectorID          int32              `db:"connector_id" json:"connector_id"`
@@ -172,6 +189,18 @@ type ConnectorStatus struct {
 	UpdatedAt            pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type DeadLetter struct {
+	ID              string             `db:"id" json:"id"`
+	ChargeStationID string             `db:"charge_station_id" json:"charge_station_id"`
+	OcppVersion     string             `db:"ocpp_version" json:"ocpp_version"`
+	MessageType     string             `db:"message_type" json:"message_type"`
+	Action          string             `db:"action" json:"action"`
+	MessageID       string             `db:"message_id" json:"message_id"`
+	Message         []byte             `db:"message" json:"message"`
+	Error           string             `db:"error" json:"error"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type DeviceReport struct {
 	ID              int32              `db:"id" json:"id"`
 	ChargeStationID string             `db:"charge_station_id" json:"charge_station_id"`
@@ -291,6 +320,21 @@ type MeterValue struct {
 	ReceivedAt      pgtype.Timestamp `db:"received_at" json:"received_at"`
 }
 
+type MeterValueRollup struct {
+	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
+	EvseID          int32            `db:"evse_id" json:"evse_id"`
+	BucketInterval  string           `db:"bucket_interval" json:"bucket_interval"`
+	BucketStart     pgtype.Timestamp `db:"bucket_start" json:"bucket_start"`
+	SampleCount     int32            `db:"sample_count" json:"sample_count"`
+	EnergyFirst     pgtype.Float8    `db:"energy_first" json:"energy_first"`
+	EnergyFirstAt   pgtype.Timestamp `db:"energy_first_at" json:"energy_first_at"`
+	EnergyLast      pgtype.Float8    `db:"energy_last" json:"energy_last"`
+	EnergyLastAt    pgtype.Timestamp `db:"energy_last_at" json:"energy_last_at"`
+	MaxPower        pgtype.Float8    `db:"max_power" json:"max_power"`
+	VoltageSum      float64          `db:"voltage_sum" json:"voltage_sum"`
+	VoltageCount    int32            `db:"voltage_count" json:"voltage_count"`
+}
+
 type OcpiParty struct {
 	ID          int64            `db:"id" json:"id"`
 	Role        string           `db:"role" json:"role"`
@@ -390,6 +434,8 @@ type Transaction struct {
 	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 	LastCost        pgtype.Numeric   `db:"last_cost" json:"last_cost"`
+	StartSeqNo      int32            `db:"start_seq_no" json:"start_seq_no"`
+	EndedSeqNo      int32            `db:"ended_seq_no" json:"ended_seq_no"`
 }
 
 type TransactionMeterValue struct {
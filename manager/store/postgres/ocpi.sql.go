@@ -102,6 +102,38 @@ func (q *Queries) ListOcpiPartiesForRole(ctx context.Context, role string) ([]Oc
 	return items, nil
 }
 
+const ListOcpiRegistrationTokens = `-- name: ListOcpiRegistrationTokens :many
+SELECT token FROM ocpi_registrations
+WHERE token > $1
+ORDER BY token ASC
+LIMIT $2
+`
+
+type ListOcpiRegistrationTokensParams struct {
+	Token string `db:"token" json:"token"`
+	Limit int32  `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListOcpiRegistrationTokens(ctx context.Context, arg ListOcpiRegistrationTokensParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListOcpiRegistrationTokens, arg.Token, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, err
+		}
+		items = append(items, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const SetOcpiParty = `-- name: SetOcpiParty :one
 INSERT INTO ocpi_parties (role, country_code, party_id, url, token)
 VALUES ($1, $2, $3, $4, $5)
@@ -10,12 +10,16 @@ import (
 
 type Querier interface {
 	AddChargeStationCertificate(ctx context.Context, arg AddChargeStationCertificateParams) (ChargeStationCertificate, error)
+	AddDeadLetter(ctx context.Context, arg AddDeadLetterParams) error
 	AddMeterValues(ctx context.Context, arg AddMeterValuesParams) error
-	CancelReservation(ctx context.Context, reservationID int32) error
+	CancelReservation(ctx context.Context, reservationID int32) (int64, error)
 	CountChargeStationEvents(ctx context.Context, chargeStationID string) (int64, error)
+	CountCommands(ctx context.Context, chargeStationID string) (int64, error)
+	CountDeadLetters(ctx context.Context) (int64, error)
 	CountDeviceReports(ctx context.Context, chargeStationID string) (int64, error)
 	CountMeterValues(ctx context.Context, arg CountMeterValuesParams) (int64, error)
 	CountTransactionsFiltered(ctx context.Context, arg CountTransactionsFilteredParams) (int64, error)
+	CreateCommand(ctx context.Context, arg CreateCommandParams) error
 	CreateOrUpdateDisplayMessage(ctx context.Context, arg CreateOrUpdateDisplayMessageParams) error
 	CreateReservation(ctx context.Context, arg CreateReservationParams) error
 	CreateToken(ctx context.Context, arg CreateTokenParams) (Token, error)
@@ -29,6 +33,7 @@ type Querier interface {
 	DeleteChargeStationChangeAvailability(ctx context.Context, chargeStationID string) error
 	DeleteChargeStationClearCache(ctx context.Context, chargeStationID string) error
 	DeleteChargeStationDataTransfer(ctx context.Context, chargeStationID string) error
+	DeleteChargeStationEventsBefore(ctx context.Context, arg DeleteChargeStationEventsBeforeParams) (int64, error)
 	DeleteChargeStationSettings(ctx context.Context, chargeStationID string) error
 	DeleteChargeStationTrigger(ctx context.Context, chargeStationID string) error
 	DeleteChargingProfileById(ctx context.Context, arg DeleteChargingProfileByIdParams) (int64, error)
@@ -36,12 +41,16 @@ type Querier interface {
 	DeleteChargingProfilesByStationAndConnector(ctx context.Context, arg DeleteChargingProfilesByStationAndConnectorParams) (int64, error)
 	DeleteChargingProfilesByStationAndPurpose(ctx context.Context, arg DeleteChargingProfilesByStationAndPurposeParams) (int64, error)
 	DeleteChargingProfilesByStationConnectorPurposeStack(ctx context.Context, arg DeleteChargingProfilesByStationConnectorPurposeStackParams) (int64, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+	DeleteDeviceReportsBefore(ctx context.Context, arg DeleteDeviceReportsBeforeParams) (int64, error)
 	DeleteDiagnosticsRequest(ctx context.Context, chargeStationID string) error
 	DeleteDisplayMessage(ctx context.Context, arg DeleteDisplayMessageParams) error
+	DeleteFinishedTransactionsBefore(ctx context.Context, arg DeleteFinishedTransactionsBeforeParams) (int64, error)
 	DeleteFirmwareUpdateRequest(ctx context.Context, chargeStationID string) error
 	DeleteLocalAuthListEntry(ctx context.Context, arg DeleteLocalAuthListEntryParams) error
 	DeleteLocation(ctx context.Context, id string) error
 	DeleteLogRequest(ctx context.Context, chargeStationID string) error
+	DeleteMeterValuesBefore(ctx context.Context, arg DeleteMeterValuesBeforeParams) (int64, error)
 	DeleteOcpiRegistration(ctx context.Context, token string) error
 	DeleteRemoteStartTransactionRequest(ctx context.Context, chargeStationID string) error
 	DeleteRemoteStopTransactionRequest(ctx context.Context, chargeStationID string) error
@@ -49,6 +58,7 @@ type Querier interface {
 	DeleteToken(ctx context.Context, uid string) error
 	DeleteUnlockConnectorRequest(ctx context.Context, chargeStationID string) error
 	DeleteVariableMonitoring(ctx context.Context, arg DeleteVariableMonitoringParams) error
+	EnsureTransaction(ctx context.Context, arg EnsureTransactionParams) error
 	ExpireReservations(ctx context.Context) (int64, error)
 	FindActiveTransaction(ctx context.Context, chargeStationID string) (Transaction, error)
 	GetActiveReservations(ctx context.Context, chargeStationID string) ([]Reservation, error)
@@ -73,7 +83,9 @@ type Querier interface {
 	GetChargeStationTrigger(ctx context.Context, chargeStationID string) (ChargeStationTrigger, error)
 	GetChargingProfilesByStation(ctx context.Context, chargeStationID string) ([]ChargingProfile, error)
 	GetChargingProfilesByStationAndConnector(ctx context.Context, arg GetChargingProfilesByStationAndConnectorParams) ([]ChargingProfile, error)
+	GetCommand(ctx context.Context, id string) (Command, error)
 	GetConnectorStatus(ctx context.Context, arg GetConnectorStatusParams) (ConnectorStatus, error)
+	GetDeadLetter(ctx context.Context, id string) (DeadLetter, error)
 	GetDiagnosticsRequest(ctx context.Context, chargeStationID string) (DiagnosticsRequest, error)
 	GetDiagnosticsStatus(ctx context.Context, chargeStationID string) (DiagnosticsStatus, error)
 	GetDisplayMessage(ctx context.Context, arg GetDisplayMessageParams) (DisplayMessage, error)
@@ -103,6 +115,7 @@ type Querier interface {
 	InsertChargeStationEvent(ctx context.Context, arg InsertChargeStationEventParams) (int32, error)
 	InsertDeviceReport(ctx context.Context, arg InsertDeviceReportParams) (int32, error)
 	ListAllLocations(ctx context.Context, arg ListAllLocationsParams) ([]Location, error)
+	ListCertificateHashes(ctx context.Context, arg ListCertificateHashesParams) ([]string, error)
 	ListCertificates(ctx context.Context) ([]Certificate, error)
 	ListChargeStationCertificateDeletions(ctx context.Context, arg ListChargeStationCertificateDeletionsParams) ([]ChargeStationCertificateDeletion, error)
 	ListChargeStationCertificateQueries(ctx context.Context, arg ListChargeStationCertificateQueriesParams) ([]ChargeStationCertificateQuery, error)
@@ -111,9 +124,12 @@ type Querier interface {
 	ListChargeStationClearCaches(ctx context.Context, arg ListChargeStationClearCachesParams) ([]ChargeStationClearCache, error)
 	ListChargeStationDataTransfers(ctx context.Context, arg ListChargeStationDataTransfersParams) ([]ChargeStationDataTransfer, error)
 	ListChargeStationEvents(ctx context.Context, arg ListChargeStationEventsParams) ([]ChargeStationEvent, error)
+	ListChargeStationIds(ctx context.Context, arg ListChargeStationIdsParams) ([]string, error)
 	ListChargeStationSettings(ctx context.Context, arg ListChargeStationSettingsParams) ([]ChargeStationSetting, error)
 	ListChargeStationTriggers(ctx context.Context, arg ListChargeStationTriggersParams) ([]ChargeStationTrigger, error)
+	ListCommands(ctx context.Context, arg ListCommandsParams) ([]Command, error)
 	ListConnectorStatuses(ctx context.Context, chargeStationID string) ([]ConnectorStatus, error)
+	ListDeadLetters(ctx context.Context, arg ListDeadLettersParams) ([]DeadLetter, error)
 	ListDeviceReports(ctx context.Context, arg ListDeviceReportsParams) ([]DeviceReport, error)
 	ListDiagnosticsRequests(ctx context.Context, arg ListDiagnosticsRequestsParams) ([]DiagnosticsRequest, error)
 	ListDisplayMessages(ctx context.Context, chargeStationID string) ([]DisplayMessage, error)
@@ -124,14 +140,17 @@ type Querier interface {
 	ListLocations(ctx context.Context, arg ListLocationsParams) ([]Location, error)
 	ListLogRequests(ctx context.Context, arg ListLogRequestsParams) ([]LogRequest, error)
 	ListOcpiPartiesForRole(ctx context.Context, role string) ([]OcpiParty, error)
+	ListOcpiRegistrationTokens(ctx context.Context, arg ListOcpiRegistrationTokensParams) ([]string, error)
 	ListRemoteStartTransactionRequests(ctx context.Context, arg ListRemoteStartTransactionRequestsParams) ([]RemoteStartTransactionRequest, error)
 	ListRemoteStopTransactionRequests(ctx context.Context, arg ListRemoteStopTransactionRequestsParams) ([]RemoteStopTransactionRequest, error)
+	ListReservations(ctx context.Context, arg ListReservationsParams) ([]Reservation, error)
 	ListTokens(ctx context.Context, arg ListTokensParams) ([]Token, error)
 	ListTransactions(ctx context.Context) ([]Transaction, error)
 	ListTransactionsFiltered(ctx context.Context, arg ListTransactionsFilteredParams) ([]Transaction, error)
 	ListVariableMonitoring(ctx context.Context, arg ListVariableMonitoringParams) ([]VariableMonitoring, error)
 	LookupChargeStationCertificateDeletion(ctx context.Context, chargeStationID string) (ChargeStationCertificateDeletion, error)
 	LookupChargeStationCertificateQuery(ctx context.Context, chargeStationID string) (ChargeStationCertificateQuery, error)
+	QueryMeterValueRollups(ctx context.Context, arg QueryMeterValueRollupsParams) ([]MeterValueRollup, error)
 	QueryMeterValues(ctx context.Context, arg QueryMeterValuesParams) ([]MeterValue, error)
 	SetCertificate(ctx context.Context, arg SetCertificateParams) (Certificate, error)
 	SetChargeStationAuth(ctx context.Context, arg SetChargeStationAuthParams) (ChargeStation, error)
@@ -139,10 +158,13 @@ type Querier interface {
 	SetChargeStationCertificateQuery(ctx context.Context, arg SetChargeStationCertificateQueryParams) (ChargeStationCertificateQuery, error)
 	SetChargeStationChangeAvailability(ctx context.Context, arg SetChargeStationChangeAvailabilityParams) (ChargeStationChangeAvailability, error)
 	SetChargeStationClearCache(ctx context.Context, arg SetChargeStationClearCacheParams) (ChargeStationClearCache, error)
+	SetChargeStationConnected(ctx context.Context, arg SetChargeStationConnectedParams) error
 	SetChargeStationDataTransfer(ctx context.Context, arg SetChargeStationDataTransferParams) (ChargeStationDataTransfer, error)
 	SetChargeStationRuntime(ctx context.Context, arg SetChargeStationRuntimeParams) (ChargeStationRuntime, error)
 	SetChargeStationSettings(ctx context.Context, arg SetChargeStationSettingsParams) (ChargeStationSetting, error)
 	SetChargeStationTrigger(ctx context.Context, arg SetChargeStationTriggerParams) (ChargeStationTrigger, error)
+	SetCommandResult(ctx context.Context, arg SetCommandResultParams) error
+	SetCommandSent(ctx context.Context, arg SetCommandSentParams) error
 	SetLocation(ctx context.Context, arg SetLocationParams) (Location, error)
 	SetOcpiParty(ctx context.Context, arg SetOcpiPartyParams) (OcpiParty, error)
 	SetOcpiRegistration(ctx context.Context, arg SetOcpiRegistrationParams) (OcpiRegistration, error)
@@ -152,10 +174,11 @@ type Querier interface {
 	SetResetRequest(ctx context.Context, arg SetResetRequestParams) (ResetRequest, error)
 	// Unlock Connector Request
 	SetUnlockConnectorRequest(ctx context.Context, arg SetUnlockConnectorRequestParams) (UnlockConnectorRequest, error)
-	StoreMeterValue(ctx context.Context, arg StoreMeterValueParams) error
+	StoreMeterValue(ctx context.Context, arg StoreMeterValueParams) (int64, error)
+	TimeOutCommands(ctx context.Context, arg TimeOutCommandsParams) (int64, error)
 	UpdateChargeStationCertificate(ctx context.Context, arg UpdateChargeStationCertificateParams) (ChargeStationCertificate, error)
 	UpdateHeartbeat(ctx context.Context, arg UpdateHeartbeatParams) error
-	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) error
+	UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (int64, error)
 	UpdateToken(ctx context.Context, arg UpdateTokenParams) (Token, error)
 	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error)
 	UpsertChargeStationStatus(ctx context.Context, arg UpsertChargeStationStatusParams) error
@@ -169,6 +192,7 @@ type Querier interface {
 	UpsertLocalListVersion(ctx context.Context, arg UpsertLocalListVersionParams) error
 	UpsertLogRequest(ctx context.Context, arg UpsertLogRequestParams) error
 	UpsertLogStatus(ctx context.Context, arg UpsertLogStatusParams) error
+	UpsertMeterValueRollup(ctx context.Context, arg UpsertMeterValueRollupParams) error
 	UpsertPublishFirmwareStatus(ctx context.Context, arg UpsertPublishFirmwareStatusParams) error
 	UpsertVariableMonitoring(ctx context.Context, arg UpsertVariableMonitoringParams) (int32, error)
 	UpsertVariableMonitoringWithId(ctx context.Context, arg UpsertVariableMonitoringWithIdParams) error
@@ -21,3 +21,9 @@ RETURNING *;
 -- name: DeleteCertificate :exec
 DELETE FROM certificates
 WHERE certificate_hash = $1;
+
+-- name: ListCertificateHashes :many
+SELECT certificate_hash FROM certificates
+WHERE certificate_hash > $1
+ORDER BY certificate_hash ASC
+LIMIT $2;
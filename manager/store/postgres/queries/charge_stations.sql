@@ -21,7 +21,7 @@ SELECT * FROM charge_station_settings WHERE charge_station_id = $1;
 INSERT INTO charge_station_settings (charge_station_id, settings)
 VALUES ($1, $2)
 ON CONFLICT (charge_station_id) DO UPDATE
-SET settings = EXCLUDED.settings, updated_at = NOW()
+SET settings = charge_station_settings.settings || EXCLUDED.settings, updated_at = NOW()
 RETURNING *;
 
 -- name: ListChargeStationSettings :many
@@ -64,7 +64,8 @@ RETURNING *;
 UPDATE charge_station_certificates
 SET certificate = $3,
     certificate_installation_status = $4,
-    send_after = $5
+    send_after = $5,
+    certificate_type = $6
 WHERE charge_station_id = $1 AND certificate_id = $2
 RETURNING *;
 
@@ -72,11 +73,15 @@ RETURNING *;
 DELETE FROM charge_station_certificates WHERE charge_station_id = $1;
 
 -- name: ListChargeStationCertificates :many
-SELECT DISTINCT ON (charge_station_id) charge_station_id, certificate_id, certificate_type, certificate, certificate_installation_status, send_after, created_at
+SELECT charge_station_id, certificate_id, certificate_type, certificate, certificate_installation_status, send_after, created_at
 FROM charge_station_certificates
-WHERE charge_station_id > $1
-ORDER BY charge_station_id ASC, created_at DESC
-LIMIT $2;
+WHERE charge_station_id IN (
+    SELECT DISTINCT c.charge_station_id FROM charge_station_certificates c
+    WHERE c.charge_station_id > $1
+    ORDER BY c.charge_station_id ASC
+    LIMIT $2
+)
+ORDER BY charge_station_id ASC, created_at DESC;
 
 -- Triggers
 -- name: GetChargeStationTrigger :one
@@ -221,3 +226,9 @@ SELECT * FROM charge_station_certificate_deletions
 WHERE charge_station_id > $1
 ORDER BY charge_station_id ASC
 LIMIT $2;
+
+-- name: ListChargeStationIds :many
+SELECT charge_station_id FROM charge_stations
+WHERE charge_station_id > $1
+ORDER BY charge_station_id ASC
+LIMIT $2;
@@ -0,0 +1,53 @@
+-- name: CreateCommand :exec
+INSERT INTO command (
+    id,
+    charge_station_id,
+    ocpp_version,
+    action,
+    status,
+    request,
+    response,
+    error_code,
+    error_description,
+    created_at,
+    updated_at,
+    awaited
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: SetCommandSent :exec
+UPDATE command
+SET status = 'Sent', updated_at = $2
+WHERE id = $1 AND status = 'Queued';
+
+-- name: SetCommandResult :exec
+UPDATE command
+SET status = $2,
+    response = $3,
+    error_code = $4,
+    error_description = $5,
+    updated_at = $6
+WHERE id = $1 AND charge_station_id = $7;
+
+-- name: GetCommand :one
+SELECT id, charge_station_id, ocpp_version, action, status, request, response,
+       error_code, error_description, created_at, updated_at, awaited
+FROM command
+WHERE id = $1;
+
+-- name: ListCommands :many
+SELECT id, charge_station_id, ocpp_version, action, status, request, response,
+       error_code, error_description, created_at, updated_at, awaited
+FROM command
+WHERE charge_station_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountCommands :one
+SELECT COUNT(*) FROM command
+WHERE charge_station_id = $1;
+
+-- name: TimeOutCommands :execrows
+UPDATE command
+SET status = 'TimedOut', updated_at = @timed_out_at::timestamptz
+WHERE status IN ('Queued', 'Sent') AND updated_at < @updated_before::timestamptz;
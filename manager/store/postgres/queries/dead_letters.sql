@@ -0,0 +1,33 @@
+-- name: AddDeadLetter :exec
+INSERT INTO dead_letter (
+    id,
+    charge_station_id,
+    ocpp_version,
+    message_type,
+    action,
+    message_id,
+    message,
+    error,
+    created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetDeadLetter :one
+SELECT id, charge_station_id, ocpp_version, message_type, action, message_id, message,
+       error, created_at
+FROM dead_letter
+WHERE id = $1;
+
+-- name: ListDeadLetters :many
+SELECT id, charge_station_id, ocpp_version, message_type, action, message_id, message,
+       error, created_at
+FROM dead_letter
+ORDER BY created_at DESC, id
+LIMIT $1 OFFSET $2;
+
+-- name: CountDeadLetters :one
+SELECT COUNT(*) FROM dead_letter;
+
+-- name: DeleteDeadLetter :exec
+DELETE FROM dead_letter
+WHERE id = $1;
@@ -6,12 +6,12 @@ LIMIT 1;
 -- name: ListLocations :many
 SELECT * FROM locations
 WHERE country_code = $1 AND party_id = $2
-ORDER BY created_at DESC
+ORDER BY id ASC
 LIMIT $3 OFFSET $4;
 
 -- name: ListAllLocations :many
 SELECT * FROM locations
-ORDER BY created_at DESC
+ORDER BY id ASC
 LIMIT $1 OFFSET $2;
 
 -- name: SetLocation :one
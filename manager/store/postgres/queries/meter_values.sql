@@ -1,6 +1,7 @@
--- name: StoreMeterValue :exec
+-- name: StoreMeterValue :execrows
 INSERT INTO meter_values (charge_station_id, evse_id, transaction_id, timestamp, sampled_values)
-VALUES ($1, $2, $3, $4, $5);
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (charge_station_id, evse_id, timestamp, (md5(sampled_values::text))) DO NOTHING;
 
 -- name: GetMeterValuesByStationAndEvse :many
 SELECT * FROM meter_values
@@ -15,18 +16,42 @@ ORDER BY timestamp DESC;
 
 -- name: QueryMeterValues :many
 SELECT * FROM meter_values
-WHERE charge_station_id = $1
-  AND ($2::int IS NULL OR evse_id = $2)
-  AND ($3::text IS NULL OR transaction_id = $3)
-  AND ($4::timestamp IS NULL OR timestamp >= $4)
-  AND ($5::timestamp IS NULL OR timestamp <= $5)
-ORDER BY timestamp DESC
-LIMIT $6 OFFSET $7;
+WHERE charge_station_id = sqlc.arg('charge_station_id')
+  AND (sqlc.narg('evse_id')::int IS NULL OR evse_id = sqlc.narg('evse_id')::int)
+  AND (sqlc.narg('transaction_id')::text IS NULL OR transaction_id = sqlc.narg('transaction_id')::text)
+  AND (sqlc.narg('start_time')::timestamp IS NULL OR timestamp >= sqlc.narg('start_time')::timestamp)
+  AND (sqlc.narg('end_time')::timestamp IS NULL OR timestamp <= sqlc.narg('end_time')::timestamp)
+ORDER BY timestamp DESC, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountMeterValues :one
 SELECT COUNT(*) FROM meter_values
-WHERE charge_station_id = $1
-  AND ($2::int IS NULL OR evse_id = $2)
-  AND ($3::text IS NULL OR transaction_id = $3)
-  AND ($4::timestamp IS NULL OR timestamp >= $4)
-  AND ($5::timestamp IS NULL OR timestamp <= $5);
+WHERE charge_station_id = sqlc.arg('charge_station_id')
+  AND (sqlc.narg('evse_id')::int IS NULL OR evse_id = sqlc.narg('evse_id')::int)
+  AND (sqlc.narg('transaction_id')::text IS NULL OR transaction_id = sqlc.narg('transaction_id')::text)
+  AND (sqlc.narg('start_time')::timestamp IS NULL OR timestamp >= sqlc.narg('start_time')::timestamp)
+  AND (sqlc.narg('end_time')::timestamp IS NULL OR timestamp <= sqlc.narg('end_time')::timestamp);
+
+-- name: UpsertMeterValueRollup :exec
+INSERT INTO meter_value_rollups (charge_station_id, evse_id, bucket_interval, bucket_start, sample_count,
+    energy_first, energy_first_at, energy_last, energy_last_at, max_power, voltage_sum, voltage_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (charge_station_id, bucket_interval, evse_id, bucket_start) DO UPDATE SET
+    sample_count = meter_value_rollups.sample_count + EXCLUDED.sample_count,
+    energy_first = CASE WHEN meter_value_rollups.energy_first_at IS NULL OR EXCLUDED.energy_first_at < meter_value_rollups.energy_first_at
+        THEN EXCLUDED.energy_first ELSE meter_value_rollups.energy_first END,
+    energy_first_at = LEAST(meter_value_rollups.energy_first_at, EXCLUDED.energy_first_at),
+    energy_last = CASE WHEN meter_value_rollups.energy_last_at IS NULL OR EXCLUDED.energy_last_at >= meter_value_rollups.energy_last_at
+        THEN EXCLUDED.energy_last ELSE meter_value_rollups.energy_last END,
+    energy_last_at = GREATEST(meter_value_rollups.energy_last_at, EXCLUDED.energy_last_at),
+    max_power = GREATEST(meter_value_rollups.max_power, EXCLUDED.max_power),
+    voltage_sum = meter_value_rollups.voltage_sum + EXCLUDED.voltage_sum,
+    voltage_count = meter_value_rollups.voltage_count + EXCLUDED.voltage_count;
+
+-- name: QueryMeterValueRollups :many
+SELECT * FROM meter_value_rollups
+WHERE charge_station_id = $1 AND bucket_interval = $2
+  AND (sqlc.narg('evse_id')::int IS NULL OR evse_id = sqlc.narg('evse_id')::int)
+  AND (sqlc.narg('start_time')::timestamp IS NULL OR bucket_start >= sqlc.narg('start_time')::timestamp)
+  AND (sqlc.narg('end_time')::timestamp IS NULL OR bucket_start <= sqlc.narg('end_time')::timestamp)
+ORDER BY evse_id, bucket_start;
@@ -35,3 +35,9 @@ RETURNING *;
 SELECT * FROM ocpi_parties
 WHERE role = $1
 ORDER BY created_at DESC;
+
+-- name: ListOcpiRegistrationTokens :many
+SELECT token FROM ocpi_registrations
+WHERE token > $1
+ORDER BY token ASC
+LIMIT $2;
@@ -6,10 +6,10 @@ INSERT INTO reservations (
 -- name: GetReservation :one
 SELECT * FROM reservations WHERE reservation_id = $1;
 
--- name: CancelReservation :exec
+-- name: CancelReservation :execrows
 UPDATE reservations SET status = 'Cancelled' WHERE reservation_id = $1;
 
--- name: UpdateReservationStatus :exec
+-- name: UpdateReservationStatus :execrows
 UPDATE reservations SET status = $2 WHERE reservation_id = $1;
 
 -- name: GetActiveReservations :many
@@ -25,3 +25,9 @@ LIMIT 1;
 -- name: ExpireReservations :execrows
 UPDATE reservations SET status = 'Expired'
 WHERE status = 'Accepted' AND expiry_date < NOW();
+
+-- name: ListReservations :many
+SELECT * FROM reservations
+WHERE reservation_id > $1
+ORDER BY reservation_id ASC
+LIMIT $2;
@@ -0,0 +1,35 @@
+-- name: DeleteMeterValuesBefore :execrows
+DELETE FROM meter_values
+WHERE id IN (
+    SELECT mv.id FROM meter_values mv
+    WHERE mv.timestamp < $1
+    ORDER BY mv.timestamp
+    LIMIT $2
+);
+
+-- name: DeleteChargeStationEventsBefore :execrows
+DELETE FROM charge_station_event
+WHERE id IN (
+    SELECT e.id FROM charge_station_event e
+    WHERE e.timestamp < $1
+    ORDER BY e.timestamp
+    LIMIT $2
+);
+
+-- name: DeleteDeviceReportsBefore :execrows
+DELETE FROM device_report
+WHERE id IN (
+    SELECT r.id FROM device_report r
+    WHERE r.generated_at < $1
+    ORDER BY r.generated_at
+    LIMIT $2
+);
+
+-- name: DeleteFinishedTransactionsBefore :execrows
+DELETE FROM transactions
+WHERE id IN (
+    SELECT t.id FROM transactions t
+    WHERE t.stop_timestamp < $1
+    ORDER BY t.stop_timestamp
+    LIMIT $2
+);
@@ -28,7 +28,9 @@ SELECT
     model,
     vendor,
     serial_number,
-    updated_at
+    updated_at,
+    last_connected,
+    last_disconnected
 FROM charge_station_status
 WHERE charge_station_id = $1;
 
@@ -40,6 +42,23 @@ ON CONFLICT (charge_station_id) DO UPDATE SET
     last_heartbeat = EXCLUDED.last_heartbeat,
     updated_at = NOW();
 
+-- name: SetChargeStationConnected :exec
+INSERT INTO charge_station_status (charge_station_id, connected, last_connected, last_disconnected, updated_at)
+VALUES (
+    @charge_station_id,
+    @connected::boolean,
+    CASE WHEN @connected::boolean THEN @timestamp::timestamptz END,
+    CASE WHEN NOT @connected::boolean THEN @timestamp::timestamptz END,
+    NOW()
+)
+ON CONFLICT (charge_station_id) DO UPDATE SET
+    connected = EXCLUDED.connected,
+    last_connected = COALESCE(EXCLUDED.last_connected, charge_station_status.last_connected),
+    last_disconnected = COALESCE(EXCLUDED.last_disconnected, charge_station_status.last_disconnected),
+    updated_at = NOW()
+WHERE GREATEST(charge_station_status.last_connected, charge_station_status.last_disconnected) IS NULL
+    OR GREATEST(charge_station_status.last_connected, charge_station_status.last_disconnected) <= @timestamp::timestamptz;
+
 -- name: UpsertConnectorStatus :exec
 INSERT INTO connector_status (
     charge_station_id,
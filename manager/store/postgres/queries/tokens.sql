@@ -4,7 +4,7 @@ WHERE uid = $1 LIMIT 1;
 
 -- name: ListTokens :many
 SELECT * FROM tokens
-ORDER BY created_at DESC
+ORDER BY uid ASC
 LIMIT $1 OFFSET $2;
 
 -- name: CreateToken :one
@@ -14,16 +14,32 @@ LIMIT 1;
 -- name: CreateTransaction :one
 INSERT INTO transactions (
     id, charge_station_id, token_uid, token_type,
-    meter_start, start_timestamp, offline
-) VALUES ($1, $2, $3, $4, $5, $6, $7)
+    meter_start, start_timestamp, offline, start_seq_no
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE
+SET token_uid = EXCLUDED.token_uid,
+    token_type = EXCLUDED.token_type,
+    meter_start = EXCLUDED.meter_start,
+    start_timestamp = EXCLUDED.start_timestamp,
+    offline = EXCLUDED.offline,
+    start_seq_no = EXCLUDED.start_seq_no,
+    updated_at = NOW()
 RETURNING *;
 
+-- name: EnsureTransaction :exec
+INSERT INTO transactions (
+    id, charge_station_id, token_uid, token_type,
+    meter_start, start_timestamp
+) VALUES ($1, $2, $3, $4, 0, $5)
+ON CONFLICT (id) DO NOTHING;
+
 -- name: UpdateTransaction :one
 UPDATE transactions
 SET meter_stop = $2,
     stop_timestamp = $3,
     stopped_reason = $4,
     updated_seq_no = $5,
+    ended_seq_no = $6,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -46,7 +62,7 @@ WHERE charge_station_id = $1
         OR sqlc.narg('status')::text = 'all')
     AND (sqlc.narg('start_date')::timestamp IS NULL OR start_timestamp >= sqlc.narg('start_date')::timestamp)
     AND (sqlc.narg('end_date')::timestamp IS NULL OR start_timestamp <= sqlc.narg('end_date')::timestamp)
-ORDER BY start_timestamp DESC
+ORDER BY start_timestamp DESC, id
 LIMIT $2 OFFSET $3;
 
 -- name: CountTransactionsFiltered :one
@@ -44,21 +44,27 @@ func (s *Store) GetReservation(ctx context.Context, reservationId int) (*store.R
 }
 
 func (s *Store) CancelReservation(ctx context.Context, reservationId int) error {
-	err := s.writeQueries().CancelReservation(ctx, int32(reservationId))
+	rows, err := s.writeQueries().CancelReservation(ctx, int32(reservationId))
 	if err != nil {
 		return fmt.Errorf("failed to cancel reservation: %w", err)
 	}
+	if rows == 0 {
+		return fmt.Errorf("reservation %d not found", reservationId)
+	}
 	return nil
 }
 
 func (s *Store) UpdateReservationStatus(ctx context.Context, reservationId int, status store.ReservationStatus) error {
-	err := s.writeQueries().UpdateReservationStatus(ctx, UpdateReservationStatusParams{
+	rows, err := s.writeQueries().UpdateReservationStatus(ctx, UpdateReservationStatusParams{
 		ReservationID: int32(reservationId),
 		Status:        string(status),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to update reservation status: %w", err)
 	}
+	if rows == 0 {
+		return fmt.Errorf("reservation %d not found", reservationId)
+	}
 	return nil
 }
 
@@ -11,13 +11,16 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const CancelReservation = `-- name: CancelReservation :exec
+const CancelReservation = `-- name: CancelReservation :execrows
 UPDATE reservations SET status = 'Cancelled' WHERE reservation_id = $1
 `
 
-func (q *Queries) CancelReservation(ctx context.Context, reservationID int32) error {
-	_, err := q.db.Exec(ctx, CancelReservation, reservationID)
-	return err
+func (q *Queries) CancelReservation(ctx context.Context, reservationID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, CancelReservation, reservationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const CreateReservation = `-- name: CreateReservation :exec
@@ -146,7 +149,48 @@ func (q *Queries) GetReservationByConnector(ctx context.Context, arg GetReservat
 	return i, err
 }
 
-const UpdateReservationStatus = `-- name: UpdateReservationStatus :exec
+const ListReservations = `-- name: ListReservations :many
+SELECT reservation_id, charge_station_id, connector_id, id_tag, parent_id_tag, expiry_date, status, created_at FROM reservations
+WHERE reservation_id > $1
+ORDER BY reservation_id ASC
+LIMIT $2
+`
+
+type ListReservationsParams struct {
+	ReservationID int32 `db:"reservation_id" json:"reservation_id"`
+	Limit         int32 `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListReservations(ctx context.Context, arg ListReservationsParams) ([]Reservation, error) {
+	rows, err := q.db.Query(ctx, ListReservations, arg.ReservationID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Reservation{}
+	for rows.Next() {
+		var i Reservation
+		if err := rows.Scan(
+			&i.ReservationID,
+			&i.ChargeStationID,
+			&i.ConnectorID,
+			&i.IDTag,
+			&i.ParentIDTag,
+			&i.ExpiryDate,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const UpdateReservationStatus = `-- name: UpdateReservationStatus :execrows
 UPDATE reservations SET status = $2 WHERE reservation_id = $1
 `
 
@@ -155,7 +199,10 @@ type UpdateReservationStatusParams struct {
 	Status        string `db:"status" json:"status"`
 }
 
-func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) error {
-	_, err := q.db.Exec(ctx, UpdateReservationStatus, arg.ReservationID, arg.Status)
-	return err
+func (q *Queries) UpdateReservationStatus(ctx context.Context, arg UpdateReservationStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, UpdateReservationStatus, arg.ReservationID, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
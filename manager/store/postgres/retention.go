@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) DeleteMeterValuesBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	limitInt32, err := safeIntToInt32(limit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit value: %w", err)
+	}
+
+	deleted, err := s.writeQueries().DeleteMeterValuesBefore(ctx, DeleteMeterValuesBeforeParams{
+		Timestamp: pgtype.Timestamp{Time: before.UTC(), Valid: true},
+		Limit:     limitInt32,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete meter values: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (s *Store) DeleteChargeStationEventsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	limitInt32, err := safeIntToInt32(limit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit value: %w", err)
+	}
+
+	deleted, err := s.writeQueries().DeleteChargeStationEventsBefore(ctx, DeleteChargeStationEventsBeforeParams{
+		Timestamp: pgtype.Timestamptz{Time: before, Valid: true},
+		Limit:     limitInt32,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete charge station events: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func (s *Store) DeleteDeviceReportsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	limitInt32, err := safeIntToInt32(limit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit value: %w", err)
+	}
+
+	deleted, err := s.writeQueries().DeleteDeviceReportsBefore(ctx, DeleteDeviceReportsBeforeParams{
+		GeneratedAt: pgtype.Timestamptz{Time: before, Valid: true},
+		Limit:       limitInt32,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete device reports: %w", err)
+	}
+	return int(deleted), nil
+}
+
+// DeleteFinishedTransactionsBefore dates each transaction by its stop timestamp, which is the
+// time of its last meter value or, if the transaction ended without one, when it ended. The
+// meter values of the transactions are deleted with them.
+func (s *Store) DeleteFinishedTransactionsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	limitInt32, err := safeIntToInt32(limit)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit value: %w", err)
+	}
+
+	deleted, err := s.writeQueries().DeleteFinishedTransactionsBefore(ctx, DeleteFinishedTransactionsBeforeParams{
+		StopTimestamp: pgtype.Timestamp{Time: before.UTC(), Valid: true},
+		Limit:         limitInt32,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished transactions: %w", err)
+	}
+	return int(deleted), nil
+}
@@ -58,7 +58,7 @@ func (s *Store) GetChargeStationStatus(ctx context.Context, chargeStationId stri
 	row, err := s.readQueries().GetChargeStationStatus(ctx, chargeStationId)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("charge station %s not found", chargeStationId)
+			return nil, fmt.Errorf("charge station %s %w", chargeStationId, store.ErrChargeStationStatusNotFound)
 		}
 		return nil, fmt.Errorf("failed to get charge station status: %w", err)
 	}
@@ -90,6 +90,16 @@ func (s *Store) GetChargeStationStatus(ctx context.Context, chargeStationId stri
 		status.SerialNumber = &row.SerialNumber.String
 	}
 
+	if row.LastConnected.Valid {
+		t := row.LastConnected.Time.UTC()
+		status.LastConnected = &t
+	}
+
+	if row.LastDisconnected.Valid {
+		t := row.LastDisconnected.Time.UTC()
+		status.LastDisconnected = &t
+	}
+
 	return status, nil
 }
 
@@ -104,6 +114,18 @@ func (s *Store) UpdateHeartbeat(ctx context.Context, chargeStationId string, tim
 	return nil
 }
 
+func (s *Store) SetChargeStationConnected(ctx context.Context, chargeStationId string, connected bool, timestamp time.Time) error {
+	err := s.writeQueries().SetChargeStationConnected(ctx, SetChargeStationConnectedParams{
+		ChargeStationID: chargeStationId,
+		Connected:       connected,
+		Timestamp:       pgtype.Timestamptz{Time: timestamp, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set charge station connected: %w", err)
+	}
+	return nil
+}
+
 func (s *Store) SetConnectorStatus(ctx context.Context, chargeStationId string, connectorId int, status *store.ConnectorStatus) error {
 	var info pgtype.Text
 	if status.Info != nil {
@@ -20,7 +20,9 @@ SELECT
     model,
     vendor,
     serial_number,
-    updated_at
+    updated_at,
+    last_connected,
+    last_disconnected
 FROM charge_station_status
 WHERE charge_station_id = $1
 `
@@ -37,6 +39,8 @@ func (q *Queries) GetChargeStationStatus(ctx context.Context, chargeStationID st
 		&i.Vendor,
 		&i.SerialNumber,
 		&i.UpdatedAt,
+		&i.LastConnected,
+		&i.LastDisconnected,
 	)
 	return i, err
 }
@@ -128,6 +132,35 @@ func (q *Queries) ListConnectorStatuses(ctx context.Context, chargeStationID str
 	return items, nil
 }
 
+const SetChargeStationConnected = `-- name: SetChargeStationConnected :exec
+INSERT INTO charge_station_status (charge_station_id, connected, last_connected, last_disconnected, updated_at)
+VALUES (
+    $1,
+    $2::boolean,
+    CASE WHEN $2::boolean THEN $3::timestamptz END,
+    CASE WHEN NOT $2::boolean THEN $3::timestamptz END,
+    NOW()
+)
+ON CONFLICT (charge_station_id) DO UPDATE SET
+    connected = EXCLUDED.connected,
+    last_connected = COALESCE(EXCLUDED.last_connected, charge_station_status.last_connected),
+    last_disconnected = COALESCE(EXCLUDED.last_disconnected, charge_station_status.last_disconnected),
+    updated_at = NOW()
+WHERE GREATEST(charge_station_status.last_connected, charge_station_status.last_disconnected) IS NULL
+    OR GREATEST(charge_station_status.last_connected, charge_station_status.last_disconnected) <= $3::timestamptz
+`
+
+type SetChargeStationConnectedParams struct {
+	ChargeStationID string             `db:"charge_station_id" json:"charge_station_id"`
+	Connected       bool               `db:"connected" json:"connected"`
+	Timestamp       pgtype.Timestamptz `db:"timestamp" json:"timestamp"`
+}
+
+func (q *Queries) SetChargeStationConnected(ctx context.Context, arg SetChargeStationConnectedParams) error {
+	_, err := q.db.Exec(ctx, SetChargeStationConnected, arg.ChargeStationID, arg.Connected, arg.Timestamp)
+	return err
+}
+
 const UpdateHeartbeat = `-- name: UpdateHeartbeat :exec
 INSERT INTO charge_station_status (charge_station_id, connected, last_heartbeat, updated_at)
 VALUES ($1, true, $2, NOW())
@@ -113,3 +113,41 @@ func TestUpdateHeartbeat(t *testing.T) {
 	require.NotNil(t, got.LastHeartbeat)
 	assert.Equal(t, now, got.LastHeartbeat.Truncate(time.Second))
 }
+
+func TestSetChargeStationConnected(t *testing.T) {
+	defer truncateAll(t)
+	ctx := context.Background()
+
+	err := testStore.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile: store.UnsecuredTransportWithBasicAuth,
+	})
+	require.NoError(t, err)
+
+	connectedAt := time.Now().UTC().Truncate(time.Second)
+	err = testStore.SetChargeStationConnected(ctx, "cs001", true, connectedAt)
+	require.NoError(t, err)
+
+	disconnectedAt := connectedAt.Add(time.Minute)
+	err = testStore.SetChargeStationConnected(ctx, "cs001", false, disconnectedAt)
+	require.NoError(t, err)
+
+	// a late event for an earlier connection is ignored
+	err = testStore.SetChargeStationConnected(ctx, "cs001", true, connectedAt.Add(time.Second))
+	require.NoError(t, err)
+
+	// a boot notification does not clear the connection times
+	err = testStore.SetChargeStationStatus(ctx, "cs001", &store.ChargeStationStatus{
+		ChargeStationId: "cs001",
+		Connected:       false,
+	})
+	require.NoError(t, err)
+
+	got, err := testStore.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.False(t, got.Connected)
+	require.NotNil(t, got.LastConnected)
+	assert.Equal(t, connectedAt, *got.LastConnected)
+	require.NotNil(t, got.LastDisconnected)
+	assert.Equal(t, disconnectedAt, *got.LastDisconnected)
+}
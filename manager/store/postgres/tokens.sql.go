@@ -112,7 +112,7 @@ func (q *Queries) GetToken(ctx context.Context, uid string) (Token, error) {
 
 const ListTokens = `-- name: ListTokens :many
 SELECT id, country_code, party_id, type, uid, contract_id, visual_number, issuer, group_id, valid, language_code, cache_mode, last_updated, created_at, updated_at FROM tokens
-ORDER BY created_at DESC
+ORDER BY uid ASC
 LIMIT $1 OFFSET $2
 `
 
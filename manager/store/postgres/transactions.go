@@ -94,6 +94,7 @@ func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transact
 		MeterStart:      meterStart,
 		StartTimestamp:  timestampFromTime(startTimestamp),
 		Offline:         offline,
+		StartSeqNo:      int32(seqNo),
 	}
 
 	_, err := s.writeQueries().CreateTransaction(ctx, params)
@@ -111,19 +112,46 @@ func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transact
 	return nil
 }
 
-// UpdateTransaction updates a transaction with additional meter values
-func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
-	// Verify transaction exists and belongs to charge station
-	txn, err := s.readQueries().GetTransaction(ctx, transactionId)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return fmt.Errorf("transaction not found")
+// ensureTransaction returns the transaction, creating it if it does not exist: messages
+// for a transaction can be received out of order, so an update or end may arrive first
+func (s *Store) ensureTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue) (*Transaction, error) {
+	startTimestamp := time.Now()
+	if len(meterValues) > 0 {
+		parsedTime, err := time.Parse(time.RFC3339, meterValues[0].Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp in meter value: %w", err)
 		}
-		return fmt.Errorf("failed to get transaction: %w", err)
+		startTimestamp = parsedTime
+	}
+
+	err := s.writeQueries().EnsureTransaction(ctx, EnsureTransactionParams{
+		ID:              transactionId,
+		ChargeStationID: chargeStationId,
+		TokenUid:        idToken,
+		TokenType:       tokenType,
+		StartTimestamp:  timestampFromTime(startTimestamp),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	txn, err := s.writeQueries().GetTransaction(ctx, transactionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
 	if txn.ChargeStationID != chargeStationId {
-		return fmt.Errorf("transaction does not belong to charge station")
+		return nil, fmt.Errorf("transaction does not belong to charge station")
+	}
+
+	return &txn, nil
+}
+
+// UpdateTransaction updates a transaction with additional meter values
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
+	txn, err := s.ensureTransaction(ctx, chargeStationId, transactionId, "", "", meterValues)
+	if err != nil {
+		return err
 	}
 
 	// Add meter values
@@ -140,6 +168,7 @@ func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transact
 		StopTimestamp: txn.StopTimestamp,
 		StoppedReason: txn.StoppedReason,
 		UpdatedSeqNo:  txn.UpdatedSeqNo + 1,
+		EndedSeqNo:    txn.EndedSeqNo,
 	}
 
 	_, err = s.writeQueries().UpdateTransaction(ctx, updateParams)
@@ -152,17 +181,9 @@ func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transact
 
 // EndTransaction ends a transaction with final meter values
 func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
-	// Verify transaction exists and belongs to charge station
-	txn, err := s.readQueries().GetTransaction(ctx, transactionId)
+	txn, err := s.ensureTransaction(ctx, chargeStationId, transactionId, idToken, tokenType, meterValues)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return fmt.Errorf("transaction not found")
-		}
-		return fmt.Errorf("failed to get transaction: %w", err)
-	}
-
-	if txn.ChargeStationID != chargeStationId {
-		return fmt.Errorf("transaction does not belong to charge station")
+		return err
 	}
 
 	// Extract meter stop from last meter value if available
@@ -201,7 +222,8 @@ func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transaction
 		MeterStop:     pgtype.Int4{Int32: meterStop, Valid: true},
 		StopTimestamp: pgtype.Timestamp{Time: stopTimestamp, Valid: true},
 		StoppedReason: pgtype.Text{String: "Remote", Valid: true},
-		UpdatedSeqNo:  txn.UpdatedSeqNo + 1,
+		UpdatedSeqNo:  txn.UpdatedSeqNo,
+		EndedSeqNo:    int32(seqNo),
 	}
 
 	_, err = s.writeQueries().UpdateTransaction(ctx, updateParams)
@@ -238,6 +260,10 @@ func (s *Store) addMeterValue(ctx context.Context, transactionId string, mv *sto
 // UpdateTransactionCost stores the most recent running cost communicated via CostUpdated.
 // Uses a direct query rather than sqlc-generated code since last_cost was added via migration.
 func (s *Store) UpdateTransactionCost(ctx context.Context, chargeStationId, transactionId string, totalCost float64) error {
+	if _, err := s.ensureTransaction(ctx, chargeStationId, transactionId, "", "", nil); err != nil {
+		return err
+	}
+
 	_, err := s.writePool().Exec(ctx,
 		`UPDATE transactions SET last_cost = $1, updated_at = NOW() WHERE id = $2 AND charge_station_id = $3`,
 		totalCost, transactionId, chargeStationId)
@@ -321,13 +347,6 @@ func (s *Store) toStoreTransaction(ctx context.Context, txn *Transaction) (*stor
 		}
 	}
 
-	// Calculate sequence numbers
-	startSeqNo := 0
-	endedSeqNo := 0
-	if txn.StopTimestamp.Valid {
-		endedSeqNo = int(txn.UpdatedSeqNo)
-	}
-
 	var lastCost *float64
 	if txn.LastCost.Valid {
 		f, _ := txn.LastCost.Float64Value()
@@ -342,8 +361,8 @@ func (s *Store) toStoreTransaction(ctx context.Context, txn *Transaction) (*stor
 		IdToken:           txn.TokenUid,
 		TokenType:         txn.TokenType,
 		MeterValues:       meterValues,
-		StartSeqNo:        startSeqNo,
-		EndedSeqNo:        endedSeqNo,
+		StartSeqNo:        int(txn.StartSeqNo),
+		EndedSeqNo:        int(txn.EndedSeqNo),
 		UpdatedSeqNoCount: int(txn.UpdatedSeqNo),
 		Offline:           txn.Offline,
 		LastCost:          lastCost,
@@ -60,9 +60,17 @@ func (q *Queries) CountTransactionsFiltered(ctx context.Context, arg CountTransa
 const CreateTransaction = `-- name: CreateTransaction :one
 INSERT INTO transactions (
     id, charge_station_id, token_uid, token_type,
-    meter_start, start_timestamp, offline
-) VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost
+    meter_start, start_timestamp, offline, start_seq_no
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE
+SET token_uid = EXCLUDED.token_uid,
+    token_type = EXCLUDED.token_type,
+    meter_start = EXCLUDED.meter_start,
+    start_timestamp = EXCLUDED.start_timestamp,
+    offline = EXCLUDED.offline,
+    start_seq_no = EXCLUDED.start_seq_no,
+    updated_at = NOW()
+RETURNING id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no
 `
 
 type CreateTransactionParams struct {
@@ -73,6 +81,7 @@ type CreateTransactionParams struct {
 	MeterStart      int32            `db:"meter_start" json:"meter_start"`
 	StartTimestamp  pgtype.Timestamp `db:"start_timestamp" json:"start_timestamp"`
 	Offline         bool             `db:"offline" json:"offline"`
+	StartSeqNo      int32            `db:"start_seq_no" json:"start_seq_no"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -84,6 +93,7 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.MeterStart,
 		arg.StartTimestamp,
 		arg.Offline,
+		arg.StartSeqNo,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -101,12 +111,41 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastCost,
+		&i.StartSeqNo,
+		&i.EndedSeqNo,
 	)
 	return i, err
 }
 
+const EnsureTransaction = `-- name: EnsureTransaction :exec
+INSERT INTO transactions (
+    id, charge_station_id, token_uid, token_type,
+    meter_start, start_timestamp
+) VALUES ($1, $2, $3, $4, 0, $5)
+ON CONFLICT (id) DO NOTHING
+`
+
+type EnsureTransactionParams struct {
+	ID              string           `db:"id" json:"id"`
+	ChargeStationID string           `db:"charge_station_id" json:"charge_station_id"`
+	TokenUid        string           `db:"token_uid" json:"token_uid"`
+	TokenType       string           `db:"token_type" json:"token_type"`
+	StartTimestamp  pgtype.Timestamp `db:"start_timestamp" json:"start_timestamp"`
+}
+
+func (q *Queries) EnsureTransaction(ctx context.Context, arg EnsureTransactionParams) error {
+	_, err := q.db.Exec(ctx, EnsureTransaction,
+		arg.ID,
+		arg.ChargeStationID,
+		arg.TokenUid,
+		arg.TokenType,
+		arg.StartTimestamp,
+	)
+	return err
+}
+
 const FindActiveTransaction = `-- name: FindActiveTransaction :one
-SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost FROM transactions 
+SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no FROM transactions 
 WHERE charge_station_id = $1 AND stop_timestamp IS NULL
 ORDER BY start_timestamp DESC
 LIMIT 1
@@ -130,6 +169,8 @@ func (q *Queries) FindActiveTransaction(ctx context.Context, chargeStationID str
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastCost,
+		&i.StartSeqNo,
+		&i.EndedSeqNo,
 	)
 	return i, err
 }
@@ -167,7 +208,7 @@ func (q *Queries) GetMeterValues(ctx context.Context, transactionID string) ([]T
 }
 
 const GetTransaction = `-- name: GetTransaction :one
-SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost FROM transactions WHERE id = $1
+SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no FROM transactions WHERE id = $1
 `
 
 func (q *Queries) GetTransaction(ctx context.Context, id string) (Transaction, error) {
@@ -188,12 +229,14 @@ func (q *Queries) GetTransaction(ctx context.Context, id string) (Transaction, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastCost,
+		&i.StartSeqNo,
+		&i.EndedSeqNo,
 	)
 	return i, err
 }
 
 const ListTransactions = `-- name: ListTransactions :many
-SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost FROM transactions
+SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no FROM transactions
 ORDER BY start_timestamp DESC
 `
 
@@ -221,6 +264,8 @@ func (q *Queries) ListTransactions(ctx context.Context) ([]Transaction, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastCost,
+			&i.StartSeqNo,
+			&i.EndedSeqNo,
 		); err != nil {
 			return nil, err
 		}
@@ -233,7 +278,7 @@ func (q *Queries) ListTransactions(ctx context.Context) ([]Transaction, error) {
 }
 
 const ListTransactionsFiltered = `-- name: ListTransactionsFiltered :many
-SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost FROM transactions
+SELECT id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no FROM transactions
 WHERE charge_station_id = $1
     AND ($4::text IS NULL 
         OR ($4::text = 'active' AND stop_timestamp IS NULL)
@@ -241,7 +286,7 @@ WHERE charge_station_id = $1
         OR $4::text = 'all')
     AND ($5::timestamp IS NULL OR start_timestamp >= $5::timestamp)
     AND ($6::timestamp IS NULL OR start_timestamp <= $6::timestamp)
-ORDER BY start_timestamp DESC
+ORDER BY start_timestamp DESC, id
 LIMIT $2 OFFSET $3
 `
 
@@ -285,6 +330,8 @@ func (q *Queries) ListTransactionsFiltered(ctx context.Context, arg ListTransact
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastCost,
+			&i.StartSeqNo,
+			&i.EndedSeqNo,
 		); err != nil {
 			return nil, err
 		}
@@ -302,9 +349,10 @@ SET meter_stop = $2,
     stop_timestamp = $3,
     stopped_reason = $4,
     updated_seq_no = $5,
+    ended_seq_no = $6,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost
+RETURNING id, charge_station_id, token_uid, token_type, meter_start, meter_stop, start_timestamp, stop_timestamp, stopped_reason, updated_seq_no, offline, created_at, updated_at, last_cost, start_seq_no, ended_seq_no
 `
 
 type UpdateTransactionParams struct {
@@ -313,6 +361,7 @@ type UpdateTransactionParams struct {
 	StopTimestamp pgtype.Timestamp `db:"stop_timestamp" json:"stop_timestamp"`
 	StoppedReason pgtype.Text      `db:"stopped_reason" json:"stopped_reason"`
 	UpdatedSeqNo  int32            `db:"updated_seq_no" json:"updated_seq_no"`
+	EndedSeqNo    int32            `db:"ended_seq_no" json:"ended_seq_no"`
 }
 
 func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error) {
@@ -322,6 +371,7 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		arg.StopTimestamp,
 		arg.StoppedReason,
 		arg.UpdatedSeqNo,
+		arg.EndedSeqNo,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -339,6 +389,8 @@ func (q *Queries) UpdateTransaction(ctx context.Context, arg UpdateTransactionPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastCost,
+		&i.StartSeqNo,
+		&i.EndedSeqNo,
 	)
 	return i, err
 }
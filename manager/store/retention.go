@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionStore defines the interface for removing the records of the high-volume stores once
+// they are older than they need to be kept. Each method deletes at most limit records that are
+// dated before the given time, oldest first, and returns the number of records that were deleted
+// so that the caller can work through a backlog in batches.
+type RetentionStore interface {
+	// DeleteMeterValuesBefore deletes the meter values that were sampled before the given time
+	DeleteMeterValuesBefore(ctx context.Context, before time.Time, limit int) (int, error)
+	// DeleteChargeStationEventsBefore deletes the events that occurred before the given time
+	DeleteChargeStationEventsBefore(ctx context.Context, before time.Time, limit int) (int, error)
+	// DeleteDeviceReportsBefore deletes the device reports that were generated before the given time
+	DeleteDeviceReportsBefore(ctx context.Context, before time.Time, limit int) (int, error)
+	// DeleteFinishedTransactionsBefore deletes the transactions that ended before the given time.
+	// A transaction is dated by its last meter value unless the backend records when it ended:
+	// transactions that have not ended are never deleted.
+	DeleteFinishedTransactionsBefore(ctx context.Context, before time.Time, limit int) (int, error)
+}
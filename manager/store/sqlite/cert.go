@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+func (s *Store) SetCertificate(ctx context.Context, pemCertificate string) error {
+	b64Hash, err := getPEMCertificateHash(pemCertificate)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO certificate (certificate_hash, pem_data) VALUES (?, ?)
+			ON CONFLICT (certificate_hash) DO UPDATE SET pem_data = excluded.pem_data`,
+		b64Hash, pemCertificate)
+	if err != nil {
+		return fmt.Errorf("set certificate %s: %w", b64Hash, err)
+	}
+	return nil
+}
+
+func getPEMCertificateHash(pemCertificate string) (string, error) {
+	block, _ := pem.Decode([]byte(pemCertificate))
+	if block == nil {
+		return "", fmt.Errorf("pem block not found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("pem block does not contain certificate, but %s", block.Type)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+func (s *Store) LookupCertificate(ctx context.Context, certificateHash string) (string, error) {
+	var pemCertificate string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT pem_data FROM certificate WHERE certificate_hash = ?`, certificateHash).Scan(&pemCertificate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("lookup certificate %s: %w", certificateHash, err)
+	}
+	return pemCertificate, nil
+}
+
+func (s *Store) DeleteCertificate(ctx context.Context, certificateHash string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM certificate WHERE certificate_hash = ?`, certificateHash)
+	if err != nil {
+		return fmt.Errorf("delete certificate %s: %w", certificateHash, err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// chargingProfileFilter returns the where clause and arguments that select a charge station's
+// charging profiles that match the given (optional) filters
+func chargingProfileFilter(chargeStationId string, profileId *int, connectorId *int, purpose *store.ChargingProfilePurpose, stackLevel *int) (string, []any) {
+	where := `WHERE charge_station_id = ?`
+	args := []any{chargeStationId}
+	if profileId != nil {
+		where += ` AND charging_profile_id = ?`
+		args = append(args, *profileId)
+	}
+	if connectorId != nil {
+		where += ` AND connector_id = ?`
+		args = append(args, *connectorId)
+	}
+	if purpose != nil {
+		where += ` AND purpose = ?`
+		args = append(args, string(*purpose))
+	}
+	if stackLevel != nil {
+		where += ` AND stack_level = ?`
+		args = append(args, *stackLevel)
+	}
+	return where, args
+}
+
+func (s *Store) SetChargingProfile(ctx context.Context, profile *store.ChargingProfile) error {
+	data, err := encode(profile)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO charging_profile (charging_profile_id, charge_station_id, connector_id, purpose, stack_level, data)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (charging_profile_id) DO UPDATE SET
+				charge_station_id = excluded.charge_station_id,
+				connector_id = excluded.connector_id,
+				purpose = excluded.purpose,
+				stack_level = excluded.stack_level,
+				data = excluded.data`,
+		profile.ChargingProfileId, profile.ChargeStationId, profile.ConnectorId,
+		string(profile.ChargingProfilePurpose), profile.StackLevel, data)
+	if err != nil {
+		return fmt.Errorf("set charging profile %d: %w", profile.ChargingProfileId, err)
+	}
+	return nil
+}
+
+func (s *Store) GetChargingProfiles(ctx context.Context, chargeStationId string, connectorId *int, purpose *store.ChargingProfilePurpose, stackLevel *int) ([]*store.ChargingProfile, error) {
+	where, args := chargingProfileFilter(chargeStationId, nil, connectorId, purpose, stackLevel)
+	profiles, err := listRecords[store.ChargingProfile](ctx, s.db,
+		`SELECT data FROM charging_profile `+where+` ORDER BY stack_level, charging_profile_id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get charging profiles for %s: %w", chargeStationId, err)
+	}
+	return profiles, nil
+}
+
+func (s *Store) ClearChargingProfile(ctx context.Context, chargeStationId string, profileId *int, connectorId *int, purpose *store.ChargingProfilePurpose, stackLevel *int) (int, error) {
+	where, args := chargingProfileFilter(chargeStationId, profileId, connectorId, purpose, stackLevel)
+	result, err := s.db.ExecContext(ctx, `DELETE FROM charging_profile `+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("clear charging profiles for %s: %w", chargeStationId, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("clear charging profiles for %s: %w", chargeStationId, err)
+	}
+	return int(n), nil
+}
+
+func (s *Store) GetCompositeSchedule(ctx context.Context, chargeStationId string, connectorId int, duration int, chargingRateUnit *store.ChargingRateUnit) (*store.ChargingSchedule, error) {
+	// Collect applicable profiles for this connector (and connector 0 for defaults)
+	candidates, err := listRecords[store.ChargingProfile](ctx, s.db,
+		`SELECT data FROM charging_profile WHERE charge_station_id = ? AND connector_id IN (?, 0)`,
+		chargeStationId, connectorId)
+	if err != nil {
+		return nil, fmt.Errorf("get composite schedule for %s: %w", chargeStationId, err)
+	}
+
+	var profiles []*store.ChargingProfile
+	for _, p := range candidates {
+		if chargingRateUnit != nil && p.ChargingSchedule.ChargingRateUnit != *chargingRateUnit {
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+
+	// Sort by purpose priority: ChargePointMaxProfile > TxDefaultProfile > TxProfile
+	// Then by stack level (higher = higher priority)
+	purposePriority := map[store.ChargingProfilePurpose]int{
+		store.ChargingProfilePurposeChargePointMaxProfile: 3,
+		store.ChargingProfilePurposeTxDefaultProfile:      2,
+		store.ChargingProfilePurposeTxProfile:             1,
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		pi := purposePriority[profiles[i].ChargingProfilePurpose]
+		pj := purposePriority[profiles[j].ChargingProfilePurpose]
+		if pi != pj {
+			return pi > pj
+		}
+		return profiles[i].StackLevel > profiles[j].StackLevel
+	})
+
+	// As with the other stores, the highest priority profile's schedule is used as the
+	// composite schedule, limited to the requested duration
+	best := profiles[0]
+	now := s.clock.Now()
+
+	rateUnit := best.ChargingSchedule.ChargingRateUnit
+	if chargingRateUnit != nil {
+		rateUnit = *chargingRateUnit
+	}
+
+	return &store.ChargingSchedule{
+		Duration:               &duration,
+		StartSchedule:          &now,
+		ChargingRateUnit:       rateUnit,
+		ChargingSchedulePeriod: filterPeriodsForDuration(best.ChargingSchedule.ChargingSchedulePeriod, duration),
+		MinChargingRate:        best.ChargingSchedule.MinChargingRate,
+	}, nil
+}
+
+// filterPeriodsForDuration returns only the periods that fall within the given duration.
+func filterPeriodsForDuration(periods []store.ChargingSchedulePeriod, duration int) []store.ChargingSchedulePeriod {
+	result := make([]store.ChargingSchedulePeriod, 0)
+	for _, p := range periods {
+		if p.StartPeriod < duration {
+			result = append(result, p)
+		}
+	}
+	return result
+}
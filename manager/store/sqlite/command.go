@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func setCommand(ctx context.Context, q queryer, command *store.Command) error {
+	data, err := encode(command)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx,
+		`INSERT INTO command (id, charge_station_id, status, created_at, updated_at, data) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				charge_station_id = excluded.charge_station_id,
+				status = excluded.status,
+				created_at = excluded.created_at,
+				updated_at = excluded.updated_at,
+				data = excluded.data`,
+		command.Id, command.ChargeStationId, string(command.Status),
+		command.CreatedAt.UnixNano(), command.UpdatedAt.UnixNano(), data)
+	if err != nil {
+		return fmt.Errorf("set command %s: %w", command.Id, err)
+	}
+	return nil
+}
+
+func getCommand(ctx context.Context, q queryer, commandId string) (*store.Command, error) {
+	command, err := getRecord[store.Command](ctx, q, `SELECT data FROM command WHERE id = ?`, commandId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup command %s: %w", commandId, err)
+	}
+	return command, nil
+}
+
+// updateCommand applies fn to the command and stores the result: nothing is changed if there is
+// no such command or fn returns false
+func (s *Store) updateCommand(ctx context.Context, commandId string, fn func(command *store.Command) bool) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		command, err := getCommand(ctx, tx, commandId)
+		if err != nil {
+			return err
+		}
+		if command == nil || !fn(command) {
+			return nil
+		}
+		return setCommand(ctx, tx, command)
+	})
+}
+
+func (s *Store) CreateCommand(ctx context.Context, command *store.Command) error {
+	return setCommand(ctx, s.db, command)
+}
+
+func (s *Store) SetCommandSent(ctx context.Context, commandId string, sentAt time.Time) error {
+	return s.updateCommand(ctx, commandId, func(command *store.Command) bool {
+		if command.Status != store.CommandStatusQueued {
+			return false
+		}
+		command.Status = store.CommandStatusSent
+		command.UpdatedAt = sentAt
+		return true
+	})
+}
+
+func (s *Store) SetCommandResult(ctx context.Context, chargeStationId, commandId string, result *store.CommandResult) error {
+	return s.updateCommand(ctx, commandId, func(command *store.Command) bool {
+		if command.ChargeStationId != chargeStationId {
+			return false
+		}
+		command.Status = result.Status
+		command.Response = result.Response
+		command.ErrorCode = result.ErrorCode
+		command.ErrorDescription = result.ErrorDescription
+		command.UpdatedAt = result.UpdatedAt
+		return true
+	})
+}
+
+func (s *Store) LookupCommand(ctx context.Context, commandId string) (*store.Command, error) {
+	return getCommand(ctx, s.db, commandId)
+}
+
+func (s *Store) ListCommands(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.Command, int, error) {
+	total, err := count(ctx, s.db, `SELECT COUNT(*) FROM command WHERE charge_station_id = ?`, chargeStationId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count commands for %s: %w", chargeStationId, err)
+	}
+
+	commands, err := listRecords[store.Command](ctx, s.db,
+		`SELECT data FROM command WHERE charge_station_id = ? ORDER BY created_at DESC, id LIMIT ? OFFSET ?`,
+		chargeStationId, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list commands for %s: %w", chargeStationId, err)
+	}
+	return commands, total, nil
+}
+
+func (s *Store) TimeOutCommands(ctx context.Context, updatedBefore time.Time, timedOutAt time.Time) (int, error) {
+	timedOut := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		commands, err := listRecords[store.Command](ctx, tx,
+			`SELECT data FROM command WHERE status IN (?, ?) AND updated_at < ?`,
+			string(store.CommandStatusQueued), string(store.CommandStatusSent), updatedBefore.UnixNano())
+		if err != nil {
+			return fmt.Errorf("list outstanding commands: %w", err)
+		}
+		for _, command := range commands {
+			command.Status = store.CommandStatusTimedOut
+			command.UpdatedAt = timedOutAt
+			if err := setCommand(ctx, tx, command); err != nil {
+				return err
+			}
+		}
+		timedOut = len(commands)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return timedOut, nil
+}
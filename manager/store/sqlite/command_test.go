@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	clock2 "k8s.io/utils/clock"
+)
+
+func newCommand(id, chargeStationId string, createdAt time.Time) *store.Command {
+	return &store.Command{
+		Id:              id,
+		ChargeStationId: chargeStationId,
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusQueued,
+		Request:         `{"type":"Immediate"}`,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+}
+
+func TestCommandLifecycle(t *testing.T) {
+	s := newStore(t, clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	err := s.CreateCommand(ctx, newCommand("abc", "cs001", now))
+	require.NoError(t, err)
+
+	err = s.SetCommandSent(ctx, "abc", now.Add(time.Second))
+	require.NoError(t, err)
+
+	got, err := s.LookupCommand(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.CommandStatusSent, got.Status)
+	assert.Equal(t, now.Add(time.Second), got.UpdatedAt)
+
+	response := `{"status":"Accepted"}`
+	err = s.SetCommandResult(ctx, "cs001", "abc", &store.CommandResult{
+		Status:    store.CommandStatusAccepted,
+		Response:  &response,
+		UpdatedAt: now.Add(2 * time.Second),
+	})
+	require.NoError(t, err)
+
+	got, err = s.LookupCommand(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.CommandStatusAccepted, got.Status)
+	assert.Equal(t, &response, got.Response)
+	assert.Equal(t, now, got.CreatedAt)
+	assert.Equal(t, now.Add(2*time.Second), got.UpdatedAt)
+}
+
+func TestSetCommandSentDoesNotOverwriteResult(t *testing.T) {
+	s := newStore(t, clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	err := s.CreateCommand(ctx, newCommand("abc", "cs001", now))
+	require.NoError(t, err)
+	err = s.SetCommandResult(ctx, "cs001", "abc", &store.CommandResult{
+		Status:    store.CommandStatusAccepted,
+		UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	err = s.SetCommandSent(ctx, "abc", now.Add(time.Second))
+	require.NoError(t, err)
+
+	got, err := s.LookupCommand(ctx, "abc")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, store.CommandStatusAccepted, got.Status)
+}
+
+func TestLookupMissingCommand(t *testing.T) {
+	s := newStore(t, clock2.RealClock{})
+
+	got, err := s.LookupCommand(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestListCommands(t *testing.T) {
+	s := newStore(t, clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	require.NoError(t, s.CreateCommand(ctx, newCommand("a", "cs001", now)))
+	require.NoError(t, s.CreateCommand(ctx, newCommand("b", "cs001", now.Add(time.Second))))
+	require.NoError(t, s.CreateCommand(ctx, newCommand("c", "cs001", now.Add(2*time.Second))))
+	require.NoError(t, s.CreateCommand(ctx, newCommand("d", "cs002", now)))
+
+	commands, total, err := s.ListCommands(ctx, "cs001", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, commands, 2)
+	assert.Equal(t, "b", commands[0].Id)
+	assert.Equal(t, "a", commands[1].Id)
+
+	commands, total, err = s.ListCommands(ctx, "cs003", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Empty(t, commands)
+}
+
+func TestTimeOutCommands(t *testing.T) {
+	s := newStore(t, clock2.RealClock{})
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	require.NoError(t, s.CreateCommand(ctx, newCommand("old", "cs001", now.Add(-time.Hour))))
+	require.NoError(t, s.CreateCommand(ctx, newCommand("new", "cs001", now)))
+	require.NoError(t, s.CreateCommand(ctx, newCommand("answered", "cs001", now.Add(-time.Hour))))
+	require.NoError(t, s.SetCommandResult(ctx, "cs001", "answered", &store.CommandResult{
+		Status:    store.CommandStatusRejected,
+		UpdatedAt: now.Add(-time.Hour),
+	}))
+
+	count, err := s.TimeOutCommands(ctx, now.Add(-time.Minute), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	got, err := s.LookupCommand(ctx, "old")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusTimedOut, got.Status)
+	assert.Equal(t, now, got.UpdatedAt)
+
+	got, err = s.LookupCommand(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusQueued, got.Status)
+
+	got, err = s.LookupCommand(ctx, "answered")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusRejected, got.Status)
+}
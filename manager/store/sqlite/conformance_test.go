@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/storetest"
+	"k8s.io/utils/clock"
+)
+
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock clock.PassiveClock) store.Engine {
+		return newStore(t, clock)
+	})
+}
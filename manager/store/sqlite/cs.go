@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetChargeStationAuth(ctx context.Context, chargeStationId string, auth *store.ChargeStationAuth) error {
+	return setChargeStationRecord(ctx, s.db, "charge_station_auth", chargeStationId, auth)
+}
+
+func (s *Store) LookupChargeStationAuth(ctx context.Context, chargeStationId string) (*store.ChargeStationAuth, error) {
+	return lookupChargeStationRecord[store.ChargeStationAuth](ctx, s.db, "charge_station_auth", chargeStationId)
+}
+
+func (s *Store) UpdateChargeStationSettings(ctx context.Context, chargeStationId string, settings *store.ChargeStationSettings) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		set, err := lookupChargeStationRecord[store.ChargeStationSettings](ctx, tx, "charge_station_settings", chargeStationId)
+		if err != nil {
+			return err
+		}
+		if set == nil {
+			set = &store.ChargeStationSettings{
+				ChargeStationId: chargeStationId,
+				Settings:        make(map[string]*store.ChargeStationSetting, len(settings.Settings)),
+			}
+		}
+		for k, v := range settings.Settings {
+			set.Settings[k] = v
+		}
+		return setChargeStationRecord(ctx, tx, "charge_station_settings", chargeStationId, set)
+	})
+}
+
+func (s *Store) LookupChargeStationSettings(ctx context.Context, chargeStationId string) (*store.ChargeStationSettings, error) {
+	return lookupChargeStationRecord[store.ChargeStationSettings](ctx, s.db, "charge_station_settings", chargeStationId)
+}
+
+func (s *Store) ListChargeStationSettings(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSettings, error) {
+	return listChargeStationRecords[store.ChargeStationSettings](ctx, s.db, "charge_station_settings", pageSize, previousChargeStationId)
+}
+
+func (s *Store) DeleteChargeStationSettings(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_settings", chargeStationId)
+}
+
+func (s *Store) UpdateChargeStationInstallCertificates(ctx context.Context, chargeStationId string, certificates *store.ChargeStationInstallCertificates) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		certs, err := lookupChargeStationRecord[store.ChargeStationInstallCertificates](ctx, tx, "charge_station_install_certificates", chargeStationId)
+		if err != nil {
+			return err
+		}
+		if certs == nil {
+			certs = &store.ChargeStationInstallCertificates{
+				ChargeStationId: chargeStationId,
+			}
+		}
+		for _, v := range certificates.Certificates {
+			matched := false
+			for _, c := range certs.Certificates {
+				if v.CertificateId == c.CertificateId {
+					c.CertificateData = v.CertificateData
+					c.CertificateInstallationStatus = v.CertificateInstallationStatus
+					c.CertificateType = v.CertificateType
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				certs.Certificates = append(certs.Certificates, v)
+			}
+		}
+		return setChargeStationRecord(ctx, tx, "charge_station_install_certificates", chargeStationId, certs)
+	})
+}
+
+func (s *Store) LookupChargeStationInstallCertificates(ctx context.Context, chargeStationId string) (*store.ChargeStationInstallCertificates, error) {
+	return lookupChargeStationRecord[store.ChargeStationInstallCertificates](ctx, s.db, "charge_station_install_certificates", chargeStationId)
+}
+
+func (s *Store) ListChargeStationInstallCertificates(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstallCertificates, error) {
+	return listChargeStationRecords[store.ChargeStationInstallCertificates](ctx, s.db, "charge_station_install_certificates", pageSize, previousChargeStationId)
+}
+
+func (s *Store) SetChargeStationRuntimeDetails(ctx context.Context, chargeStationId string, details *store.ChargeStationRuntimeDetails) error {
+	return setChargeStationRecord(ctx, s.db, "charge_station_runtime_details", chargeStationId, details)
+}
+
+func (s *Store) LookupChargeStationRuntimeDetails(ctx context.Context, chargeStationId string) (*store.ChargeStationRuntimeDetails, error) {
+	return lookupChargeStationRecord[store.ChargeStationRuntimeDetails](ctx, s.db, "charge_station_runtime_details", chargeStationId)
+}
+
+func (s *Store) SetChargeStationTriggerMessage(ctx context.Context, chargeStationId string, triggerMessage *store.ChargeStationTriggerMessage) error {
+	return setChargeStationRecord(ctx, s.db, "charge_station_trigger_message", chargeStationId, triggerMessage)
+}
+
+func (s *Store) DeleteChargeStationTriggerMessage(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_trigger_message", chargeStationId)
+}
+
+func (s *Store) LookupChargeStationTriggerMessage(ctx context.Context, chargeStationId string) (*store.ChargeStationTriggerMessage, error) {
+	return lookupChargeStationRecord[store.ChargeStationTriggerMessage](ctx, s.db, "charge_station_trigger_message", chargeStationId)
+}
+
+func (s *Store) ListChargeStationTriggerMessages(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationTriggerMessage, error) {
+	return listChargeStationRecords[store.ChargeStationTriggerMessage](ctx, s.db, "charge_station_trigger_message", pageSize, previousChargeStationId)
+}
+
+func (s *Store) SetChargeStationDataTransfer(ctx context.Context, chargeStationId string, dataTransfer *store.ChargeStationDataTransfer) error {
+	dt := *dataTransfer
+	dt.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "charge_station_data_transfer", chargeStationId, &dt)
+}
+
+func (s *Store) LookupChargeStationDataTransfer(ctx context.Context, chargeStationId string) (*store.ChargeStationDataTransfer, error) {
+	return lookupChargeStationRecord[store.ChargeStationDataTransfer](ctx, s.db, "charge_station_data_transfer", chargeStationId)
+}
+
+func (s *Store) ListChargeStationDataTransfers(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationDataTransfer, error) {
+	return listChargeStationRecords[store.ChargeStationDataTransfer](ctx, s.db, "charge_station_data_transfer", pageSize, previousChargeStationId)
+}
+
+func (s *Store) DeleteChargeStationDataTransfer(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_data_transfer", chargeStationId)
+}
+
+func (s *Store) SetChargeStationClearCache(ctx context.Context, chargeStationId string, clearCache *store.ChargeStationClearCache) error {
+	cc := *clearCache
+	cc.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "charge_station_clear_cache", chargeStationId, &cc)
+}
+
+func (s *Store) LookupChargeStationClearCache(ctx context.Context, chargeStationId string) (*store.ChargeStationClearCache, error) {
+	return lookupChargeStationRecord[store.ChargeStationClearCache](ctx, s.db, "charge_station_clear_cache", chargeStationId)
+}
+
+func (s *Store) ListChargeStationClearCaches(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationClearCache, error) {
+	return listChargeStationRecords[store.ChargeStationClearCache](ctx, s.db, "charge_station_clear_cache", pageSize, previousChargeStationId)
+}
+
+func (s *Store) DeleteChargeStationClearCache(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_clear_cache", chargeStationId)
+}
+
+func (s *Store) SetChargeStationChangeAvailability(ctx context.Context, chargeStationId string, changeAvailability *store.ChargeStationChangeAvailability) error {
+	ca := *changeAvailability
+	ca.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "charge_station_change_availability", chargeStationId, &ca)
+}
+
+func (s *Store) LookupChargeStationChangeAvailability(ctx context.Context, chargeStationId string) (*store.ChargeStationChangeAvailability, error) {
+	return lookupChargeStationRecord[store.ChargeStationChangeAvailability](ctx, s.db, "charge_station_change_availability", chargeStationId)
+}
+
+func (s *Store) ListChargeStationChangeAvailabilities(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationChangeAvailability, error) {
+	return listChargeStationRecords[store.ChargeStationChangeAvailability](ctx, s.db, "charge_station_change_availability", pageSize, previousChargeStationId)
+}
+
+func (s *Store) DeleteChargeStationChangeAvailability(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_change_availability", chargeStationId)
+}
+
+func (s *Store) SetResetRequest(ctx context.Context, chargeStationId string, request *store.ResetRequest) error {
+	request.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "reset_request", chargeStationId, request)
+}
+
+func (s *Store) GetResetRequest(ctx context.Context, chargeStationId string) (*store.ResetRequest, error) {
+	return lookupChargeStationRecord[store.ResetRequest](ctx, s.db, "reset_request", chargeStationId)
+}
+
+func (s *Store) DeleteResetRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "reset_request", chargeStationId)
+}
+
+func (s *Store) SetUnlockConnectorRequest(ctx context.Context, chargeStationId string, request *store.UnlockConnectorRequest) error {
+	request.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "unlock_connector_request", chargeStationId, request)
+}
+
+func (s *Store) GetUnlockConnectorRequest(ctx context.Context, chargeStationId string) (*store.UnlockConnectorRequest, error) {
+	return lookupChargeStationRecord[store.UnlockConnectorRequest](ctx, s.db, "unlock_connector_request", chargeStationId)
+}
+
+func (s *Store) DeleteUnlockConnectorRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "unlock_connector_request", chargeStationId)
+}
+
+func (s *Store) SetChargeStationCertificateQuery(ctx context.Context, chargeStationId string, query *store.ChargeStationCertificateQuery) error {
+	return setChargeStationRecord(ctx, s.db, "charge_station_certificate_query", chargeStationId, query)
+}
+
+func (s *Store) DeleteChargeStationCertificateQuery(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_certificate_query", chargeStationId)
+}
+
+func (s *Store) LookupChargeStationCertificateQuery(ctx context.Context, chargeStationId string) (*store.ChargeStationCertificateQuery, error) {
+	return lookupChargeStationRecord[store.ChargeStationCertificateQuery](ctx, s.db, "charge_station_certificate_query", chargeStationId)
+}
+
+func (s *Store) ListChargeStationCertificateQueries(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationCertificateQuery, error) {
+	return listChargeStationRecords[store.ChargeStationCertificateQuery](ctx, s.db, "charge_station_certificate_query", pageSize, previousChargeStationId)
+}
+
+func (s *Store) SetChargeStationCertificateDeletion(ctx context.Context, chargeStationId string, deletion *store.ChargeStationCertificateDeletion) error {
+	return setChargeStationRecord(ctx, s.db, "charge_station_certificate_deletion", chargeStationId, deletion)
+}
+
+func (s *Store) DeleteChargeStationCertificateDeletion(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "charge_station_certificate_deletion", chargeStationId)
+}
+
+func (s *Store) LookupChargeStationCertificateDeletion(ctx context.Context, chargeStationId string) (*store.ChargeStationCertificateDeletion, error) {
+	return lookupChargeStationRecord[store.ChargeStationCertificateDeletion](ctx, s.db, "charge_station_certificate_deletion", chargeStationId)
+}
+
+func (s *Store) ListChargeStationCertificateDeletions(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.ChargeStationCertificateDeletion, error) {
+	return listChargeStationRecords[store.ChargeStationCertificateDeletion](ctx, s.db, "charge_station_certificate_deletion", pageSize, previousChargeStationId)
+}
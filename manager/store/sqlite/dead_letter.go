@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) AddDeadLetter(ctx context.Context, deadLetter *store.DeadLetter) error {
+	data, err := encode(deadLetter)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO dead_letter (id, created_at, data) VALUES (?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET created_at = excluded.created_at, data = excluded.data`,
+		deadLetter.Id, deadLetter.CreatedAt.UnixNano(), data)
+	if err != nil {
+		return fmt.Errorf("add dead letter %s: %w", deadLetter.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupDeadLetter(ctx context.Context, deadLetterId string) (*store.DeadLetter, error) {
+	deadLetter, err := getRecord[store.DeadLetter](ctx, s.db, `SELECT data FROM dead_letter WHERE id = ?`, deadLetterId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup dead letter %s: %w", deadLetterId, err)
+	}
+	return deadLetter, nil
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, offset int, limit int) ([]*store.DeadLetter, int, error) {
+	total, err := count(ctx, s.db, `SELECT COUNT(*) FROM dead_letter`)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count dead letters: %w", err)
+	}
+
+	deadLetters, err := listRecords[store.DeadLetter](ctx, s.db,
+		`SELECT data FROM dead_letter ORDER BY created_at DESC, id LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list dead letters: %w", err)
+	}
+	return deadLetters, total, nil
+}
+
+func (s *Store) DeleteDeadLetter(ctx context.Context, deadLetterId string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM dead_letter WHERE id = ?`, deadLetterId)
+	if err != nil {
+		return fmt.Errorf("delete dead letter %s: %w", deadLetterId, err)
+	}
+	return nil
+}
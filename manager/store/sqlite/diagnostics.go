@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetDiagnosticsRequest(ctx context.Context, chargeStationId string, request *store.DiagnosticsRequest) error {
+	request.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "diagnostics_request", chargeStationId, request)
+}
+
+func (s *Store) GetDiagnosticsRequest(ctx context.Context, chargeStationId string) (*store.DiagnosticsRequest, error) {
+	return lookupChargeStationRecord[store.DiagnosticsRequest](ctx, s.db, "diagnostics_request", chargeStationId)
+}
+
+func (s *Store) DeleteDiagnosticsRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "diagnostics_request", chargeStationId)
+}
+
+func (s *Store) ListDiagnosticsRequests(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.DiagnosticsRequest, error) {
+	return listChargeStationRecords[store.DiagnosticsRequest](ctx, s.db, "diagnostics_request", pageSize, previousChargeStationId)
+}
+
+func (s *Store) SetLogRequest(ctx context.Context, chargeStationId string, request *store.LogRequest) error {
+	request.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "log_request", chargeStationId, request)
+}
+
+func (s *Store) GetLogRequest(ctx context.Context, chargeStationId string) (*store.LogRequest, error) {
+	return lookupChargeStationRecord[store.LogRequest](ctx, s.db, "log_request", chargeStationId)
+}
+
+func (s *Store) DeleteLogRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "log_request", chargeStationId)
+}
+
+func (s *Store) ListLogRequests(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.LogRequest, error) {
+	return listChargeStationRecords[store.LogRequest](ctx, s.db, "log_request", pageSize, previousChargeStationId)
+}
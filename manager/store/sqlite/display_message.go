@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetDisplayMessage(ctx context.Context, message *store.DisplayMessage) error {
+	data, err := encode(message)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO display_message (charge_station_id, id, data) VALUES (?, ?, ?)
+			ON CONFLICT (charge_station_id, id) DO UPDATE SET data = excluded.data`,
+		message.ChargeStationId, message.Id, data)
+	if err != nil {
+		return fmt.Errorf("set display message %d for %s: %w", message.Id, message.ChargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) GetDisplayMessage(ctx context.Context, chargeStationId string, messageId int) (*store.DisplayMessage, error) {
+	message, err := getRecord[store.DisplayMessage](ctx, s.db,
+		`SELECT data FROM display_message WHERE charge_station_id = ? AND id = ?`, chargeStationId, messageId)
+	if err != nil {
+		return nil, fmt.Errorf("get display message %d for %s: %w", messageId, chargeStationId, err)
+	}
+	return message, nil
+}
+
+func (s *Store) ListDisplayMessages(ctx context.Context, chargeStationId string, state *store.MessageState, priority *store.MessagePriority) ([]*store.DisplayMessage, error) {
+	messages, err := listRecords[store.DisplayMessage](ctx, s.db,
+		`SELECT data FROM display_message WHERE charge_station_id = ? ORDER BY id`, chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list display messages for %s: %w", chargeStationId, err)
+	}
+
+	result := make([]*store.DisplayMessage, 0, len(messages))
+	for _, msg := range messages {
+		if state != nil && (msg.State == nil || *msg.State != *state) {
+			continue
+		}
+		if priority != nil && msg.Priority != *priority {
+			continue
+		}
+		result = append(result, msg)
+	}
+	return result, nil
+}
+
+func (s *Store) DeleteDisplayMessage(ctx context.Context, chargeStationId string, messageId int) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM display_message WHERE charge_station_id = ? AND id = ?`, chargeStationId, messageId)
+	if err != nil {
+		return fmt.Errorf("delete display message %d for %s: %w", messageId, chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) DeleteAllDisplayMessages(ctx context.Context, chargeStationId string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM display_message WHERE charge_station_id = ?`, chargeStationId)
+	if err != nil {
+		return fmt.Errorf("delete display messages for %s: %w", chargeStationId, err)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sqlite provides an implementation of store.Engine using an SQLite database file.
+package sqlite
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// listKeys returns the text key in the single column of each row returned by the query
+func listKeys(ctx context.Context, q queryer, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+func (s *Store) ListChargeStationIds(ctx context.Context, pageSize int, previousChargeStationId string) ([]string, error) {
+	ids, err := listKeys(ctx, s.db,
+		`SELECT charge_station_id FROM charge_station_auth WHERE charge_station_id > ? ORDER BY charge_station_id LIMIT ?`,
+		previousChargeStationId, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list charge station ids: %w", err)
+	}
+	return ids, nil
+}
+
+func (s *Store) ListCertificateHashes(ctx context.Context, pageSize int, previousCertificateHash string) ([]string, error) {
+	hashes, err := listKeys(ctx, s.db,
+		`SELECT certificate_hash FROM certificate WHERE certificate_hash > ? ORDER BY certificate_hash LIMIT ?`,
+		previousCertificateHash, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list certificate hashes: %w", err)
+	}
+	return hashes, nil
+}
+
+func (s *Store) ListRegistrationTokens(ctx context.Context, pageSize int, previousToken string) ([]string, error) {
+	tokens, err := listKeys(ctx, s.db,
+		`SELECT token FROM ocpi_registration WHERE token > ? ORDER BY token LIMIT ?`,
+		previousToken, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list registration tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+func (s *Store) ListReservations(ctx context.Context, pageSize int, previousReservationId int) ([]*store.Reservation, error) {
+	reservations, err := listRecords[store.Reservation](ctx, s.db,
+		`SELECT data FROM reservation WHERE reservation_id > ? ORDER BY reservation_id LIMIT ?`,
+		previousReservationId, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	return reservations, nil
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetFirmwareUpdateStatus(ctx context.Context, chargeStationId string, status *store.FirmwareUpdateStatus) error {
+	status.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "firmware_update_status", chargeStationId, status)
+}
+
+func (s *Store) GetFirmwareUpdateStatus(ctx context.Context, chargeStationId string) (*store.FirmwareUpdateStatus, error) {
+	return lookupChargeStationRecord[store.FirmwareUpdateStatus](ctx, s.db, "firmware_update_status", chargeStationId)
+}
+
+func (s *Store) SetDiagnosticsStatus(ctx context.Context, chargeStationId string, status *store.DiagnosticsStatus) error {
+	status.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "diagnostics_status", chargeStationId, status)
+}
+
+func (s *Store) GetDiagnosticsStatus(ctx context.Context, chargeStationId string) (*store.DiagnosticsStatus, error) {
+	return lookupChargeStationRecord[store.DiagnosticsStatus](ctx, s.db, "diagnostics_status", chargeStationId)
+}
+
+func (s *Store) SetPublishFirmwareStatus(ctx context.Context, chargeStationId string, status *store.PublishFirmwareStatus) error {
+	status.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "publish_firmware_status", chargeStationId, status)
+}
+
+func (s *Store) GetPublishFirmwareStatus(ctx context.Context, chargeStationId string) (*store.PublishFirmwareStatus, error) {
+	return lookupChargeStationRecord[store.PublishFirmwareStatus](ctx, s.db, "publish_firmware_status", chargeStationId)
+}
+
+func (s *Store) SetLogStatus(ctx context.Context, chargeStationId string, status *store.LogStatus) error {
+	status.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "log_status", chargeStationId, status)
+}
+
+func (s *Store) GetLogStatus(ctx context.Context, chargeStationId string) (*store.LogStatus, error) {
+	return lookupChargeStationRecord[store.LogStatus](ctx, s.db, "log_status", chargeStationId)
+}
+
+func (s *Store) SetFirmwareUpdateRequest(ctx context.Context, chargeStationId string, request *store.FirmwareUpdateRequest) error {
+	request.ChargeStationId = chargeStationId
+	return setChargeStationRecord(ctx, s.db, "firmware_update_request", chargeStationId, request)
+}
+
+func (s *Store) GetFirmwareUpdateRequest(ctx context.Context, chargeStationId string) (*store.FirmwareUpdateRequest, error) {
+	return lookupChargeStationRecord[store.FirmwareUpdateRequest](ctx, s.db, "firmware_update_request", chargeStationId)
+}
+
+func (s *Store) DeleteFirmwareUpdateRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "firmware_update_request", chargeStationId)
+}
+
+func (s *Store) ListFirmwareUpdateRequests(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.FirmwareUpdateRequest, error) {
+	return listChargeStationRecords[store.FirmwareUpdateRequest](ctx, s.db, "firmware_update_request", pageSize, previousChargeStationId)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) GetLocalListVersion(ctx context.Context, chargeStationId string) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT version FROM local_auth_list_version WHERE charge_station_id = ?`, chargeStationId).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get local list version for %s: %w", chargeStationId, err)
+	}
+	return version, nil
+}
+
+func (s *Store) UpdateLocalAuthList(ctx context.Context, chargeStationId string, version int, updateType string, entries []*store.LocalAuthListEntry) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if updateType == store.LocalAuthListUpdateTypeFull {
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM local_auth_list_entry WHERE charge_station_id = ?`, chargeStationId); err != nil {
+				return fmt.Errorf("clear local auth list for %s: %w", chargeStationId, err)
+			}
+		}
+
+		for _, entry := range entries {
+			if entry.IdTagInfo == nil && updateType != store.LocalAuthListUpdateTypeFull {
+				if _, err := tx.ExecContext(ctx,
+					`DELETE FROM local_auth_list_entry WHERE charge_station_id = ? AND id_tag = ?`,
+					chargeStationId, entry.IdTag); err != nil {
+					return fmt.Errorf("remove local auth list entry %s for %s: %w", entry.IdTag, chargeStationId, err)
+				}
+				continue
+			}
+
+			data, err := encode(entry)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO local_auth_list_entry (charge_station_id, id_tag, data) VALUES (?, ?, ?)
+					ON CONFLICT (charge_station_id, id_tag) DO UPDATE SET data = excluded.data`,
+				chargeStationId, entry.IdTag, data); err != nil {
+				return fmt.Errorf("set local auth list entry %s for %s: %w", entry.IdTag, chargeStationId, err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO local_auth_list_version (charge_station_id, version) VALUES (?, ?)
+				ON CONFLICT (charge_station_id) DO UPDATE SET version = excluded.version`,
+			chargeStationId, version); err != nil {
+			return fmt.Errorf("set local list version for %s: %w", chargeStationId, err)
+		}
+		return nil
+	})
+}
+
+func (s *Store) GetLocalAuthList(ctx context.Context, chargeStationId string) ([]*store.LocalAuthListEntry, error) {
+	entries, err := listRecords[store.LocalAuthListEntry](ctx, s.db,
+		`SELECT data FROM local_auth_list_entry WHERE charge_station_id = ? ORDER BY id_tag`, chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("get local auth list for %s: %w", chargeStationId, err)
+	}
+	return entries, nil
+}
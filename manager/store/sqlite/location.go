@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetLocation(ctx context.Context, location *store.Location) error {
+	data, err := encode(location)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO location (id, data) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET data = excluded.data`,
+		location.Id, data)
+	if err != nil {
+		return fmt.Errorf("set location %s: %w", location.Id, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupLocation(ctx context.Context, locationId string) (*store.Location, error) {
+	location, err := getRecord[store.Location](ctx, s.db, `SELECT data FROM location WHERE id = ?`, locationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup location %s: %w", locationId, err)
+	}
+	return location, nil
+}
+
+func (s *Store) ListLocations(ctx context.Context, offset int, limit int) ([]*store.Location, error) {
+	locations, err := listRecords[store.Location](ctx, s.db,
+		`SELECT data FROM location ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	return locations, nil
+}
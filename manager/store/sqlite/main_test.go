@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	"k8s.io/utils/clock"
+)
+
+// newStore returns a store backed by a new database in the test's temporary directory
+func newStore(t *testing.T, clock clock.PassiveClock) *sqlite.Store {
+	s, err := sqlite.NewStore(context.Background(), filepath.Join(t.TempDir(), "csms.db"), clock)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	return s
+}
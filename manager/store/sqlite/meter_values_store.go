@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// StoreMeterValues stores meter values received from a charge station.
+func (s *Store) StoreMeterValues(ctx context.Context, chargeStationId string, evseId int, transactionId string, meterValues []store.MeterValue) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		var added []store.MeterValue
+		for _, mv := range meterValues {
+			data, err := encode(&store.StoredMeterValue{
+				ChargeStationId: chargeStationId,
+				EvseId:          evseId,
+				TransactionId:   transactionId,
+				MeterValue:      mv,
+			})
+			if err != nil {
+				return err
+			}
+			res, err := tx.ExecContext(ctx,
+				`INSERT INTO meter_value (charge_station_id, evse_id, transaction_id, timestamp, digest, data) VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT (charge_station_id, evse_id, digest) DO NOTHING`,
+				chargeStationId, evseId, transactionId, mv.Timestamp, store.MeterValueDigest(mv), data)
+			if err != nil {
+				return fmt.Errorf("store meter values for %s: %w", chargeStationId, err)
+			}
+			if n, err := res.RowsAffected(); err != nil {
+				return fmt.Errorf("store meter values for %s: %w", chargeStationId, err)
+			} else if n > 0 {
+				added = append(added, mv)
+			}
+		}
+
+		for _, rollup := range store.RollUpMeterValues(chargeStationId, evseId, added) {
+			if err := mergeMeterValueRollup(ctx, tx, rollup); err != nil {
+				return fmt.Errorf("store meter value rollups for %s: %w", chargeStationId, err)
+			}
+		}
+		return nil
+	})
+}
+
+// mergeMeterValueRollup merges the rollup with the one already stored for the same bucket
+func mergeMeterValueRollup(ctx context.Context, tx *sql.Tx, rollup *store.MeterValueRollup) error {
+	start := rollup.Start.UTC().Format(time.RFC3339)
+	existing, err := getRecord[store.MeterValueRollup](ctx, tx,
+		`SELECT data FROM meter_value_rollup WHERE charge_station_id = ? AND interval = ? AND evse_id = ? AND start = ?`,
+		rollup.ChargeStationId, rollup.Interval, rollup.EvseId, start)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existing.Merge(rollup)
+		rollup = existing
+	}
+
+	data, err := encode(rollup)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO meter_value_rollup (charge_station_id, interval, evse_id, start, data) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (charge_station_id, interval, evse_id, start) DO UPDATE SET data = excluded.data`,
+		rollup.ChargeStationId, rollup.Interval, rollup.EvseId, start, data)
+	return err
+}
+
+// GetMeterValues retrieves meter values for a specific charge station and EVSE, most recent first.
+func (s *Store) GetMeterValues(ctx context.Context, chargeStationId string, evseId int, limit int) ([]store.StoredMeterValue, error) {
+	if limit <= 0 {
+		// a negative limit means there is no limit
+		limit = -1
+	}
+	values, err := listRecords[store.StoredMeterValue](ctx, s.db,
+		`SELECT data FROM meter_value WHERE charge_station_id = ? AND evse_id = ? ORDER BY timestamp DESC, id LIMIT ?`,
+		chargeStationId, evseId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get meter values for %s: %w", chargeStationId, err)
+	}
+	return derefMeterValues(values), nil
+}
+
+// QueryMeterValues retrieves meter values with advanced filtering and pagination.
+func (s *Store) QueryMeterValues(ctx context.Context, filter store.MeterValuesFilter) (*store.MeterValuesResult, error) {
+	where := `WHERE charge_station_id = ?`
+	args := []any{filter.ChargeStationId}
+	if filter.ConnectorId != nil {
+		where += ` AND evse_id = ?`
+		args = append(args, *filter.ConnectorId)
+	}
+	if filter.TransactionId != nil {
+		where += ` AND transaction_id = ?`
+		args = append(args, *filter.TransactionId)
+	}
+	if filter.StartTime != nil {
+		where += ` AND timestamp >= ?`
+		args = append(args, *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		where += ` AND timestamp <= ?`
+		args = append(args, *filter.EndTime)
+	}
+
+	total, err := count(ctx, s.db, `SELECT COUNT(*) FROM meter_value `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("count meter values for %s: %w", filter.ChargeStationId, err)
+	}
+
+	values, err := listRecords[store.StoredMeterValue](ctx, s.db,
+		`SELECT data FROM meter_value `+where+` ORDER BY timestamp DESC, id LIMIT ? OFFSET ?`,
+		append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("query meter values for %s: %w", filter.ChargeStationId, err)
+	}
+
+	return &store.MeterValuesResult{
+		MeterValues: derefMeterValues(values),
+		Total:       total,
+	}, nil
+}
+
+// QueryMeterValueRollups retrieves the rollups for a charge station, ordered by EVSE and start.
+func (s *Store) QueryMeterValueRollups(ctx context.Context, filter store.MeterValueRollupsFilter) ([]*store.MeterValueRollup, error) {
+	where := `WHERE charge_station_id = ? AND interval = ?`
+	args := []any{filter.ChargeStationId, filter.Interval}
+	if filter.ConnectorId != nil {
+		where += ` AND evse_id = ?`
+		args = append(args, *filter.ConnectorId)
+	}
+	if filter.StartTime != nil {
+		where += ` AND julianday(start) >= julianday(?)`
+		args = append(args, filter.StartTime.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.EndTime != nil {
+		where += ` AND julianday(start) <= julianday(?)`
+		args = append(args, filter.EndTime.UTC().Format(time.RFC3339Nano))
+	}
+
+	rollups, err := listRecords[store.MeterValueRollup](ctx, s.db,
+		`SELECT data FROM meter_value_rollup `+where+` ORDER BY evse_id, start`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query meter value rollups for %s: %w", filter.ChargeStationId, err)
+	}
+	return rollups, nil
+}
+
+func derefMeterValues(values []*store.StoredMeterValue) []store.StoredMeterValue {
+	result := make([]store.StoredMeterValue, len(values))
+	for i, v := range values {
+		result[i] = *v
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+DROP TABLE IF EXISTS location;
+DROP TABLE IF EXISTS ocpi_party;
+DROP TABLE IF EXISTS ocpi_registration;
+DROP TABLE IF EXISTS certificate;
+DROP TABLE IF EXISTS token;
+DROP TABLE IF EXISTS dead_letter;
+DROP TABLE IF EXISTS command;
+DROP TABLE IF EXISTS device_report;
+DROP TABLE IF EXISTS charge_station_event;
+DROP TABLE IF EXISTS variable_monitoring;
+DROP TABLE IF EXISTS meter_value;
+DROP TABLE IF EXISTS reservation;
+DROP TABLE IF EXISTS charging_profile;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS display_message;
+DROP TABLE IF EXISTS local_auth_list_entry;
+DROP TABLE IF EXISTS connector_status;
+DROP TABLE IF EXISTS local_auth_list_version;
+DROP TABLE IF EXISTS charge_station_status;
+DROP TABLE IF EXISTS unlock_connector_request;
+DROP TABLE IF EXISTS reset_request;
+DROP TABLE IF EXISTS log_request;
+DROP TABLE IF EXISTS diagnostics_request;
+DROP TABLE IF EXISTS log_status;
+DROP TABLE IF EXISTS publish_firmware_status;
+DROP TABLE IF EXISTS diagnostics_status;
+DROP TABLE IF EXISTS firmware_update_request;
+DROP TABLE IF EXISTS firmware_update_status;
+DROP TABLE IF EXISTS remote_stop_transaction_request;
+DROP TABLE IF EXISTS remote_start_transaction_request;
+DROP TABLE IF EXISTS charge_station_certificate_deletion;
+DROP TABLE IF EXISTS charge_station_certificate_query;
+DROP TABLE IF EXISTS charge_station_change_availability;
+DROP TABLE IF EXISTS charge_station_clear_cache;
+DROP TABLE IF EXISTS charge_station_data_transfer;
+DROP TABLE IF EXISTS charge_station_trigger_message;
+DROP TABLE IF EXISTS charge_station_runtime_details;
+DROP TABLE IF EXISTS charge_station_install_certificates;
+DROP TABLE IF EXISTS charge_station_settings;
+DROP TABLE IF EXISTS charge_station_auth;
@@ -0,0 +1,254 @@
+-- Records are stored as JSON documents in the data column: only the values that are
+-- needed to look up, filter or order the records are held in their own columns.
+-- Timestamps held in their own columns are unix nanoseconds.
+
+-- Records that are keyed by the charge station
+CREATE TABLE IF NOT EXISTS charge_station_auth (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_settings (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_install_certificates (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_runtime_details (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_trigger_message (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_data_transfer (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_clear_cache (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_change_availability (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_certificate_query (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_certificate_deletion (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS remote_start_transaction_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS remote_stop_transaction_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS firmware_update_status (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS firmware_update_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS diagnostics_status (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS publish_firmware_status (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS log_status (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS diagnostics_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS log_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reset_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS unlock_connector_request (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS charge_station_status (
+    charge_station_id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS local_auth_list_version (
+    charge_station_id TEXT PRIMARY KEY,
+    version INTEGER NOT NULL
+);
+
+-- Records that are keyed by the charge station and something else
+CREATE TABLE IF NOT EXISTS connector_status (
+    charge_station_id TEXT NOT NULL,
+    connector_id INTEGER NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (charge_station_id, connector_id)
+);
+
+CREATE TABLE IF NOT EXISTS local_auth_list_entry (
+    charge_station_id TEXT NOT NULL,
+    id_tag TEXT NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (charge_station_id, id_tag)
+);
+
+CREATE TABLE IF NOT EXISTS display_message (
+    charge_station_id TEXT NOT NULL,
+    id INTEGER NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (charge_station_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    charge_station_id TEXT NOT NULL,
+    transaction_id TEXT NOT NULL,
+    ended_seq_no INTEGER NOT NULL DEFAULT 0,
+    data TEXT NOT NULL,
+    PRIMARY KEY (charge_station_id, transaction_id)
+);
+
+CREATE TABLE IF NOT EXISTS charging_profile (
+    charging_profile_id INTEGER PRIMARY KEY,
+    charge_station_id TEXT NOT NULL,
+    connector_id INTEGER NOT NULL,
+    purpose TEXT NOT NULL,
+    stack_level INTEGER NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_charging_profile_charge_station ON charging_profile(charge_station_id);
+
+CREATE TABLE IF NOT EXISTS reservation (
+    reservation_id INTEGER PRIMARY KEY,
+    charge_station_id TEXT NOT NULL,
+    connector_id INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_charge_station ON reservation(charge_station_id, status);
+
+CREATE TABLE IF NOT EXISTS meter_value (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    charge_station_id TEXT NOT NULL,
+    evse_id INTEGER NOT NULL,
+    transaction_id TEXT NOT NULL,
+    timestamp TEXT NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_meter_value_charge_station ON meter_value(charge_station_id, evse_id, timestamp DESC);
+
+CREATE TABLE IF NOT EXISTS variable_monitoring (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    charge_station_id TEXT NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_variable_monitoring_charge_station ON variable_monitoring(charge_station_id);
+
+CREATE TABLE IF NOT EXISTS charge_station_event (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    charge_station_id TEXT NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_charge_station_event_charge_station ON charge_station_event(charge_station_id);
+
+CREATE TABLE IF NOT EXISTS device_report (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    charge_station_id TEXT NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_report_charge_station ON device_report(charge_station_id);
+
+CREATE TABLE IF NOT EXISTS command (
+    id TEXT PRIMARY KEY,
+    charge_station_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_command_charge_station ON command(charge_station_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_command_status ON command(status, updated_at);
+
+CREATE TABLE IF NOT EXISTS dead_letter (
+    id TEXT PRIMARY KEY,
+    created_at INTEGER NOT NULL,
+    data TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letter_created ON dead_letter(created_at DESC);
+
+-- Records that are not related to a charge station
+CREATE TABLE IF NOT EXISTS token (
+    uid TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS certificate (
+    certificate_hash TEXT PRIMARY KEY,
+    pem_data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ocpi_registration (
+    token TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS ocpi_party (
+    role TEXT NOT NULL,
+    country_code TEXT NOT NULL,
+    party_id TEXT NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (role, country_code, party_id)
+);
+
+CREATE TABLE IF NOT EXISTS location (
+    id TEXT PRIMARY KEY,
+    data TEXT NOT NULL
+);
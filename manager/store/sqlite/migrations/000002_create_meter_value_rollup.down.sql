@@ -0,0 +1 @@
+DROP TABLE IF EXISTS meter_value_rollup;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS meter_value_rollup (
+    charge_station_id TEXT NOT NULL,
+    evse_id INTEGER NOT NULL,
+    interval TEXT NOT NULL,
+    start TEXT NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (charge_station_id, interval, evse_id, start)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_meter_value_digest;
+
+ALTER TABLE meter_value DROP COLUMN digest;
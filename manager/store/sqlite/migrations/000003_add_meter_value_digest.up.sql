@@ -0,0 +1,5 @@
+-- Identifies a meter value for an EVSE so that one that is received more than once is only stored,
+-- and rolled up, once. Meter values stored before the column was added have no digest.
+ALTER TABLE meter_value ADD COLUMN digest TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_meter_value_digest ON meter_value(charge_station_id, evse_id, digest);
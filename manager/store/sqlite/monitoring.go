@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// addRecord inserts a record into one of the tables that allocate an id to each record: setId
+// is called with the allocated id before the record is encoded
+func addRecord(ctx context.Context, tx *sql.Tx, table, chargeStationId string, record any, setId func(id int)) error {
+	var id int
+	err := tx.QueryRowContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (charge_station_id, data) VALUES (?, '{}') RETURNING id`, table),
+		chargeStationId).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("add %s for %s: %w", table, chargeStationId, err)
+	}
+	setId(id)
+
+	data, err := encode(record)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET data = ? WHERE id = ?`, table), data, id)
+	if err != nil {
+		return fmt.Errorf("add %s for %s: %w", table, chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) SetVariableMonitoring(ctx context.Context, chargeStationId string, config *store.VariableMonitoringConfig) error {
+	config.ChargeStationId = chargeStationId
+	config.CreatedAt = s.clock.Now()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if config.Id == 0 {
+			return addRecord(ctx, tx, "variable_monitoring", chargeStationId, config, func(id int) {
+				config.Id = id
+			})
+		}
+
+		data, err := encode(config)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO variable_monitoring (id, charge_station_id, data) VALUES (?, ?, ?)
+				ON CONFLICT (id) DO UPDATE SET charge_station_id = excluded.charge_station_id, data = excluded.data`,
+			config.Id, chargeStationId, data)
+		if err != nil {
+			return fmt.Errorf("set variable monitoring %d for %s: %w", config.Id, chargeStationId, err)
+		}
+		return nil
+	})
+}
+
+func (s *Store) GetVariableMonitoring(ctx context.Context, chargeStationId string, monitorId int) (*store.VariableMonitoringConfig, error) {
+	config, err := getRecord[store.VariableMonitoringConfig](ctx, s.db,
+		`SELECT data FROM variable_monitoring WHERE charge_station_id = ? AND id = ?`, chargeStationId, monitorId)
+	if err != nil {
+		return nil, fmt.Errorf("get variable monitoring %d for %s: %w", monitorId, chargeStationId, err)
+	}
+	return config, nil
+}
+
+func (s *Store) DeleteVariableMonitoring(ctx context.Context, chargeStationId string, monitorId int) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM variable_monitoring WHERE charge_station_id = ? AND id = ?`, chargeStationId, monitorId)
+	if err != nil {
+		return fmt.Errorf("delete variable monitoring %d for %s: %w", monitorId, chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) ListVariableMonitoring(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.VariableMonitoringConfig, error) {
+	configs, err := listRecords[store.VariableMonitoringConfig](ctx, s.db,
+		`SELECT data FROM variable_monitoring WHERE charge_station_id = ? ORDER BY id LIMIT ? OFFSET ?`,
+		chargeStationId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list variable monitoring for %s: %w", chargeStationId, err)
+	}
+	return configs, nil
+}
+
+func (s *Store) AddChargeStationEvent(ctx context.Context, chargeStationId string, event *store.ChargeStationEvent) error {
+	event.ChargeStationId = chargeStationId
+	event.CreatedAt = s.clock.Now()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return addRecord(ctx, tx, "charge_station_event", chargeStationId, event, func(id int) {
+			event.Id = id
+		})
+	})
+}
+
+func (s *Store) ListChargeStationEvents(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.ChargeStationEvent, int, error) {
+	total, err := count(ctx, s.db,
+		`SELECT COUNT(*) FROM charge_station_event WHERE charge_station_id = ?`, chargeStationId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count charge station events for %s: %w", chargeStationId, err)
+	}
+
+	events, err := listRecords[store.ChargeStationEvent](ctx, s.db,
+		`SELECT data FROM charge_station_event WHERE charge_station_id = ?
+		 ORDER BY julianday(json_extract(data, '$.Timestamp')) DESC, id LIMIT ? OFFSET ?`,
+		chargeStationId, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list charge station events for %s: %w", chargeStationId, err)
+	}
+	return events, total, nil
+}
+
+func (s *Store) AddDeviceReport(ctx context.Context, chargeStationId string, report *store.DeviceReport) error {
+	report.ChargeStationId = chargeStationId
+	report.CreatedAt = s.clock.Now()
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return addRecord(ctx, tx, "device_report", chargeStationId, report, func(id int) {
+			report.Id = id
+		})
+	})
+}
+
+func (s *Store) ListDeviceReports(ctx context.Context, chargeStationId string, offset int, limit int) ([]*store.DeviceReport, int, error) {
+	total, err := count(ctx, s.db,
+		`SELECT COUNT(*) FROM device_report WHERE charge_station_id = ?`, chargeStationId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count device reports for %s: %w", chargeStationId, err)
+	}
+
+	reports, err := listRecords[store.DeviceReport](ctx, s.db,
+		`SELECT data FROM device_report WHERE charge_station_id = ?
+		 ORDER BY julianday(json_extract(data, '$.GeneratedAt')) DESC, id LIMIT ? OFFSET ?`,
+		chargeStationId, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list device reports for %s: %w", chargeStationId, err)
+	}
+	return reports, total, nil
+}
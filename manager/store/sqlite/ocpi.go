@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetRegistrationDetails(ctx context.Context, token string, registration *store.OcpiRegistration) error {
+	data, err := encode(registration)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO ocpi_registration (token, data) VALUES (?, ?) ON CONFLICT (token) DO UPDATE SET data = excluded.data`,
+		token, data)
+	if err != nil {
+		return fmt.Errorf("set registration details: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) GetRegistrationDetails(ctx context.Context, token string) (*store.OcpiRegistration, error) {
+	registration, err := getRecord[store.OcpiRegistration](ctx, s.db, `SELECT data FROM ocpi_registration WHERE token = ?`, token)
+	if err != nil {
+		return nil, fmt.Errorf("get registration details: %w", err)
+	}
+	return registration, nil
+}
+
+func (s *Store) DeleteRegistrationDetails(ctx context.Context, token string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM ocpi_registration WHERE token = ?`, token)
+	if err != nil {
+		return fmt.Errorf("delete registration details: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) SetPartyDetails(ctx context.Context, partyDetails *store.OcpiParty) error {
+	data, err := encode(partyDetails)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO ocpi_party (role, country_code, party_id, data) VALUES (?, ?, ?, ?)
+			ON CONFLICT (role, country_code, party_id) DO UPDATE SET data = excluded.data`,
+		partyDetails.Role, partyDetails.CountryCode, partyDetails.PartyId, data)
+	if err != nil {
+		return fmt.Errorf("set party details %s:%s:%s: %w", partyDetails.Role, partyDetails.CountryCode, partyDetails.PartyId, err)
+	}
+	return nil
+}
+
+func (s *Store) GetPartyDetails(ctx context.Context, role, countryCode, partyId string) (*store.OcpiParty, error) {
+	party, err := getRecord[store.OcpiParty](ctx, s.db,
+		`SELECT data FROM ocpi_party WHERE role = ? AND country_code = ? AND party_id = ?`,
+		role, countryCode, partyId)
+	if err != nil {
+		return nil, fmt.Errorf("get party details %s:%s:%s: %w", role, countryCode, partyId, err)
+	}
+	return party, nil
+}
+
+func (s *Store) ListPartyDetailsForRole(ctx context.Context, role string) ([]*store.OcpiParty, error) {
+	parties, err := listRecords[store.OcpiParty](ctx, s.db,
+		`SELECT data FROM ocpi_party WHERE role = ? ORDER BY country_code, party_id`, role)
+	if err != nil {
+		return nil, fmt.Errorf("list party details for %s: %w", role, err)
+	}
+	return parties, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func setReservation(ctx context.Context, q queryer, reservation *store.Reservation) error {
+	data, err := encode(reservation)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx,
+		`INSERT INTO reservation (reservation_id, charge_station_id, connector_id, status, data) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (reservation_id) DO UPDATE SET
+				charge_station_id = excluded.charge_station_id,
+				connector_id = excluded.connector_id,
+				status = excluded.status,
+				data = excluded.data`,
+		reservation.ReservationId, reservation.ChargeStationId, reservation.ConnectorId, string(reservation.Status), data)
+	if err != nil {
+		return fmt.Errorf("set reservation %d: %w", reservation.ReservationId, err)
+	}
+	return nil
+}
+
+func getReservation(ctx context.Context, q queryer, reservationId int) (*store.Reservation, error) {
+	reservation, err := getRecord[store.Reservation](ctx, q,
+		`SELECT data FROM reservation WHERE reservation_id = ?`, reservationId)
+	if err != nil {
+		return nil, fmt.Errorf("get reservation %d: %w", reservationId, err)
+	}
+	return reservation, nil
+}
+
+func (s *Store) CreateReservation(ctx context.Context, reservation *store.Reservation) error {
+	return setReservation(ctx, s.db, reservation)
+}
+
+func (s *Store) GetReservation(ctx context.Context, reservationId int) (*store.Reservation, error) {
+	return getReservation(ctx, s.db, reservationId)
+}
+
+func (s *Store) CancelReservation(ctx context.Context, reservationId int) error {
+	return s.UpdateReservationStatus(ctx, reservationId, store.ReservationStatusCancelled)
+}
+
+func (s *Store) UpdateReservationStatus(ctx context.Context, reservationId int, status store.ReservationStatus) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		reservation, err := getReservation(ctx, tx, reservationId)
+		if err != nil {
+			return err
+		}
+		if reservation == nil {
+			return fmt.Errorf("reservation %d not found", reservationId)
+		}
+		reservation.Status = status
+		return setReservation(ctx, tx, reservation)
+	})
+}
+
+func (s *Store) GetActiveReservations(ctx context.Context, chargeStationId string) ([]*store.Reservation, error) {
+	reservations, err := listRecords[store.Reservation](ctx, s.db,
+		`SELECT data FROM reservation WHERE charge_station_id = ? AND status = ? ORDER BY reservation_id`,
+		chargeStationId, string(store.ReservationStatusAccepted))
+	if err != nil {
+		return nil, fmt.Errorf("get active reservations for %s: %w", chargeStationId, err)
+	}
+	return reservations, nil
+}
+
+func (s *Store) GetReservationByConnector(ctx context.Context, chargeStationId string, connectorId int) (*store.Reservation, error) {
+	reservation, err := getRecord[store.Reservation](ctx, s.db,
+		`SELECT data FROM reservation WHERE charge_station_id = ? AND connector_id = ? AND status = ? ORDER BY reservation_id LIMIT 1`,
+		chargeStationId, connectorId, string(store.ReservationStatusAccepted))
+	if err != nil {
+		return nil, fmt.Errorf("get reservation for %s connector %d: %w", chargeStationId, connectorId, err)
+	}
+	return reservation, nil
+}
+
+func (s *Store) ExpireReservations(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	expired := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		reservations, err := listRecords[store.Reservation](ctx, tx,
+			`SELECT data FROM reservation WHERE status = ?`, string(store.ReservationStatusAccepted))
+		if err != nil {
+			return fmt.Errorf("list accepted reservations: %w", err)
+		}
+		for _, reservation := range reservations {
+			if reservation.ExpiryDate.Before(now) {
+				reservation.Status = store.ReservationStatusExpired
+				if err := setReservation(ctx, tx, reservation); err != nil {
+					return err
+				}
+				expired++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return expired, nil
+}
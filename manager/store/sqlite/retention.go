@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// deleteBefore deletes at most limit rows of the table, oldest first, whose date (an SQL
+// expression that evaluates to a timestamp) is before the given time
+func (s *Store) deleteBefore(ctx context.Context, table, date, where string, before time.Time, limit int) (int, error) {
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(
+		`DELETE FROM %[1]s WHERE rowid IN (
+			SELECT rowid FROM %[1]s WHERE %[3]s AND julianday(%[2]s) < julianday(?)
+			ORDER BY julianday(%[2]s), rowid LIMIT ?)`, table, date, where),
+		before.UTC().Format(time.RFC3339Nano), limit)
+	if err != nil {
+		return 0, fmt.Errorf("delete %s before %s: %w", table, before.Format(time.RFC3339), err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete %s before %s: %w", table, before.Format(time.RFC3339), err)
+	}
+	return int(deleted), nil
+}
+
+func (s *Store) DeleteMeterValuesBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	return s.deleteBefore(ctx, "meter_value", "timestamp", "true", before, limit)
+}
+
+func (s *Store) DeleteChargeStationEventsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	return s.deleteBefore(ctx, "charge_station_event", "json_extract(data, '$.Timestamp')", "true", before, limit)
+}
+
+func (s *Store) DeleteDeviceReportsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	return s.deleteBefore(ctx, "device_report", "json_extract(data, '$.GeneratedAt')", "true", before, limit)
+}
+
+// DeleteFinishedTransactionsBefore dates each transaction by its last meter value as the
+// time that a transaction ended is not recorded
+func (s *Store) DeleteFinishedTransactionsBefore(ctx context.Context, before time.Time, limit int) (int, error) {
+	return s.deleteBefore(ctx, "transactions", "json_extract(data, '$.MeterValues[#-1].Timestamp')", "ended_seq_no != 0", before, limit)
+}
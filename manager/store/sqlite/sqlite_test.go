@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/sqlite"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+func TestStoreKeepsDataWhenReopened(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "csms.db")
+	clock := clockTest.NewFakePassiveClock(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	s, err := sqlite.NewStore(ctx, path, clock)
+	require.NoError(t, err)
+
+	err = s.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile:      store.TLSWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	})
+	require.NoError(t, err)
+	err = s.SetToken(ctx, &store.Token{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Type:        "RFID",
+		Uid:         "DEADBEEF",
+		ContractId:  "GBTWK012345678V",
+		Issuer:      "Thoughtworks",
+		Valid:       true,
+		CacheMode:   store.CacheModeAlways,
+	})
+	require.NoError(t, err)
+	err = s.CreateTransaction(ctx, "cs001", "1234", idToken, tokenType, NewMeterValues(100), 0, false)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	// opening the database again does not re-run the migrations
+	s, err = sqlite.NewStore(ctx, path, clock)
+	require.NoError(t, err)
+	defer func() {
+		_ = s.Close()
+	}()
+
+	auth, err := s.LookupChargeStationAuth(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Equal(t, &store.ChargeStationAuth{
+		SecurityProfile:      store.TLSWithBasicAuth,
+		Base64SHA256Password: "DEADBEEF",
+	}, auth)
+
+	token, err := s.LookupToken(ctx, "DEADBEEF")
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, "GBTWK012345678V", token.ContractId)
+	assert.Equal(t, "2023-06-01T12:00:00Z", token.LastUpdated)
+
+	transaction, err := s.FindActiveTransaction(ctx, "cs001")
+	require.NoError(t, err)
+	require.NotNil(t, transaction)
+	assert.Equal(t, "1234", transaction.TransactionId)
+}
+
+func TestStoreUsesWriteAheadLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "csms.db")
+
+	s, err := sqlite.NewStore(context.Background(), path, clockTest.NewFakePassiveClock(time.Now()))
+	require.NoError(t, err)
+	defer func() {
+		_ = s.Close()
+	}()
+
+	err = s.SetChargeStationRuntimeDetails(context.Background(), "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.0.1",
+	})
+	require.NoError(t, err)
+
+	assert.FileExists(t, path+"-wal")
+}
+
+func TestConcurrentUpdatesAreNotLost(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.UpdateTransaction(ctx, "cs001", "1234", NewMeterValues(100)))
+		}()
+	}
+	wg.Wait()
+
+	got, err := s.FindTransaction(ctx, "cs001", "1234")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 20, got.UpdatedSeqNoCount)
+	assert.Len(t, got.MeterValues, 20)
+}
+
+func TestListTokens(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	for _, uid := range []string{"C", "A", "B"} {
+		err := s.SetToken(ctx, &store.Token{Uid: uid, Type: "RFID", Valid: true})
+		require.NoError(t, err)
+	}
+
+	tokens, err := s.ListTokens(ctx, 1, 5)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "B", tokens[0].Uid)
+	assert.Equal(t, "C", tokens[1].Uid)
+
+	tokens, err = s.ListTokens(ctx, 3, 5)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestListTransactionsForChargeStation(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	require.NoError(t, s.CreateTransaction(ctx, "cs001", "t1", idToken, tokenType, nil, 0, false))
+	require.NoError(t, s.CreateTransaction(ctx, "cs001", "t2", idToken, tokenType, nil, 0, false))
+	require.NoError(t, s.EndTransaction(ctx, "cs001", "t2", idToken, tokenType, nil, 3))
+	require.NoError(t, s.CreateTransaction(ctx, "cs002", "t3", idToken, tokenType, nil, 0, false))
+
+	all, total, err := s.ListTransactionsForChargeStation(ctx, "cs001", "", nil, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, all, 2)
+
+	active, total, err := s.ListTransactionsForChargeStation(ctx, "cs001", "active", nil, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, active, 1)
+	assert.Equal(t, "t1", active[0].TransactionId)
+
+	completed, total, err := s.ListTransactionsForChargeStation(ctx, "cs001", "completed", nil, nil, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, completed, 1)
+	assert.Equal(t, "t2", completed[0].TransactionId)
+	assert.Equal(t, 3, completed[0].EndedSeqNo)
+}
+
+func TestPartyDetails(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	party := &store.OcpiParty{
+		CountryCode: "GB",
+		PartyId:     "TWK",
+		Role:        "CPO",
+		Url:         "https://example.com/ocpi/versions",
+		Token:       "abc123",
+	}
+	require.NoError(t, s.SetPartyDetails(ctx, party))
+	require.NoError(t, s.SetPartyDetails(ctx, &store.OcpiParty{CountryCode: "GB", PartyId: "ABC", Role: "EMSP"}))
+
+	got, err := s.GetPartyDetails(ctx, "CPO", "GB", "TWK")
+	require.NoError(t, err)
+	assert.Equal(t, party, got)
+
+	got, err = s.GetPartyDetails(ctx, "EMSP", "GB", "TWK")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	parties, err := s.ListPartyDetailsForRole(ctx, "CPO")
+	require.NoError(t, err)
+	assert.Equal(t, []*store.OcpiParty{party}, parties)
+}
+
+func TestSetAndLookupLocation(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	location := &store.Location{
+		Id:      "loc001",
+		Name:    "Depot",
+		Address: "1 High Street",
+		City:    "London",
+		Country: "GBR",
+		Coordinates: store.GeoLocation{
+			Latitude:  "51.5",
+			Longitude: "-0.1",
+		},
+		Evses: &[]store.Evse{
+			{
+				Uid:    "cs001",
+				Status: "AVAILABLE",
+				Connectors: []store.Connector{
+					{Id: "1", Format: "CABLE", PowerType: "AC_3_PHASE", Standard: "IEC_62196_T2", MaxVoltage: 230, MaxAmperage: 32},
+				},
+			},
+		},
+	}
+	require.NoError(t, s.SetLocation(ctx, location))
+
+	got, err := s.LookupLocation(ctx, "loc001")
+	require.NoError(t, err)
+	assert.Equal(t, location, got)
+
+	locations, err := s.ListLocations(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*store.Location{location}, locations)
+}
+
+func TestChargeStationStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := newStore(t, clockTest.NewFakePassiveClock(now))
+
+	_, err := s.GetChargeStationStatus(ctx, "cs001")
+	assert.Error(t, err)
+
+	connectedAt := now.Add(-time.Minute)
+	require.NoError(t, s.SetChargeStationConnected(ctx, "cs001", true, connectedAt))
+	require.NoError(t, s.UpdateHeartbeat(ctx, "cs001", now))
+	require.NoError(t, s.SetChargeStationStatus(ctx, "cs001", &store.ChargeStationStatus{
+		ChargeStationId: "cs001",
+		Connected:       true,
+		Model:           makePtr("model"),
+	}))
+
+	// an earlier disconnection is ignored
+	require.NoError(t, s.SetChargeStationConnected(ctx, "cs001", false, connectedAt.Add(-time.Second)))
+
+	got, err := s.GetChargeStationStatus(ctx, "cs001")
+	require.NoError(t, err)
+	assert.True(t, got.Connected)
+	assert.Equal(t, &connectedAt, got.LastConnected)
+	assert.Nil(t, got.LastDisconnected)
+	assert.Equal(t, makePtr("model"), got.Model)
+	assert.Equal(t, now, got.UpdatedAt)
+}
+
+func TestConnectorStatuses(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	_, err := s.GetConnectorStatus(ctx, "cs001", 1)
+	assert.Error(t, err)
+
+	for _, connectorId := range []int{2, 1} {
+		err := s.SetConnectorStatus(ctx, "cs001", connectorId, &store.ConnectorStatus{
+			ChargeStationId: "cs001",
+			ConnectorId:     connectorId,
+			Status:          store.ConnectorStatusAvailable,
+			ErrorCode:       store.ConnectorErrorCodeNoError,
+		})
+		require.NoError(t, err)
+	}
+
+	got, err := s.GetConnectorStatus(ctx, "cs001", 2)
+	require.NoError(t, err)
+	assert.Equal(t, store.ConnectorStatusAvailable, got.Status)
+
+	statuses, err := s.ListConnectorStatuses(ctx, "cs001")
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, 1, statuses[0].ConnectorId)
+	assert.Equal(t, 2, statuses[1].ConnectorId)
+}
+
+func TestQueryMeterValues(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	meterValue := func(timestamp string, value float64) store.MeterValue {
+		return store.MeterValue{
+			Timestamp: timestamp,
+			SampledValues: []store.SampledValue{
+				{Measurand: makePtr("Energy.Active.Import.Register"), Value: value},
+			},
+		}
+	}
+
+	err := s.StoreMeterValues(ctx, "cs001", 1, "t1", []store.MeterValue{
+		meterValue("2023-06-01T12:00:00Z", 100),
+		meterValue("2023-06-01T12:05:00Z", 200),
+	})
+	require.NoError(t, err)
+	err = s.StoreMeterValues(ctx, "cs001", 2, "", []store.MeterValue{
+		meterValue("2023-06-01T12:10:00Z", 300),
+	})
+	require.NoError(t, err)
+
+	latest, err := s.GetMeterValues(ctx, "cs001", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, 200.0, latest[0].MeterValue.SampledValues[0].Value)
+	assert.Equal(t, "t1", latest[0].TransactionId)
+
+	result, err := s.QueryMeterValues(ctx, store.MeterValuesFilter{
+		ChargeStationId: "cs001",
+		StartTime:       makePtr("2023-06-01T12:05:00Z"),
+		Limit:           1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	require.Len(t, result.MeterValues, 1)
+	assert.Equal(t, 2, result.MeterValues[0].EvseId)
+
+	result, err = s.QueryMeterValues(ctx, store.MeterValuesFilter{
+		ChargeStationId: "cs001",
+		TransactionId:   makePtr("t1"),
+		Limit:           10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	assert.Len(t, result.MeterValues, 2)
+}
+
+func TestListChargeStationDataTransfersReturnsDataInPages(t *testing.T) {
+	ctx := context.Background()
+	s := newStore(t, clockTest.NewFakePassiveClock(time.Now()))
+
+	for _, csId := range []string{"cs003", "cs001", "cs002"} {
+		err := s.SetChargeStationDataTransfer(ctx, csId, &store.ChargeStationDataTransfer{
+			VendorId: "com.example",
+			Status:   store.DataTransferStatusPending,
+		})
+		require.NoError(t, err)
+	}
+
+	page1, err := s.ListChargeStationDataTransfers(ctx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	assert.Equal(t, "cs001", page1[0].ChargeStationId)
+	assert.Equal(t, "cs002", page1[1].ChargeStationId)
+
+	page2, err := s.ListChargeStationDataTransfers(ctx, 2, page1[1].ChargeStationId)
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+	assert.Equal(t, "cs003", page2[0].ChargeStationId)
+
+	require.NoError(t, s.DeleteChargeStationDataTransfer(ctx, "cs003"))
+	got, err := s.LookupChargeStationDataTransfer(ctx, "cs003")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
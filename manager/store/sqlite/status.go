@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetConnectorStatus(ctx context.Context, chargeStationId string, connectorId int, status *store.ConnectorStatus) error {
+	statusCopy := *status
+	statusCopy.UpdatedAt = s.clock.Now()
+	data, err := encode(&statusCopy)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO connector_status (charge_station_id, connector_id, data) VALUES (?, ?, ?)
+			ON CONFLICT (charge_station_id, connector_id) DO UPDATE SET data = excluded.data`,
+		chargeStationId, connectorId, data)
+	if err != nil {
+		return fmt.Errorf("set connector %d status for %s: %w", connectorId, chargeStationId, err)
+	}
+	return nil
+}
+
+func (s *Store) GetConnectorStatus(ctx context.Context, chargeStationId string, connectorId int) (*store.ConnectorStatus, error) {
+	status, err := getRecord[store.ConnectorStatus](ctx, s.db,
+		`SELECT data FROM connector_status WHERE charge_station_id = ? AND connector_id = ?`,
+		chargeStationId, connectorId)
+	if err != nil {
+		return nil, fmt.Errorf("get connector %d status for %s: %w", connectorId, chargeStationId, err)
+	}
+	if status == nil {
+		return nil, fmt.Errorf("connector %d on charge station %s not found", connectorId, chargeStationId)
+	}
+	return status, nil
+}
+
+func (s *Store) ListConnectorStatuses(ctx context.Context, chargeStationId string) ([]*store.ConnectorStatus, error) {
+	statuses, err := listRecords[store.ConnectorStatus](ctx, s.db,
+		`SELECT data FROM connector_status WHERE charge_station_id = ? ORDER BY connector_id`, chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("list connector statuses for %s: %w", chargeStationId, err)
+	}
+	return statuses, nil
+}
+
+// updateChargeStationStatus applies fn to the charge station's status (or to a new status if
+// there is none) and stores the result unless fn returns false
+func (s *Store) updateChargeStationStatus(ctx context.Context, chargeStationId string, fn func(status *store.ChargeStationStatus) bool) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		status, err := lookupChargeStationRecord[store.ChargeStationStatus](ctx, tx, "charge_station_status", chargeStationId)
+		if err != nil {
+			return err
+		}
+		if status == nil {
+			status = &store.ChargeStationStatus{
+				ChargeStationId: chargeStationId,
+			}
+		}
+		if !fn(status) {
+			return nil
+		}
+		status.UpdatedAt = s.clock.Now()
+		return setChargeStationRecord(ctx, tx, "charge_station_status", chargeStationId, status)
+	})
+}
+
+func (s *Store) SetChargeStationStatus(ctx context.Context, chargeStationId string, status *store.ChargeStationStatus) error {
+	return s.updateChargeStationStatus(ctx, chargeStationId, func(existing *store.ChargeStationStatus) bool {
+		// connection times are only maintained by SetChargeStationConnected
+		lastConnected, lastDisconnected := existing.LastConnected, existing.LastDisconnected
+		*existing = *status
+		existing.LastConnected = lastConnected
+		existing.LastDisconnected = lastDisconnected
+		return true
+	})
+}
+
+func (s *Store) GetChargeStationStatus(ctx context.Context, chargeStationId string) (*store.ChargeStationStatus, error) {
+	status, err := lookupChargeStationRecord[store.ChargeStationStatus](ctx, s.db, "charge_station_status", chargeStationId)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, fmt.Errorf("charge station %s %w", chargeStationId, store.ErrChargeStationStatusNotFound)
+	}
+	return status, nil
+}
+
+func (s *Store) UpdateHeartbeat(ctx context.Context, chargeStationId string, timestamp time.Time) error {
+	return s.updateChargeStationStatus(ctx, chargeStationId, func(status *store.ChargeStationStatus) bool {
+		status.LastHeartbeat = &timestamp
+		status.Connected = true
+		return true
+	})
+}
+
+func (s *Store) SetChargeStationConnected(ctx context.Context, chargeStationId string, connected bool, timestamp time.Time) error {
+	return s.updateChargeStationStatus(ctx, chargeStationId, func(status *store.ChargeStationStatus) bool {
+		if (status.LastConnected != nil && status.LastConnected.After(timestamp)) ||
+			(status.LastDisconnected != nil && status.LastDisconnected.After(timestamp)) {
+			return false
+		}
+		status.Connected = connected
+		if connected {
+			status.LastConnected = &timestamp
+		} else {
+			status.LastDisconnected = &timestamp
+		}
+		return true
+	})
+}
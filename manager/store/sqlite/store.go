@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Verify Store implements store.Engine at compile time
+var _ store.Engine = (*Store)(nil)
+
+// Store provides an SQLite implementation of the store.Engine interface. Everything is held
+// in a single database file, so it is suited to running a single manager instance.
+type Store struct {
+	db    *sql.DB
+	clock clock.PassiveClock
+}
+
+// NewStore opens (creating if necessary) the SQLite database at the given path and brings
+// its schema up to date
+func NewStore(ctx context.Context, path string, clock clock.PassiveClock) (*Store, error) {
+	slog.Info("initializing SQLite store", "path", path)
+
+	// The pragmas are applied to every connection: the busy timeout lets writers wait for
+	// each other rather than failing, and transactions take the write lock when they begin
+	// so that read-modify-write updates cannot deadlock
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database %s: %w", path, err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("connect to sqlite database %s: %w", path, err)
+	}
+
+	if err := runMigrations(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	slog.Info("SQLite store initialized successfully")
+
+	return &Store{
+		db:    db,
+		clock: clock,
+	}, nil
+}
+
+// runMigrations applies the migrations that are embedded in the binary
+func runMigrations(db *sql.DB) error {
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return fmt.Errorf("read migrations: %w", err)
+	}
+
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		return fmt.Errorf("create migration driver: %w", err)
+	}
+
+	// the migrator is not closed as that would close the store's database
+	m, err := migrate.NewWithInstance("iofs", source, "sqlite", driver)
+	if err != nil {
+		return fmt.Errorf("create migrator: %w", err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	slog.Info("closing SQLite database")
+	return s.db.Close()
+}
+
+// Health checks that the database can be reached
+func (s *Store) Health(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("sqlite database health check failed: %w", err)
+	}
+	return nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx runs fn in a transaction, committing it if fn succeeds and rolling it back otherwise
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// encode returns the JSON document that is stored for a record
+func encode(record any) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("encode %T: %w", record, err)
+	}
+	return string(data), nil
+}
+
+// getRecord decodes the JSON document in the single column of the row returned by the query:
+// it returns nil if the query does not return a row
+func getRecord[T any](ctx context.Context, q queryer, query string, args ...any) (*T, error) {
+	var data string
+	err := q.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record T
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("decode %T: %w", record, err)
+	}
+	return &record, nil
+}
+
+// listRecords decodes the JSON document in the single column of each row returned by the query
+func listRecords[T any](ctx context.Context, q queryer, query string, args ...any) ([]*T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*T, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var record T
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("decode %T: %w", record, err)
+		}
+		records = append(records, &record)
+	}
+	return records, rows.Err()
+}
+
+// count returns the integer result of the query
+func count(ctx context.Context, q queryer, query string, args ...any) (int, error) {
+	var n int
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// The following functions operate on the tables that hold a single record per charge station
+
+func setChargeStationRecord(ctx context.Context, q queryer, table, chargeStationId string, record any) error {
+	data, err := encode(record)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (charge_station_id, data) VALUES (?, ?)
+			ON CONFLICT (charge_station_id) DO UPDATE SET data = excluded.data`, table),
+		chargeStationId, data)
+	if err != nil {
+		return fmt.Errorf("set %s for %s: %w", table, chargeStationId, err)
+	}
+	return nil
+}
+
+func lookupChargeStationRecord[T any](ctx context.Context, q queryer, table, chargeStationId string) (*T, error) {
+	record, err := getRecord[T](ctx, q,
+		fmt.Sprintf(`SELECT data FROM %s WHERE charge_station_id = ?`, table),
+		chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s for %s: %w", table, chargeStationId, err)
+	}
+	return record, nil
+}
+
+func listChargeStationRecords[T any](ctx context.Context, q queryer, table string, pageSize int, previousChargeStationId string) ([]*T, error) {
+	records, err := listRecords[T](ctx, q,
+		fmt.Sprintf(`SELECT data FROM %s WHERE charge_station_id > ? ORDER BY charge_station_id LIMIT ?`, table),
+		previousChargeStationId, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", table, err)
+	}
+	return records, nil
+}
+
+func deleteChargeStationRecord(ctx context.Context, q queryer, table, chargeStationId string) error {
+	_, err := q.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE charge_station_id = ?`, table),
+		chargeStationId)
+	if err != nil {
+		return fmt.Errorf("delete %s for %s: %w", table, chargeStationId, err)
+	}
+	return nil
+}
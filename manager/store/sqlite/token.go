@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *Store) SetToken(ctx context.Context, token *store.Token) error {
+	token.LastUpdated = s.clock.Now().UTC().Format(time.RFC3339)
+	data, err := encode(token)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO token (uid, data) VALUES (?, ?) ON CONFLICT (uid) DO UPDATE SET data = excluded.data`,
+		token.Uid, data)
+	if err != nil {
+		return fmt.Errorf("set token %s: %w", token.Uid, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupToken(ctx context.Context, tokenUid string) (*store.Token, error) {
+	token, err := getRecord[store.Token](ctx, s.db, `SELECT data FROM token WHERE uid = ?`, tokenUid)
+	if err != nil {
+		return nil, fmt.Errorf("lookup token %s: %w", tokenUid, err)
+	}
+	return token, nil
+}
+
+func (s *Store) ListTokens(ctx context.Context, offset int, limit int) ([]*store.Token, error) {
+	tokens, err := listRecords[store.Token](ctx, s.db,
+		`SELECT data FROM token ORDER BY uid LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+	return tokens, nil
+}
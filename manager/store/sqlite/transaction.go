@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func getTransaction(ctx context.Context, q queryer, chargeStationId, transactionId string) (*store.Transaction, error) {
+	transaction, err := getRecord[store.Transaction](ctx, q,
+		`SELECT data FROM transactions WHERE charge_station_id = ? AND transaction_id = ?`,
+		chargeStationId, transactionId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup transaction %s:%s: %w", chargeStationId, transactionId, err)
+	}
+	return transaction, nil
+}
+
+func setTransaction(ctx context.Context, q queryer, transaction *store.Transaction) error {
+	data, err := encode(transaction)
+	if err != nil {
+		return err
+	}
+	_, err = q.ExecContext(ctx,
+		`INSERT INTO transactions (charge_station_id, transaction_id, ended_seq_no, data) VALUES (?, ?, ?, ?)
+			ON CONFLICT (charge_station_id, transaction_id) DO UPDATE SET ended_seq_no = excluded.ended_seq_no, data = excluded.data`,
+		transaction.ChargeStationId, transaction.TransactionId, transaction.EndedSeqNo, data)
+	if err != nil {
+		return fmt.Errorf("set transaction %s:%s: %w", transaction.ChargeStationId, transaction.TransactionId, err)
+	}
+	return nil
+}
+
+// updateTransaction applies fn to the transaction (or to a new transaction if there is no such
+// transaction) and stores the result
+func (s *Store) updateTransaction(ctx context.Context, chargeStationId, transactionId string, fn func(transaction *store.Transaction, found bool)) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		transaction, err := getTransaction(ctx, tx, chargeStationId, transactionId)
+		if err != nil {
+			return err
+		}
+		found := transaction != nil
+		if !found {
+			transaction = &store.Transaction{
+				ChargeStationId: chargeStationId,
+				TransactionId:   transactionId,
+			}
+		}
+		fn(transaction, found)
+		return setTransaction(ctx, tx, transaction)
+	})
+}
+
+func (s *Store) Transactions(ctx context.Context) ([]*store.Transaction, error) {
+	transactions, err := listRecords[store.Transaction](ctx, s.db,
+		`SELECT data FROM transactions ORDER BY charge_station_id, transaction_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+func (s *Store) FindTransaction(ctx context.Context, chargeStationId, transactionId string) (*store.Transaction, error) {
+	return getTransaction(ctx, s.db, chargeStationId, transactionId)
+}
+
+func (s *Store) FindActiveTransaction(ctx context.Context, chargeStationId string) (*store.Transaction, error) {
+	transaction, err := getRecord[store.Transaction](ctx, s.db,
+		`SELECT data FROM transactions WHERE charge_station_id = ? AND ended_seq_no = 0 ORDER BY transaction_id LIMIT 1`,
+		chargeStationId)
+	if err != nil {
+		return nil, fmt.Errorf("find active transaction for %s: %w", chargeStationId, err)
+	}
+	return transaction, nil
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int, offline bool) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction, _ bool) {
+		transaction.IdToken = idToken
+		transaction.TokenType = tokenType
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		transaction.StartSeqNo = seqNo
+		transaction.Offline = offline
+	})
+}
+
+func (s *Store) UpdateTransaction(ctx context.Context, chargeStationId, transactionId string, meterValues []store.MeterValue) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction, _ bool) {
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		transaction.UpdatedSeqNoCount++
+	})
+}
+
+func (s *Store) UpdateTransactionCost(ctx context.Context, chargeStationId, transactionId string, totalCost float64) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction, _ bool) {
+		transaction.LastCost = &totalCost
+	})
+}
+
+func (s *Store) EndTransaction(ctx context.Context, chargeStationId, transactionId, idToken, tokenType string, meterValues []store.MeterValue, seqNo int) error {
+	return s.updateTransaction(ctx, chargeStationId, transactionId, func(transaction *store.Transaction, found bool) {
+		if !found {
+			transaction.IdToken = idToken
+			transaction.TokenType = tokenType
+		}
+		transaction.MeterValues = append(transaction.MeterValues, meterValues...)
+		transaction.EndedSeqNo = seqNo
+	})
+}
+
+func (s *Store) ListTransactionsForChargeStation(ctx context.Context, chargeStationId, status string, startDate, endDate *time.Time, limit, offset int) ([]*store.Transaction, int64, error) {
+	where := `WHERE charge_station_id = ?`
+	switch status {
+	case "active":
+		where += ` AND ended_seq_no = 0`
+	case "completed":
+		where += ` AND ended_seq_no != 0`
+	}
+
+	total, err := count(ctx, s.db, `SELECT COUNT(*) FROM transactions `+where, chargeStationId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count transactions for %s: %w", chargeStationId, err)
+	}
+
+	transactions, err := listRecords[store.Transaction](ctx, s.db,
+		`SELECT data FROM transactions `+where+` ORDER BY transaction_id LIMIT ? OFFSET ?`,
+		chargeStationId, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list transactions for %s: %w", chargeStationId, err)
+	}
+
+	return transactions, int64(total), nil
+}
+
+func (s *Store) SetRemoteStartTransactionRequest(ctx context.Context, chargeStationId string, request *store.RemoteStartTransactionRequest) error {
+	return setChargeStationRecord(ctx, s.db, "remote_start_transaction_request", chargeStationId, request)
+}
+
+func (s *Store) GetRemoteStartTransactionRequest(ctx context.Context, chargeStationId string) (*store.RemoteStartTransactionRequest, error) {
+	return lookupChargeStationRecord[store.RemoteStartTransactionRequest](ctx, s.db, "remote_start_transaction_request", chargeStationId)
+}
+
+func (s *Store) DeleteRemoteStartTransactionRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "remote_start_transaction_request", chargeStationId)
+}
+
+func (s *Store) ListRemoteStartTransactionRequests(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.RemoteStartTransactionRequest, error) {
+	return listChargeStationRecords[store.RemoteStartTransactionRequest](ctx, s.db, "remote_start_transaction_request", pageSize, previousChargeStationId)
+}
+
+func (s *Store) SetRemoteStopTransactionRequest(ctx context.Context, chargeStationId string, request *store.RemoteStopTransactionRequest) error {
+	return setChargeStationRecord(ctx, s.db, "remote_stop_transaction_request", chargeStationId, request)
+}
+
+func (s *Store) GetRemoteStopTransactionRequest(ctx context.Context, chargeStationId string) (*store.RemoteStopTransactionRequest, error) {
+	return lookupChargeStationRecord[store.RemoteStopTransactionRequest](ctx, s.db, "remote_stop_transaction_request", chargeStationId)
+}
+
+func (s *Store) DeleteRemoteStopTransactionRequest(ctx context.Context, chargeStationId string) error {
+	return deleteChargeStationRecord(ctx, s.db, "remote_stop_transaction_request", chargeStationId)
+}
+
+func (s *Store) ListRemoteStopTransactionRequests(ctx context.Context, pageSize int, previousChargeStationId string) ([]*store.RemoteStopTransactionRequest, error) {
+	return listChargeStationRecords[store.RemoteStopTransactionRequest](ctx, s.db, "remote_stop_transaction_request", pageSize, previousChargeStationId)
+}
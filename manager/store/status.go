@@ -4,9 +4,14 @@ package store
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrChargeStationStatusNotFound is returned by GetChargeStationStatus when no status has been
+// recorded for the charge station
+var ErrChargeStationStatusNotFound = errors.New("not found")
+
 // ConnectorStatusType represents the status of a connector
 type ConnectorStatusType string
 
@@ -68,7 +73,11 @@ type ChargeStationStatus struct {
 	Model           *string
 	Vendor          *string
 	SerialNumber    *string
-	UpdatedAt       time.Time
+	// LastConnected and LastDisconnected record when the gateway last reported that the
+	// charge station's websocket connection was established or closed
+	LastConnected    *time.Time
+	LastDisconnected *time.Time
+	UpdatedAt        time.Time
 }
 
 // StatusStore defines the interface for charge station and connector status tracking
@@ -79,4 +88,7 @@ type StatusStore interface {
 	SetChargeStationStatus(ctx context.Context, chargeStationId string, status *ChargeStationStatus) error
 	GetChargeStationStatus(ctx context.Context, chargeStationId string) (*ChargeStationStatus, error)
 	UpdateHeartbeat(ctx context.Context, chargeStationId string, timestamp time.Time) error
+	// SetChargeStationConnected records that the charge station connected or disconnected at
+	// the given time. The change is ignored if a later connection change has already been recorded.
+	SetChargeStationConnected(ctx context.Context, chargeStationId string, connected bool, timestamp time.Time) error
 }
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCertificate returns a new self-signed PEM certificate and the base64url encoded SHA-256 hash of
+// the certificate that it is stored under
+func newCertificate(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(der)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func (s *suite) testCertificate(t *testing.T) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.LookupCertificate(context.Background(), "missing")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("SetAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		pemCertificate, hash := newCertificate(t, "cs001")
+
+		require.NoError(t, engine.SetCertificate(ctx, pemCertificate))
+
+		got, err := engine.LookupCertificate(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, pemCertificate, got)
+	})
+
+	t.Run("SetIsIdempotent", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		pemCertificate, hash := newCertificate(t, "cs001")
+
+		require.NoError(t, engine.SetCertificate(ctx, pemCertificate))
+		require.NoError(t, engine.SetCertificate(ctx, pemCertificate))
+
+		got, err := engine.LookupCertificate(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, pemCertificate, got)
+	})
+
+	t.Run("SetRejectsNonCertificate", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		err := engine.SetCertificate(context.Background(), "not a certificate")
+		assert.Error(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		pemCertificate1, hash1 := newCertificate(t, "cs001")
+		pemCertificate2, hash2 := newCertificate(t, "cs002")
+
+		require.NoError(t, engine.SetCertificate(ctx, pemCertificate1))
+		require.NoError(t, engine.SetCertificate(ctx, pemCertificate2))
+		require.NoError(t, engine.DeleteCertificate(ctx, hash1))
+
+		got, err := engine.LookupCertificate(ctx, hash1)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		got, err = engine.LookupCertificate(ctx, hash2)
+		require.NoError(t, err)
+		assert.Equal(t, pemCertificate2, got)
+	})
+
+	t.Run("DeleteMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		assert.NoError(t, engine.DeleteCertificate(context.Background(), "missing"))
+	})
+}
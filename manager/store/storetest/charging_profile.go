@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newChargingProfile(chargeStationId string, profileId, connectorId, stackLevel int, purpose store.ChargingProfilePurpose) *store.ChargingProfile {
+	return &store.ChargingProfile{
+		ChargeStationId:        chargeStationId,
+		ConnectorId:            connectorId,
+		ChargingProfileId:      profileId,
+		StackLevel:             stackLevel,
+		ChargingProfilePurpose: purpose,
+		ChargingProfileKind:    store.ChargingProfileKindAbsolute,
+		ChargingSchedule: store.ChargingSchedule{
+			ChargingRateUnit: store.ChargingRateUnitA,
+			ChargingSchedulePeriod: []store.ChargingSchedulePeriod{
+				{StartPeriod: 0, Limit: float64(profileId) * 10},
+				{StartPeriod: 3600, Limit: float64(profileId) * 5, NumberPhases: ptr(3)},
+			},
+			MinChargingRate: ptr(6.0),
+		},
+	}
+}
+
+func (s *suite) testChargingProfile(t *testing.T) {
+	t.Run("GetEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetChargingProfiles(context.Background(), "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		profile := newChargingProfile("cs001", 1, 1, 0, store.ChargingProfilePurposeTxProfile)
+		profile.TransactionId = ptr(42)
+		profile.RecurrencyKind = ptr(store.RecurrencyKindDaily)
+		profile.ValidFrom = ptr(clock.Now())
+		profile.ValidTo = ptr(clock.Now().Add(24 * time.Hour))
+		profile.ChargingSchedule.Duration = ptr(7200)
+		profile.ChargingSchedule.StartSchedule = ptr(clock.Now())
+		require.NoError(t, engine.SetChargingProfile(ctx, profile))
+
+		got, err := engine.GetChargingProfiles(ctx, "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{profile}, got)
+	})
+
+	t.Run("SetReplacesProfileWithSameId", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 1, 1, 0, store.ChargingProfilePurposeTxProfile)))
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 1, 2, 3, store.ChargingProfilePurposeTxDefaultProfile)))
+
+		got, err := engine.GetChargingProfiles(ctx, "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{
+			newChargingProfile("cs001", 1, 2, 3, store.ChargingProfilePurposeTxDefaultProfile),
+		}, got)
+	})
+
+	t.Run("GetFiltersAndOrders", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		// profiles are ordered by stack level and then id
+		p1 := newChargingProfile("cs001", 1, 1, 2, store.ChargingProfilePurposeTxProfile)
+		p2 := newChargingProfile("cs001", 2, 1, 1, store.ChargingProfilePurposeTxDefaultProfile)
+		p3 := newChargingProfile("cs001", 3, 2, 1, store.ChargingProfilePurposeTxProfile)
+		p4 := newChargingProfile("cs001", 4, 0, 0, store.ChargingProfilePurposeChargePointMaxProfile)
+		p5 := newChargingProfile("cs002", 5, 1, 0, store.ChargingProfilePurposeTxProfile)
+		for _, p := range []*store.ChargingProfile{p1, p2, p3, p4, p5} {
+			require.NoError(t, engine.SetChargingProfile(ctx, p))
+		}
+
+		got, err := engine.GetChargingProfiles(ctx, "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p4, p2, p3, p1}, got)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs001", ptr(1), nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p2, p1}, got)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs001", nil, ptr(store.ChargingProfilePurposeTxProfile), nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p3, p1}, got)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs001", nil, nil, ptr(1))
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p2, p3}, got)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs001", ptr(1), ptr(store.ChargingProfilePurposeTxProfile), ptr(2))
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p1}, got)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		p1 := newChargingProfile("cs001", 1, 1, 0, store.ChargingProfilePurposeTxProfile)
+		p2 := newChargingProfile("cs001", 2, 1, 1, store.ChargingProfilePurposeTxDefaultProfile)
+		p3 := newChargingProfile("cs001", 3, 2, 1, store.ChargingProfilePurposeTxDefaultProfile)
+		p4 := newChargingProfile("cs001", 4, 2, 2, store.ChargingProfilePurposeTxProfile)
+		p5 := newChargingProfile("cs002", 5, 1, 0, store.ChargingProfilePurposeTxProfile)
+		for _, p := range []*store.ChargingProfile{p1, p2, p3, p4, p5} {
+			require.NoError(t, engine.SetChargingProfile(ctx, p))
+		}
+
+		n, err := engine.ClearChargingProfile(ctx, "cs001", ptr(1), nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		n, err = engine.ClearChargingProfile(ctx, "cs001", nil, nil, ptr(store.ChargingProfilePurposeTxDefaultProfile), ptr(1))
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		n, err = engine.ClearChargingProfile(ctx, "cs001", ptr(99), nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+
+		got, err := engine.GetChargingProfiles(ctx, "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p4}, got)
+
+		n, err = engine.ClearChargingProfile(ctx, "cs001", nil, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs001", nil, nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		got, err = engine.GetChargingProfiles(ctx, "cs002", nil, nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.ChargingProfile{p5}, got)
+	})
+
+	t.Run("CompositeScheduleWithoutProfiles", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetCompositeSchedule(context.Background(), "cs001", 1, 3600, nil)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("CompositeSchedule", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		// the schedule is taken from the profile with the highest priority purpose and then
+		// the highest stack level that applies to the connector (or the whole charge station)
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 1, 1, 5, store.ChargingProfilePurposeTxProfile)))
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 2, 0, 1, store.ChargingProfilePurposeTxDefaultProfile)))
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 3, 0, 2, store.ChargingProfilePurposeTxDefaultProfile)))
+		require.NoError(t, engine.SetChargingProfile(ctx, newChargingProfile("cs001", 4, 2, 0, store.ChargingProfilePurposeChargePointMaxProfile)))
+
+		got, err := engine.GetCompositeSchedule(ctx, "cs001", 1, 1800, nil)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		require.NotNil(t, got.StartSchedule)
+		assert.WithinDuration(t, clock.Now(), *got.StartSchedule, time.Minute)
+		got.StartSchedule = nil
+		assertEqual(t, &store.ChargingSchedule{
+			Duration:               ptr(1800),
+			ChargingRateUnit:       store.ChargingRateUnitA,
+			ChargingSchedulePeriod: []store.ChargingSchedulePeriod{{StartPeriod: 0, Limit: 30}},
+			MinChargingRate:        ptr(6.0),
+		}, got)
+
+		got, err = engine.GetCompositeSchedule(ctx, "cs001", 1, 1800, ptr(store.ChargingRateUnitW))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newCommand(id, chargeStationId string, createdAt time.Time) *store.Command {
+	return &store.Command{
+		Id:              id,
+		ChargeStationId: chargeStationId,
+		OcppVersion:     "2.0.1",
+		Action:          "Reset",
+		Status:          store.CommandStatusQueued,
+		Request:         `{"type":"Immediate"}`,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+	}
+}
+
+// assertCommand asserts that the commands are the same: the JSON payloads are compared
+// semantically as some stores do not preserve their formatting
+func assertCommand(t *testing.T, want, got *store.Command) {
+	t.Helper()
+	require.NotNil(t, got)
+	wantCommand, gotCommand := *want, *got
+	assert.JSONEq(t, wantCommand.Request, gotCommand.Request)
+	wantCommand.Request, gotCommand.Request = "", ""
+	if wantCommand.Response != nil && gotCommand.Response != nil {
+		assert.JSONEq(t, *wantCommand.Response, *gotCommand.Response)
+		wantCommand.Response, gotCommand.Response = nil, nil
+	}
+	assertEqual(t, wantCommand, gotCommand)
+}
+
+func (s *suite) testCommand(t *testing.T) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.LookupCommand(context.Background(), "cmd001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("CreateAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		command := newCommand("cmd001", "cs001", clock.Now())
+		require.NoError(t, engine.CreateCommand(ctx, command))
+
+		got, err := engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		assertCommand(t, command, got)
+	})
+
+	t.Run("CreateAwaitedAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		command := newCommand("cmd001", "cs001", clock.Now())
+		command.Awaited = true
+		require.NoError(t, engine.CreateCommand(ctx, command))
+
+		got, err := engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		assertCommand(t, command, got)
+	})
+
+	t.Run("SetSent", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd001", "cs001", clock.Now())))
+		sentAt := clock.Now().Add(time.Second)
+		require.NoError(t, engine.SetCommandSent(ctx, "cmd001", sentAt))
+
+		got, err := engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		want := newCommand("cmd001", "cs001", clock.Now())
+		want.Status = store.CommandStatusSent
+		want.UpdatedAt = sentAt
+		assertCommand(t, want, got)
+
+		// a missing command is ignored
+		require.NoError(t, engine.SetCommandSent(ctx, "cmd002", sentAt))
+		got, err = engine.LookupCommand(ctx, "cmd002")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	// the answer can arrive before the command is marked as sent
+	t.Run("SetSentAfterResult", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd001", "cs001", clock.Now())))
+		answeredAt := clock.Now().Add(time.Second)
+		require.NoError(t, engine.SetCommandResult(ctx, "cs001", "cmd001", &store.CommandResult{
+			Status:    store.CommandStatusAccepted,
+			Response:  ptr(`{"status":"Accepted"}`),
+			UpdatedAt: answeredAt,
+		}))
+		require.NoError(t, engine.SetCommandSent(ctx, "cmd001", answeredAt.Add(time.Second)))
+
+		got, err := engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		want := newCommand("cmd001", "cs001", clock.Now())
+		want.Status = store.CommandStatusAccepted
+		want.Response = ptr(`{"status":"Accepted"}`)
+		want.UpdatedAt = answeredAt
+		assertCommand(t, want, got)
+	})
+
+	t.Run("SetResult", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd001", "cs001", clock.Now())))
+		failedAt := clock.Now().Add(time.Second)
+		require.NoError(t, engine.SetCommandResult(ctx, "cs001", "cmd001", &store.CommandResult{
+			Status:           store.CommandStatusFailed,
+			ErrorCode:        ptr("NotSupported"),
+			ErrorDescription: ptr("not supported"),
+			UpdatedAt:        failedAt,
+		}))
+
+		got, err := engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		want := newCommand("cmd001", "cs001", clock.Now())
+		want.Status = store.CommandStatusFailed
+		want.ErrorCode = ptr("NotSupported")
+		want.ErrorDescription = ptr("not supported")
+		want.UpdatedAt = failedAt
+		assertCommand(t, want, got)
+
+		// an answer from another charge station is ignored
+		require.NoError(t, engine.SetCommandResult(ctx, "cs002", "cmd001", &store.CommandResult{
+			Status:    store.CommandStatusAccepted,
+			UpdatedAt: failedAt.Add(time.Second),
+		}))
+		got, err = engine.LookupCommand(ctx, "cmd001")
+		require.NoError(t, err)
+		assertCommand(t, want, got)
+
+		// a missing command is ignored
+		require.NoError(t, engine.SetCommandResult(ctx, "cs001", "cmd002", &store.CommandResult{
+			Status:    store.CommandStatusAccepted,
+			UpdatedAt: failedAt,
+		}))
+		got, err = engine.LookupCommand(ctx, "cmd002")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		got, total, err := engine.ListCommands(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Equal(t, 0, total)
+
+		// commands are listed most recent first: commands created at the same time are listed
+		// in id order
+		for _, command := range []*store.Command{
+			newCommand("cmd002", "cs001", clock.Now().Add(-time.Minute)),
+			newCommand("cmd004", "cs001", clock.Now()),
+			newCommand("cmd001", "cs001", clock.Now().Add(-2*time.Minute)),
+			newCommand("cmd003", "cs001", clock.Now()),
+			newCommand("cmd005", "cs002", clock.Now()),
+		} {
+			require.NoError(t, engine.CreateCommand(ctx, command))
+		}
+
+		ids := func(commands []*store.Command) []string {
+			var ids []string
+			for _, command := range commands {
+				assert.Equal(t, "cs001", command.ChargeStationId)
+				ids = append(ids, command.Id)
+			}
+			return ids
+		}
+
+		got, total, err = engine.ListCommands(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{"cmd003", "cmd004", "cmd002", "cmd001"}, ids(got))
+
+		got, total, err = engine.ListCommands(ctx, "cs001", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{"cmd004", "cmd002"}, ids(got))
+
+		got, total, err = engine.ListCommands(ctx, "cs001", 4, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Empty(t, got)
+	})
+
+	t.Run("TimeOut", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		old := clock.Now().Add(-time.Hour)
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd001", "cs001", old)))
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd002", "cs001", old)))
+		require.NoError(t, engine.SetCommandSent(ctx, "cmd002", old))
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd003", "cs001", old)))
+		require.NoError(t, engine.SetCommandResult(ctx, "cs001", "cmd003", &store.CommandResult{
+			Status:    store.CommandStatusAccepted,
+			UpdatedAt: old,
+		}))
+		require.NoError(t, engine.CreateCommand(ctx, newCommand("cmd004", "cs001", clock.Now())))
+
+		// only queued and sent commands that were last updated before the cut-off time out
+		count, err := engine.TimeOutCommands(ctx, clock.Now().Add(-time.Minute), clock.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		for id, want := range map[string]store.CommandStatus{
+			"cmd001": store.CommandStatusTimedOut,
+			"cmd002": store.CommandStatusTimedOut,
+			"cmd003": store.CommandStatusAccepted,
+			"cmd004": store.CommandStatusQueued,
+		} {
+			got, err := engine.LookupCommand(ctx, id)
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, want, got.Status, id)
+			if want == store.CommandStatusTimedOut {
+				assert.Equal(t, clock.Now(), got.UpdatedAt.UTC(), id)
+			}
+		}
+
+		count, err = engine.TimeOutCommands(ctx, clock.Now().Add(-time.Minute), clock.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,615 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// chargeStationRecord describes a record that is held for each charge station so that
+// the same behaviour can be tested for each type of record
+type chargeStationRecord[T any] struct {
+	// new returns a record for the charge station: records with a different version differ
+	new    func(chargeStationId string, sendAfter time.Time, version int) *T
+	set    func(ctx context.Context, engine store.Engine, chargeStationId string, record *T) error
+	lookup func(ctx context.Context, engine store.Engine, chargeStationId string) (*T, error)
+	// delete is nil if the record cannot be deleted
+	delete func(ctx context.Context, engine store.Engine, chargeStationId string) error
+	// list is nil if the records cannot be listed
+	list func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*T, error)
+}
+
+func (r chargeStationRecord[T]) test(t *testing.T, s *suite) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := r.lookup(context.Background(), engine, "cs001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, r.set(ctx, engine, "cs001", r.new("cs001", clock.Now(), 1)))
+
+		got, err := r.lookup(ctx, engine, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assertEqual(t, r.new("cs001", clock.Now(), 1), got)
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, r.set(ctx, engine, "cs001", r.new("cs001", clock.Now(), 1)))
+		require.NoError(t, r.set(ctx, engine, "cs001", r.new("cs001", clock.Now(), 2)))
+
+		got, err := r.lookup(ctx, engine, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assertEqual(t, r.new("cs001", clock.Now(), 2), got)
+	})
+
+	t.Run("ChargeStationsAreIndependent", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		require.NoError(t, r.set(ctx, engine, "cs001", r.new("cs001", clock.Now(), 1)))
+		require.NoError(t, r.set(ctx, engine, "cs002", r.new("cs002", clock.Now(), 2)))
+
+		got, err := r.lookup(ctx, engine, "cs001")
+		require.NoError(t, err)
+		assertEqual(t, r.new("cs001", clock.Now(), 1), got)
+		got, err = r.lookup(ctx, engine, "cs002")
+		require.NoError(t, err)
+		assertEqual(t, r.new("cs002", clock.Now(), 2), got)
+	})
+
+	if r.delete != nil {
+		t.Run("Delete", func(t *testing.T) {
+			ctx := context.Background()
+			engine, clock := s.setup(t)
+			registerChargeStations(t, engine, "cs001", "cs002")
+
+			require.NoError(t, r.set(ctx, engine, "cs001", r.new("cs001", clock.Now(), 1)))
+			require.NoError(t, r.set(ctx, engine, "cs002", r.new("cs002", clock.Now(), 1)))
+			require.NoError(t, r.delete(ctx, engine, "cs001"))
+
+			got, err := r.lookup(ctx, engine, "cs001")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+			got, err = r.lookup(ctx, engine, "cs002")
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+
+		t.Run("DeleteMissing", func(t *testing.T) {
+			engine, _ := s.setup(t)
+			registerChargeStations(t, engine, "cs001")
+
+			assert.NoError(t, r.delete(context.Background(), engine, "cs001"))
+		})
+	}
+
+	if r.list != nil {
+		t.Run("ListEmpty", func(t *testing.T) {
+			engine, _ := s.setup(t)
+			got, err := r.list(context.Background(), engine, 10, "")
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+
+		t.Run("ListPages", func(t *testing.T) {
+			ctx := context.Background()
+			engine, clock := s.setup(t)
+			// the records are added out of order: they are listed in charge station id order
+			ids := []string{"cs003", "cs001", "cs005", "cs002", "cs004"}
+			registerChargeStations(t, engine, ids...)
+			for _, id := range ids {
+				require.NoError(t, r.set(ctx, engine, id, r.new(id, clock.Now(), 1)))
+			}
+
+			want := func(ids ...string) []*T {
+				var records []*T
+				for _, id := range ids {
+					records = append(records, r.new(id, clock.Now(), 1))
+				}
+				return records
+			}
+
+			got, err := r.list(ctx, engine, 2, "")
+			require.NoError(t, err)
+			assertEqual(t, want("cs001", "cs002"), got)
+
+			got, err = r.list(ctx, engine, 2, "cs002")
+			require.NoError(t, err)
+			assertEqual(t, want("cs003", "cs004"), got)
+
+			got, err = r.list(ctx, engine, 2, "cs004")
+			require.NoError(t, err)
+			assertEqual(t, want("cs005"), got)
+
+			got, err = r.list(ctx, engine, 2, "cs005")
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+
+		t.Run("ListAfterMissingChargeStation", func(t *testing.T) {
+			ctx := context.Background()
+			engine, clock := s.setup(t)
+			ids := []string{"cs001", "cs002", "cs003"}
+			registerChargeStations(t, engine, ids...)
+			for _, id := range ids {
+				require.NoError(t, r.set(ctx, engine, id, r.new(id, clock.Now(), 1)))
+			}
+
+			got, err := r.list(ctx, engine, 10, "cs0015")
+			require.NoError(t, err)
+			assertEqual(t, []*T{r.new("cs002", clock.Now(), 1), r.new("cs003", clock.Now(), 1)}, got)
+		})
+	}
+}
+
+func (s *suite) testChargeStationAuth(t *testing.T) {
+	chargeStationRecord[store.ChargeStationAuth]{
+		new: func(_ string, _ time.Time, version int) *store.ChargeStationAuth {
+			if version == 1 {
+				return &store.ChargeStationAuth{
+					SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+					Base64SHA256Password: "DEADBEEF",
+				}
+			}
+			return &store.ChargeStationAuth{
+				SecurityProfile:        store.TLSWithClientSideCertificates,
+				Base64SHA256Password:   "BEEFDEAD",
+				InvalidUsernameAllowed: true,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationAuth) error {
+			return engine.SetChargeStationAuth(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationAuth, error) {
+			return engine.LookupChargeStationAuth(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationSettings(t *testing.T) {
+	// each version updates the same settings, so an update replaces the previous version
+	chargeStationRecord[store.ChargeStationSettings]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationSettings {
+			status := store.ChargeStationSettingStatusPending
+			if version != 1 {
+				status = store.ChargeStationSettingStatusAccepted
+			}
+			return &store.ChargeStationSettings{
+				ChargeStationId: chargeStationId,
+				Settings: map[string]*store.ChargeStationSetting{
+					"foo": {Value: "bar", Status: status, SendAfter: sendAfter},
+					"baz": {Value: "qux", Status: status, SendAfter: sendAfter},
+				},
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationSettings) error {
+			return engine.UpdateChargeStationSettings(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationSettings, error) {
+			return engine.LookupChargeStationSettings(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationSettings(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationSettings, error) {
+			return engine.ListChargeStationSettings(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+
+	t.Run("UpdateMerges", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		err := engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+			ChargeStationId: "cs001",
+			Settings: map[string]*store.ChargeStationSetting{
+				"foo": {Value: "bar", Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+				"baz": {Value: "qux", Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+			},
+		})
+		require.NoError(t, err)
+		err = engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+			ChargeStationId: "cs001",
+			Settings: map[string]*store.ChargeStationSetting{
+				"foo":  {Value: "bar", Status: store.ChargeStationSettingStatusAccepted, SendAfter: clock.Now()},
+				"quux": {Value: "corge", Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+			},
+		})
+		require.NoError(t, err)
+
+		got, err := engine.LookupChargeStationSettings(ctx, "cs001")
+		require.NoError(t, err)
+		assertEqual(t, &store.ChargeStationSettings{
+			ChargeStationId: "cs001",
+			Settings: map[string]*store.ChargeStationSetting{
+				"foo":  {Value: "bar", Status: store.ChargeStationSettingStatusAccepted, SendAfter: clock.Now()},
+				"baz":  {Value: "qux", Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+				"quux": {Value: "corge", Status: store.ChargeStationSettingStatusPending, SendAfter: clock.Now()},
+			},
+		}, got)
+	})
+}
+
+func (s *suite) testChargeStationRuntimeDetails(t *testing.T) {
+	chargeStationRecord[store.ChargeStationRuntimeDetails]{
+		new: func(_ string, _ time.Time, version int) *store.ChargeStationRuntimeDetails {
+			if version == 1 {
+				return &store.ChargeStationRuntimeDetails{
+					OcppVersion: "1.6",
+				}
+			}
+			return &store.ChargeStationRuntimeDetails{
+				OcppVersion:     "2.0.1",
+				FirmwareVersion: ptr("1.2.3"),
+				Model:           ptr("model"),
+				Vendor:          ptr("vendor"),
+				SerialNumber:    ptr("serial"),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationRuntimeDetails) error {
+			return engine.SetChargeStationRuntimeDetails(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationRuntimeDetails, error) {
+			return engine.LookupChargeStationRuntimeDetails(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationInstallCertificates(t *testing.T) {
+	// each version updates the same certificate, so an update replaces the previous version
+	chargeStationRecord[store.ChargeStationInstallCertificates]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationInstallCertificates {
+			status := store.CertificateInstallationPending
+			if version != 1 {
+				status = store.CertificateInstallationAccepted
+			}
+			return &store.ChargeStationInstallCertificates{
+				ChargeStationId: chargeStationId,
+				Certificates: []*store.ChargeStationInstallCertificate{
+					{
+						CertificateType:               store.CertificateTypeV2G,
+						CertificateId:                 "v2g001",
+						CertificateData:               "v2g-pem-data",
+						CertificateInstallationStatus: status,
+						SendAfter:                     sendAfter,
+					},
+				},
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationInstallCertificates) error {
+			return engine.UpdateChargeStationInstallCertificates(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationInstallCertificates, error) {
+			return engine.LookupChargeStationInstallCertificates(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationInstallCertificates, error) {
+			return engine.ListChargeStationInstallCertificates(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+
+	t.Run("UpdateMerges", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		cert := func(id string, status store.CertificateInstallationStatus) *store.ChargeStationInstallCertificate {
+			return &store.ChargeStationInstallCertificate{
+				CertificateType:               store.CertificateTypeMO,
+				CertificateId:                 id,
+				CertificateData:               id + "-pem-data",
+				CertificateInstallationStatus: status,
+				SendAfter:                     clock.Now(),
+			}
+		}
+
+		err := engine.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+			ChargeStationId: "cs001",
+			Certificates: []*store.ChargeStationInstallCertificate{
+				cert("mo001", store.CertificateInstallationPending),
+				cert("mo002", store.CertificateInstallationPending),
+			},
+		})
+		require.NoError(t, err)
+		err = engine.UpdateChargeStationInstallCertificates(ctx, "cs001", &store.ChargeStationInstallCertificates{
+			ChargeStationId: "cs001",
+			Certificates: []*store.ChargeStationInstallCertificate{
+				cert("mo002", store.CertificateInstallationAccepted),
+				cert("mo003", store.CertificateInstallationPending),
+			},
+		})
+		require.NoError(t, err)
+
+		got, err := engine.LookupChargeStationInstallCertificates(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "cs001", got.ChargeStationId)
+		assert.ElementsMatch(t, normalize([]*store.ChargeStationInstallCertificate{
+			cert("mo001", store.CertificateInstallationPending),
+			cert("mo002", store.CertificateInstallationAccepted),
+			cert("mo003", store.CertificateInstallationPending),
+		}), normalize(got.Certificates))
+	})
+}
+
+func (s *suite) testChargeStationTriggerMessage(t *testing.T) {
+	chargeStationRecord[store.ChargeStationTriggerMessage]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationTriggerMessage {
+			if version == 1 {
+				return &store.ChargeStationTriggerMessage{
+					ChargeStationId: chargeStationId,
+					TriggerMessage:  store.TriggerMessageStatusNotification,
+					ConnectorId:     ptr(1),
+					TriggerStatus:   store.TriggerStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.ChargeStationTriggerMessage{
+				ChargeStationId: chargeStationId,
+				TriggerMessage:  store.TriggerMessageHeartbeat,
+				TriggerStatus:   store.TriggerStatusAccepted,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationTriggerMessage) error {
+			return engine.SetChargeStationTriggerMessage(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationTriggerMessage, error) {
+			return engine.LookupChargeStationTriggerMessage(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationTriggerMessage(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationTriggerMessage, error) {
+			return engine.ListChargeStationTriggerMessages(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationDataTransfer(t *testing.T) {
+	chargeStationRecord[store.ChargeStationDataTransfer]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationDataTransfer {
+			if version == 1 {
+				return &store.ChargeStationDataTransfer{
+					ChargeStationId: chargeStationId,
+					VendorId:        "com.example",
+					MessageId:       ptr("message"),
+					Data:            ptr(`{"foo":"bar"}`),
+					Status:          store.DataTransferStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.ChargeStationDataTransfer{
+				ChargeStationId: chargeStationId,
+				VendorId:        "com.example",
+				Status:          store.DataTransferStatusAccepted,
+				ResponseData:    ptr("response"),
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationDataTransfer) error {
+			return engine.SetChargeStationDataTransfer(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationDataTransfer, error) {
+			return engine.LookupChargeStationDataTransfer(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationDataTransfer(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationDataTransfer, error) {
+			return engine.ListChargeStationDataTransfers(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationClearCache(t *testing.T) {
+	chargeStationRecord[store.ChargeStationClearCache]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationClearCache {
+			status := store.ClearCacheStatusPending
+			if version != 1 {
+				status = store.ClearCacheStatusRejected
+			}
+			return &store.ChargeStationClearCache{
+				ChargeStationId: chargeStationId,
+				Status:          status,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationClearCache) error {
+			return engine.SetChargeStationClearCache(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationClearCache, error) {
+			return engine.LookupChargeStationClearCache(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationClearCache(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationClearCache, error) {
+			return engine.ListChargeStationClearCaches(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationChangeAvailability(t *testing.T) {
+	chargeStationRecord[store.ChargeStationChangeAvailability]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationChangeAvailability {
+			if version == 1 {
+				return &store.ChargeStationChangeAvailability{
+					ChargeStationId: chargeStationId,
+					ConnectorId:     ptr(1),
+					Type:            store.AvailabilityTypeInoperative,
+					Status:          store.AvailabilityStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.ChargeStationChangeAvailability{
+				ChargeStationId: chargeStationId,
+				EvseId:          ptr(2),
+				Type:            store.AvailabilityTypeOperative,
+				Status:          store.AvailabilityStatusScheduled,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationChangeAvailability) error {
+			return engine.SetChargeStationChangeAvailability(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationChangeAvailability, error) {
+			return engine.LookupChargeStationChangeAvailability(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationChangeAvailability(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationChangeAvailability, error) {
+			return engine.ListChargeStationChangeAvailabilities(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationCertificateQuery(t *testing.T) {
+	chargeStationRecord[store.ChargeStationCertificateQuery]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationCertificateQuery {
+			if version == 1 {
+				return &store.ChargeStationCertificateQuery{
+					ChargeStationId: chargeStationId,
+					CertificateType: ptr("V2GRootCertificate"),
+					QueryStatus:     store.CertificateQueryStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.ChargeStationCertificateQuery{
+				ChargeStationId: chargeStationId,
+				QueryStatus:     store.CertificateQueryStatusAccepted,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationCertificateQuery) error {
+			return engine.SetChargeStationCertificateQuery(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationCertificateQuery, error) {
+			return engine.LookupChargeStationCertificateQuery(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationCertificateQuery(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationCertificateQuery, error) {
+			return engine.ListChargeStationCertificateQueries(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testChargeStationCertificateDeletion(t *testing.T) {
+	chargeStationRecord[store.ChargeStationCertificateDeletion]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.ChargeStationCertificateDeletion {
+			status := store.CertificateDeletionStatusPending
+			serialNumber := "1234"
+			if version != 1 {
+				status = store.CertificateDeletionStatusNotFound
+				serialNumber = "5678"
+			}
+			return &store.ChargeStationCertificateDeletion{
+				ChargeStationId: chargeStationId,
+				HashAlgorithm:   "SHA256",
+				IssuerNameHash:  "issuer-name-hash",
+				IssuerKeyHash:   "issuer-key-hash",
+				SerialNumber:    serialNumber,
+				DeletionStatus:  status,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ChargeStationCertificateDeletion) error {
+			return engine.SetChargeStationCertificateDeletion(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ChargeStationCertificateDeletion, error) {
+			return engine.LookupChargeStationCertificateDeletion(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteChargeStationCertificateDeletion(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.ChargeStationCertificateDeletion, error) {
+			return engine.ListChargeStationCertificateDeletions(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testResetRequest(t *testing.T) {
+	chargeStationRecord[store.ResetRequest]{
+		new: func(chargeStationId string, createdAt time.Time, version int) *store.ResetRequest {
+			if version == 1 {
+				return &store.ResetRequest{
+					ChargeStationId: chargeStationId,
+					Type:            store.ResetTypeSoft,
+					Status:          store.ResetRequestStatusPending,
+					CreatedAt:       createdAt,
+					UpdatedAt:       createdAt,
+				}
+			}
+			return &store.ResetRequest{
+				ChargeStationId: chargeStationId,
+				Type:            store.ResetTypeHard,
+				Status:          store.ResetRequestStatusAccepted,
+				CreatedAt:       createdAt,
+				UpdatedAt:       createdAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.ResetRequest) error {
+			return engine.SetResetRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.ResetRequest, error) {
+			return engine.GetResetRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteResetRequest(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testUnlockConnectorRequest(t *testing.T) {
+	chargeStationRecord[store.UnlockConnectorRequest]{
+		new: func(chargeStationId string, createdAt time.Time, version int) *store.UnlockConnectorRequest {
+			if version == 1 {
+				return &store.UnlockConnectorRequest{
+					ChargeStationId: chargeStationId,
+					ConnectorId:     1,
+					Status:          store.UnlockConnectorRequestStatusPending,
+					CreatedAt:       createdAt,
+					UpdatedAt:       createdAt,
+				}
+			}
+			return &store.UnlockConnectorRequest{
+				ChargeStationId: chargeStationId,
+				ConnectorId:     2,
+				Status:          store.UnlockConnectorRequestStatusUnlocked,
+				CreatedAt:       createdAt,
+				UpdatedAt:       createdAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.UnlockConnectorRequest) error {
+			return engine.SetUnlockConnectorRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.UnlockConnectorRequest, error) {
+			return engine.GetUnlockConnectorRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteUnlockConnectorRequest(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
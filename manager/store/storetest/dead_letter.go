@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newDeadLetter(id string, createdAt time.Time) *store.DeadLetter {
+	return &store.DeadLetter{
+		Id:              id,
+		ChargeStationId: "cs001",
+		OcppVersion:     "1.6",
+		MessageType:     "call",
+		Action:          "Heartbeat",
+		MessageId:       "msg-" + id,
+		Message:         `{"action":"Heartbeat","request_payload":{}}`,
+		Error:           "failed to process message",
+		CreatedAt:       createdAt,
+	}
+}
+
+// assertDeadLetter asserts that the dead letters are the same: the messages are compared
+// semantically as some stores do not preserve their formatting
+func assertDeadLetter(t *testing.T, want, got *store.DeadLetter) {
+	t.Helper()
+	require.NotNil(t, got)
+	wantDeadLetter, gotDeadLetter := *want, *got
+	assert.JSONEq(t, wantDeadLetter.Message, gotDeadLetter.Message)
+	wantDeadLetter.Message, gotDeadLetter.Message = "", ""
+	assertEqual(t, wantDeadLetter, gotDeadLetter)
+}
+
+func (s *suite) testDeadLetter(t *testing.T) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.LookupDeadLetter(context.Background(), "dl001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("AddAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		deadLetter := newDeadLetter("dl001", clock.Now())
+		require.NoError(t, engine.AddDeadLetter(ctx, deadLetter))
+
+		got, err := engine.LookupDeadLetter(ctx, "dl001")
+		require.NoError(t, err)
+		assertDeadLetter(t, deadLetter, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.AddDeadLetter(ctx, newDeadLetter("dl001", clock.Now())))
+		require.NoError(t, engine.DeleteDeadLetter(ctx, "dl001"))
+		require.NoError(t, engine.DeleteDeadLetter(ctx, "dl002"))
+
+		got, err := engine.LookupDeadLetter(ctx, "dl001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		got, total, err := engine.ListDeadLetters(ctx, 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Equal(t, 0, total)
+
+		// dead letters are listed most recent first: dead letters created at the same time are
+		// listed in id order
+		for _, deadLetter := range []*store.DeadLetter{
+			newDeadLetter("dl002", clock.Now().Add(-time.Minute)),
+			newDeadLetter("dl004", clock.Now()),
+			newDeadLetter("dl001", clock.Now().Add(-2*time.Minute)),
+			newDeadLetter("dl003", clock.Now()),
+		} {
+			require.NoError(t, engine.AddDeadLetter(ctx, deadLetter))
+		}
+
+		ids := func(deadLetters []*store.DeadLetter) []string {
+			var ids []string
+			for _, deadLetter := range deadLetters {
+				ids = append(ids, deadLetter.Id)
+			}
+			return ids
+		}
+
+		got, total, err = engine.ListDeadLetters(ctx, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{"dl003", "dl004", "dl002", "dl001"}, ids(got))
+
+		got, total, err = engine.ListDeadLetters(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, []string{"dl004", "dl002"}, ids(got))
+
+		got, total, err = engine.ListDeadLetters(ctx, 4, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Empty(t, got)
+	})
+}
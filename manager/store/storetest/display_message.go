@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newDisplayMessage(chargeStationId string, id int, priority store.MessagePriority, state *store.MessageState, createdAt time.Time) *store.DisplayMessage {
+	return &store.DisplayMessage{
+		ChargeStationId: chargeStationId,
+		Id:              id,
+		Priority:        priority,
+		State:           state,
+		Message: store.MessageContent{
+			Content: "Hello",
+			Format:  store.MessageFormatASCII,
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func (s *suite) testDisplayMessage(t *testing.T) {
+	t.Run("GetMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetDisplayMessage(context.Background(), "cs001", 1)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		message := newDisplayMessage("cs001", 1, store.MessagePriorityInFront, ptr(store.MessageStateCharging), clock.Now())
+		message.StartDateTime = ptr(clock.Now())
+		message.EndDateTime = ptr(clock.Now().Add(time.Hour))
+		message.TransactionId = ptr("tx001")
+		message.Message.Language = ptr("en")
+		message.Message.Format = store.MessageFormatHTML
+		require.NoError(t, engine.SetDisplayMessage(ctx, message))
+
+		got, err := engine.GetDisplayMessage(ctx, "cs001", 1)
+		require.NoError(t, err)
+		assertEqual(t, message, got)
+
+		got, err = engine.GetDisplayMessage(ctx, "cs002", 1)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetDisplayMessage(ctx,
+			newDisplayMessage("cs001", 1, store.MessagePriorityInFront, ptr(store.MessageStateCharging), clock.Now())))
+		message := newDisplayMessage("cs001", 1, store.MessagePriorityAlwaysFront, nil, clock.Now())
+		message.Message.Content = "Goodbye"
+		require.NoError(t, engine.SetDisplayMessage(ctx, message))
+
+		got, err := engine.GetDisplayMessage(ctx, "cs001", 1)
+		require.NoError(t, err)
+		assertEqual(t, message, got)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		got, err := engine.ListDisplayMessages(ctx, "cs001", nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		// messages are listed in id order
+		m3 := newDisplayMessage("cs001", 3, store.MessagePriorityNormalCycle, ptr(store.MessageStateCharging), clock.Now())
+		m1 := newDisplayMessage("cs001", 1, store.MessagePriorityInFront, ptr(store.MessageStateCharging), clock.Now())
+		m2 := newDisplayMessage("cs001", 2, store.MessagePriorityInFront, ptr(store.MessageStateIdle), clock.Now())
+		m4 := newDisplayMessage("cs001", 4, store.MessagePriorityInFront, nil, clock.Now())
+		m5 := newDisplayMessage("cs002", 5, store.MessagePriorityInFront, ptr(store.MessageStateCharging), clock.Now())
+		for _, m := range []*store.DisplayMessage{m3, m1, m2, m4, m5} {
+			require.NoError(t, engine.SetDisplayMessage(ctx, m))
+		}
+
+		got, err = engine.ListDisplayMessages(ctx, "cs001", nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.DisplayMessage{m1, m2, m3, m4}, got)
+
+		got, err = engine.ListDisplayMessages(ctx, "cs001", ptr(store.MessageStateCharging), nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.DisplayMessage{m1, m3}, got)
+
+		got, err = engine.ListDisplayMessages(ctx, "cs001", nil, ptr(store.MessagePriorityInFront))
+		require.NoError(t, err)
+		assertEqual(t, []*store.DisplayMessage{m1, m2, m4}, got)
+
+		got, err = engine.ListDisplayMessages(ctx, "cs001", ptr(store.MessageStateCharging), ptr(store.MessagePriorityInFront))
+		require.NoError(t, err)
+		assertEqual(t, []*store.DisplayMessage{m1}, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		for _, id := range []int{1, 2} {
+			require.NoError(t, engine.SetDisplayMessage(ctx,
+				newDisplayMessage("cs001", id, store.MessagePriorityInFront, nil, clock.Now())))
+		}
+		require.NoError(t, engine.DeleteDisplayMessage(ctx, "cs001", 1))
+		require.NoError(t, engine.DeleteDisplayMessage(ctx, "cs001", 99))
+
+		got, err := engine.ListDisplayMessages(ctx, "cs001", nil, nil)
+		require.NoError(t, err)
+		assertEqual(t, []*store.DisplayMessage{
+			newDisplayMessage("cs001", 2, store.MessagePriorityInFront, nil, clock.Now()),
+		}, got)
+	})
+
+	t.Run("DeleteAll", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		for _, id := range []int{1, 2} {
+			require.NoError(t, engine.SetDisplayMessage(ctx,
+				newDisplayMessage("cs001", id, store.MessagePriorityInFront, nil, clock.Now())))
+		}
+		require.NoError(t, engine.SetDisplayMessage(ctx,
+			newDisplayMessage("cs002", 3, store.MessagePriorityInFront, nil, clock.Now())))
+		require.NoError(t, engine.DeleteAllDisplayMessages(ctx, "cs001"))
+
+		got, err := engine.ListDisplayMessages(ctx, "cs001", nil, nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		got, err = engine.ListDisplayMessages(ctx, "cs002", nil, nil)
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+}
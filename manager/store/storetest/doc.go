@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storetest provides the behaviour that every store.Engine implementation is
+// expected to have as a suite of tests that each backend runs against its own engine.
+package storetest
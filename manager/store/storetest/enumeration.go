@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *suite) testEnumeration(t *testing.T) {
+	t.Run("ListChargeStationIds", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		got, err := engine.ListChargeStationIds(ctx, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		registerChargeStations(t, engine, "cs003", "cs001", "cs002")
+
+		got, err = engine.ListChargeStationIds(ctx, 2, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cs001", "cs002"}, got)
+
+		got, err = engine.ListChargeStationIds(ctx, 2, "cs002")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cs003"}, got)
+	})
+
+	t.Run("ListCertificateHashes", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		got, err := engine.ListCertificateHashes(ctx, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		var want []string
+		for _, commonName := range []string{"cs001", "cs002", "cs003"} {
+			pemCertificate, hash := newCertificate(t, commonName)
+			require.NoError(t, engine.SetCertificate(ctx, pemCertificate))
+			want = append(want, hash)
+		}
+		sort.Strings(want)
+
+		got, err = engine.ListCertificateHashes(ctx, 2, "")
+		require.NoError(t, err)
+		assert.Equal(t, want[:2], got)
+
+		got, err = engine.ListCertificateHashes(ctx, 2, want[1])
+		require.NoError(t, err)
+		assert.Equal(t, want[2:], got)
+	})
+
+	t.Run("ListRegistrationTokens", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		got, err := engine.ListRegistrationTokens(ctx, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		for _, token := range []string{"token003", "token001", "token002"} {
+			require.NoError(t, engine.SetRegistrationDetails(ctx, token, &store.OcpiRegistration{
+				Status: store.OcpiRegistrationStatusRegistered,
+			}))
+		}
+
+		got, err = engine.ListRegistrationTokens(ctx, 2, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"token001", "token002"}, got)
+
+		got, err = engine.ListRegistrationTokens(ctx, 2, "token002")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"token003"}, got)
+	})
+
+	t.Run("ListReservations", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		got, err := engine.ListReservations(ctx, 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		// reservations are listed in numeric id order whatever their status
+		for _, reservationId := range []int{10, 2, 1} {
+			require.NoError(t, engine.CreateReservation(ctx,
+				newReservation(reservationId, "cs001", reservationId, clock.Now().Add(time.Hour), clock.Now())))
+		}
+		require.NoError(t, engine.CancelReservation(ctx, 2))
+
+		ids := func(reservations []*store.Reservation) []int {
+			var ids []int
+			for _, reservation := range reservations {
+				ids = append(ids, reservation.ReservationId)
+			}
+			return ids
+		}
+
+		got, err = engine.ListReservations(ctx, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, ids(got))
+		assert.Equal(t, store.ReservationStatusCancelled, got[1].Status)
+
+		got, err = engine.ListReservations(ctx, 2, 2)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assertEqual(t, newReservation(10, "cs001", 10, clock.Now().Add(time.Hour), clock.Now()), got[0])
+	})
+}
@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *suite) testFirmwareUpdateStatus(t *testing.T) {
+	chargeStationRecord[store.FirmwareUpdateStatus]{
+		new: func(chargeStationId string, updatedAt time.Time, version int) *store.FirmwareUpdateStatus {
+			if version == 1 {
+				return &store.FirmwareUpdateStatus{
+					ChargeStationId: chargeStationId,
+					Status:          store.FirmwareUpdateStatusDownloading,
+					Location:        "https://example.com/firmware.bin",
+					RetrieveDate:    updatedAt,
+					UpdatedAt:       updatedAt,
+				}
+			}
+			return &store.FirmwareUpdateStatus{
+				ChargeStationId: chargeStationId,
+				Status:          store.FirmwareUpdateStatusInstalled,
+				Location:        "https://example.com/firmware.bin",
+				RetrieveDate:    updatedAt,
+				RetryCount:      2,
+				UpdatedAt:       updatedAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.FirmwareUpdateStatus) error {
+			return engine.SetFirmwareUpdateStatus(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.FirmwareUpdateStatus, error) {
+			return engine.GetFirmwareUpdateStatus(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testDiagnosticsStatus(t *testing.T) {
+	chargeStationRecord[store.DiagnosticsStatus]{
+		new: func(chargeStationId string, updatedAt time.Time, version int) *store.DiagnosticsStatus {
+			if version == 1 {
+				return &store.DiagnosticsStatus{
+					ChargeStationId: chargeStationId,
+					Status:          store.DiagnosticsStatusUploading,
+					Location:        "https://example.com/diagnostics",
+					UpdatedAt:       updatedAt,
+				}
+			}
+			return &store.DiagnosticsStatus{
+				ChargeStationId: chargeStationId,
+				Status:          store.DiagnosticsStatusUploaded,
+				Location:        "https://example.com/diagnostics",
+				UpdatedAt:       updatedAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.DiagnosticsStatus) error {
+			return engine.SetDiagnosticsStatus(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.DiagnosticsStatus, error) {
+			return engine.GetDiagnosticsStatus(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testPublishFirmwareStatus(t *testing.T) {
+	chargeStationRecord[store.PublishFirmwareStatus]{
+		new: func(chargeStationId string, updatedAt time.Time, version int) *store.PublishFirmwareStatus {
+			if version == 1 {
+				return &store.PublishFirmwareStatus{
+					ChargeStationId: chargeStationId,
+					Status:          store.PublishFirmwareStatusDownloading,
+					Location:        "https://example.com/firmware.bin",
+					Checksum:        "d41d8cd98f00b204e9800998ecf8427e",
+					RequestId:       1,
+					UpdatedAt:       updatedAt,
+				}
+			}
+			return &store.PublishFirmwareStatus{
+				ChargeStationId: chargeStationId,
+				Status:          store.PublishFirmwareStatusPublished,
+				Location:        "https://example.com/firmware.bin",
+				Checksum:        "d41d8cd98f00b204e9800998ecf8427e",
+				RequestId:       2,
+				UpdatedAt:       updatedAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.PublishFirmwareStatus) error {
+			return engine.SetPublishFirmwareStatus(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.PublishFirmwareStatus, error) {
+			return engine.GetPublishFirmwareStatus(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testLogStatus(t *testing.T) {
+	chargeStationRecord[store.LogStatus]{
+		new: func(chargeStationId string, updatedAt time.Time, version int) *store.LogStatus {
+			if version == 1 {
+				return &store.LogStatus{
+					ChargeStationId: chargeStationId,
+					Status:          store.LogStatusUploading,
+					RequestId:       1,
+					UpdatedAt:       updatedAt,
+				}
+			}
+			return &store.LogStatus{
+				ChargeStationId: chargeStationId,
+				Status:          store.LogStatusUploadFailure,
+				RequestId:       2,
+				UpdatedAt:       updatedAt.Add(time.Minute),
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.LogStatus) error {
+			return engine.SetLogStatus(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.LogStatus, error) {
+			return engine.GetLogStatus(ctx, chargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testFirmwareUpdateRequest(t *testing.T) {
+	chargeStationRecord[store.FirmwareUpdateRequest]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.FirmwareUpdateRequest {
+			if version == 1 {
+				return &store.FirmwareUpdateRequest{
+					ChargeStationId: chargeStationId,
+					Location:        "https://example.com/firmware.bin",
+					RetrieveDate:    ptr(sendAfter.Add(time.Hour)),
+					Retries:         ptr(3),
+					RetryInterval:   ptr(60),
+					Status:          store.FirmwareUpdateRequestStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.FirmwareUpdateRequest{
+				ChargeStationId:    chargeStationId,
+				Location:           "https://example.com/firmware-signed.bin",
+				Signature:          ptr("signature"),
+				SigningCertificate: ptr("certificate"),
+				Status:             store.FirmwareUpdateRequestStatusAccepted,
+				SendAfter:          sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.FirmwareUpdateRequest) error {
+			return engine.SetFirmwareUpdateRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.FirmwareUpdateRequest, error) {
+			return engine.GetFirmwareUpdateRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteFirmwareUpdateRequest(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.FirmwareUpdateRequest, error) {
+			return engine.ListFirmwareUpdateRequests(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testDiagnosticsRequest(t *testing.T) {
+	chargeStationRecord[store.DiagnosticsRequest]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.DiagnosticsRequest {
+			if version == 1 {
+				return &store.DiagnosticsRequest{
+					ChargeStationId: chargeStationId,
+					Location:        "https://example.com/diagnostics",
+					StartTime:       ptr(sendAfter.Add(-time.Hour)),
+					StopTime:        ptr(sendAfter),
+					Retries:         ptr(3),
+					RetryInterval:   ptr(60),
+					Status:          store.DiagnosticsRequestStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.DiagnosticsRequest{
+				ChargeStationId: chargeStationId,
+				Location:        "https://example.com/diagnostics",
+				Status:          store.DiagnosticsRequestStatusRejected,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.DiagnosticsRequest) error {
+			return engine.SetDiagnosticsRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.DiagnosticsRequest, error) {
+			return engine.GetDiagnosticsRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteDiagnosticsRequest(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.DiagnosticsRequest, error) {
+			return engine.ListDiagnosticsRequests(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testLogRequest(t *testing.T) {
+	chargeStationRecord[store.LogRequest]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.LogRequest {
+			if version == 1 {
+				return &store.LogRequest{
+					ChargeStationId: chargeStationId,
+					LogType:         "DiagnosticsLog",
+					RequestId:       1,
+					RemoteLocation:  "https://example.com/logs",
+					OldestTimestamp: ptr(sendAfter.Add(-time.Hour)),
+					LatestTimestamp: ptr(sendAfter),
+					Retries:         ptr(3),
+					RetryInterval:   ptr(60),
+					Status:          store.LogRequestStatusPending,
+					SendAfter:       sendAfter,
+				}
+			}
+			return &store.LogRequest{
+				ChargeStationId: chargeStationId,
+				LogType:         "SecurityLog",
+				RequestId:       2,
+				RemoteLocation:  "https://example.com/logs",
+				Status:          store.LogRequestStatusAccepted,
+				SendAfter:       sendAfter,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.LogRequest) error {
+			return engine.SetLogRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.LogRequest, error) {
+			return engine.GetLogRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteLogRequest(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.LogRequest, error) {
+			return engine.ListLogRequests(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newLocalAuthListEntry(idTag string, status string) *store.LocalAuthListEntry {
+	return &store.LocalAuthListEntry{
+		IdTag: idTag,
+		IdTagInfo: &store.IdTagInfo{
+			Status:      status,
+			ExpiryDate:  ptr("2030-01-01T00:00:00Z"),
+			ParentIdTag: ptr("parent"),
+		},
+	}
+}
+
+func (s *suite) testLocalAuthList(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		version, err := engine.GetLocalListVersion(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Equal(t, 0, version)
+
+		entries, err := engine.GetLocalAuthList(ctx, "cs001")
+		require.NoError(t, err)
+		assert.NotNil(t, entries)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("FullUpdateReplacesList", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 1, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag002", store.IdTagStatusAccepted),
+			newLocalAuthListEntry("tag001", store.IdTagStatusAccepted),
+		}))
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs002", 7, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag009", store.IdTagStatusAccepted),
+		}))
+
+		// entries are listed in id tag order
+		entries, err := engine.GetLocalAuthList(ctx, "cs001")
+		require.NoError(t, err)
+		assertEqual(t, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag001", store.IdTagStatusAccepted),
+			newLocalAuthListEntry("tag002", store.IdTagStatusAccepted),
+		}, entries)
+
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 2, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag003", store.IdTagStatusBlocked),
+		}))
+
+		version, err := engine.GetLocalListVersion(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		entries, err = engine.GetLocalAuthList(ctx, "cs001")
+		require.NoError(t, err)
+		assertEqual(t, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag003", store.IdTagStatusBlocked),
+		}, entries)
+
+		version, err = engine.GetLocalListVersion(ctx, "cs002")
+		require.NoError(t, err)
+		assert.Equal(t, 7, version)
+	})
+
+	t.Run("FullUpdateWithoutEntriesClearsList", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 1, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag001", store.IdTagStatusAccepted),
+		}))
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 2, store.LocalAuthListUpdateTypeFull, nil))
+
+		version, err := engine.GetLocalListVersion(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		entries, err := engine.GetLocalAuthList(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("DifferentialUpdate", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 1, store.LocalAuthListUpdateTypeFull, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag001", store.IdTagStatusAccepted),
+			newLocalAuthListEntry("tag002", store.IdTagStatusAccepted),
+		}))
+
+		// entries without id tag info are removed
+		require.NoError(t, engine.UpdateLocalAuthList(ctx, "cs001", 2, store.LocalAuthListUpdateTypeDifferential, []*store.LocalAuthListEntry{
+			{IdTag: "tag001"},
+			newLocalAuthListEntry("tag002", store.IdTagStatusBlocked),
+			newLocalAuthListEntry("tag003", store.IdTagStatusAccepted),
+			{IdTag: "tag004"},
+		}))
+
+		version, err := engine.GetLocalListVersion(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		entries, err := engine.GetLocalAuthList(ctx, "cs001")
+		require.NoError(t, err)
+		assertEqual(t, []*store.LocalAuthListEntry{
+			newLocalAuthListEntry("tag002", store.IdTagStatusBlocked),
+			newLocalAuthListEntry("tag003", store.IdTagStatusAccepted),
+		}, entries)
+	})
+}
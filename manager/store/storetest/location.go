@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newLocation(id string, lastUpdated time.Time) *store.Location {
+	return &store.Location{
+		Address: "1 Main Street",
+		City:    "London",
+		Coordinates: store.GeoLocation{
+			Latitude:  "51.5072",
+			Longitude: "-0.1276",
+		},
+		Country: "GBR",
+		Evses: &[]store.Evse{
+			{
+				Connectors: []store.Connector{
+					{
+						Format:      "CABLE",
+						Id:          "1",
+						MaxAmperage: 32,
+						MaxVoltage:  230,
+						PowerType:   "AC_1_PHASE",
+						Standard:    "IEC_62196_T2",
+						LastUpdated: lastUpdated.Format(time.RFC3339),
+					},
+				},
+				EvseId:      ptr("GB*TWK*E" + id + "*1"),
+				Status:      "AVAILABLE",
+				Uid:         id + "-1",
+				LastUpdated: lastUpdated.Format(time.RFC3339),
+			},
+		},
+		Id:          id,
+		LastUpdated: lastUpdated.Format(time.RFC3339),
+		Name:        "Location " + id,
+		ParkingType: "ON_STREET",
+		PostalCode:  "N1 1AA",
+	}
+}
+
+// assertLocations asserts that the locations are the same: the last updated time of the location may be
+// set by the store, so it only has to be close to the expected time
+func assertLocations(t *testing.T, want, got []*store.Location) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.NotNil(t, got[i])
+		wantLastUpdated, err := time.Parse(time.RFC3339, want[i].LastUpdated)
+		require.NoError(t, err)
+		gotLastUpdated, err := time.Parse(time.RFC3339, got[i].LastUpdated)
+		require.NoError(t, err)
+		assert.WithinDuration(t, wantLastUpdated, gotLastUpdated, time.Minute)
+
+		wantLocation, gotLocation := *want[i], *got[i]
+		wantLocation.LastUpdated, gotLocation.LastUpdated = "", ""
+		assertEqual(t, wantLocation, gotLocation)
+	}
+}
+
+func (s *suite) testLocation(t *testing.T) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.LookupLocation(context.Background(), "loc001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.SetLocation(ctx, newLocation("loc001", clock.Now())))
+
+		got, err := engine.LookupLocation(ctx, "loc001")
+		require.NoError(t, err)
+		assertLocations(t, []*store.Location{newLocation("loc001", clock.Now())}, []*store.Location{got})
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.SetLocation(ctx, newLocation("loc001", clock.Now())))
+		location := newLocation("loc001", clock.Now())
+		location.Name = "Renamed"
+		location.Evses = nil
+		require.NoError(t, engine.SetLocation(ctx, location))
+
+		got, err := engine.LookupLocation(ctx, "loc001")
+		require.NoError(t, err)
+		want := newLocation("loc001", clock.Now())
+		want.Name = "Renamed"
+		want.Evses = nil
+		assertLocations(t, []*store.Location{want}, []*store.Location{got})
+	})
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.ListLocations(context.Background(), 0, 10)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+
+	t.Run("ListPages", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		// locations are listed in id order, not the order they were added
+		for _, i := range []int{3, 1, 5, 2, 4} {
+			require.NoError(t, engine.SetLocation(ctx, newLocation(fmt.Sprintf("loc%03d", i), clock.Now())))
+		}
+
+		want := func(ids ...int) []*store.Location {
+			var locations []*store.Location
+			for _, i := range ids {
+				locations = append(locations, newLocation(fmt.Sprintf("loc%03d", i), clock.Now()))
+			}
+			return locations
+		}
+
+		got, err := engine.ListLocations(ctx, 0, 2)
+		require.NoError(t, err)
+		assertLocations(t, want(1, 2), got)
+
+		got, err = engine.ListLocations(ctx, 2, 2)
+		require.NoError(t, err)
+		assertLocations(t, want(3, 4), got)
+
+		got, err = engine.ListLocations(ctx, 4, 2)
+		require.NoError(t, err)
+		assertLocations(t, want(5), got)
+
+		got, err = engine.ListLocations(ctx, 5, 2)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+}
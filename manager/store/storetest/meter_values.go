@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *suite) testMeterValues(t *testing.T) {
+	t.Run("GetEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetMeterValues(context.Background(), "cs001", 1, 0)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	const (
+		t1 = "2026-01-01T10:01:00Z"
+		t2 = "2026-01-01T10:02:00Z"
+		t3 = "2026-01-01T10:03:00Z"
+		t4 = "2026-01-01T10:04:00Z"
+		t5 = "2026-01-01T10:05:00Z"
+	)
+
+	stored := func(evseId int, transactionId string, timestamp string, value float64) store.StoredMeterValue {
+		return store.StoredMeterValue{
+			ChargeStationId: "cs001",
+			EvseId:          evseId,
+			TransactionId:   transactionId,
+			MeterValue:      meterValue(timestamp, value),
+		}
+	}
+
+	// the meter values are added out of order: they are returned most recent first
+	setup := func(t *testing.T) store.Engine {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "tx001", []store.MeterValue{meterValue(t1, 100), meterValue(t3, 300)}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "", []store.MeterValue{meterValue(t2, 200)}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 2, "", []store.MeterValue{meterValue(t4, 400)}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs002", 1, "tx002", []store.MeterValue{meterValue(t5, 500)}))
+		return engine
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		ctx := context.Background()
+		engine := setup(t)
+
+		got, err := engine.GetMeterValues(ctx, "cs001", 1, 0)
+		require.NoError(t, err)
+		assertEqual(t, []store.StoredMeterValue{
+			stored(1, "tx001", t3, 300),
+			stored(1, "", t2, 200),
+			stored(1, "tx001", t1, 100),
+		}, got)
+
+		got, err = engine.GetMeterValues(ctx, "cs001", 1, 2)
+		require.NoError(t, err)
+		assertEqual(t, []store.StoredMeterValue{
+			stored(1, "tx001", t3, 300),
+			stored(1, "", t2, 200),
+		}, got)
+
+		got, err = engine.GetMeterValues(ctx, "cs001", 3, 0)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		ctx := context.Background()
+		engine := setup(t)
+
+		for name, tc := range map[string]struct {
+			filter store.MeterValuesFilter
+			want   []store.StoredMeterValue
+			total  int
+		}{
+			"all": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", Limit: 10},
+				want: []store.StoredMeterValue{
+					stored(2, "", t4, 400),
+					stored(1, "tx001", t3, 300),
+					stored(1, "", t2, 200),
+					stored(1, "tx001", t1, 100),
+				},
+				total: 4,
+			},
+			"connector": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", ConnectorId: ptr(2), Limit: 10},
+				want:   []store.StoredMeterValue{stored(2, "", t4, 400)},
+				total:  1,
+			},
+			"transaction": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", TransactionId: ptr("tx001"), Limit: 10},
+				want: []store.StoredMeterValue{
+					stored(1, "tx001", t3, 300),
+					stored(1, "tx001", t1, 100),
+				},
+				total: 2,
+			},
+			"time range is inclusive": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", StartTime: ptr(t2), EndTime: ptr(t3), Limit: 10},
+				want: []store.StoredMeterValue{
+					stored(1, "tx001", t3, 300),
+					stored(1, "", t2, 200),
+				},
+				total: 2,
+			},
+			"page": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", Limit: 2, Offset: 1},
+				want: []store.StoredMeterValue{
+					stored(1, "tx001", t3, 300),
+					stored(1, "", t2, 200),
+				},
+				total: 4,
+			},
+			"beyond last page": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs001", Limit: 2, Offset: 4},
+				total:  4,
+			},
+			"unknown charge station": {
+				filter: store.MeterValuesFilter{ChargeStationId: "cs003", Limit: 10},
+				total:  0,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				got, err := engine.QueryMeterValues(ctx, tc.filter)
+				require.NoError(t, err)
+				require.NotNil(t, got)
+				assertEqual(t, tc.want, got.MeterValues)
+				assert.Equal(t, tc.total, got.Total)
+			})
+		}
+	})
+	t.Run("Rollups", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		// the meter values for a bucket are merged across calls, whatever order they arrive in
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "tx001", []store.MeterValue{
+			sampledMeterValue("2026-01-01T10:01:00Z", 1000, ptr(7000.0), ptr(230.0)),
+			sampledMeterValue("2026-01-01T10:07:00Z", 1500, ptr(7200.0), ptr(232.0)),
+		}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "", []store.MeterValue{
+			sampledMeterValue("2026-01-01T11:02:00Z", 2000, nil, nil),
+			sampledMeterValue("2026-01-01T10:03:00Z", 1200, ptr(7400.0), ptr(228.0)),
+		}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 2, "", []store.MeterValue{
+			sampledMeterValue("2026-01-01T10:04:00Z", 100, nil, ptr(231.0)),
+		}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs002", 1, "", []store.MeterValue{
+			sampledMeterValue("2026-01-01T10:02:00Z", 500, nil, nil),
+		}))
+
+		for name, tc := range map[string]struct {
+			filter store.MeterValueRollupsFilter
+			want   []rollupSummary
+		}{
+			"five minutes": {
+				filter: store.MeterValueRollupsFilter{ChargeStationId: "cs001", Interval: store.MeterValueInterval5m},
+				want: []rollupSummary{
+					{1, "2026-01-01T10:00:00Z", 2, ptr(1000.0), ptr(1200.0), ptr(7400.0), ptr(229.0)},
+					{1, "2026-01-01T10:05:00Z", 1, ptr(1500.0), ptr(1500.0), ptr(7200.0), ptr(232.0)},
+					{1, "2026-01-01T11:00:00Z", 1, ptr(2000.0), ptr(2000.0), nil, nil},
+					{2, "2026-01-01T10:00:00Z", 1, ptr(100.0), ptr(100.0), nil, ptr(231.0)},
+				},
+			},
+			"hourly": {
+				filter: store.MeterValueRollupsFilter{ChargeStationId: "cs001", Interval: store.MeterValueInterval1h, ConnectorId: ptr(1)},
+				want: []rollupSummary{
+					{1, "2026-01-01T10:00:00Z", 3, ptr(1000.0), ptr(1500.0), ptr(7400.0), ptr(230.0)},
+					{1, "2026-01-01T11:00:00Z", 1, ptr(2000.0), ptr(2000.0), nil, nil},
+				},
+			},
+			"daily": {
+				filter: store.MeterValueRollupsFilter{ChargeStationId: "cs002", Interval: store.MeterValueInterval1d},
+				want: []rollupSummary{
+					{1, "2026-01-01T00:00:00Z", 1, ptr(500.0), ptr(500.0), nil, nil},
+				},
+			},
+			"start range is inclusive": {
+				filter: store.MeterValueRollupsFilter{
+					ChargeStationId: "cs001",
+					Interval:        store.MeterValueInterval5m,
+					ConnectorId:     ptr(1),
+					StartTime:       ptr(time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC)),
+					EndTime:         ptr(time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)),
+				},
+				want: []rollupSummary{
+					{1, "2026-01-01T10:05:00Z", 1, ptr(1500.0), ptr(1500.0), ptr(7200.0), ptr(232.0)},
+					{1, "2026-01-01T11:00:00Z", 1, ptr(2000.0), ptr(2000.0), nil, nil},
+				},
+			},
+			"unknown charge station": {
+				filter: store.MeterValueRollupsFilter{ChargeStationId: "cs003", Interval: store.MeterValueInterval5m},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				got, err := engine.QueryMeterValueRollups(ctx, tc.filter)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, summariseRollups(got))
+			})
+		}
+	})
+
+	t.Run("RedeliveredMeterValues", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		first := sampledMeterValue("2026-01-01T10:01:00Z", 1000, ptr(7000.0), ptr(230.0))
+		second := sampledMeterValue("2026-01-01T10:02:00Z", 1100, ptr(7200.0), ptr(232.0))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "tx001", []store.MeterValue{first}))
+		// the message is redelivered with another meter value added to it
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "tx001", []store.MeterValue{first, second}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "tx001", []store.MeterValue{first, second}))
+
+		got, err := engine.GetMeterValues(ctx, "cs001", 1, 0)
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+
+		rollups, err := engine.QueryMeterValueRollups(ctx, store.MeterValueRollupsFilter{ChargeStationId: "cs001", Interval: store.MeterValueInterval5m})
+		require.NoError(t, err)
+		assert.Equal(t, []rollupSummary{
+			{1, "2026-01-01T10:00:00Z", 2, ptr(1000.0), ptr(1100.0), ptr(7200.0), ptr(231.0)},
+		}, summariseRollups(rollups))
+	})
+}
+
+// sampledMeterValue returns a meter value with an energy register reading, sent in kWh, and optionally
+// the power and a voltage for each phase
+func sampledMeterValue(timestamp string, energyWh float64, powerW, voltage *float64) store.MeterValue {
+	mv := store.MeterValue{
+		Timestamp: timestamp,
+		SampledValues: []store.SampledValue{
+			{
+				Measurand:     ptr("Energy.Active.Import.Register"),
+				UnitOfMeasure: &store.UnitOfMeasure{Unit: "kWh"},
+				Value:         energyWh / 1000,
+			},
+			{
+				// values for a single phase are not included in the energy
+				Measurand:     ptr("Energy.Active.Import.Register"),
+				Phase:         ptr("L1"),
+				UnitOfMeasure: &store.UnitOfMeasure{Unit: "Wh"},
+				Value:         energyWh / 3,
+			},
+		},
+	}
+	if powerW != nil {
+		mv.SampledValues = append(mv.SampledValues, store.SampledValue{
+			Measurand:     ptr("Power.Active.Import"),
+			UnitOfMeasure: &store.UnitOfMeasure{Unit: "W", Multipler: 3},
+			Value:         *powerW / 1000,
+		})
+	}
+	if voltage != nil {
+		for _, phase := range []string{"L1-N", "L2-N"} {
+			mv.SampledValues = append(mv.SampledValues, store.SampledValue{
+				Measurand:     ptr("Voltage"),
+				Phase:         ptr(phase),
+				UnitOfMeasure: &store.UnitOfMeasure{Unit: "V"},
+				Value:         *voltage,
+			})
+		}
+	}
+	return mv
+}
+
+// rollupSummary holds the parts of a rollup that are compared, as backends return the times in
+// different locations
+type rollupSummary struct {
+	EvseId         int
+	Start          string
+	SampleCount    int
+	EnergyFirst    *float64
+	EnergyLast     *float64
+	MaxPower       *float64
+	AverageVoltage *float64
+}
+
+func summariseRollups(rollups []*store.MeterValueRollup) []rollupSummary {
+	var summaries []rollupSummary
+	for _, r := range rollups {
+		summaries = append(summaries, rollupSummary{
+			EvseId:         r.EvseId,
+			Start:          r.Start.UTC().Format(time.RFC3339),
+			SampleCount:    r.SampleCount,
+			EnergyFirst:    r.EnergyFirst,
+			EnergyLast:     r.EnergyLast,
+			MaxPower:       r.MaxPower,
+			AverageVoltage: r.AverageVoltage(),
+		})
+	}
+	return summaries
+}
@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newVariableMonitoringConfig(chargeStationId string, id int) *store.VariableMonitoringConfig {
+	return &store.VariableMonitoringConfig{
+		Id:                id,
+		ChargeStationId:   chargeStationId,
+		ComponentName:     "EVSE",
+		ComponentInstance: ptr("1"),
+		VariableName:      "Power",
+		VariableInstance:  ptr("Active"),
+		MonitorType:       store.MonitoringTypeUpperThreshold,
+		Value:             7400,
+		Severity:          5,
+		Transaction:       true,
+	}
+}
+
+// assertVariableMonitoringConfigs asserts that the configs are the same: the creation time is set by
+// the store, so it only has to be close to the expected time
+func assertVariableMonitoringConfigs(t *testing.T, createdAt time.Time, want, got []*store.VariableMonitoringConfig) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.NotNil(t, got[i])
+		assert.WithinDuration(t, createdAt, got[i].CreatedAt, time.Minute)
+		wantConfig, gotConfig := *want[i], *got[i]
+		wantConfig.CreatedAt, gotConfig.CreatedAt = time.Time{}, time.Time{}
+		assertEqual(t, wantConfig, gotConfig)
+	}
+}
+
+func (s *suite) testVariableMonitoring(t *testing.T) {
+	t.Run("GetMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetVariableMonitoring(context.Background(), "cs001", 1)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", newVariableMonitoringConfig("cs001", 7)))
+
+		got, err := engine.GetVariableMonitoring(ctx, "cs001", 7)
+		require.NoError(t, err)
+		assertVariableMonitoringConfigs(t, clock.Now(),
+			[]*store.VariableMonitoringConfig{newVariableMonitoringConfig("cs001", 7)},
+			[]*store.VariableMonitoringConfig{got})
+
+		got, err = engine.GetVariableMonitoring(ctx, "cs002", 7)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", newVariableMonitoringConfig("cs001", 7)))
+		config := newVariableMonitoringConfig("cs001", 7)
+		config.MonitorType = store.MonitoringTypeDelta
+		config.Value = 10
+		config.ComponentInstance = nil
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", config))
+
+		got, err := engine.GetVariableMonitoring(ctx, "cs001", 7)
+		require.NoError(t, err)
+		want := newVariableMonitoringConfig("cs001", 7)
+		want.MonitorType = store.MonitoringTypeDelta
+		want.Value = 10
+		want.ComponentInstance = nil
+		assertVariableMonitoringConfigs(t, clock.Now(),
+			[]*store.VariableMonitoringConfig{want},
+			[]*store.VariableMonitoringConfig{got})
+	})
+
+	// a config without an id is given one by the store
+	t.Run("SetAllocatesId", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		config1 := newVariableMonitoringConfig("cs001", 0)
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", config1))
+		config2 := newVariableMonitoringConfig("cs001", 0)
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", config2))
+
+		assert.Positive(t, config1.Id)
+		assert.Positive(t, config2.Id)
+		assert.NotEqual(t, config1.Id, config2.Id)
+
+		got, err := engine.GetVariableMonitoring(ctx, "cs001", config2.Id)
+		require.NoError(t, err)
+		assertVariableMonitoringConfigs(t, clock.Now(),
+			[]*store.VariableMonitoringConfig{newVariableMonitoringConfig("cs001", config2.Id)},
+			[]*store.VariableMonitoringConfig{got})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", newVariableMonitoringConfig("cs001", 1)))
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", newVariableMonitoringConfig("cs001", 2)))
+		require.NoError(t, engine.DeleteVariableMonitoring(ctx, "cs001", 1))
+		require.NoError(t, engine.DeleteVariableMonitoring(ctx, "cs001", 99))
+
+		got, err := engine.GetVariableMonitoring(ctx, "cs001", 1)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		got, err = engine.GetVariableMonitoring(ctx, "cs001", 2)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		got, err := engine.ListVariableMonitoring(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		// configs are listed in id order
+		for _, id := range []int{3, 10, 1, 2} {
+			require.NoError(t, engine.SetVariableMonitoring(ctx, "cs001", newVariableMonitoringConfig("cs001", id)))
+		}
+		require.NoError(t, engine.SetVariableMonitoring(ctx, "cs002", newVariableMonitoringConfig("cs002", 4)))
+
+		want := func(ids ...int) []*store.VariableMonitoringConfig {
+			var configs []*store.VariableMonitoringConfig
+			for _, id := range ids {
+				configs = append(configs, newVariableMonitoringConfig("cs001", id))
+			}
+			return configs
+		}
+
+		got, err = engine.ListVariableMonitoring(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assertVariableMonitoringConfigs(t, clock.Now(), want(1, 2, 3, 10), got)
+
+		got, err = engine.ListVariableMonitoring(ctx, "cs001", 1, 2)
+		require.NoError(t, err)
+		assertVariableMonitoringConfigs(t, clock.Now(), want(2, 3), got)
+
+		got, err = engine.ListVariableMonitoring(ctx, "cs001", 4, 2)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func newChargeStationEvent(timestamp time.Time, eventType string) *store.ChargeStationEvent {
+	return &store.ChargeStationEvent{
+		Timestamp:   timestamp,
+		EventType:   eventType,
+		TechCode:    ptr("tech-code"),
+		TechInfo:    ptr("tech-info"),
+		EventData:   ptr("event-data"),
+		ComponentId: ptr("EVSE"),
+		VariableId:  ptr("Power"),
+		Cleared:     true,
+	}
+}
+
+func (s *suite) testChargeStationEvent(t *testing.T) {
+	t.Run("ListEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, total, err := engine.ListChargeStationEvents(context.Background(), "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("AddAndList", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		// events are listed most recent first, whatever order they were added in
+		e2 := newChargeStationEvent(clock.Now().Add(-2*time.Minute), "Alerting")
+		e1 := newChargeStationEvent(clock.Now().Add(-3*time.Minute), "Delta")
+		e3 := newChargeStationEvent(clock.Now().Add(-time.Minute), "Alerting")
+		for _, e := range []*store.ChargeStationEvent{e2, e1, e3} {
+			require.NoError(t, engine.AddChargeStationEvent(ctx, "cs001", e))
+		}
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs002", newChargeStationEvent(clock.Now(), "Delta")))
+
+		got, total, err := engine.ListChargeStationEvents(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, got, 3)
+
+		ids := map[int]bool{}
+		for i, want := range []*store.ChargeStationEvent{e3, e2, e1} {
+			// the store gives each event an id and records when it was added
+			assert.NotZero(t, got[i].Id)
+			ids[got[i].Id] = true
+			assert.WithinDuration(t, clock.Now(), got[i].CreatedAt, time.Minute)
+
+			want := *want
+			want.Id = got[i].Id
+			want.ChargeStationId = "cs001"
+			want.CreatedAt = got[i].CreatedAt
+			assertEqual(t, &want, got[i])
+		}
+		assert.Len(t, ids, 3)
+
+		got, total, err = engine.ListChargeStationEvents(ctx, "cs001", 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, got, 1)
+		assert.Equal(t, e2.Timestamp, got[0].Timestamp.UTC())
+
+		got, total, err = engine.ListChargeStationEvents(ctx, "cs001", 3, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Empty(t, got)
+	})
+}
+
+func newDeviceReport(requestId int, generatedAt time.Time) *store.DeviceReport {
+	return &store.DeviceReport{
+		RequestId:   requestId,
+		GeneratedAt: generatedAt,
+		ReportType:  ptr("FullInventory"),
+		ReportData:  ptr(`{"reportData":[]}`),
+	}
+}
+
+func (s *suite) testDeviceReport(t *testing.T) {
+	t.Run("ListEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, total, err := engine.ListDeviceReports(context.Background(), "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("AddAndList", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		// reports are listed most recently generated first, whatever order they were added in
+		r2 := newDeviceReport(2, clock.Now().Add(-2*time.Minute))
+		r1 := newDeviceReport(1, clock.Now().Add(-3*time.Minute))
+		r3 := newDeviceReport(3, clock.Now().Add(-time.Minute))
+		for _, r := range []*store.DeviceReport{r2, r1, r3} {
+			require.NoError(t, engine.AddDeviceReport(ctx, "cs001", r))
+		}
+		require.NoError(t, engine.AddDeviceReport(ctx, "cs002", newDeviceReport(4, clock.Now())))
+
+		got, total, err := engine.ListDeviceReports(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, got, 3)
+
+		ids := map[int]bool{}
+		for i, want := range []*store.DeviceReport{r3, r2, r1} {
+			assert.NotZero(t, got[i].Id)
+			ids[got[i].Id] = true
+			assert.WithinDuration(t, clock.Now(), got[i].CreatedAt, time.Minute)
+
+			// the report data is compared semantically as some stores do not preserve its formatting
+			require.NotNil(t, got[i].ReportData)
+			assert.JSONEq(t, *want.ReportData, *got[i].ReportData)
+
+			want, got := *want, *got[i]
+			want.Id = got.Id
+			want.ChargeStationId = "cs001"
+			want.CreatedAt = got.CreatedAt
+			want.ReportData, got.ReportData = nil, nil
+			assertEqual(t, want, got)
+		}
+		assert.Len(t, ids, 3)
+
+		got, total, err = engine.ListDeviceReports(ctx, "cs001", 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, got, 1)
+		assert.Equal(t, 2, got[0].RequestId)
+
+		got, total, err = engine.ListDeviceReports(ctx, "cs001", 3, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Empty(t, got)
+	})
+}
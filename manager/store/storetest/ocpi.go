@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newParty(role, countryCode, partyId string) *store.OcpiParty {
+	return &store.OcpiParty{
+		CountryCode: countryCode,
+		PartyId:     partyId,
+		Role:        role,
+		Url:         "https://" + partyId + ".example.com/ocpi/versions",
+		Token:       "token-" + partyId,
+	}
+}
+
+func (s *suite) testOcpiRegistration(t *testing.T) {
+	t.Run("GetMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetRegistrationDetails(context.Background(), "token001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		require.NoError(t, engine.SetRegistrationDetails(ctx, "token001", &store.OcpiRegistration{
+			Status: store.OcpiRegistrationStatusPending,
+		}))
+		require.NoError(t, engine.SetRegistrationDetails(ctx, "token001", &store.OcpiRegistration{
+			Status: store.OcpiRegistrationStatusRegistered,
+		}))
+
+		got, err := engine.GetRegistrationDetails(ctx, "token001")
+		require.NoError(t, err)
+		assertEqual(t, &store.OcpiRegistration{Status: store.OcpiRegistrationStatusRegistered}, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		require.NoError(t, engine.SetRegistrationDetails(ctx, "token001", &store.OcpiRegistration{
+			Status: store.OcpiRegistrationStatusRegistered,
+		}))
+		require.NoError(t, engine.DeleteRegistrationDetails(ctx, "token001"))
+
+		got, err := engine.GetRegistrationDetails(ctx, "token001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("DeleteMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		assert.NoError(t, engine.DeleteRegistrationDetails(context.Background(), "token001"))
+	})
+}
+
+func (s *suite) testOcpiParty(t *testing.T) {
+	t.Run("GetMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetPartyDetails(context.Background(), "CPO", "GB", "TWK")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		require.NoError(t, engine.SetPartyDetails(ctx, newParty("CPO", "GB", "TWK")))
+
+		got, err := engine.GetPartyDetails(ctx, "CPO", "GB", "TWK")
+		require.NoError(t, err)
+		assertEqual(t, newParty("CPO", "GB", "TWK"), got)
+
+		got, err = engine.GetPartyDetails(ctx, "EMSP", "GB", "TWK")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		require.NoError(t, engine.SetPartyDetails(ctx, newParty("CPO", "GB", "TWK")))
+		party := newParty("CPO", "GB", "TWK")
+		party.Url = "https://new.example.com/ocpi/versions"
+		party.Token = "new-token"
+		require.NoError(t, engine.SetPartyDetails(ctx, party))
+
+		got, err := engine.GetPartyDetails(ctx, "CPO", "GB", "TWK")
+		require.NoError(t, err)
+		assertEqual(t, party, got)
+	})
+
+	t.Run("ListForRole", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+
+		got, err := engine.ListPartyDetailsForRole(ctx, "EMSP")
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+
+		require.NoError(t, engine.SetPartyDetails(ctx, newParty("EMSP", "GB", "AAA")))
+		require.NoError(t, engine.SetPartyDetails(ctx, newParty("EMSP", "NL", "BBB")))
+		require.NoError(t, engine.SetPartyDetails(ctx, newParty("CPO", "GB", "TWK")))
+
+		got, err = engine.ListPartyDetailsForRole(ctx, "EMSP")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*store.OcpiParty{
+			newParty("EMSP", "GB", "AAA"),
+			newParty("EMSP", "NL", "BBB"),
+		}, got)
+	})
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newReservation(reservationId int, chargeStationId string, connectorId int, expiryDate time.Time, createdAt time.Time) *store.Reservation {
+	return &store.Reservation{
+		ReservationId:   reservationId,
+		ChargeStationId: chargeStationId,
+		ConnectorId:     connectorId,
+		IdTag:           "token001",
+		ExpiryDate:      expiryDate,
+		Status:          store.ReservationStatusAccepted,
+		CreatedAt:       createdAt,
+	}
+}
+
+func (s *suite) testReservation(t *testing.T) {
+	t.Run("GetMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.GetReservation(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		reservation := newReservation(1, "cs001", 1, clock.Now().Add(time.Hour), clock.Now())
+		reservation.ParentIdTag = ptr("parent001")
+		require.NoError(t, engine.CreateReservation(ctx, reservation))
+
+		got, err := engine.GetReservation(ctx, 1)
+		require.NoError(t, err)
+		assertEqual(t, reservation, got)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.CreateReservation(ctx, newReservation(1, "cs001", 1, clock.Now().Add(time.Hour), clock.Now())))
+		require.NoError(t, engine.CancelReservation(ctx, 1))
+
+		got, err := engine.GetReservation(ctx, 1)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, store.ReservationStatusCancelled, got.Status)
+	})
+
+	t.Run("CancelMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		assert.Error(t, engine.CancelReservation(context.Background(), 1))
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.CreateReservation(ctx, newReservation(1, "cs001", 1, clock.Now().Add(time.Hour), clock.Now())))
+		require.NoError(t, engine.UpdateReservationStatus(ctx, 1, store.ReservationStatusOccupied))
+
+		got, err := engine.GetReservation(ctx, 1)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, store.ReservationStatusOccupied, got.Status)
+	})
+
+	t.Run("UpdateStatusMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		assert.Error(t, engine.UpdateReservationStatus(context.Background(), 1, store.ReservationStatusFaulted))
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		expiry := clock.Now().Add(time.Hour)
+		r1 := newReservation(1, "cs001", 1, expiry, clock.Now())
+		r2 := newReservation(2, "cs001", 2, expiry, clock.Now().Add(time.Second))
+		r3 := newReservation(3, "cs001", 3, expiry, clock.Now().Add(2*time.Second))
+		r4 := newReservation(4, "cs002", 1, expiry, clock.Now().Add(3*time.Second))
+		for _, r := range []*store.Reservation{r1, r2, r3, r4} {
+			require.NoError(t, engine.CreateReservation(ctx, r))
+		}
+		require.NoError(t, engine.CancelReservation(ctx, 2))
+
+		got, err := engine.GetActiveReservations(ctx, "cs001")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, normalize([]*store.Reservation{r1, r3}), normalize(got))
+
+		got, err = engine.GetActiveReservations(ctx, "cs003")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		byConnector, err := engine.GetReservationByConnector(ctx, "cs001", 3)
+		require.NoError(t, err)
+		assertEqual(t, r3, byConnector)
+
+		byConnector, err = engine.GetReservationByConnector(ctx, "cs001", 2)
+		require.NoError(t, err)
+		assert.Nil(t, byConnector)
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.CreateReservation(ctx, newReservation(1, "cs001", 1, clock.Now().Add(-time.Hour), clock.Now().Add(-2*time.Hour))))
+		require.NoError(t, engine.CreateReservation(ctx, newReservation(2, "cs001", 2, clock.Now().Add(time.Hour), clock.Now())))
+		cancelled := newReservation(3, "cs001", 3, clock.Now().Add(-time.Hour), clock.Now().Add(-2*time.Hour))
+		cancelled.Status = store.ReservationStatusCancelled
+		require.NoError(t, engine.CreateReservation(ctx, cancelled))
+
+		n, err := engine.ExpireReservations(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		for id, want := range map[int]store.ReservationStatus{
+			1: store.ReservationStatusExpired,
+			2: store.ReservationStatusAccepted,
+			3: store.ReservationStatusCancelled,
+		} {
+			got, err := engine.GetReservation(ctx, id)
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, want, got.Status, "reservation %d", id)
+		}
+
+		n, err = engine.ExpireReservations(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
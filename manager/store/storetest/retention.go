@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *suite) testRetention(t *testing.T) {
+	t.Run("DeleteMeterValuesBefore", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "", []store.MeterValue{
+			meterValue("2026-01-01T10:02:00Z", 200),
+			meterValue("2026-01-01T10:04:00Z", 400),
+		}))
+		require.NoError(t, engine.StoreMeterValues(ctx, "cs002", 1, "", []store.MeterValue{
+			meterValue("2026-01-01T10:01:00Z", 100),
+			meterValue("2026-01-01T10:03:00Z", 300),
+		}))
+		before := time.Date(2026, 1, 1, 10, 3, 30, 0, time.UTC)
+
+		// the oldest meter values are deleted first, whichever charge station they belong to
+		deleted, err := engine.DeleteMeterValuesBefore(ctx, before, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.Equal(t, []string{"2026-01-01T10:03:00Z"}, meterValueTimestamps(t, engine, "cs002"))
+
+		deleted, err = engine.DeleteMeterValuesBefore(ctx, before, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		deleted, err = engine.DeleteMeterValuesBefore(ctx, before, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+
+		assert.Equal(t, []string{"2026-01-01T10:04:00Z"}, meterValueTimestamps(t, engine, "cs001"))
+		assert.Empty(t, meterValueTimestamps(t, engine, "cs002"))
+	})
+
+	t.Run("DeleteChargeStationEventsBefore", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		now := clock.Now()
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs001", newChargeStationEvent(now.Add(-2*time.Hour), "Delta")))
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs001", newChargeStationEvent(now.Add(-time.Hour), "Delta")))
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs002", newChargeStationEvent(now.Add(-3*time.Hour), "Alerting")))
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs002", newChargeStationEvent(now, "Alerting")))
+		before := now.Add(-90 * time.Minute)
+
+		deleted, err := engine.DeleteChargeStationEventsBefore(ctx, before, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		got, total, err := engine.ListChargeStationEvents(ctx, "cs002", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, got, 1)
+		assert.True(t, got[0].Timestamp.Equal(now))
+
+		deleted, err = engine.DeleteChargeStationEventsBefore(ctx, before, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		got, total, err = engine.ListChargeStationEvents(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, got, 1)
+		assert.True(t, got[0].Timestamp.Equal(now.Add(-time.Hour)))
+	})
+
+	t.Run("DeleteDeviceReportsBefore", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		now := clock.Now()
+		require.NoError(t, engine.AddDeviceReport(ctx, "cs001", newDeviceReport(1, now.Add(-2*time.Hour))))
+		require.NoError(t, engine.AddDeviceReport(ctx, "cs001", newDeviceReport(2, now)))
+		require.NoError(t, engine.AddDeviceReport(ctx, "cs002", newDeviceReport(1, now.Add(-3*time.Hour))))
+		before := now.Add(-time.Hour)
+
+		deleted, err := engine.DeleteDeviceReportsBefore(ctx, before, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		_, total, err := engine.ListDeviceReports(ctx, "cs002", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+
+		deleted, err = engine.DeleteDeviceReportsBefore(ctx, before, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		got, total, err := engine.ListDeviceReports(ctx, "cs001", 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, got, 1)
+		assert.Equal(t, 2, got[0].RequestId)
+	})
+
+	t.Run("DeleteFinishedTransactionsBefore", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		// a transaction is dated by the last of its meter values
+		createTransaction := func(transactionId string, timestamps ...string) {
+			require.NoError(t, engine.CreateTransaction(ctx, "cs001", transactionId, "token001", "ISO14443",
+				[]store.MeterValue{meterValue(timestamps[0], 0)}, 0, false))
+			if len(timestamps) > 1 {
+				require.NoError(t, engine.EndTransaction(ctx, "cs001", transactionId, "token001", "ISO14443",
+					[]store.MeterValue{meterValue(timestamps[1], 100)}, 1))
+			}
+		}
+		createTransaction("tx001", "2026-01-01T10:00:00Z", "2026-01-01T11:00:00Z")
+		createTransaction("tx002", "2026-01-01T09:00:00Z", "2026-01-01T10:30:00Z")
+		createTransaction("tx003", "2026-01-01T12:00:00Z", "2026-01-01T13:00:00Z")
+		createTransaction("tx004", "2026-01-01T08:00:00Z")
+		before := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		deleted, err := engine.DeleteFinishedTransactionsBefore(ctx, before, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx002")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		deleted, err = engine.DeleteFinishedTransactionsBefore(ctx, before, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		// transactions that ended later and transactions that have not ended are kept
+		for transactionId, kept := range map[string]bool{"tx001": false, "tx003": true, "tx004": true} {
+			got, err := engine.FindTransaction(ctx, "cs001", transactionId)
+			require.NoError(t, err)
+			assert.Equal(t, kept, got != nil, transactionId)
+		}
+	})
+}
+
+// meterValueTimestamps returns the timestamps of the meter values stored for the first EVSE
+// of the charge station, oldest first
+func meterValueTimestamps(t *testing.T, engine store.Engine, chargeStationId string) []string {
+	t.Helper()
+	values, err := engine.GetMeterValues(context.Background(), chargeStationId, 1, 0)
+	require.NoError(t, err)
+
+	var timestamps []string
+	for i := len(values) - 1; i >= 0; i-- {
+		timestamps = append(timestamps, values[i].MeterValue.Timestamp)
+	}
+	return timestamps
+}
@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func (s *suite) testStatus(t *testing.T) {
+	t.Run("GetMissingConnectorStatus", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		_, err := engine.GetConnectorStatus(context.Background(), "cs001", 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("SetAndGetConnectorStatus", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		status := &store.ConnectorStatus{
+			ChargeStationId:      "cs001",
+			ConnectorId:          1,
+			Status:               store.ConnectorStatusCharging,
+			ErrorCode:            store.ConnectorErrorCodeNoError,
+			Info:                 ptr("info"),
+			Timestamp:            ptr(clock.Now()),
+			VendorErrorCode:      ptr("E001"),
+			VendorId:             ptr("com.example"),
+			CurrentTransactionId: ptr("tx001"),
+		}
+		require.NoError(t, engine.SetConnectorStatus(ctx, "cs001", 1, status))
+
+		got, err := engine.GetConnectorStatus(ctx, "cs001", 1)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		// the store records when the status was updated
+		assert.WithinDuration(t, clock.Now(), got.UpdatedAt, time.Minute)
+		got.UpdatedAt = time.Time{}
+		assertEqual(t, status, got)
+
+		_, err = engine.GetConnectorStatus(ctx, "cs001", 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("SetConnectorStatusReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.SetConnectorStatus(ctx, "cs001", 1, &store.ConnectorStatus{
+			ChargeStationId:      "cs001",
+			ConnectorId:          1,
+			Status:               store.ConnectorStatusCharging,
+			ErrorCode:            store.ConnectorErrorCodeNoError,
+			CurrentTransactionId: ptr("tx001"),
+		}))
+		require.NoError(t, engine.SetConnectorStatus(ctx, "cs001", 1, &store.ConnectorStatus{
+			ChargeStationId: "cs001",
+			ConnectorId:     1,
+			Status:          store.ConnectorStatusFaulted,
+			ErrorCode:       store.ConnectorErrorCodeGroundFailure,
+		}))
+
+		got, err := engine.GetConnectorStatus(ctx, "cs001", 1)
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		got.UpdatedAt = time.Time{}
+		assertEqual(t, &store.ConnectorStatus{
+			ChargeStationId: "cs001",
+			ConnectorId:     1,
+			Status:          store.ConnectorStatusFaulted,
+			ErrorCode:       store.ConnectorErrorCodeGroundFailure,
+		}, got)
+	})
+
+	t.Run("ListConnectorStatuses", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		got, err := engine.ListConnectorStatuses(ctx, "cs001")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		// connector statuses are listed in connector id order
+		for _, connectorId := range []int{2, 0, 1} {
+			require.NoError(t, engine.SetConnectorStatus(ctx, "cs001", connectorId, &store.ConnectorStatus{
+				ChargeStationId: "cs001",
+				ConnectorId:     connectorId,
+				Status:          store.ConnectorStatusAvailable,
+				ErrorCode:       store.ConnectorErrorCodeNoError,
+			}))
+		}
+		require.NoError(t, engine.SetConnectorStatus(ctx, "cs002", 1, &store.ConnectorStatus{
+			ChargeStationId: "cs002",
+			ConnectorId:     1,
+			Status:          store.ConnectorStatusUnavailable,
+			ErrorCode:       store.ConnectorErrorCodeNoError,
+		}))
+
+		got, err = engine.ListConnectorStatuses(ctx, "cs001")
+		require.NoError(t, err)
+		var connectorIds []int
+		for _, status := range got {
+			assert.Equal(t, "cs001", status.ChargeStationId)
+			connectorIds = append(connectorIds, status.ConnectorId)
+		}
+		assert.Equal(t, []int{0, 1, 2}, connectorIds)
+	})
+
+	t.Run("GetMissingChargeStationStatus", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		_, err := engine.GetChargeStationStatus(context.Background(), "cs001")
+		assert.ErrorIs(t, err, store.ErrChargeStationStatusNotFound)
+	})
+
+	t.Run("SetAndGetChargeStationStatus", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		status := &store.ChargeStationStatus{
+			ChargeStationId: "cs001",
+			Connected:       true,
+			LastHeartbeat:   ptr(clock.Now()),
+			FirmwareVersion: ptr("1.2.3"),
+			Model:           ptr("model"),
+			Vendor:          ptr("vendor"),
+			SerialNumber:    ptr("serial"),
+		}
+		require.NoError(t, engine.SetChargeStationStatus(ctx, "cs001", status))
+
+		got, err := engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.WithinDuration(t, clock.Now(), got.UpdatedAt, time.Minute)
+		got.UpdatedAt = time.Time{}
+		assertEqual(t, status, got)
+	})
+
+	t.Run("UpdateHeartbeat", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		// a heartbeat creates the status if there isn't one
+		require.NoError(t, engine.UpdateHeartbeat(ctx, "cs001", clock.Now()))
+
+		got, err := engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "cs001", got.ChargeStationId)
+		assert.True(t, got.Connected)
+		require.NotNil(t, got.LastHeartbeat)
+		assert.Equal(t, clock.Now(), got.LastHeartbeat.UTC())
+
+		require.NoError(t, engine.SetChargeStationStatus(ctx, "cs001", &store.ChargeStationStatus{
+			ChargeStationId: "cs001",
+			Connected:       false,
+			Model:           ptr("model"),
+		}))
+		require.NoError(t, engine.UpdateHeartbeat(ctx, "cs001", clock.Now().Add(time.Minute)))
+
+		got, err = engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.True(t, got.Connected)
+		assert.Equal(t, ptr("model"), got.Model)
+		require.NotNil(t, got.LastHeartbeat)
+		assert.Equal(t, clock.Now().Add(time.Minute), got.LastHeartbeat.UTC())
+	})
+
+	t.Run("SetChargeStationConnected", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		connectedAt := clock.Now()
+		disconnectedAt := connectedAt.Add(time.Minute)
+
+		require.NoError(t, engine.SetChargeStationConnected(ctx, "cs001", true, connectedAt))
+
+		got, err := engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.True(t, got.Connected)
+		require.NotNil(t, got.LastConnected)
+		assert.Equal(t, connectedAt, got.LastConnected.UTC())
+		assert.Nil(t, got.LastDisconnected)
+
+		require.NoError(t, engine.SetChargeStationConnected(ctx, "cs001", false, disconnectedAt))
+
+		// a change that happened before the last recorded change is ignored
+		require.NoError(t, engine.SetChargeStationConnected(ctx, "cs001", true, connectedAt.Add(30*time.Second)))
+
+		got, err = engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.False(t, got.Connected)
+		require.NotNil(t, got.LastConnected)
+		assert.Equal(t, connectedAt, got.LastConnected.UTC())
+		require.NotNil(t, got.LastDisconnected)
+		assert.Equal(t, disconnectedAt, got.LastDisconnected.UTC())
+
+		// setting the status does not change the connection times
+		require.NoError(t, engine.SetChargeStationStatus(ctx, "cs001", &store.ChargeStationStatus{
+			ChargeStationId: "cs001",
+			Connected:       false,
+			Vendor:          ptr("vendor"),
+		}))
+
+		got, err = engine.GetChargeStationStatus(ctx, "cs001")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, ptr("vendor"), got.Vendor)
+		require.NotNil(t, got.LastConnected)
+		assert.Equal(t, connectedAt, got.LastConnected.UTC())
+		require.NotNil(t, got.LastDisconnected)
+		assert.Equal(t, disconnectedAt, got.LastDisconnected.UTC())
+	})
+}
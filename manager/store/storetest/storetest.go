@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	clone "github.com/huandu/go-clone/generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"k8s.io/utils/clock"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+// Factory returns a new store.Engine that holds no data. Backends that take a clock
+// should use the clock that they are given.
+type Factory func(t *testing.T, clock clock.PassiveClock) store.Engine
+
+// Run runs the conformance tests against the engines returned by newEngine. Every test
+// is run as a subtest with its own engine.
+func Run(t *testing.T, newEngine Factory) {
+	s := &suite{newEngine: newEngine}
+
+	t.Run("ChargeStationAuth", s.testChargeStationAuth)
+	t.Run("ChargeStationSettings", s.testChargeStationSettings)
+	t.Run("ChargeStationRuntimeDetails", s.testChargeStationRuntimeDetails)
+	t.Run("ChargeStationInstallCertificates", s.testChargeStationInstallCertificates)
+	t.Run("ChargeStationTriggerMessage", s.testChargeStationTriggerMessage)
+	t.Run("ChargeStationDataTransfer", s.testChargeStationDataTransfer)
+	t.Run("ChargeStationClearCache", s.testChargeStationClearCache)
+	t.Run("ChargeStationChangeAvailability", s.testChargeStationChangeAvailability)
+	t.Run("ChargeStationCertificateQuery", s.testChargeStationCertificateQuery)
+	t.Run("ChargeStationCertificateDeletion", s.testChargeStationCertificateDeletion)
+	t.Run("ResetRequest", s.testResetRequest)
+	t.Run("UnlockConnectorRequest", s.testUnlockConnectorRequest)
+	t.Run("Token", s.testToken)
+	t.Run("Transaction", s.testTransaction)
+	t.Run("RemoteStartTransactionRequest", s.testRemoteStartTransactionRequest)
+	t.Run("RemoteStopTransactionRequest", s.testRemoteStopTransactionRequest)
+	t.Run("Certificate", s.testCertificate)
+	t.Run("OcpiRegistration", s.testOcpiRegistration)
+	t.Run("OcpiParty", s.testOcpiParty)
+	t.Run("Location", s.testLocation)
+	t.Run("ChargingProfile", s.testChargingProfile)
+	t.Run("FirmwareUpdateStatus", s.testFirmwareUpdateStatus)
+	t.Run("DiagnosticsStatus", s.testDiagnosticsStatus)
+	t.Run("PublishFirmwareStatus", s.testPublishFirmwareStatus)
+	t.Run("LogStatus", s.testLogStatus)
+	t.Run("FirmwareUpdateRequest", s.testFirmwareUpdateRequest)
+	t.Run("DiagnosticsRequest", s.testDiagnosticsRequest)
+	t.Run("LogRequest", s.testLogRequest)
+	t.Run("LocalAuthList", s.testLocalAuthList)
+	t.Run("Reservation", s.testReservation)
+	t.Run("MeterValues", s.testMeterValues)
+	t.Run("DisplayMessage", s.testDisplayMessage)
+	t.Run("Status", s.testStatus)
+	t.Run("VariableMonitoring", s.testVariableMonitoring)
+	t.Run("ChargeStationEvent", s.testChargeStationEvent)
+	t.Run("DeviceReport", s.testDeviceReport)
+	t.Run("Command", s.testCommand)
+	t.Run("DeadLetter", s.testDeadLetter)
+	t.Run("Enumeration", s.testEnumeration)
+	t.Run("Retention", s.testRetention)
+}
+
+type suite struct {
+	newEngine Factory
+}
+
+// setup returns a new engine and the clock that it was given. Not every backend uses the
+// clock it is given, so the clock is set to the current time (to the second) and tests
+// only rely on it for times that are close to now.
+func (s *suite) setup(t *testing.T) (store.Engine, *clockTest.FakePassiveClock) {
+	clock := clockTest.NewFakePassiveClock(time.Now().UTC().Truncate(time.Second))
+	return s.newEngine(t, clock), clock
+}
+
+// registerChargeStations registers the charge stations: records that belong to a charge
+// station are only stored for registered charge stations
+func registerChargeStations(t *testing.T, engine store.Engine, chargeStationIds ...string) {
+	t.Helper()
+	for _, chargeStationId := range chargeStationIds {
+		err := engine.SetChargeStationAuth(context.Background(), chargeStationId, &store.ChargeStationAuth{
+			SecurityProfile:      store.TLSWithBasicAuth,
+			Base64SHA256Password: "DEADBEEF",
+		})
+		require.NoError(t, err)
+	}
+}
+
+// assertEqual asserts that the records are the same once they have been normalized
+func assertEqual[T any](t *testing.T, want, got T) {
+	t.Helper()
+	assert.Equal(t, normalize(want), normalize(got))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// normalize returns a copy of v that can be compared with records returned by any backend:
+// times are converted to UTC and empty slices and maps are replaced with nil
+func normalize[T any](v T) T {
+	c := clone.Clone(v)
+	normalizeValue(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+func normalizeValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			normalizeValue(v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			if v.CanSet() {
+				v.Set(reflect.ValueOf(v.Interface().(time.Time).UTC()))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				normalizeValue(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			if v.CanSet() {
+				v.Set(reflect.Zero(v.Type()))
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			normalizeValue(v.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			normalizeValue(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			if v.CanSet() {
+				v.Set(reflect.Zero(v.Type()))
+			}
+			return
+		}
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			normalizeValue(value)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+func newToken(uid string, lastUpdated time.Time) *store.Token {
+	return &store.Token{
+		CountryCode:  "GB",
+		PartyId:      "TWK",
+		Type:         "RFID",
+		Uid:          uid,
+		ContractId:   "GBTWK012345678V",
+		VisualNumber: ptr("vis-" + uid),
+		Issuer:       "Thoughtworks",
+		GroupId:      ptr("group"),
+		Valid:        true,
+		LanguageCode: ptr("en"),
+		CacheMode:    store.CacheModeAllowed,
+		LastUpdated:  lastUpdated.Format(time.RFC3339),
+	}
+}
+
+// assertTokens asserts that the tokens are the same: the last updated time is set by the store, so it
+// only has to be close to the expected time
+func assertTokens(t *testing.T, want, got []*store.Token) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.NotNil(t, got[i])
+		wantLastUpdated, err := time.Parse(time.RFC3339, want[i].LastUpdated)
+		require.NoError(t, err)
+		gotLastUpdated, err := time.Parse(time.RFC3339, got[i].LastUpdated)
+		require.NoError(t, err)
+		assert.WithinDuration(t, wantLastUpdated, gotLastUpdated, time.Minute)
+
+		wantToken, gotToken := *want[i], *got[i]
+		wantToken.LastUpdated, gotToken.LastUpdated = "", ""
+		assertEqual(t, wantToken, gotToken)
+	}
+}
+
+func (s *suite) testToken(t *testing.T) {
+	t.Run("LookupMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.LookupToken(context.Background(), "tok001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("SetAndLookup", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.SetToken(ctx, newToken("tok001", clock.Now())))
+
+		got, err := engine.LookupToken(ctx, "tok001")
+		require.NoError(t, err)
+		assertTokens(t, []*store.Token{newToken("tok001", clock.Now())}, []*store.Token{got})
+	})
+
+	t.Run("SetReplaces", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		require.NoError(t, engine.SetToken(ctx, newToken("tok001", clock.Now())))
+		token := newToken("tok001", clock.Now())
+		token.Valid = false
+		token.VisualNumber = nil
+		token.CacheMode = store.CacheModeNever
+		require.NoError(t, engine.SetToken(ctx, token))
+
+		got, err := engine.LookupToken(ctx, "tok001")
+		require.NoError(t, err)
+		want := newToken("tok001", clock.Now())
+		want.Valid = false
+		want.VisualNumber = nil
+		want.CacheMode = store.CacheModeNever
+		assertTokens(t, []*store.Token{want}, []*store.Token{got})
+	})
+
+	t.Run("ListEmpty", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.ListTokens(context.Background(), 0, 10)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+
+	t.Run("ListPages", func(t *testing.T) {
+		ctx := context.Background()
+		engine, clock := s.setup(t)
+
+		// tokens are listed in uid order, not the order they were added
+		for _, i := range []int{3, 1, 5, 2, 4} {
+			require.NoError(t, engine.SetToken(ctx, newToken(fmt.Sprintf("tok%03d", i), clock.Now())))
+		}
+
+		want := func(ids ...int) []*store.Token {
+			var tokens []*store.Token
+			for _, i := range ids {
+				tokens = append(tokens, newToken(fmt.Sprintf("tok%03d", i), clock.Now()))
+			}
+			return tokens
+		}
+
+		got, err := engine.ListTokens(ctx, 0, 2)
+		require.NoError(t, err)
+		assertTokens(t, want(1, 2), got)
+
+		got, err = engine.ListTokens(ctx, 2, 2)
+		require.NoError(t, err)
+		assertTokens(t, want(3, 4), got)
+
+		got, err = engine.ListTokens(ctx, 4, 2)
+		require.NoError(t, err)
+		assertTokens(t, want(5), got)
+
+		got, err = engine.ListTokens(ctx, 5, 2)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+		assert.Empty(t, got)
+	})
+}
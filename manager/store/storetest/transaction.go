@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// activeTransactionFinder is implemented by the stores that can find the transaction in
+// progress at a charge station
+type activeTransactionFinder interface {
+	FindActiveTransaction(ctx context.Context, chargeStationId string) (*store.Transaction, error)
+}
+
+func meterValue(timestamp string, value float64) store.MeterValue {
+	return store.MeterValue{
+		Timestamp: timestamp,
+		SampledValues: []store.SampledValue{
+			{
+				Context:       ptr("Sample.Periodic"),
+				Location:      ptr("Outlet"),
+				Measurand:     ptr("Energy.Active.Import.Register"),
+				UnitOfMeasure: &store.UnitOfMeasure{Unit: "Wh", Multipler: 1},
+				Value:         value,
+			},
+		},
+	}
+}
+
+func (s *suite) testTransaction(t *testing.T) {
+	t.Run("FindMissing", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		got, err := engine.FindTransaction(context.Background(), "cs001", "tx001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("FindForOtherChargeStation", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "token001", "ISO14443", nil, 1, false))
+
+		got, err := engine.FindTransaction(ctx, "cs002", "tx001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		meterValues := []store.MeterValue{meterValue("2026-01-01T10:00:00Z", 100)}
+		require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "token001", "ISO14443", meterValues, 1, true))
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			IdToken:         "token001",
+			TokenType:       "ISO14443",
+			MeterValues:     []store.MeterValue{meterValue("2026-01-01T10:00:00Z", 100)},
+			StartSeqNo:      1,
+			Offline:         true,
+		}, got)
+	})
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "token001", "ISO14443",
+			[]store.MeterValue{meterValue("2026-01-01T10:00:00Z", 100)}, 1, false))
+		require.NoError(t, engine.UpdateTransaction(ctx, "cs001", "tx001",
+			[]store.MeterValue{meterValue("2026-01-01T10:05:00Z", 200)}))
+		require.NoError(t, engine.UpdateTransaction(ctx, "cs001", "tx001",
+			[]store.MeterValue{meterValue("2026-01-01T10:10:00Z", 300)}))
+		require.NoError(t, engine.UpdateTransactionCost(ctx, "cs001", "tx001", 1.25))
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			IdToken:         "token001",
+			TokenType:       "ISO14443",
+			MeterValues: []store.MeterValue{
+				meterValue("2026-01-01T10:00:00Z", 100),
+				meterValue("2026-01-01T10:05:00Z", 200),
+				meterValue("2026-01-01T10:10:00Z", 300),
+			},
+			StartSeqNo:        1,
+			UpdatedSeqNoCount: 2,
+			LastCost:          ptr(1.25),
+		}, got)
+
+		if finder, ok := engine.(activeTransactionFinder); ok {
+			active, err := finder.FindActiveTransaction(ctx, "cs001")
+			require.NoError(t, err)
+			assertEqual(t, got, active)
+		}
+
+		require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx001", "token001", "ISO14443",
+			[]store.MeterValue{meterValue("2026-01-01T10:15:00Z", 400)}, 4))
+
+		got, err = engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			IdToken:         "token001",
+			TokenType:       "ISO14443",
+			MeterValues: []store.MeterValue{
+				meterValue("2026-01-01T10:00:00Z", 100),
+				meterValue("2026-01-01T10:05:00Z", 200),
+				meterValue("2026-01-01T10:10:00Z", 300),
+				meterValue("2026-01-01T10:15:00Z", 400),
+			},
+			StartSeqNo:        1,
+			EndedSeqNo:        4,
+			UpdatedSeqNoCount: 2,
+			LastCost:          ptr(1.25),
+		}, got)
+
+		if finder, ok := engine.(activeTransactionFinder); ok {
+			active, err := finder.FindActiveTransaction(ctx, "cs001")
+			require.NoError(t, err)
+			assert.Nil(t, active)
+		}
+	})
+
+	// messages for a transaction can be received out of order (or the start of the
+	// transaction can be missed altogether), so each update creates the transaction
+	t.Run("UpdateMissing", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.UpdateTransaction(ctx, "cs001", "tx001",
+			[]store.MeterValue{meterValue("2026-01-01T10:05:00Z", 200)}))
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId:   "cs001",
+			TransactionId:     "tx001",
+			MeterValues:       []store.MeterValue{meterValue("2026-01-01T10:05:00Z", 200)},
+			UpdatedSeqNoCount: 1,
+		}, got)
+	})
+
+	t.Run("EndMissing", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.EndTransaction(ctx, "cs001", "tx001", "token001", "ISO14443",
+			[]store.MeterValue{meterValue("2026-01-01T10:15:00Z", 400)}, 2))
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			IdToken:         "token001",
+			TokenType:       "ISO14443",
+			MeterValues:     []store.MeterValue{meterValue("2026-01-01T10:15:00Z", 400)},
+			EndedSeqNo:      2,
+		}, got)
+	})
+
+	t.Run("UpdateCostMissing", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001")
+
+		require.NoError(t, engine.UpdateTransactionCost(ctx, "cs001", "tx001", 2.5))
+
+		got, err := engine.FindTransaction(ctx, "cs001", "tx001")
+		require.NoError(t, err)
+		assertEqual(t, &store.Transaction{
+			ChargeStationId: "cs001",
+			TransactionId:   "tx001",
+			LastCost:        ptr(2.5),
+		}, got)
+	})
+
+	t.Run("FindActiveWithoutTransactions", func(t *testing.T) {
+		engine, _ := s.setup(t)
+		finder, ok := engine.(activeTransactionFinder)
+		if !ok {
+			t.Skip("store does not find active transactions")
+		}
+		got, err := finder.FindActiveTransaction(context.Background(), "cs001")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Transactions", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		got, err := engine.Transactions(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		require.NoError(t, engine.CreateTransaction(ctx, "cs001", "tx001", "token001", "ISO14443", nil, 1, false))
+		require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx002", "token002", "ISO14443", nil, 1, false))
+
+		got, err = engine.Transactions(ctx)
+		require.NoError(t, err)
+		var ids []string
+		for _, transaction := range got {
+			ids = append(ids, transaction.ChargeStationId+":"+transaction.TransactionId)
+		}
+		assert.ElementsMatch(t, []string{"cs001:tx001", "cs002:tx002"}, ids)
+	})
+
+	t.Run("ListForChargeStation", func(t *testing.T) {
+		ctx := context.Background()
+		engine, _ := s.setup(t)
+		registerChargeStations(t, engine, "cs001", "cs002")
+
+		for _, id := range []string{"tx001", "tx002", "tx003", "tx004", "tx005"} {
+			require.NoError(t, engine.CreateTransaction(ctx, "cs001", id, "token001", "ISO14443", nil, 1, false))
+		}
+		for _, id := range []string{"tx002", "tx004"} {
+			require.NoError(t, engine.EndTransaction(ctx, "cs001", id, "token001", "ISO14443", nil, 2))
+		}
+		require.NoError(t, engine.CreateTransaction(ctx, "cs002", "tx006", "token002", "ISO14443", nil, 1, false))
+
+		list := func(status string, limit, offset int) ([]string, int64) {
+			transactions, total, err := engine.ListTransactionsForChargeStation(ctx, "cs001", status, nil, nil, limit, offset)
+			require.NoError(t, err)
+			var ids []string
+			for _, transaction := range transactions {
+				assert.Equal(t, "cs001", transaction.ChargeStationId)
+				ids = append(ids, transaction.TransactionId)
+			}
+			return ids, total
+		}
+
+		// the order is not part of the contract, but the pages must not overlap
+		page1, total := list("", 2, 0)
+		assert.Len(t, page1, 2)
+		assert.Equal(t, int64(5), total)
+		page2, total := list("", 2, 2)
+		assert.Len(t, page2, 2)
+		assert.Equal(t, int64(5), total)
+		page3, total := list("", 2, 4)
+		assert.Len(t, page3, 1)
+		assert.Equal(t, int64(5), total)
+		assert.ElementsMatch(t, []string{"tx001", "tx002", "tx003", "tx004", "tx005"}, append(append(page1, page2...), page3...))
+
+		active, total := list("active", 10, 0)
+		assert.ElementsMatch(t, []string{"tx001", "tx003", "tx005"}, active)
+		assert.Equal(t, int64(3), total)
+
+		completed, total := list("completed", 10, 0)
+		assert.ElementsMatch(t, []string{"tx002", "tx004"}, completed)
+		assert.Equal(t, int64(2), total)
+
+		beyond, total := list("", 10, 10)
+		assert.Empty(t, beyond)
+		assert.Equal(t, int64(5), total)
+	})
+}
+
+func (s *suite) testRemoteStartTransactionRequest(t *testing.T) {
+	chargeStationRecord[store.RemoteStartTransactionRequest]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.RemoteStartTransactionRequest {
+			if version == 1 {
+				return &store.RemoteStartTransactionRequest{
+					ChargeStationId: chargeStationId,
+					IdTag:           "token001",
+					ConnectorId:     ptr(1),
+					ChargingProfile: ptr(`{"chargingProfileId":1}`),
+					Status:          store.RemoteTransactionRequestStatusPending,
+					SendAfter:       sendAfter,
+					RequestType:     store.RemoteTransactionRequestTypeStart,
+				}
+			}
+			return &store.RemoteStartTransactionRequest{
+				ChargeStationId: chargeStationId,
+				IdTag:           "token002",
+				Status:          store.RemoteTransactionRequestStatusAccepted,
+				SendAfter:       sendAfter,
+				RequestType:     store.RemoteTransactionRequestTypeStart,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.RemoteStartTransactionRequest) error {
+			return engine.SetRemoteStartTransactionRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.RemoteStartTransactionRequest, error) {
+			return engine.GetRemoteStartTransactionRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteRemoteStartTransactionRequest(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.RemoteStartTransactionRequest, error) {
+			return engine.ListRemoteStartTransactionRequests(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
+
+func (s *suite) testRemoteStopTransactionRequest(t *testing.T) {
+	chargeStationRecord[store.RemoteStopTransactionRequest]{
+		new: func(chargeStationId string, sendAfter time.Time, version int) *store.RemoteStopTransactionRequest {
+			status := store.RemoteTransactionRequestStatusPending
+			if version != 1 {
+				status = store.RemoteTransactionRequestStatusRejected
+			}
+			return &store.RemoteStopTransactionRequest{
+				ChargeStationId: chargeStationId,
+				TransactionId:   "tx001",
+				Status:          status,
+				SendAfter:       sendAfter,
+				RequestType:     store.RemoteTransactionRequestTypeStop,
+			}
+		},
+		set: func(ctx context.Context, engine store.Engine, chargeStationId string, record *store.RemoteStopTransactionRequest) error {
+			return engine.SetRemoteStopTransactionRequest(ctx, chargeStationId, record)
+		},
+		lookup: func(ctx context.Context, engine store.Engine, chargeStationId string) (*store.RemoteStopTransactionRequest, error) {
+			return engine.GetRemoteStopTransactionRequest(ctx, chargeStationId)
+		},
+		delete: func(ctx context.Context, engine store.Engine, chargeStationId string) error {
+			return engine.DeleteRemoteStopTransactionRequest(ctx, chargeStationId)
+		},
+		list: func(ctx context.Context, engine store.Engine, pageSize int, previousChargeStationId string) ([]*store.RemoteStopTransactionRequest, error) {
+			return engine.ListRemoteStopTransactionRequests(ctx, pageSize, previousChargeStationId)
+		},
+	}.test(t, s)
+}
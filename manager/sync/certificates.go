@@ -13,7 +13,7 @@ import (
 	"k8s.io/utils/clock"
 )
 
-func SyncCertificates(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, runEvery, retryAfter time.Duration) {
+func SyncCertificates(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker, v21CallMaker handlers.CallMaker, runEvery, retryAfter time.Duration) {
 	var previousChargeStationId string
 	for {
 		select {
@@ -43,7 +43,7 @@ func SyncCertificates(ctx context.Context, engine store.Engine, clock clock.Pass
 				if details.OcppVersion == "1.6" {
 					callMaker = v16CallMaker
 				} else {
-					callMaker = v201CallMaker
+					callMaker = ocpp2CallMaker(details.OcppVersion, v201CallMaker, v21CallMaker)
 				}
 
 				csId := pendingCertificateInstallation.ChargeStationId
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// SyncCommandTimeouts marks the commands that have been queued or sent without an answer
+// for longer than timeout as timed out
+func SyncCommandTimeouts(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.CommandStore,
+	clock clock.PassiveClock,
+	runEvery,
+	timeout time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync command timeouts")
+			return
+		case <-time.After(runEvery):
+			func() {
+				ctx, span := tracer.Start(ctx, "sync command timeouts", trace.WithSpanKind(trace.SpanKindInternal))
+				defer span.End()
+				now := clock.Now()
+				count, err := engine.TimeOutCommands(ctx, now.Add(-timeout), now)
+				if err != nil {
+					span.RecordError(err)
+					return
+				}
+				span.SetAttributes(attribute.Int("sync.commands.timed_out", count))
+			}()
+		}
+	}
+}
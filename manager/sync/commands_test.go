@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/utils/clock"
+)
+
+func TestSyncCommandTimeouts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now()
+	for id, updatedAt := range map[string]time.Time{"old": now.Add(-time.Hour), "new": now.Add(time.Hour)} {
+		err := engine.CreateCommand(ctx, &store.Command{
+			Id:              id,
+			ChargeStationId: "cs001",
+			OcppVersion:     "1.6",
+			Action:          "ClearCache",
+			Status:          store.CommandStatusSent,
+			Request:         "{}",
+			CreatedAt:       updatedAt,
+			UpdatedAt:       updatedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	sync.SyncCommandTimeouts(ctx, noop.NewTracerProvider().Tracer(""), engine, clock.RealClock{}, 100*time.Millisecond, time.Minute)
+
+	command, err := engine.LookupCommand(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusTimedOut, command.Status)
+
+	command, err = engine.LookupCommand(context.Background(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, store.CommandStatusSent, command.Status)
+}
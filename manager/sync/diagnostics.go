@@ -18,7 +18,8 @@ func SyncDiagnostics(ctx context.Context,
 	engine store.Engine,
 	clock clock.PassiveClock,
 	v16CallMaker,
-	v201CallMaker handlers.CallMaker,
+	v201CallMaker,
+	v21CallMaker handlers.CallMaker,
 	runEvery,
 	retryAfter time.Duration) {
 	var previousDiagnosticsId string
@@ -30,7 +31,7 @@ func SyncDiagnostics(ctx context.Context,
 			return
 		case <-time.After(runEvery):
 			previousDiagnosticsId = syncDiagnosticsRequests(ctx, engine, clock, v16CallMaker, previousDiagnosticsId, retryAfter)
-			previousLogId = syncLogRequests(ctx, engine, clock, v16CallMaker, v201CallMaker, previousLogId, retryAfter)
+			previousLogId = syncLogRequests(ctx, engine, clock, v16CallMaker, v201CallMaker, v21CallMaker, previousLogId, retryAfter)
 		}
 	}
 }
@@ -132,7 +133,8 @@ func syncLogRequests(ctx context.Context,
 	engine store.Engine,
 	clock clock.PassiveClock,
 	v16CallMaker,
-	v201CallMaker handlers.CallMaker,
+	v201CallMaker,
+	v21CallMaker handlers.CallMaker,
 	previousChargeStationId string,
 	retryAfter time.Duration) string {
 
@@ -197,7 +199,7 @@ func syncLogRequests(ctx context.Context,
 				ocppReq.Log.LatestTimestamp = &latestTimestamp
 			}
 			sendErr = v16CallMaker.Send(ctx, csId, ocppReq)
-		case "2.0.1":
+		case "2.0.1", "2.1":
 			ocppReq := &ocpp201.GetLogRequestJson{
 				LogType:   ocpp201.LogEnumType(req.LogType),
 				RequestId: req.RequestId,
@@ -215,7 +217,7 @@ func syncLogRequests(ctx context.Context,
 				latestTimestamp := req.LatestTimestamp.Format(time.RFC3339)
 				ocppReq.Log.LatestTimestamp = &latestTimestamp
 			}
-			sendErr = v201CallMaker.Send(ctx, csId, ocppReq)
+			sendErr = ocpp2CallMaker(details.OcppVersion, v201CallMaker, v21CallMaker).Send(ctx, csId, ocppReq)
 		default:
 			slog.Warn("unsupported OCPP version for log request",
 				slog.String("chargeStationId", csId),
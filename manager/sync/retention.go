@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+var prunedRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "manager_pruned_records_total",
+	Help: "The number of records deleted because they were older than their retention period",
+}, []string{"kind"})
+
+// Retention configures how long the records of the high-volume stores are kept for
+type Retention struct {
+	// MeterValues is how long meter values are kept for: zero keeps them forever
+	MeterValues time.Duration
+	// ChargeStationEvents is how long charge station events are kept for: zero keeps them forever
+	ChargeStationEvents time.Duration
+	// DeviceReports is how long device reports are kept for: zero keeps them forever
+	DeviceReports time.Duration
+	// Transactions is how long transactions are kept for once they have ended: zero keeps them forever
+	Transactions time.Duration
+	// RunEvery is how often the records are pruned
+	RunEvery time.Duration
+	// BatchSize is the maximum number of records that are deleted at a time
+	BatchSize int
+}
+
+// SyncRetention deletes the records that are older than their retention period. Each kind of
+// record is deleted in batches until there are none left that are too old.
+func SyncRetention(ctx context.Context,
+	tracer trace.Tracer,
+	engine store.RetentionStore,
+	clock clock.PassiveClock,
+	retention Retention) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down sync retention")
+			return
+		case <-time.After(retention.RunEvery):
+			pruneRecords(ctx, tracer, engine, clock, retention)
+		}
+	}
+}
+
+func pruneRecords(ctx context.Context, tracer trace.Tracer, engine store.RetentionStore, clock clock.PassiveClock, retention Retention) {
+	ctx, span := tracer.Start(ctx, "sync retention", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	now := clock.Now()
+	for _, kind := range []struct {
+		name         string
+		period       time.Duration
+		deleteBefore func(ctx context.Context, before time.Time, limit int) (int, error)
+	}{
+		{"meter_values", retention.MeterValues, engine.DeleteMeterValuesBefore},
+		{"charge_station_events", retention.ChargeStationEvents, engine.DeleteChargeStationEventsBefore},
+		{"device_reports", retention.DeviceReports, engine.DeleteDeviceReportsBefore},
+		{"transactions", retention.Transactions, engine.DeleteFinishedTransactionsBefore},
+	} {
+		if kind.period == 0 {
+			continue
+		}
+
+		count, err := prune(ctx, now.Add(-kind.period), retention.BatchSize, kind.deleteBefore)
+		prunedRecords.WithLabelValues(kind.name).Add(float64(count))
+		span.SetAttributes(attribute.Int("sync.retention."+kind.name+".pruned", count))
+		if err != nil {
+			span.RecordError(err)
+			slog.Error("pruning records", "kind", kind.name, "err", err)
+		}
+	}
+}
+
+// prune deletes batches of records until a batch that is not full shows that there are no
+// more records to delete
+func prune(ctx context.Context, before time.Time, batchSize int, deleteBefore func(ctx context.Context, before time.Time, limit int) (int, error)) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		count, err := deleteBefore(ctx, before, batchSize)
+		total += count
+		if err != nil || count < batchSize {
+			return total, err
+		}
+	}
+}
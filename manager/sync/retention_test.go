@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/utils/clock"
+)
+
+func TestSyncRetention(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	now := time.Now().UTC()
+	var meterValues []store.MeterValue
+	for _, age := range []time.Duration{72 * time.Hour, 49 * time.Hour, 48 * time.Hour, time.Hour} {
+		meterValues = append(meterValues, store.MeterValue{Timestamp: now.Add(-age).Format(time.RFC3339)})
+	}
+	require.NoError(t, engine.StoreMeterValues(ctx, "cs001", 1, "", meterValues))
+	for _, age := range []time.Duration{72 * time.Hour, time.Hour} {
+		require.NoError(t, engine.AddChargeStationEvent(ctx, "cs001", &store.ChargeStationEvent{
+			Timestamp: now.Add(-age),
+			EventType: "Delta",
+		}))
+	}
+
+	// events are kept forever as no retention period is set for them
+	sync.SyncRetention(ctx, noop.NewTracerProvider().Tracer(""), engine, clock.RealClock{}, sync.Retention{
+		MeterValues: 24 * time.Hour,
+		RunEvery:    100 * time.Millisecond,
+		BatchSize:   2,
+	})
+
+	got, err := engine.GetMeterValues(context.Background(), "cs001", 1, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, meterValues[3].Timestamp, got[0].MeterValue.Timestamp)
+
+	_, total, err := engine.ListChargeStationEvents(context.Background(), "cs001", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
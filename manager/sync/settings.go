@@ -17,7 +17,7 @@ import (
 	"k8s.io/utils/clock"
 )
 
-func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration) {
+func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveClock, v16CallMaker, v201CallMaker, v21CallMaker handlers.CallMaker, runEvery time.Duration, retryAfter time.Duration) {
 	var previousChargeStationId string
 	for {
 		select {
@@ -72,7 +72,7 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 							}
 						}
 					}
-				case "2.0.1":
+				case "2.0.1", "2.1":
 					var variables []ocpp201.SetVariableDataType
 					for name, setting := range pendingSetting.Settings {
 						slog.Info("updating charge station settings", slog.String("chargeStationId", csId),
@@ -103,7 +103,7 @@ func SyncSettings(ctx context.Context, engine store.Engine, clock clock.PassiveC
 						req := &ocpp201.SetVariablesRequestJson{
 							SetVariableData: variables,
 						}
-						err = v201CallMaker.Send(ctx, csId, req)
+						err = ocpp2CallMaker(details.OcppVersion, v201CallMaker, v21CallMaker).Send(ctx, csId, req)
 						if err != nil {
 							slog.Error("send set variables request", slog.String("err", err.Error()),
 								slog.String("chargeStationId", csId))
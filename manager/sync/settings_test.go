@@ -128,7 +128,7 @@ func TestSyncV16Settings(t *testing.T) {
 	require.NoError(t, err)
 
 	v16CallMaker := &mockCallMaker{engine: engine, updateFn: updateV16StatusToAccepted}
-	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, nil, 100*time.Millisecond, 500*time.Millisecond)
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, nil, nil, 100*time.Millisecond, 500*time.Millisecond)
 
 	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
 	require.NoError(t, err)
@@ -164,7 +164,7 @@ func TestSyncV16SettingsRetryAfterDelay(t *testing.T) {
 
 	updater := updateWithNoResponse{}
 	v16CallMaker := &mockCallMaker{engine: engine, updateFn: updater.update}
-	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, nil, 100*time.Millisecond, 400*time.Millisecond)
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, nil, nil, 100*time.Millisecond, 400*time.Millisecond)
 
 	require.Equal(t, 3, len(updater.updateAttempts))
 	assert.True(t, updater.updateAttempts[1].After(updater.updateAttempts[0].Add(400*time.Millisecond)))
@@ -199,7 +199,7 @@ func TestSyncV201Variables(t *testing.T) {
 	require.NoError(t, err)
 
 	v201CallMaker := &mockCallMaker{engine: engine, updateFn: updateV201StatusToAccepted}
-	sync.SyncSettings(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, nil, v201CallMaker, nil, 100*time.Millisecond, 500*time.Millisecond)
 
 	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
 	require.NoError(t, err)
@@ -216,6 +216,36 @@ func TestSyncV201Variables(t *testing.T) {
 	}
 }
 
+func TestSyncV21Variables(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	engine := inmemory.NewStore(clock.RealClock{})
+
+	err := engine.SetChargeStationRuntimeDetails(ctx, "cs001", &store.ChargeStationRuntimeDetails{
+		OcppVersion: "2.1",
+	})
+	require.NoError(t, err)
+	err = engine.UpdateChargeStationSettings(ctx, "cs001", &store.ChargeStationSettings{
+		Settings: map[string]*store.ChargeStationSetting{
+			"foo/fam": {Value: "bar", Status: "Pending"},
+		},
+	})
+	require.NoError(t, err)
+
+	v201CallMaker := &mockCallMaker{engine: engine}
+	v21CallMaker := &mockCallMaker{engine: engine, updateFn: updateV201StatusToAccepted}
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, nil, v201CallMaker, v21CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+
+	settings, err := engine.LookupChargeStationSettings(ctx, "cs001")
+	require.NoError(t, err)
+	assert.Len(t, settings.Settings, 1)
+	for _, v := range settings.Settings {
+		assert.Equal(t, store.ChargeStationSettingStatusAccepted, v.Status)
+	}
+	assert.Empty(t, v201CallMaker.callEvents)
+	assert.Len(t, v21CallMaker.callEvents, 1)
+}
+
 func TestSyncV201SettingsRetryAfterDelay(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
@@ -235,7 +265,7 @@ func TestSyncV201SettingsRetryAfterDelay(t *testing.T) {
 
 	updater := updateWithNoResponse{}
 	v201CallMaker := &mockCallMaker{engine: engine, updateFn: updater.update}
-	sync.SyncSettings(ctx, engine, clock.RealClock{}, nil, v201CallMaker, 100*time.Millisecond, 400*time.Millisecond)
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, nil, v201CallMaker, nil, 100*time.Millisecond, 400*time.Millisecond)
 
 	require.Equal(t, 3, len(updater.updateAttempts))
 	assert.True(t, updater.updateAttempts[1].After(updater.updateAttempts[0].Add(400*time.Millisecond)))
@@ -290,7 +320,7 @@ func TestSyncSettingsWithManyChargeStations(t *testing.T) {
 
 	v16CallMaker := &mockCallMaker{engine: engine, updateFn: updateV16StatusToAccepted}
 	v201CallMaker := &mockCallMaker{engine: engine, updateFn: updateV201StatusToAccepted}
-	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, v201CallMaker, 100*time.Millisecond, 500*time.Millisecond)
+	sync.SyncSettings(ctx, engine, clock.RealClock{}, v16CallMaker, v201CallMaker, nil, 100*time.Millisecond, 500*time.Millisecond)
 
 	settings, err := engine.LookupChargeStationSettings(ctx, "cs133")
 	require.NoError(t, err)
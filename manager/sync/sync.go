@@ -6,24 +6,28 @@ import (
 	"context"
 	"time"
 
+	"github.com/thoughtworks/maeve-csms/manager/handlers"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp16"
 	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp201"
+	"github.com/thoughtworks/maeve-csms/manager/handlers/ocpp21"
 	"github.com/thoughtworks/maeve-csms/manager/store"
 	"github.com/thoughtworks/maeve-csms/manager/transport"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/utils/clock"
 )
 
-func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter) {
+func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tracer, emitter transport.Emitter, retention Retention) {
 	v16SyncCallMaker := ocpp16.NewCallMaker(emitter)
 	dataTransferCallMaker := ocpp16.NewDataTransferCallMaker(emitter)
 	v201SyncCallMaker := ocpp201.NewCallMaker(emitter)
+	v21SyncCallMaker := ocpp21.NewCallMaker(emitter)
 
 	go SyncSettings(context.Background(),
 		storageEngine,
 		clock,
 		v16SyncCallMaker,
 		v201SyncCallMaker,
+		v21SyncCallMaker,
 		1*time.Minute,
 		2*time.Minute)
 	go SyncCertificates(context.Background(),
@@ -31,6 +35,7 @@ func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tra
 		clock,
 		dataTransferCallMaker,
 		v201SyncCallMaker,
+		v21SyncCallMaker,
 		1*time.Minute,
 		2*time.Minute)
 	go SyncTriggers(context.Background(),
@@ -40,6 +45,27 @@ func Sync(storageEngine store.Engine, clock clock.PassiveClock, tracer trace.Tra
 		v16SyncCallMaker,
 		dataTransferCallMaker,
 		v201SyncCallMaker,
+		v21SyncCallMaker,
 		1*time.Minute,
 		2*time.Minute)
+	go SyncCommandTimeouts(context.Background(),
+		tracer,
+		storageEngine,
+		clock,
+		1*time.Minute,
+		5*time.Minute)
+	go SyncRetention(context.Background(),
+		tracer,
+		storageEngine,
+		clock,
+		retention)
+}
+
+// ocpp2CallMaker returns the call maker to use for a charge station that uses the OCPP 2.0.1
+// messages: OCPP 2.1 charge stations accept the same messages but on a different subprotocol
+func ocpp2CallMaker(ocppVersion string, v201CallMaker, v21CallMaker handlers.CallMaker) handlers.CallMaker {
+	if ocppVersion == "2.1" {
+		return v21CallMaker
+	}
+	return v201CallMaker
 }
@@ -23,7 +23,8 @@ func SyncTriggers(ctx context.Context,
 	clock clock.PassiveClock,
 	v16CallMaker,
 	dataTransferCallMaker,
-	v201CallMaker handlers.CallMaker,
+	v201CallMaker,
+	v21CallMaker handlers.CallMaker,
 	runEvery,
 	retryAfter time.Duration) {
 	var previousChargeStationId string
@@ -112,7 +113,7 @@ func SyncTriggers(ctx context.Context,
 										Id: *pendingTriggerMessage.ConnectorId,
 									}
 								}
-								err = v201CallMaker.Send(ctx, csId, &ocpp201.TriggerMessageRequestJson{
+								err = ocpp2CallMaker(details.OcppVersion, v201CallMaker, v21CallMaker).Send(ctx, csId, &ocpp201.TriggerMessageRequestJson{
 									RequestedMessage: ocpp201.MessageTriggerEnumType(pendingTriggerMessage.TriggerMessage),
 									Evse:             evse,
 								})
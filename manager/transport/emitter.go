@@ -12,6 +12,7 @@ type OcppVersion string
 const (
 	OcppVersion16  OcppVersion = "ocpp1.6"   // OCPP 1.6
 	OcppVersion201 OcppVersion = "ocpp2.0.1" // OCPP 2.0.1
+	OcppVersion21  OcppVersion = "ocpp2.1"   // OCPP 2.1
 )
 
 // Emitter defines the contract for sending messages to the gateway.
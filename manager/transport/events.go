@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import (
+	"context"
+	"time"
+)
+
+type ConnectionEventType string
+
+const (
+	ConnectionEventConnected    ConnectionEventType = "connected"
+	ConnectionEventDisconnected ConnectionEventType = "disconnected"
+)
+
+// ConnectionEvent is published by the gateway when a charge station's websocket
+// connection is established or closed.
+type ConnectionEvent struct {
+	Type            ConnectionEventType `json:"type"`
+	ChargeStationId string              `json:"clientId"`
+	Protocol        string              `json:"protocol"`
+	SecurityProfile int                 `json:"securityProfile"`
+	RemoteAddr      string              `json:"remoteAddr,omitempty"`
+	TLSCertHash     string              `json:"tlsCertHash,omitempty"`
+	CloseCode       int                 `json:"closeCode,omitempty"`
+	CloseReason     string              `json:"closeReason,omitempty"`
+	GatewayId       string              `json:"gatewayId,omitempty"`
+	Timestamp       time.Time           `json:"timestamp"`
+}
+
+type ConnectionEventHandler interface {
+	// HandleConnectionEvent is called for each connection event published by the gateway.
+	HandleConnectionEvent(ctx context.Context, event *ConnectionEvent)
+}
+
+type ConnectionEventHandlerFunc func(ctx context.Context, event *ConnectionEvent)
+
+func (h ConnectionEventHandlerFunc) HandleConnectionEvent(ctx context.Context, event *ConnectionEvent) {
+	h(ctx, event)
+}
@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/exp/slog"
+)
+
+// Outbound is a message from the manager to a charge station
+type Outbound struct {
+	Context         context.Context
+	OcppVersion     transport.OcppVersion
+	ChargeStationId string
+	Message         *transport.Message
+}
+
+type inbound struct {
+	ctx             context.Context
+	chargeStationId string
+	message         *transport.Message
+}
+
+type event struct {
+	ctx   context.Context
+	event *transport.ConnectionEvent
+}
+
+// Broker is an implementation of transport.Emitter and transport.Listener that
+// exchanges messages with a gateway running in the same process using channels.
+//
+// The gateway passes the messages from the charge stations to Publish and the
+// connection events to PublishEvent, and receives the messages for the charge
+// stations from the Outbound channel. Listening to all charge stations shares a
+// channel per OCPP version between the connections, so each message is handled
+// by a single connection: the equivalent of an MQTT shared subscription.
+// Messages from charge stations are discarded if nothing is listening for them.
+type Broker struct {
+	bufLen int
+	tracer trace.Tracer
+	out    chan *Outbound
+
+	mu        sync.Mutex
+	in        map[transport.OcppVersion]chan *inbound
+	listeners map[transport.OcppVersion]int
+	stations  map[string][]chan *inbound
+	events    chan *event
+	eventSubs int
+}
+
+type Opt func(*Broker)
+
+// WithBufLen sets the number of messages that are held in each direction before
+// publishing blocks
+func WithBufLen(bufLen int) Opt {
+	return func(b *Broker) {
+		b.bufLen = bufLen
+	}
+}
+
+func WithOtelTracer(tracer trace.Tracer) Opt {
+	return func(b *Broker) {
+		b.tracer = tracer
+	}
+}
+
+func NewBroker(opts ...Opt) *Broker {
+	b := &Broker{
+		bufLen:    100,
+		in:        make(map[transport.OcppVersion]chan *inbound),
+		listeners: make(map[transport.OcppVersion]int),
+		stations:  make(map[string][]chan *inbound),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.tracer == nil {
+		b.tracer = noop.NewTracerProvider().Tracer("")
+	}
+	b.out = make(chan *Outbound, b.bufLen)
+	b.events = make(chan *event, b.bufLen)
+	return b
+}
+
+// Emit queues a message for the gateway, blocking whilst the gateway has not received
+// the messages that were queued before it
+func (b *Broker) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	newCtx, span := b.tracer.Start(ctx,
+		fmt.Sprintf("out/%s publish", ocppVersion),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("inprocess"),
+			semconv.MessagingOperationKey.String("publish"),
+			semconv.MessagingMessageConversationID(message.MessageId),
+			attribute.String("csId", chargeStationId),
+		))
+	defer span.End()
+
+	select {
+	case b.out <- &Outbound{Context: newCtx, OcppVersion: ocppVersion, ChargeStationId: chargeStationId, Message: message}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("emitting message to %s: %w", chargeStationId, ctx.Err())
+	}
+}
+
+// Outbound returns the channel that the gateway receives the messages for the charge
+// stations from
+func (b *Broker) Outbound() <-chan *Outbound {
+	return b.out
+}
+
+// Publish passes a message from a charge station to the listeners, blocking whilst the
+// listeners have not received the messages that were published before it. The message
+// is handled with the values of ctx, but is not cancelled with it.
+func (b *Broker) Publish(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	msg := &inbound{ctx: context.WithoutCancel(ctx), chargeStationId: chargeStationId, message: message}
+
+	b.mu.Lock()
+	for _, ch := range b.stations[stationKey(ocppVersion, chargeStationId)] {
+		select {
+		case ch <- msg:
+		default:
+			slog.Warn("charge station listener buffer full - dropping message", "chargeStationId", chargeStationId)
+		}
+	}
+	listening := b.listeners[ocppVersion] > 0
+	ch := b.inbound(ocppVersion)
+	b.mu.Unlock()
+
+	if !listening {
+		slog.Debug("no listener - discarding message", "ocppVersion", ocppVersion, "chargeStationId", chargeStationId)
+		return nil
+	}
+
+	select {
+	case ch <- msg:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("publishing message from %s: %w", chargeStationId, ctx.Err())
+	}
+}
+
+// PublishEvent passes a connection event to the listeners
+func (b *Broker) PublishEvent(ctx context.Context, connectionEvent *transport.ConnectionEvent) error {
+	b.mu.Lock()
+	listening := b.eventSubs > 0
+	b.mu.Unlock()
+
+	if !listening {
+		return nil
+	}
+
+	select {
+	case b.events <- &event{ctx: context.WithoutCancel(ctx), event: connectionEvent}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("publishing connection event for %s: %w", connectionEvent.ChargeStationId, ctx.Err())
+	}
+}
+
+func (b *Broker) Connect(_ context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
+	conn := &connection{done: make(chan struct{})}
+
+	var ch chan *inbound
+	b.mu.Lock()
+	if chargeStationId != nil {
+		key := stationKey(ocppVersion, *chargeStationId)
+		ch = make(chan *inbound, b.bufLen)
+		b.stations[key] = append(b.stations[key], ch)
+		conn.release = func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			subs := b.stations[key]
+			for i, sub := range subs {
+				if sub == ch {
+					b.stations[key] = append(subs[:i:i], subs[i+1:]...)
+					break
+				}
+			}
+			if len(b.stations[key]) == 0 {
+				delete(b.stations, key)
+			}
+		}
+	} else {
+		ch = b.inbound(ocppVersion)
+		b.listeners[ocppVersion]++
+		conn.release = func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.listeners[ocppVersion]--
+		}
+	}
+	b.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case msg := <-ch:
+				b.handle(ocppVersion, msg, handler)
+			case <-conn.done:
+				return
+			}
+		}
+	}()
+
+	return conn, nil
+}
+
+func (b *Broker) ConnectEvents(_ context.Context, handler transport.ConnectionEventHandler) (transport.Connection, error) {
+	conn := &connection{done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.eventSubs++
+	b.mu.Unlock()
+	conn.release = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.eventSubs--
+	}
+
+	go func() {
+		for {
+			select {
+			case e := <-b.events:
+				newCtx, span := b.tracer.Start(e.ctx, "events receive",
+					trace.WithSpanKind(trace.SpanKindConsumer),
+					trace.WithAttributes(
+						semconv.MessagingSystem("inprocess"),
+						semconv.MessagingOperationKey.String("receive"),
+						attribute.String("csId", e.event.ChargeStationId),
+						attribute.String("connection.event", string(e.event.Type)),
+					))
+				handler.HandleConnectionEvent(newCtx, e.event)
+				span.End()
+			case <-conn.done:
+				return
+			}
+		}
+	}()
+
+	return conn, nil
+}
+
+func (b *Broker) handle(ocppVersion transport.OcppVersion, msg *inbound, handler transport.MessageHandler) {
+	version, _ := strings.CutPrefix(string(ocppVersion), "ocpp")
+	newCtx, span := b.tracer.Start(msg.ctx,
+		fmt.Sprintf("in/%s receive", ocppVersion),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("inprocess"),
+			semconv.MessagingOperationKey.String("receive"),
+			semconv.MessagingMessageConversationID(msg.message.MessageId),
+			attribute.String("csId", msg.chargeStationId),
+			attribute.String("ocpp.version", version),
+			attribute.String(fmt.Sprintf("%s.action", msg.message.MessageType), msg.message.Action),
+		))
+	defer span.End()
+
+	handler.Handle(newCtx, msg.chargeStationId, msg.message)
+}
+
+// inbound returns the channel shared by the listeners for the OCPP version: b.mu must be held
+func (b *Broker) inbound(ocppVersion transport.OcppVersion) chan *inbound {
+	ch, ok := b.in[ocppVersion]
+	if !ok {
+		ch = make(chan *inbound, b.bufLen)
+		b.in[ocppVersion] = ch
+	}
+	return ch
+}
+
+func stationKey(ocppVersion transport.OcppVersion, chargeStationId string) string {
+	return string(ocppVersion) + "/" + chargeStationId
+}
+
+type connection struct {
+	once    sync.Once
+	done    chan struct{}
+	release func()
+}
+
+func (c *connection) Disconnect(_ context.Context) error {
+	c.once.Do(func() {
+		close(c.done)
+		c.release()
+	})
+	return nil
+}
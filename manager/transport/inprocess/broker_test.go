@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inprocess_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/inprocess"
+)
+
+func TestBrokerSharesMessagesBetweenListeners(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := inprocess.NewBroker()
+
+	const count = 20
+	var mu sync.Mutex
+	received := make(map[string]int)
+	done := make(chan struct{})
+	handler := transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[msg.MessageId]++
+		if len(received) == count {
+			close(done)
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		conn, err := broker.Connect(ctx, transport.OcppVersion201, nil, handler)
+		require.NoError(t, err)
+		defer func() {
+			err := conn.Disconnect(ctx)
+			require.NoError(t, err)
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		err := broker.Publish(ctx, transport.OcppVersion201, fmt.Sprintf("cs%03d", i), &transport.Message{
+			MessageType: transport.MessageTypeCall,
+			Action:      "Heartbeat",
+			MessageId:   fmt.Sprintf("msg-%d", i),
+		})
+		require.NoError(t, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for messages")
+	case <-done:
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for id, n := range received {
+		assert.Equal(t, 1, n, "message %s", id)
+	}
+}
+
+func TestBrokerDeliversMessagesForAChargeStation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := inprocess.NewBroker()
+
+	received := make(chan string, 2)
+	csId := "cs001"
+	conn, err := broker.Connect(ctx, transport.OcppVersion16, &csId, transport.MessageHandlerFunc(
+		func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+			received <- chargeStationId + "/" + msg.MessageId
+		}))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	for _, chargeStationId := range []string{"cs002", "cs001"} {
+		err = broker.Publish(ctx, transport.OcppVersion16, chargeStationId, &transport.Message{
+			MessageType: transport.MessageTypeCall,
+			Action:      "Heartbeat",
+			MessageId:   "1234",
+		})
+		require.NoError(t, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message")
+	case got := <-received:
+		assert.Equal(t, "cs001/1234", got)
+	}
+}
+
+func TestBrokerDiscardsMessagesWhenNothingIsListening(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := inprocess.NewBroker(inprocess.WithBufLen(1))
+
+	received := make(chan struct{}, 1)
+	conn, err := broker.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(
+		func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+			received <- struct{}{}
+		}))
+	require.NoError(t, err)
+	err = conn.Disconnect(ctx)
+	require.NoError(t, err)
+
+	// more messages than the buffer holds: none of them block
+	for i := 0; i < 3; i++ {
+		err = broker.Publish(ctx, transport.OcppVersion201, "cs001", &transport.Message{
+			MessageType: transport.MessageTypeCall,
+			Action:      "Heartbeat",
+			MessageId:   fmt.Sprintf("msg-%d", i),
+		})
+		require.NoError(t, err)
+	}
+
+	select {
+	case <-received:
+		assert.Fail(t, "message delivered to disconnected listener")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBrokerPassesEmittedMessagesToTheGateway(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tracer, exporter := testutil.GetTracer()
+	broker := inprocess.NewBroker(inprocess.WithOtelTracer(tracer))
+
+	msg := &transport.Message{
+		MessageType: transport.MessageTypeCall,
+		Action:      "Reset",
+		MessageId:   "1234",
+	}
+	err := broker.Emit(ctx, transport.OcppVersion201, "cs001", msg)
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message")
+	case got := <-broker.Outbound():
+		assert.Equal(t, transport.OcppVersion201, got.OcppVersion)
+		assert.Equal(t, "cs001", got.ChargeStationId)
+		assert.Equal(t, msg, got.Message)
+		require.Len(t, exporter.GetSpans(), 1)
+		testutil.AssertSpan(t, &exporter.GetSpans()[0], "out/ocpp2.0.1 publish", map[string]any{
+			"messaging.system":                  "inprocess",
+			"messaging.operation":               "publish",
+			"messaging.message.conversation_id": "1234",
+			"csId":                              "cs001",
+		})
+	}
+}
+
+func TestBrokerDeliversConnectionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := inprocess.NewBroker()
+
+	received := make(chan *transport.ConnectionEvent, 1)
+	conn, err := broker.ConnectEvents(ctx, transport.ConnectionEventHandlerFunc(
+		func(ctx context.Context, event *transport.ConnectionEvent) {
+			received <- event
+		}))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	event := &transport.ConnectionEvent{
+		Type:            transport.ConnectionEventConnected,
+		ChargeStationId: "cs001",
+		Protocol:        "ocpp2.0.1",
+	}
+	err = broker.PublishEvent(ctx, event)
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for event")
+	case got := <-received:
+		assert.Equal(t, event, got)
+	}
+}
@@ -21,6 +21,13 @@ type Listener interface {
 	//
 	// Returns either a Connection on success or an error.
 	Connect(ctx context.Context, ocppVersion OcppVersion, chargeStationId *string, handler MessageHandler) (Connection, error)
+
+	// ConnectEvents establishes a connection to the broker and subscribes to receive the
+	// connection events published by the gateway for all charge stations. The events are
+	// delivered to the provided ConnectionEventHandler.
+	//
+	// Returns either a Connection on success or an error.
+	ConnectEvents(ctx context.Context, handler ConnectionEventHandler) (Connection, error)
 }
 
 type Connection interface {
@@ -47,14 +47,24 @@ func NewEmitter(opts ...Opt[Emitter]) transport.Emitter {
 }
 
 func (e *Emitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
-	topic := fmt.Sprintf("%s/out/%s/%s", e.mqttPrefix, ocppVersion, chargeStationId)
+	return e.publishMessage(ctx, "out", ocppVersion, chargeStationId, message)
+}
+
+// EmitResult shares the answer to a call made by the CSMS with all the manager instances.
+// It is published on the <prefix>/results/<ocpp-version>/<cs-id> topic.
+func (e *Emitter) EmitResult(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	return e.publishMessage(ctx, "results", ocppVersion, chargeStationId, message)
+}
+
+func (e *Emitter) publishMessage(ctx context.Context, kind string, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	topic := fmt.Sprintf("%s/%s/%s/%s", e.mqttPrefix, kind, ocppVersion, chargeStationId)
 	payload, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("marshalling response of type %s: %v", message.Action, err)
 	}
 
 	newCtx, span := e.tracer.Start(ctx,
-		fmt.Sprintf("%s/out/%s/# publish", e.mqttPrefix, ocppVersion),
+		fmt.Sprintf("%s/%s/%s/# publish", e.mqttPrefix, kind, ocppVersion),
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(
 			semconv.MessagingSystem("mqtt"),
@@ -92,6 +102,65 @@ func (e *Emitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, c
 	return nil
 }
 
+// registryInvalidation tells the gateway to discard the cached details of a charge
+// station or certificate
+type registryInvalidation struct {
+	ChargeStationId string `json:"clientId,omitempty"`
+	CertificateHash string `json:"certificateHash,omitempty"`
+}
+
+// ChargeStationAuthChanged tells the gateway that the authentication details for the
+// charge station have changed. It is published on the <prefix>/registry topic.
+func (e *Emitter) ChargeStationAuthChanged(ctx context.Context, chargeStationId string) error {
+	return e.invalidateRegistry(ctx, &registryInvalidation{ChargeStationId: chargeStationId})
+}
+
+// CertificateChanged tells the gateway that the certificate has been added or removed.
+// It is published on the <prefix>/registry topic.
+func (e *Emitter) CertificateChanged(ctx context.Context, certificateHash string) error {
+	return e.invalidateRegistry(ctx, &registryInvalidation{CertificateHash: certificateHash})
+}
+
+func (e *Emitter) invalidateRegistry(ctx context.Context, invalidation *registryInvalidation) error {
+	topic := fmt.Sprintf("%s/registry", e.mqttPrefix)
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return fmt.Errorf("marshalling registry invalidation: %v", err)
+	}
+
+	newCtx, span := e.tracer.Start(ctx,
+		fmt.Sprintf("%s publish", topic),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("mqtt"),
+			semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+			semconv.MessagingOperationKey.String("publish"),
+		))
+	defer span.End()
+
+	if invalidation.ChargeStationId != "" {
+		span.SetAttributes(attribute.String("csId", invalidation.ChargeStationId))
+	}
+
+	err = e.ensureConnection(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to MQTT: %v", err)
+	}
+
+	_, err = e.conn.Publish(newCtx, &paho.Publish{
+		Topic:   topic,
+		QoS:     1,
+		Payload: payload,
+		Properties: &paho.PublishProperties{
+			ContentType: "application/json",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", topic, err)
+	}
+	return nil
+}
+
 func getActionName(msg *transport.Message) string {
 	switch msg.MessageType {
 	case transport.MessageTypeCall:
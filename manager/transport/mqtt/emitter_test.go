@@ -226,3 +226,67 @@ func listenForMessageSentByManager(t *testing.T, ctx context.Context, clientUrl
 
 	return mqttClient
 }
+
+func TestEmitterPublishesRegistryInvalidations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	broker, clientUrl := mqtt2.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	emitter := mqtt2.NewEmitter(
+		mqtt2.WithMqttBrokerUrl[mqtt2.Emitter](clientUrl),
+		mqtt2.WithMqttPrefix[mqtt2.Emitter]("cs")).(*mqtt2.Emitter)
+
+	rcvdCh := make(chan string, 2)
+	subscribedCh := make(chan struct{})
+	mqttClient, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		BrokerUrls:        []*url.URL{clientUrl},
+		KeepAlive:         10,
+		ConnectRetryDelay: 10,
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, connack *paho.Connack) {
+			_, err := manager.Subscribe(context.Background(), &paho.Subscribe{
+				Subscriptions: map[string]paho.SubscribeOptions{
+					"cs/registry": {QoS: 1},
+				},
+			})
+			require.NoError(t, err)
+			close(subscribedCh)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: "test",
+			Router: paho.NewSingleHandlerRouter(func(publish *paho.Publish) {
+				rcvdCh <- string(publish.Payload)
+			}),
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = mqttClient.Disconnect(ctx)
+	}()
+	select {
+	case <-subscribedCh:
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for subscription")
+	}
+
+	err = emitter.ChargeStationAuthChanged(ctx, "cs001")
+	require.NoError(t, err)
+	err = emitter.CertificateChanged(ctx, "abc")
+	require.NoError(t, err)
+
+	for _, want := range []string{`{"clientId":"cs001"}`, `{"certificateHash":"abc"}`} {
+		select {
+		case got := <-rcvdCh:
+			assert.JSONEq(t, want, got)
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for registry invalidation")
+		}
+	}
+}
@@ -67,15 +67,8 @@ func ensureListenerDefaults(l *Listener) {
 }
 
 func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
-	var err error
-
-	ctx, cancel := context.WithTimeout(ctx, l.mqttConnectTimeout)
-	defer cancel()
-
 	clientId := fmt.Sprintf("%s-%s", l.mqttGroup, randSeq(5))
 
-	readyCh := make(chan struct{})
-
 	var topic string
 	if chargeStationId != nil {
 		topic = fmt.Sprintf("%s/in/%s/%s", l.mqttPrefix, ocppVersion, *chargeStationId)
@@ -83,6 +76,134 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 		topic = fmt.Sprintf("$share/%s/%s/in/%s/#", l.mqttGroup, l.mqttPrefix, ocppVersion)
 	}
 
+	return l.subscribe(ctx, clientId, topic, l.receiveMessage(clientId, handler))
+}
+
+// ConnectResults subscribes to the answers to CSMS calls that are shared by all the manager
+// instances on <prefix>/results/<ocpp-version>/<cs-id>. Unlike the other subscriptions
+// this is not shared, so each listener receives every answer.
+func (l *Listener) ConnectResults(ctx context.Context, handler transport.MessageHandler) (transport.Connection, error) {
+	clientId := fmt.Sprintf("%s-results-%s", l.mqttGroup, randSeq(5))
+	topic := fmt.Sprintf("%s/results/#", l.mqttPrefix)
+
+	return l.subscribe(ctx, clientId, topic, l.receiveMessage(clientId, handler))
+}
+
+// receiveMessage returns a function that passes the OCPP messages received on the
+// <prefix>/<kind>/<ocpp-version>/<cs-id> topics to handler
+func (l *Listener) receiveMessage(clientId string, handler transport.MessageHandler) func(*paho.Publish) {
+	return func(mqttMsg *paho.Publish) {
+		ctx := context.Background()
+
+		// extract trace id
+		if mqttMsg.Properties != nil && mqttMsg.Properties.CorrelationData != nil {
+			correlationMap := make(map[string]string)
+			err := json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
+			if err != nil {
+				slog.Warn("failed to unmarshal correlation data", "error", err)
+			} else {
+				ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(correlationMap))
+			}
+		}
+
+		// create span
+		newCtx, span := l.tracer.Start(ctx,
+			fmt.Sprintf("%s receive", getTopicPattern(mqttMsg.Topic)),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("mqtt"),
+				semconv.MessagingConsumerID(clientId),
+				semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
+				semconv.MessagingOperationKey.String("receive"),
+			))
+		defer span.End()
+
+		// determine charge station id and ocpp version
+		topicParts := strings.Split(mqttMsg.Topic, "/")
+		var chargeStationId = topicParts[len(topicParts)-1]
+		var ocppVersion string
+		if len(topicParts) > 1 {
+			ocppVersion = topicParts[len(topicParts)-2]
+		}
+
+		// unmarshal the message
+		var msg transport.Message
+		err := json.Unmarshal(mqttMsg.Payload, &msg)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unable to unmarshal message")
+			slog.Warn("unable to unmarshal message", "err", err)
+			return
+		}
+
+		// add additional span attributes
+		version, _ := strings.CutPrefix(ocppVersion, "ocpp")
+		span.SetAttributes(
+			attribute.String("csId", chargeStationId),
+			attribute.String("ocpp.version", version),
+			attribute.String(fmt.Sprintf("%s.action", msg.MessageType), msg.Action),
+			semconv.MessagingMessageConversationID(msg.MessageId),
+		)
+
+		if msg.MessageType == transport.MessageTypeCallError {
+			span.SetAttributes(
+				attribute.String(fmt.Sprintf("%s.code", msg.MessageType), string(msg.ErrorCode)),
+				attribute.String(fmt.Sprintf("%s.description", msg.MessageType), msg.ErrorDescription))
+		}
+
+		// execute the handler
+		handler.Handle(newCtx, chargeStationId, &msg)
+	}
+}
+
+func (l *Listener) ConnectEvents(ctx context.Context, handler transport.ConnectionEventHandler) (transport.Connection, error) {
+	clientId := fmt.Sprintf("%s-events-%s", l.mqttGroup, randSeq(5))
+	topic := fmt.Sprintf("$share/%s/%s/events/#", l.mqttGroup, l.mqttPrefix)
+
+	return l.subscribe(ctx, clientId, topic, func(mqttMsg *paho.Publish) {
+		newCtx, span := l.tracer.Start(context.Background(),
+			fmt.Sprintf("%s receive", getTopicPattern(mqttMsg.Topic)),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("mqtt"),
+				semconv.MessagingConsumerID(clientId),
+				semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
+				semconv.MessagingOperationKey.String("receive"),
+			))
+		defer span.End()
+
+		var event transport.ConnectionEvent
+		err := json.Unmarshal(mqttMsg.Payload, &event)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unable to unmarshal connection event")
+			slog.Warn("unable to unmarshal connection event", "err", err)
+			return
+		}
+
+		// the topic is authoritative for the charge station id
+		topicParts := strings.Split(mqttMsg.Topic, "/")
+		event.ChargeStationId = topicParts[len(topicParts)-1]
+
+		span.SetAttributes(
+			attribute.String("csId", event.ChargeStationId),
+			attribute.String("connection.event", string(event.Type)),
+		)
+
+		handler.HandleConnectionEvent(newCtx, &event)
+	})
+}
+
+// subscribe establishes a connection to the broker that subscribes to topic and passes
+// each message received to handler. It returns once the subscription is in place.
+func (l *Listener) subscribe(ctx context.Context, clientId, topic string, handler func(*paho.Publish)) (transport.Connection, error) {
+	var err error
+
+	ctx, cancel := context.WithTimeout(ctx, l.mqttConnectTimeout)
+	defer cancel()
+
+	readyCh := make(chan struct{})
+
 	conn := new(connection)
 	mqttRouter := paho.NewStandardRouter()
 	conn.mqttConn, err = autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
@@ -100,64 +221,7 @@ func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersio
 				return
 			}
 			mqttRouter.UnregisterHandler(topic)
-			mqttRouter.RegisterHandler(topic, func(mqttMsg *paho.Publish) {
-				ctx := context.Background()
-
-				// extract trace id
-				if mqttMsg.Properties != nil && mqttMsg.Properties.CorrelationData != nil {
-					correlationMap := make(map[string]string)
-					err := json.Unmarshal(mqttMsg.Properties.CorrelationData, &correlationMap)
-					if err != nil {
-						slog.Warn("failed to unmarshal correlation data", "error", err)
-					} else {
-						ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(correlationMap))
-					}
-				}
-
-				// create span
-				newCtx, span := l.tracer.Start(ctx,
-					fmt.Sprintf("%s receive", getTopicPattern(mqttMsg.Topic)),
-					trace.WithSpanKind(trace.SpanKindConsumer),
-					trace.WithAttributes(
-						semconv.MessagingSystem("mqtt"),
-						semconv.MessagingConsumerID(clientId),
-						semconv.MessagingMessagePayloadSizeBytes(len(mqttMsg.Payload)),
-						semconv.MessagingOperationKey.String("receive"),
-					))
-				defer span.End()
-
-				// determine charge station id
-				topicParts := strings.Split(mqttMsg.Topic, "/")
-				var chargeStationId = topicParts[len(topicParts)-1]
-
-				// unmarshal the message
-				var msg transport.Message
-				err := json.Unmarshal(mqttMsg.Payload, &msg)
-				if err != nil {
-					span.RecordError(err)
-					span.SetStatus(codes.Error, "unable to unmarshal message")
-					slog.Warn("unable to unmarshal message", "err", err)
-					return
-				}
-
-				// add additional span attributes
-				version, _ := strings.CutPrefix(string(ocppVersion), "ocpp")
-				span.SetAttributes(
-					attribute.String("csId", chargeStationId),
-					attribute.String("ocpp.version", version),
-					attribute.String(fmt.Sprintf("%s.action", msg.MessageType), msg.Action),
-					semconv.MessagingMessageConversationID(msg.MessageId),
-				)
-
-				if msg.MessageType == transport.MessageTypeCallError {
-					span.SetAttributes(
-						attribute.String(fmt.Sprintf("%s.code", msg.MessageType), string(msg.ErrorCode)),
-						attribute.String(fmt.Sprintf("%s.description", msg.MessageType), msg.ErrorDescription))
-				}
-
-				// execute the handler
-				handler.Handle(newCtx, chargeStationId, &msg)
-			})
+			mqttRouter.RegisterHandler(topic, handler)
 			readyCh <- struct{}{}
 		},
 		ClientConfig: paho.ClientConfig{
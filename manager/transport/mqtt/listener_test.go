@@ -192,6 +192,129 @@ func TestListenerAddsTraceInformation(t *testing.T) {
 	}
 }
 
+func TestListenerDeliversConnectionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// start the broker
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	// setup the handler
+	receivedEventCh := make(chan *transport.ConnectionEvent, 1)
+	handler := func(ctx context.Context, event *transport.ConnectionEvent) {
+		receivedEventCh <- event
+	}
+
+	// connect the listener to the broker
+	listener := mqtt.NewListener(mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl))
+	conn, err := listener.ConnectEvents(ctx, transport.ConnectionEventHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		if conn != nil {
+			err := conn.Disconnect(ctx)
+			require.NoError(t, err)
+		}
+	}()
+
+	// publish event
+	timestamp := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	eventBytes, err := json.Marshal(transport.ConnectionEvent{
+		Type:            transport.ConnectionEventDisconnected,
+		ChargeStationId: "cs001",
+		Protocol:        "ocpp2.0.1",
+		SecurityProfile: 2,
+		CloseCode:       1001,
+		CloseReason:     "going away",
+		Timestamp:       timestamp,
+	})
+	require.NoError(t, err)
+
+	cl := broker.NewClient(nil, "local", "inline", true)
+	err = broker.InjectPacket(cl, packets.Packet{
+		FixedHeader: packets.FixedHeader{
+			Type: packets.Publish,
+		},
+		TopicName: "cs/events/ocpp2.0.1/cs001",
+		Payload:   eventBytes,
+	})
+	require.NoError(t, err)
+
+	// wait for event to be received / timeout
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case event := <-receivedEventCh:
+		assert.Equal(t, transport.ConnectionEventDisconnected, event.Type)
+		assert.Equal(t, "cs001", event.ChargeStationId)
+		assert.Equal(t, "ocpp2.0.1", event.Protocol)
+		assert.Equal(t, 2, event.SecurityProfile)
+		assert.Equal(t, 1001, event.CloseCode)
+		assert.Equal(t, "going away", event.CloseReason)
+		assert.True(t, timestamp.Equal(event.Timestamp))
+	}
+}
+
+func TestListenerReceivesResultsEmittedByAnyInstance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// start the broker
+	broker, clientUrl := mqtt.NewBroker(t)
+	defer func() {
+		err := broker.Close()
+		assert.NoError(t, err)
+	}()
+	err := broker.Serve()
+	require.NoError(t, err)
+
+	// connect two listeners in the same group: both should receive the result
+	type result struct {
+		chargeStationId string
+		msg             *transport.Message
+	}
+	receivedCh := make(chan result, 2)
+	handler := transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		receivedCh <- result{chargeStationId, msg}
+	})
+
+	for i := 0; i < 2; i++ {
+		listener := mqtt.NewListener(mqtt.WithMqttBrokerUrl[mqtt.Listener](clientUrl))
+		conn, err := listener.ConnectResults(ctx, handler)
+		require.NoError(t, err)
+		defer func() {
+			err := conn.Disconnect(ctx)
+			require.NoError(t, err)
+		}()
+	}
+
+	emitter := mqtt.NewEmitter(mqtt.WithMqttBrokerUrl[mqtt.Emitter](clientUrl)).(transport.ResultEmitter)
+	err = emitter.EmitResult(ctx, transport.OcppVersion201, "cs001", &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          "Reset",
+		MessageId:       "1234",
+		ResponsePayload: []byte(`{"status":"Accepted"}`),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.Done():
+			require.Fail(t, "timeout waiting for result")
+		case received := <-receivedCh:
+			assert.Equal(t, "cs001", received.chargeStationId)
+			assert.Equal(t, transport.MessageTypeCallResult, received.msg.MessageType)
+			assert.Equal(t, "1234", received.msg.MessageId)
+			assert.JSONEq(t, `{"status":"Accepted"}`, string(received.msg.ResponsePayload))
+		}
+	}
+}
+
 func publishMessage(t *testing.T, ctx context.Context, broker *server.Server, msg transport.Message) {
 	msgBytes, err := json.Marshal(msg)
 	require.NoError(t, err)
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Emitter is an implementation of transport.Emitter that uses NATS
+// as the transport.
+//
+// Messages are published on a subject that is composed of a number of
+// tokens: <prefix>.out.<ocpp-version>.<cs-id>. The prefix is configured,
+// the ocpp-version and cs-id are provided to the Emit function and are
+// encoded so that they form a single token each. If not configured the
+// default prefix is `cs`.
+//
+// Messages to the gateway are only of interest to the gateway instance that
+// the charge station is connected to at the time, so they are published
+// without JetStream.
+//
+// The Emitter defaults to connecting to a server on 127.0.0.1:4222.
+type Emitter struct {
+	sync.Mutex
+	connectionDetails
+	tracer trace.Tracer
+	conn   *nats.Conn
+}
+
+func NewEmitter(opts ...Opt[Emitter]) transport.Emitter {
+	e := new(Emitter)
+	for _, opt := range opts {
+		opt(e)
+	}
+	ensureEmitterDefaults(e)
+	return e
+}
+
+func (e *Emitter) Emit(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	return e.publishMessage(ctx, "out", ocppVersion, chargeStationId, message)
+}
+
+// EmitResult shares the answer to a call made by the CSMS with all the manager instances.
+// It is published on the <prefix>.results.<ocpp-version>.<cs-id> subject.
+func (e *Emitter) EmitResult(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	return e.publishMessage(ctx, "results", ocppVersion, chargeStationId, message)
+}
+
+func (e *Emitter) publishMessage(ctx context.Context, kind string, ocppVersion transport.OcppVersion, chargeStationId string, message *transport.Message) error {
+	subj := subject(e.natsPrefix, kind, string(ocppVersion), chargeStationId)
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshalling response of type %s: %v", message.Action, err)
+	}
+
+	newCtx, span := e.tracer.Start(ctx,
+		fmt.Sprintf("%s publish", subjectPattern(subj)),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+			semconv.MessagingOperationKey.String("publish"),
+			semconv.MessagingMessageConversationID(message.MessageId),
+			attribute.String("csId", chargeStationId),
+			attribute.String(getActionName(message), message.Action),
+		))
+	defer span.End()
+
+	msg := nats.NewMsg(subj)
+	msg.Data = payload
+	otel.GetTextMapPropagator().Inject(newCtx, propagation.HeaderCarrier(http.Header(msg.Header)))
+
+	err = e.ensureConnection()
+	if err != nil {
+		return fmt.Errorf("connecting to NATS: %v", err)
+	}
+
+	err = e.conn.PublishMsg(msg)
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", subj, err)
+	}
+	return nil
+}
+
+// registryInvalidation tells the gateway to discard the cached details of a charge
+// station or certificate
+type registryInvalidation struct {
+	ChargeStationId string `json:"clientId,omitempty"`
+	CertificateHash string `json:"certificateHash,omitempty"`
+}
+
+// ChargeStationAuthChanged tells the gateway that the authentication details for the
+// charge station have changed. It is published on the <prefix>.registry subject.
+func (e *Emitter) ChargeStationAuthChanged(ctx context.Context, chargeStationId string) error {
+	return e.invalidateRegistry(ctx, &registryInvalidation{ChargeStationId: chargeStationId})
+}
+
+// CertificateChanged tells the gateway that the certificate has been added or removed.
+// It is published on the <prefix>.registry subject.
+func (e *Emitter) CertificateChanged(ctx context.Context, certificateHash string) error {
+	return e.invalidateRegistry(ctx, &registryInvalidation{CertificateHash: certificateHash})
+}
+
+func (e *Emitter) invalidateRegistry(ctx context.Context, invalidation *registryInvalidation) error {
+	subj := subject(e.natsPrefix, "registry")
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return fmt.Errorf("marshalling registry invalidation: %v", err)
+	}
+
+	_, span := e.tracer.Start(ctx,
+		fmt.Sprintf("%s publish", subj),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingMessagePayloadSizeBytes(len(payload)),
+			semconv.MessagingOperationKey.String("publish"),
+		))
+	defer span.End()
+
+	if invalidation.ChargeStationId != "" {
+		span.SetAttributes(attribute.String("csId", invalidation.ChargeStationId))
+	}
+
+	err = e.ensureConnection()
+	if err != nil {
+		return fmt.Errorf("connecting to NATS: %v", err)
+	}
+
+	msg := nats.NewMsg(subj)
+	msg.Data = payload
+	msg.Header.Set("Content-Type", "application/json")
+	err = e.conn.PublishMsg(msg)
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", subj, err)
+	}
+	// make sure the gateways are told before the change is reported as complete
+	err = e.conn.Flush()
+	if err != nil {
+		return fmt.Errorf("publishing to %s: %v", subj, err)
+	}
+	return nil
+}
+
+func getActionName(msg *transport.Message) string {
+	switch msg.MessageType {
+	case transport.MessageTypeCall:
+		return "call.action"
+	case transport.MessageTypeCallResult:
+		return "call_result.action"
+	default:
+		return "call_error.action"
+	}
+}
+
+func ensureEmitterDefaults(e *Emitter) {
+	ensureConnectionDefaults(&e.connectionDetails)
+	if e.tracer == nil {
+		e.tracer = noop.NewTracerProvider().Tracer("")
+	}
+}
+
+func ensureConnectionDefaults(c *connectionDetails) {
+	if c.natsUrls == nil {
+		c.natsUrls = []string{nats.DefaultURL}
+	}
+	if c.natsPrefix == "" {
+		c.natsPrefix = "cs"
+	}
+	if c.natsStream == "" {
+		c.natsStream = "csms"
+	}
+	if c.natsConnectTimeout == 0 {
+		c.natsConnectTimeout = 10 * time.Second
+	}
+	if c.natsReconnectWait == 0 {
+		c.natsReconnectWait = 1 * time.Second
+	}
+}
+
+// connect establishes a connection to the NATS servers that reconnects whenever the
+// connection is lost
+func (c *connectionDetails) connect(name string) (*nats.Conn, error) {
+	return nats.Connect(strings.Join(c.natsUrls, ","),
+		nats.Name(name),
+		nats.Timeout(c.natsConnectTimeout),
+		nats.ReconnectWait(c.natsReconnectWait),
+		nats.MaxReconnects(-1))
+}
+
+func (e *Emitter) ensureConnection() error {
+	e.Lock()
+	defer e.Unlock()
+	if e.conn == nil {
+		conn, err := e.connect(fmt.Sprintf("%s-%s", "manager-emit", randSeq(5)))
+		if err != nil {
+			return err
+		}
+		e.conn = conn
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/nats"
+)
+
+// subscribe receives the messages published on subject as the gateway does
+func subscribe(t *testing.T, url, subject string) chan *natsgo.Msg {
+	conn, err := natsgo.Connect(url)
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	ch := make(chan *natsgo.Msg, 1)
+	_, err = conn.ChanSubscribe(subject, ch)
+	require.NoError(t, err)
+	require.NoError(t, conn.Flush())
+	return ch
+}
+
+func TestEmitterSendsMessage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	tracer, _ := testutil.GetTracer()
+
+	msgCh := subscribe(t, url, prefix+".out.ocpp1%2E6.cs%2E001")
+
+	emitter := nats.NewEmitter(
+		nats.WithNatsUrl[nats.Emitter](url),
+		nats.WithNatsPrefix[nats.Emitter](prefix),
+		nats.WithNatsStream[nats.Emitter](stream),
+		nats.WithOtelTracer[nats.Emitter](tracer))
+
+	newCtx, span := tracer.Start(ctx, "test span")
+	defer span.End()
+	err := emitter.Emit(newCtx, transport.OcppVersion16, "cs.001", &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          "Heartbeat",
+		MessageId:       "1234",
+		ResponsePayload: json.RawMessage(`{"currentTime":"2023-06-15T15:05:00+01:00"}`),
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message")
+	case msg := <-msgCh:
+		var got transport.Message
+		err := json.Unmarshal(msg.Data, &got)
+		require.NoError(t, err)
+		assert.Equal(t, "1234", got.MessageId)
+		assert.Equal(t, transport.MessageTypeCallResult, got.MessageType)
+		assert.NotEmpty(t, msg.Header.Get("traceparent"))
+	}
+}
+
+func TestEmitterPublishesRegistryInvalidations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	msgCh := subscribe(t, url, prefix+".registry")
+
+	emitter := nats.NewEmitter(
+		nats.WithNatsUrl[nats.Emitter](url),
+		nats.WithNatsPrefix[nats.Emitter](prefix),
+		nats.WithNatsStream[nats.Emitter](stream))
+	notifier, ok := emitter.(store.AuthChangeNotifier)
+	require.True(t, ok)
+
+	err := notifier.ChargeStationAuthChanged(ctx, "cs001")
+	require.NoError(t, err)
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for message")
+	case msg := <-msgCh:
+		assert.JSONEq(t, `{"clientId":"cs001"}`, string(msg.Data))
+	}
+}
@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"golang.org/x/exp/slog"
+)
+
+// Listener is an implementation of transport.Listener that uses NATS JetStream
+// as the transport.
+//
+// The gateway publishes the messages from the charge stations on
+// <prefix>.in.<ocpp-version>.<cs-id> and the connection events on
+// <prefix>.events.<ocpp-version>.<cs-id>, which are held in a JetStream stream. Listening to
+// all charge stations uses a durable consumer per OCPP version (and one for the
+// connection events) that is named after the group and is shared by all the
+// listeners in the group using a queue group: this is equivalent to an MQTT
+// shared subscription, but messages that arrive whilst no listener is connected
+// are delivered when one reconnects.
+type Listener struct {
+	connectionDetails
+	natsGroup string
+	tracer    trace.Tracer
+}
+
+func NewListener(opts ...Opt[Listener]) *Listener {
+	l := new(Listener)
+	for _, opt := range opts {
+		opt(l)
+	}
+	ensureListenerDefaults(l)
+	return l
+}
+
+func ensureListenerDefaults(l *Listener) {
+	ensureConnectionDefaults(&l.connectionDetails)
+	if l.natsGroup == "" {
+		l.natsGroup = "manager"
+	}
+	if l.tracer == nil {
+		l.tracer = noop.NewTracerProvider().Tracer("")
+	}
+}
+
+func (l *Listener) Connect(ctx context.Context, ocppVersion transport.OcppVersion, chargeStationId *string, handler transport.MessageHandler) (transport.Connection, error) {
+	clientName := fmt.Sprintf("%s-%s", l.natsGroup, randSeq(5))
+
+	var subj, durable string
+	if chargeStationId != nil {
+		subj = subject(l.natsPrefix, "in", string(ocppVersion), *chargeStationId)
+	} else {
+		subj = subject(l.natsPrefix, "in", string(ocppVersion)) + ".*"
+		durable = consumerName(l.natsGroup, "in", string(ocppVersion))
+	}
+
+	return l.subscribe(ctx, clientName, subj, durable, l.receiveMessage(clientName, handler))
+}
+
+// ConnectResults subscribes to the answers to CSMS calls that are shared by all the manager
+// instances on <prefix>.results.<ocpp-version>.<cs-id>. The answers are only of use to the
+// instance that is waiting for them at the time, so they are not held in the stream and
+// each listener receives every answer.
+func (l *Listener) ConnectResults(_ context.Context, handler transport.MessageHandler) (transport.Connection, error) {
+	clientName := fmt.Sprintf("%s-results-%s", l.natsGroup, randSeq(5))
+	subj := subject(l.natsPrefix, "results") + ".>"
+
+	conn, err := l.connect(clientName)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %w", err)
+	}
+	sub, err := conn.Subscribe(subj, l.receiveMessage(clientName, handler))
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribing to %s: %w", subj, err)
+	}
+	// make sure the subscription is in place before returning
+	err = conn.Flush()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribing to %s: %w", subj, err)
+	}
+	return &connection{natsConn: conn, sub: sub}, nil
+}
+
+// receiveMessage returns a function that passes the OCPP messages received on the
+// <prefix>.<kind>.<ocpp-version>.<cs-id> subjects to handler
+func (l *Listener) receiveMessage(clientName string, handler transport.MessageHandler) nats.MsgHandler {
+	return func(natsMsg *nats.Msg) {
+		// extract trace id
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(natsMsg.Header))
+
+		// create span
+		newCtx, span := l.tracer.Start(ctx,
+			fmt.Sprintf("%s receive", subjectPattern(natsMsg.Subject)),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("nats"),
+				semconv.MessagingConsumerID(clientName),
+				semconv.MessagingMessagePayloadSizeBytes(len(natsMsg.Data)),
+				semconv.MessagingOperationKey.String("receive"),
+			))
+		defer span.End()
+
+		// determine charge station id and ocpp version
+		chargeStationId := lastToken(natsMsg.Subject)
+		ocppVersion := lastToken(natsMsg.Subject[:max(strings.LastIndexByte(natsMsg.Subject, '.'), 0)])
+
+		// unmarshal the message
+		var msg transport.Message
+		err := json.Unmarshal(natsMsg.Data, &msg)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unable to unmarshal message")
+			slog.Warn("unable to unmarshal message", "err", err)
+			terminate(natsMsg)
+			return
+		}
+
+		// add additional span attributes
+		version, _ := strings.CutPrefix(ocppVersion, "ocpp")
+		span.SetAttributes(
+			attribute.String("csId", chargeStationId),
+			attribute.String("ocpp.version", version),
+			attribute.String(fmt.Sprintf("%s.action", msg.MessageType), msg.Action),
+			semconv.MessagingMessageConversationID(msg.MessageId),
+		)
+
+		if msg.MessageType == transport.MessageTypeCallError {
+			span.SetAttributes(
+				attribute.String(fmt.Sprintf("%s.code", msg.MessageType), string(msg.ErrorCode)),
+				attribute.String(fmt.Sprintf("%s.description", msg.MessageType), msg.ErrorDescription))
+		}
+
+		// execute the handler
+		handler.Handle(newCtx, chargeStationId, &msg)
+		ack(natsMsg)
+	}
+}
+
+func (l *Listener) ConnectEvents(ctx context.Context, handler transport.ConnectionEventHandler) (transport.Connection, error) {
+	clientName := fmt.Sprintf("%s-events-%s", l.natsGroup, randSeq(5))
+	subj := subject(l.natsPrefix, "events") + ".>"
+
+	return l.subscribe(ctx, clientName, subj, consumerName(l.natsGroup, "events"), func(natsMsg *nats.Msg) {
+		newCtx, span := l.tracer.Start(context.Background(),
+			fmt.Sprintf("%s receive", subjectPattern(natsMsg.Subject)),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystem("nats"),
+				semconv.MessagingConsumerID(clientName),
+				semconv.MessagingMessagePayloadSizeBytes(len(natsMsg.Data)),
+				semconv.MessagingOperationKey.String("receive"),
+			))
+		defer span.End()
+
+		var event transport.ConnectionEvent
+		err := json.Unmarshal(natsMsg.Data, &event)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "unable to unmarshal connection event")
+			slog.Warn("unable to unmarshal connection event", "err", err)
+			terminate(natsMsg)
+			return
+		}
+
+		// the subject is authoritative for the charge station id
+		event.ChargeStationId = lastToken(natsMsg.Subject)
+
+		span.SetAttributes(
+			attribute.String("csId", event.ChargeStationId),
+			attribute.String("connection.event", string(event.Type)),
+		)
+
+		handler.HandleConnectionEvent(newCtx, &event)
+		ack(natsMsg)
+	})
+}
+
+// subscribe establishes a connection to NATS that consumes the messages in the stream
+// that match subj and passes each one to handler. When durable is set the messages are
+// consumed through the group's durable consumer, otherwise only the messages published
+// after the subscription is made are received. It returns once the subscription is in
+// place.
+func (l *Listener) subscribe(ctx context.Context, clientName, subj, durable string, handler func(*nats.Msg)) (transport.Connection, error) {
+	conn, err := l.connect(clientName)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to nats: %w", err)
+	}
+
+	sub, err := l.consume(ctx, conn, subj, durable, handler)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &connection{natsConn: conn, sub: sub}, nil
+}
+
+func (l *Listener) consume(ctx context.Context, conn *nats.Conn, subj, durable string, handler func(*nats.Msg)) (*nats.Subscription, error) {
+	js, err := conn.JetStream(nats.Context(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("creating jetstream context: %w", err)
+	}
+	err = ensureStream(js, l.natsStream, l.natsPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if durable == "" {
+		sub, err := js.Subscribe(subj, handler, nats.BindStream(l.natsStream), nats.DeliverNew(), nats.ManualAck())
+		if err != nil {
+			return nil, fmt.Errorf("subscribing to %s: %w", subj, err)
+		}
+		return sub, nil
+	}
+
+	sub, err := js.QueueSubscribe(subj, l.natsGroup, handler,
+		nats.BindStream(l.natsStream), nats.Durable(durable), nats.DeliverAll(), nats.AckExplicit(), nats.ManualAck())
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to %s with consumer %s: %w", subj, durable, err)
+	}
+	return sub, nil
+}
+
+var consumerNameReplacer = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_")
+
+// consumerName returns the name of a durable consumer, e.g. manager-in-ocpp2_0_1: the
+// names cannot contain the characters that have a special meaning in a subject
+func consumerName(parts ...string) string {
+	return consumerNameReplacer.Replace(strings.Join(parts, "-"))
+}
+
+// headerCarrier returns the message headers with canonical keys: NATS headers are case-sensitive
+// and the NATS server lower-cases the trace context headers that the emitter sets
+func headerCarrier(header nats.Header) propagation.HeaderCarrier {
+	carrier := make(http.Header, len(header))
+	for key, values := range header {
+		for _, value := range values {
+			carrier.Add(key, value)
+		}
+	}
+	return propagation.HeaderCarrier(carrier)
+}
+
+// ack acknowledges a message received from the stream: messages received without
+// JetStream have no reply subject and are not acknowledged
+func ack(msg *nats.Msg) {
+	if msg.Reply == "" {
+		return
+	}
+	err := msg.Ack()
+	if err != nil {
+		slog.Warn("unable to acknowledge message", "subject", msg.Subject, "err", err)
+	}
+}
+
+// terminate stops a message that cannot be processed from being redelivered
+func terminate(msg *nats.Msg) {
+	if msg.Reply == "" {
+		return
+	}
+	err := msg.Term()
+	if err != nil {
+		slog.Warn("unable to terminate message", "subject", msg.Subject, "err", err)
+	}
+}
+
+type connection struct {
+	natsConn *nats.Conn
+	sub      *nats.Subscription
+}
+
+// Disconnect closes the connection to NATS. The subscription is not removed first as
+// that would delete a durable consumer, losing the messages that arrive before the
+// listener reconnects.
+func (c *connection) Disconnect(_ context.Context) error {
+	if c.natsConn != nil {
+		c.natsConn.Close()
+		c.natsConn = nil
+		c.sub = nil
+	}
+	return nil
+}
+
+var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+func randSeq(n int) string {
+	b := make([]rune, n)
+	for i := range b {
+		//#nosec G404 - client suffix does not require secure random number generator
+		b[i] = letters[rand.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	natstest "github.com/nats-io/nats-server/v2/test"
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/manager/testutil"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/nats"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// testServer starts an in-process NATS server, with JetStream enabled, for the test and
+// returns its URL together with the subject prefix and stream name used by the test.
+func testServer(t *testing.T) (url, prefix, stream string) {
+	opts := natstest.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natstest.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+
+	return srv.ClientURL(), "cs", "CSMS"
+}
+
+func newListener(url, prefix, stream string, opts ...nats.Opt[nats.Listener]) *nats.Listener {
+	return nats.NewListener(append([]nats.Opt[nats.Listener]{
+		nats.WithNatsUrl[nats.Listener](url),
+		nats.WithNatsPrefix[nats.Listener](prefix),
+		nats.WithNatsStream[nats.Listener](stream),
+	}, opts...)...)
+}
+
+// publish sends a message to the stream as the gateway does
+func publish(t *testing.T, ctx context.Context, url, subject string, payload any) {
+	conn, err := natsgo.Connect(url)
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	msg := natsgo.NewMsg(subject)
+	msg.Data = data
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(http.Header(msg.Header)))
+
+	_, err = js.PublishMsg(msg, natsgo.Context(ctx))
+	require.NoError(t, err)
+}
+
+func TestListenerProcessesMessagesPublishedByTheGateway(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	tracer, exporter := testutil.GetTracer()
+
+	receivedMsgCh := make(chan struct{})
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		assert.Equal(t, "cs.001", chargeStationId)
+		assert.Equal(t, transport.MessageTypeCall, msg.MessageType)
+		assert.Equal(t, "Test", msg.Action)
+		assert.Equal(t, "my-message-id", msg.MessageId)
+		assert.Equal(t, json.RawMessage(`{"someKey":"someValue"}`), msg.RequestPayload)
+		receivedMsgCh <- struct{}{}
+	}
+
+	listener := newListener(url, prefix, stream, nats.WithOtelTracer[nats.Listener](tracer))
+	conn, err := listener.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	newCtx, span := tracer.Start(ctx, "test span")
+	defer span.End()
+	publish(t, newCtx, url, prefix+".in.ocpp2%2E0%2E1.cs%2E001", transport.Message{
+		MessageType:    transport.MessageTypeCall,
+		Action:         "Test",
+		MessageId:      "my-message-id",
+		RequestPayload: json.RawMessage(`{"someKey":"someValue"}`),
+	})
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case <-receivedMsgCh:
+		// the span ends once the handler returns
+		require.Eventually(t, func() bool { return len(exporter.GetSpans()) > 0 }, time.Second, 10*time.Millisecond)
+		assert.True(t, exporter.GetSpans()[0].Parent.HasTraceID())
+		testutil.AssertSpan(t, &exporter.GetSpans()[0], prefix+".in.ocpp2%2E0%2E1.* receive", map[string]any{
+			"messaging.system":                     "nats",
+			"messaging.operation":                  "receive",
+			"messaging.message.payload_size_bytes": 81,
+			"ocpp.version":                         "2.0.1",
+			"csId":                                 "cs.001",
+			"call.action":                          "Test",
+			"messaging.consumer.id": func(val attribute.Value) bool {
+				return strings.HasPrefix(val.AsString(), "manager-")
+			},
+			"messaging.message.conversation_id": "my-message-id",
+		})
+	}
+}
+
+func TestListenerSharesMessagesBetweenTheGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	const count = 20
+	var mu sync.Mutex
+	received := make(map[string]int)
+	done := make(chan struct{})
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[msg.MessageId]++
+		if len(received) == count {
+			close(done)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		conn, err := newListener(url, prefix, stream).Connect(ctx, transport.OcppVersion16, nil, transport.MessageHandlerFunc(handler))
+		require.NoError(t, err)
+		defer func() {
+			err := conn.Disconnect(ctx)
+			require.NoError(t, err)
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		publish(t, ctx, url, fmt.Sprintf("%s.in.ocpp1%%2E6.cs%03d", prefix, i), transport.Message{
+			MessageType: transport.MessageTypeCall,
+			Action:      "Heartbeat",
+			MessageId:   fmt.Sprintf("msg-%d", i),
+		})
+	}
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case <-done:
+	}
+
+	// give any duplicate deliveries a chance to arrive
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	for id, n := range received {
+		assert.Equal(t, 1, n, "message %s", id)
+	}
+}
+
+func TestListenerReceivesMessagesPublishedWhilstDisconnected(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	receivedMsgCh := make(chan string, 1)
+	handler := func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		receivedMsgCh <- msg.MessageId
+	}
+
+	// the first connection creates the group's durable consumer
+	conn, err := newListener(url, prefix, stream).Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	err = conn.Disconnect(ctx)
+	require.NoError(t, err)
+
+	publish(t, ctx, url, prefix+".in.ocpp2%2E0%2E1.cs001", transport.Message{
+		MessageType: transport.MessageTypeCall,
+		Action:      "Heartbeat",
+		MessageId:   "while-disconnected",
+	})
+
+	conn, err = newListener(url, prefix, stream).Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(handler))
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case id := <-receivedMsgCh:
+		assert.Equal(t, "while-disconnected", id)
+	}
+}
+
+func TestListenerDeliversConnectionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	receivedCh := make(chan *transport.ConnectionEvent, 1)
+	handler := transport.ConnectionEventHandlerFunc(func(ctx context.Context, event *transport.ConnectionEvent) {
+		receivedCh <- event
+	})
+
+	conn, err := newListener(url, prefix, stream).ConnectEvents(ctx, handler)
+	require.NoError(t, err)
+	defer func() {
+		err := conn.Disconnect(ctx)
+		require.NoError(t, err)
+	}()
+
+	publish(t, ctx, url, prefix+".events.ocpp2%2E0%2E1.cs001", map[string]any{
+		"type":      "connected",
+		"clientId":  "not-the-subject",
+		"protocol":  "ocpp2.0.1",
+		"gatewayId": "gateway-1",
+	})
+
+	select {
+	case <-ctx.Done():
+		assert.Fail(t, "timeout waiting for test to complete")
+	case event := <-receivedCh:
+		assert.Equal(t, "cs001", event.ChargeStationId)
+		assert.Equal(t, transport.ConnectionEventConnected, event.Type)
+		assert.Equal(t, "ocpp2.0.1", event.Protocol)
+	}
+}
+
+func TestListenerReceivesResultsEmittedByAnyInstance(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	url, prefix, stream := testServer(t)
+
+	// both listeners are in the same group but should each receive the result
+	receivedCh := make(chan string, 2)
+	handler := transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, msg *transport.Message) {
+		assert.Equal(t, "cs.001", chargeStationId)
+		receivedCh <- msg.MessageId
+	})
+	for i := 0; i < 2; i++ {
+		conn, err := newListener(url, prefix, stream).ConnectResults(ctx, handler)
+		require.NoError(t, err)
+		defer func() {
+			_ = conn.Disconnect(ctx)
+		}()
+	}
+
+	emitter := nats.NewEmitter(
+		nats.WithNatsUrl[nats.Emitter](url),
+		nats.WithNatsPrefix[nats.Emitter](prefix),
+		nats.WithNatsStream[nats.Emitter](stream)).(transport.ResultEmitter)
+	err := emitter.EmitResult(ctx, transport.OcppVersion16, "cs.001", &transport.Message{
+		MessageType:     transport.MessageTypeCallResult,
+		Action:          "Reset",
+		MessageId:       "1234",
+		ResponsePayload: json.RawMessage(`{"status":"Accepted"}`),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.Done():
+			require.Fail(t, "timeout waiting for result")
+		case messageId := <-receivedCh:
+			assert.Equal(t, "1234", messageId)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type connectionDetails struct {
+	natsUrls           []string
+	natsPrefix         string
+	natsStream         string
+	natsConnectTimeout time.Duration
+	natsReconnectWait  time.Duration
+}
+
+type Opt[T any] func(h *T)
+
+func WithNatsUrl[T Emitter | Listener](natsUrl string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.natsUrls = append(x.natsUrls, natsUrl)
+		case *Listener:
+			x.natsUrls = append(x.natsUrls, natsUrl)
+		}
+	}
+}
+
+func WithNatsUrls[T Emitter | Listener](natsUrls []string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.natsUrls = natsUrls
+		case *Listener:
+			x.natsUrls = natsUrls
+		}
+	}
+}
+
+func WithNatsPrefix[T Emitter | Listener](natsPrefix string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.natsPrefix = natsPrefix
+		case *Listener:
+			x.natsPrefix = natsPrefix
+		}
+	}
+}
+
+// WithNatsStream sets the name of the JetStream stream that holds the messages
+// published by the gateway
+func WithNatsStream[T Emitter | Listener](natsStream string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.natsStream = natsStream
+		case *Listener:
+			x.natsStream = natsStream
+		}
+	}
+}
+
+func WithNatsConnectSettings[T Emitter | Listener](natsConnectTimeout, natsReconnectWait time.Duration) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.natsConnectTimeout = natsConnectTimeout
+			x.natsReconnectWait = natsReconnectWait
+		case *Listener:
+			x.natsConnectTimeout = natsConnectTimeout
+			x.natsReconnectWait = natsReconnectWait
+		}
+	}
+}
+
+func WithOtelTracer[T Emitter | Listener](tracer trace.Tracer) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Emitter:
+			x.tracer = tracer
+		case *Listener:
+			x.tracer = tracer
+		}
+	}
+}
+
+// WithNatsGroup sets the name of the queue group that the listener joins: the
+// messages are shared between the listeners in the same group, and the group's
+// durable consumers keep the messages that arrive whilst no listener is connected
+func WithNatsGroup[T Listener](natsGroup string) Opt[T] {
+	return func(h *T) {
+		switch x := any(h).(type) {
+		case *Listener:
+			x.natsGroup = natsGroup
+		}
+	}
+}
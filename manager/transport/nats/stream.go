@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package nats
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// subject builds a subject from the prefix and tokens. The characters that have a special
+// meaning in a NATS subject are percent encoded in the tokens, so the OCPP version
+// ocpp2.0.1 becomes the single token ocpp2%2E0%2E1.
+func subject(prefix string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, token := range tokens {
+		b.WriteByte('.')
+		for i := 0; i < len(token); i++ {
+			c := token[i]
+			if c == '.' || c == '*' || c == '>' || c == '%' || c <= ' ' || c == 0x7f {
+				_, _ = fmt.Fprintf(&b, "%%%02X", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	return b.String()
+}
+
+// lastToken returns the decoded final token of a subject built by subject
+func lastToken(subject string) string {
+	token := subject[strings.LastIndexByte(subject, '.')+1:]
+	decoded, err := url.PathUnescape(token)
+	if err != nil {
+		return token
+	}
+	return decoded
+}
+
+// subjectPattern replaces the final token of a subject with a wildcard
+func subjectPattern(subject string) string {
+	return subject[:strings.LastIndexByte(subject, '.')+1] + "*"
+}
+
+// ensureStream creates the stream that holds the messages the gateway publishes to the
+// CSMS if it does not already exist. An existing stream is left unchanged so that its
+// limits can be managed outside the CSMS.
+//
+// The stream uses interest retention: a message is kept until every durable consumer
+// (one per listener group) has acknowledged it.
+func ensureStream(js nats.JetStreamContext, name, prefix string) error {
+	_, err := js.StreamInfo(name)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		return fmt.Errorf("looking up stream %s: %w", name, err)
+	}
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:      name,
+		Subjects:  []string{prefix + ".in.>", prefix + ".events.>"},
+		Retention: nats.InterestPolicy,
+		Storage:   nats.FileStorage,
+	})
+	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return fmt.Errorf("creating stream %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transport
+
+import "context"
+
+// ResultEmitter is implemented by the emitters that can share the answers (CallResults and
+// CallErrors) that charge stations give to calls made by the CSMS between manager instances.
+// A charge station's answer is received by whichever instance the broker chooses, which need
+// not be the instance that made the call.
+type ResultEmitter interface {
+	// EmitResult sends the answer from the charge station identified by chargeStationId to
+	// every manager instance.
+	EmitResult(ctx context.Context, ocppVersion OcppVersion, chargeStationId string, message *Message) error
+}
+
+// ResultListener is implemented by the listeners that can receive the answers shared by a
+// ResultEmitter.
+type ResultListener interface {
+	// ConnectResults establishes a connection to the broker and subscribes to receive the
+	// answers shared by all manager instances, including this one. The answers are delivered
+	// to the provided MessageHandler.
+	//
+	// Returns either a Connection on success or an error.
+	ConnectResults(ctx context.Context, handler MessageHandler) (Connection, error)
+}
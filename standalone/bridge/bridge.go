@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/inprocess"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// Run passes messages between the gateway and the manager until ctx is done. The
+// topicPrefix must match the MQTT topic prefix that the gateway has been configured with.
+func Run(ctx context.Context, gateway *server.LocalTransport, manager *inprocess.Broker, topicPrefix string) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		toManager(ctx, gateway, manager, topicPrefix)
+	}()
+	toGateway(ctx, gateway, manager, topicPrefix)
+	<-done
+}
+
+func toManager(ctx context.Context, gateway *server.LocalTransport, manager *inprocess.Broker, topicPrefix string) {
+	for {
+		select {
+		case msg := <-gateway.Messages():
+			err := forward(ctx, manager, topicPrefix, msg)
+			if err != nil {
+				slog.Warn("passing message to manager", "topic", msg.Topic, "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func toGateway(ctx context.Context, gateway *server.LocalTransport, manager *inprocess.Broker, topicPrefix string) {
+	for {
+		select {
+		case out := <-manager.Outbound():
+			payload, err := json.Marshal(out.Message)
+			if err != nil {
+				slog.Warn("marshalling message for charge station", "chargeStationId", out.ChargeStationId, "err", err)
+				continue
+			}
+			gateway.Deliver(out.Context, fmt.Sprintf("%s/out/%s/%s", topicPrefix, out.OcppVersion, out.ChargeStationId), payload)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forward passes a message published by the gateway to the manager
+func forward(ctx context.Context, manager *inprocess.Broker, topicPrefix string, msg *server.LocalMessage) error {
+	kind, ocppVersion, chargeStationId, err := parseTopic(topicPrefix, msg.Topic)
+	if err != nil {
+		return err
+	}
+
+	// the message context is tied to the charge station's connection, so only the
+	// trace is passed on to the manager
+	msgCtx := context.Background()
+	if msg.Context != nil {
+		msgCtx = trace.ContextWithSpanContext(msgCtx, trace.SpanContextFromContext(msg.Context))
+	}
+	msgCtx, cancel := context.WithCancel(msgCtx)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	switch kind {
+	case "in":
+		var message transport.Message
+		err = json.Unmarshal(msg.Payload, &message)
+		if err != nil {
+			return fmt.Errorf("unmarshalling message: %w", err)
+		}
+		return manager.Publish(msgCtx, transport.OcppVersion(ocppVersion), chargeStationId, &message)
+	case "events":
+		var event transport.ConnectionEvent
+		err = json.Unmarshal(msg.Payload, &event)
+		if err != nil {
+			return fmt.Errorf("unmarshalling connection event: %w", err)
+		}
+		// the topic is authoritative for the charge station id
+		event.ChargeStationId = chargeStationId
+		return manager.PublishEvent(msgCtx, &event)
+	default:
+		return fmt.Errorf("unexpected topic %s", msg.Topic)
+	}
+}
+
+// parseTopic splits a topic of the form <prefix>/<kind>/<ocppVersion>/<chargeStationId>
+func parseTopic(topicPrefix, topic string) (kind, ocppVersion, chargeStationId string, err error) {
+	rest, ok := strings.CutPrefix(topic, topicPrefix+"/")
+	if !ok {
+		return "", "", "", fmt.Errorf("topic %s does not have prefix %s", topic, topicPrefix)
+	}
+	parts := strings.SplitN(rest, "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("unexpected topic %s", topic)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thoughtworks/maeve-csms/gateway/server"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+	"github.com/thoughtworks/maeve-csms/manager/store/inmemory"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/inprocess"
+	"github.com/thoughtworks/maeve-csms/standalone/bridge"
+	"k8s.io/utils/clock"
+	"nhooyr.io/websocket"
+)
+
+func TestBridgeExchangesMessagesBetweenGatewayAndManager(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationAuth(ctx, "cs001", &store.ChargeStationAuth{
+		SecurityProfile:      store.UnsecuredTransportWithBasicAuth,
+		Base64SHA256Password: "XohImNooBHFR0OVvjcYpJ3NgPQ1qq73WKhHvch0VQtg=", // password
+	})
+	require.NoError(t, err)
+
+	broker := inprocess.NewBroker()
+	local := server.NewLocalTransport(10)
+	go bridge.Run(ctx, local, broker, "cs")
+
+	events := make(chan *transport.ConnectionEvent, 1)
+	eventsConn, err := broker.ConnectEvents(ctx, transport.ConnectionEventHandlerFunc(func(_ context.Context, event *transport.ConnectionEvent) {
+		if event.Type == transport.ConnectionEventConnected {
+			events <- event
+		}
+	}))
+	require.NoError(t, err)
+	defer func() {
+		_ = eventsConn.Disconnect(ctx)
+	}()
+	conn, err := broker.Connect(ctx, transport.OcppVersion201, nil, transport.MessageHandlerFunc(func(ctx context.Context, chargeStationId string, message *transport.Message) {
+		_ = broker.Emit(ctx, transport.OcppVersion201, chargeStationId, &transport.Message{
+			MessageType:     transport.MessageTypeCallResult,
+			Action:          message.Action,
+			MessageId:       message.MessageId,
+			ResponsePayload: json.RawMessage(`{"currentTime":"2023-06-15T15:05:00Z"}`),
+		})
+	}))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Disconnect(ctx)
+	}()
+
+	srv := httptest.NewServer(server.NewWebsocketHandler(
+		server.WithLocalTransport(local),
+		server.WithMqttTopicPrefix("cs"),
+		server.WithDeviceRegistry(bridge.StoreRegistry{Engine: engine})))
+	defer srv.Close()
+
+	authHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte("cs001:password"))
+	wsConn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s/ws/cs001", srv.URL), &websocket.DialOptions{
+		Subprotocols: []string{"ocpp2.0.1"},
+		HTTPHeader: http.Header{
+			"authorization": []string{authHeader},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_ = wsConn.Close(websocket.StatusNormalClosure, "OK")
+	}()
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "cs001", event.ChargeStationId)
+		assert.Equal(t, "ocpp2.0.1", event.Protocol)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for connection event")
+	}
+
+	err = wsConn.Write(ctx, websocket.MessageText, []byte(`[2,"1234","Heartbeat",{}]`))
+	require.NoError(t, err)
+	_, resp, err := wsConn.Read(ctx)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[3,"1234",{"currentTime":"2023-06-15T15:05:00Z"}]`, string(resp))
+}
+
+func TestStoreRegistryLookupChargeStation(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	err := engine.SetChargeStationAuth(context.Background(), "cs001", &store.ChargeStationAuth{
+		SecurityProfile:        store.TLSWithBasicAuth,
+		Base64SHA256Password:   "DEADBEEF",
+		InvalidUsernameAllowed: true,
+	})
+	require.NoError(t, err)
+
+	reg := bridge.StoreRegistry{Engine: engine}
+
+	cs, err := reg.LookupChargeStation("cs001")
+	require.NoError(t, err)
+	require.NotNil(t, cs)
+	assert.Equal(t, "cs001", cs.ClientId)
+	assert.Equal(t, "DEADBEEF", cs.Base64SHA256Password)
+	assert.True(t, cs.InvalidUsernameAllowed)
+
+	cs, err = reg.LookupChargeStation("unknown")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+}
@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge connects a gateway and a manager that are running in the same process.
+// The messages that the gateway would publish to MQTT are passed to the manager's
+// in-process broker and the messages that the manager emits are delivered to the
+// gateway's connected charge stations. The charge station and certificate details
+// that the gateway needs are read directly from the manager's store.
+package bridge
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/thoughtworks/maeve-csms/gateway/registry"
+	"github.com/thoughtworks/maeve-csms/manager/store"
+)
+
+// StoreRegistry is a registry.DeviceRegistry that reads the charge station and
+// certificate details directly from the manager's store, rather than using the
+// manager's API
+type StoreRegistry struct {
+	Engine store.Engine
+}
+
+func (r StoreRegistry) LookupChargeStation(clientId string) (*registry.ChargeStation, error) {
+	auth, err := r.Engine.LookupChargeStationAuth(context.Background(), clientId)
+	if err != nil {
+		return nil, fmt.Errorf("looking up charge station %s: %w", clientId, err)
+	}
+	if auth == nil {
+		return nil, nil
+	}
+	return &registry.ChargeStation{
+		ClientId:               clientId,
+		SecurityProfile:        registry.SecurityProfile(auth.SecurityProfile),
+		Base64SHA256Password:   auth.Base64SHA256Password,
+		InvalidUsernameAllowed: auth.InvalidUsernameAllowed,
+	}, nil
+}
+
+func (r StoreRegistry) LookupCertificate(certHash string) (*x509.Certificate, error) {
+	// the store holds the hashes base64 URL encoded without padding
+	certHash = strings.ReplaceAll(certHash, "/", "_")
+	certHash = strings.ReplaceAll(certHash, "+", "-")
+	certHash = strings.TrimRight(certHash, "=")
+
+	pemCertificate, err := r.Engine.LookupCertificate(context.Background(), certHash)
+	if err != nil {
+		return nil, fmt.Errorf("looking up certificate %s: %w", certHash, err)
+	}
+	if pemCertificate == "" {
+		return nil, nil
+	}
+
+	block, _ := pem.Decode([]byte(pemCertificate))
+	if block == nil {
+		return nil, fmt.Errorf("no pem data found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in PEM data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	gateway "github.com/thoughtworks/maeve-csms/gateway/server"
+	"github.com/thoughtworks/maeve-csms/manager/api"
+	"github.com/thoughtworks/maeve-csms/manager/config"
+	"github.com/thoughtworks/maeve-csms/manager/server"
+	"github.com/thoughtworks/maeve-csms/manager/sync"
+	"github.com/thoughtworks/maeve-csms/manager/transport"
+	"github.com/thoughtworks/maeve-csms/manager/transport/inprocess"
+	"github.com/thoughtworks/maeve-csms/standalone/bridge"
+	"golang.org/x/exp/slog"
+	"k8s.io/utils/clock"
+)
+
+// topicPrefix is the prefix of the topics that the gateway uses to address messages:
+// the topics never leave the process, but the gateway and the bridge must agree on it
+const topicPrefix = "cs"
+
+var (
+	configFile    string
+	wsAddr        string
+	wssAddr       string
+	statusAddr    string
+	tlsServerCert string
+	tlsServerKey  string
+	tlsTrustCert  []string
+	orgNames      []string
+	bufLen        int
+	logFormat     string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the gateway and manager",
+	Long: `Starts the gateway, which accepts websocket connections from
+charge stations, and the manager, which responds to the charge stations'
+messages. The transport type in the config file is ignored: messages are
+always exchanged in-process.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if logFormat == "json" {
+			slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+		}
+
+		cfg := config.DefaultConfig
+		if configFile != "" {
+			err := cfg.LoadFromFile(configFile)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.Transport.Type = "in_process"
+
+		settings, err := config.Configure(context.Background(), &cfg)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := settings.TracerProvider.Shutdown(context.Background())
+			if err != nil {
+				slog.Warn("shutting down tracer provider", "error", err)
+			}
+		}()
+		broker, ok := settings.MsgListener.(*inprocess.Broker)
+		if !ok {
+			return fmt.Errorf("expected an in-process broker but got %T", settings.MsgListener)
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, os.Interrupt)
+		defer stop()
+
+		local := gateway.NewLocalTransport(bufLen)
+		bridgeDone := make(chan struct{})
+		go func() {
+			defer close(bridgeDone)
+			bridge.Run(ctx, local, broker, topicPrefix)
+		}()
+
+		apiServer := server.New("api", cfg.Api.Addr, nil,
+			server.NewApiHandler(settings.Api, settings.Storage, settings.OcpiApi, settings.ChargeStationCertProviderService,
+				api.WithCallCorrelation(settings.MsgEmitter, settings.Correlator),
+				api.WithRedrivers(settings.Redrivers)))
+
+		sync.Sync(settings.Storage, clock.RealClock{}, settings.Tracer, settings.MsgEmitter, settings.Retention)
+
+		// each of the api, ocpi, ws, status and wss servers reports at most one error
+		errCh := make(chan error, 5)
+		apiServer.Start(errCh)
+
+		var connections []transport.Connection
+		for _, listener := range []struct {
+			ocppVersion transport.OcppVersion
+			handler     transport.MessageHandler
+		}{
+			{transport.OcppVersion16, settings.Ocpp16Handler},
+			{transport.OcppVersion201, settings.Ocpp201Handler},
+			{transport.OcppVersion21, settings.Ocpp21Handler},
+		} {
+			if listener.handler == nil {
+				continue
+			}
+			conn, err := broker.Connect(context.Background(), listener.ocppVersion, nil, listener.handler)
+			if err != nil {
+				return err
+			}
+			connections = append(connections, conn)
+		}
+		if settings.ConnectionEventHandler != nil {
+			conn, err := broker.ConnectEvents(context.Background(), settings.ConnectionEventHandler)
+			if err != nil {
+				return err
+			}
+			connections = append(connections, conn)
+		}
+
+		if settings.OcpiApi != nil {
+			ocpiServer := server.New("ocpi", cfg.Ocpi.Addr, nil, server.NewOcpiHandler(settings.Storage, clock.RealClock{}, settings.OcpiApi, settings.MsgEmitter))
+			ocpiServer.Start(errCh)
+		}
+
+		connectionTracker := gateway.NewConnectionTracker()
+		drainer := gateway.NewDrainer(connectionTracker)
+		websocketHandler := gateway.NewWebsocketHandler(
+			gateway.WithLocalTransport(local),
+			gateway.WithMqttTopicPrefix(topicPrefix),
+			gateway.WithConnectionTracker(connectionTracker),
+			gateway.WithDeviceRegistry(bridge.StoreRegistry{Engine: settings.Storage}),
+			gateway.WithOrgNames(orgNames),
+			gateway.WithDrainer(drainer),
+			gateway.WithOtelTracer(settings.TracerProvider.Tracer("gateway")),
+		)
+		servers := []*gateway.Server{
+			gateway.New("ws", wsAddr, nil, websocketHandler),
+			gateway.New("status", statusAddr, nil, gateway.NewStatusHandler(gateway.WithDrainControl(drainer))),
+		}
+		if wssAddr != "" {
+			tlsConfig, err := serverTLSConfig()
+			if err != nil {
+				return err
+			}
+			servers = append(servers, gateway.New("wss", wssAddr, tlsConfig, websocketHandler))
+		}
+		for _, srv := range servers {
+			srv.Start(errCh)
+		}
+
+		select {
+		case err = <-errCh:
+		case <-ctx.Done():
+		}
+
+		slog.Info("shutting down")
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer stopCancel()
+		for _, srv := range servers {
+			err := srv.Stop(stopCtx)
+			if err != nil {
+				slog.Warn("stopping server", "err", err)
+			}
+		}
+		websocketHandler.Close()
+		for _, conn := range connections {
+			err := conn.Disconnect(context.Background())
+			if err != nil {
+				slog.Warn("disconnecting from broker", "err", err)
+			}
+		}
+		stop()
+		<-bridgeDone
+
+		return err
+	},
+}
+
+func serverTLSConfig() (*tls.Config, error) {
+	if tlsServerCert == "" {
+		return nil, fmt.Errorf("no tls server cert specified for wss connection")
+	}
+	if tlsServerKey == "" {
+		return nil, fmt.Errorf("no tls server key specified for wss connection")
+	}
+
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	cb, err := os.ReadFile(tlsServerCert)
+	if err != nil {
+		return nil, fmt.Errorf("reading tls cert from %s: %v", tlsServerCert, err)
+	}
+	//#nosec G304 - only files specified by the person running the application will be loaded
+	kb, err := os.ReadFile(tlsServerKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading tls key from %s: %v", tlsServerKey, err)
+	}
+	tlsCert, err := tls.X509KeyPair(cb, kb)
+	if err != nil {
+		return nil, fmt.Errorf("processing tls key pair: %v", err)
+	}
+	trustedCerts := x509.NewCertPool()
+	for _, tc := range tlsTrustCert {
+		//#nosec G304 - only files specified by the person running the application will be loaded
+		tcb, err := os.ReadFile(tc)
+		if err != nil {
+			return nil, fmt.Errorf("reading trusted certs from %s: %v", tc, err)
+		}
+		if ok := trustedCerts.AppendCertsFromPEM(tcb); !ok {
+			return nil, fmt.Errorf("processing trusted certs from %s: no certificate found", tc)
+		}
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		ClientCAs:    trustedCerts,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVarP(&configFile, "config-file", "c", "",
+		"The manager config file to use (defaults are used if not set)")
+	serveCmd.Flags().StringVarP(&wsAddr, "ws-addr", "a", "127.0.0.1:9310",
+		"The address that the insecure websocket server will listen on for connections, e.g. 127.0.0.1:9310")
+	serveCmd.Flags().StringVarP(&wssAddr, "wss-addr", "w", "",
+		"The address that the secure websocket server will listen on for connections, e.g. 127.0.0.1:9311")
+	serveCmd.Flags().StringVarP(&statusAddr, "status-addr", "s", "127.0.0.1:9312",
+		"The address that the status server will listen on for connections, e.g. 127.0.0.1:9312")
+	serveCmd.Flags().StringVar(&tlsServerCert, "tls-server-cert", "",
+		"A file that contains a PEM encoded certificate to use as the TLS server cert")
+	serveCmd.Flags().StringVar(&tlsServerKey, "tls-server-key", "",
+		"A file that contains a PEM encoded private key to use as the TLS server key")
+	serveCmd.Flags().StringArrayVarP(&tlsTrustCert, "tls-trust-cert", "t", []string{},
+		"A file that contains a PEM encoded certificate to add to the TLS trust store")
+	serveCmd.Flags().StringSliceVarP(&orgNames, "org-name", "o", []string{"Thoughtworks"},
+		"A comma-separated list of organisation names that are valid in client certificates")
+	serveCmd.Flags().IntVar(&bufLen, "buffer-len", 100,
+		"The number of messages from the charge stations held whilst the manager is busy")
+	serveCmd.Flags().StringVar(&logFormat, "log-format", "text",
+		"The format of the logs, one of [text, json]")
+}
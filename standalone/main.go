@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/thoughtworks/maeve-csms/standalone/cmd"
+)
+
+func main() {
+	cmd.Execute()
+}